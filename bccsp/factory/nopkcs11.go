@@ -51,6 +51,16 @@ func initFactories(config *FactoryOpts) error {
 		}
 	}
 
+	// Remote signer based BCCSP
+	if config.Default == "REMOTE" && config.Remote != nil {
+		f := &RemoteFactory{}
+		var err error
+		defaultBCCSP, err = initBCCSP(f, config)
+		if err != nil {
+			return errors.Wrapf(err, "Failed initializing REMOTE.BCCSP")
+		}
+	}
+
 	if defaultBCCSP == nil {
 		return errors.Errorf("Could not find default `%s` BCCSP", config.Default)
 	}
@@ -64,6 +74,8 @@ func GetBCCSPFromOpts(config *FactoryOpts) (bccsp.BCCSP, error) {
 	switch config.Default {
 	case "SW":
 		f = &SWFactory{}
+	case "REMOTE":
+		f = &RemoteFactory{}
 	default:
 		return nil, errors.Errorf("Could not find BCCSP, no '%s' provider", config.Default)
 	}
@@ -11,6 +11,7 @@ package factory
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/bccsp/remote"
 	"github.com/stretchr/testify/require"
 )
 
@@ -25,4 +26,10 @@ func TestInitFactories(t *testing.T) {
 		Default: "PKCS11",
 	})
 	require.EqualError(t, err, "Could not find default `PKCS11` BCCSP")
+
+	err = initFactories(&FactoryOpts{
+		Default: "REMOTE",
+		Remote:  &remote.RemoteOpts{Security: 256, Hash: "SHA2"},
+	})
+	require.EqualError(t, err, "Failed initializing REMOTE.BCCSP: Could not initialize BCCSP REMOTE [remote signer address not provided]")
 }
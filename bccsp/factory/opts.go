@@ -6,13 +6,17 @@ SPDX-License-Identifier: Apache-2.0
 
 package factory
 
-import "github.com/hyperledger/fabric/bccsp/pkcs11"
+import (
+	"github.com/hyperledger/fabric/bccsp/pkcs11"
+	"github.com/hyperledger/fabric/bccsp/remote"
+)
 
 // FactoryOpts holds configuration information used to initialize factory implementations
 type FactoryOpts struct {
 	Default string             `json:"default" yaml:"Default"`
 	SW      *SwOpts            `json:"SW,omitempty" yaml:"SW,omitempty"`
 	PKCS11  *pkcs11.PKCS11Opts `json:"PKCS11,omitempty" yaml:"PKCS11"`
+	Remote  *remote.RemoteOpts `json:"REMOTE,omitempty" yaml:"REMOTE,omitempty"`
 }
 
 // GetDefaultOpts offers a default implementation for Opts
@@ -28,6 +32,10 @@ func GetDefaultOpts() *FactoryOpts {
 			Hash:     "SHA2",
 			Security: 256,
 		},
+		Remote: &remote.RemoteOpts{
+			Hash:     "SHA2",
+			Security: 256,
+		},
 	}
 }
 
@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/remote"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/pkg/errors"
+)
+
+const (
+	// RemoteBasedFactoryName is the name of the factory of the BCCSP implementation
+	// that delegates signing to a remote signer
+	RemoteBasedFactoryName = "REMOTE"
+)
+
+// RemoteFactory is the factory of the remote signer based BCCSP.
+type RemoteFactory struct{}
+
+// Name returns the name of this factory
+func (f *RemoteFactory) Name() string {
+	return RemoteBasedFactoryName
+}
+
+// Get returns an instance of BCCSP using Opts.
+func (f *RemoteFactory) Get(config *FactoryOpts) (bccsp.BCCSP, error) {
+	// Validate arguments
+	if config == nil || config.Remote == nil {
+		return nil, errors.New("Invalid config. It must not be nil.")
+	}
+
+	remoteOpts := config.Remote
+	ks := sw.NewDummyKeyStore()
+	return remote.New(*remoteOpts, ks)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package factory
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/remote"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteFactoryName(t *testing.T) {
+	f := &RemoteFactory{}
+	require.Equal(t, f.Name(), RemoteBasedFactoryName)
+}
+
+func TestRemoteFactoryGetInvalidArgs(t *testing.T) {
+	f := &RemoteFactory{}
+
+	_, err := f.Get(nil)
+	require.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	_, err = f.Get(&FactoryOpts{})
+	require.EqualError(t, err, "Invalid config. It must not be nil.")
+
+	opts := &FactoryOpts{
+		Remote: &remote.RemoteOpts{
+			Security: 256,
+			Hash:     "SHA2",
+		},
+	}
+	_, err = f.Get(opts)
+	require.EqualError(t, err, "remote signer address not provided")
+}
+
+func TestRemoteFactoryGet(t *testing.T) {
+	f := &RemoteFactory{}
+
+	opts := &FactoryOpts{
+		Remote: &remote.RemoteOpts{
+			Security: 256,
+			Hash:     "SHA2",
+			Address:  "unix:///var/run/signer.sock",
+		},
+	}
+	csp, err := f.Get(opts)
+	require.NoError(t, err)
+	require.NotNil(t, csp)
+}
@@ -0,0 +1,43 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import "time"
+
+const defaultTimeout = 5 * time.Second
+
+// RemoteOpts contains options for the RemoteFactory
+type RemoteOpts struct {
+	// Default algorithms when not specified (Deprecated?)
+	Security int    `json:"security"`
+	Hash     string `json:"hash"`
+
+	// Address of the remote signer, either host:port or unix:///path/to/socket
+	Address string `json:"address"`
+	// Timeout bounds every call to the remote signer, defaults to 5s
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// TLS configures the connection to the remote signer
+	TLS *TLSOpts `json:"tls,omitempty"`
+	// KeyHandles maps hex encoded subject key identifiers to the handles of
+	// the keys in the remote signer. Keys without an entry are addressed by
+	// their hex encoded SKI.
+	KeyHandles map[string]string `json:"keyhandles,omitempty"`
+}
+
+// TLSOpts contains the TLS options of the connection to the remote signer
+type TLSOpts struct {
+	Enabled bool `json:"enabled"`
+	// RootCertFile is the PEM encoded CA certificate of the remote signer
+	RootCertFile string `json:"rootcertfile"`
+	// ClientCertFile and ClientKeyFile are the PEM encoded certificate and
+	// key used for mutual TLS, if the remote signer requires it
+	ClientCertFile string `json:"clientcertfile,omitempty"`
+	ClientKeyFile  string `json:"clientkeyfile,omitempty"`
+	// ServerNameOverride overrides the host name used to verify the
+	// certificate of the remote signer
+	ServerNameOverride string `json:"servernameoverride,omitempty"`
+}
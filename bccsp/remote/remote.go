@@ -0,0 +1,274 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"io/ioutil"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+var logger = flogging.MustGetLogger("bccsp_remote")
+
+const unixScheme = "unix://"
+
+type Provider struct {
+	bccsp.BCCSP
+
+	conn    *grpc.ClientConn
+	client  SignerClient
+	timeout time.Duration
+	handles map[string]string
+
+	cacheLock sync.RWMutex
+	keyCache  map[string]bccsp.Key
+}
+
+// Ensure we satisfy the BCCSP interfaces.
+var _ bccsp.BCCSP = (*Provider)(nil)
+
+// New returns a new instance of a BCCSP that sends signing requests for the
+// keys held by a remote signer to that signer, over the Signer gRPC service.
+// The public keys of the remote keys are cached once retrieved.
+//
+// All other cryptographic functions, as well as keys not held by the remote
+// signer, are delegated to a software based BCCSP implementation that is
+// configured to use the security level and hashing family from opts and the
+// key store that is provided.
+func New(opts RemoteOpts, keyStore bccsp.KeyStore) (*Provider, error) {
+	if opts.Address == "" {
+		return nil, errors.New("remote signer address not provided")
+	}
+	// The signer signs for any client that reaches it, so over TCP the client
+	// certificate is what authenticates the node to the signer.
+	if !strings.HasPrefix(opts.Address, unixScheme) && (opts.TLS == nil || !opts.TLS.Enabled || opts.TLS.ClientCertFile == "" || opts.TLS.ClientKeyFile == "") {
+		return nil, errors.Errorf("mutual TLS is required to connect to the remote signer at %s: enable TLS and set a client certificate and key, or use a unix:// socket", opts.Address)
+	}
+
+	swCSP, err := sw.NewWithParams(opts.Security, opts.Hash, keyStore)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed initializing fallback SW BCCSP")
+	}
+
+	dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+	if opts.TLS != nil && opts.TLS.Enabled {
+		tlsConfig, err := clientTLSConfig(*opts.TLS)
+		if err != nil {
+			return nil, err
+		}
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	}
+
+	target := opts.Address
+	if strings.HasPrefix(target, unixScheme) {
+		path := strings.TrimPrefix(target, unixScheme)
+		dialOpts = append(dialOpts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", path)
+		}))
+		target = "passthrough:///" + path
+	}
+
+	// The connection is established lazily, so that a peer or an orderer
+	// starts even if the remote signer is not reachable yet.
+	conn, err := grpc.Dial(target, dialOpts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed connecting to remote signer at %s", opts.Address)
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+
+	handles := map[string]string{}
+	for ski, handle := range opts.KeyHandles {
+		handles[strings.ToLower(ski)] = handle
+	}
+
+	return &Provider{
+		BCCSP:    swCSP,
+		conn:     conn,
+		client:   NewSignerClient(conn),
+		timeout:  timeout,
+		handles:  handles,
+		keyCache: map[string]bccsp.Key{},
+	}, nil
+}
+
+func clientTLSConfig(opts TLSOpts) (*tls.Config, error) {
+	rootCert, err := ioutil.ReadFile(opts.RootCertFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading remote signer root certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(rootCert) {
+		return nil, errors.Errorf("no certificates found in %s", opts.RootCertFile)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    rootCAs,
+		ServerName: opts.ServerNameOverride,
+	}
+	if opts.ClientCertFile != "" || opts.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(opts.ClientCertFile, opts.ClientKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed loading remote signer client key pair")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// Close closes the connection to the remote signer.
+func (csp *Provider) Close() error {
+	return csp.conn.Close()
+}
+
+func (csp *Provider) handle(ski []byte) string {
+	encodedSKI := hex.EncodeToString(ski)
+	if handle, ok := csp.handles[encodedSKI]; ok {
+		return handle
+	}
+	return encodedSKI
+}
+
+func (csp *Provider) cacheKey(ski []byte, key bccsp.Key) {
+	csp.cacheLock.Lock()
+	csp.keyCache[hex.EncodeToString(ski)] = key
+	csp.cacheLock.Unlock()
+}
+
+func (csp *Provider) cachedKey(ski []byte) (bccsp.Key, bool) {
+	csp.cacheLock.RLock()
+	defer csp.cacheLock.RUnlock()
+	key, ok := csp.keyCache[hex.EncodeToString(ski)]
+	return key, ok
+}
+
+// GetKey returns the key this CSP associates to
+// the Subject Key Identifier ski.
+func (csp *Provider) GetKey(ski []byte) (bccsp.Key, error) {
+	if key, ok := csp.cachedKey(ski); ok {
+		return key, nil
+	}
+
+	handle := csp.handle(ski)
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+	resp, err := csp.client.GetPublicKey(ctx, &GetPublicKeyRequest{KeyHandle: handle})
+	if status.Code(err) == codes.NotFound {
+		logger.Debugf("Key [%s] not found in remote signer", handle)
+		return csp.BCCSP.GetKey(ski)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed retrieving key [%s] from remote signer", handle)
+	}
+
+	goPub, err := x509.ParsePKIXPublicKey(resp.PublicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed parsing public key for key [%s] returned by remote signer", handle)
+	}
+	pub, err := csp.importPublicKey(goPub)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid public key for key [%s] returned by remote signer", handle)
+	}
+	if !bytes.Equal(pub.SKI(), ski) {
+		return nil, errors.Errorf("public key for key [%s] returned by remote signer does not match SKI [%x]", handle, ski)
+	}
+
+	key := &privateKey{handle: handle, ski: ski, pub: pub, goPub: goPub}
+	csp.cacheKey(ski, key)
+	return key, nil
+}
+
+func (csp *Provider) importPublicKey(pub crypto.PublicKey) (bccsp.Key, error) {
+	switch pub := pub.(type) {
+	case *ecdsa.PublicKey:
+		return csp.BCCSP.KeyImport(pub, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true})
+	case ed25519.PublicKey:
+		return csp.BCCSP.KeyImport(pub, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	default:
+		return nil, errors.Errorf("unsupported public key type %T", pub)
+	}
+}
+
+// Sign signs digest using key k.
+// The opts argument should be appropriate for the primitive used.
+//
+// Note that when a signature of a hash of a larger message is needed,
+// the caller is responsible for hashing the larger message and passing
+// the hash (as digest).
+func (csp *Provider) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) ([]byte, error) {
+	// Validate arguments
+	if k == nil {
+		return nil, errors.New("Invalid Key. It must not be nil")
+	}
+	if len(digest) == 0 {
+		return nil, errors.New("Invalid digest. Cannot be empty")
+	}
+
+	// Check key type
+	switch key := k.(type) {
+	case *privateKey:
+		return csp.signRemote(key, digest)
+	default:
+		return csp.BCCSP.Sign(key, digest, opts)
+	}
+}
+
+func (csp *Provider) signRemote(k *privateKey, digest []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), csp.timeout)
+	defer cancel()
+	resp, err := csp.client.Sign(ctx, &SignRequest{KeyHandle: k.handle, Digest: digest})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed signing with key [%s] in remote signer", k.handle)
+	}
+
+	// Fabric normalizes ECDSA signatures to low-S, which remote key
+	// management services don't necessarily do
+	if ecdsaPub, ok := k.goPub.(*ecdsa.PublicKey); ok {
+		return utils.SignatureToLowS(ecdsaPub, resp.Signature)
+	}
+
+	return resp.Signature, nil
+}
+
+// Verify verifies signature against key k and digest
+func (csp *Provider) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (bool, error) {
+	// Validate arguments
+	if k == nil {
+		return false, errors.New("Invalid Key. It must not be nil")
+	}
+
+	// Remote keys are verified in software using their public part
+	if key, ok := k.(*privateKey); ok {
+		return csp.BCCSP.Verify(key.pub, signature, digest, opts)
+	}
+	return csp.BCCSP.Verify(k, signature, digest, opts)
+}
@@ -0,0 +1,483 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/common/crypto/tlsgen"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// countingSigner counts the calls to a Signer service, optionally resolves
+// key handles through aliases and can return high-S ECDSA signatures.
+type countingSigner struct {
+	SignerServer
+	aliases       map[string]string
+	highS         bool
+	getPublicKeys int32
+	signs         int32
+}
+
+func (s *countingSigner) resolve(handle string) string {
+	if ski, ok := s.aliases[handle]; ok {
+		return ski
+	}
+	return handle
+}
+
+func (s *countingSigner) GetPublicKey(ctx context.Context, req *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	atomic.AddInt32(&s.getPublicKeys, 1)
+	return s.SignerServer.GetPublicKey(ctx, &GetPublicKeyRequest{KeyHandle: s.resolve(req.KeyHandle)})
+}
+
+func (s *countingSigner) Sign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
+	atomic.AddInt32(&s.signs, 1)
+	resp, err := s.SignerServer.Sign(ctx, &SignRequest{KeyHandle: s.resolve(req.KeyHandle), Digest: req.Digest})
+	if err != nil || !s.highS {
+		return resp, err
+	}
+
+	r, sv, err := utils.UnmarshalECDSASignature(resp.Signature)
+	if err != nil {
+		return nil, err
+	}
+	// s and N-s are both valid, return the larger one
+	highS := new(big.Int).Sub(elliptic.P256().Params().N, sv)
+	if highS.Cmp(sv) < 0 {
+		highS = sv
+	}
+	sig, err := utils.MarshalECDSASignature(r, highS)
+	if err != nil {
+		return nil, err
+	}
+	return &SignResponse{Signature: sig}, nil
+}
+
+type testEnv struct {
+	signer    *countingSigner
+	serverCSP bccsp.BCCSP
+	address   string
+	stop      func()
+}
+
+// newTestEnv starts a signer listening on a unix socket, or on a local TCP
+// port when network is "tcp".
+func newTestEnv(t *testing.T, network string, serverOpts ...grpc.ServerOption) *testEnv {
+	ksDir, err := ioutil.TempDir("", "remote-signer")
+	require.NoError(t, err)
+	serverCSP, err := sw.NewDefaultSecurityLevel(ksDir)
+	require.NoError(t, err)
+
+	signer := &countingSigner{SignerServer: &LocalSigner{CSP: serverCSP}}
+	var lis net.Listener
+	var address string
+	if network == "tcp" {
+		lis, err = net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address = lis.Addr().String()
+	} else {
+		socket := filepath.Join(ksDir, "signer.sock")
+		lis, err = net.Listen("unix", socket)
+		require.NoError(t, err)
+		address = unixScheme + socket
+	}
+	server := grpc.NewServer(serverOpts...)
+	RegisterSignerServer(server, signer)
+	go server.Serve(lis)
+
+	return &testEnv{
+		signer:    signer,
+		serverCSP: serverCSP,
+		address:   address,
+		stop: func() {
+			server.Stop()
+			os.RemoveAll(ksDir)
+		},
+	}
+}
+
+func (e *testEnv) opts() RemoteOpts {
+	return RemoteOpts{
+		Security: 256,
+		Hash:     "SHA2",
+		Address:  e.address,
+	}
+}
+
+func newProvider(t *testing.T, opts RemoteOpts) *Provider {
+	csp, err := New(opts, sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	return csp
+}
+
+func TestSignAndVerify(t *testing.T) {
+	env := newTestEnv(t, "unix")
+	defer env.stop()
+
+	for _, keyGenOpts := range []bccsp.KeyGenOpts{
+		&bccsp.ECDSAP256KeyGenOpts{},
+		&bccsp.ED25519KeyGenOpts{},
+	} {
+		t.Run(keyGenOpts.Algorithm(), func(t *testing.T) {
+			serverKey, err := env.serverCSP.KeyGen(keyGenOpts)
+			require.NoError(t, err)
+
+			csp := newProvider(t, env.opts())
+			defer csp.Close()
+
+			key, err := csp.GetKey(serverKey.SKI())
+			require.NoError(t, err)
+			require.True(t, key.Private())
+			require.False(t, key.Symmetric())
+			require.Equal(t, serverKey.SKI(), key.SKI())
+			_, err = key.Bytes()
+			require.EqualError(t, err, "Not supported.")
+
+			pub, err := key.PublicKey()
+			require.NoError(t, err)
+			serverPub, err := serverKey.PublicKey()
+			require.NoError(t, err)
+			require.Equal(t, serverPub.SKI(), pub.SKI())
+
+			digest := sha256.Sum256([]byte("hello world"))
+			sig, err := csp.Sign(key, digest[:], nil)
+			require.NoError(t, err)
+
+			valid, err := csp.Verify(key, sig, digest[:], nil)
+			require.NoError(t, err)
+			require.True(t, valid)
+			valid, err = csp.Verify(pub, sig, digest[:], nil)
+			require.NoError(t, err)
+			require.True(t, valid)
+			valid, err = env.serverCSP.Verify(serverPub, sig, digest[:], nil)
+			require.NoError(t, err)
+			require.True(t, valid)
+		})
+	}
+}
+
+func TestPublicKeysAreCached(t *testing.T) {
+	env := newTestEnv(t, "unix")
+	defer env.stop()
+
+	serverKey, err := env.serverCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.NoError(t, err)
+
+	csp := newProvider(t, env.opts())
+	defer csp.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err := csp.GetKey(serverKey.SKI())
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 1, atomic.LoadInt32(&env.signer.getPublicKeys))
+}
+
+func TestKeyHandles(t *testing.T) {
+	env := newTestEnv(t, "unix")
+	defer env.stop()
+
+	key1, err := env.serverCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.NoError(t, err)
+	key2, err := env.serverCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.NoError(t, err)
+	env.signer.aliases = map[string]string{
+		"projects/test/keys/peer0": hex.EncodeToString(key1.SKI()),
+		"projects/test/keys/peer1": hex.EncodeToString(key2.SKI()),
+	}
+
+	opts := env.opts()
+	opts.KeyHandles = map[string]string{
+		hex.EncodeToString(key1.SKI()): "projects/test/keys/peer0",
+		hex.EncodeToString(key2.SKI()): "projects/test/keys/peer0",
+	}
+	csp := newProvider(t, opts)
+	defer csp.Close()
+
+	key, err := csp.GetKey(key1.SKI())
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("hello world"))
+	sig, err := csp.Sign(key, digest[:], nil)
+	require.NoError(t, err)
+	valid, err := env.serverCSP.Verify(key1, sig, digest[:], nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	// the handle of key2 points to key1
+	_, err = csp.GetKey(key2.SKI())
+	require.EqualError(t, err, "public key for key [projects/test/keys/peer0] returned by remote signer does not match SKI ["+hex.EncodeToString(key2.SKI())+"]")
+}
+
+func TestSignaturesAreLowS(t *testing.T) {
+	env := newTestEnv(t, "unix")
+	defer env.stop()
+	env.signer.highS = true
+
+	serverKey, err := env.serverCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.NoError(t, err)
+
+	csp := newProvider(t, env.opts())
+	defer csp.Close()
+
+	key, err := csp.GetKey(serverKey.SKI())
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("hello world"))
+	sig, err := csp.Sign(key, digest[:], nil)
+	require.NoError(t, err)
+
+	pub, err := key.PublicKey()
+	require.NoError(t, err)
+	raw, err := pub.Bytes()
+	require.NoError(t, err)
+	goPub, err := x509.ParsePKIXPublicKey(raw)
+	require.NoError(t, err)
+	_, s, err := utils.UnmarshalECDSASignature(sig)
+	require.NoError(t, err)
+	lowS, err := utils.IsLowS(goPub.(*ecdsa.PublicKey), s)
+	require.NoError(t, err)
+	require.True(t, lowS)
+
+	valid, err := csp.Verify(key, sig, digest[:], nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestLocalKeys(t *testing.T) {
+	env := newTestEnv(t, "unix")
+	defer env.stop()
+
+	csp := newProvider(t, env.opts())
+	defer csp.Close()
+
+	// keys unknown to the remote signer are handled in software
+	key, err := csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("hello world"))
+	sig, err := csp.Sign(key, digest[:], nil)
+	require.NoError(t, err)
+	valid, err := csp.Verify(key, sig, digest[:], nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+	require.EqualValues(t, 0, atomic.LoadInt32(&env.signer.signs))
+
+	_, err = csp.GetKey([]byte{1, 2, 3})
+	require.Error(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt32(&env.signer.getPublicKeys))
+}
+
+func TestSignerUnavailable(t *testing.T) {
+	env := newTestEnv(t, "unix")
+	serverKey, err := env.serverCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.NoError(t, err)
+
+	csp := newProvider(t, env.opts())
+	defer csp.Close()
+	key, err := csp.GetKey(serverKey.SKI())
+	require.NoError(t, err)
+
+	env.stop()
+	csp.timeout = 100 * time.Millisecond
+
+	_, err = csp.GetKey([]byte{1, 2, 3})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed retrieving key [010203] from remote signer")
+
+	digest := sha256.Sum256([]byte("hello world"))
+	_, err = csp.Sign(key, digest[:], nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed signing with key ["+hex.EncodeToString(serverKey.SKI())+"] in remote signer")
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	serverPair, err := ca.NewServerCertKeyPair("127.0.0.1")
+	require.NoError(t, err)
+	clientPair, err := ca.NewClientCertKeyPair()
+	require.NoError(t, err)
+
+	serverCert, err := tls.X509KeyPair(serverPair.Cert, serverPair.Key)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(ca.CertBytes())
+	env := newTestEnv(t, "tcp", grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	defer env.stop()
+
+	serverKey, err := env.serverCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "remote-tls")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for name, content := range map[string][]byte{
+		"ca.pem":     ca.CertBytes(),
+		"client.pem": clientPair.Cert,
+		"client.key": clientPair.Key,
+	} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), content, 0600))
+	}
+
+	opts := env.opts()
+	opts.TLS = &TLSOpts{
+		Enabled:        true,
+		RootCertFile:   filepath.Join(dir, "ca.pem"),
+		ClientCertFile: filepath.Join(dir, "client.pem"),
+		ClientKeyFile:  filepath.Join(dir, "client.key"),
+	}
+	csp := newProvider(t, opts)
+	defer csp.Close()
+	_, err = csp.GetKey(serverKey.SKI())
+	require.NoError(t, err)
+
+	// a client key pair the signer does not trust fails the handshake
+	otherCA, err := tlsgen.NewCA()
+	require.NoError(t, err)
+	otherPair, err := otherCA.NewClientCertKeyPair()
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "other.pem"), otherPair.Cert, 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "other.key"), otherPair.Key, 0600))
+	opts.TLS.ClientCertFile = filepath.Join(dir, "other.pem")
+	opts.TLS.ClientKeyFile = filepath.Join(dir, "other.key")
+	opts.Timeout = 500 * time.Millisecond
+	csp = newProvider(t, opts)
+	defer csp.Close()
+	_, err = csp.GetKey(serverKey.SKI())
+	require.Error(t, err)
+
+	opts.TLS.RootCertFile = filepath.Join(dir, "missing.pem")
+	_, err = New(opts, sw.NewDummyKeyStore())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed reading remote signer root certificate")
+}
+
+func TestUnixSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remote-unix")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	serverCSP, err := sw.NewDefaultSecurityLevel(dir)
+	require.NoError(t, err)
+	serverKey, err := serverCSP.KeyGen(&bccsp.ED25519KeyGenOpts{})
+	require.NoError(t, err)
+
+	socket := filepath.Join(dir, "signer.sock")
+	lis, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	RegisterSignerServer(server, &LocalSigner{CSP: serverCSP})
+	go server.Serve(lis)
+	defer server.Stop()
+
+	csp := newProvider(t, RemoteOpts{Security: 256, Hash: "SHA2", Address: "unix://" + socket})
+	defer csp.Close()
+	key, err := csp.GetKey(serverKey.SKI())
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("hello world"))
+	_, err = csp.Sign(key, digest[:], nil)
+	require.NoError(t, err)
+}
+
+func TestNewErrors(t *testing.T) {
+	_, err := New(RemoteOpts{Security: 256, Hash: "SHA2"}, sw.NewDummyKeyStore())
+	require.EqualError(t, err, "remote signer address not provided")
+
+	_, err = New(RemoteOpts{Security: 1, Hash: "SHA2", Address: "unix:///tmp/signer.sock"}, sw.NewDummyKeyStore())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Failed initializing fallback SW BCCSP")
+}
+
+func TestNewRequiresMutualTLSOverTCP(t *testing.T) {
+	errMsg := "mutual TLS is required to connect to the remote signer at localhost:7060: enable TLS and set a client certificate and key, or use a unix:// socket"
+
+	_, err := New(RemoteOpts{Security: 256, Hash: "SHA2", Address: "localhost:7060"}, sw.NewDummyKeyStore())
+	require.EqualError(t, err, errMsg)
+
+	_, err = New(RemoteOpts{Security: 256, Hash: "SHA2", Address: "localhost:7060", TLS: &TLSOpts{RootCertFile: "ca.pem", ClientCertFile: "client.pem", ClientKeyFile: "client.key"}}, sw.NewDummyKeyStore())
+	require.EqualError(t, err, errMsg)
+
+	_, err = New(RemoteOpts{Security: 256, Hash: "SHA2", Address: "localhost:7060", TLS: &TLSOpts{Enabled: true, RootCertFile: "ca.pem"}}, sw.NewDummyKeyStore())
+	require.EqualError(t, err, errMsg)
+
+	_, err = New(RemoteOpts{Security: 256, Hash: "SHA2", Address: "localhost:7060", TLS: &TLSOpts{Enabled: true, RootCertFile: "ca.pem", ClientCertFile: "client.pem"}}, sw.NewDummyKeyStore())
+	require.EqualError(t, err, errMsg)
+}
+
+func TestInvalidArgs(t *testing.T) {
+	csp := newProvider(t, RemoteOpts{Security: 256, Hash: "SHA2", Address: "unix:///tmp/signer.sock"})
+	defer csp.Close()
+
+	_, err := csp.Sign(nil, []byte{1}, nil)
+	require.EqualError(t, err, "Invalid Key. It must not be nil")
+	_, err = csp.Sign(&privateKey{}, nil, nil)
+	require.EqualError(t, err, "Invalid digest. Cannot be empty")
+	_, err = csp.Verify(nil, []byte{1}, []byte{1}, nil)
+	require.EqualError(t, err, "Invalid Key. It must not be nil")
+}
+
+func TestLocalSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remote-signer")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	serverCSP, err := sw.NewDefaultSecurityLevel(dir)
+	require.NoError(t, err)
+	signer := &LocalSigner{CSP: serverCSP}
+
+	_, err = signer.GetPublicKey(context.Background(), &GetPublicKeyRequest{KeyHandle: "not hex"})
+	require.EqualError(t, err, "rpc error: code = InvalidArgument desc = key handle [not hex] is not a hex encoded SKI")
+	_, err = signer.GetPublicKey(context.Background(), &GetPublicKeyRequest{KeyHandle: "0102"})
+	require.EqualError(t, err, "rpc error: code = NotFound desc = private key [0102] not found")
+	_, err = signer.Sign(context.Background(), &SignRequest{KeyHandle: "0102"})
+	require.EqualError(t, err, "rpc error: code = InvalidArgument desc = digest must not be empty")
+
+	key, err := serverCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{})
+	require.NoError(t, err)
+	pub, err := key.PublicKey()
+	require.NoError(t, err)
+	raw, err := pub.Bytes()
+	require.NoError(t, err)
+
+	resp, err := signer.GetPublicKey(context.Background(), &GetPublicKeyRequest{KeyHandle: hex.EncodeToString(key.SKI())})
+	require.NoError(t, err)
+	require.Equal(t, raw, resp.PublicKey)
+
+	// public keys without their private part are not served
+	otherKey, err := serverCSP.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
+	require.NoError(t, err)
+	otherPub, err := otherKey.PublicKey()
+	require.NoError(t, err)
+	raw, err = otherPub.Bytes()
+	require.NoError(t, err)
+	_, err = serverCSP.KeyImport(raw, &bccsp.ECDSAPKIXPublicKeyImportOpts{})
+	require.NoError(t, err)
+
+	_, err = signer.Sign(context.Background(), &SignRequest{KeyHandle: hex.EncodeToString(otherKey.SKI()), Digest: []byte{1}})
+	require.EqualError(t, err, "rpc error: code = NotFound desc = private key ["+hex.EncodeToString(otherKey.SKI())+"] not found")
+}
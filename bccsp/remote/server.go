@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package remote
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// LocalSigner is a reference implementation of the Signer service that
+// signs with the private keys of a local BCCSP, such as a software BCCSP
+// backed by a file keystore. Keys are addressed by their hex encoded SKI.
+type LocalSigner struct {
+	CSP bccsp.BCCSP
+}
+
+// Ensure we satisfy the Signer service.
+var _ SignerServer = (*LocalSigner)(nil)
+
+// GetPublicKey returns the public key of the private key with the given handle.
+func (s *LocalSigner) GetPublicKey(ctx context.Context, req *GetPublicKeyRequest) (*GetPublicKeyResponse, error) {
+	key, err := s.privateKey(req.KeyHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := key.PublicKey()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed getting public key of key [%s]: %s", req.KeyHandle, err)
+	}
+	raw, err := pub.Bytes()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed marshaling public key of key [%s]: %s", req.KeyHandle, err)
+	}
+
+	return &GetPublicKeyResponse{PublicKey: raw}, nil
+}
+
+// Sign signs the given digest with the private key with the given handle.
+func (s *LocalSigner) Sign(ctx context.Context, req *SignRequest) (*SignResponse, error) {
+	if len(req.Digest) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "digest must not be empty")
+	}
+
+	key, err := s.privateKey(req.KeyHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := s.CSP.Sign(key, req.Digest, nil)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed signing with key [%s]: %s", req.KeyHandle, err)
+	}
+	logger.Debugf("Signed digest with key [%s]", req.KeyHandle)
+
+	return &SignResponse{Signature: signature}, nil
+}
+
+func (s *LocalSigner) privateKey(handle string) (bccsp.Key, error) {
+	ski, err := hex.DecodeString(handle)
+	if err != nil || len(ski) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "key handle [%s] is not a hex encoded SKI", handle)
+	}
+
+	key, err := s.CSP.GetKey(ski)
+	if err != nil || !key.Private() {
+		return nil, status.Errorf(codes.NotFound, "private key [%s] not found", handle)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyED25519(t *testing.T) {
+	t.Parallel()
+
+	pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	msg := []byte("hello world")
+	sigma, err := signED25519(privKey, msg, nil)
+	require.NoError(t, err)
+
+	valid, err := verifyED25519(pubKey, sigma, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = verifyED25519(pubKey, sigma, []byte("hello world!"), nil)
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	_, err = verifyED25519(pubKey, nil, msg, nil)
+	require.EqualError(t, err, "Invalid signature length [0]. Must be 64 bytes")
+}
+
+func TestEd25519SignerSign(t *testing.T) {
+	t.Parallel()
+
+	signer := &ed25519Signer{}
+	verifierPrivateKey := &ed25519PrivateKeyVerifier{}
+	verifierPublicKey := &ed25519PublicKeyKeyVerifier{}
+
+	// Generate a key
+	_, lowLevelKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	k := &ed25519PrivateKey{lowLevelKey}
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+
+	// Sign
+	msg := []byte("Hello World")
+	sigma, err := signer.Sign(k, msg, nil)
+	require.NoError(t, err)
+	require.NotNil(t, sigma)
+
+	// Verify
+	valid, err := verifierPrivateKey.Verify(k, sigma, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = verifierPublicKey.Verify(pk, sigma, msg, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestEd25519PrivateKey(t *testing.T) {
+	t.Parallel()
+
+	pubKey, lowLevelKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	k := &ed25519PrivateKey{lowLevelKey}
+
+	require.False(t, k.Symmetric())
+	require.True(t, k.Private())
+
+	_, err = k.Bytes()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Not supported.")
+
+	k.privKey = nil
+	ski := k.SKI()
+	require.Nil(t, ski)
+
+	k.privKey = lowLevelKey
+	ski = k.SKI()
+	hash := sha256.Sum256(pubKey)
+	require.Equal(t, hash[:], ski, "SKI is not computed in the right way.")
+
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+	require.NotNil(t, pk)
+	ed25519PK, ok := pk.(*ed25519PublicKey)
+	require.True(t, ok)
+	require.Equal(t, pubKey, ed25519PK.pubKey)
+	require.Equal(t, ski, pk.SKI())
+}
+
+func TestEd25519PublicKey(t *testing.T) {
+	t.Parallel()
+
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	k := &ed25519PublicKey{pubKey}
+
+	require.False(t, k.Symmetric())
+	require.False(t, k.Private())
+
+	k.pubKey = nil
+	ski := k.SKI()
+	require.Nil(t, ski)
+
+	k.pubKey = pubKey
+	ski = k.SKI()
+	hash := sha256.Sum256(pubKey)
+	require.Equal(t, hash[:], ski, "SKI is not computed in the right way.")
+
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+	require.Equal(t, k, pk)
+
+	bytes, err := k.Bytes()
+	require.NoError(t, err)
+	bytes2, err := x509.MarshalPKIXPublicKey(k.pubKey)
+	require.NoError(t, err)
+	require.Equal(t, bytes2, bytes, "bytes are not computed in the right way.")
+}
+
+func TestED25519KeyLifecycle(t *testing.T) {
+	t.Parallel()
+
+	tempDir, err := ioutil.TempDir("", "ed25519")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	csp, err := NewDefaultSecurityLevel(tempDir)
+	require.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.ED25519KeyGenOpts{Temporary: false})
+	require.NoError(t, err)
+	require.True(t, k.Private())
+
+	// The key can be retrieved from the key store
+	k2, err := csp.GetKey(k.SKI())
+	require.NoError(t, err)
+	require.Equal(t, k, k2)
+
+	digest, err := csp.Hash([]byte("Hello World"), &bccsp.SHAOpts{})
+	require.NoError(t, err)
+	signature, err := csp.Sign(k2, digest, nil)
+	require.NoError(t, err)
+
+	pk, err := k.PublicKey()
+	require.NoError(t, err)
+	pkRaw, err := pk.Bytes()
+	require.NoError(t, err)
+	pk2, err := csp.KeyImport(pkRaw, &bccsp.ED25519PKIXPublicKeyImportOpts{Temporary: true})
+	require.NoError(t, err)
+	require.Equal(t, pk.SKI(), pk2.SKI())
+
+	valid, err := csp.Verify(pk2, signature, digest, nil)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	// An imported private key matches the generated one
+	privRaw, err := x509.MarshalPKCS8PrivateKey(k.(*ed25519PrivateKey).privKey)
+	require.NoError(t, err)
+	k3, err := csp.KeyImport(privRaw, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+	require.NoError(t, err)
+	require.Equal(t, k.SKI(), k3.SKI())
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package sw
 
 import (
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"errors"
 	"reflect"
@@ -61,6 +62,19 @@ func TestECDSAKeyGenerator(t *testing.T) {
 	require.Equal(t, ecdsaK.privKey.Curve, elliptic.P256())
 }
 
+func TestED25519KeyGenerator(t *testing.T) {
+	t.Parallel()
+
+	kg := &ed25519KeyGenerator{}
+
+	k, err := kg.KeyGen(nil)
+	require.NoError(t, err)
+
+	ed25519K, ok := k.(*ed25519PrivateKey)
+	require.True(t, ok)
+	require.Len(t, ed25519K.privKey, ed25519.PrivateKeySize)
+}
+
 func TestAESKeyGenerator(t *testing.T) {
 	t.Parallel()
 
@@ -8,6 +8,7 @@ package sw
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -111,6 +112,67 @@ func (*ecdsaGoPublicKeyImportOptsKeyImporter) KeyImport(raw interface{}, opts bc
 	return &ecdsaPublicKey{lowLevelKey}, nil
 }
 
+type ed25519PKIXPublicKeyImportOptsKeyImporter struct{}
+
+func (*ed25519PKIXPublicKeyImportOptsKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (bccsp.Key, error) {
+	der, ok := raw.([]byte)
+	if !ok {
+		return nil, errors.New("Invalid raw material. Expected byte array.")
+	}
+
+	if len(der) == 0 {
+		return nil, errors.New("Invalid raw. It must not be nil.")
+	}
+
+	lowLevelKey, err := derToPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("Failed converting PKIX to ED25519 public key [%s]", err)
+	}
+
+	ed25519PK, ok := lowLevelKey.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("Failed casting to ED25519 public key. Invalid raw material.")
+	}
+
+	return &ed25519PublicKey{ed25519PK}, nil
+}
+
+type ed25519PrivateKeyImportOptsKeyImporter struct{}
+
+func (*ed25519PrivateKeyImportOptsKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (bccsp.Key, error) {
+	der, ok := raw.([]byte)
+	if !ok {
+		return nil, errors.New("[ED25519PrivateKeyImportOpts] Invalid raw material. Expected byte array.")
+	}
+
+	if len(der) == 0 {
+		return nil, errors.New("[ED25519PrivateKeyImportOpts] Invalid raw. It must not be nil.")
+	}
+
+	lowLevelKey, err := derToPrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("Failed converting PKCS#8 to ED25519 private key [%s]", err)
+	}
+
+	ed25519SK, ok := lowLevelKey.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("Failed casting to ED25519 private key. Invalid raw material.")
+	}
+
+	return &ed25519PrivateKey{ed25519SK}, nil
+}
+
+type ed25519GoPublicKeyImportOptsKeyImporter struct{}
+
+func (*ed25519GoPublicKeyImportOptsKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (bccsp.Key, error) {
+	lowLevelKey, ok := raw.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("Invalid raw material. Expected ed25519.PublicKey.")
+	}
+
+	return &ed25519PublicKey{lowLevelKey}, nil
+}
+
 type x509PublicKeyImportOptsKeyImporter struct {
 	bccsp *CSP
 }
@@ -128,7 +190,11 @@ func (ki *x509PublicKeyImportOptsKeyImporter) KeyImport(raw interface{}, opts bc
 		return ki.bccsp.KeyImporters[reflect.TypeOf(&bccsp.ECDSAGoPublicKeyImportOpts{})].KeyImport(
 			pk,
 			&bccsp.ECDSAGoPublicKeyImportOpts{Temporary: opts.Ephemeral()})
+	case ed25519.PublicKey:
+		return ki.bccsp.KeyImporters[reflect.TypeOf(&bccsp.ED25519GoPublicKeyImportOpts{})].KeyImport(
+			pk,
+			&bccsp.ED25519GoPublicKeyImportOpts{Temporary: opts.Ephemeral()})
 	default:
-		return nil, errors.New("Certificate's public key type not recognized. Supported keys: [ECDSA]")
+		return nil, errors.New("Certificate's public key type not recognized. Supported keys: [ECDSA, ED25519]")
 	}
 }
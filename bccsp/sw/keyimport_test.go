@@ -17,6 +17,9 @@ limitations under the License.
 package sw
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -175,6 +178,74 @@ func TestECDSAGoPublicKeyImportOptsKeyImporter(t *testing.T) {
 	require.Contains(t, err.Error(), "Invalid raw material. Expected *ecdsa.PublicKey.")
 }
 
+func TestED25519PKIXPublicKeyImportOptsKeyImporter(t *testing.T) {
+	t.Parallel()
+
+	ki := ed25519PKIXPublicKeyImportOptsKeyImporter{}
+
+	_, err := ki.KeyImport("Hello World", &mocks2.KeyImportOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid raw material. Expected byte array.")
+
+	_, err = ki.KeyImport([]byte(nil), &mocks2.KeyImportOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid raw. It must not be nil.")
+
+	_, err = ki.KeyImport([]byte{0}, &mocks2.KeyImportOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Failed converting PKIX to ED25519 public key [")
+
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	raw, err := x509.MarshalPKIXPublicKey(&k.PublicKey)
+	require.NoError(t, err)
+	_, err = ki.KeyImport(raw, &mocks2.KeyImportOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Failed casting to ED25519 public key. Invalid raw material.")
+}
+
+func TestED25519PrivateKeyImportOptsKeyImporter(t *testing.T) {
+	t.Parallel()
+
+	ki := ed25519PrivateKeyImportOptsKeyImporter{}
+
+	_, err := ki.KeyImport("Hello World", &mocks2.KeyImportOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid raw material. Expected byte array.")
+
+	_, err = ki.KeyImport([]byte(nil), &mocks2.KeyImportOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid raw. It must not be nil.")
+
+	_, err = ki.KeyImport([]byte{0}, &mocks2.KeyImportOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Failed converting PKCS#8 to ED25519 private key")
+
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	raw, err := x509.MarshalPKCS8PrivateKey(k)
+	require.NoError(t, err)
+	_, err = ki.KeyImport(raw, &mocks2.KeyImportOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Failed casting to ED25519 private key. Invalid raw material.")
+}
+
+func TestED25519GoPublicKeyImportOptsKeyImporter(t *testing.T) {
+	t.Parallel()
+
+	ki := ed25519GoPublicKeyImportOptsKeyImporter{}
+
+	_, err := ki.KeyImport("Hello World", &mocks2.KeyImportOpts{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid raw material. Expected ed25519.PublicKey.")
+
+	pubKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	k, err := ki.KeyImport(pubKey, &mocks2.KeyImportOpts{})
+	require.NoError(t, err)
+	require.Equal(t, &ed25519PublicKey{pubKey}, k)
+}
+
 func TestX509PublicKeyImportOptsKeyImporter(t *testing.T) {
 	t.Parallel()
 
@@ -192,5 +263,5 @@ func TestX509PublicKeyImportOptsKeyImporter(t *testing.T) {
 	cert.PublicKey = "Hello world"
 	_, err = ki.KeyImport(cert, &mocks2.KeyImportOpts{})
 	require.Error(t, err)
-	require.Contains(t, err.Error(), "Certificate's public key type not recognized. Supported keys: [ECDSA]")
+	require.Contains(t, err.Error(), "Certificate's public key type not recognized. Supported keys: [ECDSA, ED25519]")
 }
@@ -9,6 +9,7 @@ package signer
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/asn1"
@@ -37,7 +38,7 @@ type Config struct {
 // initialize an MSP without a CA cert that signs the signing identity,
 // this will do for now.
 type Signer struct {
-	key     *ecdsa.PrivateKey
+	key     crypto.PrivateKey
 	Creator []byte
 }
 
@@ -93,11 +94,16 @@ func validateEnrollmentCertificate(b []byte) error {
 }
 
 func (si *Signer) Sign(msg []byte) ([]byte, error) {
-	digest := util.ComputeSHA256(msg)
-	return signECDSA(si.key, digest)
+	switch key := si.key.(type) {
+	case ed25519.PrivateKey:
+		// Ed25519 signs the message itself, it hashes it internally
+		return ed25519.Sign(key, msg), nil
+	default:
+		return signECDSA(key.(*ecdsa.PrivateKey), util.ComputeSHA256(msg))
+	}
 }
 
-func loadPrivateKey(file string) (*ecdsa.PrivateKey, error) {
+func loadPrivateKey(file string) (crypto.PrivateKey, error) {
 	b, err := ioutil.ReadFile(file)
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -106,11 +112,7 @@ func loadPrivateKey(file string) (*ecdsa.PrivateKey, error) {
 	if bl == nil {
 		return nil, errors.Errorf("failed to decode PEM block from %s", file)
 	}
-	key, err := parsePrivateKey(bl.Bytes)
-	if err != nil {
-		return nil, err
-	}
-	return key.(*ecdsa.PrivateKey), nil
+	return parsePrivateKey(bl.Bytes)
 }
 
 // Based on crypto/tls/tls.go but modified for Fabric:
@@ -118,8 +120,8 @@ func parsePrivateKey(der []byte) (crypto.PrivateKey, error) {
 	// OpenSSL 1.0.0 generates PKCS#8 keys.
 	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
 		switch key := key.(type) {
-		// Fabric only supports ECDSA at the moment.
-		case *ecdsa.PrivateKey:
+		// Fabric only supports ECDSA and Ed25519 at the moment.
+		case *ecdsa.PrivateKey, ed25519.PrivateKey:
 			return key, nil
 		default:
 			return nil, errors.Errorf("found unknown private key type (%T) in PKCS#8 wrapping", key)
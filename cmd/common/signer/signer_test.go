@@ -8,6 +8,7 @@ package signer
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/x509"
 	"encoding/pem"
 	"io/ioutil"
@@ -36,7 +37,25 @@ func TestSigner(t *testing.T) {
 
 	r, s, err := utils.UnmarshalECDSASignature(sig)
 	require.NoError(t, err)
-	ecdsa.Verify(&signer.key.PublicKey, util.ComputeSHA256(msg), r, s)
+	ecdsa.Verify(&signer.key.(*ecdsa.PrivateKey).PublicKey, util.ComputeSHA256(msg), r, s)
+}
+
+func TestSignerED25519(t *testing.T) {
+	conf := Config{
+		MSPID:        "SampleOrg",
+		IdentityPath: filepath.Join("testdata", "signer", "ed25519_cert.pem"),
+		KeyPath:      filepath.Join("testdata", "signer", "ed25519_sk"),
+	}
+
+	signer, err := NewSigner(conf)
+	require.NoError(t, err)
+
+	msg := []byte("foo")
+	sig, err := signer.Sign(msg)
+	require.NoError(t, err)
+
+	key := signer.key.(ed25519.PrivateKey)
+	require.True(t, ed25519.Verify(key.Public().(ed25519.PublicKey), msg, sig))
 }
 
 func TestSignerDifferentFormats(t *testing.T) {
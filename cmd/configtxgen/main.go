@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -23,6 +25,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
 	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/internal/configtxgen/lint"
 	"github.com/hyperledger/fabric/internal/configtxgen/metadata"
 	"github.com/hyperledger/fabric/internal/configtxlator/update"
 	"github.com/hyperledger/fabric/protoutil"
@@ -31,6 +34,11 @@ import (
 
 var logger = flogging.MustGetLogger("common.tools.configtxgen")
 
+var (
+	doLintProfile bool
+	lintOutput    string
+)
+
 func doOutputBlock(config *genesisconfig.Profile, channelID string, outputBlock string) error {
 	pgen, err := encoder.NewBootstrapper(config)
 	if err != nil {
@@ -188,6 +196,49 @@ func doPrintOrg(t *genesisconfig.TopLevel, printOrg string) error {
 	return errors.Errorf("organization %s not found", printOrg)
 }
 
+// lintReport is the JSON output of the linter
+type lintReport struct {
+	Findings []lint.Finding `json:"findings"`
+	Errors   int            `json:"errors"`
+	Warnings int            `json:"warnings"`
+}
+
+func doLint(w io.Writer, conf *genesisconfig.Profile, channelID, output string) error {
+	if output != "text" && output != "json" {
+		return errors.Errorf("unknown lint output format %s, must be text or json", output)
+	}
+	if channelID == "" {
+		channelID = "lint"
+	}
+	report := lintReport{
+		Findings: lint.Lint(conf, channelID, factory.GetDefault()),
+	}
+	for _, f := range report.Findings {
+		if f.Severity == lint.Error {
+			report.Errors++
+		}
+	}
+	report.Warnings = len(report.Findings) - report.Errors
+
+	if output == "json" {
+		if report.Findings == nil {
+			report.Findings = []lint.Finding{}
+		}
+		if err := json.NewEncoder(w).Encode(report); err != nil {
+			return errors.Wrap(err, "failed writing lint report")
+		}
+	} else {
+		for _, f := range report.Findings {
+			fmt.Fprintln(w, f)
+		}
+		fmt.Fprintf(w, "%d error(s), %d warning(s)\n", report.Errors, report.Warnings)
+	}
+	if report.Errors != 0 {
+		return errors.Errorf("profile has %d error(s)", report.Errors)
+	}
+	return nil
+}
+
 func writeFile(filename string, data []byte, perm os.FileMode) error {
 	dirPath := filepath.Dir(filename)
 	exists, err := dirExists(dirPath)
@@ -228,6 +279,8 @@ func main() {
 	flag.StringVar(&outputAnchorPeersUpdate, "outputAnchorPeersUpdate", "", "[DEPRECATED] Creates a config update to update an anchor peer (works only with the default channel creation, and only for the first update)")
 	flag.StringVar(&asOrg, "asOrg", "", "Performs the config generation as a particular organization (by name), only including values in the write set that org (likely) has privilege to set")
 	flag.StringVar(&printOrg, "printOrg", "", "Prints the definition of an organization as JSON. (useful for adding an org to a channel manually)")
+	flag.BoolVar(&doLintProfile, "lint", false, "Checks the profile for errors and likely mistakes, printing the errors and warnings found")
+	flag.StringVar(&lintOutput, "lintOutput", "text", "The format the '-lint' findings are printed in, either 'text' or 'json'")
 
 	version := flag.Bool("version", false, "Show version information")
 
@@ -268,9 +321,9 @@ func main() {
 		logger.Fatalf("Error on initFactories: %s", err)
 	}
 	var profileConfig *genesisconfig.Profile
-	if outputBlock != "" || outputChannelCreateTx != "" || outputAnchorPeersUpdate != "" {
+	if outputBlock != "" || outputChannelCreateTx != "" || outputAnchorPeersUpdate != "" || doLintProfile {
 		if profile == "" {
-			logger.Fatalf("The '-profile' is required when '-outputBlock', '-outputChannelCreateTx', or '-outputAnchorPeersUpdate' is specified")
+			logger.Fatalf("The '-profile' is required when '-outputBlock', '-outputChannelCreateTx', '-outputAnchorPeersUpdate', or '-lint' is specified")
 		}
 
 		if configPath != "" {
@@ -292,6 +345,12 @@ func main() {
 		}
 	}
 
+	if doLintProfile {
+		if err := doLint(os.Stdout, profileConfig, channelID, lintOutput); err != nil {
+			logger.Fatalf("Error on lint: %s", err)
+		}
+	}
+
 	if outputBlock != "" {
 		if err := doOutputBlock(profileConfig, channelID, outputBlock); err != nil {
 			logger.Fatalf("Error on outputBlock: %s", err)
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -17,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/internal/configtxgen/lint"
 	"github.com/stretchr/testify/require"
 )
 
@@ -217,3 +220,43 @@ func TestPrintOrg(t *testing.T) {
 	require.Error(t, err, "Fake org")
 	require.Regexp(t, "bad org definition", err.Error())
 }
+
+func TestLint(t *testing.T) {
+	factory.InitFactories(nil)
+	config := genesisconfig.Load(genesisconfig.SampleAppChannelInsecureSoloProfile, configtest.GetDevConfigDir())
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, doLint(buf, config, "foo", "text"), "Good profile to lint")
+	require.Regexp(t, "\n0 error\\(s\\), [0-9]+ warning\\(s\\)\n$", buf.String())
+
+	buf.Reset()
+	require.NoError(t, doLint(buf, config, "foo", "json"), "Good profile to lint")
+	report := &lintReport{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), report))
+	require.Equal(t, 0, report.Errors)
+	require.Equal(t, len(report.Findings), report.Warnings)
+	warnings := report.Warnings
+
+	config.Application.ACLs["custom/Resource"] = "/Channel/Application/Nonexistent"
+	buf.Reset()
+	require.EqualError(t, doLint(buf, config, "", "text"), "profile has 1 error(s)")
+	require.Contains(t, buf.String(), "error: /Channel/Application/Values/ACLs")
+
+	buf.Reset()
+	require.EqualError(t, doLint(buf, config, "", "json"), "profile has 1 error(s)")
+	report = &lintReport{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), report))
+	require.Equal(t, 1, report.Errors)
+	require.Equal(t, warnings, report.Warnings)
+	require.Len(t, report.Findings, warnings+1)
+	var errs []lint.Finding
+	for _, f := range report.Findings {
+		if f.Severity == lint.Error {
+			errs = append(errs, f)
+		}
+	}
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Message, "/Channel/Application/Nonexistent")
+
+	require.EqualError(t, doLint(buf, config, "", "yaml"), "unknown lint output format yaml, must be text or json")
+}
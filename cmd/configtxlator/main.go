@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
@@ -20,11 +21,15 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common" // Import these to register the proto types
 	_ "github.com/hyperledger/fabric-protos-go/msp"
 	_ "github.com/hyperledger/fabric-protos-go/orderer"
-	_ "github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
-	_ "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/internal/configtxlator/diff"
+	"github.com/hyperledger/fabric/internal/configtxlator/edit"
 	"github.com/hyperledger/fabric/internal/configtxlator/metadata"
 	"github.com/hyperledger/fabric/internal/configtxlator/rest"
+	"github.com/hyperledger/fabric/internal/configtxlator/sigcheck"
 	"github.com/hyperledger/fabric/internal/configtxlator/update"
 
 	"github.com/gorilla/handlers"
@@ -57,6 +62,63 @@ var (
 	computeUpdateChannelID = computeUpdate.Flag("channel_id", "The name of the channel for this update.").Required().String()
 	computeUpdateDest      = computeUpdate.Flag("output", "A file to write the JSON document to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 
+	editConfig      = app.Command("edit", "Applies a semantic edit to the config in a config block and outputs the resulting unsigned config update envelope.")
+	editConfigBlock = editConfig.Flag("config_block", "The config block of the channel to edit.").Required().File()
+	editDest        = editConfig.Flag("output", "A file to write the config update envelope to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	editAddOrg    = editConfig.Command("add_org", "Adds an organization to the application group.")
+	editAddOrgOrg = editAddOrg.Flag("org", "A file containing the organization definition printed by configtxgen -printOrg.").Required().File()
+
+	editRemoveOrg      = editConfig.Command("remove_org", "Removes an organization from the application group.")
+	editRemoveOrgMSPID = editRemoveOrg.Flag("msp_id", "The MSP ID of the organization to remove.").Required().String()
+
+	editSetAnchorPeers      = editConfig.Command("set_anchor_peers", "Replaces the anchor peers of an application organization.")
+	editSetAnchorPeersMSPID = editSetAnchorPeers.Flag("msp_id", "The MSP ID of the organization.").Required().String()
+	editSetAnchorPeersPeers = editSetAnchorPeers.Flag("anchor_peer", "An anchor peer as host:port (may be repeated, omit to remove all anchor peers).").Strings()
+
+	editAddConsenter              = editConfig.Command("add_consenter", "Adds an etcdraft consenter.")
+	editAddConsenterHost          = editAddConsenter.Flag("host", "The host of the consenter.").Required().String()
+	editAddConsenterPort          = editAddConsenter.Flag("port", "The port of the consenter.").Required().Uint32()
+	editAddConsenterClientTLSCert = editAddConsenter.Flag("client_tls_cert", "A file containing the PEM encoded client TLS certificate of the consenter.").Required().File()
+	editAddConsenterServerTLSCert = editAddConsenter.Flag("server_tls_cert", "A file containing the PEM encoded server TLS certificate of the consenter.").Required().File()
+
+	editRemoveConsenter     = editConfig.Command("remove_consenter", "Removes an etcdraft consenter.")
+	editRemoveConsenterHost = editRemoveConsenter.Flag("host", "The host of the consenter.").Required().String()
+	editRemoveConsenterPort = editRemoveConsenter.Flag("port", "The port of the consenter.").Required().Uint32()
+
+	editReplaceConsenter              = editConfig.Command("replace_consenter", "Replaces an etcdraft consenter.")
+	editReplaceConsenterHost          = editReplaceConsenter.Flag("host", "The host of the consenter to replace.").Required().String()
+	editReplaceConsenterPort          = editReplaceConsenter.Flag("port", "The port of the consenter to replace.").Required().Uint32()
+	editReplaceConsenterNewHost       = editReplaceConsenter.Flag("new_host", "The host of the new consenter, defaults to the replaced host.").String()
+	editReplaceConsenterNewPort       = editReplaceConsenter.Flag("new_port", "The port of the new consenter, defaults to the replaced port.").Uint32()
+	editReplaceConsenterClientTLSCert = editReplaceConsenter.Flag("client_tls_cert", "A file containing the PEM encoded client TLS certificate of the new consenter.").Required().File()
+	editReplaceConsenterServerTLSCert = editReplaceConsenter.Flag("server_tls_cert", "A file containing the PEM encoded server TLS certificate of the new consenter.").Required().File()
+
+	editSetBatchParams                  = editConfig.Command("set_batch_params", "Updates the batch size and batch timeout of the ordering service.")
+	editSetBatchParamsMaxMessageCount   = editSetBatchParams.Flag("max_message_count", "The maximum number of messages in a batch.").Uint32()
+	editSetBatchParamsAbsoluteMaxBytes  = editSetBatchParams.Flag("absolute_max_bytes", "The absolute maximum number of bytes in a batch.").Uint32()
+	editSetBatchParamsPreferredMaxBytes = editSetBatchParams.Flag("preferred_max_bytes", "The preferred maximum number of bytes in a batch.").Uint32()
+	editSetBatchParamsTimeout           = editSetBatchParams.Flag("timeout", "The amount of time to wait before creating a batch, e.g. '2s'.").Duration()
+
+	editSetCapabilities      = editConfig.Command("set_capabilities", "Replaces the capabilities of the channel, orderer or application level.")
+	editSetCapabilitiesLevel = editSetCapabilities.Flag("level", "The level to set the capabilities of: channel, orderer or application.").Required().Enum(edit.ChannelLevel, edit.OrdererLevel, edit.ApplicationLevel)
+	editSetCapabilitiesCaps  = editSetCapabilities.Flag("capability", "A capability to enable, e.g. 'V2_0' (may be repeated).").Required().Strings()
+
+	editSetACLs     = editConfig.Command("set_acls", "Updates the application ACLs, leaving resources which are not specified unchanged.")
+	editSetACLsACLs = editSetACLs.Flag("acl", "A mapping of a resource to a policy as resource=policy, e.g. 'peer/Propose=/Channel/Application/Writers' (may be repeated).").Required().Strings()
+
+	checkSignatures            = app.Command("check_signatures", "Reports whether the signatures on a config update satisfy the mod_policy of every config element it modifies.")
+	checkSignaturesConfigBlock = checkSignatures.Flag("config_block", "The current config block of the channel.").Required().File()
+	checkSignaturesUpdate      = checkSignatures.Flag("update", "The marshaled config update envelope, possibly partially signed.").Required().File()
+	checkSignaturesDest        = checkSignatures.Flag("output", "A file to write the JSON report to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	diffConfigs         = app.Command("diff", "Reports the semantic changes between two marshaled common.Config messages, or the changes a config update makes to a common.Config message.")
+	diffConfigsOriginal = diffConfigs.Flag("original", "The original config message.").Required().File()
+	diffConfigsUpdated  = diffConfigs.Flag("updated", "The updated config message.").File()
+	diffConfigsUpdate   = diffConfigs.Flag("update", "The marshaled config update envelope to apply to the original config, instead of an updated config.").File()
+	diffConfigsFormat   = diffConfigs.Flag("format", "The format of the report: text or json.").Default(diff.TextFormat).Enum(diff.TextFormat, diff.JSONFormat)
+	diffConfigsDest     = diffConfigs.Flag("output", "A file to write the report to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
 	version = app.Command("version", "Show version information")
 )
 
@@ -91,6 +153,83 @@ func main() {
 		if err != nil {
 			app.Fatalf("Error computing update: %s", err)
 		}
+	case editAddOrg.FullCommand():
+		defer (*editAddOrgOrg).Close()
+		org, err := edit.ParseOrg(*editAddOrgOrg)
+		if err != nil {
+			app.Fatalf("Error reading organization: %s", err)
+		}
+		editConfigBlockTo(edit.AddApplicationOrg(org))
+	case editRemoveOrg.FullCommand():
+		editConfigBlockTo(edit.RemoveApplicationOrg(*editRemoveOrgMSPID))
+	case editSetAnchorPeers.FullCommand():
+		var anchorPeers []*pb.AnchorPeer
+		for _, s := range *editSetAnchorPeersPeers {
+			ap, err := edit.ParseAnchorPeer(s)
+			if err != nil {
+				app.Fatalf("Error parsing anchor peer: %s", err)
+			}
+			anchorPeers = append(anchorPeers, ap)
+		}
+		editConfigBlockTo(edit.SetAnchorPeers(*editSetAnchorPeersMSPID, anchorPeers))
+	case editAddConsenter.FullCommand():
+		consenter, err := newConsenter(*editAddConsenterHost, *editAddConsenterPort, *editAddConsenterClientTLSCert, *editAddConsenterServerTLSCert)
+		if err != nil {
+			app.Fatalf("Error reading consenter: %s", err)
+		}
+		editConfigBlockTo(edit.AddConsenter(consenter))
+	case editRemoveConsenter.FullCommand():
+		editConfigBlockTo(edit.RemoveConsenter(*editRemoveConsenterHost, *editRemoveConsenterPort))
+	case editReplaceConsenter.FullCommand():
+		host, port := *editReplaceConsenterHost, *editReplaceConsenterPort
+		if *editReplaceConsenterNewHost != "" {
+			host = *editReplaceConsenterNewHost
+		}
+		if *editReplaceConsenterNewPort != 0 {
+			port = *editReplaceConsenterNewPort
+		}
+		consenter, err := newConsenter(host, port, *editReplaceConsenterClientTLSCert, *editReplaceConsenterServerTLSCert)
+		if err != nil {
+			app.Fatalf("Error reading consenter: %s", err)
+		}
+		editConfigBlockTo(edit.ReplaceConsenter(*editReplaceConsenterHost, *editReplaceConsenterPort, consenter))
+	case editSetBatchParams.FullCommand():
+		editConfigBlockTo(edit.SetBatchParameters(edit.BatchParameters{
+			MaxMessageCount:   *editSetBatchParamsMaxMessageCount,
+			AbsoluteMaxBytes:  *editSetBatchParamsAbsoluteMaxBytes,
+			PreferredMaxBytes: *editSetBatchParamsPreferredMaxBytes,
+			Timeout:           *editSetBatchParamsTimeout,
+		}))
+	case editSetCapabilities.FullCommand():
+		editConfigBlockTo(edit.SetCapabilities(*editSetCapabilitiesLevel, *editSetCapabilitiesCaps))
+	case editSetACLs.FullCommand():
+		acls := map[string]string{}
+		for _, s := range *editSetACLsACLs {
+			resource, policy, err := edit.ParseACL(s)
+			if err != nil {
+				app.Fatalf("Error parsing ACL: %s", err)
+			}
+			acls[resource] = policy
+		}
+		editConfigBlockTo(edit.SetACLs(acls))
+	case checkSignatures.FullCommand():
+		defer (*checkSignaturesConfigBlock).Close()
+		defer (*checkSignaturesUpdate).Close()
+		defer (*checkSignaturesDest).Close()
+		err := checkSigs(*checkSignaturesConfigBlock, *checkSignaturesUpdate, *checkSignaturesDest)
+		if err != nil {
+			app.Fatalf("Error checking signatures: %s", err)
+		}
+	case diffConfigs.FullCommand():
+		defer (*diffConfigsOriginal).Close()
+		defer (*diffConfigsDest).Close()
+		if (*diffConfigsUpdated == nil) == (*diffConfigsUpdate == nil) {
+			app.Fatalf("Exactly one of --updated and --update must be specified")
+		}
+		err := diffCfgs(*diffConfigsOriginal, *diffConfigsUpdated, *diffConfigsUpdate, *diffConfigsDest, *diffConfigsFormat)
+		if err != nil {
+			app.Fatalf("Error computing diff: %s", err)
+		}
 	// "version" command
 	case version.FullCommand():
 		printVersion()
@@ -122,6 +261,17 @@ func startServer(address string, cors []string) {
 	app.Fatalf("Error starting server:[%s]\n", err)
 }
 
+// editConfigBlockTo applies the operation to the config block given by the
+// edit command flags and writes the resulting envelope to the output.
+func editConfigBlockTo(op edit.Operation) {
+	defer (*editConfigBlock).Close()
+	defer (*editDest).Close()
+	err := editBlock(*editConfigBlock, *editDest, op)
+	if err != nil {
+		app.Fatalf("Error editing config: %s", err)
+	}
+}
+
 func printVersion() {
 	fmt.Println(metadata.GetVersionInfo())
 }
@@ -218,3 +368,156 @@ func computeUpdt(original, updated, output *os.File, channelID string) error {
 
 	return nil
 }
+
+func editBlock(input, output *os.File, op edit.Operation) error {
+	in, err := ioutil.ReadAll(input)
+	if err != nil {
+		return errors.Wrapf(err, "error reading config block")
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(in, block)
+	if err != nil {
+		return errors.Wrapf(err, "error unmarshaling config block")
+	}
+
+	env, err := edit.Edit(block, op)
+	if err != nil {
+		return err
+	}
+
+	outBytes, err := proto.Marshal(env)
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling config update envelope")
+	}
+
+	_, err = output.Write(outBytes)
+	if err != nil {
+		return errors.Wrapf(err, "error writing config update envelope to output")
+	}
+
+	return nil
+}
+
+func newConsenter(host string, port uint32, clientTLSCert, serverTLSCert *os.File) (*etcdraft.Consenter, error) {
+	defer clientTLSCert.Close()
+	defer serverTLSCert.Close()
+
+	clientCert, err := ioutil.ReadAll(clientTLSCert)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading client TLS certificate")
+	}
+	serverCert, err := ioutil.ReadAll(serverTLSCert)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading server TLS certificate")
+	}
+
+	return &etcdraft.Consenter{
+		Host:          host,
+		Port:          port,
+		ClientTlsCert: clientCert,
+		ServerTlsCert: serverCert,
+	}, nil
+}
+
+func checkSigs(configBlock, configUpdate, output *os.File) error {
+	blockIn, err := ioutil.ReadAll(configBlock)
+	if err != nil {
+		return errors.Wrapf(err, "error reading config block")
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(blockIn, block)
+	if err != nil {
+		return errors.Wrapf(err, "error unmarshaling config block")
+	}
+
+	updateIn, err := ioutil.ReadAll(configUpdate)
+	if err != nil {
+		return errors.Wrapf(err, "error reading config update envelope")
+	}
+
+	env := &cb.Envelope{}
+	err = proto.Unmarshal(updateIn, env)
+	if err != nil {
+		return errors.Wrapf(err, "error unmarshaling config update envelope")
+	}
+
+	report, err := sigcheck.Check(block, env, factory.GetDefault())
+	if err != nil {
+		return err
+	}
+
+	outBytes, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling report")
+	}
+
+	_, err = output.Write(append(outBytes, '\n'))
+	if err != nil {
+		return errors.Wrapf(err, "error writing report to output")
+	}
+
+	return nil
+}
+
+func diffCfgs(original, updated, configUpdate, output *os.File, format string) error {
+	origIn, err := ioutil.ReadAll(original)
+	if err != nil {
+		return errors.Wrapf(err, "error reading original config")
+	}
+
+	origConf := &cb.Config{}
+	err = proto.Unmarshal(origIn, origConf)
+	if err != nil {
+		return errors.Wrapf(err, "error unmarshaling original config")
+	}
+
+	updtConf := &cb.Config{}
+	if updated != nil {
+		defer updated.Close()
+		updtIn, err := ioutil.ReadAll(updated)
+		if err != nil {
+			return errors.Wrapf(err, "error reading updated config")
+		}
+
+		err = proto.Unmarshal(updtIn, updtConf)
+		if err != nil {
+			return errors.Wrapf(err, "error unmarshaling updated config")
+		}
+	} else {
+		defer configUpdate.Close()
+		updateIn, err := ioutil.ReadAll(configUpdate)
+		if err != nil {
+			return errors.Wrapf(err, "error reading config update envelope")
+		}
+
+		env := &cb.Envelope{}
+		err = proto.Unmarshal(updateIn, env)
+		if err != nil {
+			return errors.Wrapf(err, "error unmarshaling config update envelope")
+		}
+
+		updtConf, err = diff.Apply(origConf, env)
+		if err != nil {
+			return err
+		}
+	}
+
+	report, err := diff.Compare(origConf, updtConf)
+	if err != nil {
+		return err
+	}
+
+	outBytes, err := diff.Marshal(report, format)
+	if err != nil {
+		return err
+	}
+
+	_, err = output.Write(outBytes)
+	if err != nil {
+		return errors.Wrapf(err, "error writing report to output")
+	}
+
+	return nil
+}
@@ -13,11 +13,17 @@ import (
 	"os"
 	"path/filepath"
 	"text/template"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/configtxlator/edit"
 	"github.com/hyperledger/fabric/internal/cryptogen/ca"
 	"github.com/hyperledger/fabric/internal/cryptogen/csp"
 	"github.com/hyperledger/fabric/internal/cryptogen/metadata"
 	"github.com/hyperledger/fabric/internal/cryptogen/msp"
+	"github.com/hyperledger/fabric/internal/cryptogen/renew"
+	"github.com/hyperledger/fabric/protoutil"
 
 	kingpin "gopkg.in/alecthomas/kingpin.v2"
 	yaml "gopkg.in/yaml.v2"
@@ -43,10 +49,11 @@ type SpecData struct {
 }
 
 type NodeTemplate struct {
-	Count    int      `yaml:"Count"`
-	Start    int      `yaml:"Start"`
-	Hostname string   `yaml:"Hostname"`
-	SANS     []string `yaml:"SANS"`
+	Count              int      `yaml:"Count"`
+	Start              int      `yaml:"Start"`
+	Hostname           string   `yaml:"Hostname"`
+	SANS               []string `yaml:"SANS"`
+	PublicKeyAlgorithm string   `yaml:"PublicKeyAlgorithm"`
 }
 
 type NodeSpec struct {
@@ -60,6 +67,7 @@ type NodeSpec struct {
 	StreetAddress      string   `yaml:"StreetAddress"`
 	PostalCode         string   `yaml:"PostalCode"`
 	SANS               []string `yaml:"SANS"`
+	PublicKeyAlgorithm string   `yaml:"PublicKeyAlgorithm"`
 }
 
 type UsersSpec struct {
@@ -124,6 +132,7 @@ PeerOrgs:
     #    OrganizationalUnit: Hyperledger Fabric
     #    StreetAddress: address for org # default nil
     #    PostalCode: postalCode for org # default nil
+    #    PublicKeyAlgorithm: ecdsa # or ed25519, default for all nodes and users
 
     # ---------------------------------------------------------------------------
     # "Specs"
@@ -148,6 +157,10 @@ PeerOrgs:
     #                 NOTE: Two implicit entries are created for you:
     #                     - {{ .CommonName }}
     #                     - {{ .Hostname }}
+    #   - PublicKeyAlgorithm: (Optional) The algorithm of the node's signing
+    #                 key, either "ecdsa" or "ed25519".  By default, this is
+    #                 the algorithm of the CA.  Ed25519 identities require
+    #                 the V3_0 channel capability.  TLS keys always use ECDSA.
     # ---------------------------------------------------------------------------
     # Specs:
     #   - Hostname: foo # implicitly "foo.org1.example.com"
@@ -158,6 +171,7 @@ PeerOrgs:
     #       - "{{.Hostname}}.org6.net"
     #       - 172.16.10.31
     #   - Hostname: bar
+    #     PublicKeyAlgorithm: ed25519
     #   - Hostname: baz
 
     # ---------------------------------------------------------------------------
@@ -178,6 +192,7 @@ PeerOrgs:
       # Hostname: {{.Prefix}}{{.Index}} # default
       # SANS:
       #   - "{{.Hostname}}.alt.{{.Domain}}"
+      # PublicKeyAlgorithm: ed25519 # default is the algorithm of the CA
 
     # ---------------------------------------------------------------------------
     # "Users"
@@ -213,6 +228,17 @@ var (
 	ext           = app.Command("extend", "Extend existing network")
 	inputDir      = ext.Flag("input", "The input directory in which existing network place").Default("crypto-config").String()
 	extConfigFile = ext.Flag("config", "The configuration template to use").File()
+
+	renewCmd         = app.Command("renew", "Renew the certificates of an existing network")
+	renewInputDir    = renewCmd.Flag("input", "The input directory in which existing network place").Default("crypto-config").String()
+	renewOrgs        = renewCmd.Flag("org", "The domain of an organization to renew, may be repeated (default: all organizations)").Strings()
+	renewNodeTypes   = renewCmd.Flag("nodeType", "The node type to renew, one of ca, tlsca, peer, orderer or user, may be repeated (default: all node types)").Enums(renew.CA, renew.TLSCA, renew.Peer, renew.Orderer, renew.User)
+	expiringWithin   = renewCmd.Flag("expiringWithin", "Only renew certificates expiring within the given duration, e.g. 720h (default: all certificates)").Duration()
+	rotateKeys       = renewCmd.Flag("rotateKeys", "Generate new keys for the renewed node and user certificates").Bool()
+	rotateCAs        = renewCmd.Flag("rotateCAs", "Generate new keys for the renewed CAs, reissue the certificates they issued and keep trusting their previous certificates").Bool()
+	issuerCADirs     = renewCmd.Flag("issuerCA", "A directory holding the certificate and key of the CA that issued an intermediate CA, may be repeated").Strings()
+	configBlocks     = renewCmd.Flag("configBlock", "A channel config block for which to write a config update distributing the renewed certificates, may be repeated").ExistingFiles()
+	channelUpdateDir = renewCmd.Flag("channelUpdateDir", "The output directory in which to place the config updates").Default(".").String()
 )
 
 func main() {
@@ -226,6 +252,10 @@ func main() {
 	case ext.FullCommand():
 		extend()
 
+		// "renew" command
+	case renewCmd.FullCommand():
+		renewCerts()
+
 		// "showtemplate" command
 	case showtemplate.FullCommand():
 		fmt.Print(defaultConfig)
@@ -295,6 +325,91 @@ func extend() {
 
 }
 
+func renewCerts() {
+	report, err := renew.Renew(*renewInputDir, renew.Options{
+		Orgs:           *renewOrgs,
+		NodeTypes:      *renewNodeTypes,
+		ExpiringWithin: *expiringWithin,
+		RotateKeys:     *rotateKeys,
+		RotateCAs:      *rotateCAs,
+		IssuerCADirs:   *issuerCADirs,
+	})
+	if err != nil {
+		fmt.Printf("Error renewing certificates: %s\n", err)
+		os.Exit(-1)
+	}
+
+	if len(report.Changes) == 0 {
+		fmt.Println("No certificates to renew")
+		return
+	}
+	for _, c := range report.Changes {
+		keyInfo := ""
+		if c.KeyRotated {
+			keyInfo = ", new key"
+		}
+		fmt.Printf("Renewed %s (%s): expires %s instead of %s%s\n", c.Path, c.Subject,
+			c.NotAfter.Format(time.RFC3339), c.PreviousNotAfter.Format(time.RFC3339), keyInfo)
+	}
+	for _, f := range report.UpdatedFiles {
+		fmt.Printf("Updated %s\n", f)
+	}
+
+	var renewals []edit.CertificateRenewal
+	for _, c := range report.Changes {
+		renewals = append(renewals, edit.CertificateRenewal{
+			Previous:     c.Previous,
+			Current:      c.Current,
+			KeepPrevious: c.IsCA && c.KeyRotated,
+		})
+	}
+	for _, blockFile := range *configBlocks {
+		if err := writeChannelUpdate(blockFile, renewals); err != nil {
+			fmt.Printf("Error writing config update for %s: %s\n", blockFile, err)
+			os.Exit(-1)
+		}
+	}
+}
+
+// writeChannelUpdate writes the config update distributing the renewed
+// certificates to the channel of the config block, if the channel config
+// holds any of them.
+func writeChannelUpdate(blockFile string, renewals []edit.CertificateRenewal) error {
+	data, err := ioutil.ReadFile(blockFile)
+	if err != nil {
+		return err
+	}
+	block := &cb.Block{}
+	if err := proto.Unmarshal(data, block); err != nil {
+		return fmt.Errorf("could not unmarshal block: %s", err)
+	}
+
+	channelID, config, err := edit.ConfigFromBlock(block)
+	if err != nil {
+		return err
+	}
+	op := edit.RenewCertificates(renewals)
+	updated := proto.Clone(config).(*cb.Config)
+	if err := op(updated); err != nil {
+		return err
+	}
+	if proto.Equal(config, updated) {
+		fmt.Printf("Channel %s does not hold any renewed certificate\n", channelID)
+		return nil
+	}
+
+	env, err := edit.Edit(block, op)
+	if err != nil {
+		return err
+	}
+	output := filepath.Join(*channelUpdateDir, channelID+"_renewal.tx")
+	if err := ioutil.WriteFile(output, protoutil.MarshalOrPanic(env), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote config update of channel %s to %s\n", channelID, output)
+	return nil
+}
+
 func extendPeerOrg(orgSpec OrgSpec) {
 	orgName := orgSpec.Domain
 	orgDir := filepath.Join(*inputDir, "peerOrganizations", orgName)
@@ -314,8 +429,9 @@ func extendPeerOrg(orgSpec OrgSpec) {
 	generateNodes(peersDir, orgSpec.Specs, signCA, tlsCA, msp.PEER, orgSpec.EnableNodeOUs)
 
 	adminUser := NodeSpec{
-		isAdmin:    true,
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		isAdmin:            true,
+		CommonName:         fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		PublicKeyAlgorithm: orgSpec.CA.PublicKeyAlgorithm,
 	}
 	// copy the admin cert to each of the org's peer's MSP admincerts
 	for _, spec := range orgSpec.Specs {
@@ -335,7 +451,8 @@ func extendPeerOrg(orgSpec OrgSpec) {
 	users := []NodeSpec{}
 	for j := 1; j <= orgSpec.Users.Count; j++ {
 		user := NodeSpec{
-			CommonName: fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			CommonName:         fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			PublicKeyAlgorithm: orgSpec.CA.PublicKeyAlgorithm,
 		}
 
 		users = append(users, user)
@@ -363,8 +480,9 @@ func extendOrdererOrg(orgSpec OrgSpec) {
 	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, msp.ORDERER, orgSpec.EnableNodeOUs)
 
 	adminUser := NodeSpec{
-		isAdmin:    true,
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		isAdmin:            true,
+		CommonName:         fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		PublicKeyAlgorithm: orgSpec.CA.PublicKeyAlgorithm,
 	}
 
 	for _, spec := range orgSpec.Specs {
@@ -434,7 +552,16 @@ func parseTemplateWithDefault(input, defaultInput string, data interface{}) (str
 	return parseTemplate(input, data)
 }
 
-func renderNodeSpec(domain string, spec *NodeSpec) error {
+func renderNodeSpec(domain string, spec *NodeSpec, defaultKeyAlg string) error {
+	switch spec.PublicKeyAlgorithm {
+	case "":
+		spec.PublicKeyAlgorithm = defaultKeyAlg
+	case csp.ECDSA, csp.ED25519:
+	default:
+		return fmt.Errorf("Unsupported public key algorithm %q for %s, must be one of %q or %q",
+			spec.PublicKeyAlgorithm, spec.Hostname, csp.ECDSA, csp.ED25519)
+	}
+
 	data := SpecData{
 		Hostname: spec.Hostname,
 		Domain:   domain,
@@ -469,7 +596,17 @@ func renderNodeSpec(domain string, spec *NodeSpec) error {
 }
 
 func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
-	// First process all of our templated nodes
+	// Process the CA node-spec first, its key algorithm is the default
+	// for all other nodes
+	if len(orgSpec.CA.Hostname) == 0 {
+		orgSpec.CA.Hostname = "ca"
+	}
+	err := renderNodeSpec(orgSpec.Domain, &orgSpec.CA, csp.ECDSA)
+	if err != nil {
+		return err
+	}
+
+	// Then process all of our templated nodes
 	for i := 0; i < orgSpec.Template.Count; i++ {
 		data := HostnameData{
 			Prefix: prefix,
@@ -483,15 +620,16 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 		}
 
 		spec := NodeSpec{
-			Hostname: hostname,
-			SANS:     orgSpec.Template.SANS,
+			Hostname:           hostname,
+			SANS:               orgSpec.Template.SANS,
+			PublicKeyAlgorithm: orgSpec.Template.PublicKeyAlgorithm,
 		}
 		orgSpec.Specs = append(orgSpec.Specs, spec)
 	}
 
 	// Touch up all general node-specs to add the domain
 	for idx, spec := range orgSpec.Specs {
-		err := renderNodeSpec(orgSpec.Domain, &spec)
+		err := renderNodeSpec(orgSpec.Domain, &spec, orgSpec.CA.PublicKeyAlgorithm)
 		if err != nil {
 			return err
 		}
@@ -499,15 +637,6 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 		orgSpec.Specs[idx] = spec
 	}
 
-	// Process the CA node-spec in the same manner
-	if len(orgSpec.CA.Hostname) == 0 {
-		orgSpec.CA.Hostname = "ca"
-	}
-	err := renderNodeSpec(orgSpec.Domain, &orgSpec.CA)
-	if err != nil {
-		return err
-	}
-
 	return nil
 }
 
@@ -525,13 +654,13 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, csp.ECDSA)
 	if err != nil {
 		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -549,15 +678,17 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	users := []NodeSpec{}
 	for j := 1; j <= orgSpec.Users.Count; j++ {
 		user := NodeSpec{
-			CommonName: fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			CommonName:         fmt.Sprintf("%s%d@%s", userBaseName, j, orgName),
+			PublicKeyAlgorithm: orgSpec.CA.PublicKeyAlgorithm,
 		}
 
 		users = append(users, user)
 	}
 	// add an admin user
 	adminUser := NodeSpec{
-		isAdmin:    true,
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		isAdmin:            true,
+		CommonName:         fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		PublicKeyAlgorithm: orgSpec.CA.PublicKeyAlgorithm,
 	}
 
 	users = append(users, adminUser)
@@ -620,7 +751,7 @@ func generateNodes(baseDir string, nodes []NodeSpec, signCA *ca.CA, tlsCA *ca.CA
 			if node.isAdmin && nodeOUs {
 				currentNodeType = msp.ADMIN
 			}
-			err := msp.GenerateLocalMSP(nodeDir, node.CommonName, node.SANS, signCA, tlsCA, currentNodeType, nodeOUs)
+			err := msp.GenerateLocalMSP(nodeDir, node.CommonName, node.SANS, signCA, tlsCA, currentNodeType, nodeOUs, node.PublicKeyAlgorithm)
 			if err != nil {
 				fmt.Printf("Error generating local MSP for %v:\n%v\n", node, err)
 				os.Exit(1)
@@ -642,13 +773,13 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.CA.PublicKeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, csp.ECDSA)
 	if err != nil {
 		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -663,8 +794,9 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, msp.ORDERER, orgSpec.EnableNodeOUs)
 
 	adminUser := NodeSpec{
-		isAdmin:    true,
-		CommonName: fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		isAdmin:            true,
+		CommonName:         fmt.Sprintf("%s@%s", adminBaseName, orgName),
+		PublicKeyAlgorithm: orgSpec.CA.PublicKeyAlgorithm,
 	}
 
 	// generate an admin for the orderer org
@@ -723,12 +855,12 @@ func printVersion() {
 }
 
 func getCA(caDir string, spec OrgSpec, name string) *ca.CA {
-	priv, _ := csp.LoadPrivateKey(caDir)
+	signer, _ := csp.LoadSigner(caDir)
 	cert, _ := ca.LoadCertificateECDSA(caDir)
 
 	return &ca.CA{
 		Name:               name,
-		Signer:             priv,
+		Signer:             signer,
 		SignCert:           cert,
 		Country:            spec.CA.Country,
 		Province:           spec.CA.Province,
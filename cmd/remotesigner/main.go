@@ -0,0 +1,143 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/hyperledger/fabric/bccsp/remote"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const unixScheme = "unix://"
+
+// command line flags
+var (
+	app = kingpin.New("remotesigner", "Reference remote signer serving the keys of a local keystore to the REMOTE BCCSP provider")
+
+	listenAddress = app.Flag("listen", "The address to listen on, either host:port or unix:///path").Default("127.0.0.1:7060").String()
+	keyStore      = app.Flag("keystore", "The directory holding the private keys").Required().ExistingDir()
+	tlsCert       = app.Flag("tls-cert", "The TLS certificate of the signer, required unless listening on a unix socket").ExistingFile()
+	tlsKey        = app.Flag("tls-key", "The TLS private key of the signer, required unless listening on a unix socket").ExistingFile()
+	clientCAs     = app.Flag("client-ca", "A root CA for client certificates, required unless listening on a unix socket (may be repeated)").ExistingFiles()
+)
+
+var logger = flogging.MustGetLogger("remotesigner")
+
+func main() {
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+
+	server, lis, err := newServer()
+	if err != nil {
+		app.Fatalf("Error starting remote signer: %s", err)
+	}
+
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+		server.GracefulStop()
+	}()
+
+	logger.Infof("Serving keys from %s on %s", *keyStore, *listenAddress)
+	if err := server.Serve(lis); err != nil {
+		app.Fatalf("Error serving remote signer: %s", err)
+	}
+}
+
+func newServer() (*grpc.Server, net.Listener, error) {
+	if err := checkTransport(); err != nil {
+		return nil, nil, err
+	}
+
+	csp, err := sw.NewDefaultSecurityLevel(*keyStore)
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed initializing keystore")
+	}
+
+	var serverOpts []grpc.ServerOption
+	if *tlsCert != "" || *tlsKey != "" {
+		tlsConfig, err := serverTLSConfig()
+		if err != nil {
+			return nil, nil, err
+		}
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	network, address := "tcp", *listenAddress
+	if strings.HasPrefix(address, unixScheme) {
+		network, address = "unix", strings.TrimPrefix(address, unixScheme)
+	}
+	lis, err := net.Listen(network, address)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed listening on %s", *listenAddress)
+	}
+
+	server := grpc.NewServer(serverOpts...)
+	remote.RegisterSignerServer(server, &remote.LocalSigner{CSP: csp})
+	return server, lis, nil
+}
+
+// checkTransport enforces the trust model of the signer: any client able to
+// connect can sign with the keys of the keystore, hence clients connecting over
+// TCP must authenticate with mutual TLS. Only unix sockets, whose access is
+// controlled by file permissions, may be served without it.
+func checkTransport() error {
+	if (*tlsCert == "") != (*tlsKey == "") {
+		return errors.New("both --tls-cert and --tls-key must be set to enable TLS")
+	}
+	if *tlsCert == "" && len(*clientCAs) != 0 {
+		return errors.New("--client-ca requires --tls-cert and --tls-key")
+	}
+	if strings.HasPrefix(*listenAddress, unixScheme) {
+		return nil
+	}
+	if *tlsCert == "" || len(*clientCAs) == 0 {
+		return errors.Errorf("mutual TLS is required to listen on %s: set --tls-cert, --tls-key and --client-ca, or listen on a %s socket", *listenAddress, unixScheme)
+	}
+	return nil
+}
+
+func serverTLSConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(*tlsCert, *tlsKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed loading TLS key pair")
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if len(*clientCAs) == 0 {
+		return tlsConfig, nil
+	}
+	pool := x509.NewCertPool()
+	for _, file := range *clientCAs {
+		pem, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed reading client CA %s", file)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("no certificates found in %s", file)
+		}
+	}
+	tlsConfig.ClientCAs = pool
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	return tlsConfig, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTransport(t *testing.T) {
+	tests := []struct {
+		name          string
+		listenAddress string
+		tlsCert       string
+		tlsKey        string
+		clientCAs     []string
+		expectedErr   string
+	}{
+		{
+			name:          "mutual TLS over TCP",
+			listenAddress: "127.0.0.1:7060",
+			tlsCert:       "cert.pem",
+			tlsKey:        "key.pem",
+			clientCAs:     []string{"ca.pem"},
+		},
+		{
+			name:          "plaintext unix socket",
+			listenAddress: "unix:///tmp/signer.sock",
+		},
+		{
+			name:          "server-side TLS unix socket",
+			listenAddress: "unix:///tmp/signer.sock",
+			tlsCert:       "cert.pem",
+			tlsKey:        "key.pem",
+		},
+		{
+			name:          "plaintext TCP",
+			listenAddress: "127.0.0.1:7060",
+			expectedErr:   "mutual TLS is required to listen on 127.0.0.1:7060: set --tls-cert, --tls-key and --client-ca, or listen on a unix:// socket",
+		},
+		{
+			name:          "TCP without client CA",
+			listenAddress: "127.0.0.1:7060",
+			tlsCert:       "cert.pem",
+			tlsKey:        "key.pem",
+			expectedErr:   "mutual TLS is required to listen on 127.0.0.1:7060: set --tls-cert, --tls-key and --client-ca, or listen on a unix:// socket",
+		},
+		{
+			name:          "missing TLS key",
+			listenAddress: "unix:///tmp/signer.sock",
+			tlsCert:       "cert.pem",
+			expectedErr:   "both --tls-cert and --tls-key must be set to enable TLS",
+		},
+		{
+			name:          "client CA without TLS",
+			listenAddress: "unix:///tmp/signer.sock",
+			clientCAs:     []string{"ca.pem"},
+			expectedErr:   "--client-ca requires --tls-cert and --tls-key",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*listenAddress, *tlsCert, *tlsKey, *clientCAs = tt.listenAddress, tt.tlsCert, tt.tlsKey, tt.clientCAs
+			err := checkTransport()
+			if tt.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestNewServerRefusesPlaintextTCP(t *testing.T) {
+	dir, err := ioutil.TempDir("", "remotesigner")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	*keyStore, *tlsCert, *tlsKey, *clientCAs = dir, "", "", nil
+
+	*listenAddress = "127.0.0.1:0"
+	_, _, err = newServer()
+	require.EqualError(t, err, "mutual TLS is required to listen on 127.0.0.1:0: set --tls-cert, --tls-key and --client-ca, or listen on a unix:// socket")
+
+	*listenAddress = unixScheme + filepath.Join(dir, "signer.sock")
+	server, lis, err := newServer()
+	require.NoError(t, err)
+	defer lis.Close()
+	server.Stop()
+}
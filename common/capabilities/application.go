@@ -35,6 +35,16 @@ const (
 	ApplicationResourcesTreeExperimental = "V1_1_RESOURCETREE_EXPERIMENTAL"
 )
 
+// applicationCapabilities lists the application capabilities from the oldest to the newest,
+// leaving out the experimental ones.
+var applicationCapabilities = []string{
+	ApplicationV1_1,
+	ApplicationV1_2,
+	ApplicationV1_3,
+	ApplicationV1_4_2,
+	ApplicationV2_0,
+}
+
 // ApplicationProvider provides capabilities information for application level config.
 type ApplicationProvider struct {
 	*registry
@@ -49,3 +49,24 @@ func (r *registry) Supported() error {
 	}
 	return nil
 }
+
+// Supported returns the capabilities supported by this binary, by the name of
+// the config group which enables them, from the oldest to the newest.
+// Experimental capabilities are not included.
+func Supported() map[string][]string {
+	return map[string][]string{
+		channelTypeName:     supported(&ChannelProvider{}, channelCapabilities),
+		ordererTypeName:     supported(&OrdererProvider{}, ordererCapabilities),
+		applicationTypeName: supported(&ApplicationProvider{}, applicationCapabilities),
+	}
+}
+
+func supported(p provider, capabilities []string) []string {
+	var names []string
+	for _, capability := range capabilities {
+		if p.HasCapability(capability) {
+			names = append(names, capability)
+		}
+	}
+	return names
+}
@@ -36,3 +36,24 @@ func TestNotSatisfied(t *testing.T) {
 		require.Error(t, provider.Supported())
 	}
 }
+
+func TestSupported(t *testing.T) {
+	supported := Supported()
+	require.Equal(t, []string{"V1_1", "V1_3", "V1_4_2", "V1_4_3", "V2_0", "V3_0", "V3_1"}, supported["Channel"])
+	require.Equal(t, []string{"V1_1", "V1_4_2", "V2_0"}, supported["Orderer"])
+	require.Equal(t, []string{"V1_1", "V1_2", "V1_3", "V1_4_2", "V2_0"}, supported["Application"])
+
+	for group, names := range supported {
+		for _, name := range names {
+			caps := map[string]*cb.Capability{name: {}}
+			switch group {
+			case "Channel":
+				require.NoError(t, NewChannelProvider(caps).Supported())
+			case "Orderer":
+				require.NoError(t, NewOrdererProvider(caps).Supported())
+			case "Application":
+				require.NoError(t, NewApplicationProvider(caps).Supported())
+			}
+		}
+	}
+}
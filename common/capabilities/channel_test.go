@@ -100,6 +100,28 @@ func TestChannelV20(t *testing.T) {
 	require.True(t, cp.OrgSpecificOrdererEndpoints())
 }
 
+func TestChannelV30(t *testing.T) {
+	cp := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV3_0: {},
+	})
+	require.NoError(t, cp.Supported())
+	require.True(t, cp.MSPVersion() == msp.MSPv3_0)
+	require.True(t, cp.ConsensusTypeMigration())
+	require.True(t, cp.OrgSpecificOrdererEndpoints())
+	require.False(t, cp.ExpressionPolicies())
+}
+
+func TestChannelV31(t *testing.T) {
+	cp := NewChannelProvider(map[string]*cb.Capability{
+		ChannelV3_1: {},
+	})
+	require.NoError(t, cp.Supported())
+	require.True(t, cp.MSPVersion() == msp.MSPv3_0)
+	require.True(t, cp.ConsensusTypeMigration())
+	require.True(t, cp.OrgSpecificOrdererEndpoints())
+	require.True(t, cp.ExpressionPolicies())
+}
+
 func TestChannelNotSupported(t *testing.T) {
 	cp := NewChannelProvider(map[string]*cb.Capability{
 		ChannelV1_1:           {},
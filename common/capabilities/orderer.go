@@ -23,6 +23,13 @@ const (
 	OrdererV2_0 = "V2_0"
 )
 
+// ordererCapabilities lists the orderer capabilities from the oldest to the newest.
+var ordererCapabilities = []string{
+	OrdererV1_1,
+	OrdererV1_4_2,
+	OrdererV2_0,
+}
+
 // OrdererProvider provides capabilities information for orderer level config.
 type OrdererProvider struct {
 	*registry
@@ -13,6 +13,7 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	mb "github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
 	"go.uber.org/zap/zapcore"
 )
@@ -90,3 +91,51 @@ func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal) (func([]
 		return nil, fmt.Errorf("Unknown type: %T:%v", t, t)
 	}
 }
+
+// trace re-evaluates the policy with the same semantics as the function
+// returned by compile, recording which principal was satisfied by which
+// identity and why the others were not.
+func trace(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, signedData []msp.Identity, used []bool) *policies.EvaluationTrace {
+	switch t := policy.Type.(type) {
+	case *cb.SignaturePolicy_NOutOf_:
+		result := &policies.EvaluationTrace{
+			Rule:     fmt.Sprintf("OutOf(%d)", t.NOutOf.N),
+			Required: int(t.NOutOf.N),
+		}
+		_used := make([]bool, len(used))
+		for _, policy := range t.NOutOf.Rules {
+			copy(_used, used)
+			sub := trace(policy, identities, signedData, _used)
+			if sub.Satisfied {
+				result.SatisfiedCount++
+				copy(used, _used)
+			}
+			result.SubPolicies = append(result.SubPolicies, sub)
+		}
+		result.Satisfied = result.SatisfiedCount >= result.Required
+		return result
+	case *cb.SignaturePolicy_SignedBy:
+		principal := identities[t.SignedBy]
+		result := &policies.EvaluationTrace{
+			Rule: fmt.Sprintf("SignedBy(%s)", policies.PrincipalString(principal)),
+		}
+		for i, sd := range signedData {
+			if used[i] {
+				result.Mismatches = append(result.Mismatches, fmt.Sprintf("%s: already used for another principal", policies.IdentityString(sd)))
+				continue
+			}
+			if err := sd.SatisfiesPrincipal(principal); err != nil {
+				result.Mismatches = append(result.Mismatches, fmt.Sprintf("%s: %s", policies.IdentityString(sd), err))
+				continue
+			}
+			used[i] = true
+			result.Satisfied = true
+			result.MatchedIdentity = policies.IdentityString(sd)
+			result.Mismatches = nil
+			break
+		}
+		return result
+	default:
+		return &policies.EvaluationTrace{Rule: fmt.Sprintf("Unknown type: %T", t)}
+	}
+}
@@ -91,7 +91,15 @@ func (p *policy) EvaluateSignedData(signatureSet []*protoutil.SignedData) error
 
 	ids := policies.SignatureSetToValidIdentities(signatureSet, p.deserializer)
 
-	return p.EvaluateIdentities(ids)
+	err := p.EvaluateIdentities(ids)
+	if e, ok := err.(*policies.EvaluationError); ok {
+		return policies.NewEvaluationError(e.Error(), func() *policies.EvaluationTrace {
+			trace := *e.Trace()
+			trace.DiscardedSignatures = policies.DiscardedSignatures(signatureSet, p.deserializer)
+			return &trace
+		})
+	}
+	return err
 }
 
 // EvaluateIdentities takes an array of identities and evaluates whether
@@ -103,7 +111,9 @@ func (p *policy) EvaluateIdentities(identities []msp.Identity) error {
 
 	ok := p.evaluator(identities, make([]bool, len(identities)))
 	if !ok {
-		return errors.New("signature set did not satisfy policy")
+		return policies.NewEvaluationError("signature set did not satisfy policy", func() *policies.EvaluationTrace {
+			return trace(p.signaturePolicyEnvelope.Rule, p.signaturePolicyEnvelope.Identities, identities, make([]bool, len(identities)))
+		})
 	}
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/hyperledger/fabric/protoutil"
@@ -150,3 +151,34 @@ func TestConverter(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, cp, policydsl.RejectAllPolicy)
 }
+
+func TestEvaluationTrace(t *testing.T) {
+	org1 := marshalOrPanic(&mb.SerializedIdentity{Mspid: "Org1MSP"})
+	org2 := marshalOrPanic(&mb.SerializedIdentity{Mspid: "Org2MSP"})
+	pp := &EnvelopeBasedPolicyProvider{Deserializer: &mockDeserializer{}}
+	p, err := pp.NewPolicy(policydsl.Envelope(policydsl.And(policydsl.SignedBy(0), policydsl.SignedBy(1)), [][]byte{org1, org2}))
+	require.NoError(t, err)
+
+	err = p.EvaluateSignedData([]*protoutil.SignedData{
+		{Identity: org1},
+		{Identity: org1},
+		{Identity: org2, Signature: invalidSignature},
+	})
+	require.EqualError(t, err, "signature set did not satisfy policy")
+	require.Equal(t, &policies.EvaluationTrace{
+		Rule:           "OutOf(2)",
+		Required:       2,
+		SatisfiedCount: 1,
+		DiscardedSignatures: []string{
+			"signature 1 by Org1MSP: duplicate identity",
+			"signature 2 by Org2MSP: invalid signature: Invalid signature",
+		},
+		SubPolicies: []*policies.EvaluationTrace{
+			{Rule: "SignedBy(identity Org1MSP)", Satisfied: true, MatchedIdentity: "Org1MSP"},
+			{Rule: "SignedBy(identity Org2MSP)", Mismatches: []string{"Org1MSP: already used for another principal"}},
+		},
+	}, policies.TraceFromError(err))
+
+	err = p.EvaluateSignedData([]*protoutil.SignedData{{Identity: org1}, {Identity: org2}})
+	require.NoError(t, err)
+}
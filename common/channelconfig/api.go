@@ -135,6 +135,9 @@ type ChannelCapabilities interface {
 
 	// OrgSpecificOrdererEndpoints return true if the channel config processing allows orderer orgs to specify their own endpoints
 	OrgSpecificOrdererEndpoints() bool
+
+	// ExpressionPolicies returns true if policies of the expression type may be used in the channel config
+	ExpressionPolicies() bool
 }
 
 // ApplicationCapabilities defines the capabilities for the application portion of a channel
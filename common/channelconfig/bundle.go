@@ -7,12 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package channelconfig
 
 import (
+	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/configtx"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/policies/expression"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -201,6 +203,12 @@ func NewBundle(channelID string, config *cb.Config, bccsp bccsp.BCCSP) (*Bundle,
 			// Add hook for MSP Handler here
 		}
 	}
+	if channelConfig.Capabilities().ExpressionPolicies() {
+		if err := validateEndorsementPolicies(config.ChannelGroup); err != nil {
+			return nil, err
+		}
+		policyProviderMap[expression.PolicyType] = expression.NewPolicyProvider(channelConfig.MSPManager())
+	}
 
 	policyManager, err := policies.NewManagerImpl(RootGroupKey, policyProviderMap, config.ChannelGroup)
 	if err != nil {
@@ -219,6 +227,45 @@ func NewBundle(channelID string, config *cb.Config, bccsp bccsp.BCCSP) (*Bundle,
 	}, nil
 }
 
+// endorsementPolicyNames are the names of the application policies which are
+// converted to signature policies when chaincodes and service discovery refer
+// to them.
+var endorsementPolicyNames = []string{"Endorsement", "LifecycleEndorsement"}
+
+// validateEndorsementPolicies checks that the expression policies used for
+// endorsement, at the application level and in the application
+// organizations, can be converted to signature policies.
+func validateEndorsementPolicies(channelGroup *cb.ConfigGroup) error {
+	ag, ok := channelGroup.Groups[ApplicationGroupKey]
+	if !ok {
+		return nil
+	}
+	groups := map[string]*cb.ConfigGroup{ApplicationGroupKey: ag}
+	for orgName, og := range ag.Groups {
+		groups[ApplicationGroupKey+"/"+orgName] = og
+	}
+	for groupName, group := range groups {
+		for _, policyName := range endorsementPolicyNames {
+			configPolicy, ok := group.Policies[policyName]
+			if !ok || configPolicy.Policy == nil || configPolicy.Policy.Type != expression.PolicyType {
+				continue
+			}
+			ep := &expression.ExpressionPolicy{}
+			if err := proto.Unmarshal(configPolicy.Policy.Value, ep); err != nil {
+				return errors.Wrapf(err, "error unmarshaling policy /%s/%s/%s", RootGroupKey, groupName, policyName)
+			}
+			e, err := expression.Parse(ep.Expression)
+			if err != nil {
+				return errors.Wrapf(err, "invalid expression for policy /%s/%s/%s", RootGroupKey, groupName, policyName)
+			}
+			if _, err := e.SignaturePolicy(); err != nil {
+				return errors.WithMessagef(err, "policy /%s/%s/%s is used for endorsement and must be convertible to a signature policy", RootGroupKey, groupName, policyName)
+			}
+		}
+	}
+	return nil
+}
+
 func preValidate(config *cb.Config) error {
 	if config == nil {
 		return errors.New("channelconfig Config cannot be nil")
@@ -9,8 +9,11 @@ package channelconfig_test
 import (
 	"testing"
 
+	cb "github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/policies/expression"
 	"github.com/hyperledger/fabric/core/config/configtest"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
 	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
@@ -80,3 +83,58 @@ func TestOrgSpecificOrdererEndpoints(t *testing.T) {
 	})
 
 }
+
+func TestExpressionPolicies(t *testing.T) {
+	newConfig := func(t *testing.T, capabilities map[string]bool) *cb.Config {
+		conf := genesisconfig.Load(genesisconfig.SampleDevModeSoloProfile, configtest.GetDevConfigDir())
+		conf.Capabilities = capabilities
+		conf.Application.Policies["Auditors"] = &genesisconfig.Policy{
+			Type: encoder.ExpressionPolicyType,
+			Rule: "Identity('SampleOrg.admin', Attr('dept', 'audit'))",
+		}
+
+		cg, err := encoder.NewChannelGroup(conf)
+		require.NoError(t, err)
+		return &cb.Config{ChannelGroup: cg}
+	}
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	t.Run("Without_Capability", func(t *testing.T) {
+		_, err := channelconfig.NewBundle("mychannel", newConfig(t, map[string]bool{"V2_0": true}), cryptoProvider)
+		require.EqualError(t, err, "initializing policymanager failed: policy Auditors at path Channel/Application has unknown policy type: 10000")
+	})
+
+	t.Run("With_Capability", func(t *testing.T) {
+		bundle, err := channelconfig.NewBundle("mychannel", newConfig(t, map[string]bool{"V3_1": true}), cryptoProvider)
+		require.NoError(t, err)
+
+		policy, ok := bundle.PolicyManager().GetPolicy("/Channel/Application/Auditors")
+		require.True(t, ok)
+		require.EqualError(t, policy.EvaluateSignedData(nil), "signature set did not satisfy policy")
+	})
+
+	t.Run("Convertible_Endorsement", func(t *testing.T) {
+		config := newConfig(t, map[string]bool{"V3_1": true})
+		endorsement, err := expression.NewConfigPolicy("OutOf(2, Weight(2, 'SampleOrg.peer'), 'SampleOrg.admin', 'SampleOrg.client')")
+		require.NoError(t, err)
+		config.ChannelGroup.Groups["Application"].Groups["SampleOrg"].Policies["Endorsement"].Policy = endorsement
+
+		bundle, err := channelconfig.NewBundle("mychannel", config, cryptoProvider)
+		require.NoError(t, err)
+
+		policy, ok := bundle.PolicyManager().GetPolicy("/Channel/Application/SampleOrg/Endorsement")
+		require.True(t, ok)
+		_, err = policy.(policies.Converter).Convert()
+		require.NoError(t, err)
+	})
+
+	t.Run("Unconvertible_Endorsement", func(t *testing.T) {
+		config := newConfig(t, map[string]bool{"V3_1": true})
+		config.ChannelGroup.Groups["Application"].Policies["Endorsement"].Policy = config.ChannelGroup.Groups["Application"].Policies["Auditors"].Policy
+
+		_, err := channelconfig.NewBundle("mychannel", config, cryptoProvider)
+		require.EqualError(t, err, "policy /Channel/Application/Endorsement is used for endorsement and must be convertible to a signature policy: expression \"Identity('SampleOrg.admin', Attr('dept', 'audit'))\" cannot be converted to a signature policy: Identity('SampleOrg.admin', Attr('dept', 'audit')) places conditions on the certificate")
+	})
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package configtx
 
 import (
+	"sort"
 	"strings"
 
 	cb "github.com/hyperledger/fabric-protos-go/common"
@@ -71,22 +72,13 @@ func (vi *ValidatorImpl) verifyDeltaSet(deltaSet map[string]comparable, signedDa
 	}
 
 	for key, value := range deltaSet {
-		logger.Debugf("Processing change to key: %s", key)
-		if err := validateModPolicy(value.modPolicy()); err != nil {
-			return errors.Wrapf(err, "invalid mod_policy for element %s", key)
+		existing, ok, err := vi.verifyDeltaSetElement(key, value)
+		if err != nil {
+			return err
 		}
-
-		existing, ok := vi.configMap[key]
 		if !ok {
-			if value.version() != 0 {
-				return errors.Errorf("attempted to set key %s to version %d, but key does not exist", key, value.version())
-			}
-
 			continue
 		}
-		if value.version() != existing.version()+1 {
-			return errors.Errorf("attempt to set key %s to version %d, but key is at version %d", key, value.version(), existing.version())
-		}
 
 		policy, ok := vi.policyForItem(existing)
 		if !ok {
@@ -101,6 +93,29 @@ func (vi *ValidatorImpl) verifyDeltaSet(deltaSet map[string]comparable, signedDa
 	return nil
 }
 
+// verifyDeltaSetElement checks the mod_policy and version of an element in
+// the delta set and returns the existing element it modifies, if any.
+func (vi *ValidatorImpl) verifyDeltaSetElement(key string, value comparable) (comparable, bool, error) {
+	logger.Debugf("Processing change to key: %s", key)
+	if err := validateModPolicy(value.modPolicy()); err != nil {
+		return comparable{}, false, errors.Wrapf(err, "invalid mod_policy for element %s", key)
+	}
+
+	existing, ok := vi.configMap[key]
+	if !ok {
+		if value.version() != 0 {
+			return comparable{}, false, errors.Errorf("attempted to set key %s to version %d, but key does not exist", key, value.version())
+		}
+
+		return comparable{}, false, nil
+	}
+	if value.version() != existing.version()+1 {
+		return comparable{}, false, errors.Errorf("attempt to set key %s to version %d, but key is at version %d", key, value.version(), existing.version())
+	}
+
+	return existing, true, nil
+}
+
 func verifyFullProposedConfig(writeSet, fullProposedConfig map[string]comparable) error {
 	for key := range writeSet {
 		if _, ok := fullProposedConfig[key]; !ok {
@@ -113,41 +128,134 @@ func verifyFullProposedConfig(writeSet, fullProposedConfig map[string]comparable
 // authorizeUpdate validates that all modified config has the corresponding modification policies satisfied by the signature set
 // it returns a map of the modified config
 func (vi *ValidatorImpl) authorizeUpdate(configUpdateEnv *cb.ConfigUpdateEnvelope) (map[string]comparable, error) {
+	deltaSet, writeSet, err := vi.deltaSetForUpdate(configUpdateEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	signedData, err := protoutil.ConfigUpdateEnvelopeAsSignedData(configUpdateEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = vi.verifyDeltaSet(deltaSet, signedData); err != nil {
+		return nil, errors.Wrapf(err, "error validating DeltaSet")
+	}
+
+	fullProposedConfig := vi.computeUpdateResult(deltaSet)
+	if err := verifyFullProposedConfig(writeSet, fullProposedConfig); err != nil {
+		return nil, errors.Wrapf(err, "full config did not verify")
+	}
+
+	return fullProposedConfig, nil
+}
+
+// deltaSetForUpdate verifies the read set of the config update and returns
+// the elements it changes along with its full write set.
+func (vi *ValidatorImpl) deltaSetForUpdate(configUpdateEnv *cb.ConfigUpdateEnvelope) (deltaSet, writeSet map[string]comparable, err error) {
 	if configUpdateEnv == nil {
-		return nil, errors.Errorf("cannot process nil ConfigUpdateEnvelope")
+		return nil, nil, errors.Errorf("cannot process nil ConfigUpdateEnvelope")
 	}
 
 	configUpdate, err := UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if configUpdate.ChannelId != vi.channelID {
-		return nil, errors.Errorf("ConfigUpdate for channel '%s' but envelope for channel '%s'", configUpdate.ChannelId, vi.channelID)
+		return nil, nil, errors.Errorf("ConfigUpdate for channel '%s' but envelope for channel '%s'", configUpdate.ChannelId, vi.channelID)
 	}
 
 	readSet, err := mapConfig(configUpdate.ReadSet, vi.namespace)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error mapping ReadSet")
+		return nil, nil, errors.Wrapf(err, "error mapping ReadSet")
 	}
 	err = vi.verifyReadSet(readSet)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error validating ReadSet")
+		return nil, nil, errors.Wrapf(err, "error validating ReadSet")
 	}
 
-	writeSet, err := mapConfig(configUpdate.WriteSet, vi.namespace)
+	writeSet, err = mapConfig(configUpdate.WriteSet, vi.namespace)
 	if err != nil {
-		return nil, errors.Wrapf(err, "error mapping WriteSet")
+		return nil, nil, errors.Wrapf(err, "error mapping WriteSet")
 	}
 
-	deltaSet := computeDeltaSet(readSet, writeSet)
-	signedData, err := protoutil.ConfigUpdateEnvelopeAsSignedData(configUpdateEnv)
+	return computeDeltaSet(readSet, writeSet), writeSet, nil
+}
+
+// ModifiedElement is an existing config element modified by a config update.
+type ModifiedElement struct {
+	// Key identifies the element, e.g. "[Group]  /Channel/Application".
+	Key string
+	// ModPolicy is the absolute path of the policy governing the element.
+	ModPolicy string
+	// Policy is the policy which must be satisfied to modify the element.
+	Policy policies.Policy
+}
+
+// ModifiedElements returns, sorted by key, the existing config elements
+// modified by the config update along with the policy which must be satisfied
+// for each. It performs the same checks as ProposeConfigUpdate except for the
+// evaluation of the policies, so that callers may evaluate them against the
+// signatures collected so far.
+func (vi *ValidatorImpl) ModifiedElements(configUpdateEnv *cb.ConfigUpdateEnvelope) ([]*ModifiedElement, error) {
+	deltaSet, writeSet, err := vi.deltaSetForUpdate(configUpdateEnv)
 	if err != nil {
 		return nil, err
 	}
+	if len(deltaSet) == 0 {
+		return nil, errors.Errorf("delta set was empty -- update would have no effect")
+	}
 
-	if err = vi.verifyDeltaSet(deltaSet, signedData); err != nil {
-		return nil, errors.Wrapf(err, "error validating DeltaSet")
+	var elements []*ModifiedElement
+	for key, value := range deltaSet {
+		existing, ok, err := vi.verifyDeltaSetElement(key, value)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		policy, ok := vi.policyForItem(existing)
+		if !ok {
+			return nil, errors.Errorf("unexpected missing policy %s for item %s", existing.modPolicy(), key)
+		}
+
+		elements = append(elements, &ModifiedElement{
+			Key:       key,
+			ModPolicy: modPolicyPath(existing),
+			Policy:    policy,
+		})
+	}
+
+	if err := verifyFullProposedConfig(writeSet, vi.computeUpdateResult(deltaSet)); err != nil {
+		return nil, errors.Wrapf(err, "full config did not verify")
+	}
+
+	sort.Slice(elements, func(i, j int) bool {
+		return elements[i].Key < elements[j].Key
+	})
+	return elements, nil
+}
+
+// ProposedConfig returns the config which would result from applying the
+// config update. It performs the same checks as ProposeConfigUpdate except
+// for the evaluation of the policies, so the policy manager of the validator
+// is never consulted.
+func (vi *ValidatorImpl) ProposedConfig(configUpdateEnv *cb.ConfigUpdateEnvelope) (*cb.Config, error) {
+	deltaSet, writeSet, err := vi.deltaSetForUpdate(configUpdateEnv)
+	if err != nil {
+		return nil, err
+	}
+	if len(deltaSet) == 0 {
+		return nil, errors.Errorf("delta set was empty -- update would have no effect")
+	}
+
+	for key, value := range deltaSet {
+		if _, _, err := vi.verifyDeltaSetElement(key, value); err != nil {
+			return nil, err
+		}
 	}
 
 	fullProposedConfig := vi.computeUpdateResult(deltaSet)
@@ -155,7 +263,30 @@ func (vi *ValidatorImpl) authorizeUpdate(configUpdateEnv *cb.ConfigUpdateEnvelop
 		return nil, errors.Wrapf(err, "full config did not verify")
 	}
 
-	return fullProposedConfig, nil
+	channelGroup, err := configMapToConfig(fullProposedConfig, vi.namespace)
+	if err != nil {
+		return nil, errors.Errorf("could not turn configMap back to channelGroup: %s", err)
+	}
+
+	return &cb.Config{
+		Sequence:     vi.sequence + 1,
+		ChannelGroup: channelGroup,
+	}, nil
+}
+
+// modPolicyPath returns the absolute path of the mod_policy of an item,
+// resolving relative mod_policies the same way policyForItem does.
+func modPolicyPath(item comparable) string {
+	modPolicy := item.modPolicy()
+	if modPolicy == "" || modPolicy[0] == policies.PathSeparator[0] {
+		return modPolicy
+	}
+
+	path := append([]string{}, item.path...)
+	if item.ConfigGroup != nil {
+		path = append(path, item.key)
+	}
+	return pathSeparator + strings.Join(append(path, modPolicy), pathSeparator)
 }
 
 func (vi *ValidatorImpl) policyForItem(item comparable) (policies.Policy, bool) {
@@ -428,3 +428,118 @@ func TestConstructionErrors(t *testing.T) {
 		require.EqualError(t, err, "bad channel ID: channel ID illegal, cannot be longer than 249")
 	})
 }
+
+func TestModifiedElements(t *testing.T) {
+	pm := defaultPolicyManager()
+	vi, err := NewValidatorImpl(
+		defaultChannel,
+		makeConfig(
+			makeConfigPair("foo", "foo", 0, []byte("foo")),
+			makeConfigPair("bar", "/foonamespace/Admins", 0, []byte("bar")),
+		),
+		"foonamespace",
+		pm)
+	require.NoError(t, err)
+
+	// The policy is not evaluated, so a failing policy must not matter
+	fakePolicy := &mockpolicies.Policy{}
+	fakePolicy.EvaluateSignedDataReturns(fmt.Errorf("err"))
+	pm.GetPolicyReturns(fakePolicy, true)
+
+	newConfig := makeConfigUpdateEnvelope(defaultChannel, makeConfigSet(), makeConfigSet(
+		makeConfigPair("foo", "foo", 1, []byte("foo")),
+		makeConfigPair("bar", "/foonamespace/Admins", 1, []byte("bar")),
+		makeConfigPair("baz", "foo", 0, []byte("baz")),
+	))
+	configUpdateEnv, err := protoutil.EnvelopeToConfigUpdate(newConfig)
+	require.NoError(t, err)
+
+	elements, err := vi.ModifiedElements(configUpdateEnv)
+	require.NoError(t, err)
+	require.Len(t, elements, 2)
+	require.Equal(t, "[Value]  /foonamespace/bar", elements[0].Key)
+	require.Equal(t, "/foonamespace/Admins", elements[0].ModPolicy)
+	require.Equal(t, "[Value]  /foonamespace/foo", elements[1].Key)
+	require.Equal(t, "/foonamespace/foo", elements[1].ModPolicy)
+	require.Equal(t, fakePolicy, elements[1].Policy)
+	require.Equal(t, 0, fakePolicy.EvaluateSignedDataCallCount())
+
+	t.Run("Version skip", func(t *testing.T) {
+		newConfig := makeConfigUpdateEnvelope(defaultChannel, makeConfigSet(), makeConfigSet(makeConfigPair("foo", "foo", 2, []byte("foo"))))
+		configUpdateEnv, err := protoutil.EnvelopeToConfigUpdate(newConfig)
+		require.NoError(t, err)
+		_, err = vi.ModifiedElements(configUpdateEnv)
+		require.EqualError(t, err, "attempt to set key [Value]  /foonamespace/foo to version 2, but key is at version 0")
+	})
+
+	t.Run("Empty update", func(t *testing.T) {
+		newConfig := makeConfigUpdateEnvelope(defaultChannel, makeConfigSet(), makeConfigSet())
+		configUpdateEnv, err := protoutil.EnvelopeToConfigUpdate(newConfig)
+		require.NoError(t, err)
+		_, err = vi.ModifiedElements(configUpdateEnv)
+		require.EqualError(t, err, "delta set was empty -- update would have no effect")
+	})
+}
+
+func TestProposedConfig(t *testing.T) {
+	vi, err := NewValidatorImpl(
+		defaultChannel,
+		makeConfig(
+			makeConfigPair("foo", "foo", 0, []byte("foo")),
+			makeConfigPair("bar", "bar", 0, []byte("bar")),
+		),
+		"foonamespace",
+		nil)
+	require.NoError(t, err)
+
+	newConfig := makeConfigUpdateEnvelope(defaultChannel, makeConfigSet(), makeConfigSet(
+		makeConfigPair("foo", "foo", 1, []byte("updated")),
+	))
+	configUpdateEnv, err := protoutil.EnvelopeToConfigUpdate(newConfig)
+	require.NoError(t, err)
+
+	config, err := vi.ProposedConfig(configUpdateEnv)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), config.Sequence)
+	require.Len(t, config.ChannelGroup.Values, 2)
+	require.Equal(t, []byte("updated"), config.ChannelGroup.Values["foo"].Value)
+	require.Equal(t, []byte("bar"), config.ChannelGroup.Values["bar"].Value)
+
+	t.Run("Version skip", func(t *testing.T) {
+		newConfig := makeConfigUpdateEnvelope(defaultChannel, makeConfigSet(), makeConfigSet(makeConfigPair("foo", "foo", 2, []byte("foo"))))
+		configUpdateEnv, err := protoutil.EnvelopeToConfigUpdate(newConfig)
+		require.NoError(t, err)
+		_, err = vi.ProposedConfig(configUpdateEnv)
+		require.EqualError(t, err, "attempt to set key [Value]  /foonamespace/foo to version 2, but key is at version 0")
+	})
+
+	t.Run("Empty update", func(t *testing.T) {
+		newConfig := makeConfigUpdateEnvelope(defaultChannel, makeConfigSet(), makeConfigSet())
+		configUpdateEnv, err := protoutil.EnvelopeToConfigUpdate(newConfig)
+		require.NoError(t, err)
+		_, err = vi.ProposedConfig(configUpdateEnv)
+		require.EqualError(t, err, "delta set was empty -- update would have no effect")
+	})
+}
+
+func TestModPolicyPath(t *testing.T) {
+	require.Equal(t, "/Channel/Admins", modPolicyPath(comparable{
+		key:         "Channel",
+		ConfigGroup: &cb.ConfigGroup{ModPolicy: "Admins"},
+	}))
+	require.Equal(t, "/Channel/Application/Org1/Admins", modPolicyPath(comparable{
+		key:         "Org1",
+		path:        []string{"Channel", "Application"},
+		ConfigGroup: &cb.ConfigGroup{ModPolicy: "Admins"},
+	}))
+	require.Equal(t, "/Channel/Application/Admins", modPolicyPath(comparable{
+		key:         "ACLs",
+		path:        []string{"Channel", "Application"},
+		ConfigValue: &cb.ConfigValue{ModPolicy: "Admins"},
+	}))
+	require.Equal(t, "/Channel/Orderer/Admins", modPolicyPath(comparable{
+		key:         "Capabilities",
+		path:        []string{"Channel", "Application"},
+		ConfigValue: &cb.ConfigValue{ModPolicy: "/Channel/Orderer/Admins"},
+	}))
+}
@@ -39,6 +39,11 @@ func (e VSCCEndorsementPolicyError) Error() string {
 	return e.Err.Error()
 }
 
+// Unwrap returns the error which lead to the failure
+func (e VSCCEndorsementPolicyError) Unwrap() error {
+	return e.Err
+}
+
 // VSCCExecutionFailureError error to indicate
 // failure during attempt of executing VSCC
 // endorsement policy check
@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expression
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// fabricCAAttributesOID is the OID of the certificate extension in which
+// Fabric CA stores the attributes of an identity.
+var fabricCAAttributesOID = asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 7, 8, 1}
+
+// condition is a requirement on the certificate of an identity.
+type condition interface {
+	// check returns why the certificate does not meet the condition, if it doesn't.
+	check(cert *x509.Certificate) error
+	String() string
+}
+
+type attrCondition struct {
+	name  string
+	value *string
+}
+
+func (c *attrCondition) check(cert *x509.Certificate) error {
+	attrs, err := fabricCAAttributes(cert)
+	if err != nil {
+		return err
+	}
+	value, ok := attrs[c.name]
+	switch {
+	case !ok:
+		return errors.Errorf("certificate has no attribute %s", c.name)
+	case c.value != nil && value != *c.value:
+		return errors.Errorf("attribute %s of certificate is %s, not %s", c.name, quote(value), quote(*c.value))
+	}
+	return nil
+}
+
+func (c *attrCondition) String() string {
+	if c.value == nil {
+		return fmt.Sprintf("Attr(%s)", quote(c.name))
+	}
+	return fmt.Sprintf("Attr(%s, %s)", quote(c.name), quote(*c.value))
+}
+
+// fabricCAAttributes returns the attributes Fabric CA embedded in the certificate.
+func fabricCAAttributes(cert *x509.Certificate) (map[string]string, error) {
+	for _, ext := range cert.Extensions {
+		if !ext.Id.Equal(fabricCAAttributesOID) {
+			continue
+		}
+		attrs := struct {
+			Attrs map[string]string `json:"attrs"`
+		}{}
+		if err := json.Unmarshal(ext.Value, &attrs); err != nil {
+			return nil, errors.Wrap(err, "invalid attributes extension")
+		}
+		return attrs.Attrs, nil
+	}
+	return nil, nil
+}
+
+type ouCondition struct {
+	ou string
+}
+
+func (c *ouCondition) check(cert *x509.Certificate) error {
+	for _, ou := range cert.Subject.OrganizationalUnit {
+		if ou == c.ou {
+			return nil
+		}
+	}
+	return errors.Errorf("certificate subject has no OU %s", c.ou)
+}
+
+func (c *ouCondition) String() string {
+	return fmt.Sprintf("OU(%s)", quote(c.ou))
+}
+
+type cnCondition struct {
+	cn string
+}
+
+func (c *cnCondition) check(cert *x509.Certificate) error {
+	if cert.Subject.CommonName != c.cn {
+		return errors.Errorf("certificate subject CN is %s, not %s", cert.Subject.CommonName, c.cn)
+	}
+	return nil
+}
+
+func (c *cnCondition) String() string {
+	return fmt.Sprintf("CN(%s)", quote(c.cn))
+}
+
+type issuedCondition struct {
+	after bool
+	time  time.Time
+}
+
+func (c *issuedCondition) check(cert *x509.Certificate) error {
+	switch {
+	case c.after && !cert.NotBefore.After(c.time):
+		return errors.Errorf("certificate was issued at %s, not after %s", cert.NotBefore.UTC().Format(time.RFC3339), c.time.Format(time.RFC3339))
+	case !c.after && !cert.NotBefore.Before(c.time):
+		return errors.Errorf("certificate was issued at %s, not before %s", cert.NotBefore.UTC().Format(time.RFC3339), c.time.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (c *issuedCondition) String() string {
+	if c.after {
+		return fmt.Sprintf("IssuedAfter('%s')", c.time.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("IssuedBefore('%s')", c.time.Format(time.RFC3339))
+}
@@ -0,0 +1,115 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expression
+
+import (
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/pkg/errors"
+)
+
+// maxConvertedCombinations bounds the number of combinations of rules a
+// weighted threshold is expanded into when converted to a signature policy.
+const maxConvertedCombinations = 256
+
+// SignaturePolicy converts the expression to an equivalent signature policy.
+// A weighted threshold is converted to the combinations of its rules whose
+// weights reach the threshold. Conditions on certificates have no equivalent
+// in signature policies, so expressions using them cannot be converted.
+func (e *Expression) SignaturePolicy() (*cb.SignaturePolicyEnvelope, error) {
+	c := &converter{indices: map[string]int32{}}
+	rule, err := e.rule.convert(c)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "expression %s cannot be converted to a signature policy", quote(e.String()))
+	}
+	return &cb.SignaturePolicyEnvelope{
+		Version:    0,
+		Rule:       rule,
+		Identities: c.principals,
+	}, nil
+}
+
+// converter collects the distinct principals of a converted expression.
+type converter struct {
+	principals []*mb.MSPPrincipal
+	indices    map[string]int32
+}
+
+func (c *converter) index(principal *mb.MSPPrincipal) int32 {
+	key := proto.CompactTextString(principal)
+	if i, ok := c.indices[key]; ok {
+		return i
+	}
+	i := int32(len(c.principals))
+	c.principals = append(c.principals, principal)
+	c.indices[key] = i
+	return i
+}
+
+func (t *threshold) convert(c *converter) (*cb.SignaturePolicy, error) {
+	rules := make([]*cb.SignaturePolicy, len(t.rules))
+	for i, r := range t.rules {
+		sp, err := r.convert(c)
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = sp
+	}
+
+	weighted := false
+	for i := range t.rules {
+		weighted = weighted || t.weight(i) != 1
+	}
+	if !weighted {
+		return policydsl.NOutOf(int32(t.n), rules), nil
+	}
+
+	// the minimal combinations of rules whose weights reach the threshold,
+	// in the order of the rules
+	var combinations []*cb.SignaturePolicy
+	var combine func(start, weight, lightest int, chosen []*cb.SignaturePolicy) error
+	combine = func(start, weight, lightest int, chosen []*cb.SignaturePolicy) error {
+		if weight >= t.n {
+			if len(chosen) > 0 && weight-lightest >= t.n {
+				// a subset of the combination already reaches the threshold
+				return nil
+			}
+			if len(combinations) == maxConvertedCombinations {
+				return errors.Errorf("%s expands to more than %d combinations of rules", t, maxConvertedCombinations)
+			}
+			combinations = append(combinations, policydsl.NOutOf(int32(len(chosen)), append([]*cb.SignaturePolicy(nil), chosen...)))
+			return nil
+		}
+		for i := start; i < len(rules); i++ {
+			w, min := t.weight(i), t.weight(i)
+			if len(chosen) > 0 && lightest < min {
+				min = lightest
+			}
+			if err := combine(i+1, weight+w, min, append(chosen, rules[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := combine(0, 0, 0, nil); err != nil {
+		return nil, err
+	}
+
+	if len(combinations) == 1 {
+		return combinations[0], nil
+	}
+	return policydsl.NOutOf(1, combinations), nil
+}
+
+func (p *principal) convert(c *converter) (*cb.SignaturePolicy, error) {
+	if len(p.conditions) != 0 {
+		return nil, errors.Errorf("%s places conditions on the certificate", p)
+	}
+	return policydsl.SignedBy(c.index(p.principal)), nil
+}
@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expression
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func envelope(rule *cb.SignaturePolicy, principals ...*mb.MSPPrincipal) *cb.SignaturePolicyEnvelope {
+	return &cb.SignaturePolicyEnvelope{Rule: rule, Identities: principals}
+}
+
+func rolePrincipal(mspID string, role mb.MSPRole_MSPRoleType) *mb.MSPPrincipal {
+	return &mb.MSPPrincipal{
+		PrincipalClassification: mb.MSPPrincipal_ROLE,
+		Principal:               protoutil.MarshalOrPanic(&mb.MSPRole{MspIdentifier: mspID, Role: role}),
+	}
+}
+
+func TestSignaturePolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   *cb.SignaturePolicyEnvelope
+	}{
+		{
+			name:       "unweighted thresholds",
+			expression: "AND('Org1MSP.member', OR('Org2MSP.peer', 'Org3MSP.admin'))",
+			expected: envelope(
+				policydsl.NOutOf(2, []*cb.SignaturePolicy{
+					policydsl.SignedBy(0),
+					policydsl.NOutOf(1, []*cb.SignaturePolicy{policydsl.SignedBy(1), policydsl.SignedBy(2)}),
+				}),
+				rolePrincipal("Org1MSP", mb.MSPRole_MEMBER),
+				rolePrincipal("Org2MSP", mb.MSPRole_PEER),
+				rolePrincipal("Org3MSP", mb.MSPRole_ADMIN),
+			),
+		},
+		{
+			name:       "repeated principals",
+			expression: "OutOf(2, 'Org1MSP.member', 'Org1MSP.member')",
+			expected: envelope(
+				policydsl.NOutOf(2, []*cb.SignaturePolicy{policydsl.SignedBy(0), policydsl.SignedBy(0)}),
+				rolePrincipal("Org1MSP", mb.MSPRole_MEMBER),
+			),
+		},
+		{
+			name:       "weighted threshold",
+			expression: "OutOf(2, Weight(2, 'Org1MSP.admin'), 'Org2MSP.admin', 'Org3MSP.admin')",
+			expected: envelope(
+				policydsl.NOutOf(1, []*cb.SignaturePolicy{
+					policydsl.NOutOf(1, []*cb.SignaturePolicy{policydsl.SignedBy(0)}),
+					policydsl.NOutOf(2, []*cb.SignaturePolicy{policydsl.SignedBy(1), policydsl.SignedBy(2)}),
+				}),
+				rolePrincipal("Org1MSP", mb.MSPRole_ADMIN),
+				rolePrincipal("Org2MSP", mb.MSPRole_ADMIN),
+				rolePrincipal("Org3MSP", mb.MSPRole_ADMIN),
+			),
+		},
+		{
+			name:       "weighted threshold reached by a single combination",
+			expression: "OutOf(3, Weight(2, 'Org1MSP.admin'), 'Org2MSP.admin')",
+			expected: envelope(
+				policydsl.NOutOf(2, []*cb.SignaturePolicy{policydsl.SignedBy(0), policydsl.SignedBy(1)}),
+				rolePrincipal("Org1MSP", mb.MSPRole_ADMIN),
+				rolePrincipal("Org2MSP", mb.MSPRole_ADMIN),
+			),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := Parse(tt.expression)
+			require.NoError(t, err)
+			spe, err := e.SignaturePolicy()
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tt.expected, spe), "expected %v, got %v", tt.expected, spe)
+		})
+	}
+}
+
+func TestSignaturePolicyEquivalence(t *testing.T) {
+	admin1 := newIdentity(t, "Org1MSP", mb.MSPRole_ADMIN, certOptions{cn: "admin1"})
+	admin2 := newIdentity(t, "Org2MSP", mb.MSPRole_ADMIN, certOptions{cn: "admin2"})
+	admin3 := newIdentity(t, "Org3MSP", mb.MSPRole_ADMIN, certOptions{cn: "admin3"})
+	deserializer := mockDeserializer{}
+	for _, id := range []*mockIdentity{admin1, admin2, admin3} {
+		deserializer[string(id.serialized)] = id
+	}
+
+	expressions := []string{
+		"OutOf(2, Weight(2, 'Org1MSP.admin'), 'Org2MSP.admin', 'Org3MSP.admin')",
+		"OutOf(3, Weight(2, 'Org1MSP.admin'), Weight(2, 'Org2MSP.member'), 'Org3MSP.admin')",
+		"AND('Org1MSP.member', OutOf(2, 'Org2MSP.admin', Weight(2, 'Org3MSP.admin')))",
+	}
+	identitySets := [][]msp.Identity{
+		nil,
+		{admin1},
+		{admin2},
+		{admin3},
+		{admin1, admin2},
+		{admin2, admin3},
+		{admin1, admin3},
+		{admin1, admin2, admin3},
+	}
+
+	for _, expression := range expressions {
+		policy := newPolicy(t, expression, deserializer)
+		spe, err := policy.(policies.Converter).Convert()
+		require.NoError(t, err)
+		converted, _, err := cauthdsl.NewPolicyProvider(deserializer).NewPolicy(protoutil.MarshalOrPanic(spe))
+		require.NoError(t, err)
+
+		for _, identities := range identitySets {
+			var names []string
+			for _, id := range identities {
+				names = append(names, id.GetMSPIdentifier())
+			}
+			desc := fmt.Sprintf("%s with [%s]", expression, strings.Join(names, ", "))
+			require.Equal(t, policy.EvaluateIdentities(identities) == nil, converted.EvaluateIdentities(identities) == nil, desc)
+		}
+	}
+}
+
+func TestSignaturePolicyErrors(t *testing.T) {
+	e, err := Parse("AND('Org1MSP.member', Identity('Org2MSP.admin', OU('ops')))")
+	require.NoError(t, err)
+	_, err = e.SignaturePolicy()
+	require.EqualError(t, err, "expression \"AND('Org1MSP.member', Identity('Org2MSP.admin', OU('ops')))\" cannot be converted to a signature policy: Identity('Org2MSP.admin', OU('ops')) places conditions on the certificate")
+
+	var rules []string
+	for i := 0; i < 20; i++ {
+		rules = append(rules, fmt.Sprintf("Weight(2, 'Org%dMSP.admin')", i))
+	}
+	e, err = Parse(fmt.Sprintf("OutOf(20, %s)", strings.Join(rules, ", ")))
+	require.NoError(t, err)
+	_, err = e.SignaturePolicy()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expands to more than 256 combinations of rules")
+}
@@ -0,0 +1,422 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expression
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/golang/protobuf/proto"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/pkg/errors"
+)
+
+// Expression is a parsed policy expression.
+//
+// The expression language extends the signature policy language of configtx.yaml:
+//
+//	'Org1MSP.member'                  is satisfied by a member of Org1MSP; the roles are
+//	                                  member, admin, client, peer and orderer
+//	Identity('Org1MSP.admin', c...)   is satisfied by an admin of Org1MSP whose certificate
+//	                                  meets all the conditions c
+//	AND(e...), OR(e...)               are satisfied by all or any of the expressions e
+//	OutOf(n, e...)                    is satisfied when n of the expressions e are satisfied
+//	Weight(w, e)                      counts w times towards the threshold of the enclosing OutOf
+//
+// The conditions on certificates are:
+//
+//	Attr('name', 'value')  the Fabric CA attribute name has the given value
+//	Attr('name')           the Fabric CA attribute name is present
+//	OU('unit')             the subject has the given organizational unit
+//	CN('name')             the subject has the given common name
+//	IssuedAfter('time')    the certificate is valid from after the given RFC 3339 time
+//	IssuedBefore('time')   the certificate is valid from before the given RFC 3339 time
+//
+// Time conditions refer to the certificate rather than to the time of the
+// evaluation, so that all peers reach the same decision regardless of when
+// they validate a transaction.
+//
+// As with signature policies, each identity satisfies at most one principal.
+type Expression struct {
+	rule rule
+}
+
+// Parse parses the given policy expression.
+func Parse(expression string) (*Expression, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	r, err := p.parseRule()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.next(); t.kind != tokenEOF {
+		return nil, errors.Errorf("unexpected %s at offset %d after the end of the expression", t, t.offset)
+	}
+	return &Expression{rule: r}, nil
+}
+
+// String returns the expression in canonical form.
+func (e *Expression) String() string {
+	return e.rule.String()
+}
+
+// MSPIDs returns the sorted IDs of the MSPs referenced by the expression.
+func (e *Expression) MSPIDs() []string {
+	ids := map[string]struct{}{}
+	e.rule.mspIDs(ids)
+	var res []string
+	for id := range ids {
+		res = append(res, id)
+	}
+	sort.Strings(res)
+	return res
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenString:
+		return fmt.Sprintf("string %s", quote(t.text))
+	default:
+		return fmt.Sprintf("'%s'", t.text)
+	}
+}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(s); {
+		c := rune(s[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", offset: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", offset: i})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", offset: i})
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(s[i+1:], s[i])
+			if end < 0 {
+				return nil, errors.Errorf("unterminated string at offset %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: s[i+1 : i+1+end], offset: i})
+			i += end + 2
+		case c >= '0' && c <= '9':
+			j := i
+			for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenInt, text: s[i:j], offset: i})
+			i = j
+		case unicode.IsLetter(c):
+			j := i
+			for j < len(s) && (unicode.IsLetter(rune(s[j])) || unicode.IsDigit(rune(s[j])) || s[j] == '_') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: s[i:j], offset: i})
+			i = j
+		default:
+			return nil, errors.Errorf("unexpected character %q at offset %d", c, i)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, offset: len(s)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) expect(kind tokenKind, what string) (token, error) {
+	t := p.next()
+	if t.kind != kind {
+		return t, errors.Errorf("expected %s at offset %d, found %s", what, t.offset, t)
+	}
+	return t, nil
+}
+
+// parseRule parses a principal or a combination of rules.
+func (p *parser) parseRule() (rule, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenString:
+		return newPrincipal(t)
+	case tokenIdent:
+	default:
+		return nil, errors.Errorf("expected a principal or a function at offset %d, found %s", t.offset, t)
+	}
+
+	if _, err := p.expect(tokenLParen, "'('"); err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(t.text) {
+	case "and", "or":
+		rules, err := p.parseRules()
+		if err != nil {
+			return nil, err
+		}
+		th := &threshold{op: strings.ToUpper(t.text), n: 1, rules: rules}
+		if th.op == "AND" {
+			th.n = len(rules)
+		}
+		return th, nil
+	case "outof":
+		n, err := p.parseInt()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenComma, "','"); err != nil {
+			return nil, err
+		}
+		th := &threshold{op: "OutOf", n: n}
+		if err := p.parseWeightedRules(th); err != nil {
+			return nil, err
+		}
+		total := 0
+		for _, w := range th.weights {
+			total += w
+		}
+		if n < 1 || n > total {
+			return nil, errors.Errorf("threshold of OutOf at offset %d must be between 1 and %d", t.offset, total)
+		}
+		return th, nil
+	case "identity":
+		st, err := p.expect(tokenString, "a principal")
+		if err != nil {
+			return nil, err
+		}
+		pr, err := newPrincipal(st)
+		if err != nil {
+			return nil, err
+		}
+		for p.peek().kind == tokenComma {
+			p.next()
+			c, err := p.parseCondition()
+			if err != nil {
+				return nil, err
+			}
+			pr.conditions = append(pr.conditions, c)
+		}
+		if _, err := p.expect(tokenRParen, "')'"); err != nil {
+			return nil, err
+		}
+		return pr, nil
+	case "weight":
+		return nil, errors.Errorf("Weight at offset %d is only allowed as an argument of OutOf", t.offset)
+	default:
+		return nil, errors.Errorf("unknown function %s at offset %d", t.text, t.offset)
+	}
+}
+
+// parseRules parses a non-empty list of rules up to the closing parenthesis.
+func (p *parser) parseRules() ([]rule, error) {
+	var rules []rule
+	for {
+		r, err := p.parseRule()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+		t := p.next()
+		switch t.kind {
+		case tokenComma:
+		case tokenRParen:
+			return rules, nil
+		default:
+			return nil, errors.Errorf("expected ',' or ')' at offset %d, found %s", t.offset, t)
+		}
+	}
+}
+
+// parseWeightedRules parses the rules of an OutOf up to the closing
+// parenthesis, any of which may be wrapped in a Weight.
+func (p *parser) parseWeightedRules(th *threshold) error {
+	for {
+		weight, weighted := 1, false
+		if t := p.peek(); t.kind == tokenIdent && strings.EqualFold(t.text, "weight") {
+			p.next()
+			weighted = true
+			if _, err := p.expect(tokenLParen, "'('"); err != nil {
+				return err
+			}
+			w, err := p.parseInt()
+			if err != nil {
+				return err
+			}
+			if w < 1 {
+				return errors.Errorf("weight at offset %d must be positive", t.offset)
+			}
+			if _, err := p.expect(tokenComma, "','"); err != nil {
+				return err
+			}
+			weight = w
+		}
+		r, err := p.parseRule()
+		if err != nil {
+			return err
+		}
+		if weighted {
+			if _, err := p.expect(tokenRParen, "')'"); err != nil {
+				return err
+			}
+		}
+		th.rules = append(th.rules, r)
+		th.weights = append(th.weights, weight)
+
+		t := p.next()
+		switch t.kind {
+		case tokenComma:
+		case tokenRParen:
+			return nil
+		default:
+			return errors.Errorf("expected ',' or ')' at offset %d, found %s", t.offset, t)
+		}
+	}
+}
+
+func (p *parser) parseInt() (int, error) {
+	t, err := p.expect(tokenInt, "a number")
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(t.text)
+	if err != nil {
+		return 0, errors.Errorf("invalid number %s at offset %d", t.text, t.offset)
+	}
+	return n, nil
+}
+
+func (p *parser) parseCondition() (condition, error) {
+	t, err := p.expect(tokenIdent, "a condition")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokenLParen, "'('"); err != nil {
+		return nil, err
+	}
+	arg, err := p.expect(tokenString, "a string")
+	if err != nil {
+		return nil, err
+	}
+
+	var c condition
+	switch strings.ToLower(t.text) {
+	case "attr":
+		a := &attrCondition{name: arg.text}
+		if p.peek().kind == tokenComma {
+			p.next()
+			value, err := p.expect(tokenString, "a string")
+			if err != nil {
+				return nil, err
+			}
+			a.value = &value.text
+		}
+		c = a
+	case "ou":
+		c = &ouCondition{ou: arg.text}
+	case "cn":
+		c = &cnCondition{cn: arg.text}
+	case "issuedafter", "issuedbefore":
+		when, err := time.Parse(time.RFC3339, arg.text)
+		if err != nil {
+			return nil, errors.Errorf("invalid time %s at offset %d, expected RFC 3339 format such as 2006-01-02T15:04:05Z", quote(arg.text), arg.offset)
+		}
+		c = &issuedCondition{after: strings.EqualFold(t.text, "issuedafter"), time: when}
+	default:
+		return nil, errors.Errorf("unknown condition %s at offset %d", t.text, t.offset)
+	}
+
+	if _, err := p.expect(tokenRParen, "')'"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+var principalRegex = regexp.MustCompile(`^([[:alnum:].-]+)[.](member|admin|client|peer|orderer)$`)
+
+var roles = map[string]mb.MSPRole_MSPRoleType{
+	"member":  mb.MSPRole_MEMBER,
+	"admin":   mb.MSPRole_ADMIN,
+	"client":  mb.MSPRole_CLIENT,
+	"peer":    mb.MSPRole_PEER,
+	"orderer": mb.MSPRole_ORDERER,
+}
+
+func newPrincipal(t token) (*principal, error) {
+	match := principalRegex.FindStringSubmatch(t.text)
+	if match == nil {
+		return nil, errors.Errorf("invalid principal %s at offset %d, expected MSPID.role with role one of member, admin, client, peer or orderer", quote(t.text), t.offset)
+	}
+	role := &mb.MSPRole{
+		MspIdentifier: match[1],
+		Role:          roles[match[2]],
+	}
+	roleBytes, err := proto.Marshal(role)
+	if err != nil {
+		return nil, err
+	}
+	return &principal{
+		name: t.text,
+		principal: &mb.MSPPrincipal{
+			PrincipalClassification: mb.MSPPrincipal_ROLE,
+			Principal:               roleBytes,
+		},
+		mspID: match[1],
+	}, nil
+}
+
+// quote quotes s with single quotes, unless s contains any.
+func quote(s string) string {
+	if strings.ContainsRune(s, '\'') {
+		return `"` + s + `"`
+	}
+	return "'" + s + "'"
+}
@@ -0,0 +1,100 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		expression string
+		canonical  string
+	}{
+		{
+			expression: "'Org1MSP.member'",
+			canonical:  "'Org1MSP.member'",
+		},
+		{
+			expression: `or ( "Org1MSP.peer",'Org2MSP.peer' )`,
+			canonical:  "OR('Org1MSP.peer', 'Org2MSP.peer')",
+		},
+		{
+			expression: "AND('Org1MSP.admin', OR('Org2MSP.client', 'Org3MSP.orderer'))",
+			canonical:  "AND('Org1MSP.admin', OR('Org2MSP.client', 'Org3MSP.orderer'))",
+		},
+		{
+			expression: "OutOf(3, Weight(2, 'Org1MSP.member'), 'Org2MSP.member', weight(1, 'Org3MSP.member'))",
+			canonical:  "OutOf(3, Weight(2, 'Org1MSP.member'), 'Org2MSP.member', 'Org3MSP.member')",
+		},
+		{
+			expression: "Identity('Org1MSP.admin', Attr('dept', 'audit'), Attr('approver'), OU('finance'), CN('alice'), IssuedAfter('2026-01-01T00:00:00Z'), IssuedBefore('2027-01-01T00:00:00+01:00'))",
+			canonical:  "Identity('Org1MSP.admin', Attr('dept', 'audit'), Attr('approver'), OU('finance'), CN('alice'), IssuedAfter('2026-01-01T00:00:00Z'), IssuedBefore('2027-01-01T00:00:00+01:00'))",
+		},
+		{
+			expression: "Identity('Org1MSP.member')",
+			canonical:  "'Org1MSP.member'",
+		},
+		{
+			expression: `Identity('Org-1.example.com.member', CN("o'neil"))`,
+			canonical:  `Identity('Org-1.example.com.member', CN("o'neil"))`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			e, err := Parse(tt.expression)
+			require.NoError(t, err)
+			require.Equal(t, tt.canonical, e.String())
+
+			reparsed, err := Parse(e.String())
+			require.NoError(t, err)
+			require.Equal(t, tt.canonical, reparsed.String())
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		err        string
+	}{
+		{"", "expected a principal or a function at offset 0, found end of expression"},
+		{"'Org1MSP.member", "unterminated string at offset 0"},
+		{"'Org1MSP.superuser'", "invalid principal 'Org1MSP.superuser' at offset 0, expected MSPID.role with role one of member, admin, client, peer or orderer"},
+		{"'Org1MSP.member' 'Org2MSP.member'", "unexpected string 'Org2MSP.member' at offset 17 after the end of the expression"},
+		{"OR()", "expected a principal or a function at offset 3, found ')'"},
+		{"OR('Org1MSP.member' 'Org2MSP.member')", "expected ',' or ')' at offset 20, found string 'Org2MSP.member'"},
+		{"XOR('Org1MSP.member')", "unknown function XOR at offset 0"},
+		{"OutOf(3, 'Org1MSP.member', 'Org2MSP.member')", "threshold of OutOf at offset 0 must be between 1 and 2"},
+		{"OutOf(0, 'Org1MSP.member')", "threshold of OutOf at offset 0 must be between 1 and 1"},
+		{"OutOf('Org1MSP.member')", "expected a number at offset 6, found string 'Org1MSP.member'"},
+		{"OutOf(1, Weight(0, 'Org1MSP.member'))", "weight at offset 9 must be positive"},
+		{"OutOf(1, Weight(2, 'Org1MSP.member', 'Org2MSP.member'))", "expected ')' at offset 35, found ','"},
+		{"OR(Weight(2, 'Org1MSP.member'))", "Weight at offset 3 is only allowed as an argument of OutOf"},
+		{"Identity(OR('Org1MSP.member'))", "expected a principal at offset 9, found 'OR'"},
+		{"Identity('Org1MSP.member', Role('admin'))", "unknown condition Role at offset 27"},
+		{"Identity('Org1MSP.member', Attr())", "expected a string at offset 32, found ')'"},
+		{"Identity('Org1MSP.member', IssuedAfter('2026-01-01'))", "invalid time '2026-01-01' at offset 39, expected RFC 3339 format such as 2006-01-02T15:04:05Z"},
+		{"OR('Org1MSP.member') & 'Org2MSP.member'", "unexpected character '&' at offset 21"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := Parse(tt.expression)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestMSPIDs(t *testing.T) {
+	e, err := Parse("AND(OutOf(1, 'Org2MSP.member', Identity('Org1MSP.admin', Attr('dept', 'audit'))), 'Org2MSP.peer')")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Org1MSP", "Org2MSP"}, e.MSPIDs())
+}
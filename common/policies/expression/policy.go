@@ -0,0 +1,103 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expression
+
+import (
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// PolicyType is the type of policies whose value is an ExpressionPolicy.
+// cb.Policy_PolicyType does not assign values from 10000, which are used for
+// the policy types defined in this repository so that they cannot collide
+// with the types added to fabric-protos.
+const PolicyType int32 = 10000
+
+// NewConfigPolicy returns a policy of the expression type for the given
+// expression, or an error if the expression is invalid.
+func NewConfigPolicy(expression string) (*cb.Policy, error) {
+	e, err := Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &cb.Policy{
+		Type:  PolicyType,
+		Value: protoutil.MarshalOrPanic(&ExpressionPolicy{Expression: e.String()}),
+	}, nil
+}
+
+type provider struct {
+	deserializer msp.IdentityDeserializer
+}
+
+// NewPolicyProvider provides a policy generator for expression type policies
+func NewPolicyProvider(deserializer msp.IdentityDeserializer) policies.Provider {
+	return &provider{
+		deserializer: deserializer,
+	}
+}
+
+// NewPolicy creates a new policy based on the policy bytes
+func (pr *provider) NewPolicy(data []byte) (policies.Policy, proto.Message, error) {
+	ep := &ExpressionPolicy{}
+	if err := proto.Unmarshal(data, ep); err != nil {
+		return nil, nil, errors.Wrap(err, "error unmarshaling to ExpressionPolicy")
+	}
+
+	e, err := Parse(ep.Expression)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid expression %s", quote(ep.Expression))
+	}
+
+	return &policy{
+		expression:   e,
+		deserializer: pr.deserializer,
+	}, ep, nil
+}
+
+type policy struct {
+	expression   *Expression
+	deserializer msp.IdentityDeserializer
+}
+
+// EvaluateSignedData takes a set of SignedData and evaluates whether
+// 1) the signatures are valid over the related message
+// 2) the signing identities satisfy the policy
+func (p *policy) EvaluateSignedData(signatureSet []*protoutil.SignedData) error {
+	ids := policies.SignatureSetToValidIdentities(signatureSet, p.deserializer)
+
+	err := p.EvaluateIdentities(ids)
+	if e, ok := err.(*policies.EvaluationError); ok {
+		return policies.NewEvaluationError(e.Error(), func() *policies.EvaluationTrace {
+			trace := *e.Trace()
+			trace.DiscardedSignatures = policies.DiscardedSignatures(signatureSet, p.deserializer)
+			return &trace
+		})
+	}
+	return err
+}
+
+// EvaluateIdentities takes an array of identities and evaluates whether
+// they satisfy the policy
+func (p *policy) EvaluateIdentities(identities []msp.Identity) error {
+	if !p.expression.rule.evaluate(newEvaluation(identities), make([]bool, len(identities))) {
+		return policies.NewEvaluationError("signature set did not satisfy policy", func() *policies.EvaluationTrace {
+			return p.expression.rule.trace(newEvaluation(identities), make([]bool, len(identities)))
+		})
+	}
+	return nil
+}
+
+// Convert converts the policy to a signature policy, see
+// Expression.SignaturePolicy.
+func (p *policy) Convert() (*cb.SignaturePolicyEnvelope, error) {
+	return p.expression.SignaturePolicy()
+}
@@ -0,0 +1,327 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expression
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type mockIdentity struct {
+	mspID      string
+	role       mb.MSPRole_MSPRoleType
+	serialized []byte
+}
+
+func (id *mockIdentity) Anonymous() bool {
+	return false
+}
+
+func (id *mockIdentity) ExpiresAt() time.Time {
+	return time.Time{}
+}
+
+func (id *mockIdentity) SatisfiesPrincipal(p *mb.MSPPrincipal) error {
+	role := &mb.MSPRole{}
+	if err := proto.Unmarshal(p.Principal, role); err != nil {
+		return err
+	}
+	if role.MspIdentifier != id.mspID {
+		return errors.Errorf("the identity is a member of a different MSP (expected %s, got %s)", role.MspIdentifier, id.mspID)
+	}
+	if role.Role != mb.MSPRole_MEMBER && role.Role != id.role {
+		return errors.Errorf("the identity is not a %s", role.Role)
+	}
+	return nil
+}
+
+func (id *mockIdentity) GetIdentifier() *msp.IdentityIdentifier {
+	return &msp.IdentityIdentifier{Mspid: id.mspID, Id: string(id.serialized)}
+}
+
+func (id *mockIdentity) GetMSPIdentifier() string {
+	return id.mspID
+}
+
+func (id *mockIdentity) Validate() error {
+	return nil
+}
+
+func (id *mockIdentity) GetOrganizationalUnits() []*msp.OUIdentifier {
+	return nil
+}
+
+func (id *mockIdentity) Verify(msg []byte, sig []byte) error {
+	if string(sig) == "invalid" {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+func (id *mockIdentity) Serialize() ([]byte, error) {
+	return id.serialized, nil
+}
+
+type mockDeserializer map[string]*mockIdentity
+
+func (md mockDeserializer) IsWellFormed(_ *mb.SerializedIdentity) error {
+	return nil
+}
+
+func (md mockDeserializer) DeserializeIdentity(serializedIdentity []byte) (msp.Identity, error) {
+	id, ok := md[string(serializedIdentity)]
+	if !ok {
+		return nil, errors.New("unknown identity")
+	}
+	return id, nil
+}
+
+type certOptions struct {
+	cn       string
+	ou       []string
+	attrs    map[string]string
+	issuedAt time.Time
+}
+
+func newIdentity(t *testing.T, mspID string, role mb.MSPRole_MSPRoleType, opts certOptions) *mockIdentity {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	if opts.issuedAt.IsZero() {
+		opts.issuedAt = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			CommonName:         opts.cn,
+			OrganizationalUnit: opts.ou,
+		},
+		NotBefore: opts.issuedAt,
+		NotAfter:  opts.issuedAt.Add(365 * 24 * time.Hour),
+	}
+	if opts.attrs != nil {
+		value, err := json.Marshal(map[string]interface{}{"attrs": opts.attrs})
+		require.NoError(t, err)
+		template.ExtraExtensions = []pkix.Extension{{Id: fabricCAAttributesOID, Value: value}}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return &mockIdentity{
+		mspID: mspID,
+		role:  role,
+		serialized: protoutil.MarshalOrPanic(&mb.SerializedIdentity{
+			Mspid:   mspID,
+			IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		}),
+	}
+}
+
+func newPolicy(t *testing.T, expression string, deserializer msp.IdentityDeserializer) policies.Policy {
+	configPolicy, err := NewConfigPolicy(expression)
+	require.NoError(t, err)
+	require.Equal(t, PolicyType, configPolicy.Type)
+
+	policy, msg, err := NewPolicyProvider(deserializer).NewPolicy(configPolicy.Value)
+	require.NoError(t, err)
+	require.IsType(t, &ExpressionPolicy{}, msg)
+	return policy
+}
+
+func TestEvaluateIdentities(t *testing.T) {
+	auditor := newIdentity(t, "Org1MSP", mb.MSPRole_ADMIN, certOptions{cn: "auditor", ou: []string{"finance"}, attrs: map[string]string{"dept": "audit"}})
+	admin1 := newIdentity(t, "Org1MSP", mb.MSPRole_ADMIN, certOptions{cn: "admin", attrs: map[string]string{"dept": "ops"}})
+	member2 := newIdentity(t, "Org2MSP", mb.MSPRole_CLIENT, certOptions{cn: "user2"})
+	member3 := newIdentity(t, "Org3MSP", mb.MSPRole_PEER, certOptions{cn: "peer3", issuedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+	tests := []struct {
+		name       string
+		expression string
+		identities []msp.Identity
+		satisfied  bool
+	}{
+		{
+			// As with signature policies, principals are matched greedily, so the
+			// auditor is consumed by the first principal it satisfies
+			name:       "two orgs, one of them an auditing admin, matched greedily",
+			expression: "AND(OutOf(2, 'Org1MSP.member', 'Org2MSP.member', 'Org3MSP.member'), Identity('Org1MSP.admin', Attr('dept', 'audit')))",
+			identities: []msp.Identity{member2, auditor},
+			satisfied:  false,
+		},
+		{
+			name:       "two orgs, one of them an auditing admin",
+			expression: "AND(Identity('Org1MSP.admin', Attr('dept', 'audit')), OutOf(2, 'Org1MSP.member', 'Org2MSP.member', 'Org3MSP.member'))",
+			identities: []msp.Identity{admin1, member2, auditor},
+			satisfied:  true,
+		},
+		{
+			name:       "auditing admin of the wrong department",
+			expression: "Identity('Org1MSP.admin', Attr('dept', 'audit'))",
+			identities: []msp.Identity{admin1},
+			satisfied:  false,
+		},
+		{
+			name:       "attribute presence",
+			expression: "Identity('Org1MSP.member', Attr('dept'))",
+			identities: []msp.Identity{admin1},
+			satisfied:  true,
+		},
+		{
+			name:       "missing attributes",
+			expression: "Identity('Org2MSP.member', Attr('dept'))",
+			identities: []msp.Identity{member2},
+			satisfied:  false,
+		},
+		{
+			name:       "subject conditions",
+			expression: "Identity('Org1MSP.member', OU('finance'), CN('auditor'))",
+			identities: []msp.Identity{admin1, auditor},
+			satisfied:  true,
+		},
+		{
+			name:       "subject conditions not met",
+			expression: "OR(Identity('Org1MSP.member', OU('finance'), CN('admin')), Identity('Org2MSP.member', CN('admin')))",
+			identities: []msp.Identity{admin1, auditor, member2},
+			satisfied:  false,
+		},
+		{
+			name:       "issued after",
+			expression: "Identity('Org3MSP.peer', IssuedAfter('2026-01-01T00:00:00Z'))",
+			identities: []msp.Identity{member3},
+			satisfied:  false,
+		},
+		{
+			name:       "issued before",
+			expression: "Identity('Org3MSP.peer', IssuedBefore('2026-01-01T00:00:00Z'))",
+			identities: []msp.Identity{member3},
+			satisfied:  true,
+		},
+		{
+			name:       "weighted threshold met by a heavy org",
+			expression: "OutOf(2, Weight(2, 'Org1MSP.admin'), 'Org2MSP.member', 'Org3MSP.member')",
+			identities: []msp.Identity{admin1},
+			satisfied:  true,
+		},
+		{
+			name:       "weighted threshold met by light orgs",
+			expression: "OutOf(2, Weight(2, 'Org1MSP.admin'), 'Org2MSP.member', 'Org3MSP.member')",
+			identities: []msp.Identity{member2, member3},
+			satisfied:  true,
+		},
+		{
+			name:       "weighted threshold not met",
+			expression: "OutOf(3, Weight(2, 'Org1MSP.admin'), 'Org2MSP.member', 'Org3MSP.member')",
+			identities: []msp.Identity{member2, member3},
+			satisfied:  false,
+		},
+		{
+			name:       "an identity satisfies a single principal",
+			expression: "AND('Org1MSP.admin', 'Org1MSP.member')",
+			identities: []msp.Identity{admin1},
+			satisfied:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := newPolicy(t, tt.expression, mockDeserializer{})
+			err := policy.EvaluateIdentities(tt.identities)
+			if tt.satisfied {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, "signature set did not satisfy policy")
+			require.NotNil(t, policies.TraceFromError(err))
+		})
+	}
+}
+
+func TestEvaluateSignedData(t *testing.T) {
+	auditor := newIdentity(t, "Org1MSP", mb.MSPRole_ADMIN, certOptions{cn: "auditor", attrs: map[string]string{"dept": "audit"}})
+	member2 := newIdentity(t, "Org2MSP", mb.MSPRole_CLIENT, certOptions{cn: "user2"})
+	deserializer := mockDeserializer{
+		string(auditor.serialized): auditor,
+		string(member2.serialized): member2,
+	}
+
+	policy := newPolicy(t, "AND(Identity('Org1MSP.admin', Attr('dept', 'audit')), 'Org2MSP.member')", deserializer)
+
+	err := policy.EvaluateSignedData([]*protoutil.SignedData{
+		{Identity: auditor.serialized, Data: []byte("data"), Signature: []byte("signature")},
+		{Identity: member2.serialized, Data: []byte("data"), Signature: []byte("signature")},
+	})
+	require.NoError(t, err)
+
+	err = policy.EvaluateSignedData([]*protoutil.SignedData{
+		{Identity: auditor.serialized, Data: []byte("data"), Signature: []byte("signature")},
+		{Identity: member2.serialized, Data: []byte("data"), Signature: []byte("invalid")},
+	})
+	require.EqualError(t, err, "signature set did not satisfy policy")
+	trace := policies.TraceFromError(err)
+	require.Equal(t, "AND", trace.Rule)
+	require.Len(t, trace.DiscardedSignatures, 1)
+	require.Contains(t, trace.DiscardedSignatures[0], "invalid signature")
+	require.True(t, trace.SubPolicies[0].Satisfied)
+	require.Equal(t, "Identity('Org1MSP.admin', Attr('dept', 'audit'))", trace.SubPolicies[0].Rule)
+	require.Equal(t, "Org1MSP (CN=auditor)", trace.SubPolicies[0].MatchedIdentity)
+	require.False(t, trace.SubPolicies[1].Satisfied)
+	require.Equal(t, []string{"Org1MSP (CN=auditor): already used for another principal"}, trace.SubPolicies[1].Mismatches)
+}
+
+func TestTrace(t *testing.T) {
+	admin1 := newIdentity(t, "Org1MSP", mb.MSPRole_ADMIN, certOptions{cn: "admin", attrs: map[string]string{"dept": "ops"}})
+	member2 := newIdentity(t, "Org2MSP", mb.MSPRole_CLIENT, certOptions{cn: "user2"})
+
+	policy := newPolicy(t, "OutOf(3, Weight(2, Identity('Org1MSP.admin', Attr('dept', 'audit'))), 'Org2MSP.member', 'Org3MSP.member')", mockDeserializer{})
+	err := policy.EvaluateIdentities([]msp.Identity{admin1, member2})
+	require.Error(t, err)
+
+	require.Equal(t, `OutOf(3) 1/3 not satisfied
+  Weight(2, Identity('Org1MSP.admin', Attr('dept', 'audit'))) not satisfied
+    - Org1MSP (CN=admin): attribute dept of certificate is 'ops', not 'audit'
+    - Org2MSP (CN=user2): the identity is a member of a different MSP (expected Org1MSP, got Org2MSP)
+  'Org2MSP.member' satisfied by Org2MSP (CN=user2)
+  'Org3MSP.member' not satisfied
+    - Org1MSP (CN=admin): the identity is a member of a different MSP (expected Org3MSP, got Org1MSP)
+    - Org2MSP (CN=user2): already used for another principal`, policies.TraceFromError(err).String())
+}
+
+func TestNewPolicyErrors(t *testing.T) {
+	provider := NewPolicyProvider(mockDeserializer{})
+
+	_, _, err := provider.NewPolicy([]byte{0})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error unmarshaling to ExpressionPolicy")
+
+	_, _, err = provider.NewPolicy(protoutil.MarshalOrPanic(&ExpressionPolicy{Expression: "OR("}))
+	require.EqualError(t, err, "invalid expression 'OR(': expected a principal or a function at offset 3, found end of expression")
+
+	_, err = NewConfigPolicy("OR(")
+	require.EqualError(t, err, "expected a principal or a function at offset 3, found end of expression")
+}
+
+func TestPolicyTypeIsUnused(t *testing.T) {
+	_, ok := cb.Policy_PolicyType_name[PolicyType]
+	require.False(t, ok)
+}
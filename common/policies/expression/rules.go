@@ -0,0 +1,235 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expression
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+)
+
+// rule is a node of a parsed expression.
+type rule interface {
+	// evaluate returns whether the identities which are not used yet satisfy
+	// the rule, marking the identities the rule relies upon as used.
+	evaluate(e *evaluation, used []bool) bool
+	// trace evaluates the rule with the same semantics as evaluate, recording
+	// which principal was satisfied by which identity and why the others were not.
+	trace(e *evaluation, used []bool) *policies.EvaluationTrace
+	// mspIDs adds the IDs of the MSPs the rule references to ids.
+	mspIDs(ids map[string]struct{})
+	// convert converts the rule to a signature policy whose principals are
+	// collected by c.
+	convert(c *converter) (*cb.SignaturePolicy, error)
+	String() string
+}
+
+// evaluation holds the identities a rule is evaluated against, along with
+// their certificates which are parsed on demand.
+type evaluation struct {
+	identities []msp.Identity
+	certs      []*x509.Certificate
+	certErrs   []error
+}
+
+func newEvaluation(identities []msp.Identity) *evaluation {
+	return &evaluation{
+		identities: identities,
+		certs:      make([]*x509.Certificate, len(identities)),
+		certErrs:   make([]error, len(identities)),
+	}
+}
+
+// certificate returns the X.509 certificate of the i-th identity.
+func (e *evaluation) certificate(i int) (*x509.Certificate, error) {
+	if e.certs[i] != nil || e.certErrs[i] != nil {
+		return e.certs[i], e.certErrs[i]
+	}
+
+	e.certErrs[i] = errors.New("not an X.509 identity")
+	serialized, err := e.identities[i].Serialize()
+	if err != nil {
+		e.certErrs[i] = errors.Wrap(err, "failed serializing identity")
+		return nil, e.certErrs[i]
+	}
+	sID := &mb.SerializedIdentity{}
+	if err := proto.Unmarshal(serialized, sID); err != nil {
+		return nil, e.certErrs[i]
+	}
+	block, _ := pem.Decode(sID.IdBytes)
+	if block == nil {
+		return nil, e.certErrs[i]
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, e.certErrs[i]
+	}
+	e.certs[i], e.certErrs[i] = cert, nil
+	return cert, nil
+}
+
+// threshold is satisfied when the sum of the weights of its satisfied rules
+// reaches n. AND and OR are thresholds whose rules all weigh 1.
+type threshold struct {
+	op      string
+	n       int
+	rules   []rule
+	weights []int
+}
+
+func (t *threshold) weight(i int) int {
+	if t.weights == nil {
+		return 1
+	}
+	return t.weights[i]
+}
+
+func (t *threshold) evaluate(e *evaluation, used []bool) bool {
+	satisfied := 0
+	_used := make([]bool, len(used))
+	for i, r := range t.rules {
+		copy(_used, used)
+		if r.evaluate(e, _used) {
+			satisfied += t.weight(i)
+			copy(used, _used)
+		}
+	}
+	return satisfied >= t.n
+}
+
+func (t *threshold) trace(e *evaluation, used []bool) *policies.EvaluationTrace {
+	result := &policies.EvaluationTrace{
+		Rule:     t.op,
+		Required: t.n,
+	}
+	if t.op == "OutOf" {
+		result.Rule = fmt.Sprintf("OutOf(%d)", t.n)
+	}
+	_used := make([]bool, len(used))
+	for i, r := range t.rules {
+		copy(_used, used)
+		sub := r.trace(e, _used)
+		if sub.Satisfied {
+			result.SatisfiedCount += t.weight(i)
+			copy(used, _used)
+		}
+		if t.weight(i) != 1 {
+			sub.Rule = fmt.Sprintf("Weight(%d, %s)", t.weight(i), sub.Rule)
+		}
+		result.SubPolicies = append(result.SubPolicies, sub)
+	}
+	result.Satisfied = result.SatisfiedCount >= result.Required
+	return result
+}
+
+func (t *threshold) mspIDs(ids map[string]struct{}) {
+	for _, r := range t.rules {
+		r.mspIDs(ids)
+	}
+}
+
+func (t *threshold) String() string {
+	var args []string
+	if t.op == "OutOf" {
+		args = append(args, fmt.Sprint(t.n))
+	}
+	for i, r := range t.rules {
+		if t.weight(i) != 1 {
+			args = append(args, fmt.Sprintf("Weight(%d, %s)", t.weight(i), r))
+			continue
+		}
+		args = append(args, r.String())
+	}
+	return fmt.Sprintf("%s(%s)", t.op, strings.Join(args, ", "))
+}
+
+// principal is satisfied by an identity which satisfies the MSP principal
+// and meets all the conditions.
+type principal struct {
+	name       string
+	mspID      string
+	principal  *mb.MSPPrincipal
+	conditions []condition
+}
+
+// check returns why the i-th identity does not satisfy the principal, if it doesn't.
+func (p *principal) check(e *evaluation, i int) error {
+	if err := e.identities[i].SatisfiesPrincipal(p.principal); err != nil {
+		return err
+	}
+	if len(p.conditions) == 0 {
+		return nil
+	}
+	cert, err := e.certificate(i)
+	if err != nil {
+		return err
+	}
+	for _, c := range p.conditions {
+		if err := c.check(cert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *principal) evaluate(e *evaluation, used []bool) bool {
+	for i := range e.identities {
+		if used[i] {
+			continue
+		}
+		if p.check(e, i) == nil {
+			used[i] = true
+			return true
+		}
+	}
+	return false
+}
+
+func (p *principal) trace(e *evaluation, used []bool) *policies.EvaluationTrace {
+	result := &policies.EvaluationTrace{
+		Rule: p.String(),
+	}
+	for i, id := range e.identities {
+		if used[i] {
+			result.Mismatches = append(result.Mismatches, fmt.Sprintf("%s: already used for another principal", policies.IdentityString(id)))
+			continue
+		}
+		if err := p.check(e, i); err != nil {
+			result.Mismatches = append(result.Mismatches, fmt.Sprintf("%s: %s", policies.IdentityString(id), err))
+			continue
+		}
+		used[i] = true
+		result.Satisfied = true
+		result.MatchedIdentity = policies.IdentityString(id)
+		result.Mismatches = nil
+		break
+	}
+	return result
+}
+
+func (p *principal) mspIDs(ids map[string]struct{}) {
+	ids[p.mspID] = struct{}{}
+}
+
+func (p *principal) String() string {
+	if len(p.conditions) == 0 {
+		return quote(p.name)
+	}
+	args := []string{quote(p.name)}
+	for _, c := range p.conditions {
+		args = append(args, c.String())
+	}
+	return fmt.Sprintf("Identity(%s)", strings.Join(args, ", "))
+}
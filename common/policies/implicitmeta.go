@@ -88,8 +88,9 @@ func (imp *ImplicitMetaPolicy) EvaluateSignedData(signatureSet []*protoutil.Sign
 		}
 	}()
 
-	for _, policy := range imp.SubPolicies {
-		if policy.EvaluateSignedData(signatureSet) == nil {
+	errs := make([]error, len(imp.SubPolicies))
+	for i, policy := range imp.SubPolicies {
+		if errs[i] = policy.EvaluateSignedData(signatureSet); errs[i] == nil {
 			remaining--
 			if remaining == 0 {
 				return nil
@@ -99,7 +100,10 @@ func (imp *ImplicitMetaPolicy) EvaluateSignedData(signatureSet []*protoutil.Sign
 	if remaining == 0 {
 		return nil
 	}
-	return fmt.Errorf("implicit policy evaluation failed - %d sub-policies were satisfied, but this policy requires %d of the '%s' sub-policies to be satisfied", (imp.Threshold - remaining), imp.Threshold, imp.SubPolicyName)
+	return NewEvaluationError(
+		fmt.Sprintf("implicit policy evaluation failed - %d sub-policies were satisfied, but this policy requires %d of the '%s' sub-policies to be satisfied", (imp.Threshold-remaining), imp.Threshold, imp.SubPolicyName),
+		func() *EvaluationTrace { return imp.trace(errs) },
+	)
 }
 
 // EvaluateIdentities takes an array of identities and evaluates whether
@@ -129,8 +133,9 @@ func (imp *ImplicitMetaPolicy) EvaluateIdentities(identities []msp.Identity) err
 		logger.Debugf(b.String())
 	}()
 
-	for _, policy := range imp.SubPolicies {
-		if policy.EvaluateIdentities(identities) == nil {
+	errs := make([]error, len(imp.SubPolicies))
+	for i, policy := range imp.SubPolicies {
+		if errs[i] = policy.EvaluateIdentities(identities); errs[i] == nil {
 			remaining--
 			if remaining == 0 {
 				return nil
@@ -140,5 +145,41 @@ func (imp *ImplicitMetaPolicy) EvaluateIdentities(identities []msp.Identity) err
 	if remaining == 0 {
 		return nil
 	}
-	return fmt.Errorf("implicit policy evaluation failed - %d sub-policies were satisfied, but this policy requires %d of the '%s' sub-policies to be satisfied", (imp.Threshold - remaining), imp.Threshold, imp.SubPolicyName)
+	return NewEvaluationError(
+		fmt.Sprintf("implicit policy evaluation failed - %d sub-policies were satisfied, but this policy requires %d of the '%s' sub-policies to be satisfied", (imp.Threshold-remaining), imp.Threshold, imp.SubPolicyName),
+		func() *EvaluationTrace { return imp.trace(errs) },
+	)
+}
+
+// trace describes the evaluation of the policy given the outcome of the
+// evaluation of each of its sub-policies.
+func (imp *ImplicitMetaPolicy) trace(errs []error) *EvaluationTrace {
+	trace := &EvaluationTrace{
+		Rule:     fmt.Sprintf("ImplicitMeta(%s)", imp.SubPolicyName),
+		Required: imp.Threshold,
+	}
+	for i, policy := range imp.SubPolicies {
+		var sub *EvaluationTrace
+		switch {
+		case errs[i] == nil:
+			sub = &EvaluationTrace{Name: policyName(policy), Satisfied: true}
+			trace.SatisfiedCount++
+		case TraceFromError(errs[i]) != nil:
+			sub = TraceFromError(errs[i])
+		default:
+			sub = &EvaluationTrace{Name: policyName(policy), Mismatches: []string{errs[i].Error()}}
+		}
+		trace.SubPolicies = append(trace.SubPolicies, sub)
+	}
+	trace.Satisfied = trace.SatisfiedCount >= imp.Threshold
+	return trace
+}
+
+// policyName returns the path of the policy if it was obtained from a
+// policy manager.
+func policyName(policy Policy) string {
+	if pl, ok := policy.(*PolicyLogger); ok {
+		return pl.policyName
+	}
+	return ""
 }
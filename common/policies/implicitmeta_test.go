@@ -115,3 +115,44 @@ func TestImplicitMetaMajority(t *testing.T) {
 	err = runPolicyTest(t, cb.ImplicitMetaPolicy_MAJORITY, 10, 0)
 	require.EqualError(t, err, "implicit policy evaluation failed - 0 sub-policies were satisfied, but this policy requires 6 of the 'TestPolicyName' sub-policies to be satisfied")
 }
+
+type traceRejectPolicy struct{}
+
+func (rp traceRejectPolicy) EvaluateSignedData(signedData []*protoutil.SignedData) error {
+	return rp.EvaluateIdentities(nil)
+}
+
+func (rp traceRejectPolicy) EvaluateIdentities(identity []msp.Identity) error {
+	return NewEvaluationError("signature set did not satisfy policy", func() *EvaluationTrace {
+		return &EvaluationTrace{Rule: "SignedBy(Org2MSP.peer)", Mismatches: []string{"Org1MSP: not a member of Org2MSP"}}
+	})
+}
+
+func TestImplicitMetaTrace(t *testing.T) {
+	managers := map[string]*ManagerImpl{
+		"Org1MSP": {path: "Application/Org1MSP", Policies: map[string]Policy{TestPolicyName: acceptPolicy{}}},
+		"Org2MSP": {path: "Application/Org2MSP", Policies: map[string]Policy{TestPolicyName: traceRejectPolicy{}}},
+		"Org3MSP": {path: "Application/Org3MSP", Policies: map[string]Policy{}},
+	}
+	imp, err := NewImplicitMetaPolicy(protoutil.MarshalOrPanic(&cb.ImplicitMetaPolicy{
+		Rule:      cb.ImplicitMetaPolicy_ALL,
+		SubPolicy: TestPolicyName,
+	}), managers)
+	require.NoError(t, err)
+
+	err = imp.EvaluateSignedData(nil)
+	require.EqualError(t, err, "implicit policy evaluation failed - 1 sub-policies were satisfied, but this policy requires 3 of the 'TestPolicyName' sub-policies to be satisfied")
+
+	trace := TraceFromError(err)
+	require.Equal(t, "ImplicitMeta(TestPolicyName)", trace.Rule)
+	require.False(t, trace.Satisfied)
+	require.Equal(t, 3, trace.Required)
+	require.Equal(t, 1, trace.SatisfiedCount)
+	require.ElementsMatch(t, []*EvaluationTrace{
+		{Name: "/Application/Org1MSP/TestPolicyName", Satisfied: true},
+		{Name: "/Application/Org2MSP/TestPolicyName", Rule: "SignedBy(Org2MSP.peer)", Mismatches: []string{"Org1MSP: not a member of Org2MSP"}},
+		{Mismatches: []string{"no such policy: 'TestPolicyName'"}},
+	}, trace.SubPolicies)
+
+	require.Equal(t, trace, TraceFromError(imp.EvaluateIdentities(nil)))
+}
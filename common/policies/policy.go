@@ -288,6 +288,9 @@ func (pl *PolicyLogger) EvaluateSignedData(signatureSet []*protoutil.SignedData)
 	err := pl.Policy.EvaluateSignedData(signatureSet)
 	if err != nil {
 		logger.Debugf("Signature set did not satisfy policy %s", pl.policyName)
+		if e, ok := err.(*EvaluationError); ok {
+			return e.named(pl.policyName)
+		}
 	} else {
 		logger.Debugf("Signature set satisfies policy %s", pl.policyName)
 	}
@@ -303,6 +306,9 @@ func (pl *PolicyLogger) EvaluateIdentities(identities []mspi.Identity) error {
 	err := pl.Policy.EvaluateIdentities(identities)
 	if err != nil {
 		logger.Debugf("Signature set did not satisfy policy %s", pl.policyName)
+		if e, ok := err.(*EvaluationError); ok {
+			return e.named(pl.policyName)
+		}
 	} else {
 		logger.Debugf("Signature set satisfies policy %s", pl.policyName)
 	}
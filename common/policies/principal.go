@@ -0,0 +1,57 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policies
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+)
+
+// PrincipalString returns a human readable representation of a principal.
+func PrincipalString(principal *msp.MSPPrincipal) string {
+	switch principal.PrincipalClassification {
+	case msp.MSPPrincipal_ROLE:
+		role := &msp.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			break
+		}
+		return fmt.Sprintf("%s.%s", role.MspIdentifier, strings.ToLower(role.Role.String()))
+	case msp.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &msp.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			break
+		}
+		return fmt.Sprintf("%s.OU=%s", ou.MspIdentifier, ou.OrganizationalUnitIdentifier)
+	case msp.MSPPrincipal_IDENTITY:
+		id := &msp.SerializedIdentity{}
+		if err := proto.Unmarshal(principal.Principal, id); err != nil {
+			break
+		}
+		return fmt.Sprintf("identity %s", describeSerializedIdentity(principal.Principal))
+	}
+	return fmt.Sprintf("%s principal", principal.PrincipalClassification)
+}
+
+// describeSerializedIdentity returns the MSP ID of the serialized identity
+// followed by the subject of its certificate, if it has one.
+func describeSerializedIdentity(serializedIdentity []byte) string {
+	id := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, id); err != nil {
+		return "malformed identity"
+	}
+	if pemBlock, _ := pem.Decode(id.IdBytes); pemBlock != nil {
+		if cert, err := x509.ParseCertificate(pemBlock.Bytes); err == nil {
+			return fmt.Sprintf("%s (%s)", id.Mspid, cert.Subject)
+		}
+	}
+	return id.Mspid
+}
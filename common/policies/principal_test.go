@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policies
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrincipalString(t *testing.T) {
+	tests := []struct {
+		principal *msp.MSPPrincipal
+		expected  string
+	}{
+		{
+			principal: &msp.MSPPrincipal{
+				PrincipalClassification: msp.MSPPrincipal_ROLE,
+				Principal:               protoutil.MarshalOrPanic(&msp.MSPRole{MspIdentifier: "Org1MSP", Role: msp.MSPRole_ADMIN}),
+			},
+			expected: "Org1MSP.admin",
+		},
+		{
+			principal: &msp.MSPPrincipal{
+				PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT,
+				Principal:               protoutil.MarshalOrPanic(&msp.OrganizationUnit{MspIdentifier: "Org1MSP", OrganizationalUnitIdentifier: "finance"}),
+			},
+			expected: "Org1MSP.OU=finance",
+		},
+		{
+			principal: &msp.MSPPrincipal{
+				PrincipalClassification: msp.MSPPrincipal_IDENTITY,
+				Principal:               protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "Org1MSP"}),
+			},
+			expected: "identity Org1MSP",
+		},
+		{
+			principal: &msp.MSPPrincipal{
+				PrincipalClassification: msp.MSPPrincipal_ANONYMITY,
+			},
+			expected: "ANONYMITY principal",
+		},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, PrincipalString(tt.principal))
+	}
+}
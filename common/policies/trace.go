@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policies
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// EvaluationTrace describes how a policy, and recursively its sub-policies,
+// was evaluated against a set of identities.
+type EvaluationTrace struct {
+	// Name is the path of the policy in the policy hierarchy, when known.
+	Name string `json:"name,omitempty"`
+	// Rule describes the evaluated rule, e.g. "OutOf(2)" or "SignedBy(Org1MSP.peer)".
+	Rule      string `json:"rule,omitempty"`
+	Satisfied bool   `json:"satisfied"`
+	// Required and SatisfiedCount are the number of sub-policies which must
+	// be and which were satisfied, for rules combining sub-policies.
+	Required       int `json:"required,omitempty"`
+	SatisfiedCount int `json:"satisfied_count,omitempty"`
+	// MatchedIdentity is the identity which satisfied a SignedBy rule.
+	MatchedIdentity string `json:"matched_identity,omitempty"`
+	// Mismatches explain why each identity did not satisfy an unsatisfied
+	// SignedBy rule.
+	Mismatches []string `json:"mismatches,omitempty"`
+	// DiscardedSignatures explain why signatures were not considered at all.
+	DiscardedSignatures []string           `json:"discarded_signatures,omitempty"`
+	SubPolicies         []*EvaluationTrace `json:"sub_policies,omitempty"`
+}
+
+// String renders the trace as an indented tree.
+func (t *EvaluationTrace) String() string {
+	var b strings.Builder
+	t.write(&b, "")
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (t *EvaluationTrace) write(b *strings.Builder, indent string) {
+	b.WriteString(indent)
+	switch {
+	case t.Name != "" && t.Rule != "":
+		fmt.Fprintf(b, "%s: %s", t.Name, t.Rule)
+	case t.Name != "":
+		b.WriteString(t.Name)
+	default:
+		b.WriteString(t.Rule)
+	}
+	if t.Required != 0 || len(t.SubPolicies) != 0 {
+		fmt.Fprintf(b, " %d/%d", t.SatisfiedCount, t.Required)
+	}
+	if t.Satisfied {
+		b.WriteString(" satisfied")
+	} else {
+		b.WriteString(" not satisfied")
+	}
+	if t.MatchedIdentity != "" {
+		fmt.Fprintf(b, " by %s", t.MatchedIdentity)
+	}
+	b.WriteString("\n")
+
+	for _, m := range t.Mismatches {
+		fmt.Fprintf(b, "%s  - %s\n", indent, m)
+	}
+	for _, d := range t.DiscardedSignatures {
+		fmt.Fprintf(b, "%s  - discarded %s\n", indent, d)
+	}
+	for _, sub := range t.SubPolicies {
+		sub.write(b, indent+"  ")
+	}
+}
+
+// EvaluationError is returned by policies which are not satisfied. Computing
+// the trace explaining the failure is deferred until it is requested, as
+// failures of sub-policies are often benign.
+type EvaluationError struct {
+	msg       string
+	traceFunc func() *EvaluationTrace
+	once      sync.Once
+	trace     *EvaluationTrace
+}
+
+// NewEvaluationError returns an error with the given message whose trace is
+// computed by traceFunc when first requested.
+func NewEvaluationError(msg string, traceFunc func() *EvaluationTrace) *EvaluationError {
+	return &EvaluationError{
+		msg:       msg,
+		traceFunc: traceFunc,
+	}
+}
+
+func (e *EvaluationError) Error() string {
+	return e.msg
+}
+
+// Trace returns the trace explaining the failed evaluation.
+func (e *EvaluationError) Trace() *EvaluationTrace {
+	e.once.Do(func() {
+		e.trace = e.traceFunc()
+	})
+	return e.trace
+}
+
+// named returns an error identical to e whose trace carries the given
+// policy name, unless it already has one.
+func (e *EvaluationError) named(name string) *EvaluationError {
+	return NewEvaluationError(e.msg, func() *EvaluationTrace {
+		trace := *e.Trace()
+		if trace.Name == "" {
+			trace.Name = name
+		}
+		return &trace
+	})
+}
+
+// TraceFromError returns the evaluation trace carried by err, or by any
+// error it wraps, or nil if there is none.
+func TraceFromError(err error) *EvaluationTrace {
+	for err != nil {
+		if e, ok := err.(*EvaluationError); ok {
+			return e.Trace()
+		}
+		switch e := err.(type) {
+		case interface{ Cause() error }:
+			err = e.Cause()
+		case interface{ Unwrap() error }:
+			err = e.Unwrap()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// DiscardedSignatures explains why signatures of the signature set are
+// discarded by SignatureSetToValidIdentities.
+func DiscardedSignatures(signedData []*protoutil.SignedData, identityDeserializer msp.IdentityDeserializer) []string {
+	var discarded []string
+	idMap := map[string]struct{}{}
+
+	for i, sd := range signedData {
+		identity, err := identityDeserializer.DeserializeIdentity(sd.Identity)
+		if err != nil {
+			discarded = append(discarded, fmt.Sprintf("signature %d by %s: invalid identity: %s", i, describeSerializedIdentity(sd.Identity), err))
+			continue
+		}
+
+		key := identity.GetIdentifier().Mspid + identity.GetIdentifier().Id
+		if _, ok := idMap[key]; ok {
+			discarded = append(discarded, fmt.Sprintf("signature %d by %s: duplicate identity", i, describeSerializedIdentity(sd.Identity)))
+			continue
+		}
+
+		if err := identity.Verify(sd.Data, sd.Signature); err != nil {
+			discarded = append(discarded, fmt.Sprintf("signature %d by %s: invalid signature: %s", i, describeSerializedIdentity(sd.Identity), err))
+			continue
+		}
+
+		idMap[key] = struct{}{}
+	}
+
+	return discarded
+}
+
+// IdentityString returns a human readable representation of an identity.
+func IdentityString(identity msp.Identity) string {
+	serialized, err := identity.Serialize()
+	if err != nil {
+		return identity.GetMSPIdentifier()
+	}
+	return describeSerializedIdentity(serialized)
+}
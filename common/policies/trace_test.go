@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policies
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type unwrapper struct {
+	err error
+}
+
+func (u *unwrapper) Error() string { return u.err.Error() }
+func (u *unwrapper) Unwrap() error { return u.err }
+
+func TestTraceFromError(t *testing.T) {
+	calls := 0
+	evalErr := NewEvaluationError("signature set did not satisfy policy", func() *EvaluationTrace {
+		calls++
+		return &EvaluationTrace{Rule: "OutOf(1)", Required: 1}
+	})
+
+	require.Nil(t, TraceFromError(nil))
+	require.Nil(t, TraceFromError(errors.New("boom")))
+
+	err := &unwrapper{err: errors.WithMessage(evalErr, "validation of key foo failed")}
+	require.Equal(t, "validation of key foo failed: signature set did not satisfy policy", err.Error())
+	require.Equal(t, &EvaluationTrace{Rule: "OutOf(1)", Required: 1}, TraceFromError(err))
+	require.Equal(t, &EvaluationTrace{Rule: "OutOf(1)", Required: 1}, TraceFromError(evalErr))
+	require.Equal(t, 1, calls)
+
+	named := evalErr.named("/Channel/Application/Org1MSP/Endorsement")
+	require.Equal(t, evalErr.Error(), named.Error())
+	require.Equal(t, "/Channel/Application/Org1MSP/Endorsement", named.Trace().Name)
+	require.Empty(t, evalErr.Trace().Name)
+}
+
+func TestEvaluationTraceString(t *testing.T) {
+	trace := &EvaluationTrace{
+		Name:           "/Channel/Application/Endorsement",
+		Rule:           "ImplicitMeta(Endorsement)",
+		Required:       2,
+		SatisfiedCount: 1,
+		SubPolicies: []*EvaluationTrace{
+			{Name: "/Channel/Application/Org1MSP/Endorsement", Satisfied: true},
+			{
+				Name:                "/Channel/Application/Org2MSP/Endorsement",
+				Rule:                "OutOf(1)",
+				Required:            1,
+				DiscardedSignatures: []string{"signature 1 by Org2MSP: invalid signature"},
+				SubPolicies: []*EvaluationTrace{
+					{Rule: "SignedBy(Org2MSP.peer)", Mismatches: []string{"Org1MSP: already used for another principal"}},
+				},
+			},
+		},
+	}
+
+	require.Equal(t, `/Channel/Application/Endorsement: ImplicitMeta(Endorsement) 1/2 not satisfied
+  /Channel/Application/Org1MSP/Endorsement satisfied
+  /Channel/Application/Org2MSP/Endorsement: OutOf(1) 0/1 not satisfied
+    - discarded signature 1 by Org2MSP: invalid signature
+    SignedBy(Org2MSP.peer) not satisfied
+      - Org1MSP: already used for another principal`, trace.String())
+}
@@ -351,3 +351,64 @@ func FromString(policy string) (*cb.SignaturePolicyEnvelope, error) {
 
 	return p, nil
 }
+
+// ToString returns the representation of the policy in the language
+// accepted by FromString. Gates requiring all of their sub-policies are
+// written as AND, gates requiring one of them as OR and the remaining ones
+// as OutOf. An error is returned if the policy references principals other
+// than MSP roles, which the language cannot express.
+func ToString(policy *cb.SignaturePolicyEnvelope) (string, error) {
+	if policy == nil || policy.Rule == nil {
+		return "", fmt.Errorf("policy has no rule")
+	}
+
+	if _, ok := policy.Rule.Type.(*cb.SignaturePolicy_SignedBy); ok {
+		// a single principal is only accepted as an argument of a gate
+		policy = &cb.SignaturePolicyEnvelope{
+			Identities: policy.Identities,
+			Rule:       NOutOf(1, []*cb.SignaturePolicy{policy.Rule}),
+		}
+	}
+
+	return ruleToString(policy.Rule, policy.Identities)
+}
+
+func ruleToString(rule *cb.SignaturePolicy, identities []*mb.MSPPrincipal) (string, error) {
+	switch t := rule.Type.(type) {
+	case *cb.SignaturePolicy_SignedBy:
+		if t.SignedBy < 0 || int(t.SignedBy) >= len(identities) {
+			return "", fmt.Errorf("identity index %d out of range", t.SignedBy)
+		}
+		principal := identities[t.SignedBy]
+		if principal.PrincipalClassification != mb.MSPPrincipal_ROLE {
+			return "", fmt.Errorf("principal of type %s cannot be expressed", principal.PrincipalClassification)
+		}
+		role := &mb.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return "", fmt.Errorf("error unmarshaling msp role: %s", err)
+		}
+		return fmt.Sprintf("'%s.%s'", role.MspIdentifier, strings.ToLower(role.Role.String())), nil
+
+	case *cb.SignaturePolicy_NOutOf_:
+		args := make([]string, 0, len(t.NOutOf.Rules))
+		for _, r := range t.NOutOf.Rules {
+			arg, err := ruleToString(r, identities)
+			if err != nil {
+				return "", err
+			}
+			args = append(args, arg)
+		}
+
+		switch {
+		case t.NOutOf.N == 1 && len(args) > 0:
+			return fmt.Sprintf("%s(%s)", strings.ToUpper(GateOr), strings.Join(args, ", ")), nil
+		case int(t.NOutOf.N) == len(args):
+			return fmt.Sprintf("%s(%s)", strings.ToUpper(GateAnd), strings.Join(args, ", ")), nil
+		default:
+			return fmt.Sprintf("%s(%d, %s)", GateOutOf, t.NOutOf.N, strings.Join(args, ", ")), nil
+		}
+
+	default:
+		return "", fmt.Errorf("unknown rule type %T", rule.Type)
+	}
+}
@@ -379,3 +379,45 @@ func TestSecondPassBoundaryCheck(t *testing.T) {
 	require.Nil(t, p3)
 	require.EqualError(t, err3, "invalid t-out-of-n predicate, t 4, n 2")
 }
+
+func TestToString(t *testing.T) {
+	for _, policy := range []string{
+		"OR('A.member', 'B.admin')",
+		"AND('A.peer', 'B.client')",
+		"OutOf(2, 'A.orderer', AND('B.member', 'C.member'), OR('D.admin'))",
+	} {
+		envelope, err := FromString(policy)
+		require.NoError(t, err)
+		s, err := ToString(envelope)
+		require.NoError(t, err)
+		require.Equal(t, policy, s)
+	}
+
+	s, err := ToString(Envelope(SignedBy(0), [][]byte{[]byte("A")}))
+	require.Error(t, err)
+	require.Empty(t, s)
+
+	s, err = ToString(&common.SignaturePolicyEnvelope{
+		Rule: SignedBy(0),
+		Identities: []*msp.MSPPrincipal{{
+			PrincipalClassification: msp.MSPPrincipal_ROLE,
+			Principal:               protoutil.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_ADMIN, MspIdentifier: "A"}),
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "OR('A.admin')", s)
+
+	s, err = ToString(SignedByMspMember("A"))
+	require.NoError(t, err)
+	require.Equal(t, "OR('A.member')", s)
+
+	ouPrincipal := &common.SignaturePolicyEnvelope{
+		Rule: SignedBy(0),
+		Identities: []*msp.MSPPrincipal{{
+			PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT,
+			Principal:               protoutil.MarshalOrPanic(&msp.OrganizationUnit{MspIdentifier: "A", OrganizationalUnitIdentifier: "OU"}),
+		}},
+	}
+	_, err = ToString(ouPrincipal)
+	require.EqualError(t, err, "principal of type ORGANIZATION_UNIT cannot be expressed")
+}
@@ -374,6 +374,26 @@ func TestBCCSPDecodeHookOverride(t *testing.T) {
 	require.Equal(t, 1111, tc.BCCSP.SW.Security)
 }
 
+func TestBCCSPDecodeHookRemote(t *testing.T) {
+	yaml := "---\nBCCSP:\n  Default: REMOTE\n  REMOTE:\n    Address: localhost:7060\n    Timeout: 3s\n    TLS:\n      Enabled: true\n"
+
+	config := New()
+	config.SetConfigName(testConfigName)
+	err := config.ReadConfig(strings.NewReader(yaml))
+	require.NoError(t, err, "error reading config")
+
+	var tc struct {
+		BCCSP *factory.FactoryOpts
+	}
+	err = config.EnhancedExactUnmarshal(&tc)
+	require.NoError(t, err, "failed to unmarshal")
+	require.NotNil(t, tc.BCCSP.Remote)
+	require.Equal(t, "localhost:7060", tc.BCCSP.Remote.Address)
+	require.Equal(t, 3*time.Second, tc.BCCSP.Remote.Timeout)
+	require.True(t, tc.BCCSP.Remote.TLS.Enabled)
+	require.Equal(t, "SHA2", tc.BCCSP.Remote.Hash)
+}
+
 func TestDurationDecode(t *testing.T) {
 	tests := []struct {
 		input    string
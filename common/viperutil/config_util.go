@@ -402,7 +402,15 @@ func bccspHook(f reflect.Type, t reflect.Type, data interface{}) (interface{}, e
 
 	config := factory.GetDefaultOpts()
 
-	err := mapstructure.WeakDecode(data, config)
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           config,
+		WeaklyTypedInput: true,
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create bccsp decoder")
+	}
+	err = decoder.Decode(data)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not decode bccsp type")
 	}
@@ -12,16 +12,19 @@ package resources
 
 const (
 	// _lifecycle resources
-	Lifecycle_InstallChaincode                   = "_lifecycle/InstallChaincode"
-	Lifecycle_QueryInstalledChaincode            = "_lifecycle/QueryInstalledChaincode"
-	Lifecycle_GetInstalledChaincodePackage       = "_lifecycle/GetInstalledChaincodePackage"
-	Lifecycle_QueryInstalledChaincodes           = "_lifecycle/QueryInstalledChaincodes"
-	Lifecycle_ApproveChaincodeDefinitionForMyOrg = "_lifecycle/ApproveChaincodeDefinitionForMyOrg"
-	Lifecycle_QueryApprovedChaincodeDefinition   = "_lifecycle/QueryApprovedChaincodeDefinition"
-	Lifecycle_CommitChaincodeDefinition          = "_lifecycle/CommitChaincodeDefinition"
-	Lifecycle_QueryChaincodeDefinition           = "_lifecycle/QueryChaincodeDefinition"
-	Lifecycle_QueryChaincodeDefinitions          = "_lifecycle/QueryChaincodeDefinitions"
-	Lifecycle_CheckCommitReadiness               = "_lifecycle/CheckCommitReadiness"
+	Lifecycle_InstallChaincode                    = "_lifecycle/InstallChaincode"
+	Lifecycle_QueryInstalledChaincode             = "_lifecycle/QueryInstalledChaincode"
+	Lifecycle_GetInstalledChaincodePackage        = "_lifecycle/GetInstalledChaincodePackage"
+	Lifecycle_QueryInstalledChaincodes            = "_lifecycle/QueryInstalledChaincodes"
+	Lifecycle_ApproveChaincodeDefinitionForMyOrg  = "_lifecycle/ApproveChaincodeDefinitionForMyOrg"
+	Lifecycle_ApproveChaincodeDefinitionsForMyOrg = "_lifecycle/ApproveChaincodeDefinitionsForMyOrg"
+	Lifecycle_QueryApprovedChaincodeDefinition    = "_lifecycle/QueryApprovedChaincodeDefinition"
+	Lifecycle_CommitChaincodeDefinition           = "_lifecycle/CommitChaincodeDefinition"
+	Lifecycle_CommitChaincodeDefinitions          = "_lifecycle/CommitChaincodeDefinitions"
+	Lifecycle_QueryChaincodeDefinition            = "_lifecycle/QueryChaincodeDefinition"
+	Lifecycle_QueryChaincodeDefinitions           = "_lifecycle/QueryChaincodeDefinitions"
+	Lifecycle_CheckCommitReadiness                = "_lifecycle/CheckCommitReadiness"
+	Lifecycle_CheckCommitReadinessForDefinitions  = "_lifecycle/CheckCommitReadinessForDefinitions"
 
 	// snapshot resources
 	Snapshot_submitrequest = "snapshot/submitrequest"
@@ -46,6 +49,8 @@ const (
 	Qscc_GetTransactionByID = "qscc/GetTransactionByID"
 	Qscc_GetBlockByTxID     = "qscc/GetBlockByTxID"
 
+	Qscc_GetEndorsementPolicyTrace = "qscc/GetEndorsementPolicyTrace"
+
 	//Cscc resources
 	Cscc_JoinChain            = "cscc/JoinChain"
 	Cscc_JoinChainBySnapshot  = "cscc/JoinChainBySnapshot"
@@ -360,6 +360,83 @@ func (ef *ExternalFunctions) CommitChaincodeDefinition(chname, ccname string, cd
 	return approvals, nil
 }
 
+// BatchDefinition is one of several chaincode definitions approved, checked,
+// or committed together in a single transaction.
+type BatchDefinition struct {
+	Name       string
+	Definition *ChaincodeDefinition
+	// PackageID is only used when approving the definition.
+	PackageID string
+}
+
+// CommitReadiness is the commit readiness of one of a batch of chaincode
+// definitions. Err is set instead of Approvals when the definition cannot be
+// committed.
+type CommitReadiness struct {
+	Approvals map[string]bool
+	Err       error
+}
+
+// checkBatch checks that a batch holds at least one definition and no two
+// definitions for the same chaincode. The latter is required as the reads
+// of a transaction do not observe its own writes.
+func checkBatch(definitions []*BatchDefinition) error {
+	if len(definitions) == 0 {
+		return errors.New("no chaincode definitions supplied")
+	}
+	names := map[string]struct{}{}
+	for _, d := range definitions {
+		if _, ok := names[d.Name]; ok {
+			return errors.Errorf("chaincode '%s' has more than one definition", d.Name)
+		}
+		names[d.Name] = struct{}{}
+	}
+	return nil
+}
+
+// CheckCommitReadinessForDefinitions checks the commit readiness of each of
+// a batch of chaincode definitions. The readiness of a definition does not
+// depend on the other definitions of the batch.
+func (ef *ExternalFunctions) CheckCommitReadinessForDefinitions(chname string, definitions []*BatchDefinition, publicState ReadWritableState, orgStates []OpaqueState) ([]*CommitReadiness, error) {
+	if err := checkBatch(definitions); err != nil {
+		return nil, err
+	}
+
+	readiness := make([]*CommitReadiness, len(definitions))
+	for i, d := range definitions {
+		approvals, err := ef.CheckCommitReadiness(chname, d.Name, d.Definition, publicState, orgStates)
+		readiness[i] = &CommitReadiness{Approvals: approvals, Err: err}
+	}
+
+	return readiness, nil
+}
+
+// CommitChaincodeDefinitions checks each of a batch of chaincode definitions
+// as CommitChaincodeDefinition does and, only if all of them are valid,
+// applies them to the public world state. It returns the approvals of each
+// definition, in the order of the definitions.
+func (ef *ExternalFunctions) CommitChaincodeDefinitions(chname string, definitions []*BatchDefinition, publicState ReadWritableState, orgStates []OpaqueState) ([]map[string]bool, error) {
+	if err := checkBatch(definitions); err != nil {
+		return nil, err
+	}
+
+	approvals := make([]map[string]bool, len(definitions))
+	for i, d := range definitions {
+		var err error
+		if approvals[i], err = ef.CheckCommitReadiness(chname, d.Name, d.Definition, publicState, orgStates); err != nil {
+			return nil, errors.WithMessagef(err, "invalid definition for chaincode '%s'", d.Name)
+		}
+	}
+
+	for _, d := range definitions {
+		if err := ef.Resources.Serializer.Serialize(NamespacesName, d.Name, d.Definition, publicState); err != nil {
+			return nil, errors.WithMessagef(err, "could not serialize chaincode definition for chaincode '%s'", d.Name)
+		}
+	}
+
+	return approvals, nil
+}
+
 // DefaultEndorsementPolicyAsBytes returns a marshalled version
 // of the default chaincode endorsement policy in the supplied channel
 func (ef *ExternalFunctions) DefaultEndorsementPolicyAsBytes(channelID string) ([]byte, error) {
@@ -413,6 +490,16 @@ func (ef *ExternalFunctions) SetChaincodeDefinitionDefaults(chname string, cd *C
 // for either the currently defined sequence number or the next sequence number.  If the definition is
 // for the current sequence number, then it must match exactly the current definition or it will be rejected.
 func (ef *ExternalFunctions) ApproveChaincodeDefinitionForOrg(chname, ccname string, cd *ChaincodeDefinition, packageID string, publicState ReadableState, orgState ReadWritableState) error {
+	if err := ef.checkApproval(chname, ccname, cd, packageID, publicState, orgState); err != nil {
+		return err
+	}
+
+	return ef.writeApproval(chname, ccname, cd, packageID, orgState)
+}
+
+// checkApproval checks that a chaincode definition may be approved and sets
+// its defaults, without writing anything to the org state.
+func (ef *ExternalFunctions) checkApproval(chname, ccname string, cd *ChaincodeDefinition, packageID string, publicState ReadableState, orgState ReadableState) error {
 	// Get the current sequence from the public state
 	currentSequence, err := ef.Resources.Serializer.DeserializeFieldAsInt64(NamespacesName, ccname, "Sequence", publicState)
 	if err != nil {
@@ -495,6 +582,12 @@ func (ef *ExternalFunctions) ApproveChaincodeDefinitionForOrg(chname, ccname str
 		}
 	}
 
+	return nil
+}
+
+// writeApproval records an approval checked by checkApproval in the org state.
+func (ef *ExternalFunctions) writeApproval(chname, ccname string, cd *ChaincodeDefinition, packageID string, orgState ReadWritableState) error {
+	privateName := fmt.Sprintf("%s#%d", ccname, cd.Sequence)
 	if err := ef.Resources.Serializer.Serialize(NamespacesName, privateName, cd.Parameters(), orgState); err != nil {
 		return errors.WithMessage(err, "could not serialize chaincode parameters to state")
 	}
@@ -516,6 +609,29 @@ func (ef *ExternalFunctions) ApproveChaincodeDefinitionForOrg(chname, ccname str
 	return nil
 }
 
+// ApproveChaincodeDefinitionsForOrg checks each of a batch of chaincode
+// definitions as ApproveChaincodeDefinitionForOrg does and, only if all of
+// them may be approved, adds them to the passed in Org state.
+func (ef *ExternalFunctions) ApproveChaincodeDefinitionsForOrg(chname string, definitions []*BatchDefinition, publicState ReadableState, orgState ReadWritableState) error {
+	if err := checkBatch(definitions); err != nil {
+		return err
+	}
+
+	for _, d := range definitions {
+		if err := ef.checkApproval(chname, d.Name, d.Definition, d.PackageID, publicState, orgState); err != nil {
+			return errors.WithMessagef(err, "invalid definition for chaincode '%s'", d.Name)
+		}
+	}
+
+	for _, d := range definitions {
+		if err := ef.writeApproval(chname, d.Name, d.Definition, d.PackageID, orgState); err != nil {
+			return errors.WithMessagef(err, "could not approve definition for chaincode '%s'", d.Name)
+		}
+	}
+
+	return nil
+}
+
 // QueryApprovedChaincodeDefinition returns the approved chaincode definition in Org state by using the given parameters.
 // If the parameter of sequence is not provided, this function returns the latest approved chaincode definition
 // (latest: new one of the currently defined sequence number and the next sequence number).
@@ -1108,6 +1108,190 @@ var _ = Describe("ExternalFunctions", func() {
 		})
 	})
 
+	Describe("batches of chaincode definitions", func() {
+		var (
+			fakePublicState *mock.ReadWritableState
+			fakeOrgStates   []*mock.ReadWritableState
+
+			definitions []*lifecycle.BatchDefinition
+
+			publicKVS, org0KVS, org1KVS MapLedgerShim
+		)
+
+		newDefinition := func(sequence int64) *lifecycle.ChaincodeDefinition {
+			return &lifecycle.ChaincodeDefinition{
+				Sequence: sequence,
+				EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+					Version:           "version",
+					EndorsementPlugin: "endorsement-plugin",
+				},
+				ValidationInfo: &lb.ChaincodeValidationInfo{
+					ValidationPlugin:    "validation-plugin",
+					ValidationParameter: []byte("validation-parameter"),
+				},
+				Collections: &pb.CollectionConfigPackage{},
+			}
+		}
+
+		BeforeEach(func() {
+			definitions = []*lifecycle.BatchDefinition{
+				{Name: "cc-name", Definition: newDefinition(5), PackageID: "hash"},
+				{Name: "other-cc", Definition: newDefinition(1), PackageID: "other-hash"},
+			}
+
+			publicKVS = MapLedgerShim(map[string][]byte{})
+			fakePublicState = &mock.ReadWritableState{}
+			fakePublicState.GetStateStub = publicKVS.GetState
+			fakePublicState.PutStateStub = publicKVS.PutState
+
+			err := resources.Serializer.Serialize("namespaces", "cc-name", newDefinition(4), publicKVS)
+			Expect(err).NotTo(HaveOccurred())
+
+			org0KVS = MapLedgerShim(map[string][]byte{})
+			org1KVS = MapLedgerShim(map[string][]byte{})
+			fakeOrg0State := &mock.ReadWritableState{}
+			fakeOrg0State.CollectionNameReturns("_implicit_org_org0")
+			fakeOrg1State := &mock.ReadWritableState{}
+			fakeOrg1State.CollectionNameReturns("_implicit_org_org1")
+			fakeOrgStates = []*mock.ReadWritableState{
+				fakeOrg0State,
+				fakeOrg1State,
+			}
+			for i, kvs := range []MapLedgerShim{org0KVS, org1KVS} {
+				kvs := kvs
+				fakeOrgStates[i].GetStateStub = kvs.GetState
+				fakeOrgStates[i].GetStateHashStub = kvs.GetStateHash
+				fakeOrgStates[i].PutStateStub = kvs.PutState
+			}
+		})
+
+		Describe("ApproveChaincodeDefinitionsForOrg", func() {
+			It("serializes all the chaincode definitions to the org scoped collection", func() {
+				err := ef.ApproveChaincodeDefinitionsForOrg("my-channel", definitions, fakePublicState, fakeOrgStates[0])
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, name := range []string{"cc-name#5", "other-cc#1"} {
+					ok, err := resources.Serializer.IsSerialized("namespaces", name, newDefinition(0).Parameters(), fakeOrgStates[0])
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ok).To(BeTrue())
+				}
+				localPackage := &lifecycle.ChaincodeLocalPackage{}
+				metadata, ok, err := resources.Serializer.DeserializeMetadata("chaincode-sources", "other-cc#1", fakeOrgStates[0])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ok).To(BeTrue())
+				err = resources.Serializer.Deserialize("chaincode-sources", "other-cc#1", metadata, localPackage, fakeOrgStates[0])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(localPackage.PackageID).To(Equal("other-hash"))
+			})
+
+			Context("when one of the definitions cannot be approved", func() {
+				BeforeEach(func() {
+					definitions[1].Definition.Sequence = 3
+				})
+
+				It("approves none of the definitions", func() {
+					err := ef.ApproveChaincodeDefinitionsForOrg("my-channel", definitions, fakePublicState, fakeOrgStates[0])
+					Expect(err).To(MatchError("invalid definition for chaincode 'other-cc': requested sequence 3 is larger than the next available sequence number 1"))
+					Expect(fakeOrgStates[0].PutStateCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when a chaincode has more than one definition", func() {
+				BeforeEach(func() {
+					definitions[1].Name = "cc-name"
+				})
+
+				It("returns an error", func() {
+					err := ef.ApproveChaincodeDefinitionsForOrg("my-channel", definitions, fakePublicState, fakeOrgStates[0])
+					Expect(err).To(MatchError("chaincode 'cc-name' has more than one definition"))
+				})
+			})
+
+			Context("when no definitions are supplied", func() {
+				It("returns an error", func() {
+					err := ef.ApproveChaincodeDefinitionsForOrg("my-channel", nil, fakePublicState, fakeOrgStates[0])
+					Expect(err).To(MatchError("no chaincode definitions supplied"))
+				})
+			})
+		})
+
+		Describe("CheckCommitReadinessForDefinitions", func() {
+			BeforeEach(func() {
+				definitions[1].Definition.Sequence = 2
+				resources.Serializer.Serialize("namespaces", "cc-name#5", definitions[0].Definition.Parameters(), fakeOrgStates[0])
+			})
+
+			It("returns the readiness of each definition", func() {
+				readiness, err := ef.CheckCommitReadinessForDefinitions("my-channel", definitions, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(readiness).To(HaveLen(2))
+				Expect(readiness[0].Err).NotTo(HaveOccurred())
+				Expect(readiness[0].Approvals).To(Equal(map[string]bool{
+					"org0": true,
+					"org1": false,
+				}))
+				Expect(readiness[1].Err).To(MatchError("requested sequence is 2, but new definition must be sequence 1"))
+				Expect(readiness[1].Approvals).To(BeNil())
+			})
+
+			Context("when a chaincode has more than one definition", func() {
+				BeforeEach(func() {
+					definitions[1].Name = "cc-name"
+				})
+
+				It("returns an error", func() {
+					_, err := ef.CheckCommitReadinessForDefinitions("my-channel", definitions, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+					Expect(err).To(MatchError("chaincode 'cc-name' has more than one definition"))
+				})
+			})
+		})
+
+		Describe("CommitChaincodeDefinitions", func() {
+			BeforeEach(func() {
+				resources.Serializer.Serialize("namespaces", "cc-name#5", definitions[0].Definition.Parameters(), fakeOrgStates[0])
+				resources.Serializer.Serialize("namespaces", "other-cc#1", definitions[1].Definition.Parameters(), fakeOrgStates[1])
+			})
+
+			It("applies all the chaincode definitions to the public state and returns the approvals", func() {
+				approvals, err := ef.CommitChaincodeDefinitions("my-channel", definitions, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(approvals).To(Equal([]map[string]bool{
+					{"org0": true, "org1": false},
+					{"org0": false, "org1": true},
+				}))
+
+				for _, d := range definitions {
+					committed := &lifecycle.ChaincodeDefinition{}
+					metadata, ok, err := resources.Serializer.DeserializeMetadata("namespaces", d.Name, fakePublicState)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ok).To(BeTrue())
+					err = resources.Serializer.Deserialize("namespaces", d.Name, metadata, committed, fakePublicState)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(committed.Sequence).To(Equal(d.Definition.Sequence))
+				}
+			})
+
+			Context("when one of the definitions cannot be committed", func() {
+				BeforeEach(func() {
+					definitions[1].Definition.Sequence = 2
+				})
+
+				It("commits none of the definitions", func() {
+					_, err := ef.CommitChaincodeDefinitions("my-channel", definitions, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+					Expect(err).To(MatchError("invalid definition for chaincode 'other-cc': requested sequence is 2, but new definition must be sequence 1"))
+					Expect(fakePublicState.PutStateCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when no definitions are supplied", func() {
+				It("returns an error", func() {
+					_, err := ef.CommitChaincodeDefinitions("my-channel", nil, fakePublicState, []lifecycle.OpaqueState{fakeOrgStates[0], fakeOrgStates[1]})
+					Expect(err).To(MatchError("no chaincode definitions supplied"))
+				})
+			})
+		})
+	})
+
 	Describe("QueryApprovedChaincodeDefinition", func() {
 		var (
 			fakePublicState *mock.ReadWritableState
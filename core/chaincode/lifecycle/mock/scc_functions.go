@@ -25,6 +25,20 @@ type SCCFunctions struct {
 	approveChaincodeDefinitionForOrgReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ApproveChaincodeDefinitionsForOrgStub        func(string, []*lifecycle.BatchDefinition, lifecycle.ReadableState, lifecycle.ReadWritableState) error
+	approveChaincodeDefinitionsForOrgMutex       sync.RWMutex
+	approveChaincodeDefinitionsForOrgArgsForCall []struct {
+		arg1 string
+		arg2 []*lifecycle.BatchDefinition
+		arg3 lifecycle.ReadableState
+		arg4 lifecycle.ReadWritableState
+	}
+	approveChaincodeDefinitionsForOrgReturns struct {
+		result1 error
+	}
+	approveChaincodeDefinitionsForOrgReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CheckCommitReadinessStub        func(string, string, *lifecycle.ChaincodeDefinition, lifecycle.ReadWritableState, []lifecycle.OpaqueState) (map[string]bool, error)
 	checkCommitReadinessMutex       sync.RWMutex
 	checkCommitReadinessArgsForCall []struct {
@@ -42,6 +56,22 @@ type SCCFunctions struct {
 		result1 map[string]bool
 		result2 error
 	}
+	CheckCommitReadinessForDefinitionsStub        func(string, []*lifecycle.BatchDefinition, lifecycle.ReadWritableState, []lifecycle.OpaqueState) ([]*lifecycle.CommitReadiness, error)
+	checkCommitReadinessForDefinitionsMutex       sync.RWMutex
+	checkCommitReadinessForDefinitionsArgsForCall []struct {
+		arg1 string
+		arg2 []*lifecycle.BatchDefinition
+		arg3 lifecycle.ReadWritableState
+		arg4 []lifecycle.OpaqueState
+	}
+	checkCommitReadinessForDefinitionsReturns struct {
+		result1 []*lifecycle.CommitReadiness
+		result2 error
+	}
+	checkCommitReadinessForDefinitionsReturnsOnCall map[int]struct {
+		result1 []*lifecycle.CommitReadiness
+		result2 error
+	}
 	CommitChaincodeDefinitionStub        func(string, string, *lifecycle.ChaincodeDefinition, lifecycle.ReadWritableState, []lifecycle.OpaqueState) (map[string]bool, error)
 	commitChaincodeDefinitionMutex       sync.RWMutex
 	commitChaincodeDefinitionArgsForCall []struct {
@@ -59,6 +89,22 @@ type SCCFunctions struct {
 		result1 map[string]bool
 		result2 error
 	}
+	CommitChaincodeDefinitionsStub        func(string, []*lifecycle.BatchDefinition, lifecycle.ReadWritableState, []lifecycle.OpaqueState) ([]map[string]bool, error)
+	commitChaincodeDefinitionsMutex       sync.RWMutex
+	commitChaincodeDefinitionsArgsForCall []struct {
+		arg1 string
+		arg2 []*lifecycle.BatchDefinition
+		arg3 lifecycle.ReadWritableState
+		arg4 []lifecycle.OpaqueState
+	}
+	commitChaincodeDefinitionsReturns struct {
+		result1 []map[string]bool
+		result2 error
+	}
+	commitChaincodeDefinitionsReturnsOnCall map[int]struct {
+		result1 []map[string]bool
+		result2 error
+	}
 	GetInstalledChaincodePackageStub        func(string) ([]byte, error)
 	getInstalledChaincodePackageMutex       sync.RWMutex
 	getInstalledChaincodePackageArgsForCall []struct {
@@ -236,6 +282,74 @@ func (fake *SCCFunctions) ApproveChaincodeDefinitionForOrgReturnsOnCall(i int, r
 	}{result1}
 }
 
+func (fake *SCCFunctions) ApproveChaincodeDefinitionsForOrg(arg1 string, arg2 []*lifecycle.BatchDefinition, arg3 lifecycle.ReadableState, arg4 lifecycle.ReadWritableState) error {
+	var arg2Copy []*lifecycle.BatchDefinition
+	if arg2 != nil {
+		arg2Copy = make([]*lifecycle.BatchDefinition, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.approveChaincodeDefinitionsForOrgMutex.Lock()
+	ret, specificReturn := fake.approveChaincodeDefinitionsForOrgReturnsOnCall[len(fake.approveChaincodeDefinitionsForOrgArgsForCall)]
+	fake.approveChaincodeDefinitionsForOrgArgsForCall = append(fake.approveChaincodeDefinitionsForOrgArgsForCall, struct {
+		arg1 string
+		arg2 []*lifecycle.BatchDefinition
+		arg3 lifecycle.ReadableState
+		arg4 lifecycle.ReadWritableState
+	}{arg1, arg2Copy, arg3, arg4})
+	fake.recordInvocation("ApproveChaincodeDefinitionsForOrg", []interface{}{arg1, arg2Copy, arg3, arg4})
+	fake.approveChaincodeDefinitionsForOrgMutex.Unlock()
+	if fake.ApproveChaincodeDefinitionsForOrgStub != nil {
+		return fake.ApproveChaincodeDefinitionsForOrgStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.approveChaincodeDefinitionsForOrgReturns
+	return fakeReturns.result1
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionsForOrgCallCount() int {
+	fake.approveChaincodeDefinitionsForOrgMutex.RLock()
+	defer fake.approveChaincodeDefinitionsForOrgMutex.RUnlock()
+	return len(fake.approveChaincodeDefinitionsForOrgArgsForCall)
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionsForOrgCalls(stub func(string, []*lifecycle.BatchDefinition, lifecycle.ReadableState, lifecycle.ReadWritableState) error) {
+	fake.approveChaincodeDefinitionsForOrgMutex.Lock()
+	defer fake.approveChaincodeDefinitionsForOrgMutex.Unlock()
+	fake.ApproveChaincodeDefinitionsForOrgStub = stub
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionsForOrgArgsForCall(i int) (string, []*lifecycle.BatchDefinition, lifecycle.ReadableState, lifecycle.ReadWritableState) {
+	fake.approveChaincodeDefinitionsForOrgMutex.RLock()
+	defer fake.approveChaincodeDefinitionsForOrgMutex.RUnlock()
+	argsForCall := fake.approveChaincodeDefinitionsForOrgArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionsForOrgReturns(result1 error) {
+	fake.approveChaincodeDefinitionsForOrgMutex.Lock()
+	defer fake.approveChaincodeDefinitionsForOrgMutex.Unlock()
+	fake.ApproveChaincodeDefinitionsForOrgStub = nil
+	fake.approveChaincodeDefinitionsForOrgReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *SCCFunctions) ApproveChaincodeDefinitionsForOrgReturnsOnCall(i int, result1 error) {
+	fake.approveChaincodeDefinitionsForOrgMutex.Lock()
+	defer fake.approveChaincodeDefinitionsForOrgMutex.Unlock()
+	fake.ApproveChaincodeDefinitionsForOrgStub = nil
+	if fake.approveChaincodeDefinitionsForOrgReturnsOnCall == nil {
+		fake.approveChaincodeDefinitionsForOrgReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.approveChaincodeDefinitionsForOrgReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *SCCFunctions) CheckCommitReadiness(arg1 string, arg2 string, arg3 *lifecycle.ChaincodeDefinition, arg4 lifecycle.ReadWritableState, arg5 []lifecycle.OpaqueState) (map[string]bool, error) {
 	var arg5Copy []lifecycle.OpaqueState
 	if arg5 != nil {
@@ -308,6 +422,82 @@ func (fake *SCCFunctions) CheckCommitReadinessReturnsOnCall(i int, result1 map[s
 	}{result1, result2}
 }
 
+func (fake *SCCFunctions) CheckCommitReadinessForDefinitions(arg1 string, arg2 []*lifecycle.BatchDefinition, arg3 lifecycle.ReadWritableState, arg4 []lifecycle.OpaqueState) ([]*lifecycle.CommitReadiness, error) {
+	var arg2Copy []*lifecycle.BatchDefinition
+	if arg2 != nil {
+		arg2Copy = make([]*lifecycle.BatchDefinition, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg4Copy []lifecycle.OpaqueState
+	if arg4 != nil {
+		arg4Copy = make([]lifecycle.OpaqueState, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.checkCommitReadinessForDefinitionsMutex.Lock()
+	ret, specificReturn := fake.checkCommitReadinessForDefinitionsReturnsOnCall[len(fake.checkCommitReadinessForDefinitionsArgsForCall)]
+	fake.checkCommitReadinessForDefinitionsArgsForCall = append(fake.checkCommitReadinessForDefinitionsArgsForCall, struct {
+		arg1 string
+		arg2 []*lifecycle.BatchDefinition
+		arg3 lifecycle.ReadWritableState
+		arg4 []lifecycle.OpaqueState
+	}{arg1, arg2Copy, arg3, arg4Copy})
+	fake.recordInvocation("CheckCommitReadinessForDefinitions", []interface{}{arg1, arg2Copy, arg3, arg4Copy})
+	fake.checkCommitReadinessForDefinitionsMutex.Unlock()
+	if fake.CheckCommitReadinessForDefinitionsStub != nil {
+		return fake.CheckCommitReadinessForDefinitionsStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.checkCommitReadinessForDefinitionsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) CheckCommitReadinessForDefinitionsCallCount() int {
+	fake.checkCommitReadinessForDefinitionsMutex.RLock()
+	defer fake.checkCommitReadinessForDefinitionsMutex.RUnlock()
+	return len(fake.checkCommitReadinessForDefinitionsArgsForCall)
+}
+
+func (fake *SCCFunctions) CheckCommitReadinessForDefinitionsCalls(stub func(string, []*lifecycle.BatchDefinition, lifecycle.ReadWritableState, []lifecycle.OpaqueState) ([]*lifecycle.CommitReadiness, error)) {
+	fake.checkCommitReadinessForDefinitionsMutex.Lock()
+	defer fake.checkCommitReadinessForDefinitionsMutex.Unlock()
+	fake.CheckCommitReadinessForDefinitionsStub = stub
+}
+
+func (fake *SCCFunctions) CheckCommitReadinessForDefinitionsArgsForCall(i int) (string, []*lifecycle.BatchDefinition, lifecycle.ReadWritableState, []lifecycle.OpaqueState) {
+	fake.checkCommitReadinessForDefinitionsMutex.RLock()
+	defer fake.checkCommitReadinessForDefinitionsMutex.RUnlock()
+	argsForCall := fake.checkCommitReadinessForDefinitionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *SCCFunctions) CheckCommitReadinessForDefinitionsReturns(result1 []*lifecycle.CommitReadiness, result2 error) {
+	fake.checkCommitReadinessForDefinitionsMutex.Lock()
+	defer fake.checkCommitReadinessForDefinitionsMutex.Unlock()
+	fake.CheckCommitReadinessForDefinitionsStub = nil
+	fake.checkCommitReadinessForDefinitionsReturns = struct {
+		result1 []*lifecycle.CommitReadiness
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) CheckCommitReadinessForDefinitionsReturnsOnCall(i int, result1 []*lifecycle.CommitReadiness, result2 error) {
+	fake.checkCommitReadinessForDefinitionsMutex.Lock()
+	defer fake.checkCommitReadinessForDefinitionsMutex.Unlock()
+	fake.CheckCommitReadinessForDefinitionsStub = nil
+	if fake.checkCommitReadinessForDefinitionsReturnsOnCall == nil {
+		fake.checkCommitReadinessForDefinitionsReturnsOnCall = make(map[int]struct {
+			result1 []*lifecycle.CommitReadiness
+			result2 error
+		})
+	}
+	fake.checkCommitReadinessForDefinitionsReturnsOnCall[i] = struct {
+		result1 []*lifecycle.CommitReadiness
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *SCCFunctions) CommitChaincodeDefinition(arg1 string, arg2 string, arg3 *lifecycle.ChaincodeDefinition, arg4 lifecycle.ReadWritableState, arg5 []lifecycle.OpaqueState) (map[string]bool, error) {
 	var arg5Copy []lifecycle.OpaqueState
 	if arg5 != nil {
@@ -380,6 +570,82 @@ func (fake *SCCFunctions) CommitChaincodeDefinitionReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
+func (fake *SCCFunctions) CommitChaincodeDefinitions(arg1 string, arg2 []*lifecycle.BatchDefinition, arg3 lifecycle.ReadWritableState, arg4 []lifecycle.OpaqueState) ([]map[string]bool, error) {
+	var arg2Copy []*lifecycle.BatchDefinition
+	if arg2 != nil {
+		arg2Copy = make([]*lifecycle.BatchDefinition, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg4Copy []lifecycle.OpaqueState
+	if arg4 != nil {
+		arg4Copy = make([]lifecycle.OpaqueState, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.commitChaincodeDefinitionsMutex.Lock()
+	ret, specificReturn := fake.commitChaincodeDefinitionsReturnsOnCall[len(fake.commitChaincodeDefinitionsArgsForCall)]
+	fake.commitChaincodeDefinitionsArgsForCall = append(fake.commitChaincodeDefinitionsArgsForCall, struct {
+		arg1 string
+		arg2 []*lifecycle.BatchDefinition
+		arg3 lifecycle.ReadWritableState
+		arg4 []lifecycle.OpaqueState
+	}{arg1, arg2Copy, arg3, arg4Copy})
+	fake.recordInvocation("CommitChaincodeDefinitions", []interface{}{arg1, arg2Copy, arg3, arg4Copy})
+	fake.commitChaincodeDefinitionsMutex.Unlock()
+	if fake.CommitChaincodeDefinitionsStub != nil {
+		return fake.CommitChaincodeDefinitionsStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.commitChaincodeDefinitionsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionsCallCount() int {
+	fake.commitChaincodeDefinitionsMutex.RLock()
+	defer fake.commitChaincodeDefinitionsMutex.RUnlock()
+	return len(fake.commitChaincodeDefinitionsArgsForCall)
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionsCalls(stub func(string, []*lifecycle.BatchDefinition, lifecycle.ReadWritableState, []lifecycle.OpaqueState) ([]map[string]bool, error)) {
+	fake.commitChaincodeDefinitionsMutex.Lock()
+	defer fake.commitChaincodeDefinitionsMutex.Unlock()
+	fake.CommitChaincodeDefinitionsStub = stub
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionsArgsForCall(i int) (string, []*lifecycle.BatchDefinition, lifecycle.ReadWritableState, []lifecycle.OpaqueState) {
+	fake.commitChaincodeDefinitionsMutex.RLock()
+	defer fake.commitChaincodeDefinitionsMutex.RUnlock()
+	argsForCall := fake.commitChaincodeDefinitionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionsReturns(result1 []map[string]bool, result2 error) {
+	fake.commitChaincodeDefinitionsMutex.Lock()
+	defer fake.commitChaincodeDefinitionsMutex.Unlock()
+	fake.CommitChaincodeDefinitionsStub = nil
+	fake.commitChaincodeDefinitionsReturns = struct {
+		result1 []map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *SCCFunctions) CommitChaincodeDefinitionsReturnsOnCall(i int, result1 []map[string]bool, result2 error) {
+	fake.commitChaincodeDefinitionsMutex.Lock()
+	defer fake.commitChaincodeDefinitionsMutex.Unlock()
+	fake.CommitChaincodeDefinitionsStub = nil
+	if fake.commitChaincodeDefinitionsReturnsOnCall == nil {
+		fake.commitChaincodeDefinitionsReturnsOnCall = make(map[int]struct {
+			result1 []map[string]bool
+			result2 error
+		})
+	}
+	fake.commitChaincodeDefinitionsReturnsOnCall[i] = struct {
+		result1 []map[string]bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *SCCFunctions) GetInstalledChaincodePackage(arg1 string) ([]byte, error) {
 	fake.getInstalledChaincodePackageMutex.Lock()
 	ret, specificReturn := fake.getInstalledChaincodePackageReturnsOnCall[len(fake.getInstalledChaincodePackageArgsForCall)]
@@ -895,10 +1161,16 @@ func (fake *SCCFunctions) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.approveChaincodeDefinitionForOrgMutex.RLock()
 	defer fake.approveChaincodeDefinitionForOrgMutex.RUnlock()
+	fake.approveChaincodeDefinitionsForOrgMutex.RLock()
+	defer fake.approveChaincodeDefinitionsForOrgMutex.RUnlock()
 	fake.checkCommitReadinessMutex.RLock()
 	defer fake.checkCommitReadinessMutex.RUnlock()
+	fake.checkCommitReadinessForDefinitionsMutex.RLock()
+	defer fake.checkCommitReadinessForDefinitionsMutex.RUnlock()
 	fake.commitChaincodeDefinitionMutex.RLock()
 	defer fake.commitChaincodeDefinitionMutex.RUnlock()
+	fake.commitChaincodeDefinitionsMutex.RLock()
+	defer fake.commitChaincodeDefinitionsMutex.RUnlock()
 	fake.getInstalledChaincodePackageMutex.RLock()
 	defer fake.getInstalledChaincodePackageMutex.RUnlock()
 	fake.installChaincodeMutex.RLock()
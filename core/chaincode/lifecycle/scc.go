@@ -20,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/chaincode/implicitcollection"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/batch"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/core/ledger"
@@ -71,6 +72,20 @@ const (
 	// QueryChaincodeDefinitionsFuncName is the chaincode function name used to
 	// query the committed chaincode definitions in a channel.
 	QueryChaincodeDefinitionsFuncName = "QueryChaincodeDefinitions"
+
+	// ApproveChaincodeDefinitionsForMyOrgFuncName is the chaincode function
+	// name used to approve several chaincode definitions for execution by the
+	// user's own org in a single transaction
+	ApproveChaincodeDefinitionsForMyOrgFuncName = "ApproveChaincodeDefinitionsForMyOrg"
+
+	// CheckCommitReadinessForDefinitionsFuncName is the chaincode function
+	// name used to check whether several chaincode definitions are ready to
+	// be committed. It returns the approval status of each definition
+	CheckCommitReadinessForDefinitionsFuncName = "CheckCommitReadinessForDefinitions"
+
+	// CommitChaincodeDefinitionsFuncName is the chaincode function name used
+	// to commit several chaincode definitions in a single transaction
+	CommitChaincodeDefinitionsFuncName = "CommitChaincodeDefinitions"
 )
 
 // SCCFunctions provides a backing implementation with concrete arguments
@@ -92,6 +107,10 @@ type SCCFunctions interface {
 	// ApproveChaincodeDefinitionForOrg records a chaincode definition into this org's implicit collection.
 	ApproveChaincodeDefinitionForOrg(chname, ccname string, cd *ChaincodeDefinition, packageID string, publicState ReadableState, orgState ReadWritableState) error
 
+	// ApproveChaincodeDefinitionsForOrg records several chaincode definitions into this org's
+	// implicit collection, provided that all of them may be approved.
+	ApproveChaincodeDefinitionsForOrg(chname string, definitions []*BatchDefinition, publicState ReadableState, orgState ReadWritableState) error
+
 	// QueryApprovedChaincodeDefinition returns a approved chaincode definition from this org's implicit collection.
 	QueryApprovedChaincodeDefinition(chname, ccname string, sequence int64, publicState ReadableState, orgState ReadableState) (*ApprovedChaincodeDefinition, error)
 
@@ -100,11 +119,20 @@ type SCCFunctions interface {
 	// the specified definition.
 	CheckCommitReadiness(chname, ccname string, cd *ChaincodeDefinition, publicState ReadWritableState, orgStates []OpaqueState) (map[string]bool, error)
 
+	// CheckCommitReadinessForDefinitions returns the commit readiness of
+	// each of the supplied definitions.
+	CheckCommitReadinessForDefinitions(chname string, definitions []*BatchDefinition, publicState ReadWritableState, orgStates []OpaqueState) ([]*CommitReadiness, error)
+
 	// CommitChaincodeDefinition records a new chaincode definition into the
 	// public state and returns a map containing the orgs whose orgStates
 	// were supplied and whether or not they have approved the definition.
 	CommitChaincodeDefinition(chname, ccname string, cd *ChaincodeDefinition, publicState ReadWritableState, orgStates []OpaqueState) (map[string]bool, error)
 
+	// CommitChaincodeDefinitions records several chaincode definitions into
+	// the public state, provided that all of them are valid, and returns the
+	// org approvals of each definition.
+	CommitChaincodeDefinitions(chname string, definitions []*BatchDefinition, publicState ReadWritableState, orgStates []OpaqueState) ([]map[string]bool, error)
+
 	// QueryChaincodeDefinition returns a chaincode definition from the public
 	// state.
 	QueryChaincodeDefinition(name string, publicState ReadableState) (*ChaincodeDefinition, error)
@@ -365,6 +393,63 @@ func (i *Invocation) ApproveChaincodeDefinitionForMyOrg(input *lb.ApproveChainco
 		return nil, errors.WithMessage(err, "error validating chaincode definition")
 	}
 	collectionName := implicitcollection.NameForOrg(i.SCC.OrgMSPID)
+	cd, packageID := approvalDefinition(input)
+
+	logger.Debugf("received invocation of ApproveChaincodeDefinitionForMyOrg on channel '%s' for definition '%s'",
+		i.Stub.GetChannelID(),
+		cd,
+	)
+
+	if err := i.SCC.Functions.ApproveChaincodeDefinitionForOrg(
+		i.Stub.GetChannelID(),
+		input.Name,
+		cd,
+		packageID,
+		i.Stub,
+		&ChaincodePrivateLedgerShim{
+			Collection: collectionName,
+			Stub:       i.Stub,
+		},
+	); err != nil {
+		return nil, err
+	}
+	return &lb.ApproveChaincodeDefinitionForMyOrgResult{}, nil
+}
+
+// ApproveChaincodeDefinitionsForMyOrg is a SCC function that may be
+// dispatched to which routes to the underlying lifecycle implementation.
+func (i *Invocation) ApproveChaincodeDefinitionsForMyOrg(input *batch.ApproveChaincodeDefinitionsForMyOrgArgs) (proto.Message, error) {
+	definitions := make([]*BatchDefinition, len(input.Definitions))
+	for j, args := range input.Definitions {
+		if err := i.validateInput(args.Name, args.Version, args.Collections); err != nil {
+			return nil, errors.WithMessagef(err, "error validating chaincode definition %d", j)
+		}
+		cd, packageID := approvalDefinition(args)
+		definitions[j] = &BatchDefinition{Name: args.Name, Definition: cd, PackageID: packageID}
+	}
+
+	logger.Debugf("received invocation of ApproveChaincodeDefinitionsForMyOrg on channel '%s' for %d definitions",
+		i.Stub.GetChannelID(),
+		len(definitions),
+	)
+
+	if err := i.SCC.Functions.ApproveChaincodeDefinitionsForOrg(
+		i.Stub.GetChannelID(),
+		definitions,
+		i.Stub,
+		&ChaincodePrivateLedgerShim{
+			Collection: implicitcollection.NameForOrg(i.SCC.OrgMSPID),
+			Stub:       i.Stub,
+		},
+	); err != nil {
+		return nil, err
+	}
+	return &batch.ApproveChaincodeDefinitionsForMyOrgResult{}, nil
+}
+
+// approvalDefinition returns the chaincode definition and the package ID
+// of the approval arguments.
+func approvalDefinition(input *lb.ApproveChaincodeDefinitionForMyOrgArgs) (*ChaincodeDefinition, string) {
 	var collectionConfig []*pb.CollectionConfig
 	if input.Collections != nil {
 		collectionConfig = input.Collections.Config
@@ -396,25 +481,7 @@ func (i *Invocation) ApproveChaincodeDefinitionForMyOrg(input *lb.ApproveChainco
 		},
 	}
 
-	logger.Debugf("received invocation of ApproveChaincodeDefinitionForMyOrg on channel '%s' for definition '%s'",
-		i.Stub.GetChannelID(),
-		cd,
-	)
-
-	if err := i.SCC.Functions.ApproveChaincodeDefinitionForOrg(
-		i.Stub.GetChannelID(),
-		input.Name,
-		cd,
-		packageID,
-		i.Stub,
-		&ChaincodePrivateLedgerShim{
-			Collection: collectionName,
-			Stub:       i.Stub,
-		},
-	); err != nil {
-		return nil, err
-	}
-	return &lb.ApproveChaincodeDefinitionForMyOrgResult{}, nil
+	return cd, packageID
 }
 
 // QueryApprovedChaincodeDefinition is a SCC function that may be dispatched
@@ -460,19 +527,7 @@ func (i *Invocation) CheckCommitReadiness(input *lb.CheckCommitReadinessArgs) (p
 		return nil, err
 	}
 
-	cd := &ChaincodeDefinition{
-		Sequence: input.Sequence,
-		EndorsementInfo: &lb.ChaincodeEndorsementInfo{
-			Version:           input.Version,
-			EndorsementPlugin: input.EndorsementPlugin,
-			InitRequired:      input.InitRequired,
-		},
-		ValidationInfo: &lb.ChaincodeValidationInfo{
-			ValidationPlugin:    input.ValidationPlugin,
-			ValidationParameter: input.ValidationParameter,
-		},
-		Collections: input.Collections,
-	}
+	cd := definition(input.Sequence, input.Version, input.EndorsementPlugin, input.ValidationPlugin, input.ValidationParameter, input.InitRequired, input.Collections)
 
 	logger.Debugf("received invocation of CheckCommitReadiness on channel '%s' for definition '%s'",
 		i.Stub.GetChannelID(),
@@ -495,48 +550,67 @@ func (i *Invocation) CheckCommitReadiness(input *lb.CheckCommitReadinessArgs) (p
 	}, nil
 }
 
-// CommitChaincodeDefinition is a SCC function that may be dispatched
-// to which routes to the underlying lifecycle implementation.
-func (i *Invocation) CommitChaincodeDefinition(input *lb.CommitChaincodeDefinitionArgs) (proto.Message, error) {
-	if err := i.validateInput(input.Name, input.Version, input.Collections); err != nil {
-		return nil, errors.WithMessage(err, "error validating chaincode definition")
+// CheckCommitReadinessForDefinitions is a SCC function that may be
+// dispatched to the underlying lifecycle implementation.
+func (i *Invocation) CheckCommitReadinessForDefinitions(input *batch.CheckCommitReadinessForDefinitionsArgs) (proto.Message, error) {
+	opaqueStates, err := i.createOpaqueStates()
+	if err != nil {
+		return nil, err
 	}
 
-	if i.ApplicationConfig == nil {
-		return nil, errors.Errorf("no application config for channel '%s'", i.Stub.GetChannelID())
+	definitions := make([]*BatchDefinition, len(input.Definitions))
+	for j, args := range input.Definitions {
+		definitions[j] = &BatchDefinition{
+			Name:       args.Name,
+			Definition: definition(args.Sequence, args.Version, args.EndorsementPlugin, args.ValidationPlugin, args.ValidationParameter, args.InitRequired, args.Collections),
+		}
 	}
 
-	orgs := i.ApplicationConfig.Organizations()
-	opaqueStates := make([]OpaqueState, 0, len(orgs))
-	var myOrg string
-	for _, org := range orgs {
-		opaqueStates = append(opaqueStates, &ChaincodePrivateLedgerShim{
-			Collection: implicitcollection.NameForOrg(org.MSPID()),
-			Stub:       i.Stub,
-		})
-		if org.MSPID() == i.SCC.OrgMSPID {
-			myOrg = i.SCC.OrgMSPID
+	logger.Debugf("received invocation of CheckCommitReadinessForDefinitions on channel '%s' for %d definitions",
+		i.Stub.GetChannelID(),
+		len(definitions),
+	)
+
+	readiness, err := i.SCC.Functions.CheckCommitReadinessForDefinitions(
+		i.Stub.GetChannelID(),
+		definitions,
+		i.Stub,
+		opaqueStates,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &batch.CheckCommitReadinessForDefinitionsResult{}
+	for j, r := range readiness {
+		definitionReadiness := &batch.CheckCommitReadinessForDefinitionsResult_Readiness{
+			Name:      definitions[j].Name,
+			Sequence:  definitions[j].Definition.Sequence,
+			Approvals: r.Approvals,
+		}
+		if r.Err != nil {
+			definitionReadiness.Error = r.Err.Error()
 		}
+		result.Definitions = append(result.Definitions, definitionReadiness)
 	}
 
-	if myOrg == "" {
-		return nil, errors.Errorf("impossibly, this peer's org is processing requests for a channel it is not a member of")
+	return result, nil
+}
+
+// CommitChaincodeDefinition is a SCC function that may be dispatched
+// to which routes to the underlying lifecycle implementation.
+func (i *Invocation) CommitChaincodeDefinition(input *lb.CommitChaincodeDefinitionArgs) (proto.Message, error) {
+	if err := i.validateInput(input.Name, input.Version, input.Collections); err != nil {
+		return nil, errors.WithMessage(err, "error validating chaincode definition")
 	}
 
-	cd := &ChaincodeDefinition{
-		Sequence: input.Sequence,
-		EndorsementInfo: &lb.ChaincodeEndorsementInfo{
-			Version:           input.Version,
-			EndorsementPlugin: input.EndorsementPlugin,
-			InitRequired:      input.InitRequired,
-		},
-		ValidationInfo: &lb.ChaincodeValidationInfo{
-			ValidationPlugin:    input.ValidationPlugin,
-			ValidationParameter: input.ValidationParameter,
-		},
-		Collections: input.Collections,
+	opaqueStates, myOrg, err := i.commitOrgStates()
+	if err != nil {
+		return nil, err
 	}
 
+	cd := definition(input.Sequence, input.Version, input.EndorsementPlugin, input.ValidationPlugin, input.ValidationParameter, input.InitRequired, input.Collections)
+
 	logger.Debugf("received invocation of CommitChaincodeDefinition on channel '%s' for definition '%s'",
 		i.Stub.GetChannelID(),
 		cd,
@@ -562,6 +636,96 @@ func (i *Invocation) CommitChaincodeDefinition(input *lb.CommitChaincodeDefiniti
 	return &lb.CommitChaincodeDefinitionResult{}, nil
 }
 
+// CommitChaincodeDefinitions is a SCC function that may be dispatched
+// to which routes to the underlying lifecycle implementation.
+func (i *Invocation) CommitChaincodeDefinitions(input *batch.CommitChaincodeDefinitionsArgs) (proto.Message, error) {
+	definitions := make([]*BatchDefinition, len(input.Definitions))
+	for j, args := range input.Definitions {
+		if err := i.validateInput(args.Name, args.Version, args.Collections); err != nil {
+			return nil, errors.WithMessagef(err, "error validating chaincode definition %d", j)
+		}
+		definitions[j] = &BatchDefinition{
+			Name:       args.Name,
+			Definition: definition(args.Sequence, args.Version, args.EndorsementPlugin, args.ValidationPlugin, args.ValidationParameter, args.InitRequired, args.Collections),
+		}
+	}
+
+	opaqueStates, myOrg, err := i.commitOrgStates()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debugf("received invocation of CommitChaincodeDefinitions on channel '%s' for %d definitions",
+		i.Stub.GetChannelID(),
+		len(definitions),
+	)
+
+	approvals, err := i.SCC.Functions.CommitChaincodeDefinitions(
+		i.Stub.GetChannelID(),
+		definitions,
+		i.Stub,
+		opaqueStates,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, d := range definitions {
+		if !approvals[j][myOrg] {
+			return nil, errors.Errorf("chaincode definition for chaincode '%s' not agreed to by this org (%s)", d.Name, i.SCC.OrgMSPID)
+		}
+	}
+
+	logger.Infof("Successfully endorsed commit of %d chaincode definitions on channel '%s'", len(definitions), i.Stub.GetChannelID())
+
+	return &batch.CommitChaincodeDefinitionsResult{}, nil
+}
+
+// commitOrgStates returns the implicit collection states of the orgs of the
+// channel and the MSP ID of this peer's org.
+func (i *Invocation) commitOrgStates() ([]OpaqueState, string, error) {
+	if i.ApplicationConfig == nil {
+		return nil, "", errors.Errorf("no application config for channel '%s'", i.Stub.GetChannelID())
+	}
+
+	orgs := i.ApplicationConfig.Organizations()
+	opaqueStates := make([]OpaqueState, 0, len(orgs))
+	var myOrg string
+	for _, org := range orgs {
+		opaqueStates = append(opaqueStates, &ChaincodePrivateLedgerShim{
+			Collection: implicitcollection.NameForOrg(org.MSPID()),
+			Stub:       i.Stub,
+		})
+		if org.MSPID() == i.SCC.OrgMSPID {
+			myOrg = i.SCC.OrgMSPID
+		}
+	}
+
+	if myOrg == "" {
+		return nil, "", errors.Errorf("impossibly, this peer's org is processing requests for a channel it is not a member of")
+	}
+
+	return opaqueStates, myOrg, nil
+}
+
+// definition returns the chaincode definition of the arguments of a
+// readiness check or a commit.
+func definition(sequence int64, version, endorsementPlugin, validationPlugin string, validationParameter []byte, initRequired bool, collections *pb.CollectionConfigPackage) *ChaincodeDefinition {
+	return &ChaincodeDefinition{
+		Sequence: sequence,
+		EndorsementInfo: &lb.ChaincodeEndorsementInfo{
+			Version:           version,
+			EndorsementPlugin: endorsementPlugin,
+			InitRequired:      initRequired,
+		},
+		ValidationInfo: &lb.ChaincodeValidationInfo{
+			ValidationPlugin:    validationPlugin,
+			ValidationParameter: validationParameter,
+		},
+		Collections: collections,
+	}
+}
+
 // QueryChaincodeDefinition is a SCC function that may be dispatched
 // to which routes to the underlying lifecycle implementation.
 func (i *Invocation) QueryChaincodeDefinition(input *lb.QueryChaincodeDefinitionArgs) (proto.Message, error) {
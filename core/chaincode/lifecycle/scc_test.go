@@ -22,10 +22,12 @@ import (
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/batch"
 	"github.com/hyperledger/fabric/core/chaincode/lifecycle/mock"
 	"github.com/hyperledger/fabric/core/chaincode/persistence"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 
 	. "github.com/onsi/ginkgo"
@@ -1191,6 +1193,240 @@ var _ = Describe("SCC", func() {
 			})
 		})
 
+		Describe("ApproveChaincodeDefinitionsForMyOrg", func() {
+			var arg *batch.ApproveChaincodeDefinitionsForMyOrgArgs
+
+			BeforeEach(func() {
+				arg = &batch.ApproveChaincodeDefinitionsForMyOrgArgs{
+					Definitions: []*lb.ApproveChaincodeDefinitionForMyOrgArgs{
+						{
+							Name:     "cc-name",
+							Version:  "version",
+							Sequence: 2,
+							Source: &lb.ChaincodeSource{
+								Type: &lb.ChaincodeSource_LocalPackage{
+									LocalPackage: &lb.ChaincodeSource_Local{
+										PackageId: "package-id",
+									},
+								},
+							},
+						},
+						{
+							Name:     "other-cc",
+							Version:  "version",
+							Sequence: 1,
+						},
+					},
+				}
+				fakeStub.GetArgsReturns([][]byte{[]byte("ApproveChaincodeDefinitionsForMyOrg"), protoutil.MarshalOrPanic(arg)})
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Message).To(Equal(""))
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &batch.ApproveChaincodeDefinitionsForMyOrgResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSCCFuncs.ApproveChaincodeDefinitionsForOrgCallCount()).To(Equal(1))
+				chname, definitions, pubState, privState := fakeSCCFuncs.ApproveChaincodeDefinitionsForOrgArgsForCall(0)
+				Expect(chname).To(Equal("test-channel"))
+				Expect(definitions).To(HaveLen(2))
+				Expect(definitions[0].Name).To(Equal("cc-name"))
+				Expect(definitions[0].PackageID).To(Equal("package-id"))
+				Expect(definitions[0].Definition.Sequence).To(Equal(int64(2)))
+				Expect(definitions[1].Name).To(Equal("other-cc"))
+				Expect(definitions[1].PackageID).To(Equal(""))
+				Expect(definitions[1].Definition.EndorsementInfo.Version).To(Equal("version"))
+				Expect(pubState).To(Equal(fakeStub))
+				Expect(privState).To(Equal(&lifecycle.ChaincodePrivateLedgerShim{
+					Collection: "_implicit_org_fake-mspid",
+					Stub:       fakeStub,
+				}))
+			})
+
+			Context("when one of the definitions is invalid", func() {
+				BeforeEach(func() {
+					arg.Definitions[1].Version = "$money$"
+					fakeStub.GetArgsReturns([][]byte{[]byte("ApproveChaincodeDefinitionsForMyOrg"), protoutil.MarshalOrPanic(arg)})
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'ApproveChaincodeDefinitionsForMyOrg': error validating chaincode definition 1: invalid chaincode version '$money$'. Versions can only consist of alphanumerics, '_', '-', '+', and '.'"))
+					Expect(fakeSCCFuncs.ApproveChaincodeDefinitionsForOrgCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the backing implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.ApproveChaincodeDefinitionsForOrgReturns(fmt.Errorf("fake-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'ApproveChaincodeDefinitionsForMyOrg': fake-error"))
+				})
+			})
+		})
+
+		Describe("CommitChaincodeDefinitions", func() {
+			var arg *batch.CommitChaincodeDefinitionsArgs
+
+			BeforeEach(func() {
+				arg = &batch.CommitChaincodeDefinitionsArgs{
+					Definitions: []*lb.CommitChaincodeDefinitionArgs{
+						{Name: "cc-name", Version: "version", Sequence: 2},
+						{Name: "other-cc", Version: "version", Sequence: 1},
+					},
+				}
+				fakeStub.GetArgsReturns([][]byte{[]byte("CommitChaincodeDefinitions"), protoutil.MarshalOrPanic(arg)})
+
+				fakeOrgConfigs := []*mock.ApplicationOrgConfig{{}, {}}
+				fakeOrgConfigs[0].MSPIDReturns("fake-mspid")
+				fakeOrgConfigs[1].MSPIDReturns("other-mspid")
+				fakeApplicationConfig.OrganizationsReturns(map[string]channelconfig.ApplicationOrg{
+					"org0": fakeOrgConfigs[0],
+					"org1": fakeOrgConfigs[1],
+				})
+
+				fakeSCCFuncs.CommitChaincodeDefinitionsReturns([]map[string]bool{
+					{"fake-mspid": true, "other-mspid": false},
+					{"fake-mspid": true, "other-mspid": true},
+				}, nil)
+			})
+
+			It("passes the arguments to and returns the results from the backing scc function implementation", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Message).To(Equal(""))
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &batch.CommitChaincodeDefinitionsResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeSCCFuncs.CommitChaincodeDefinitionsCallCount()).To(Equal(1))
+				chname, definitions, pubState, orgStates := fakeSCCFuncs.CommitChaincodeDefinitionsArgsForCall(0)
+				Expect(chname).To(Equal("test-channel"))
+				Expect(definitions).To(HaveLen(2))
+				Expect(definitions[0].Name).To(Equal("cc-name"))
+				Expect(definitions[0].Definition.Sequence).To(Equal(int64(2)))
+				Expect(definitions[1].Name).To(Equal("other-cc"))
+				Expect(definitions[1].Definition.Sequence).To(Equal(int64(1)))
+				Expect(pubState).To(Equal(fakeStub))
+				Expect(orgStates).To(HaveLen(2))
+			})
+
+			Context("when one of the definitions is not agreed to by this org", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.CommitChaincodeDefinitionsReturns([]map[string]bool{
+						{"fake-mspid": true, "other-mspid": false},
+						{"fake-mspid": false, "other-mspid": true},
+					}, nil)
+				})
+
+				It("returns an error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'CommitChaincodeDefinitions': chaincode definition for chaincode 'other-cc' not agreed to by this org (fake-mspid)"))
+				})
+			})
+
+			Context("when one of the definitions is invalid", func() {
+				BeforeEach(func() {
+					arg.Definitions[0].Name = "_invalid"
+					fakeStub.GetArgsReturns([][]byte{[]byte("CommitChaincodeDefinitions"), protoutil.MarshalOrPanic(arg)})
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'CommitChaincodeDefinitions': error validating chaincode definition 0: invalid chaincode name '_invalid'. Names can only consist of alphanumerics, '_', and '-' and can only begin with alphanumerics"))
+				})
+			})
+
+			Context("when the backing implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.CommitChaincodeDefinitionsReturns(nil, fmt.Errorf("fake-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'CommitChaincodeDefinitions': fake-error"))
+				})
+			})
+		})
+
+		Describe("CheckCommitReadinessForDefinitions", func() {
+			BeforeEach(func() {
+				arg := &batch.CheckCommitReadinessForDefinitionsArgs{
+					Definitions: []*lb.CheckCommitReadinessArgs{
+						{Name: "cc-name", Version: "version", Sequence: 2},
+						{Name: "other-cc", Version: "version", Sequence: 3},
+					},
+				}
+				fakeStub.GetArgsReturns([][]byte{[]byte("CheckCommitReadinessForDefinitions"), protoutil.MarshalOrPanic(arg)})
+
+				fakeOrgConfigs := []*mock.ApplicationOrgConfig{{}, {}}
+				fakeOrgConfigs[0].MSPIDReturns("fake-mspid")
+				fakeOrgConfigs[1].MSPIDReturns("other-mspid")
+				fakeApplicationConfig.OrganizationsReturns(map[string]channelconfig.ApplicationOrg{
+					"org0": fakeOrgConfigs[0],
+					"org1": fakeOrgConfigs[1],
+				})
+
+				fakeSCCFuncs.CheckCommitReadinessForDefinitionsReturns([]*lifecycle.CommitReadiness{
+					{Approvals: map[string]bool{"fake-mspid": true, "other-mspid": false}},
+					{Err: fmt.Errorf("requested sequence is 3, but new definition must be sequence 1")},
+				}, nil)
+			})
+
+			It("returns the readiness of each definition", func() {
+				res := scc.Invoke(fakeStub)
+				Expect(res.Message).To(Equal(""))
+				Expect(res.Status).To(Equal(int32(200)))
+				payload := &batch.CheckCommitReadinessForDefinitionsResult{}
+				err := proto.Unmarshal(res.Payload, payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(proto.Equal(payload, &batch.CheckCommitReadinessForDefinitionsResult{
+					Definitions: []*batch.CheckCommitReadinessForDefinitionsResult_Readiness{
+						{
+							Name:      "cc-name",
+							Sequence:  2,
+							Approvals: map[string]bool{"fake-mspid": true, "other-mspid": false},
+						},
+						{
+							Name:     "other-cc",
+							Sequence: 3,
+							Error:    "requested sequence is 3, but new definition must be sequence 1",
+						},
+					},
+				})).To(BeTrue())
+
+				Expect(fakeSCCFuncs.CheckCommitReadinessForDefinitionsCallCount()).To(Equal(1))
+				chname, definitions, pubState, orgStates := fakeSCCFuncs.CheckCommitReadinessForDefinitionsArgsForCall(0)
+				Expect(chname).To(Equal("test-channel"))
+				Expect(definitions).To(HaveLen(2))
+				Expect(pubState).To(Equal(fakeStub))
+				Expect(orgStates).To(HaveLen(2))
+			})
+
+			Context("when the backing implementation fails", func() {
+				BeforeEach(func() {
+					fakeSCCFuncs.CheckCommitReadinessForDefinitionsReturns(nil, fmt.Errorf("fake-error"))
+				})
+
+				It("wraps and returns the error", func() {
+					res := scc.Invoke(fakeStub)
+					Expect(res.Status).To(Equal(int32(500)))
+					Expect(res.Message).To(Equal("failed to invoke backing implementation of 'CheckCommitReadinessForDefinitions': fake-error"))
+				})
+			})
+		})
+
 		Describe("CheckCommitReadiness", func() {
 			var (
 				err            error
@@ -0,0 +1,60 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"sync"
+
+	"github.com/hyperledger/fabric/common/policies"
+)
+
+// PolicyTraceStore keeps the endorsement policy evaluation traces of the
+// most recent transactions of a channel which were marked invalid with
+// ENDORSEMENT_POLICY_FAILURE. The traces are kept in memory only and do not
+// affect the validation result.
+type PolicyTraceStore struct {
+	mutex    sync.Mutex
+	capacity int
+	txIDs    []string
+	traces   map[string]*policies.EvaluationTrace
+}
+
+// NewPolicyTraceStore returns a store which keeps at most capacity traces,
+// evicting the oldest ones first.
+func NewPolicyTraceStore(capacity int) *PolicyTraceStore {
+	return &PolicyTraceStore{
+		capacity: capacity,
+		traces:   map[string]*policies.EvaluationTrace{},
+	}
+}
+
+// Put stores the trace of the transaction.
+func (s *PolicyTraceStore) Put(txID string, trace *policies.EvaluationTrace) {
+	if s.capacity <= 0 {
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.traces[txID]; !ok {
+		if len(s.txIDs) == s.capacity {
+			delete(s.traces, s.txIDs[0])
+			s.txIDs = s.txIDs[1:]
+		}
+		s.txIDs = append(s.txIDs, txID)
+	}
+	s.traces[txID] = trace
+}
+
+// Get returns the trace of the transaction, or nil if there is none.
+func (s *PolicyTraceStore) Get(txID string) *policies.EvaluationTrace {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.traces[txID]
+}
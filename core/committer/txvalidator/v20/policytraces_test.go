@@ -0,0 +1,42 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package txvalidator
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyTraceStore(t *testing.T) {
+	store := NewPolicyTraceStore(2)
+	trace1 := &policies.EvaluationTrace{Rule: "OutOf(1)"}
+	trace2 := &policies.EvaluationTrace{Rule: "OutOf(2)"}
+	trace3 := &policies.EvaluationTrace{Rule: "OutOf(3)"}
+
+	require.Nil(t, store.Get("tx1"))
+
+	store.Put("tx1", trace1)
+	store.Put("tx2", trace2)
+	require.Equal(t, trace1, store.Get("tx1"))
+	require.Equal(t, trace2, store.Get("tx2"))
+
+	// storing the trace of a known transaction does not evict another one
+	store.Put("tx2", trace3)
+	require.Equal(t, trace1, store.Get("tx1"))
+	require.Equal(t, trace3, store.Get("tx2"))
+
+	store.Put("tx3", trace3)
+	require.Nil(t, store.Get("tx1"))
+	require.Equal(t, trace3, store.Get("tx2"))
+	require.Equal(t, trace3, store.Get("tx3"))
+
+	store = NewPolicyTraceStore(0)
+	store.Put("tx1", trace1)
+	require.Nil(t, store.Get("tx1"))
+}
@@ -111,6 +111,9 @@ type TxValidator struct {
 	LedgerResources  LedgerResources
 	Dispatcher       Dispatcher
 	CryptoProvider   bccsp.BCCSP
+	// PolicyTraces, when set, keeps the policy evaluation traces of the
+	// transactions failing their endorsement policy.
+	PolicyTraces *PolicyTraceStore
 }
 
 var logger = flogging.MustGetLogger("committer.txvalidator")
@@ -387,6 +390,9 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 					}
 					return
 				default:
+					if cde == peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE {
+						v.recordPolicyTrace(txID, err)
+					}
 					results <- &blockValidationResult{
 						tIdx:           tIdx,
 						validationCode: cde,
@@ -451,6 +457,23 @@ func (v *TxValidator) validateTx(req *blockValidationRequest, results chan<- *bl
 	}
 }
 
+// recordPolicyTrace logs and stores the trace explaining why the transaction
+// failed its endorsement policy, if the policy evaluation produced one.
+// Building the trace verifies the endorsements again, so it is only done
+// when traces are kept.
+func (v *TxValidator) recordPolicyTrace(txID string, err error) {
+	if v.PolicyTraces == nil {
+		return
+	}
+
+	trace := policies.TraceFromError(err)
+	if trace == nil {
+		return
+	}
+	logger.Debugf("Endorsement policy evaluation trace for txId = %s:\n%s", txID, trace)
+	v.PolicyTraces.Put(txID, trace)
+}
+
 // CheckTxIdDupsLedger returns a vlockValidationResult enhanced with the respective
 // error codes if and only if there is transaction with the same transaction identifier
 // in the ledger or no decision can be made for whether such transaction exists;
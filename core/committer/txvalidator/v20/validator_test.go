@@ -22,6 +22,7 @@ import (
 	protospeer "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	commonerrors "github.com/hyperledger/fabric/common/errors"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/hyperledger/fabric/common/semaphore"
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
@@ -42,6 +43,7 @@ import (
 	"github.com/hyperledger/fabric/msp/mgmt"
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protoutil"
+	pkgerrors "github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -1125,6 +1127,83 @@ func TestValidationInvalidEndorsing(t *testing.T) {
 	assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
 }
 
+func TestValidationEndorsementPolicyTrace(t *testing.T) {
+	ccID := "mycc"
+
+	mspmgr := &supportmocks.MSPManager{}
+	mockID := &supportmocks.Identity{}
+	mockID.SatisfiesPrincipalReturns(nil)
+	mockID.GetIdentifierReturns(&msp.IdentityIdentifier{})
+	mspmgr.DeserializeIdentityReturns(mockID, nil)
+
+	trace := &policies.EvaluationTrace{
+		Rule:     "OutOf(1)",
+		Required: 1,
+		SubPolicies: []*policies.EvaluationTrace{
+			{Rule: "SignedBy(Org2MSP.peer)", Mismatches: []string{"SampleOrg: not a member of Org2MSP"}},
+		},
+	}
+	policyErr := policies.NewEvaluationError("signature set did not satisfy policy", func() *policies.EvaluationTrace { return trace })
+
+	pm := &plugindispatchermocks.Mapper{}
+	factory := &plugindispatchermocks.PluginFactory{}
+	pm.On("FactoryByName", txvalidatorplugin.Name("vscc")).Return(factory)
+	plugin := &plugindispatchermocks.Plugin{}
+	factory.On("New").Return(plugin)
+	plugin.On("Init", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	plugin.On("Validate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		&commonerrors.VSCCEndorsementPolicyError{Err: pkgerrors.WithMessage(policyErr, "validation of endorsement policy for chaincode mycc in tx 0:0 failed")},
+	)
+
+	mockQE := &txvalidatormocks.QueryExecutor{}
+	mockQE.On("Done").Return(nil)
+
+	mockLedger := &txvalidatormocks.LedgerResources{}
+	mockLedger.On("TxIDExists", mock.Anything).Return(false, nil)
+	mockLedger.On("NewQueryExecutor").Return(mockQE, nil)
+
+	mockCpmg := &plugindispatchermocks.ChannelPolicyManagerGetter{}
+	mockCpmg.On("Manager", mock.Anything).Return(&txvalidatormocks.PolicyManager{})
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	v := txvalidatorv20.NewTxValidator(
+		"",
+		semaphore.New(10),
+		&mocktxvalidator.Support{ACVal: v20Capabilities(), MSPManagerVal: mspmgr},
+		mockLedger,
+		&lscc.SCC{BCCSP: cryptoProvider},
+		&txvalidatormocks.CollectionResources{},
+		pm,
+		mockCpmg,
+		cryptoProvider,
+	)
+	v.PolicyTraces = txvalidatorv20.NewPolicyTraceStore(10)
+
+	tx := getEnv(ccID, nil, createRWset(t, ccID), t)
+	txID, err := protoutil.GetOrComputeTxIDFromEnvelope(protoutil.MarshalOrPanic(tx))
+	require.NoError(t, err)
+
+	cd := &ccp.ChaincodeData{
+		Name:    ccID,
+		Version: ccVersion,
+		Vscc:    "vscc",
+		Policy:  signedByAnyMember([]string{"SampleOrg"}),
+	}
+	mockQE.On("GetState", "lscc", ccID).Return(protoutil.MarshalOrPanic(cd), nil)
+
+	b := &common.Block{
+		Data:   &common.BlockData{Data: [][]byte{protoutil.MarshalOrPanic(tx)}},
+		Header: &common.BlockHeader{},
+	}
+
+	err = v.Validate(b)
+	require.NoError(t, err)
+	assertInvalid(b, t, peer.TxValidationCode_ENDORSEMENT_POLICY_FAILURE)
+	require.Equal(t, trace, v.PolicyTraces.Get(txID))
+}
+
 func TestValidationPluginExecutionError(t *testing.T) {
 	ccID := "mycc"
 
@@ -0,0 +1,29 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processcontroller
+
+import "github.com/hyperledger/fabric/common/metrics"
+
+var (
+	chaincodeBuildDuration = metrics.HistogramOpts{
+		Namespace:    "processcontroller",
+		Name:         "chaincode_build_duration",
+		Help:         "The time to build a chaincode with the process runtime in seconds.",
+		LabelNames:   []string{"chaincode", "success"},
+		StatsdFormat: "%{#fqname}.%{chaincode}.%{success}",
+	}
+)
+
+type BuildMetrics struct {
+	ChaincodeBuildDuration metrics.Histogram
+}
+
+func NewBuildMetrics(p metrics.Provider) *BuildMetrics {
+	return &BuildMetrics{
+		ChaincodeBuildDuration: p.NewHistogram(chaincodeBuildDuration),
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processcontroller
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/container/externalbuilder"
+	"github.com/pkg/errors"
+)
+
+// A platform builds the chaincode of a chaincode type with a local toolchain
+// and describes how the build output is run.
+type platform interface {
+	// PropagateEnvironment lists the environment variables of the peer
+	// used by the toolchain and the runtime of the platform.
+	PropagateEnvironment() []string
+	// Build builds the chaincode at path in sourceDir into outputDir.
+	Build(b *builder, sourceDir, path, outputDir string) error
+	// RunArgs returns the working directory and the command line of the
+	// chaincode built in bldDir.
+	RunArgs(bldDir, peerAddress string) (dir string, args []string)
+}
+
+var platforms = map[string]platform{
+	pb.ChaincodeSpec_GOLANG.String(): golangPlatform{},
+	pb.ChaincodeSpec_NODE.String():   nodePlatform{},
+	pb.ChaincodeSpec_JAVA.String():   javaPlatform{},
+}
+
+// builder runs the toolchain commands of a chaincode build.
+type builder struct {
+	logger *flogging.FabricLogger
+	env    []string
+}
+
+// run runs a command in dir. The output of the command is logged when the
+// command fails.
+func (b *builder) run(dir string, env []string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Env = append(append([]string{}, b.env...), env...)
+	output := &bytes.Buffer{}
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	b.logger.Debugf("running '%s %s' in %s", name, strings.Join(args, " "), dir)
+	if err := cmd.Run(); err != nil {
+		b.logger.Errorf("Build Output:\n********************\n%s\n********************", output.String())
+		return errors.Wrapf(err, "'%s %s' failed", name, strings.Join(args, " "))
+	}
+	return nil
+}
+
+type golangPlatform struct{}
+
+func (golangPlatform) PropagateEnvironment() []string {
+	return []string{"GOCACHE", "GOFLAGS", "GOPATH", "GOPROXY", "GOSUMDB", "GOPRIVATE", "GONOSUMDB", "GOROOT"}
+}
+
+// Build mirrors the build script of the golang platform used for chaincode
+// images.
+func (golangPlatform) Build(b *builder, sourceDir, path, outputDir string) error {
+	output := filepath.Join(outputDir, "chaincode")
+	for _, dir := range []string{sourceDir, filepath.Join(sourceDir, path)} {
+		if !exists(filepath.Join(dir, "go.mod")) {
+			continue
+		}
+		target := path
+		if dir != sourceDir {
+			target = "."
+		}
+		mod := "-mod=readonly"
+		if exists(filepath.Join(dir, "vendor")) {
+			mod = "-mod=vendor"
+		}
+		return b.run(dir, []string{"GO111MODULE=on"}, "go", "build", mod, "-o", output, target)
+	}
+
+	gopath := filepath.Dir(sourceDir)
+	if val, ok := os.LookupEnv("GOPATH"); ok {
+		gopath = gopath + string(filepath.ListSeparator) + val
+	}
+	return b.run(sourceDir, []string{"GO111MODULE=off", "GOPATH=" + gopath}, "go", "build", "-o", output, path)
+}
+
+func (golangPlatform) RunArgs(bldDir, peerAddress string) (string, []string) {
+	return bldDir, []string{filepath.Join(bldDir, "chaincode"), fmt.Sprintf("-peer.address=%s", peerAddress)}
+}
+
+type nodePlatform struct{}
+
+func (nodePlatform) PropagateEnvironment() []string {
+	return []string{"NODE_PATH", "NPM_CONFIG_CACHE", "NPM_CONFIG_REGISTRY", "NPM_CONFIG_USERCONFIG"}
+}
+
+func (nodePlatform) Build(b *builder, sourceDir, path, outputDir string) error {
+	if err := externalbuilder.CopyDir(b.logger, sourceDir, outputDir); err != nil {
+		return errors.WithMessage(err, "could not copy chaincode source")
+	}
+	return b.run(outputDir, nil, "npm", "install", "--production")
+}
+
+func (nodePlatform) RunArgs(bldDir, peerAddress string) (string, []string) {
+	return bldDir, []string{"npm", "start", "--", "--peer.address", peerAddress}
+}
+
+type javaPlatform struct{}
+
+func (javaPlatform) PropagateEnvironment() []string {
+	return []string{"JAVA_HOME", "GRADLE_USER_HOME", "MAVEN_OPTS", "MAVEN_HOME"}
+}
+
+// Build builds the chaincode jar with gradle or maven, depending on the
+// build file found in the source, and keeps it as chaincode.jar.
+func (javaPlatform) Build(b *builder, sourceDir, path, outputDir string) error {
+	var jar string
+	switch {
+	case exists(filepath.Join(sourceDir, "build.gradle")) || exists(filepath.Join(sourceDir, "build.gradle.kts")):
+		gradle := "gradle"
+		if exists(filepath.Join(sourceDir, "gradlew")) {
+			gradle = filepath.Join(sourceDir, "gradlew")
+		}
+		if err := b.run(sourceDir, nil, gradle, "build", "shadowJar", "-x", "test"); err != nil {
+			return err
+		}
+		jar = filepath.Join(sourceDir, "build", "libs", "chaincode.jar")
+	case exists(filepath.Join(sourceDir, "pom.xml")):
+		if err := b.run(sourceDir, nil, "mvn", "-q", "-DskipTests", "package"); err != nil {
+			return err
+		}
+		jar = filepath.Join(sourceDir, "target", "chaincode.jar")
+	default:
+		return errors.New("no build.gradle or pom.xml found in java chaincode")
+	}
+
+	if !exists(jar) {
+		return errors.Errorf("java chaincode build did not produce '%s'", jar)
+	}
+	return os.Rename(jar, filepath.Join(outputDir, "chaincode.jar"))
+}
+
+func (javaPlatform) RunArgs(bldDir, peerAddress string) (string, []string) {
+	return bldDir, []string{"java", "-jar", filepath.Join(bldDir, "chaincode.jar"), "--peerAddress", peerAddress}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processcontroller
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// cpuPeriod is the cgroup CPU bandwidth period, in microseconds, over which
+// the CPU limit of a chaincode process is enforced.
+const cpuPeriod = 100000
+
+// setProcessGroup makes the command the leader of a new process group so
+// that processes it spawns are signaled with it.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func signalProcessGroup(cmd *exec.Cmd, sig syscall.Signal) {
+	syscall.Kill(-cmd.Process.Pid, sig)
+}
+
+// cgroup is the cgroup v2 of a chaincode process.
+type cgroup struct {
+	path string
+}
+
+// newCgroup creates the cgroup named name under the configured cgroup and
+// applies the configured limits to it.
+func newCgroup(config *CgroupConfig, name string) (*cgroup, error) {
+	var controllers []string
+	if config.CPUs > 0 {
+		controllers = append(controllers, "+cpu")
+	}
+	if config.Memory > 0 {
+		controllers = append(controllers, "+memory")
+	}
+	if len(controllers) != 0 {
+		// enabling the controllers fails when they are already enabled by the
+		// delegating user, any real problem surfaces when setting the limits
+		subtreeControl := filepath.Join(config.Path, "cgroup.subtree_control")
+		if err := ioutil.WriteFile(subtreeControl, []byte(strings.Join(controllers, " ")), 0); err != nil {
+			processLogger.Debugf("could not enable controllers in '%s': %s", subtreeControl, err)
+		}
+	}
+
+	cg := &cgroup{path: filepath.Join(config.Path, name)}
+	if err := os.Mkdir(cg.path, 0755); err != nil && !os.IsExist(err) {
+		return nil, errors.WithMessagef(err, "could not create cgroup '%s'", cg.path)
+	}
+
+	if config.CPUs > 0 {
+		quota := int64(config.CPUs * cpuPeriod)
+		if err := cg.write("cpu.max", strconv.FormatInt(quota, 10)+" "+strconv.Itoa(cpuPeriod)); err != nil {
+			cg.remove()
+			return nil, err
+		}
+	}
+	if config.Memory > 0 {
+		if err := cg.write("memory.max", strconv.FormatInt(config.Memory, 10)); err != nil {
+			cg.remove()
+			return nil, err
+		}
+	}
+
+	return cg, nil
+}
+
+// command wraps the command line args so that the process joins the cgroup
+// before it executes them. Moving the process once started would let the
+// chaincode run, and fork, outside of the limits in the meantime.
+func (c *cgroup) command(args []string) []string {
+	joinAndExec := `echo $$ > "$0" && exec "$@"`
+	return append([]string{"/bin/sh", "-c", joinAndExec, filepath.Join(c.path, "cgroup.procs")}, args...)
+}
+
+func (c *cgroup) write(file, value string) error {
+	path := filepath.Join(c.path, file)
+	if err := ioutil.WriteFile(path, []byte(value), 0); err != nil {
+		return errors.WithMessagef(err, "could not write '%s' to '%s'", value, path)
+	}
+	return nil
+}
+
+// remove removes the cgroup. It fails if processes are still in the cgroup.
+func (c *cgroup) remove() {
+	if err := os.Remove(c.path); err != nil {
+		processLogger.Warningf("could not remove cgroup '%s': %s", c.path, err)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processcontroller
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunInCgroup(t *testing.T) {
+	vm, cleanup := newTestProcessVM(t)
+	defer cleanup()
+
+	// a plain directory stands in for the delegated cgroup, the chaincode
+	// process records its pid in cgroup.procs as it would join a cgroup
+	cgroupDir, err := ioutil.TempDir("", "processcontroller-cgroup")
+	require.NoError(t, err)
+	defer os.RemoveAll(cgroupDir)
+	vm.Cgroup = &CgroupConfig{Path: cgroupDir, CPUs: 0.5, Memory: 1 << 30}
+	// the interface files of a cgroup are created by the kernel
+	cg := filepath.Join(cgroupDir, "cc-1")
+	require.NoError(t, os.Mkdir(cg, 0755))
+	for _, file := range []string{"cpu.max", "memory.max"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(cg, file), nil, 0644))
+	}
+
+	metadata := &persistence.ChaincodePackageMetadata{Type: "golang", Path: "example.com/cc"}
+	instance, err := vm.Build("cc:1", metadata, codePackage(t, map[string]string{
+		"src/go.mod":  "module example.com/cc\n",
+		"src/main.go": testChaincode,
+	}))
+	require.NoError(t, err)
+
+	err = instance.Start(&ccintf.PeerConnection{Address: "peer.example.com:7052"})
+	require.NoError(t, err)
+	defer instance.Stop()
+
+	logPath := filepath.Join(vm.LogDir, "cc-1.log")
+	require.Eventually(t, func() bool {
+		output, _ := ioutil.ReadFile(logPath)
+		return strings.Contains(string(output), "ready")
+	}, 10*time.Second, 50*time.Millisecond)
+
+	output, err := ioutil.ReadFile(logPath)
+	require.NoError(t, err)
+	require.Contains(t, string(output), "args: -peer.address=peer.example.com:7052\n")
+	pid := regexp.MustCompile(`pid: (\d+)\n`).FindStringSubmatch(string(output))
+	require.NotNil(t, pid)
+
+	procs, err := ioutil.ReadFile(filepath.Join(cg, "cgroup.procs"))
+	require.NoError(t, err)
+	require.Equal(t, pid[1], strings.TrimSpace(string(procs)), "the chaincode process joined the cgroup before it started")
+	cpuMax, err := ioutil.ReadFile(filepath.Join(cg, "cpu.max"))
+	require.NoError(t, err)
+	require.Equal(t, "50000 100000", string(cpuMax))
+	memoryMax, err := ioutil.ReadFile(filepath.Join(cg, "memory.max"))
+	require.NoError(t, err)
+	require.Equal(t, "1073741824", string(memoryMax))
+}
+
+func TestRunInCgroupFailure(t *testing.T) {
+	vm, cleanup := newTestProcessVM(t)
+	defer cleanup()
+
+	cgroupDir, err := ioutil.TempDir("", "processcontroller-cgroup")
+	require.NoError(t, err)
+	defer os.RemoveAll(cgroupDir)
+	vm.Cgroup = &CgroupConfig{Path: cgroupDir}
+
+	metadata := &persistence.ChaincodePackageMetadata{Type: "golang", Path: "example.com/cc"}
+	instance, err := vm.Build("cc:1", metadata, codePackage(t, map[string]string{
+		"src/go.mod":  "module example.com/cc\n",
+		"src/main.go": testChaincode,
+	}))
+	require.NoError(t, err)
+
+	// the chaincode is not run when the process cannot join the cgroup
+	require.NoError(t, os.Mkdir(filepath.Join(cgroupDir, "cc-1"), 0755))
+	require.NoError(t, os.Mkdir(filepath.Join(cgroupDir, "cc-1", "cgroup.procs"), 0755))
+	err = instance.Start(&ccintf.PeerConnection{Address: "peer.example.com:7052"})
+	require.NoError(t, err)
+
+	exitCode, err := instance.Wait()
+	require.Error(t, err)
+	require.NotEqual(t, 0, exitCode)
+	output, err := ioutil.ReadFile(filepath.Join(vm.LogDir, "cc-1.log"))
+	require.NoError(t, err)
+	require.NotContains(t, string(output), "args:")
+}
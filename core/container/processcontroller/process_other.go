@@ -0,0 +1,34 @@
+// +build !linux
+
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processcontroller
+
+import (
+	"os/exec"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {}
+
+func signalProcessGroup(cmd *exec.Cmd, sig syscall.Signal) {
+	cmd.Process.Signal(sig)
+}
+
+type cgroup struct{}
+
+func newCgroup(config *CgroupConfig, name string) (*cgroup, error) {
+	return nil, errors.New("cgroup limits are only supported on linux")
+}
+
+func (c *cgroup) command(args []string) []string {
+	return args
+}
+
+func (c *cgroup) remove() {}
@@ -0,0 +1,403 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processcontroller
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/hyperledger/fabric/core/container/externalbuilder"
+	"github.com/pkg/errors"
+)
+
+var (
+	// DefaultPropagateEnvironment enumerates the list of environment variables that are
+	// implicitly propagated to chaincode build and run processes.
+	DefaultPropagateEnvironment = []string{"HOME", "LD_LIBRARY_PATH", "LIBPATH", "PATH", "TMPDIR"}
+
+	processLogger = flogging.MustGetLogger("processcontroller")
+)
+
+// Names of the TLS files written to the run directory of a chaincode process.
+// They mirror the files made available to chaincode containers.
+const (
+	TLSClientKeyPath      string = "client.key"
+	TLSClientCertPath     string = "client.crt"
+	TLSClientKeyFile      string = "client_pem.key"
+	TLSClientCertFile     string = "client_pem.crt"
+	TLSClientRootCertFile string = "peer.crt"
+)
+
+// CgroupConfig holds the cgroup v2 resource limits applied to chaincode
+// processes.
+type CgroupConfig struct {
+	// Path is a cgroup v2 directory delegated to the peer under which a cgroup
+	// is created for each chaincode process.
+	Path string
+	// CPUs is the maximum CPU bandwidth of a chaincode process, in CPUs.
+	// Zero means no limit.
+	CPUs float64
+	// Memory is the maximum memory of a chaincode process, in bytes. Zero
+	// means no limit.
+	Memory int64
+}
+
+// ProcessVM builds chaincode packages with the toolchains installed on the
+// peer host and runs them as child processes of the peer.
+type ProcessVM struct {
+	// BuildDir is where the output of chaincode builds is persisted, in a
+	// directory per package.
+	BuildDir string
+	// LogDir is where the standard output and error of chaincode processes
+	// are captured, in a file per package.
+	LogDir string
+	// MaxLogSize is the size in bytes beyond which the log file of a chaincode
+	// is rotated when the chaincode is started. Zero disables rotation.
+	MaxLogSize int64
+	// PropagateEnvironment lists the environment variables of the peer,
+	// in addition to DefaultPropagateEnvironment, that are visible to chaincode
+	// build and run processes.
+	PropagateEnvironment []string
+	LoggingEnv           []string
+	MSPID                string
+	// Cgroup holds the resource limits of chaincode processes. No limits are
+	// applied when nil.
+	Cgroup *CgroupConfig
+	// TermTimeout is the time a chaincode process is given to exit after
+	// SIGTERM before being killed.
+	TermTimeout  time.Duration
+	BuildMetrics *BuildMetrics
+}
+
+// Build builds the chaincode package unless a previous build of the package
+// is found in the build directory.
+func (vm *ProcessVM) Build(ccid string, metadata *persistence.ChaincodePackageMetadata, codePackage io.Reader) (container.Instance, error) {
+	// The old enum types are capital, but lifecycle tools allow type to be set lower case.
+	ccType := strings.ToUpper(metadata.Type)
+	platform, ok := platforms[ccType]
+	if !ok {
+		return nil, errors.Errorf("chaincode type %s is not supported by the process runtime", ccType)
+	}
+
+	instance := &Instance{
+		CCID:     ccid,
+		Platform: platform,
+		BldDir:   filepath.Join(vm.BuildDir, externalbuilder.SanitizeCCIDPath(ccid)),
+		VM:       vm,
+	}
+
+	_, err := os.Stat(instance.BldDir)
+	switch {
+	case err == nil:
+		processLogger.Debugf("using existing build of chaincode %s", ccid)
+		return instance, nil
+	case !os.IsNotExist(err):
+		return nil, errors.WithMessage(err, "existing build detected, but something went wrong inspecting it")
+	}
+
+	startTime := time.Now()
+	err = vm.build(ccid, platform, metadata.Path, codePackage, instance.BldDir)
+	vm.BuildMetrics.ChaincodeBuildDuration.With(
+		"chaincode", ccid,
+		"success", strconv.FormatBool(err == nil),
+	).Observe(time.Since(startTime).Seconds())
+	if err != nil {
+		return nil, err
+	}
+
+	return instance, nil
+}
+
+// build extracts the package in a scratch directory, builds it, and moves
+// the build output to bldDir.
+func (vm *ProcessVM) build(ccid string, platform platform, path string, codePackage io.Reader, bldDir string) error {
+	// the scratch directory is created in the build directory so that the
+	// build output can be renamed into place
+	scratchDir, err := ioutil.TempDir(vm.BuildDir, ".build-")
+	if err != nil {
+		return errors.WithMessage(err, "could not create build dir")
+	}
+	defer os.RemoveAll(scratchDir)
+
+	inputDir := filepath.Join(scratchDir, "input")
+	if err := externalbuilder.Untar(codePackage, inputDir); err != nil {
+		return errors.WithMessage(err, "could not untar source package")
+	}
+	outputDir := filepath.Join(scratchDir, "output")
+	if err := os.Mkdir(outputDir, 0700); err != nil {
+		return errors.WithMessage(err, "could not create build output dir")
+	}
+
+	b := &builder{
+		logger: processLogger.With("chaincode", ccid),
+		env:    vm.environment(platform.PropagateEnvironment()...),
+	}
+	if err := platform.Build(b, filepath.Join(inputDir, "src"), path, outputDir); err != nil {
+		return errors.WithMessagef(err, "failed to build chaincode %s", ccid)
+	}
+
+	if err := os.Rename(outputDir, bldDir); err != nil {
+		return errors.WithMessagef(err, "could not move build output to '%s'", bldDir)
+	}
+	return nil
+}
+
+// environment returns the environment of chaincode build and run processes.
+// Only the propagated variables of the peer environment are included.
+func (vm *ProcessVM) environment(additional ...string) []string {
+	var env []string
+	seen := map[string]bool{}
+	for _, list := range [][]string{DefaultPropagateEnvironment, vm.PropagateEnvironment, additional} {
+		for _, key := range list {
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if val, ok := os.LookupEnv(key); ok {
+				env = append(env, fmt.Sprintf("%s=%s", key, val))
+			}
+		}
+	}
+	return env
+}
+
+// chaincodeEnv returns the variables configuring the chaincode shim.
+func (vm *ProcessVM) chaincodeEnv(ccid, runDir string, tlsConfig *ccintf.TLSConfig) []string {
+	// FIXME: as with chaincode containers, the env variable CHAINCODE_ID
+	// holds the package ID
+	envs := []string{fmt.Sprintf("CORE_CHAINCODE_ID_NAME=%s", ccid)}
+	envs = append(envs, vm.LoggingEnv...)
+
+	if tlsConfig != nil {
+		envs = append(envs, "CORE_PEER_TLS_ENABLED=true")
+		envs = append(envs, fmt.Sprintf("CORE_TLS_CLIENT_KEY_PATH=%s", filepath.Join(runDir, TLSClientKeyPath)))
+		envs = append(envs, fmt.Sprintf("CORE_TLS_CLIENT_CERT_PATH=%s", filepath.Join(runDir, TLSClientCertPath)))
+		envs = append(envs, fmt.Sprintf("CORE_TLS_CLIENT_KEY_FILE=%s", filepath.Join(runDir, TLSClientKeyFile)))
+		envs = append(envs, fmt.Sprintf("CORE_TLS_CLIENT_CERT_FILE=%s", filepath.Join(runDir, TLSClientCertFile)))
+		envs = append(envs, fmt.Sprintf("CORE_PEER_TLS_ROOTCERT_FILE=%s", filepath.Join(runDir, TLSClientRootCertFile)))
+	} else {
+		envs = append(envs, "CORE_PEER_TLS_ENABLED=false")
+	}
+
+	envs = append(envs, fmt.Sprintf("CORE_PEER_LOCALMSPID=%s", vm.MSPID))
+
+	return envs
+}
+
+// openLog opens the log file of a chaincode for appending, first rotating it
+// if it is larger than MaxLogSize.
+func (vm *ProcessVM) openLog(ccid string) (*os.File, error) {
+	if err := os.MkdirAll(vm.LogDir, 0700); err != nil {
+		return nil, errors.WithMessage(err, "could not create log dir")
+	}
+
+	logPath := filepath.Join(vm.LogDir, externalbuilder.SanitizeCCIDPath(ccid)+".log")
+	if fi, err := os.Stat(logPath); err == nil && vm.MaxLogSize > 0 && fi.Size() > vm.MaxLogSize {
+		if err := os.Rename(logPath, logPath+".1"); err != nil {
+			return nil, errors.WithMessagef(err, "could not rotate log file '%s'", logPath)
+		}
+	}
+
+	f, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "could not open log file '%s'", logPath)
+	}
+	return f, nil
+}
+
+// writeTLSFiles writes the TLS material of the peer connection to runDir.
+func writeTLSFiles(runDir string, tlsConfig *ccintf.TLSConfig) error {
+	files := map[string][]byte{
+		TLSClientKeyPath:      []byte(base64.StdEncoding.EncodeToString(tlsConfig.ClientKey)),
+		TLSClientCertPath:     []byte(base64.StdEncoding.EncodeToString(tlsConfig.ClientCert)),
+		TLSClientKeyFile:      tlsConfig.ClientKey,
+		TLSClientCertFile:     tlsConfig.ClientCert,
+		TLSClientRootCertFile: tlsConfig.RootCert,
+	}
+	for name, contents := range files {
+		if err := ioutil.WriteFile(filepath.Join(runDir, name), contents, 0600); err != nil {
+			return errors.WithMessagef(err, "could not write %s", name)
+		}
+	}
+	return nil
+}
+
+// Instance is a chaincode built by the ProcessVM.
+type Instance struct {
+	CCID     string
+	Platform platform
+	BldDir   string
+	VM       *ProcessVM
+
+	mutex   sync.Mutex
+	process *process
+}
+
+// process is a running chaincode process.
+type process struct {
+	cmd      *exec.Cmd
+	exited   chan struct{}
+	exitCode int
+	exitErr  error
+}
+
+// Start starts the chaincode process, stopping the previous process of the
+// instance if it is still running.
+func (i *Instance) Start(peerConnection *ccintf.PeerConnection) error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if i.process != nil {
+		select {
+		case <-i.process.exited:
+		default:
+			if err := i.stop(i.process); err != nil {
+				return err
+			}
+		}
+	}
+
+	p, err := i.start(peerConnection)
+	if err != nil {
+		return errors.WithMessagef(err, "could not start chaincode %s", i.CCID)
+	}
+	i.process = p
+	return nil
+}
+
+func (i *Instance) start(peerConnection *ccintf.PeerConnection) (p *process, err error) {
+	runDir, err := ioutil.TempDir("", "fabric-run-"+externalbuilder.SanitizeCCIDPath(i.CCID))
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not create run dir")
+	}
+	var cleanups []func()
+	cleanup := func() {
+		for _, c := range cleanups {
+			c()
+		}
+	}
+	cleanups = append(cleanups, func() { os.RemoveAll(runDir) })
+	defer func() {
+		if err != nil {
+			cleanup()
+		}
+	}()
+
+	if peerConnection.TLSConfig != nil {
+		if err := writeTLSFiles(runDir, peerConnection.TLSConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	logFile, err := i.VM.openLog(i.CCID)
+	if err != nil {
+		return nil, err
+	}
+	cleanups = append(cleanups, func() { logFile.Close() })
+
+	dir, args := i.Platform.RunArgs(i.BldDir, peerConnection.Address)
+	name := args[0]
+	if i.VM.Cgroup != nil {
+		cg, err := newCgroup(i.VM.Cgroup, externalbuilder.SanitizeCCIDPath(i.CCID))
+		if err != nil {
+			return nil, errors.WithMessage(err, "could not create cgroup")
+		}
+		cleanups = append(cleanups, cg.remove)
+		args = cg.command(args)
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = append(i.VM.environment(i.Platform.PropagateEnvironment()...), i.VM.chaincodeEnv(i.CCID, runDir, peerConnection.TLSConfig)...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	setProcessGroup(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return nil, errors.WithMessagef(err, "could not run '%s'", name)
+	}
+	processLogger.Infof("started chaincode %s with pid %d", i.CCID, cmd.Process.Pid)
+
+	p = &process{cmd: cmd, exited: make(chan struct{})}
+	go func() {
+		err := cmd.Wait()
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			p.exitCode = exitErr.ExitCode()
+		}
+		p.exitErr = err
+		processLogger.Infof("chaincode %s with pid %d exited: %v", i.CCID, cmd.Process.Pid, err)
+		cleanup()
+		close(p.exited)
+	}()
+
+	return p, nil
+}
+
+func (i *Instance) ChaincodeServerInfo() (*ccintf.ChaincodeServerInfo, error) {
+	return nil, nil
+}
+
+// Stop signals the process group of the chaincode to terminate with SIGTERM.
+// If the process doesn't terminate within TermTimeout, it is killed with
+// SIGKILL.
+func (i *Instance) Stop() error {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if i.process == nil {
+		return errors.Errorf("instance has not been started")
+	}
+	return i.stop(i.process)
+}
+
+func (i *Instance) stop(p *process) error {
+	signalProcessGroup(p.cmd, syscall.SIGTERM)
+	select {
+	case <-time.After(i.VM.TermTimeout):
+		signalProcessGroup(p.cmd, syscall.SIGKILL)
+	case <-p.exited:
+		return nil
+	}
+
+	select {
+	case <-time.After(5 * time.Second):
+		return errors.Errorf("failed to stop chaincode %s", i.CCID)
+	case <-p.exited:
+		return nil
+	}
+}
+
+// Wait blocks until the chaincode process exits and returns its exit code.
+func (i *Instance) Wait() (int, error) {
+	i.mutex.Lock()
+	p := i.process
+	i.mutex.Unlock()
+
+	if p == nil {
+		return -1, errors.Errorf("instance was not successfully started")
+	}
+
+	<-p.exited
+	if p.exitErr != nil {
+		return p.exitCode, errors.Wrapf(p.exitErr, "chaincode %s exited", i.CCID)
+	}
+	return 0, nil
+}
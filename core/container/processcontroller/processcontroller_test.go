@@ -0,0 +1,266 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package processcontroller
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/container/ccintf"
+	"github.com/stretchr/testify/require"
+)
+
+const testChaincode = `package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+func main() {
+	fmt.Println("args:", strings.Join(os.Args[1:], " "))
+	fmt.Println("pid:", os.Getpid())
+	for _, env := range os.Environ() {
+		fmt.Println("env:", env)
+	}
+	fmt.Println("ready")
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGTERM)
+	<-c
+	os.Exit(3)
+}
+`
+
+func newTestProcessVM(t *testing.T) (*ProcessVM, func()) {
+	tempDir, err := ioutil.TempDir("", "processcontroller")
+	require.NoError(t, err)
+
+	buildDir := filepath.Join(tempDir, "builds")
+	require.NoError(t, os.Mkdir(buildDir, 0700))
+
+	vm := &ProcessVM{
+		BuildDir:     buildDir,
+		LogDir:       filepath.Join(tempDir, "logs"),
+		LoggingEnv:   []string{"CORE_CHAINCODE_LOGGING_LEVEL=info"},
+		MSPID:        "mspid",
+		TermTimeout:  5 * time.Second,
+		BuildMetrics: NewBuildMetrics(&disabled.Provider{}),
+	}
+	return vm, func() { os.RemoveAll(tempDir) }
+}
+
+func codePackage(t *testing.T, files map[string]string) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, contents := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     name,
+			Size:     int64(len(contents)),
+			Mode:     0600,
+		})
+		require.NoError(t, err)
+		_, err = tw.Write([]byte(contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+	return buf
+}
+
+func TestBuildAndRunGolang(t *testing.T) {
+	vm, cleanup := newTestProcessVM(t)
+	defer cleanup()
+
+	os.Setenv("PROCESS_TEST_PROPAGATED", "propagated")
+	defer os.Unsetenv("PROCESS_TEST_PROPAGATED")
+	os.Setenv("PROCESS_TEST_SECRET", "secret")
+	defer os.Unsetenv("PROCESS_TEST_SECRET")
+	vm.PropagateEnvironment = []string{"PROCESS_TEST_PROPAGATED"}
+	os.Setenv("GONOSUMDB", "example.com")
+	defer os.Unsetenv("GONOSUMDB")
+
+	metadata := &persistence.ChaincodePackageMetadata{Type: "golang", Path: "example.com/cc"}
+	pkg := codePackage(t, map[string]string{
+		"src/go.mod":  "module example.com/cc\n",
+		"src/main.go": testChaincode,
+	})
+	instance, err := vm.Build("cc:1", metadata, pkg)
+	require.NoError(t, err)
+	bldDir := instance.(*Instance).BldDir
+	require.Equal(t, filepath.Join(vm.BuildDir, "cc-1"), bldDir)
+	require.FileExists(t, filepath.Join(bldDir, "chaincode"))
+
+	// the existing build is used without reading the package
+	_, err = vm.Build("cc:1", metadata, &bytes.Buffer{})
+	require.NoError(t, err)
+
+	err = instance.Start(&ccintf.PeerConnection{
+		Address: "peer.example.com:7052",
+		TLSConfig: &ccintf.TLSConfig{
+			ClientKey:  []byte("key"),
+			ClientCert: []byte("cert"),
+			RootCert:   []byte("root"),
+		},
+	})
+	require.NoError(t, err)
+
+	logPath := filepath.Join(vm.LogDir, "cc-1.log")
+	require.Eventually(t, func() bool {
+		output, _ := ioutil.ReadFile(logPath)
+		return strings.Contains(string(output), "ready")
+	}, 10*time.Second, 50*time.Millisecond)
+
+	output, err := ioutil.ReadFile(logPath)
+	require.NoError(t, err)
+	require.Contains(t, string(output), "args: -peer.address=peer.example.com:7052\n")
+	require.Contains(t, string(output), "env: CORE_CHAINCODE_ID_NAME=cc:1\n")
+	require.Contains(t, string(output), "env: CORE_PEER_TLS_ENABLED=true\n")
+	require.Contains(t, string(output), "env: CORE_PEER_LOCALMSPID=mspid\n")
+	require.Contains(t, string(output), "env: CORE_CHAINCODE_LOGGING_LEVEL=info\n")
+	require.Contains(t, string(output), "env: PROCESS_TEST_PROPAGATED=propagated\n")
+	require.Contains(t, string(output), "env: GONOSUMDB=example.com\n")
+	require.NotContains(t, string(output), "PROCESS_TEST_SECRET")
+
+	require.NoError(t, instance.Stop())
+	exitCode, err := instance.Wait()
+	require.EqualError(t, err, "chaincode cc:1 exited: exit status 3")
+	require.Equal(t, 3, exitCode)
+}
+
+func TestBuildFailure(t *testing.T) {
+	vm, cleanup := newTestProcessVM(t)
+	defer cleanup()
+
+	fakeHistogram := &metricsfakes.Histogram{}
+	fakeHistogram.WithReturns(fakeHistogram)
+	vm.BuildMetrics = &BuildMetrics{ChaincodeBuildDuration: fakeHistogram}
+
+	metadata := &persistence.ChaincodePackageMetadata{Type: "GOLANG", Path: "example.com/cc"}
+	pkg := codePackage(t, map[string]string{
+		"src/go.mod":  "module example.com/cc\n",
+		"src/main.go": "package main\n\nfunc main() { undefined() }\n",
+	})
+	_, err := vm.Build("cc:1", metadata, pkg)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to build chaincode cc:1: 'go build -mod=readonly -o ")
+
+	require.Equal(t, 1, fakeHistogram.WithCallCount())
+	require.Equal(t, []string{"chaincode", "cc:1", "success", "false"}, fakeHistogram.WithArgsForCall(0))
+
+	entries, err := ioutil.ReadDir(vm.BuildDir)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func TestBuildUnsupportedType(t *testing.T) {
+	vm, cleanup := newTestProcessVM(t)
+	defer cleanup()
+
+	_, err := vm.Build("cc:1", &persistence.ChaincodePackageMetadata{Type: "car"}, &bytes.Buffer{})
+	require.EqualError(t, err, "chaincode type CAR is not supported by the process runtime")
+}
+
+func TestInstanceNotStarted(t *testing.T) {
+	instance := &Instance{CCID: "cc:1", VM: &ProcessVM{}}
+
+	require.EqualError(t, instance.Stop(), "instance has not been started")
+	_, err := instance.Wait()
+	require.EqualError(t, err, "instance was not successfully started")
+	info, err := instance.ChaincodeServerInfo()
+	require.NoError(t, err)
+	require.Nil(t, info)
+}
+
+func TestChaincodeEnv(t *testing.T) {
+	vm := &ProcessVM{MSPID: "mspid"}
+
+	env := vm.chaincodeEnv("cc:1", "/run/dir", nil)
+	require.Equal(t, []string{
+		"CORE_CHAINCODE_ID_NAME=cc:1",
+		"CORE_PEER_TLS_ENABLED=false",
+		"CORE_PEER_LOCALMSPID=mspid",
+	}, env)
+
+	env = vm.chaincodeEnv("cc:1", "/run/dir", &ccintf.TLSConfig{})
+	require.Equal(t, []string{
+		"CORE_CHAINCODE_ID_NAME=cc:1",
+		"CORE_PEER_TLS_ENABLED=true",
+		"CORE_TLS_CLIENT_KEY_PATH=/run/dir/client.key",
+		"CORE_TLS_CLIENT_CERT_PATH=/run/dir/client.crt",
+		"CORE_TLS_CLIENT_KEY_FILE=/run/dir/client_pem.key",
+		"CORE_TLS_CLIENT_CERT_FILE=/run/dir/client_pem.crt",
+		"CORE_PEER_TLS_ROOTCERT_FILE=/run/dir/peer.crt",
+		"CORE_PEER_LOCALMSPID=mspid",
+	}, env)
+}
+
+func TestOpenLogRotation(t *testing.T) {
+	vm, cleanup := newTestProcessVM(t)
+	defer cleanup()
+	vm.MaxLogSize = 5
+
+	f, err := vm.openLog("cc:1")
+	require.NoError(t, err)
+	_, err = f.WriteString("first run\n")
+	require.NoError(t, err)
+	f.Close()
+
+	f, err = vm.openLog("cc:1")
+	require.NoError(t, err)
+	f.Close()
+
+	rotated, err := ioutil.ReadFile(filepath.Join(vm.LogDir, "cc-1.log.1"))
+	require.NoError(t, err)
+	require.Equal(t, "first run\n", string(rotated))
+	current, err := ioutil.ReadFile(filepath.Join(vm.LogDir, "cc-1.log"))
+	require.NoError(t, err)
+	require.Empty(t, current)
+}
+
+func TestPlatformRunArgs(t *testing.T) {
+	tests := []struct {
+		platform     platform
+		expectedArgs []string
+	}{
+		{golangPlatform{}, []string{"/bld/chaincode", "-peer.address=peer:7052"}},
+		{nodePlatform{}, []string{"npm", "start", "--", "--peer.address", "peer:7052"}},
+		{javaPlatform{}, []string{"java", "-jar", "/bld/chaincode.jar", "--peerAddress", "peer:7052"}},
+	}
+
+	for _, tc := range tests {
+		dir, args := tc.platform.RunArgs("/bld", "peer:7052")
+		require.Equal(t, "/bld", dir)
+		require.Equal(t, tc.expectedArgs, args)
+	}
+}
+
+func TestJavaBuildWithoutBuildFile(t *testing.T) {
+	sourceDir, err := ioutil.TempDir("", "processcontroller")
+	require.NoError(t, err)
+	defer os.RemoveAll(sourceDir)
+
+	b := &builder{logger: flogging.MustGetLogger("test")}
+	err = javaPlatform{}.Build(b, sourceDir, "", sourceDir)
+	require.EqualError(t, err, "no build.gradle or pom.xml found in java chaincode")
+}
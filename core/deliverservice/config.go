@@ -34,6 +34,10 @@ type DeliverServiceConfig struct {
 	// ReconnectTotalTimeThreshold sets the total time the delivery service may spend in reconnection attempts
 	// until its retry logic gives up and returns an error.
 	ReconnectTotalTimeThreshold time.Duration
+	// LeaderDemotionThreshold sets the time a peer elected as leader may fail to receive blocks
+	// from the ordering service before it yields its leadership. Only the delays between
+	// reconnection attempts are counted. Zero disables the demotion.
+	LeaderDemotionThreshold time.Duration
 	// ConnectionTimeout sets the delivery service <-> ordering service node connection timeout
 	ConnectionTimeout time.Duration
 	// Keepalive option for deliveryservice
@@ -107,6 +111,8 @@ func (c *DeliverServiceConfig) loadDeliverServiceConfig() {
 		c.ReconnectTotalTimeThreshold = DefaultReConnectTotalTimeThreshold
 	}
 
+	c.LeaderDemotionThreshold = viper.GetDuration("peer.deliveryclient.leaderDemotionThreshold")
+
 	c.ConnectionTimeout = viper.GetDuration("peer.deliveryclient.connTimeout")
 	if c.ConnectionTimeout == 0 {
 		c.ConnectionTimeout = DefaultConnectionTimeout
@@ -85,6 +85,7 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.tls.enabled", true)
 	viper.Set("peer.deliveryclient.reConnectBackoffThreshold", "25s")
 	viper.Set("peer.deliveryclient.reconnectTotalTimeThreshold", "20s")
+	viper.Set("peer.deliveryclient.leaderDemotionThreshold", "30s")
 	viper.Set("peer.deliveryclient.connTimeout", "10s")
 	viper.Set("peer.keepalive.deliveryClient.interval", "5s")
 	viper.Set("peer.keepalive.deliveryClient.timeout", "2s")
@@ -95,6 +96,7 @@ func TestGlobalConfig(t *testing.T) {
 		PeerTLSEnabled:              true,
 		ReConnectBackoffThreshold:   25 * time.Second,
 		ReconnectTotalTimeThreshold: 20 * time.Second,
+		LeaderDemotionThreshold:     30 * time.Second,
 		ConnectionTimeout:           10 * time.Second,
 		KeepaliveOptions: comm.KeepaliveOptions{
 			ClientInterval:    time.Second * 5,
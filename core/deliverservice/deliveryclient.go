@@ -128,15 +128,16 @@ func (d *deliverServiceImpl) StartDeliverForChannel(chainID string, ledgerInfo b
 		Dialer: DialerAdapter{
 			Client: d.conf.DeliverGRPCClient,
 		},
-		Orderers:          d.conf.OrdererSource,
-		DoneC:             make(chan struct{}),
-		Signer:            d.conf.Signer,
-		DeliverStreamer:   DeliverAdapter{},
-		Logger:            flogging.MustGetLogger("peer.blocksprovider").With("channel", chainID),
-		MaxRetryDelay:     d.conf.DeliverServiceConfig.ReConnectBackoffThreshold,
-		MaxRetryDuration:  d.conf.DeliverServiceConfig.ReconnectTotalTimeThreshold,
-		InitialRetryDelay: 100 * time.Millisecond,
-		YieldLeadership:   !d.conf.IsStaticLeader,
+		Orderers:                d.conf.OrdererSource,
+		DoneC:                   make(chan struct{}),
+		Signer:                  d.conf.Signer,
+		DeliverStreamer:         DeliverAdapter{},
+		Logger:                  flogging.MustGetLogger("peer.blocksprovider").With("channel", chainID),
+		MaxRetryDelay:           d.conf.DeliverServiceConfig.ReConnectBackoffThreshold,
+		MaxRetryDuration:        d.conf.DeliverServiceConfig.ReconnectTotalTimeThreshold,
+		LeaderDemotionThreshold: d.conf.DeliverServiceConfig.LeaderDemotionThreshold,
+		InitialRetryDelay:       100 * time.Millisecond,
+		YieldLeadership:         !d.conf.IsStaticLeader,
 	}
 
 	if d.conf.DeliverGRPCClient.MutualTLSRequired() {
@@ -47,6 +47,9 @@ type Support interface {
 	// by way of the supplied txid
 	GetTxSimulator(ledgername string, txid string) (ledger.TxSimulator, error)
 
+	// GetQueryExecutor returns a query executor for the specified ledger
+	GetQueryExecutor(ledgername string) (ledger.QueryExecutor, error)
+
 	// GetHistoryQueryExecutor gives handle to a history query executor for the
 	// specified ledger
 	GetHistoryQueryExecutor(ledgername string) (ledger.HistoryQueryExecutor, error)
@@ -92,6 +95,12 @@ type Channel struct {
 	IdentityDeserializer msp.IdentityDeserializer
 }
 
+// RevocationChecker checks online whether the certificates of an identity
+// have been revoked.
+type RevocationChecker interface {
+	CheckIdentity(id msp.Identity) error
+}
+
 // Endorser provides the Endorser service ProcessProposal
 type Endorser struct {
 	ChannelFetcher         ChannelFetcher
@@ -100,6 +109,12 @@ type Endorser struct {
 	Support                Support
 	PvtRWSetAssembler      PvtRWSetAssembler
 	Metrics                *Metrics
+	// Limiter enforces per-channel and per-chaincode limits on proposals.
+	// When nil, no such limits are applied.
+	Limiter *Limiter
+	// RevocationChecker checks online that the certificates of the creators
+	// of proposals have not been revoked. When nil, no such check is made.
+	RevocationChecker RevocationChecker
 }
 
 // call specified chaincode (system or user)
@@ -251,6 +266,9 @@ func (e *Endorser) preProcess(up *UnpackedProposal, channel *Channel) error {
 	// at first, we check whether the message is valid
 
 	err := up.Validate(channel.IdentityDeserializer)
+	if err == nil {
+		err = e.checkRevocation(up, channel.IdentityDeserializer)
+	}
 	if err != nil {
 		e.Metrics.ProposalValidationFailed.Add(1)
 		return errors.WithMessage(err, "error validating proposal")
@@ -292,6 +310,26 @@ func (e *Endorser) preProcess(up *UnpackedProposal, channel *Channel) error {
 	return nil
 }
 
+// checkRevocation checks online that the certificates of the creator of the
+// proposal have not been revoked. The creator is only admitted by this check,
+// the validation of the resulting transaction does not depend on it.
+func (e *Endorser) checkRevocation(up *UnpackedProposal, idDeserializer msp.IdentityDeserializer) error {
+	if e.RevocationChecker == nil {
+		return nil
+	}
+
+	creator, err := idDeserializer.DeserializeIdentity(up.SignatureHeader.Creator)
+	if err != nil {
+		return errors.WithMessage(err, "access denied")
+	}
+	if err := e.RevocationChecker.CheckIdentity(creator); err != nil {
+		endorserLogger.Warningf("access denied: channel [%s] creator org [%s]: %s", up.ChannelID(), creator.GetMSPIdentifier(), err)
+		return errors.Errorf("access denied: channel [%s] creator org [%s]", up.ChannelID(), creator.GetMSPIdentifier())
+	}
+
+	return nil
+}
+
 // ProcessProposal process the Proposal
 func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
 	// start time for computing elapsed time metric for successfully endorsed proposals
@@ -328,6 +366,14 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
 	}
 
+	if up.ChannelID() != "" {
+		release, err := e.acquireLimits(up)
+		if err != nil {
+			return &pb.ProposalResponse{Response: &pb.Response{Status: StatusTooManyRequests, Message: err.Error()}}, nil
+		}
+		defer release()
+	}
+
 	defer func() {
 		meterLabels := []string{
 			"channel", up.ChannelHeader.ChannelId,
@@ -354,6 +400,34 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	return pResp, nil
 }
 
+// acquireLimits reserves capacity for the proposal under the configured
+// channel and chaincode limits and tracks it as in flight.
+func (e *Endorser) acquireLimits(up *UnpackedProposal) (release func(), err error) {
+	meterLabels := []string{
+		"channel", up.ChannelID(),
+		"chaincode", up.ChaincodeName,
+	}
+
+	releaseLimits := func() {}
+	if e.Limiter != nil {
+		releaseLimits, err = e.Limiter.Acquire(up.ChannelID(), up.ChaincodeName)
+		if err != nil {
+			if lerr, ok := err.(*LimitExceededError); ok {
+				e.Metrics.ProposalsThrottled.With(append(meterLabels, "scope", lerr.Scope, "reason", lerr.Reason)...).Add(1)
+			}
+			endorserLogger.Warningf("rejecting proposal [%s]: %s", shorttxid(up.TxID()), err)
+			return nil, err
+		}
+	}
+
+	inFlight := e.Metrics.ProposalsInFlight.With(meterLabels...)
+	inFlight.Add(1)
+	return func() {
+		inFlight.Add(-1)
+		releaseLimits()
+	}, nil
+}
+
 func (e *Endorser) ProcessProposalSuccessfullyOrError(up *UnpackedProposal) (*pb.ProposalResponse, error) {
 	txParams := &ccprovider.TransactionParams{
 		ChannelID:  up.ChannelHeader.ChannelId,
@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/core/endorser"
 	"github.com/hyperledger/fabric/core/endorser/fake"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protoutil"
 
 	"github.com/golang/protobuf/proto"
@@ -40,6 +41,8 @@ var _ = Describe("Endorser", func() {
 		fakeEndorsementsFailed       *metricsfakes.Counter
 		fakeDuplicateTxsFailure      *metricsfakes.Counter
 		fakeSimulateFailure          *metricsfakes.Counter
+		fakeProposalsThrottled       *metricsfakes.Counter
+		fakeProposalsInFlight        *metricsfakes.Gauge
 
 		fakeLocalIdentity                *fake.Identity
 		fakeLocalMSPIdentityDeserializer *fake.IdentityDeserializer
@@ -89,6 +92,12 @@ var _ = Describe("Endorser", func() {
 		fakeSimulateFailure = &metricsfakes.Counter{}
 		fakeSimulateFailure.WithReturns(fakeSimulateFailure)
 
+		fakeProposalsThrottled = &metricsfakes.Counter{}
+		fakeProposalsThrottled.WithReturns(fakeProposalsThrottled)
+
+		fakeProposalsInFlight = &metricsfakes.Gauge{}
+		fakeProposalsInFlight.WithReturns(fakeProposalsInFlight)
+
 		fakeLocalIdentity = &fake.Identity{}
 		fakeLocalMSPIdentityDeserializer = &fake.IdentityDeserializer{}
 		fakeLocalMSPIdentityDeserializer.DeserializeIdentityReturns(fakeLocalIdentity, nil)
@@ -173,6 +182,8 @@ var _ = Describe("Endorser", func() {
 				EndorsementsFailed:       fakeEndorsementsFailed,
 				DuplicateTxsFailure:      fakeDuplicateTxsFailure,
 				SimulationFailure:        fakeSimulateFailure,
+				ProposalsThrottled:       fakeProposalsThrottled,
+				ProposalsInFlight:        fakeProposalsInFlight,
 			},
 			Support:        fakeSupport,
 			ChannelFetcher: fakeChannelFetcher,
@@ -293,6 +304,64 @@ var _ = Describe("Endorser", func() {
 		})
 	})
 
+	It("tracks the proposal as in flight", func() {
+		_, err := e.ProcessProposal(context.Background(), signedProposal)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeProposalsInFlight.WithCallCount()).To(Equal(1))
+		Expect(fakeProposalsInFlight.WithArgsForCall(0)).To(Equal([]string{
+			"channel", "channel-id",
+			"chaincode", "chaincode-name",
+		}))
+		Expect(fakeProposalsInFlight.AddCallCount()).To(Equal(2))
+		Expect(fakeProposalsInFlight.AddArgsForCall(0)).To(Equal(1.0))
+		Expect(fakeProposalsInFlight.AddArgsForCall(1)).To(Equal(-1.0))
+	})
+
+	Context("when a chaincode limit is exceeded", func() {
+		var release func()
+
+		BeforeEach(func() {
+			e.Limiter = endorser.NewLimiter(endorser.LimiterConfig{
+				Chaincode: endorser.Limit{Concurrency: 1},
+			}, limiterRegistry{})
+			var err error
+			release, err = e.Limiter.Acquire("channel-id", "chaincode-name")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects the proposal before simulation with a distinct status", func() {
+			proposalResponse, err := e.ProcessProposal(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proposalResponse).To(Equal(&pb.ProposalResponse{
+				Response: &pb.Response{
+					Status:  endorser.StatusTooManyRequests,
+					Message: "too many requests for chaincode chaincode-name on channel channel-id, exceeding concurrency limit",
+				},
+			}))
+			Expect(fakeSupport.GetTxSimulatorCallCount()).To(Equal(0))
+			Expect(fakeSupport.ExecuteCallCount()).To(Equal(0))
+			Expect(fakeProposalsThrottled.WithArgsForCall(0)).To(Equal([]string{
+				"channel", "channel-id",
+				"chaincode", "chaincode-name",
+				"scope", "chaincode",
+				"reason", "concurrency",
+			}))
+			Expect(fakeProposalsThrottled.AddArgsForCall(0)).To(Equal(1.0))
+			Expect(fakeProposalsInFlight.AddCallCount()).To(Equal(0))
+		})
+
+		It("releases the capacity once the proposal completes", func() {
+			release()
+			_, err := e.ProcessProposal(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSupport.ExecuteCallCount()).To(Equal(1))
+
+			proposalResponse, err := e.ProcessProposal(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proposalResponse.Response.Status).To(Equal(int32(200)))
+		})
+	})
+
 	It("gets a transaction simulator", func() {
 		_, err := e.ProcessProposal(context.Background(), signedProposal)
 		Expect(err).NotTo(HaveOccurred())
@@ -395,6 +464,36 @@ var _ = Describe("Endorser", func() {
 		})
 	})
 
+	Context("when the creator's certificate has been revoked", func() {
+		BeforeEach(func() {
+			fakeChannelIdentity.GetMSPIdentifierReturns("msp-id")
+			e.RevocationChecker = revocationChecker{err: fmt.Errorf("fake-revoked-error")}
+		})
+
+		It("wraps and returns an error and responds to the client", func() {
+			proposalResponse, err := e.ProcessProposal(context.Background(), signedProposal)
+			Expect(err).To(MatchError("error validating proposal: access denied: channel [channel-id] creator org [msp-id]"))
+			Expect(proposalResponse).To(Equal(&pb.ProposalResponse{
+				Response: &pb.Response{
+					Status:  500,
+					Message: "error validating proposal: access denied: channel [channel-id] creator org [msp-id]",
+				},
+			}))
+			Expect(fakeProposalValidationFailed.AddCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the creator's certificate has not been revoked", func() {
+		BeforeEach(func() {
+			e.RevocationChecker = revocationChecker{}
+		})
+
+		It("processes the proposal", func() {
+			_, err := e.ProcessProposal(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
 	It("checks the ACLs for the identity", func() {
 		_, err := e.ProcessProposal(context.Background(), signedProposal)
 		Expect(err).NotTo(HaveOccurred())
@@ -1021,3 +1120,17 @@ var _ = Describe("Endorser", func() {
 		})
 	})
 })
+
+// limiterRegistry reports every channel and chaincode as existing.
+type limiterRegistry struct{}
+
+func (limiterRegistry) ChannelExists(string) bool { return true }
+
+func (limiterRegistry) ChaincodeExists(string, string) bool { return true }
+
+// revocationChecker fails the check of every identity with err.
+type revocationChecker struct {
+	err error
+}
+
+func (r revocationChecker) CheckIdentity(msp.Identity) error { return r.err }
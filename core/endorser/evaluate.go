@@ -0,0 +1,251 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Evaluate runs the chaincode invoked by the proposal against a query
+// executor rather than a transaction simulator. No read-write set is built,
+// no private data is distributed and the response is not endorsed, so the
+// result cannot be submitted as a transaction. Chaincode attempting to write
+// to the ledger fails the evaluation.
+func (e *Endorser) Evaluate(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+	startTime := time.Now()
+	e.Metrics.EvaluationsReceived.Add(1)
+
+	addr := util.ExtractRemoteAddress(ctx)
+	endorserLogger.Debug("evaluate request from", addr)
+
+	up, err := UnpackProposal(signedProp)
+	if err != nil {
+		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+	}
+
+	if up.ChannelID() == "" {
+		err := errors.New("proposals without a channel cannot be evaluated")
+		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+	}
+
+	channel := e.ChannelFetcher.Channel(up.ChannelID())
+	if channel == nil {
+		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: fmt.Sprintf("channel '%s' not found", up.ChannelHeader.ChannelId)}}, nil
+	}
+
+	// the result of an evaluation is never submitted, so unlike
+	// preProcess there is no check for duplicate transactions
+	err = up.Validate(channel.IdentityDeserializer)
+	if err == nil {
+		err = e.checkRevocation(up, channel.IdentityDeserializer)
+	}
+	if err != nil {
+		err = errors.WithMessage(err, "error validating proposal")
+		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+	}
+	if !e.Support.IsSysCC(up.ChaincodeName) {
+		if err := e.Support.CheckACL(up.ChannelID(), up.SignedProposal); err != nil {
+			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
+		}
+	}
+
+	release, err := e.acquireLimits(up)
+	if err != nil {
+		return &pb.ProposalResponse{Response: &pb.Response{Status: StatusTooManyRequests, Message: err.Error()}}, nil
+	}
+	defer release()
+
+	success := false
+	defer func() {
+		meterLabels := []string{
+			"channel", up.ChannelID(),
+			"chaincode", up.ChaincodeName,
+			"success", strconv.FormatBool(success),
+		}
+		e.Metrics.EvaluateDuration.With(meterLabels...).Observe(time.Since(startTime).Seconds())
+	}()
+
+	pResp, err := e.evaluate(up)
+	if err != nil {
+		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, nil
+	}
+
+	if pResp.Response.Status < shim.ERRORTHRESHOLD {
+		success = true
+		e.Metrics.SuccessfulEvaluations.Add(1)
+	}
+	return pResp, nil
+}
+
+func (e *Endorser) evaluate(up *UnpackedProposal) (*pb.ProposalResponse, error) {
+	txParams := &ccprovider.TransactionParams{
+		ChannelID:  up.ChannelHeader.ChannelId,
+		TxID:       up.ChannelHeader.TxId,
+		SignedProp: up.SignedProposal,
+		Proposal:   up.Proposal,
+	}
+
+	logger := decorateLogger(endorserLogger, txParams)
+
+	meterLabels := []string{
+		"channel", up.ChannelID(),
+		"chaincode", up.ChaincodeName,
+	}
+
+	var sim *readOnlySimulator
+	if acquireTxSimulator(up.ChannelID(), up.ChaincodeName) {
+		qe, err := e.Support.GetQueryExecutor(up.ChannelID())
+		if err != nil {
+			return nil, err
+		}
+		// like the tx simulator, the query executor holds a shared lock on
+		// the stateDB which must be released as early as possible
+		defer qe.Done()
+
+		hqe, err := e.Support.GetHistoryQueryExecutor(up.ChannelID())
+		if err != nil {
+			return nil, err
+		}
+
+		sim = &readOnlySimulator{QueryExecutor: qe}
+		txParams.TXSimulator = sim
+		txParams.HistoryQueryExecutor = hqe
+	}
+
+	cdLedger, err := e.Support.ChaincodeEndorsementInfo(up.ChannelID(), up.ChaincodeName, txParams.TXSimulator)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "make sure the chaincode %s has been successfully defined on channel %s and try again", up.ChaincodeName, up.ChannelID())
+	}
+
+	res, _, err := e.Support.Execute(txParams, up.ChaincodeName, up.Input)
+	if sim != nil {
+		// the chaincode may have ignored the error returned for a write, so
+		// the evaluation is rejected even if the chaincode succeeded
+		if werr := sim.writeError(); werr != nil {
+			e.Metrics.EvaluationWritesRejected.With(meterLabels...).Add(1)
+			logger.Debugf("rejecting evaluation of chaincode %s: %s", up.ChaincodeName, werr)
+			return nil, errors.WithMessagef(werr, "chaincode %s attempted to write to the ledger", up.ChaincodeName)
+		}
+	}
+	if err != nil {
+		e.Metrics.EvaluationFailure.With(meterLabels...).Add(1)
+		logger.Errorf("failed to evaluate chaincode %s, error: %+v", up.ChaincodeName, err)
+		return nil, errors.WithMessage(err, "error in evaluation")
+	}
+
+	// chaincode events are only emitted by committed transactions, so the
+	// payload carries neither simulation results nor events
+	prpBytes, err := protoutil.GetBytesProposalResponsePayload(up.ProposalHash, res, nil, nil, &pb.ChaincodeID{
+		Name:    up.ChaincodeName,
+		Version: cdLedger.Version,
+	})
+	if err != nil {
+		logger.Warning("Failed marshaling the proposal response payload to bytes", err)
+		return nil, errors.WithMessage(err, "failed to create the proposal response")
+	}
+
+	return &pb.ProposalResponse{
+		Version:  1,
+		Payload:  prpBytes,
+		Response: res,
+	}, nil
+}
+
+// readOnlySimulator adapts a query executor to the transaction simulator
+// used to run chaincode. Reads are served by the query executor, which does
+// not record a read set, and writes fail.
+type readOnlySimulator struct {
+	ledger.QueryExecutor
+
+	mutex    sync.Mutex
+	writeErr error
+}
+
+// rejectWrite returns the error for a write and records the first one.
+func (s *readOnlySimulator) rejectWrite(err error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.writeErr == nil {
+		s.writeErr = err
+	}
+	return err
+}
+
+// writeError returns the error of the first write attempted, if any.
+func (s *readOnlySimulator) writeError() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.writeErr
+}
+
+func (s *readOnlySimulator) rejectStateWrite(namespace string) error {
+	return s.rejectWrite(errors.Errorf("writes to namespace '%s' are not permitted when evaluating a proposal", namespace))
+}
+
+func (s *readOnlySimulator) rejectPrivateDataWrite(namespace, collection string) error {
+	return s.rejectWrite(errors.Errorf("writes to collection '%s' of namespace '%s' are not permitted when evaluating a proposal", collection, namespace))
+}
+
+func (s *readOnlySimulator) SetState(namespace string, key string, value []byte) error {
+	return s.rejectStateWrite(namespace)
+}
+
+func (s *readOnlySimulator) DeleteState(namespace string, key string) error {
+	return s.rejectStateWrite(namespace)
+}
+
+func (s *readOnlySimulator) SetStateMultipleKeys(namespace string, kvs map[string][]byte) error {
+	return s.rejectStateWrite(namespace)
+}
+
+func (s *readOnlySimulator) SetStateMetadata(namespace, key string, metadata map[string][]byte) error {
+	return s.rejectStateWrite(namespace)
+}
+
+func (s *readOnlySimulator) DeleteStateMetadata(namespace, key string) error {
+	return s.rejectStateWrite(namespace)
+}
+
+func (s *readOnlySimulator) ExecuteUpdate(query string) error {
+	return s.rejectWrite(errors.New("updates are not permitted when evaluating a proposal"))
+}
+
+func (s *readOnlySimulator) SetPrivateData(namespace, collection, key string, value []byte) error {
+	return s.rejectPrivateDataWrite(namespace, collection)
+}
+
+func (s *readOnlySimulator) SetPrivateDataMultipleKeys(namespace, collection string, kvs map[string][]byte) error {
+	return s.rejectPrivateDataWrite(namespace, collection)
+}
+
+func (s *readOnlySimulator) DeletePrivateData(namespace, collection, key string) error {
+	return s.rejectPrivateDataWrite(namespace, collection)
+}
+
+func (s *readOnlySimulator) SetPrivateDataMetadata(namespace, collection, key string, metadata map[string][]byte) error {
+	return s.rejectPrivateDataWrite(namespace, collection)
+}
+
+func (s *readOnlySimulator) DeletePrivateDataMetadata(namespace, collection, key string) error {
+	return s.rejectPrivateDataWrite(namespace, collection)
+}
+
+func (s *readOnlySimulator) GetTxSimulationResults() (*ledger.TxSimulationResults, error) {
+	return nil, errors.New("simulation results are not collected when evaluating a proposal")
+}
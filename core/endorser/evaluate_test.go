@@ -0,0 +1,328 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/endorser"
+	"github.com/hyperledger/fabric/core/endorser/fake"
+	"github.com/hyperledger/fabric/protoutil"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Evaluate", func() {
+	var (
+		fakeEvaluateDuration         *metricsfakes.Histogram
+		fakeEvaluationsReceived      *metricsfakes.Counter
+		fakeSuccessfulEvaluations    *metricsfakes.Counter
+		fakeEvaluationFailure        *metricsfakes.Counter
+		fakeEvaluationWritesRejected *metricsfakes.Counter
+		fakeProposalsInFlight        *metricsfakes.Gauge
+
+		fakeChannelIdentity                *fake.Identity
+		fakeChannelMSPIdentityDeserializer *fake.IdentityDeserializer
+		fakeChannelFetcher                 *fake.ChannelFetcher
+
+		fakeSupport              *fake.Support
+		fakeQueryExecutor        *fake.QueryExecutor
+		fakeHistoryQueryExecutor *fake.HistoryQueryExecutor
+
+		signedProposal *pb.SignedProposal
+		channelID      string
+		chaincodeName  string
+
+		e *endorser.Endorser
+	)
+
+	BeforeEach(func() {
+		fakeEvaluateDuration = &metricsfakes.Histogram{}
+		fakeEvaluateDuration.WithReturns(fakeEvaluateDuration)
+		fakeEvaluationsReceived = &metricsfakes.Counter{}
+		fakeSuccessfulEvaluations = &metricsfakes.Counter{}
+		fakeEvaluationFailure = &metricsfakes.Counter{}
+		fakeEvaluationFailure.WithReturns(fakeEvaluationFailure)
+		fakeEvaluationWritesRejected = &metricsfakes.Counter{}
+		fakeEvaluationWritesRejected.WithReturns(fakeEvaluationWritesRejected)
+		fakeProposalsInFlight = &metricsfakes.Gauge{}
+		fakeProposalsInFlight.WithReturns(fakeProposalsInFlight)
+
+		fakeChannelIdentity = &fake.Identity{}
+		fakeChannelMSPIdentityDeserializer = &fake.IdentityDeserializer{}
+		fakeChannelMSPIdentityDeserializer.DeserializeIdentityReturns(fakeChannelIdentity, nil)
+		fakeChannelFetcher = &fake.ChannelFetcher{}
+		fakeChannelFetcher.ChannelReturns(&endorser.Channel{
+			IdentityDeserializer: fakeChannelMSPIdentityDeserializer,
+		})
+
+		channelID = "channel-id"
+		chaincodeName = "chaincode-name"
+
+		fakeQueryExecutor = &fake.QueryExecutor{}
+		fakeHistoryQueryExecutor = &fake.HistoryQueryExecutor{}
+
+		fakeSupport = &fake.Support{}
+		fakeSupport.GetQueryExecutorReturns(fakeQueryExecutor, nil)
+		fakeSupport.GetHistoryQueryExecutorReturns(fakeHistoryQueryExecutor, nil)
+		fakeSupport.ChaincodeEndorsementInfoReturns(&lifecycle.ChaincodeEndorsementInfo{
+			Version:           "chaincode-definition-version",
+			EndorsementPlugin: "plugin-name",
+		}, nil)
+		fakeSupport.ExecuteReturns(
+			&pb.Response{Status: 200, Payload: []byte("response-payload")},
+			&pb.ChaincodeEvent{EventName: "event-name"},
+			nil,
+		)
+
+		e = &endorser.Endorser{
+			Metrics: &endorser.Metrics{
+				EvaluateDuration:         fakeEvaluateDuration,
+				EvaluationsReceived:      fakeEvaluationsReceived,
+				SuccessfulEvaluations:    fakeSuccessfulEvaluations,
+				EvaluationFailure:        fakeEvaluationFailure,
+				EvaluationWritesRejected: fakeEvaluationWritesRejected,
+				ProposalsInFlight:        fakeProposalsInFlight,
+			},
+			Support:        fakeSupport,
+			ChannelFetcher: fakeChannelFetcher,
+		}
+	})
+
+	JustBeforeEach(func() {
+		signedProposal = &pb.SignedProposal{
+			ProposalBytes: protoutil.MarshalOrPanic(&pb.Proposal{
+				Header: protoutil.MarshalOrPanic(&cb.Header{
+					ChannelHeader: protoutil.MarshalOrPanic(&cb.ChannelHeader{
+						Type:      int32(cb.HeaderType_ENDORSER_TRANSACTION),
+						ChannelId: channelID,
+						Extension: protoutil.MarshalOrPanic(&pb.ChaincodeHeaderExtension{
+							ChaincodeId: &pb.ChaincodeID{
+								Name: chaincodeName,
+							},
+						}),
+						TxId: "6f142589e4ef6a1e62c9c816e2074f70baa9f7cf67c2f0c287d4ef907d6d2015",
+					}),
+					SignatureHeader: protoutil.MarshalOrPanic(&cb.SignatureHeader{
+						Creator: protoutil.MarshalOrPanic(&mspproto.SerializedIdentity{
+							Mspid: "msp-id",
+						}),
+						Nonce: []byte("nonce"),
+					}),
+				}),
+				Payload: protoutil.MarshalOrPanic(&pb.ChaincodeProposalPayload{
+					Input: protoutil.MarshalOrPanic(&pb.ChaincodeInvocationSpec{
+						ChaincodeSpec: &pb.ChaincodeSpec{
+							Input: &pb.ChaincodeInput{Args: [][]byte{[]byte("query")}},
+						},
+					}),
+				}),
+			}),
+			Signature: []byte("signature"),
+		}
+	})
+
+	It("evaluates the proposal against a query executor without endorsing it", func() {
+		proposalResponse, err := e.Evaluate(context.Background(), signedProposal)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proposalResponse.Endorsement).To(BeNil())
+		Expect(proposalResponse.Version).To(Equal(int32(1)))
+		Expect(proto.Equal(proposalResponse.Response, &pb.Response{
+			Status:  200,
+			Payload: []byte("response-payload"),
+		})).To(BeTrue())
+
+		prp := &pb.ProposalResponsePayload{}
+		Expect(proto.Unmarshal(proposalResponse.Payload, prp)).To(Succeed())
+		ccAct := &pb.ChaincodeAction{}
+		Expect(proto.Unmarshal(prp.Extension, ccAct)).To(Succeed())
+		Expect(ccAct.Results).To(BeNil())
+		Expect(ccAct.Events).To(BeNil())
+		Expect(ccAct.ChaincodeId.Version).To(Equal("chaincode-definition-version"))
+
+		Expect(fakeSupport.GetTxSimulatorCallCount()).To(Equal(0))
+		Expect(fakeSupport.GetTransactionByIDCallCount()).To(Equal(0))
+		Expect(fakeSupport.EndorseWithPluginCallCount()).To(Equal(0))
+		Expect(fakeSupport.GetQueryExecutorCallCount()).To(Equal(1))
+		Expect(fakeSupport.GetQueryExecutorArgsForCall(0)).To(Equal("channel-id"))
+		Expect(fakeQueryExecutor.DoneCallCount()).To(Equal(1))
+
+		Expect(fakeSupport.ExecuteCallCount()).To(Equal(1))
+		txParams, name, _ := fakeSupport.ExecuteArgsForCall(0)
+		Expect(name).To(Equal("chaincode-name"))
+		Expect(txParams.HistoryQueryExecutor).To(Equal(fakeHistoryQueryExecutor))
+
+		Expect(fakeEvaluationsReceived.AddCallCount()).To(Equal(1))
+		Expect(fakeSuccessfulEvaluations.AddCallCount()).To(Equal(1))
+		Expect(fakeEvaluateDuration.WithArgsForCall(0)).To(Equal([]string{
+			"channel", "channel-id",
+			"chaincode", "chaincode-name",
+			"success", "true",
+		}))
+	})
+
+	It("serves reads from the query executor", func() {
+		fakeQueryExecutor.GetStateReturns([]byte("value"), nil)
+		fakeSupport.ExecuteStub = func(txParams *ccprovider.TransactionParams, name string, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error) {
+			value, err := txParams.TXSimulator.GetState("chaincode-name", "key")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal([]byte("value")))
+			_, err = txParams.TXSimulator.GetTxSimulationResults()
+			Expect(err).To(MatchError("simulation results are not collected when evaluating a proposal"))
+			return &pb.Response{Status: 200}, nil, nil
+		}
+
+		_, err := e.Evaluate(context.Background(), signedProposal)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeQueryExecutor.GetStateCallCount()).To(Equal(1))
+	})
+
+	Context("when the chaincode writes to the ledger", func() {
+		BeforeEach(func() {
+			fakeSupport.ExecuteStub = func(txParams *ccprovider.TransactionParams, name string, input *pb.ChaincodeInput) (*pb.Response, *pb.ChaincodeEvent, error) {
+				err := txParams.TXSimulator.SetPrivateData("chaincode-name", "collection", "key", []byte("value"))
+				Expect(err).To(MatchError("writes to collection 'collection' of namespace 'chaincode-name' are not permitted when evaluating a proposal"))
+				err = txParams.TXSimulator.SetState("chaincode-name", "key", []byte("value"))
+				Expect(err).To(MatchError("writes to namespace 'chaincode-name' are not permitted when evaluating a proposal"))
+				// the chaincode ignores the errors
+				return &pb.Response{Status: 200}, nil, nil
+			}
+		})
+
+		It("rejects the evaluation", func() {
+			proposalResponse, err := e.Evaluate(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proposalResponse).To(Equal(&pb.ProposalResponse{
+				Response: &pb.Response{
+					Status:  500,
+					Message: "chaincode chaincode-name attempted to write to the ledger: writes to collection 'collection' of namespace 'chaincode-name' are not permitted when evaluating a proposal",
+				},
+			}))
+			Expect(fakeEvaluationWritesRejected.AddCallCount()).To(Equal(1))
+			Expect(fakeEvaluationWritesRejected.WithArgsForCall(0)).To(Equal([]string{
+				"channel", "channel-id",
+				"chaincode", "chaincode-name",
+			}))
+			Expect(fakeSuccessfulEvaluations.AddCallCount()).To(Equal(0))
+			Expect(fakeEvaluateDuration.WithArgsForCall(0)).To(Equal([]string{
+				"channel", "channel-id",
+				"chaincode", "chaincode-name",
+				"success", "false",
+			}))
+		})
+	})
+
+	Context("when the chaincode returns an error response", func() {
+		BeforeEach(func() {
+			fakeSupport.ExecuteReturns(&pb.Response{Status: 500, Message: "chaincode-error"}, nil, nil)
+		})
+
+		It("returns the response but does not count it as successful", func() {
+			proposalResponse, err := e.Evaluate(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proto.Equal(proposalResponse.Response, &pb.Response{Status: 500, Message: "chaincode-error"})).To(BeTrue())
+			Expect(fakeSuccessfulEvaluations.AddCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when calling the chaincode fails", func() {
+		BeforeEach(func() {
+			fakeSupport.ExecuteReturns(nil, nil, fmt.Errorf("fake-execute-error"))
+		})
+
+		It("returns an error response", func() {
+			proposalResponse, err := e.Evaluate(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proposalResponse.Response).To(Equal(&pb.Response{
+				Status:  500,
+				Message: "error in evaluation: fake-execute-error",
+			}))
+			Expect(fakeEvaluationFailure.AddCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when getting the query executor fails", func() {
+		BeforeEach(func() {
+			fakeSupport.GetQueryExecutorReturns(nil, fmt.Errorf("fake-query-executor-error"))
+		})
+
+		It("returns an error response", func() {
+			proposalResponse, err := e.Evaluate(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proposalResponse.Response).To(Equal(&pb.Response{
+				Status:  500,
+				Message: "fake-query-executor-error",
+			}))
+			Expect(fakeSupport.ExecuteCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the acl check fails", func() {
+		BeforeEach(func() {
+			fakeSupport.CheckACLReturns(fmt.Errorf("fake-acl-error"))
+		})
+
+		It("returns an error", func() {
+			proposalResponse, err := e.Evaluate(context.Background(), signedProposal)
+			Expect(err).To(MatchError("fake-acl-error"))
+			Expect(proposalResponse.Response).To(Equal(&pb.Response{
+				Status:  500,
+				Message: "fake-acl-error",
+			}))
+			Expect(fakeSupport.ExecuteCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the creator's certificate has been revoked", func() {
+		BeforeEach(func() {
+			fakeChannelIdentity.GetMSPIdentifierReturns("msp-id")
+			e.RevocationChecker = revocationChecker{err: fmt.Errorf("fake-revoked-error")}
+		})
+
+		It("returns an error", func() {
+			proposalResponse, err := e.Evaluate(context.Background(), signedProposal)
+			Expect(err).To(MatchError("error validating proposal: access denied: channel [channel-id] creator org [msp-id]"))
+			Expect(proposalResponse.Response.Status).To(Equal(int32(500)))
+			Expect(fakeSupport.ExecuteCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the channel id is empty", func() {
+		BeforeEach(func() {
+			channelID = ""
+		})
+
+		It("returns an error", func() {
+			proposalResponse, err := e.Evaluate(context.Background(), signedProposal)
+			Expect(err).To(MatchError("proposals without a channel cannot be evaluated"))
+			Expect(proposalResponse.Response.Status).To(Equal(int32(500)))
+			Expect(fakeSupport.ExecuteCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the chaincode name is qscc", func() {
+		BeforeEach(func() {
+			chaincodeName = "qscc"
+		})
+
+		It("does not acquire a query executor", func() {
+			_, err := e.Evaluate(context.Background(), signedProposal)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeSupport.GetQueryExecutorCallCount()).To(Equal(0))
+			txParams, _, _ := fakeSupport.ExecuteArgsForCall(0)
+			Expect(txParams.TXSimulator).To(BeNil())
+		})
+	})
+})
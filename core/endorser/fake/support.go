@@ -127,6 +127,19 @@ type Support struct {
 		result1 uint64
 		result2 error
 	}
+	GetQueryExecutorStub        func(string) (ledger.QueryExecutor, error)
+	getQueryExecutorMutex       sync.RWMutex
+	getQueryExecutorArgsForCall []struct {
+		arg1 string
+	}
+	getQueryExecutorReturns struct {
+		result1 ledger.QueryExecutor
+		result2 error
+	}
+	getQueryExecutorReturnsOnCall map[int]struct {
+		result1 ledger.QueryExecutor
+		result2 error
+	}
 	GetTransactionByIDStub        func(string, string) (*peer.ProcessedTransaction, error)
 	getTransactionByIDMutex       sync.RWMutex
 	getTransactionByIDArgsForCall []struct {
@@ -710,6 +723,69 @@ func (fake *Support) GetLedgerHeightReturnsOnCall(i int, result1 uint64, result2
 	}{result1, result2}
 }
 
+func (fake *Support) GetQueryExecutor(arg1 string) (ledger.QueryExecutor, error) {
+	fake.getQueryExecutorMutex.Lock()
+	ret, specificReturn := fake.getQueryExecutorReturnsOnCall[len(fake.getQueryExecutorArgsForCall)]
+	fake.getQueryExecutorArgsForCall = append(fake.getQueryExecutorArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetQueryExecutor", []interface{}{arg1})
+	fake.getQueryExecutorMutex.Unlock()
+	if fake.GetQueryExecutorStub != nil {
+		return fake.GetQueryExecutorStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getQueryExecutorReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Support) GetQueryExecutorCallCount() int {
+	fake.getQueryExecutorMutex.RLock()
+	defer fake.getQueryExecutorMutex.RUnlock()
+	return len(fake.getQueryExecutorArgsForCall)
+}
+
+func (fake *Support) GetQueryExecutorCalls(stub func(string) (ledger.QueryExecutor, error)) {
+	fake.getQueryExecutorMutex.Lock()
+	defer fake.getQueryExecutorMutex.Unlock()
+	fake.GetQueryExecutorStub = stub
+}
+
+func (fake *Support) GetQueryExecutorArgsForCall(i int) string {
+	fake.getQueryExecutorMutex.RLock()
+	defer fake.getQueryExecutorMutex.RUnlock()
+	argsForCall := fake.getQueryExecutorArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Support) GetQueryExecutorReturns(result1 ledger.QueryExecutor, result2 error) {
+	fake.getQueryExecutorMutex.Lock()
+	defer fake.getQueryExecutorMutex.Unlock()
+	fake.GetQueryExecutorStub = nil
+	fake.getQueryExecutorReturns = struct {
+		result1 ledger.QueryExecutor
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Support) GetQueryExecutorReturnsOnCall(i int, result1 ledger.QueryExecutor, result2 error) {
+	fake.getQueryExecutorMutex.Lock()
+	defer fake.getQueryExecutorMutex.Unlock()
+	fake.GetQueryExecutorStub = nil
+	if fake.getQueryExecutorReturnsOnCall == nil {
+		fake.getQueryExecutorReturnsOnCall = make(map[int]struct {
+			result1 ledger.QueryExecutor
+			result2 error
+		})
+	}
+	fake.getQueryExecutorReturnsOnCall[i] = struct {
+		result1 ledger.QueryExecutor
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Support) GetTransactionByID(arg1 string, arg2 string) (*peer.ProcessedTransaction, error) {
 	fake.getTransactionByIDMutex.Lock()
 	ret, specificReturn := fake.getTransactionByIDReturnsOnCall[len(fake.getTransactionByIDArgsForCall)]
@@ -1040,6 +1116,8 @@ func (fake *Support) Invocations() map[string][][]interface{} {
 	defer fake.getHistoryQueryExecutorMutex.RUnlock()
 	fake.getLedgerHeightMutex.RLock()
 	defer fake.getLedgerHeightMutex.RUnlock()
+	fake.getQueryExecutorMutex.RLock()
+	defer fake.getQueryExecutorMutex.RUnlock()
 	fake.getTransactionByIDMutex.RLock()
 	defer fake.getTransactionByIDMutex.RUnlock()
 	fake.getTxSimulatorMutex.RLock()
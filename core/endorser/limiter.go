@@ -0,0 +1,246 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/semaphore"
+)
+
+// StatusTooManyRequests is the response status returned to clients when a
+// proposal is rejected because a channel or chaincode limit was exceeded.
+const StatusTooManyRequests = 429
+
+// Limit describes the concurrency and request-rate limits applied to
+// proposals. A zero value for a field disables the corresponding limit.
+type Limit struct {
+	// Concurrency is the maximum number of proposals processed at once.
+	Concurrency int
+	// Rate is the sustained number of proposals accepted per second.
+	Rate float64
+	// Burst is the number of proposals that may be accepted at once when
+	// Rate is set. If unset, it defaults to the rate rounded up.
+	Burst int
+}
+
+// LimiterConfig holds the limits enforced by a Limiter.
+type LimiterConfig struct {
+	// Channel is applied to all proposals on a channel.
+	Channel Limit
+	// Chaincode is applied to the proposals for each chaincode on a channel.
+	Chaincode Limit
+	// ChaincodeOverrides replaces the Chaincode limit for the named chaincodes.
+	ChaincodeOverrides map[string]Limit
+}
+
+// LimitExceededError is returned by the Limiter when a proposal is rejected.
+type LimitExceededError struct {
+	// Scope is either "channel" or "chaincode".
+	Scope string
+	// Reason is either "concurrency" or "rate".
+	Reason    string
+	ChannelID string
+	Chaincode string
+	// Shared is set when the exceeded limit is the one shared by all the
+	// channels, or chaincodes, that are unknown to the peer rather than the
+	// limit of ChannelID or Chaincode alone.
+	Shared bool
+}
+
+func (e *LimitExceededError) Error() string {
+	limit := e.Reason + " limit"
+	if e.Shared && e.Scope == "channel" {
+		limit += " shared by the channels the peer has not joined"
+	} else if e.Shared {
+		limit += " shared by the undefined chaincodes"
+	}
+	if e.Scope == "channel" {
+		return fmt.Sprintf("too many requests for channel %s, exceeding %s", e.ChannelID, limit)
+	}
+	return fmt.Sprintf("too many requests for chaincode %s on channel %s, exceeding %s", e.Chaincode, e.ChannelID, limit)
+}
+
+// LimiterRegistry tells the Limiter which channels and chaincodes exist.
+type LimiterRegistry interface {
+	// ChannelExists returns whether the peer has joined the channel.
+	ChannelExists(channelID string) bool
+	// ChaincodeExists returns whether the chaincode is defined on the channel.
+	ChaincodeExists(channelID, chaincodeName string) bool
+}
+
+// Limiter enforces per-channel and per-chaincode concurrency and rate limits
+// on proposals. Limits are tracked separately only for the channels and
+// chaincodes that exist or have an override, all others share a default
+// entry, so that clients cannot grow the Limiter by sending made-up names.
+type Limiter struct {
+	config   LimiterConfig
+	registry LimiterRegistry
+	now      func() time.Time
+
+	mutex      sync.Mutex
+	channels   map[string]*limiterEntry
+	chaincodes map[chaincodeKey]*limiterEntry
+}
+
+type chaincodeKey struct {
+	channelID string
+	chaincode string
+}
+
+// NewLimiter creates a Limiter enforcing the supplied configuration for the
+// channels and chaincodes of the registry.
+func NewLimiter(config LimiterConfig, registry LimiterRegistry) *Limiter {
+	return &Limiter{
+		config:     config,
+		registry:   registry,
+		now:        time.Now,
+		channels:   map[string]*limiterEntry{},
+		chaincodes: map[chaincodeKey]*limiterEntry{},
+	}
+}
+
+// Acquire reserves capacity for a proposal for the chaincode on the channel.
+// On success, the returned function must be called once the proposal has
+// been processed. If any limit is exceeded, a *LimitExceededError is returned
+// and no capacity is held.
+func (l *Limiter) Acquire(channelID, chaincodeName string) (release func(), err error) {
+	channel, chaincode := l.entries(channelID, chaincodeName)
+	exceeded := func(entry *limiterEntry, scope, reason string) error {
+		return &LimitExceededError{Scope: scope, Reason: reason, ChannelID: channelID, Chaincode: chaincodeName, Shared: entry.shared}
+	}
+
+	if !channel.tryAcquire() {
+		return nil, exceeded(channel, "channel", "concurrency")
+	}
+	if !chaincode.tryAcquire() {
+		channel.release()
+		return nil, exceeded(chaincode, "chaincode", "concurrency")
+	}
+
+	release = func() {
+		chaincode.release()
+		channel.release()
+	}
+
+	now := l.now()
+	if !channel.take(now) {
+		release()
+		return nil, exceeded(channel, "channel", "rate")
+	}
+	if !chaincode.take(now) {
+		release()
+		return nil, exceeded(chaincode, "chaincode", "rate")
+	}
+
+	return release, nil
+}
+
+// entries returns the limiter entries of the channel and of the chaincode on
+// it. The empty channel and chaincode names key the entries shared by the
+// unknown channels and chaincodes.
+func (l *Limiter) entries(channelID, chaincodeName string) (*limiterEntry, *limiterEntry) {
+	limit, overridden := l.config.ChaincodeOverrides[chaincodeName]
+	if !overridden {
+		limit = l.config.Chaincode
+	}
+	if !l.registry.ChannelExists(channelID) {
+		channelID = ""
+	}
+	if !overridden && (channelID == "" || !l.registry.ChaincodeExists(channelID, chaincodeName)) {
+		chaincodeName = ""
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	channel, ok := l.channels[channelID]
+	if !ok {
+		channel = newLimiterEntry(l.config.Channel, l.now())
+		channel.shared = channelID == ""
+		l.channels[channelID] = channel
+	}
+
+	key := chaincodeKey{channelID: channelID, chaincode: chaincodeName}
+	chaincode, ok := l.chaincodes[key]
+	if !ok {
+		chaincode = newLimiterEntry(limit, l.now())
+		chaincode.shared = chaincodeName == ""
+		l.chaincodes[key] = chaincode
+	}
+
+	return channel, chaincode
+}
+
+// limiterEntry combines a concurrency semaphore and a token bucket. Either
+// may be disabled.
+type limiterEntry struct {
+	sema semaphore.Semaphore
+	// shared is set for the entries of unknown channels and chaincodes
+	shared bool
+
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newLimiterEntry(limit Limit, now time.Time) *limiterEntry {
+	entry := &limiterEntry{}
+	if limit.Concurrency > 0 {
+		entry.sema = semaphore.New(limit.Concurrency)
+	}
+	if limit.Rate > 0 {
+		burst := float64(limit.Burst)
+		if burst <= 0 {
+			burst = math.Ceil(limit.Rate)
+		}
+		entry.rate = limit.Rate
+		entry.burst = burst
+		entry.tokens = burst
+		entry.last = now
+	}
+	return entry
+}
+
+func (le *limiterEntry) tryAcquire() bool {
+	if le.sema == nil {
+		return true
+	}
+	return le.sema.TryAcquire()
+}
+
+func (le *limiterEntry) release() {
+	if le.sema != nil {
+		le.sema.Release()
+	}
+}
+
+// take removes a token from the bucket, refilling it first based on the time
+// elapsed since the last call.
+func (le *limiterEntry) take(now time.Time) bool {
+	if le.rate == 0 {
+		return true
+	}
+
+	le.mutex.Lock()
+	defer le.mutex.Unlock()
+
+	if elapsed := now.Sub(le.last).Seconds(); elapsed > 0 {
+		le.tokens = math.Min(le.burst, le.tokens+elapsed*le.rate)
+		le.last = now
+	}
+	if le.tokens < 1 {
+		return false
+	}
+	le.tokens--
+	return true
+}
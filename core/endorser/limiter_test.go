@@ -0,0 +1,160 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package endorser
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// registry reports the chaincodes it maps to their channel as existing.
+type registry map[string]string
+
+func (r registry) ChannelExists(channelID string) bool {
+	for _, channel := range r {
+		if channel == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r registry) ChaincodeExists(channelID, chaincodeName string) bool {
+	channel, ok := r[chaincodeName]
+	return ok && channel == channelID
+}
+
+func TestLimiterConcurrency(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	limiter := NewLimiter(LimiterConfig{
+		Channel:   Limit{Concurrency: 3},
+		Chaincode: Limit{Concurrency: 2},
+		ChaincodeOverrides: map[string]Limit{
+			"unlimited": {},
+		},
+	}, registry{"cc": "channel"})
+
+	release1, err := limiter.Acquire("channel", "cc")
+	gt.Expect(err).NotTo(HaveOccurred())
+	_, err = limiter.Acquire("channel", "cc")
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	_, err = limiter.Acquire("channel", "cc")
+	gt.Expect(err).To(Equal(&LimitExceededError{Scope: "chaincode", Reason: "concurrency", ChannelID: "channel", Chaincode: "cc"}))
+	gt.Expect(err).To(MatchError("too many requests for chaincode cc on channel channel, exceeding concurrency limit"))
+
+	_, err = limiter.Acquire("other-channel", "cc")
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	_, err = limiter.Acquire("channel", "unlimited")
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	_, err = limiter.Acquire("channel", "unlimited")
+	gt.Expect(err).To(Equal(&LimitExceededError{Scope: "channel", Reason: "concurrency", ChannelID: "channel", Chaincode: "unlimited"}))
+	gt.Expect(err).To(MatchError("too many requests for channel channel, exceeding concurrency limit"))
+
+	release1()
+	_, err = limiter.Acquire("channel", "cc")
+	gt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestLimiterRate(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	now := time.Unix(0, 0)
+	limiter := NewLimiter(LimiterConfig{
+		Chaincode: Limit{Rate: 2, Burst: 3},
+		ChaincodeOverrides: map[string]Limit{
+			"slow": {Rate: 0.5},
+		},
+	}, registry{"cc": "channel"})
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		_, err := limiter.Acquire("channel", "cc")
+		gt.Expect(err).NotTo(HaveOccurred())
+	}
+	_, err := limiter.Acquire("channel", "cc")
+	gt.Expect(err).To(Equal(&LimitExceededError{Scope: "chaincode", Reason: "rate", ChannelID: "channel", Chaincode: "cc"}))
+
+	now = now.Add(500 * time.Millisecond)
+	_, err = limiter.Acquire("channel", "cc")
+	gt.Expect(err).NotTo(HaveOccurred())
+	_, err = limiter.Acquire("channel", "cc")
+	gt.Expect(err).To(HaveOccurred())
+
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		_, err := limiter.Acquire("channel", "cc")
+		gt.Expect(err).NotTo(HaveOccurred())
+	}
+	_, err = limiter.Acquire("channel", "cc")
+	gt.Expect(err).To(HaveOccurred())
+
+	_, err = limiter.Acquire("channel", "slow")
+	gt.Expect(err).NotTo(HaveOccurred())
+	_, err = limiter.Acquire("channel", "slow")
+	gt.Expect(err).To(HaveOccurred())
+	now = now.Add(2 * time.Second)
+	_, err = limiter.Acquire("channel", "slow")
+	gt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestLimiterRateRejectionReleasesConcurrency(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	limiter := NewLimiter(LimiterConfig{
+		Channel: Limit{Concurrency: 1, Rate: 1},
+	}, registry{"cc": "channel"})
+	limiter.now = func() time.Time { return time.Unix(0, 0) }
+
+	release, err := limiter.Acquire("channel", "cc")
+	gt.Expect(err).NotTo(HaveOccurred())
+	release()
+
+	_, err = limiter.Acquire("channel", "cc")
+	gt.Expect(err).To(Equal(&LimitExceededError{Scope: "channel", Reason: "rate", ChannelID: "channel", Chaincode: "cc"}))
+
+	limiter.now = func() time.Time { return time.Unix(1, 0) }
+	_, err = limiter.Acquire("channel", "cc")
+	gt.Expect(err).NotTo(HaveOccurred())
+}
+
+func TestLimiterUnknownNames(t *testing.T) {
+	gt := NewGomegaWithT(t)
+
+	limiter := NewLimiter(LimiterConfig{
+		Chaincode: Limit{Concurrency: 1},
+		ChaincodeOverrides: map[string]Limit{
+			"overridden": {Concurrency: 1},
+		},
+	}, registry{"cc": "channel"})
+
+	release, err := limiter.Acquire("channel", "unknown1")
+	gt.Expect(err).NotTo(HaveOccurred())
+	_, err = limiter.Acquire("channel", "unknown2")
+	gt.Expect(err).To(Equal(&LimitExceededError{Scope: "chaincode", Reason: "concurrency", ChannelID: "channel", Chaincode: "unknown2", Shared: true}))
+	gt.Expect(err).To(MatchError("too many requests for chaincode unknown2 on channel channel, exceeding concurrency limit shared by the undefined chaincodes"))
+	_, err = limiter.Acquire("channel", "cc")
+	gt.Expect(err).NotTo(HaveOccurred())
+	release()
+
+	for i := 0; i < 100; i++ {
+		release, err := limiter.Acquire(fmt.Sprintf("channel%d", i), fmt.Sprintf("cc%d", i))
+		gt.Expect(err).NotTo(HaveOccurred())
+		release()
+	}
+	_, err = limiter.Acquire("unknown", "overridden")
+	gt.Expect(err).NotTo(HaveOccurred())
+
+	gt.Expect(limiter.channels).To(HaveLen(2))
+	gt.Expect(limiter.chaincodes).To(HaveLen(4))
+}
@@ -74,6 +74,58 @@ var (
 		LabelNames:   []string{"channel", "chaincode"},
 		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
 	}
+
+	throttledProposalsCounterOpts = metrics.CounterOpts{
+		Namespace:    "endorser",
+		Name:         "proposals_throttled",
+		Help:         "The number of proposals rejected for exceeding a channel or chaincode limit.",
+		LabelNames:   []string{"channel", "chaincode", "scope", "reason"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}.%{scope}.%{reason}",
+	}
+
+	inFlightProposalsGaugeOpts = metrics.GaugeOpts{
+		Namespace:    "endorser",
+		Name:         "proposals_in_flight",
+		Help:         "The number of proposals currently being processed.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+
+	evaluateDurationHistogramOpts = metrics.HistogramOpts{
+		Namespace:    "endorser",
+		Name:         "evaluate_duration",
+		Help:         "The time to complete an evaluation.",
+		LabelNames:   []string{"channel", "chaincode", "success"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}.%{success}",
+	}
+
+	receivedEvaluationsCounterOpts = metrics.CounterOpts{
+		Namespace: "endorser",
+		Name:      "evaluations_received",
+		Help:      "The number of evaluation requests received.",
+	}
+
+	successfulEvaluationsCounterOpts = metrics.CounterOpts{
+		Namespace: "endorser",
+		Name:      "successful_evaluations",
+		Help:      "The number of successful evaluations.",
+	}
+
+	evaluationFailureCounterOpts = metrics.CounterOpts{
+		Namespace:    "endorser",
+		Name:         "evaluation_failures",
+		Help:         "The number of evaluations that failed to execute the chaincode.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
+
+	rejectedEvaluationWritesCounterOpts = metrics.CounterOpts{
+		Namespace:    "endorser",
+		Name:         "evaluation_writes_rejected",
+		Help:         "The number of evaluations rejected for attempting to write to the ledger.",
+		LabelNames:   []string{"channel", "chaincode"},
+		StatsdFormat: "%{#fqname}.%{channel}.%{chaincode}",
+	}
 )
 
 type Metrics struct {
@@ -86,6 +138,13 @@ type Metrics struct {
 	EndorsementsFailed       metrics.Counter
 	DuplicateTxsFailure      metrics.Counter
 	SimulationFailure        metrics.Counter
+	ProposalsThrottled       metrics.Counter
+	ProposalsInFlight        metrics.Gauge
+	EvaluateDuration         metrics.Histogram
+	EvaluationsReceived      metrics.Counter
+	SuccessfulEvaluations    metrics.Counter
+	EvaluationFailure        metrics.Counter
+	EvaluationWritesRejected metrics.Counter
 }
 
 func NewMetrics(p metrics.Provider) *Metrics {
@@ -99,5 +158,12 @@ func NewMetrics(p metrics.Provider) *Metrics {
 		EndorsementsFailed:       p.NewCounter(endorsementFailureCounterOpts),
 		DuplicateTxsFailure:      p.NewCounter(duplicateTxsFailureCounterOpts),
 		SimulationFailure:        p.NewCounter(simulationFailureCounterOpts),
+		ProposalsThrottled:       p.NewCounter(throttledProposalsCounterOpts),
+		ProposalsInFlight:        p.NewGauge(inFlightProposalsGaugeOpts),
+		EvaluateDuration:         p.NewHistogram(evaluateDurationHistogramOpts),
+		EvaluationsReceived:      p.NewCounter(receivedEvaluationsCounterOpts),
+		SuccessfulEvaluations:    p.NewCounter(successfulEvaluationsCounterOpts),
+		EvaluationFailure:        p.NewCounter(evaluationFailureCounterOpts),
+		EvaluationWritesRejected: p.NewCounter(rejectedEvaluationWritesCounterOpts),
 	}
 }
@@ -19,6 +19,7 @@ func TestNewMetrics(t *testing.T) {
 	provider := &metricsfakes.Provider{}
 	provider.NewHistogramReturns(&metricsfakes.Histogram{})
 	provider.NewCounterReturns(&metricsfakes.Counter{})
+	provider.NewGaugeReturns(&metricsfakes.Gauge{})
 
 	endorserMetrics := NewMetrics(provider)
 	gt.Expect(endorserMetrics).To(Equal(&Metrics{
@@ -31,14 +32,22 @@ func TestNewMetrics(t *testing.T) {
 		EndorsementsFailed:       &metricsfakes.Counter{},
 		DuplicateTxsFailure:      &metricsfakes.Counter{},
 		SimulationFailure:        &metricsfakes.Counter{},
+		ProposalsThrottled:       &metricsfakes.Counter{},
+		ProposalsInFlight:        &metricsfakes.Gauge{},
+		EvaluateDuration:         &metricsfakes.Histogram{},
+		EvaluationsReceived:      &metricsfakes.Counter{},
+		SuccessfulEvaluations:    &metricsfakes.Counter{},
+		EvaluationFailure:        &metricsfakes.Counter{},
+		EvaluationWritesRejected: &metricsfakes.Counter{},
 	}))
 
-	gt.Expect(provider.NewHistogramCallCount()).To(Equal(1))
+	gt.Expect(provider.NewHistogramCallCount()).To(Equal(2))
 	gt.Expect(provider.Invocations()["NewHistogram"]).To(ConsistOf([][]interface{}{
 		{proposalDurationHistogramOpts},
+		{evaluateDurationHistogramOpts},
 	}))
 
-	gt.Expect(provider.NewCounterCallCount()).To(Equal(8))
+	gt.Expect(provider.NewCounterCallCount()).To(Equal(13))
 	gt.Expect(provider.Invocations()["NewCounter"]).To(ConsistOf([][]interface{}{
 		{receivedProposalsCounterOpts},
 		{successfulProposalsCounterOpts},
@@ -48,5 +57,15 @@ func TestNewMetrics(t *testing.T) {
 		{endorsementFailureCounterOpts},
 		{duplicateTxsFailureCounterOpts},
 		{simulationFailureCounterOpts},
+		{throttledProposalsCounterOpts},
+		{receivedEvaluationsCounterOpts},
+		{successfulEvaluationsCounterOpts},
+		{evaluationFailureCounterOpts},
+		{rejectedEvaluationWritesCounterOpts},
+	}))
+
+	gt.Expect(provider.NewGaugeCallCount()).To(Equal(1))
+	gt.Expect(provider.Invocations()["NewGauge"]).To(ConsistOf([][]interface{}{
+		{inFlightProposalsGaugeOpts},
 	}))
 }
@@ -66,6 +66,15 @@ func (s *SupportImpl) GetTxSimulator(ledgername string, txid string) (ledger.TxS
 	return lgr.NewTxSimulator(txid)
 }
 
+// GetQueryExecutor returns a query executor for the specified ledger
+func (s *SupportImpl) GetQueryExecutor(ledgername string) (ledger.QueryExecutor, error) {
+	lgr := s.Peer.GetLedger(ledgername)
+	if lgr == nil {
+		return nil, errors.Errorf("Channel does not exist: %s", ledgername)
+	}
+	return lgr.NewQueryExecutor()
+}
+
 // GetHistoryQueryExecutor gives handle to a history query executor for the
 // specified ledger
 func (s *SupportImpl) GetHistoryQueryExecutor(ledgername string) (ledger.HistoryQueryExecutor, error) {
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package auth
 
 import (
+	"context"
+
 	"github.com/hyperledger/fabric-protos-go/peer"
 )
 
@@ -35,3 +37,46 @@ func ChainFilters(endorser peer.EndorserServer, filters ...Filter) peer.Endorser
 
 	return filters[0]
 }
+
+// Evaluator evaluates proposals without endorsing them
+type Evaluator interface {
+	Evaluate(ctx context.Context, signedProp *peer.SignedProposal) (*peer.ProposalResponse, error)
+}
+
+// ChainEvaluator routes the proposals submitted to the returned Evaluator
+// through the given chain of filters, whose last filter must forward to
+// the EndorserServer returned by EvaluationDispatcher. The filters are thus
+// applied to evaluations exactly as they are to endorsements.
+func ChainEvaluator(chain peer.EndorserServer) Evaluator {
+	return &chainedEvaluator{chain: chain}
+}
+
+// EvaluationDispatcher returns an EndorserServer that forwards the proposals
+// submitted through ChainEvaluator to the evaluator, and all the other
+// proposals to the endorser.
+func EvaluationDispatcher(endorser peer.EndorserServer, evaluator Evaluator) peer.EndorserServer {
+	return &dispatcher{endorser: endorser, evaluator: evaluator}
+}
+
+// evaluationKey marks the context of the proposals submitted for evaluation
+type evaluationKey struct{}
+
+type chainedEvaluator struct {
+	chain peer.EndorserServer
+}
+
+func (e *chainedEvaluator) Evaluate(ctx context.Context, signedProp *peer.SignedProposal) (*peer.ProposalResponse, error) {
+	return e.chain.ProcessProposal(context.WithValue(ctx, evaluationKey{}, true), signedProp)
+}
+
+type dispatcher struct {
+	endorser  peer.EndorserServer
+	evaluator Evaluator
+}
+
+func (d *dispatcher) ProcessProposal(ctx context.Context, signedProp *peer.SignedProposal) (*peer.ProposalResponse, error) {
+	if evaluation, _ := ctx.Value(evaluationKey{}).(bool); evaluation {
+		return d.evaluator.Evaluate(ctx, signedProp)
+	}
+	return d.endorser.ProcessProposal(ctx, signedProp)
+}
@@ -40,6 +40,31 @@ func TestChainFilters(t *testing.T) {
 		"Expected endorser to be invoked first")
 }
 
+func TestChainEvaluator(t *testing.T) {
+	filters := createNFilters(3)
+	endorser := &mockEndorserServer{}
+	evaluator := &mockEvaluator{}
+
+	chain := ChainFilters(EvaluationDispatcher(endorser, evaluator), filters...)
+	proposal := &peer.SignedProposal{ProposalBytes: make([]byte, 4)}
+	ChainEvaluator(chain).Evaluate(context.Background(), proposal)
+	for i := range filters {
+		require.Equal(t, uint32(i), filters[i].(*mockAuthFilter).sequence,
+			"Expected filters to be invoked in the provided sequence")
+	}
+	require.Equal(t, uint32(len(filters)), evaluator.sequence,
+		"Expected evaluator to be invoked after filters")
+	require.Equal(t, uint32(0), endorser.sequence, "Expected endorser not to be invoked")
+
+	// proposals submitted to the chain are still endorsed
+	binary.BigEndian.PutUint32(proposal.ProposalBytes, 0)
+	evaluator.sequence = 0
+	chain.ProcessProposal(context.Background(), proposal)
+	require.Equal(t, uint32(len(filters)), endorser.sequence,
+		"Expected endorser to be invoked after filters")
+	require.Equal(t, uint32(0), evaluator.sequence, "Expected evaluator not to be invoked")
+}
+
 func createNFilters(n int) []Filter {
 	filters := make([]Filter, n)
 	for i := 0; i < n; i++ {
@@ -72,3 +97,12 @@ func (f *mockAuthFilter) ProcessProposal(ctx context.Context, prop *peer.SignedP
 func (f *mockAuthFilter) Init(next peer.EndorserServer) {
 	f.next = next
 }
+
+type mockEvaluator struct {
+	sequence uint32
+}
+
+func (e *mockEvaluator) Evaluate(ctx context.Context, prop *peer.SignedProposal) (*peer.ProposalResponse, error) {
+	e.sequence = binary.BigEndian.Uint32(prop.ProposalBytes)
+	return nil, nil
+}
@@ -762,6 +762,17 @@ func (l *kvLedger) GetMissingPvtDataInfoForMostRecentBlocks(maxBlock int) (ledge
 	return l.pvtdataStore.GetMissingPvtDataInfoForMostRecentBlocks(maxBlock)
 }
 
+// ListMissingPvtData returns the private data missing on the peer, for both the collections the
+// peer is eligible for and those it is not, which matches the given filter.
+func (l *kvLedger) ListMissingPvtData(filter *ledger.MissingPvtDataFilter) ([]*ledger.MissingPvtDataEntry, error) {
+	// as in GetMissingPvtDataInfoForMostRecentBlocks, the missing pvtData info of blocks which are yet
+	// to be committed to the blockStore and stateDB is not returned, so that it does not get reconciled
+	if l.isPvtstoreAheadOfBlkstore.Load().(bool) {
+		return nil, nil
+	}
+	return l.pvtdataStore.ListMissingPvtData(filter)
+}
+
 func (l *kvLedger) addBlockCommitHash(block *common.Block, updateBatchBytes []byte) {
 	var valueBytes []byte
 
@@ -548,6 +548,29 @@ type ConfigHistoryRetriever interface {
 // MissingPvtDataTracker allows getting information about the private data that is not missing on the peer
 type MissingPvtDataTracker interface {
 	GetMissingPvtDataInfoForMostRecentBlocks(maxBlocks int) (MissingPvtDataInfo, error)
+	// ListMissingPvtData returns the private data missing on the peer, for both the collections
+	// the peer is eligible for and those it is not, which matches the given filter
+	ListMissingPvtData(filter *MissingPvtDataFilter) ([]*MissingPvtDataEntry, error)
+}
+
+// MissingPvtDataFilter selects missing private data by block range, namespace and collection.
+// An EndBlock of zero stands for the last committed block, and an empty Namespace or
+// Collection matches any namespace or collection.
+type MissingPvtDataFilter struct {
+	StartBlock uint64
+	EndBlock   uint64
+	Namespace  string
+	Collection string
+}
+
+// MissingPvtDataEntry lists the transactions of a block for which the private data of
+// a collection is missing, and whether the peer is eligible for the collection
+type MissingPvtDataEntry struct {
+	BlockNum   uint64
+	Namespace  string
+	Collection string
+	TxNums     []uint64
+	Eligible   bool
 }
 
 // MissingPvtDataInfo is a map of block number to MissingBlockPvtdataInfo
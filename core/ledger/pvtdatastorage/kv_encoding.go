@@ -257,6 +257,10 @@ func createRangeScanKeysForInelgMissingData(maxBlkNum uint64, ns, coll string) (
 	return startKey, endKey
 }
 
+func createRangeScanKeysForInelgMissingDataGroup() ([]byte, []byte) {
+	return inelgMissingDataGroup, []byte{inelgMissingDataGroup[0] + 1}
+}
+
 func createRangeScanKeysForCollElg() (startKey, endKey []byte) {
 	return encodeCollElgKey(math.MaxUint64),
 		encodeCollElgKey(0)
@@ -8,6 +8,7 @@ package pvtdatastorage
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -551,6 +552,122 @@ func (s *Store) getMissingData(group []byte, maxBlock int) (ledger.MissingPvtDat
 	return missingPvtDataInfo, nil
 }
 
+// ListMissingPvtData returns the private data missing on the peer, for both the collections the
+// peer is eligible for and those it is not, which match the given filter. Entries of expired
+// private data are skipped. The entries are sorted by block number in decreasing order, then by
+// namespace and collection, with the eligible entry first.
+func (s *Store) ListMissingPvtData(filter *ledger.MissingPvtDataFilter) ([]*ledger.MissingPvtDataEntry, error) {
+	if filter == nil {
+		filter = &ledger.MissingPvtDataFilter{}
+	}
+	lastCommittedBlock := atomic.LoadUint64(&s.lastCommittedBlock)
+	endBlock := lastCommittedBlock
+	if filter.EndBlock != 0 && filter.EndBlock < endBlock {
+		endBlock = filter.EndBlock
+	}
+	if filter.StartBlock > endBlock {
+		return nil, nil
+	}
+
+	missingData := make(map[missingDataEntryKey]*bitset.BitSet)
+	// the missing data of a block, namespace and collection can be split between the
+	// prioritized and the deprioritized list when only part of it got reconciled
+	for _, group := range [][]byte{elgPrioritizedMissingDataGroup, elgDeprioritizedMissingDataGroup} {
+		startKey, endKey := createRangeScanKeysForElgMissingData(endBlock, group)
+		if err := s.collectMissingData(startKey, endKey, true, filter, endBlock, lastCommittedBlock, missingData); err != nil {
+			return nil, err
+		}
+	}
+	startKey, endKey := createRangeScanKeysForInelgMissingDataGroup()
+	if err := s.collectMissingData(startKey, endKey, false, filter, endBlock, lastCommittedBlock, missingData); err != nil {
+		return nil, err
+	}
+
+	var entries []*ledger.MissingPvtDataEntry
+	for key, bitmap := range missingData {
+		entry := &ledger.MissingPvtDataEntry{
+			BlockNum:   key.blkNum,
+			Namespace:  key.ns,
+			Collection: key.coll,
+			Eligible:   key.eligible,
+		}
+		for index, isSet := bitmap.NextSet(0); isSet; index, isSet = bitmap.NextSet(index + 1) {
+			entry.TxNums = append(entry.TxNums, uint64(index))
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch {
+		case a.BlockNum != b.BlockNum:
+			return a.BlockNum > b.BlockNum
+		case a.Namespace != b.Namespace:
+			return a.Namespace < b.Namespace
+		case a.Collection != b.Collection:
+			return a.Collection < b.Collection
+		default:
+			return a.Eligible && !b.Eligible
+		}
+	})
+	return entries, nil
+}
+
+type missingDataEntryKey struct {
+	nsCollBlk
+	eligible bool
+}
+
+// collectMissingData merges the missing data entries in the range [startKey, endKey) which match
+// the filter into missingData.
+func (s *Store) collectMissingData(startKey, endKey []byte, eligible bool, filter *ledger.MissingPvtDataFilter,
+	endBlock, lastCommittedBlock uint64, missingData map[missingDataEntryKey]*bitset.BitSet) error {
+	dbItr, err := s.db.GetIterator(startKey, endKey)
+	if err != nil {
+		return err
+	}
+	defer dbItr.Release()
+
+	for dbItr.Next() {
+		var key *missingDataKey
+		if eligible {
+			key = decodeElgMissingDataKey(dbItr.Key())
+			// eligible entries are ordered by decreasing block number
+			if key.blkNum < filter.StartBlock {
+				break
+			}
+		} else {
+			key = decodeInelgMissingDataKey(dbItr.Key())
+			if key.blkNum < filter.StartBlock || key.blkNum > endBlock {
+				continue
+			}
+		}
+		if (filter.Namespace != "" && key.ns != filter.Namespace) ||
+			(filter.Collection != "" && key.coll != filter.Collection) {
+			continue
+		}
+
+		expired, err := isExpired(key.nsCollBlk, s.btlPolicy, lastCommittedBlock)
+		if err != nil {
+			return err
+		}
+		if expired {
+			continue
+		}
+
+		bitmap, err := decodeMissingDataValue(dbItr.Value())
+		if err != nil {
+			return err
+		}
+		entryKey := missingDataEntryKey{nsCollBlk: key.nsCollBlk, eligible: eligible}
+		if existing, ok := missingData[entryKey]; ok {
+			existing.InPlaceUnion(bitmap)
+			continue
+		}
+		missingData[entryKey] = bitmap
+	}
+	return nil
+}
+
 // FetchBootKVHashes returns the KVHashes from the data that was loaded from a snapshot at the time of
 // bootstrapping. This funciton returns an error if the supplied blkNum is greater than the last block
 // number in the booting snapshot
@@ -297,6 +297,60 @@ func TestGetMissingDataInfo(t *testing.T) {
 
 }
 
+func TestListMissingPvtData(t *testing.T) {
+	btlPolicy := btltestutil.SampleBTLPolicy(
+		map[[2]string]uint64{
+			{"ns-1", "coll-1"}: 0,
+			{"ns-1", "coll-2"}: 0,
+			{"ns-2", "coll-1"}: 0,
+		},
+	)
+	env := NewTestStoreEnv(t, "TestListMissingPvtData", btlPolicy, pvtDataConf())
+	defer env.Cleanup()
+	store := env.TestStore
+
+	blk1MissingData := make(ledger.TxMissingPvtData)
+	blk1MissingData.Add(1, "ns-1", "coll-1", true)
+	blk1MissingData.Add(2, "ns-1", "coll-1", true)
+	blk1MissingData.Add(2, "ns-2", "coll-1", false)
+	blk2MissingData := make(ledger.TxMissingPvtData)
+	blk2MissingData.Add(0, "ns-1", "coll-2", true)
+
+	require.NoError(t, store.Commit(0, nil, nil))
+	require.NoError(t, store.Commit(1, nil, blk1MissingData))
+	require.NoError(t, store.Commit(2, nil, blk2MissingData))
+
+	// move the missing data of the second transaction of block 1 to the deprioritized list
+	deprioritizedList := ledger.MissingPvtDataInfo{}
+	deprioritizedList.Add(1, 2, "ns-1", "coll-1")
+	require.NoError(t, store.CommitPvtDataOfOldBlocks(nil, deprioritizedList))
+
+	entries, err := store.ListMissingPvtData(nil)
+	require.NoError(t, err)
+	require.Equal(t, []*ledger.MissingPvtDataEntry{
+		{BlockNum: 2, Namespace: "ns-1", Collection: "coll-2", TxNums: []uint64{0}, Eligible: true},
+		{BlockNum: 1, Namespace: "ns-1", Collection: "coll-1", TxNums: []uint64{1, 2}, Eligible: true},
+		{BlockNum: 1, Namespace: "ns-2", Collection: "coll-1", TxNums: []uint64{2}, Eligible: false},
+	}, entries)
+
+	entries, err = store.ListMissingPvtData(&ledger.MissingPvtDataFilter{EndBlock: 1, Collection: "coll-1"})
+	require.NoError(t, err)
+	require.Equal(t, []*ledger.MissingPvtDataEntry{
+		{BlockNum: 1, Namespace: "ns-1", Collection: "coll-1", TxNums: []uint64{1, 2}, Eligible: true},
+		{BlockNum: 1, Namespace: "ns-2", Collection: "coll-1", TxNums: []uint64{2}, Eligible: false},
+	}, entries)
+
+	entries, err = store.ListMissingPvtData(&ledger.MissingPvtDataFilter{StartBlock: 2, Namespace: "ns-1"})
+	require.NoError(t, err)
+	require.Equal(t, []*ledger.MissingPvtDataEntry{
+		{BlockNum: 2, Namespace: "ns-1", Collection: "coll-2", TxNums: []uint64{0}, Eligible: true},
+	}, entries)
+
+	entries, err = store.ListMissingPvtData(&ledger.MissingPvtDataFilter{StartBlock: 3})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
 func TestExpiryDataNotIncluded(t *testing.T) {
 	ledgerid := "TestExpiryDataNotIncluded"
 	btlPolicy := btltestutil.SampleBTLPolicy(
@@ -13,6 +13,7 @@ import (
 
 	kitstatsd "github.com/go-kit/kit/metrics/statsd"
 	"github.com/hyperledger/fabric-lib-go/healthz"
+	"github.com/hyperledger/fabric/common/capabilities"
 	"github.com/hyperledger/fabric/common/fabhttp"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/flogging/httpadmin"
@@ -156,8 +157,9 @@ func (s *System) initializeHealthCheckHandler() {
 
 func (s *System) initializeVersionInfoHandler() {
 	versionInfo := &VersionInfoHandler{
-		CommitSHA: metadata.CommitSHA,
-		Version:   metadata.Version,
+		CommitSHA:    metadata.CommitSHA,
+		Version:      metadata.Version,
+		Capabilities: capabilities.Supported(),
 	}
 	s.RegisterHandler("/version", versionInfo, false)
 }
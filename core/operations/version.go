@@ -15,8 +15,9 @@ import (
 )
 
 type VersionInfoHandler struct {
-	CommitSHA string `json:"CommitSHA,omitempty"`
-	Version   string `json:"Version,omitempty"`
+	CommitSHA    string              `json:"CommitSHA,omitempty"`
+	Version      string              `json:"Version,omitempty"`
+	Capabilities map[string][]string `json:"Capabilities,omitempty"`
 }
 
 func (m *VersionInfoHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
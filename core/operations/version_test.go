@@ -25,6 +25,15 @@ var _ = Describe("Version", func() {
 		Expect(resp.Body).To(MatchJSON(`{"Version": "latest"}`))
 	})
 
+	It("returns the supported capabilities", func() {
+		resp := httptest.NewRecorder()
+
+		versionInfoHandler := &VersionInfoHandler{Version: "latest", Capabilities: map[string][]string{"Channel": {"V3_0"}}}
+		versionInfoHandler.ServeHTTP(resp, &http.Request{Method: http.MethodGet})
+		Expect(resp.Result().StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(`{"Version": "latest", "Capabilities": {"Channel": ["V3_0"]}}`))
+	})
+
 	It("returns 400 when an unsupported method is used", func() {
 		resp := httptest.NewRecorder()
 
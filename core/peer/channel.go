@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
 	"github.com/hyperledger/fabric/common/ledger/blockledger/fileledger"
 	"github.com/hyperledger/fabric/common/policies"
+	validatorv20 "github.com/hyperledger/fabric/core/committer/txvalidator/v20"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/msp"
@@ -37,6 +38,10 @@ type Channel struct {
 	// resources is used to acquire configuration bundle resources. The reference
 	// is maintained by callbacks from the bundleSource.
 	resources channelconfig.Resources
+
+	// policyTraces keeps the traces of the most recent transactions which
+	// failed their endorsement policy.
+	policyTraces *validatorv20.PolicyTraceStore
 }
 
 // Apply is used to validate and apply configuration transactions for a channel.
@@ -136,6 +141,15 @@ func (c *Channel) Store() *transientstore.Store {
 	return c.store
 }
 
+// EndorsementPolicyTrace returns the endorsement policy evaluation trace of
+// a transaction which failed its endorsement policy, or nil if none is kept.
+func (c *Channel) EndorsementPolicyTrace(txID string) *policies.EvaluationTrace {
+	if c.policyTraces == nil {
+		return nil
+	}
+	return c.policyTraces.Get(txID)
+}
+
 // Reader returns a blockledger.Reader backed by the ledger associated with
 // this channel.
 func (c *Channel) Reader() blockledger.Reader {
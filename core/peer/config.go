@@ -30,6 +30,7 @@ import (
 
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
@@ -46,6 +47,36 @@ type ExternalBuilder struct {
 	Path                 string   `yaml:"path"`
 }
 
+// EndorserLimit represents the concurrency and request-rate limits the
+// endorser applies to proposals. A zero value disables the limit.
+type EndorserLimit struct {
+	Concurrency int     `yaml:"concurrency"`
+	Rate        float64 `yaml:"rate"`
+	Burst       int     `yaml:"burst"`
+}
+
+// validate returns an error if any of the limits is negative.
+func (l EndorserLimit) validate(scope string) error {
+	switch {
+	case l.Concurrency < 0:
+		return fmt.Errorf("invalid endorser limit configuration, negative concurrency %d for %s", l.Concurrency, scope)
+	case l.Rate < 0:
+		return fmt.Errorf("invalid endorser limit configuration, negative rate %g for %s", l.Rate, scope)
+	case l.Burst < 0:
+		return fmt.Errorf("invalid endorser limit configuration, negative burst %d for %s", l.Burst, scope)
+	}
+	return nil
+}
+
+// ChaincodeEndorserLimit overrides the default chaincode endorser limit for
+// the named chaincode.
+type ChaincodeEndorserLimit struct {
+	Name        string  `yaml:"name"`
+	Concurrency int     `yaml:"concurrency"`
+	Rate        float64 `yaml:"rate"`
+	Burst       int     `yaml:"burst"`
+}
+
 // Config is the struct that defines the Peer configurations.
 type Config struct {
 	// LocalMSPID is the identifier of the local MSP.
@@ -76,6 +107,12 @@ type Config struct {
 	// transaction validation in parallel. If omitted, it defaults to number of
 	// hardware threads on the machine.
 	ValidatorPoolSize int
+	// PolicyTraceCapacity is the number of endorsement policy evaluation
+	// traces kept per channel. Tracing is disabled when it is not positive.
+	PolicyTraceCapacity int
+	// MSPRevocation configures the online checking of the revocation status
+	// of the certificates of the identities validated by the MSPs.
+	MSPRevocation msp.RevocationOpts
 
 	// ----- Peer Delivery Client Keepalive -----
 	// DeliveryClient Keepalive settings for communication with ordering nodes.
@@ -123,6 +160,18 @@ type Config struct {
 	// registered to deliver service for blocks and transaction events.
 	LimitsConcurrencyDeliverService int
 
+	// LimitsEndorserChannel sets the concurrency and rate limits applied by the
+	// endorser to proposals on each channel.
+	LimitsEndorserChannel EndorserLimit
+
+	// LimitsEndorserChaincode sets the concurrency and rate limits applied by
+	// the endorser to proposals for each chaincode on each channel.
+	LimitsEndorserChaincode EndorserLimit
+
+	// LimitsEndorserChaincodeOverrides replaces LimitsEndorserChaincode for
+	// the named chaincodes.
+	LimitsEndorserChaincodeOverrides []ChaincodeEndorserLimit
+
 	// ----- TLS -----
 	// Require server-side TLS.
 	// TODO: create separate sub-struct for PeerTLS config.
@@ -154,6 +203,25 @@ type Config struct {
 	// VMNetworkMode sets the networking mode for the container.
 	VMNetworkMode string
 
+	// ----- vm.process -----
+
+	// VMProcessEnabled enables the built-in process runtime, which builds
+	// chaincode with local toolchains and runs it as child processes of the
+	// peer in place of docker containers.
+	VMProcessEnabled bool
+	// VMProcessPropagateEnvironment lists the environment variables
+	// propagated from the peer to chaincode build and run processes.
+	VMProcessPropagateEnvironment []string
+	// VMProcessCgroupPath is the cgroup v2 directory under which a cgroup is
+	// created for each chaincode process. No limits are applied when empty.
+	VMProcessCgroupPath string
+	// VMProcessCgroupCPUs is the maximum CPU bandwidth of a chaincode process,
+	// in CPUs.
+	VMProcessCgroupCPUs float64
+	// VMProcessCgroupMemory is the maximum memory of a chaincode process, in
+	// bytes.
+	VMProcessCgroupMemory int64
+
 	// ChaincodePull enables/disables force pulling of the base docker image.
 	ChaincodePull bool
 	// ExternalBuilders represents the builders and launchers for
@@ -231,6 +299,20 @@ func (c *Config) load() error {
 	c.LocalMSPID = viper.GetString("peer.localMspId")
 	c.ListenAddress = viper.GetString("peer.listenAddress")
 
+	c.MSPRevocation = msp.RevocationOpts{
+		OCSP:                  viper.GetBool("peer.mspRevocation.ocsp"),
+		CRLDistributionPoints: viper.GetBool("peer.mspRevocation.crlDistributionPoints"),
+		FailurePolicy:         msp.RevocationFailurePolicy(viper.GetString("peer.mspRevocation.failurePolicy")),
+		Timeout:               viper.GetDuration("peer.mspRevocation.timeout"),
+		DefaultValidity:       viper.GetDuration("peer.mspRevocation.defaultValidity"),
+		FailureRetry:          viper.GetDuration("peer.mspRevocation.failureRetry"),
+	}
+	switch c.MSPRevocation.FailurePolicy {
+	case "", msp.RevocationSoftFail, msp.RevocationHardFail:
+	default:
+		return errors.Errorf("invalid peer.mspRevocation.failurePolicy %q, must be %q or %q", c.MSPRevocation.FailurePolicy, msp.RevocationSoftFail, msp.RevocationHardFail)
+	}
+
 	c.AuthenticationTimeWindow = viper.GetDuration("peer.authentication.timewindow")
 	if c.AuthenticationTimeWindow == 0 {
 		defaultTimeWindow := 15 * time.Minute
@@ -242,6 +324,37 @@ func (c *Config) load() error {
 	c.NetworkID = viper.GetString("peer.networkId")
 	c.LimitsConcurrencyEndorserService = viper.GetInt("peer.limits.concurrency.endorserService")
 	c.LimitsConcurrencyDeliverService = viper.GetInt("peer.limits.concurrency.deliverService")
+	c.LimitsEndorserChannel = EndorserLimit{
+		Concurrency: viper.GetInt("peer.limits.endorser.channel.concurrency"),
+		Rate:        viper.GetFloat64("peer.limits.endorser.channel.rate"),
+		Burst:       viper.GetInt("peer.limits.endorser.channel.burst"),
+	}
+	c.LimitsEndorserChaincode = EndorserLimit{
+		Concurrency: viper.GetInt("peer.limits.endorser.chaincode.concurrency"),
+		Rate:        viper.GetFloat64("peer.limits.endorser.chaincode.rate"),
+		Burst:       viper.GetInt("peer.limits.endorser.chaincode.burst"),
+	}
+	if err := c.LimitsEndorserChannel.validate("channels"); err != nil {
+		return err
+	}
+	if err := c.LimitsEndorserChaincode.validate("chaincodes"); err != nil {
+		return err
+	}
+	var chaincodeLimits []ChaincodeEndorserLimit
+	err = viper.UnmarshalKey("peer.limits.endorser.chaincodeOverrides", &chaincodeLimits)
+	if err != nil {
+		return err
+	}
+	for _, limit := range chaincodeLimits {
+		if limit.Name == "" {
+			return fmt.Errorf("invalid endorser limit configuration, name attribute missing in one or more chaincode overrides")
+		}
+		override := EndorserLimit{Concurrency: limit.Concurrency, Rate: limit.Rate, Burst: limit.Burst}
+		if err := override.validate("chaincode " + limit.Name); err != nil {
+			return err
+		}
+	}
+	c.LimitsEndorserChaincodeOverrides = chaincodeLimits
 	c.DiscoveryEnabled = viper.GetBool("peer.discovery.enabled")
 	c.ProfileEnabled = viper.GetBool("peer.profile.enabled")
 	c.ProfileListenAddress = viper.GetString("peer.profile.listenAddress")
@@ -256,6 +369,7 @@ func (c *Config) load() error {
 	if c.ValidatorPoolSize <= 0 {
 		c.ValidatorPoolSize = runtime.NumCPU()
 	}
+	c.PolicyTraceCapacity = viper.GetInt("peer.policyTraceCapacity")
 
 	c.DeliverClientKeepaliveOptions = comm.DefaultKeepaliveOptions
 	if viper.IsSet("peer.keepalive.deliveryClient.interval") {
@@ -274,6 +388,12 @@ func (c *Config) load() error {
 		c.VMNetworkMode = "host"
 	}
 
+	c.VMProcessEnabled = viper.GetBool("vm.process.enabled")
+	c.VMProcessPropagateEnvironment = viper.GetStringSlice("vm.process.propagateEnvironment")
+	c.VMProcessCgroupPath = viper.GetString("vm.process.cgroup.path")
+	c.VMProcessCgroupCPUs = viper.GetFloat64("vm.process.cgroup.cpus")
+	c.VMProcessCgroupMemory = viper.GetInt64("vm.process.cgroup.memory")
+
 	c.ChaincodePull = viper.GetBool("chaincode.pull")
 	var externalBuilders []ExternalBuilder
 	err = viper.UnmarshalKey("chaincode.externalBuilders", &externalBuilders)
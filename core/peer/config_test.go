@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 )
@@ -227,6 +228,17 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.networkId", "testNetwork")
 	viper.Set("peer.limits.concurrency.endorserService", 2500)
 	viper.Set("peer.limits.concurrency.deliverService", 2500)
+	viper.Set("peer.limits.endorser.channel.concurrency", 500)
+	viper.Set("peer.limits.endorser.channel.rate", 1000.5)
+	viper.Set("peer.limits.endorser.chaincode.concurrency", 100)
+	viper.Set("peer.limits.endorser.chaincode.rate", 200)
+	viper.Set("peer.limits.endorser.chaincode.burst", 50)
+	viper.Set("peer.limits.endorser.chaincodeOverrides", &[]ChaincodeEndorserLimit{
+		{
+			Name:        "mycc",
+			Concurrency: 10,
+		},
+	})
 	viper.Set("peer.discovery.enabled", true)
 	viper.Set("peer.profile.enabled", false)
 	viper.Set("peer.profile.listenAddress", "peer.authentication.timewindow")
@@ -237,11 +249,22 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.chaincodeListenAddress", "0.0.0.0:7052")
 	viper.Set("peer.chaincodeAddress", "0.0.0.0:7052")
 	viper.Set("peer.validatorPoolSize", 1)
+	viper.Set("peer.mspRevocation.ocsp", true)
+	viper.Set("peer.mspRevocation.crlDistributionPoints", true)
+	viper.Set("peer.mspRevocation.failurePolicy", "hard")
+	viper.Set("peer.mspRevocation.timeout", "3s")
+	viper.Set("peer.mspRevocation.defaultValidity", "30m")
+	viper.Set("peer.mspRevocation.failureRetry", "20s")
 
 	viper.Set("vm.endpoint", "unix:///var/run/docker.sock")
 	viper.Set("vm.docker.tls.enabled", false)
 	viper.Set("vm.docker.attachStdout", false)
 	viper.Set("vm.docker.hostConfig.NetworkMode", "TestingHost")
+	viper.Set("vm.process.enabled", true)
+	viper.Set("vm.process.propagateEnvironment", []string{"GOPROXY", "GOCACHE"})
+	viper.Set("vm.process.cgroup.path", "/sys/fs/cgroup/peer")
+	viper.Set("vm.process.cgroup.cpus", 0.5)
+	viper.Set("vm.process.cgroup.memory", 536870912)
 	viper.Set("vm.docker.tls.cert.file", "test/vm/tls/cert/file")
 	viper.Set("vm.docker.tls.key.file", "test/vm/tls/key/file")
 	viper.Set("vm.docker.tls.ca.file", "test/vm/tls/ca/file")
@@ -275,15 +298,30 @@ func TestGlobalConfig(t *testing.T) {
 	require.NoError(t, err)
 
 	expectedConfig := &Config{
-		LocalMSPID:                            "SampleOrg",
-		ListenAddress:                         "0.0.0.0:7051",
-		AuthenticationTimeWindow:              15 * time.Minute,
-		PeerTLSEnabled:                        false,
-		PeerAddress:                           "localhost:8080",
-		PeerID:                                "testPeerID",
-		NetworkID:                             "testNetwork",
-		LimitsConcurrencyEndorserService:      2500,
-		LimitsConcurrencyDeliverService:       2500,
+		LocalMSPID:                       "SampleOrg",
+		ListenAddress:                    "0.0.0.0:7051",
+		AuthenticationTimeWindow:         15 * time.Minute,
+		PeerTLSEnabled:                   false,
+		PeerAddress:                      "localhost:8080",
+		PeerID:                           "testPeerID",
+		NetworkID:                        "testNetwork",
+		LimitsConcurrencyEndorserService: 2500,
+		LimitsConcurrencyDeliverService:  2500,
+		LimitsEndorserChannel: EndorserLimit{
+			Concurrency: 500,
+			Rate:        1000.5,
+		},
+		LimitsEndorserChaincode: EndorserLimit{
+			Concurrency: 100,
+			Rate:        200,
+			Burst:       50,
+		},
+		LimitsEndorserChaincodeOverrides: []ChaincodeEndorserLimit{
+			{
+				Name:        "mycc",
+				Concurrency: 10,
+			},
+		},
 		DiscoveryEnabled:                      true,
 		ProfileEnabled:                        false,
 		ProfileListenAddress:                  "peer.authentication.timewindow",
@@ -294,13 +332,27 @@ func TestGlobalConfig(t *testing.T) {
 		ChaincodeListenAddress:                "0.0.0.0:7052",
 		ChaincodeAddress:                      "0.0.0.0:7052",
 		ValidatorPoolSize:                     1,
-		DeliverClientKeepaliveOptions:         comm.DefaultKeepaliveOptions,
+		MSPRevocation: msp.RevocationOpts{
+			OCSP:                  true,
+			CRLDistributionPoints: true,
+			FailurePolicy:         msp.RevocationHardFail,
+			Timeout:               3 * time.Second,
+			DefaultValidity:       30 * time.Minute,
+			FailureRetry:          20 * time.Second,
+		},
+		DeliverClientKeepaliveOptions: comm.DefaultKeepaliveOptions,
 
 		VMEndpoint:           "unix:///var/run/docker.sock",
 		VMDockerTLSEnabled:   false,
 		VMDockerAttachStdout: false,
 		VMNetworkMode:        "TestingHost",
 
+		VMProcessEnabled:              true,
+		VMProcessPropagateEnvironment: []string{"GOPROXY", "GOCACHE"},
+		VMProcessCgroupPath:           "/sys/fs/cgroup/peer",
+		VMProcessCgroupCPUs:           0.5,
+		VMProcessCgroupMemory:         536870912,
+
 		ChaincodePull: false,
 		ExternalBuilders: []ExternalBuilder{
 			{
@@ -430,3 +482,52 @@ func TestMissingExternalBuilderName(t *testing.T) {
 	_, err := GlobalConfig()
 	require.EqualError(t, err, "external builder at path relative/plugin_dir has no name attribute")
 }
+
+func TestMissingEndorserLimitName(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("peer.address", "localhost:8080")
+	viper.Set("peer.limits.endorser.chaincodeOverrides", &[]ChaincodeEndorserLimit{
+		{
+			Concurrency: 10,
+		},
+	})
+	_, err := GlobalConfig()
+	require.EqualError(t, err, "invalid endorser limit configuration, name attribute missing in one or more chaincode overrides")
+}
+
+func TestNegativeEndorserLimits(t *testing.T) {
+	for key, expected := range map[string]string{
+		"peer.limits.endorser.channel.concurrency": "invalid endorser limit configuration, negative concurrency -1 for channels",
+		"peer.limits.endorser.channel.rate":        "invalid endorser limit configuration, negative rate -1 for channels",
+		"peer.limits.endorser.chaincode.burst":     "invalid endorser limit configuration, negative burst -1 for chaincodes",
+	} {
+		t.Run(key, func(t *testing.T) {
+			defer viper.Reset()
+			viper.Set("peer.address", "localhost:8080")
+			viper.Set(key, -1)
+			_, err := GlobalConfig()
+			require.EqualError(t, err, expected)
+		})
+	}
+
+	t.Run("chaincode override", func(t *testing.T) {
+		defer viper.Reset()
+		viper.Set("peer.address", "localhost:8080")
+		viper.Set("peer.limits.endorser.chaincodeOverrides", &[]ChaincodeEndorserLimit{
+			{
+				Name: "mycc",
+				Rate: -0.5,
+			},
+		})
+		_, err := GlobalConfig()
+		require.EqualError(t, err, "invalid endorser limit configuration, negative rate -0.5 for chaincode mycc")
+	})
+}
+
+func TestInvalidMSPRevocationFailurePolicy(t *testing.T) {
+	defer viper.Reset()
+	viper.Set("peer.address", "localhost:8080")
+	viper.Set("peer.mspRevocation.failurePolicy", "never")
+	_, err := GlobalConfig()
+	require.EqualError(t, err, `invalid peer.mspRevocation.failurePolicy "never", must be "soft" or "hard"`)
+}
@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package peer
 
 import (
+	"regexp"
 	"runtime/debug"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
 	"github.com/hyperledger/fabric-protos-go/peer"
@@ -17,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/peer/ccevents"
 	"github.com/hyperledger/fabric/internal/pkg/txflags"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/mgmt"
@@ -229,6 +232,131 @@ func (bprs *blockAndPrivateDataResponseSender) getPrivateData(
 	return seqs2Namespaces.asPrivateDataMap(), nil
 }
 
+// chaincodeEventsResponseSender structure used to send the chaincode events
+// matching the filter of the current request
+type chaincodeEventsResponseSender struct {
+	ccevents.ChaincodeEvents_DeliverChaincodeEventsServer
+	filter *chaincodeEventsFilter
+	// started is set once a block has been sent for the current request
+	started bool
+}
+
+// SendStatusResponse generates status reply proto message
+func (cers *chaincodeEventsResponseSender) SendStatusResponse(status common.Status) error {
+	response := &ccevents.ChaincodeEventsResponse{
+		Type: &ccevents.ChaincodeEventsResponse_Status{Status: status},
+	}
+	return cers.Send(response)
+}
+
+// IsFiltered is a marker method which indicates that this response sender
+// sends filtered blocks.
+func (cers *chaincodeEventsResponseSender) IsFiltered() bool {
+	return true
+}
+
+// SendBlockResponse sends the matching chaincode events of the block. Blocks
+// without matching events are skipped, except for the first block of a
+// request, so that clients learn the number of the block they started from.
+func (cers *chaincodeEventsResponseSender) SendBlockResponse(
+	block *common.Block,
+	channelID string,
+	chain deliver.Chain,
+	signedData *protoutil.SignedData,
+) error {
+	b := blockEvent(*block)
+	events, err := b.chaincodeEvents(cers.filter)
+	if err != nil {
+		logger.Warningf("Failed to extract chaincode events due to: %s", err)
+		return cers.SendStatusResponse(common.Status_BAD_REQUEST)
+	}
+	if len(events) == 0 && cers.started {
+		return nil
+	}
+	cers.started = true
+	response := &ccevents.ChaincodeEventsResponse{
+		Type: &ccevents.ChaincodeEventsResponse_Block{
+			Block: &ccevents.ChaincodeEventsBlock{
+				ChannelId: channelID,
+				Number:    block.Header.Number,
+				Events:    events,
+			},
+		},
+	}
+	return cers.Send(response)
+}
+
+func (cers *chaincodeEventsResponseSender) DataType() string {
+	return "chaincode_events"
+}
+
+// chaincodeEventsReceiver extracts the chaincode events request from each
+// seek envelope received and hands it to the response sender before the
+// envelope is processed.
+type chaincodeEventsReceiver struct {
+	ccevents.ChaincodeEvents_DeliverChaincodeEventsServer
+	sender *chaincodeEventsResponseSender
+}
+
+func (cer *chaincodeEventsReceiver) Recv() (*common.Envelope, error) {
+	envelope, err := cer.ChaincodeEvents_DeliverChaincodeEventsServer.Recv()
+	if err != nil {
+		return nil, err
+	}
+	filter, err := newChaincodeEventsFilter(envelope)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid chaincode events request")
+	}
+	cer.sender.filter = filter
+	cer.sender.started = false
+	return envelope, nil
+}
+
+// chaincodeEventsFilter selects the events of a chaincode, optionally
+// restricted to the event names matching a pattern.
+type chaincodeEventsFilter struct {
+	chaincodeID string
+	eventName   *regexp.Regexp
+}
+
+func newChaincodeEventsFilter(envelope *common.Envelope) (*chaincodeEventsFilter, error) {
+	payload, err := protoutil.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, err
+	}
+	if payload.Header == nil {
+		return nil, errors.New("envelope has no header")
+	}
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &ccevents.ChaincodeEventsRequest{}
+	if err := proto.Unmarshal(chdr.Extension, request); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling channel header extension")
+	}
+	if request.ChaincodeId == "" {
+		return nil, errors.New("chaincode ID must be specified")
+	}
+
+	filter := &chaincodeEventsFilter{chaincodeID: request.ChaincodeId}
+	if request.EventNamePattern != "" {
+		filter.eventName, err = regexp.Compile("^(?:" + request.EventNamePattern + ")$")
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid event name pattern '%s'", request.EventNamePattern)
+		}
+	}
+	return filter, nil
+}
+
+func (f *chaincodeEventsFilter) matches(event *peer.ChaincodeEvent) bool {
+	if event.GetChaincodeId() != f.chaincodeID {
+		return false
+	}
+	return f.eventName == nil || f.eventName.MatchString(event.EventName)
+}
+
 // transactionActions aliasing for peer.TransactionAction pointers slice
 type transactionActions []*peer.TransactionAction
 
@@ -290,6 +418,101 @@ func (s *DeliverServer) DeliverWithPrivateData(srv peer.Deliver_DeliverWithPriva
 	return err
 }
 
+// DeliverChaincodeEvents sends a stream of the events emitted by a chaincode
+// to a client after commitment
+func (s *DeliverServer) DeliverChaincodeEvents(srv ccevents.ChaincodeEvents_DeliverChaincodeEventsServer) error {
+	logger.Debugf("Starting new DeliverChaincodeEvents handler")
+	defer dumpStacktraceOnPanic()
+	sender := &chaincodeEventsResponseSender{
+		ChaincodeEvents_DeliverChaincodeEventsServer: srv,
+	}
+	// getting policy checker based on resources.Event_Block resource name
+	// as the events carry their payloads
+	deliverServer := &deliver.Server{
+		PolicyChecker: s.PolicyCheckerProvider(resources.Event_Block),
+		Receiver: &chaincodeEventsReceiver{
+			ChaincodeEvents_DeliverChaincodeEventsServer: srv,
+			sender: sender,
+		},
+		ResponseSender: sender,
+	}
+	return s.DeliverHandler.Handle(srv.Context(), deliverServer)
+}
+
+// chaincodeEvents returns the events matching the filter that were emitted by
+// the valid endorser transactions of the block.
+func (block *blockEvent) chaincodeEvents(filter *chaincodeEventsFilter) ([]*peer.ChaincodeEvent, error) {
+	var events []*peer.ChaincodeEvent
+
+	txsFltr := txflags.ValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txIndex, ebytes := range block.Data.Data {
+		if ebytes == nil || !txsFltr.IsValid(txIndex) {
+			continue
+		}
+
+		env, err := protoutil.GetEnvelopeFromBlock(ebytes)
+		if err != nil {
+			logger.Errorf("error getting tx from block, %s", err)
+			continue
+		}
+
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		if err != nil {
+			return nil, errors.WithMessage(err, "could not extract payload from envelope")
+		}
+		if payload.Header == nil {
+			continue
+		}
+		chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+
+		tx, err := protoutil.UnmarshalTransaction(payload.Data)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error unmarshal transaction payload for block event")
+		}
+		for _, action := range tx.Actions {
+			ccEvent, err := chaincodeEventFromAction(action)
+			if err != nil {
+				return nil, err
+			}
+			if ccEvent != nil && filter.matches(ccEvent) {
+				events = append(events, ccEvent)
+			}
+		}
+	}
+
+	return events, nil
+}
+
+func chaincodeEventFromAction(action *peer.TransactionAction) (*peer.ChaincodeEvent, error) {
+	chaincodeActionPayload, err := protoutil.UnmarshalChaincodeActionPayload(action.Payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshal transaction action payload for block event")
+	}
+	if chaincodeActionPayload.Action == nil {
+		logger.Debugf("chaincode action, the payload action is nil, skipping")
+		return nil, nil
+	}
+	propRespPayload, err := protoutil.UnmarshalProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshal proposal response payload for block event")
+	}
+	caPayload, err := protoutil.UnmarshalChaincodeAction(propRespPayload.Extension)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshal chaincode action for block event")
+	}
+	ccEvent, err := protoutil.UnmarshalChaincodeEvents(caPayload.Events)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error unmarshal chaincode event for block event")
+	}
+	return ccEvent, nil
+}
+
 func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 	filteredBlock := &peer.FilteredBlock{
 		Number: block.Header.Number,
@@ -356,28 +579,9 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_TransactionActions, error) {
 	transactionActions := &peer.FilteredTransactionActions{}
 	for _, action := range ta {
-		chaincodeActionPayload, err := protoutil.UnmarshalChaincodeActionPayload(action.Payload)
-		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal transaction action payload for block event")
-		}
-
-		if chaincodeActionPayload.Action == nil {
-			logger.Debugf("chaincode action, the payload action is nil, skipping")
-			continue
-		}
-		propRespPayload, err := protoutil.UnmarshalProposalResponsePayload(chaincodeActionPayload.Action.ProposalResponsePayload)
+		ccEvent, err := chaincodeEventFromAction(action)
 		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal proposal response payload for block event")
-		}
-
-		caPayload, err := protoutil.UnmarshalChaincodeAction(propRespPayload.Extension)
-		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal chaincode action for block event")
-		}
-
-		ccEvent, err := protoutil.UnmarshalChaincodeEvents(caPayload.Events)
-		if err != nil {
-			return nil, errors.WithMessage(err, "error unmarshal chaincode event for block event")
+			return nil, err
 		}
 
 		if ccEvent.GetChaincodeId() != "" {
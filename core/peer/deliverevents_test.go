@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/peer/ccevents"
 	fake "github.com/hyperledger/fabric/core/peer/mock"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/stretchr/testify/mock"
@@ -579,6 +580,166 @@ func TestEventsServer_DeliverWithPrivateData(t *testing.T) {
 	}
 }
 
+// mockChaincodeEventsServer implementation of the
+// ChaincodeEvents_DeliverChaincodeEventsServer which replays a fixed set of
+// requests and records the responses
+type mockChaincodeEventsServer struct {
+	ccevents.ChaincodeEvents_DeliverChaincodeEventsServer
+	requests  []*common.Envelope
+	responses []*ccevents.ChaincodeEventsResponse
+}
+
+func (m *mockChaincodeEventsServer) Context() context.Context {
+	return peer2.NewContext(context.TODO(), &peer2.Peer{})
+}
+
+func (m *mockChaincodeEventsServer) Recv() (*common.Envelope, error) {
+	if len(m.requests) == 0 {
+		return nil, io.EOF
+	}
+	request := m.requests[0]
+	m.requests = m.requests[1:]
+	return request, nil
+}
+
+func (m *mockChaincodeEventsServer) Send(response *ccevents.ChaincodeEventsResponse) error {
+	m.responses = append(m.responses, response)
+	return nil
+}
+
+func TestEventsServer_DeliverChaincodeEvents(t *testing.T) {
+	newEnvelope := func(t *testing.T, chaincodeName, txID, eventName string) *common.Envelope {
+		chaincodeActionPayload, err := createChaincodeAction(chaincodeName, eventName, txID)
+		require.NoError(t, err)
+		payload, err := createEndorsement("testChannelID", txID, chaincodeActionPayload)
+		require.NoError(t, err)
+		return &common.Envelope{Payload: protoutil.MarshalOrPanic(payload)}
+	}
+
+	newRequest := func(request *ccevents.ChaincodeEventsRequest) *common.Envelope {
+		return &common.Envelope{
+			Payload: protoutil.MarshalOrPanic(&common.Payload{
+				Header: &common.Header{
+					ChannelHeader: protoutil.MarshalOrPanic(&common.ChannelHeader{
+						ChannelId: "testChannelID",
+						Timestamp: util.CreateUtcTimestamp(),
+						Extension: protoutil.MarshalOrPanic(request),
+					}),
+					SignatureHeader: protoutil.MarshalOrPanic(&common.SignatureHeader{}),
+				},
+				Data: protoutil.MarshalOrPanic(&orderer.SeekInfo{
+					Start:    &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 0}}},
+					Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 1}}},
+					Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+				}),
+			}),
+		}
+	}
+
+	newChainManager := func(t *testing.T) *mockChainManager {
+		block0, err := createTestBlock([]*common.Envelope{
+			newEnvelope(t, "mycc", "tx1", "created"),
+			newEnvelope(t, "othercc", "tx2", "created"),
+			newEnvelope(t, "mycc", "tx3", "deleted"),
+			newEnvelope(t, "mycc", "tx4", "created"),
+		})
+		require.NoError(t, err)
+		txflags := block0.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]
+		txflags[3] = byte(peer.TxValidationCode_MVCC_READ_CONFLICT)
+
+		block1, err := createTestBlock([]*common.Envelope{
+			newEnvelope(t, "othercc", "tx5", "created"),
+		})
+		require.NoError(t, err)
+		block1.Header.Number = 1
+
+		iter := &mockIterator{}
+		iter.On("Next").Return(block0, common.Status_SUCCESS).Once()
+		iter.On("Next").Return(block1, common.Status_SUCCESS).Once()
+		iter.On("Close")
+		reader := &mockReader{}
+		reader.On("Iterator", mock.Anything).Return(iter, uint64(0))
+		reader.On("Height").Return(uint64(2))
+		chain := &mockChainSupport{}
+		chain.On("Sequence").Return(uint64(0))
+		chain.On("Reader").Return(reader)
+		chainManager := &mockChainManager{}
+		chainManager.On("GetChain", "testChannelID").Return(chain, true)
+		return chainManager
+	}
+
+	newServer := func(chainManager *mockChainManager) *DeliverServer {
+		return &DeliverServer{
+			DeliverHandler:        deliver.NewHandler(chainManager, time.Second, false, deliver.NewMetrics(&disabled.Provider{}), false),
+			PolicyCheckerProvider: defaultPolicyCheckerProvider,
+		}
+	}
+
+	t.Run("delivers the events of the chaincode", func(t *testing.T) {
+		srv := &mockChaincodeEventsServer{
+			requests: []*common.Envelope{newRequest(&ccevents.ChaincodeEventsRequest{ChaincodeId: "mycc"})},
+		}
+		err := newServer(newChainManager(t)).DeliverChaincodeEvents(srv)
+		require.NoError(t, err)
+
+		require.Len(t, srv.responses, 2)
+		block := srv.responses[0].GetBlock()
+		require.NotNil(t, block)
+		require.Equal(t, "testChannelID", block.ChannelId)
+		require.Equal(t, uint64(0), block.Number)
+		require.Len(t, block.Events, 2)
+		require.True(t, proto.Equal(&peer.ChaincodeEvent{ChaincodeId: "mycc", TxId: "tx1", EventName: "created"}, block.Events[0]))
+		require.True(t, proto.Equal(&peer.ChaincodeEvent{ChaincodeId: "mycc", TxId: "tx3", EventName: "deleted"}, block.Events[1]))
+		require.Equal(t, common.Status_SUCCESS, srv.responses[1].GetStatus())
+	})
+
+	t.Run("filters the events by name", func(t *testing.T) {
+		srv := &mockChaincodeEventsServer{
+			requests: []*common.Envelope{newRequest(&ccevents.ChaincodeEventsRequest{ChaincodeId: "mycc", EventNamePattern: "del.*"})},
+		}
+		err := newServer(newChainManager(t)).DeliverChaincodeEvents(srv)
+		require.NoError(t, err)
+
+		require.Len(t, srv.responses, 2)
+		block := srv.responses[0].GetBlock()
+		require.Len(t, block.Events, 1)
+		require.Equal(t, "tx3", block.Events[0].TxId)
+		require.Equal(t, common.Status_SUCCESS, srv.responses[1].GetStatus())
+	})
+
+	t.Run("sends the first block without matching events", func(t *testing.T) {
+		srv := &mockChaincodeEventsServer{
+			requests: []*common.Envelope{newRequest(&ccevents.ChaincodeEventsRequest{ChaincodeId: "nocc"})},
+		}
+		err := newServer(newChainManager(t)).DeliverChaincodeEvents(srv)
+		require.NoError(t, err)
+
+		require.Len(t, srv.responses, 2)
+		block := srv.responses[0].GetBlock()
+		require.NotNil(t, block)
+		require.Equal(t, uint64(0), block.Number)
+		require.Empty(t, block.Events)
+		require.Equal(t, common.Status_SUCCESS, srv.responses[1].GetStatus())
+	})
+
+	t.Run("rejects a request without a chaincode", func(t *testing.T) {
+		srv := &mockChaincodeEventsServer{
+			requests: []*common.Envelope{newRequest(&ccevents.ChaincodeEventsRequest{})},
+		}
+		err := newServer(newChainManager(t)).DeliverChaincodeEvents(srv)
+		require.EqualError(t, err, "invalid chaincode events request: chaincode ID must be specified")
+		require.Empty(t, srv.responses)
+	})
+
+	t.Run("rejects an invalid event name pattern", func(t *testing.T) {
+		srv := &mockChaincodeEventsServer{
+			requests: []*common.Envelope{newRequest(&ccevents.ChaincodeEventsRequest{ChaincodeId: "mycc", EventNamePattern: "("})},
+		}
+		err := newServer(newChainManager(t)).DeliverChaincodeEvents(srv)
+		require.EqualError(t, err, "invalid chaincode events request: invalid event name pattern '(': error parsing regexp: missing closing ): `^(?:()$`")
+	})
+}
+
 func createDefaultSupportMamangerMock(config testConfig, chaincodeActionPayload *peer.ChaincodeActionPayload, pvtData []*ledger.TxPvtData) *mockChainManager {
 	chainManager := &mockChainManager{}
 	iter := &mockIterator{}
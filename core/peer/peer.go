@@ -183,6 +183,9 @@ type Peer struct {
 	LedgerMgr                *ledgermgmt.LedgerMgr
 	OrdererEndpointOverrides map[string]*orderers.Endpoint
 	CryptoProvider           bccsp.BCCSP
+	// PolicyTraceCapacity is the number of endorsement policy evaluation
+	// traces kept per channel, or 0 to disable tracing.
+	PolicyTraceCapacity int
 
 	// validationWorkersSemaphore is used to limit the number of concurrent validation
 	// go routines.
@@ -339,6 +342,9 @@ func (p *Peer) createChannel(
 		resources:      bundle,
 		cryptoProvider: p.CryptoProvider,
 	}
+	if p.PolicyTraceCapacity > 0 {
+		channel.policyTraces = validatorv20.NewPolicyTraceStore(p.PolicyTraceCapacity)
+	}
 
 	channel.bundleSource = channelconfig.NewBundleSource(
 		bundle,
@@ -350,6 +356,24 @@ func (p *Peer) createChannel(
 	)
 
 	committer := committer.NewLedgerCommitter(l)
+	v20Validator := validatorv20.NewTxValidator(
+		cid,
+		p.validationWorkersSemaphore,
+		channel,
+		channel.Ledger(),
+		&vir.ValidationInfoRetrieveShim{
+			New:    newLifecycleValidation,
+			Legacy: legacyLifecycleValidation,
+		},
+		&CollectionInfoShim{
+			CollectionAndLifecycleResources: newLifecycleValidation,
+			ChannelID:                       bundle.ConfigtxValidator().ChannelID(),
+		},
+		p.pluginMapper,
+		policies.PolicyManagerGetterFunc(p.GetPolicyManager),
+		p.CryptoProvider,
+	)
+	v20Validator.PolicyTraces = channel.policyTraces
 	validator := &txvalidator.ValidationRouter{
 		CapabilityProvider: channel,
 		V14Validator: validatorv14.NewTxValidator(
@@ -359,23 +383,7 @@ func (p *Peer) createChannel(
 			p.pluginMapper,
 			p.CryptoProvider,
 		),
-		V20Validator: validatorv20.NewTxValidator(
-			cid,
-			p.validationWorkersSemaphore,
-			channel,
-			channel.Ledger(),
-			&vir.ValidationInfoRetrieveShim{
-				New:    newLifecycleValidation,
-				Legacy: legacyLifecycleValidation,
-			},
-			&CollectionInfoShim{
-				CollectionAndLifecycleResources: newLifecycleValidation,
-				ChannelID:                       bundle.ConfigtxValidator().ChannelID(),
-			},
-			p.pluginMapper,
-			policies.PolicyManagerGetterFunc(p.GetPolicyManager),
-			p.CryptoProvider,
-		),
+		V20Validator: v20Validator,
 	}
 
 	// TODO: does someone need to call Close() on the transientStoreFactory at shutdown of the peer?
@@ -463,6 +471,16 @@ func (p *Peer) GetLedger(cid string) ledger.PeerLedger {
 	return nil
 }
 
+// GetEndorsementPolicyTrace returns the endorsement policy evaluation trace
+// of a transaction on the channel which failed its endorsement policy, or nil
+// if none is kept.
+func (p *Peer) GetEndorsementPolicyTrace(cid, txID string) *policies.EvaluationTrace {
+	if c := p.Channel(cid); c != nil {
+		return c.EndorsementPolicyTrace(txID)
+	}
+	return nil
+}
+
 // GetMSPIDs returns the ID of each application MSP defined on this channel
 func (p *Peer) GetMSPIDs(cid string) []string {
 	if c := p.Channel(cid); c != nil {
@@ -7,12 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package qscc
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/protoutil"
@@ -23,12 +25,19 @@ type LedgerGetter interface {
 	GetLedger(cid string) ledger.PeerLedger
 }
 
+// PolicyTraceGetter gets the endorsement policy evaluation trace of a
+// transaction which failed its endorsement policy.
+type PolicyTraceGetter interface {
+	GetEndorsementPolicyTrace(cid, txID string) *policies.EvaluationTrace
+}
+
 // New returns an instance of QSCC.
 // Typically this is called once per peer.
-func New(aclProvider aclmgmt.ACLProvider, ledgers LedgerGetter) *LedgerQuerier {
+func New(aclProvider aclmgmt.ACLProvider, ledgers LedgerGetter, policyTraces PolicyTraceGetter) *LedgerQuerier {
 	return &LedgerQuerier{
-		aclProvider: aclProvider,
-		ledgers:     ledgers,
+		aclProvider:  aclProvider,
+		ledgers:      ledgers,
+		policyTraces: policyTraces,
 	}
 }
 
@@ -40,9 +49,11 @@ func (e *LedgerQuerier) Chaincode() shim.Chaincode { return e }
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetEndorsementPolicyTrace returns why a transaction failed its endorsement policy
 type LedgerQuerier struct {
-	aclProvider aclmgmt.ACLProvider
-	ledgers     LedgerGetter
+	aclProvider  aclmgmt.ACLProvider
+	ledgers      LedgerGetter
+	policyTraces PolicyTraceGetter
 }
 
 var qscclogger = flogging.MustGetLogger("qscc")
@@ -54,6 +65,8 @@ const (
 	GetBlockByHash     string = "GetBlockByHash"
 	GetTransactionByID string = "GetTransactionByID"
 	GetBlockByTxID     string = "GetBlockByTxID"
+
+	GetEndorsementPolicyTrace string = "GetEndorsementPolicyTrace"
 )
 
 // Init is called once per chain when the chain is created.
@@ -72,6 +85,7 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetEndorsementPolicyTrace: Return why the transaction specified by ID in args[2] failed its endorsement policy
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -124,6 +138,8 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetEndorsementPolicyTrace:
+		return getEndorsementPolicyTrace(e.policyTraces, cid, args[2])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -223,6 +239,28 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getEndorsementPolicyTrace(policyTraces PolicyTraceGetter, cid string, rawTxID []byte) pb.Response {
+	txID := string(rawTxID)
+	if txID == "" {
+		return shim.Error("Transaction ID must not be empty.")
+	}
+
+	var trace *policies.EvaluationTrace
+	if policyTraces != nil {
+		trace = policyTraces.GetEndorsementPolicyTrace(cid, txID)
+	}
+	if trace == nil {
+		return shim.Error(fmt.Sprintf("No endorsement policy trace for txID %s", txID))
+	}
+
+	bytes, err := json.Marshal(trace)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "qscc/" + fname
 }
@@ -18,6 +18,7 @@ import (
 	peer2 "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
@@ -60,8 +61,9 @@ func setupTestLedger(chainid string, path string) (*shimtest.MockStub, *peer.Pee
 	peer.CreateMockChannel(peerInstance, chainid, nil)
 
 	lq := &LedgerQuerier{
-		aclProvider: mockAclProvider,
-		ledgers:     peerInstance,
+		aclProvider:  mockAclProvider,
+		ledgers:      peerInstance,
+		policyTraces: peerInstance,
 	}
 	stub := shimtest.NewMockStub("LedgerQuerier", lq)
 	if res := stub.MockInit("1", nil); res.Status != shim.OK {
@@ -369,6 +371,53 @@ func TestFailingAccessControl(t *testing.T) {
 	mockAclProvider.AssertExpectations(t)
 }
 
+type policyTraceMap map[string]*policies.EvaluationTrace
+
+func (m policyTraceMap) GetEndorsementPolicyTrace(cid, txID string) *policies.EvaluationTrace {
+	return m[txID]
+}
+
+func TestQueryGetEndorsementPolicyTrace(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := tempDir(t, "test9")
+	defer os.RemoveAll(path)
+
+	_, peerInstance, cleanup, err := setupTestLedger(chainid, path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer cleanup()
+
+	trace := &policies.EvaluationTrace{
+		Rule:     "OutOf(1)",
+		Required: 1,
+		SubPolicies: []*policies.EvaluationTrace{
+			{Rule: "SignedBy(Org2MSP.peer)", Mismatches: []string{"Org1MSP: not a member of Org2MSP"}},
+		},
+	}
+	lq := &LedgerQuerier{
+		aclProvider:  mockAclProvider,
+		ledgers:      peerInstance,
+		policyTraces: policyTraceMap{"tx1": trace},
+	}
+	stub := shimtest.NewMockStub("LedgerQuerier", lq)
+
+	args := [][]byte{[]byte(GetEndorsementPolicyTrace), []byte(chainid), []byte("tx1")}
+	prop := resetProvider(resources.Qscc_GetEndorsementPolicyTrace, chainid, nil, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	require.Equal(t, int32(shim.OK), res.Status, res.Message)
+	require.JSONEq(t, `{"rule":"OutOf(1)","satisfied":false,"required":1,"sub_policies":[{"rule":"SignedBy(Org2MSP.peer)","satisfied":false,"mismatches":["Org1MSP: not a member of Org2MSP"]}]}`, string(res.Payload))
+
+	args = [][]byte{[]byte(GetEndorsementPolicyTrace), []byte(chainid), []byte("tx2")}
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status)
+	require.Equal(t, "No endorsement policy trace for txID tx2", res.Message)
+
+	args = [][]byte{[]byte(GetEndorsementPolicyTrace), []byte(chainid), []byte("")}
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	require.Equal(t, int32(shim.ERROR), res.Status, "GetEndorsementPolicyTrace should have failed with blank txId.")
+}
+
 func TestQueryNonexistentFunction(t *testing.T) {
 	chainid := "mytestchainid7"
 	path := tempDir(t, "test7")
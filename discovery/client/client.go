@@ -615,9 +615,23 @@ func validateInterests(interests ...*discovery.ChaincodeInterest) error {
 // InvocationChain aggregates ChaincodeCalls
 type InvocationChain []*discovery.ChaincodeCall
 
+// chaincodeCall is a ChaincodeCall along with the keys it writes
+type chaincodeCall struct {
+	*discovery.ChaincodeCall
+	WrittenKeys []*protoext.WrittenKey `json:"written_keys,omitempty"`
+}
+
 // String returns a string representation of this invocation chain
 func (ic InvocationChain) String() string {
-	s, _ := json.Marshal(ic)
+	calls := make([]chaincodeCall, len(ic))
+	for i, cc := range ic {
+		writtenKeys, _ := protoext.GetWrittenKeys(cc)
+		calls[i] = chaincodeCall{
+			ChaincodeCall: cc,
+			WrittenKeys:   writtenKeys,
+		}
+	}
+	s, _ := json.Marshal(calls)
 	return string(s)
 }
 
@@ -630,6 +644,15 @@ func (ic InvocationChain) ValidateInvocationChain() error {
 		if cc.Name == "" {
 			return errors.New("chaincode name should not be empty")
 		}
+		writtenKeys, err := protoext.GetWrittenKeys(cc)
+		if err != nil {
+			return errors.Wrapf(err, "failed extracting written keys of chaincode %s", cc.Name)
+		}
+		for _, writtenKey := range writtenKeys {
+			if writtenKey.Key == "" {
+				return errors.Errorf("written key of chaincode %s should not be empty", cc.Name)
+			}
+		}
 	}
 	return nil
 }
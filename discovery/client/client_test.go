@@ -30,6 +30,7 @@ import (
 	"github.com/hyperledger/fabric/common/util"
 	fabricdisc "github.com/hyperledger/fabric/discovery"
 	"github.com/hyperledger/fabric/discovery/endorsement"
+	discprotoext "github.com/hyperledger/fabric/discovery/protoext"
 	"github.com/hyperledger/fabric/gossip/api"
 	gossipcommon "github.com/hyperledger/fabric/gossip/common"
 	gdisc "github.com/hyperledger/fabric/gossip/discovery"
@@ -769,6 +770,26 @@ func TestString(t *testing.T) {
 	})
 	expected := `[{"name":"foo","collection_names":["c1","c2"]},{"name":"bar","collection_names":["c3","c4"]}]`
 	require.Equal(t, expected, ic.String())
+
+	err := discprotoext.SetWrittenKeys(ic[1], &discprotoext.WrittenKey{Key: "k1"}, &discprotoext.WrittenKey{Collection: "c3", Key: "k2"})
+	require.NoError(t, err)
+	expected = `[{"name":"foo","collection_names":["c1","c2"]},{"name":"bar","collection_names":["c3","c4"],"written_keys":[{"key":"k1"},{"collection":"c3","key":"k2"}]}]`
+	require.Equal(t, expected, ic.String())
+}
+
+func TestValidateInvocationChainWrittenKeys(t *testing.T) {
+	cc := &discovery.ChaincodeCall{Name: "foo"}
+	require.NoError(t, discprotoext.SetWrittenKeys(cc, &discprotoext.WrittenKey{Key: "k1"}))
+	require.NoError(t, InvocationChain{cc}.ValidateInvocationChain())
+
+	require.NoError(t, discprotoext.SetWrittenKeys(cc, &discprotoext.WrittenKey{Collection: "c1"}))
+	err := InvocationChain{cc}.ValidateInvocationChain()
+	require.EqualError(t, err, "written key of chaincode foo should not be empty")
+
+	cc.XXX_unrecognized = []byte{0}
+	err = InvocationChain{cc}.ValidateInvocationChain()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed extracting written keys of chaincode foo")
 }
 
 func getMSP(peer *Peer) string {
@@ -824,6 +845,10 @@ func (pf *policyFetcher) PoliciesByChaincode(channel string, cc string, collecti
 	return []policies.InquireablePolicy{pf.Called(cc).Get(0).(policies.InquireablePolicy)}
 }
 
+func (pf *policyFetcher) PoliciesByKeys(channel string, cc string, keys ...*discprotoext.WrittenKey) ([]policies.InquireablePolicy, error) {
+	return nil, nil
+}
+
 type endorsementAnalyzer interface {
 	PeersForEndorsement(chainID gossipcommon.ChannelID, interest *discovery.ChaincodeInterest) (*discovery.EndorsementDescriptor, error)
 
@@ -0,0 +1,246 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/protoext"
+)
+
+const (
+	defaultHealthWindow     = time.Minute
+	defaultHealthMaxSamples = 100
+)
+
+// HealthConfig configures how a HealthTracker ranks and excludes peers.
+// Zero valued thresholds disable the corresponding exclusion.
+type HealthConfig struct {
+	// Window is the period of time endorsement measurements are taken into account for.
+	// Defaults to a minute.
+	Window time.Duration
+	// MaxSamples is the maximum number of measurements kept per peer.
+	// Defaults to 100.
+	MaxSamples int
+	// MinSamples is the number of measurements a peer needs to have within the window
+	// before it is excluded due to its error rate or latency.
+	MinSamples int
+	// MaxErrorRate is the fraction of failed endorsements above which a peer is excluded.
+	MaxErrorRate float64
+	// MaxLatency is the average endorsement latency above which a peer is excluded.
+	MaxLatency time.Duration
+	// MaxHeightLag is the number of blocks a peer may lag behind the highest
+	// ledger height observed in the channel before it is excluded.
+	MaxHeightLag uint64
+	// PreferredLabels are labels that peers are preferred to have, such as
+	// the data center of the client.
+	PreferredLabels map[string]string
+	// RequiredLabels are labels that peers are excluded for not having.
+	RequiredLabels map[string]string
+}
+
+type endorsementSample struct {
+	time    time.Time
+	latency time.Duration
+	failed  bool
+}
+
+type peerHealth struct {
+	samples     int
+	errorRate   float64
+	meanLatency time.Duration
+}
+
+// HealthTracker ranks and excludes peers according to measurements of
+// endorsements sent to them, their ledger height and their labels.
+// It can be used either as a PrioritySelector and an ExclusionFilter,
+// or directly as a Filter.
+type HealthTracker struct {
+	config HealthConfig
+	now    func() time.Time
+
+	lock      sync.RWMutex
+	samples   map[string][]endorsementSample
+	maxHeight uint64
+}
+
+// NewHealthTracker creates a new HealthTracker out of the given configuration
+func NewHealthTracker(config HealthConfig) *HealthTracker {
+	if config.Window <= 0 {
+		config.Window = defaultHealthWindow
+	}
+	if config.MaxSamples <= 0 {
+		config.MaxSamples = defaultHealthMaxSamples
+	}
+	if config.MinSamples <= 0 {
+		config.MinSamples = 1
+	}
+	return &HealthTracker{
+		config:  config,
+		now:     time.Now,
+		samples: make(map[string][]endorsementSample),
+	}
+}
+
+// RecordEndorsement records the latency and the outcome of an endorsement
+// sent to the peer with the given endpoint
+func (ht *HealthTracker) RecordEndorsement(endpoint string, latency time.Duration, err error) {
+	now := ht.now()
+
+	ht.lock.Lock()
+	defer ht.lock.Unlock()
+
+	samples := append(ht.samples[endpoint], endorsementSample{
+		time:    now,
+		latency: latency,
+		failed:  err != nil,
+	})
+	samples = ht.recent(samples, now)
+	if len(samples) > ht.config.MaxSamples {
+		samples = samples[len(samples)-ht.config.MaxSamples:]
+	}
+	ht.samples[endpoint] = samples
+}
+
+// ObservePeers updates the highest ledger height of the channel
+// according to the ledger heights of the given peers
+func (ht *HealthTracker) ObservePeers(peers ...*Peer) {
+	ht.lock.Lock()
+	defer ht.lock.Unlock()
+
+	for _, p := range peers {
+		if height := ledgerHeight(*p); height > ht.maxHeight {
+			ht.maxHeight = height
+		}
+	}
+}
+
+// Filter observes the given endorsers, and then excludes and sorts them
+// according to their health
+func (ht *HealthTracker) Filter(endorsers Endorsers) Endorsers {
+	ht.ObservePeers(endorsers...)
+	return endorsers.Shuffle().Filter(ht).Sort(ht)
+}
+
+// Exclude returns whether the given peer lacks one of the required labels,
+// lags too far behind the channel, or fails or responds too slowly
+func (ht *HealthTracker) Exclude(p Peer) bool {
+	if matchingLabels(p, ht.config.RequiredLabels) < len(ht.config.RequiredLabels) {
+		return true
+	}
+
+	ht.lock.RLock()
+	maxHeight := ht.maxHeight
+	ht.lock.RUnlock()
+	if ht.config.MaxHeightLag > 0 && maxHeight > ledgerHeight(p)+ht.config.MaxHeightLag {
+		return true
+	}
+
+	health := ht.health(endpoint(p))
+	if health.samples < ht.config.MinSamples {
+		return false
+	}
+	if ht.config.MaxErrorRate > 0 && health.errorRate > ht.config.MaxErrorRate {
+		return true
+	}
+	return ht.config.MaxLatency > 0 && health.meanLatency > ht.config.MaxLatency
+}
+
+// Compare prefers peers matching more of the preferred labels, then peers
+// with a lower error rate, then peers with a lower average latency, and then
+// peers with a higher ledger height. Peers with no measurements are treated
+// as healthy, so that they get a chance to be measured.
+func (ht *HealthTracker) Compare(left Peer, right Peer) Priority {
+	leftMatches := matchingLabels(left, ht.config.PreferredLabels)
+	rightMatches := matchingLabels(right, ht.config.PreferredLabels)
+	if leftMatches != rightMatches {
+		return Priority(leftMatches - rightMatches)
+	}
+
+	leftHealth := ht.health(endpoint(left))
+	rightHealth := ht.health(endpoint(right))
+	if leftHealth.errorRate < rightHealth.errorRate {
+		return 1
+	}
+	if rightHealth.errorRate < leftHealth.errorRate {
+		return -1
+	}
+	if leftHealth.meanLatency < rightHealth.meanLatency {
+		return 1
+	}
+	if rightHealth.meanLatency < leftHealth.meanLatency {
+		return -1
+	}
+
+	return PrioritiesByHeight.Compare(left, right)
+}
+
+func (ht *HealthTracker) health(endpoint string) peerHealth {
+	ht.lock.RLock()
+	samples := ht.recent(ht.samples[endpoint], ht.now())
+	ht.lock.RUnlock()
+
+	if len(samples) == 0 {
+		return peerHealth{}
+	}
+
+	var failures int
+	var totalLatency time.Duration
+	for _, s := range samples {
+		if s.failed {
+			failures++
+		}
+		totalLatency += s.latency
+	}
+	return peerHealth{
+		samples:     len(samples),
+		errorRate:   float64(failures) / float64(len(samples)),
+		meanLatency: totalLatency / time.Duration(len(samples)),
+	}
+}
+
+// recent returns the suffix of the given samples that were taken within the window
+func (ht *HealthTracker) recent(samples []endorsementSample, now time.Time) []endorsementSample {
+	for i, s := range samples {
+		if now.Sub(s.time) <= ht.config.Window {
+			return samples[i:]
+		}
+	}
+	return nil
+}
+
+func endpoint(p Peer) string {
+	if p.AliveMessage == nil {
+		return ""
+	}
+	return p.AliveMessage.GetAliveMsg().GetMembership().GetEndpoint()
+}
+
+func ledgerHeight(p Peer) uint64 {
+	if p.StateInfoMessage == nil {
+		return 0
+	}
+	return p.StateInfoMessage.GetStateInfo().GetProperties().GetLedgerHeight()
+}
+
+func matchingLabels(p Peer, labels map[string]string) int {
+	if len(labels) == 0 || p.StateInfoMessage == nil {
+		return 0
+	}
+	peerLabels, err := protoext.GetLabels(p.StateInfoMessage.GetStateInfo().GetProperties())
+	if err != nil {
+		return 0
+	}
+	var matches int
+	for key, value := range labels {
+		if peerLabels[key] == value {
+			matches++
+		}
+	}
+	return matches
+}
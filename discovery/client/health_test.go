@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthTrackerExclusion(t *testing.T) {
+	now := time.Now()
+	ht := NewHealthTracker(HealthConfig{
+		Window:         time.Minute,
+		MinSamples:     2,
+		MaxErrorRate:   0.5,
+		MaxLatency:     time.Second,
+		MaxHeightLag:   5,
+		RequiredLabels: map[string]string{"zone": "a"},
+	})
+	ht.now = func() time.Time { return now }
+
+	healthy := healthPeer(1, 100, map[string]string{"zone": "a"})
+	failing := healthPeer(2, 100, map[string]string{"zone": "a"})
+	slow := healthPeer(3, 100, map[string]string{"zone": "a"})
+	lagging := healthPeer(4, 94, map[string]string{"zone": "a"})
+	elsewhere := healthPeer(5, 100, map[string]string{"zone": "b"})
+	unlabeled := healthPeer(6, 100, nil)
+
+	ht.ObservePeers(healthy, failing, slow, lagging, elsewhere, unlabeled)
+
+	// A single failure is not enough to exclude a peer
+	ht.RecordEndorsement("p2", time.Millisecond, errors.New("timeout"))
+	require.False(t, ht.Exclude(*failing))
+	ht.RecordEndorsement("p2", time.Millisecond, errors.New("timeout"))
+	ht.RecordEndorsement("p1", time.Millisecond, nil)
+	ht.RecordEndorsement("p1", time.Millisecond, errors.New("timeout"))
+	ht.RecordEndorsement("p3", 2*time.Second, nil)
+	ht.RecordEndorsement("p3", 2*time.Second, nil)
+
+	require.False(t, ht.Exclude(*healthy))
+	require.True(t, ht.Exclude(*failing))
+	require.True(t, ht.Exclude(*slow))
+	require.True(t, ht.Exclude(*lagging))
+	require.True(t, ht.Exclude(*elsewhere))
+	require.True(t, ht.Exclude(*unlabeled))
+
+	// Once the measurements expire, peers are given another chance
+	now = now.Add(2 * time.Minute)
+	require.False(t, ht.Exclude(*failing))
+	require.False(t, ht.Exclude(*slow))
+	// But the channel height is not forgotten
+	require.True(t, ht.Exclude(*lagging))
+}
+
+func TestHealthTrackerPriorities(t *testing.T) {
+	ht := NewHealthTracker(HealthConfig{
+		PreferredLabels: map[string]string{"datacenter": "dc1"},
+	})
+
+	local := healthPeer(1, 10, map[string]string{"datacenter": "dc1"})
+	fast := healthPeer(2, 10, map[string]string{"datacenter": "dc2"})
+	slow := healthPeer(3, 10, map[string]string{"datacenter": "dc2"})
+	failing := healthPeer(4, 10, nil)
+	behind := healthPeer(5, 9, nil)
+
+	ht.RecordEndorsement("p1", time.Second, nil)
+	ht.RecordEndorsement("p2", 10*time.Millisecond, nil)
+	ht.RecordEndorsement("p3", 100*time.Millisecond, nil)
+	ht.RecordEndorsement("p4", time.Millisecond, errors.New("unavailable"))
+
+	require.Equal(t, Priority(1), ht.Compare(*local, *fast))
+	require.Equal(t, Priority(1), ht.Compare(*fast, *slow))
+	require.Equal(t, Priority(-1), ht.Compare(*failing, *slow))
+	// Peers without measurements are compared by their height
+	require.Equal(t, Priority(1), ht.Compare(*healthPeer(6, 10, nil), *behind))
+
+	endorsers := Endorsers{behind, failing, slow, fast, local}
+	var endpoints []string
+	for _, e := range ht.Filter(endorsers) {
+		endpoints = append(endpoints, endpoint(*e))
+	}
+	require.Equal(t, []string{"p1", "p5", "p2", "p3", "p4"}, endpoints)
+}
+
+func TestHealthTrackerMaxSamples(t *testing.T) {
+	ht := NewHealthTracker(HealthConfig{
+		MaxSamples:   3,
+		MaxErrorRate: 0.1,
+	})
+	p := healthPeer(1, 1, nil)
+
+	for i := 0; i < 10; i++ {
+		ht.RecordEndorsement("p1", time.Millisecond, errors.New("unavailable"))
+	}
+	require.True(t, ht.Exclude(*p))
+	for i := 0; i < 3; i++ {
+		ht.RecordEndorsement("p1", time.Millisecond, nil)
+	}
+	require.False(t, ht.Exclude(*p))
+	require.Len(t, ht.samples["p1"], 3)
+}
+
+func healthPeer(id int, height uint64, labels map[string]string) *Peer {
+	properties := &gossip.Properties{
+		LedgerHeight: height,
+	}
+	if err := protoext.SetLabels(properties, labels); err != nil {
+		panic(err)
+	}
+	si, _ := protoext.NoopSign(&gossip.GossipMessage{
+		Content: &gossip.GossipMessage_StateInfo{
+			StateInfo: &gossip.StateInfo{
+				Properties: properties,
+				Timestamp:  &gossip.PeerTime{},
+			},
+		},
+	})
+	am, _ := protoext.EnvelopeToGossipMessage(aliveMessage(id))
+	return &Peer{
+		StateInfoMessage: si,
+		AliveMessage:     am,
+	}
+}
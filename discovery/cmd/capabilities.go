@@ -0,0 +1,488 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	discovery "github.com/hyperledger/fabric/discovery/client"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/internal/configtxlator/edit"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// capabilityGroups are the config groups holding capabilities, along with the
+// edit level of each.
+var capabilityGroups = []struct {
+	name  string
+	level string
+}{
+	{channelconfig.ChannelGroupKey, edit.ChannelLevel},
+	{channelconfig.OrdererGroupKey, edit.OrdererLevel},
+	{channelconfig.ApplicationGroupKey, edit.ApplicationLevel},
+}
+
+// NewCapabilitiesCmd creates a new CapabilitiesCmd with the given Stub,
+// writing the upgrade plan to the given Writer
+func NewCapabilitiesCmd(stub Stub, writer io.Writer) *CapabilitiesCmd {
+	return &CapabilitiesCmd{
+		stub:   stub,
+		writer: writer,
+	}
+}
+
+// CapabilitiesCmd executes a command that checks whether the peers and the
+// orderers of a channel support the capabilities it may be upgraded to
+type CapabilitiesCmd struct {
+	stub              Stub
+	writer            io.Writer
+	server            *string
+	configBlock       *string
+	capabilities      *map[string]string
+	ordererOperations *[]string
+	operationsTLSCA   *string
+	output            *string
+}
+
+// SetServer sets the server of the CapabilitiesCmd
+func (cc *CapabilitiesCmd) SetServer(server *string) {
+	cc.server = server
+}
+
+// SetConfigBlock sets the path of the latest config block of the channel
+func (cc *CapabilitiesCmd) SetConfigBlock(configBlock *string) {
+	cc.configBlock = configBlock
+}
+
+// SetCapabilities sets the capability to upgrade to, by config group
+func (cc *CapabilitiesCmd) SetCapabilities(capabilities *map[string]string) {
+	cc.capabilities = capabilities
+}
+
+// SetOrdererOperations sets the URLs of the operations endpoints of the orderers
+func (cc *CapabilitiesCmd) SetOrdererOperations(urls *[]string) {
+	cc.ordererOperations = urls
+}
+
+// SetOperationsTLSCA sets the path of the TLS CA certificate of the operations endpoints
+func (cc *CapabilitiesCmd) SetOperationsTLSCA(path *string) {
+	cc.operationsTLSCA = path
+}
+
+// SetOutput sets the path to write the config update enabling the capabilities to
+func (cc *CapabilitiesCmd) SetOutput(output *string) {
+	cc.output = output
+}
+
+// Execute executes the command
+func (cc *CapabilitiesCmd) Execute(conf common.Config) error {
+	if cc.server == nil || *cc.server == "" {
+		return errors.New("no server specified")
+	}
+	if cc.configBlock == nil || *cc.configBlock == "" {
+		return errors.New("no config block specified")
+	}
+	var requested map[string]string
+	if cc.capabilities != nil {
+		requested = *cc.capabilities
+	}
+	output := ""
+	if cc.output != nil {
+		output = *cc.output
+	}
+	if output != "" && len(requested) == 0 {
+		return errors.New("no capability specified for the config update")
+	}
+
+	blockBytes, err := ioutil.ReadFile(*cc.configBlock)
+	if err != nil {
+		return errors.Wrap(err, "failed reading config block")
+	}
+	block, err := protoutil.UnmarshalBlock(blockBytes)
+	if err != nil {
+		return errors.Wrap(err, "failed unmarshaling config block")
+	}
+	channel, config, err := edit.ConfigFromBlock(block)
+	if err != nil {
+		return err
+	}
+	upgrades, err := upgradesOf(config, requested)
+	if err != nil {
+		return err
+	}
+
+	req := discovery.NewRequest().OfChannel(channel).AddPeersQuery()
+	res, err := cc.stub.Send(*cc.server, conf, req)
+	if err != nil {
+		return err
+	}
+	peers, err := res.ForChannel(channel).Peers()
+	if err != nil {
+		return err
+	}
+	nodes := peerNodes(peers)
+
+	if cc.ordererOperations != nil && len(*cc.ordererOperations) > 0 {
+		client, err := cc.operationsClient()
+		if err != nil {
+			return err
+		}
+		for _, url := range *cc.ordererOperations {
+			nodes = append(nodes, queryOrderer(client, url))
+		}
+	}
+
+	plan := &upgradePlan{
+		Channel:  channel,
+		Nodes:    nodes,
+		Upgrades: upgrades,
+	}
+	plan.check(ordererEndpoints(config))
+
+	b, err := json.MarshalIndent(plan, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling the upgrade plan")
+	}
+	fmt.Fprintln(cc.writer, string(b))
+
+	if output == "" {
+		return nil
+	}
+	var ops []edit.Operation
+	for _, u := range plan.Upgrades {
+		if !u.Safe {
+			return errors.Errorf("not writing the config update, upgrading to %s capability %s is not safe", u.Group, u.Capability)
+		}
+		ops = append(ops, edit.SetCapabilities(u.level, u.enabled()))
+	}
+	env, err := edit.Edit(block, ops...)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(output, protoutil.MarshalOrPanic(env), 0o640); err != nil {
+		return errors.Wrap(err, "failed writing config update")
+	}
+	return nil
+}
+
+func (cc *CapabilitiesCmd) operationsClient() (*http.Client, error) {
+	client := &http.Client{Timeout: defaultTimeout}
+	if cc.operationsTLSCA == nil || *cc.operationsTLSCA == "" {
+		return client, nil
+	}
+	caPEM, err := ioutil.ReadFile(*cc.operationsTLSCA)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed reading operations TLS CA certificate")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.Errorf("no certificates found in %s", *cc.operationsTLSCA)
+	}
+	client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}
+	return client, nil
+}
+
+// node is a peer or an orderer of the channel, along with the capabilities
+// it supports by config group, if known.
+type node struct {
+	Kind         string
+	MSPID        string `json:",omitempty"`
+	Endpoint     string
+	Version      string `json:",omitempty"`
+	Error        string `json:",omitempty"`
+	capabilities map[string][]string
+}
+
+func (n node) String() string {
+	if n.MSPID != "" {
+		return fmt.Sprintf("%s %s (%s)", n.Kind, n.Endpoint, n.MSPID)
+	}
+	return fmt.Sprintf("%s %s", n.Kind, n.Endpoint)
+}
+
+// processes returns whether the node processes the capabilities of the given group.
+func (n node) processes(group string) bool {
+	switch group {
+	case channelconfig.OrdererGroupKey:
+		return n.Kind == "orderer"
+	case channelconfig.ApplicationGroupKey:
+		return n.Kind == "peer"
+	default:
+		return true
+	}
+}
+
+func peerNodes(peers []*discovery.Peer) []node {
+	var nodes []node
+	for _, p := range peers {
+		n := node{
+			Kind:  "peer",
+			MSPID: p.MSPID,
+		}
+		if p.AliveMessage != nil && p.AliveMessage.GetAliveMsg() != nil && p.AliveMessage.GetAliveMsg().Membership != nil {
+			n.Endpoint = p.AliveMessage.GetAliveMsg().Membership.Endpoint
+		}
+		if p.StateInfoMessage != nil && p.StateInfoMessage.GetStateInfo() != nil {
+			pc, err := protoext.GetCapabilities(p.StateInfoMessage.GetStateInfo().Properties)
+			switch {
+			case err != nil:
+				n.Error = err.Error()
+			case pc != nil:
+				n.Version = pc.Version
+				n.capabilities = map[string][]string{
+					channelconfig.ChannelGroupKey:     pc.Channel,
+					channelconfig.OrdererGroupKey:     pc.Orderer,
+					channelconfig.ApplicationGroupKey: pc.Application,
+				}
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// queryOrderer retrieves the version and the capabilities of the orderer
+// from the version endpoint of its operations service.
+func queryOrderer(client *http.Client, url string) node {
+	n := node{
+		Kind:     "orderer",
+		Endpoint: url,
+	}
+	resp, err := client.Get(strings.TrimSuffix(url, "/") + "/version")
+	if err != nil {
+		n.Error = err.Error()
+		return n
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		n.Error = fmt.Sprintf("unexpected status %s", resp.Status)
+		return n
+	}
+	versionInfo := struct {
+		Version      string
+		Capabilities map[string][]string
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&versionInfo); err != nil {
+		n.Error = fmt.Sprintf("invalid version info: %s", err)
+		return n
+	}
+	n.Version = versionInfo.Version
+	n.capabilities = versionInfo.Capabilities
+	return n
+}
+
+// ordererEndpoints returns the distinct orderer endpoints of the channel config.
+func ordererEndpoints(config *cb.Config) []string {
+	endpoints := map[string]struct{}{}
+	addEndpoints := func(value *cb.ConfigValue) {
+		if value == nil {
+			return
+		}
+		addresses := &cb.OrdererAddresses{}
+		if err := proto.Unmarshal(value.Value, addresses); err != nil {
+			return
+		}
+		for _, address := range addresses.Addresses {
+			endpoints[address] = struct{}{}
+		}
+	}
+	addEndpoints(config.ChannelGroup.Values[channelconfig.OrdererAddressesKey])
+	if orderer, ok := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]; ok {
+		for _, org := range orderer.Groups {
+			addEndpoints(org.Values[channelconfig.EndpointsKey])
+		}
+	}
+
+	var sorted []string
+	for endpoint := range endpoints {
+		sorted = append(sorted, endpoint)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// upgradePlan reports, for each capability the channel may be upgraded to,
+// whether all of its peers and orderers support it.
+type upgradePlan struct {
+	Channel  string
+	Nodes    []node
+	Upgrades []*upgrade
+}
+
+// upgrade is the upgrade of a config group to a capability.
+type upgrade struct {
+	Group      string
+	Current    []string
+	Capability string
+	Safe       bool
+	// Blockers tell why the upgrade is not safe.
+	Blockers []string `json:",omitempty"`
+	level    string
+}
+
+// enabled returns the capabilities enabled by the upgrade, that is the
+// capability replacing the older ones of its group.
+func (u *upgrade) enabled() []string {
+	older := map[string]bool{}
+	for _, name := range capabilities.Supported()[u.Group] {
+		older[name] = true
+	}
+	enabled := []string{u.Capability}
+	for _, name := range u.Current {
+		if !older[name] {
+			enabled = append(enabled, name)
+		}
+	}
+	return enabled
+}
+
+// upgradesOf returns the upgrades to the requested capabilities, or to every
+// capability newer than the current ones if none are requested.
+func upgradesOf(config *cb.Config, requested map[string]string) ([]*upgrade, error) {
+	for group := range requested {
+		if !isCapabilityGroup(group) {
+			return nil, errors.Errorf("unknown config group '%s', must be one of %s, %s or %s", group,
+				channelconfig.ChannelGroupKey, channelconfig.OrdererGroupKey, channelconfig.ApplicationGroupKey)
+		}
+	}
+
+	var upgrades []*upgrade
+	for _, g := range capabilityGroups {
+		group := config.ChannelGroup
+		if g.name != channelconfig.ChannelGroupKey {
+			group = config.ChannelGroup.Groups[g.name]
+		}
+		if group == nil {
+			if _, ok := requested[g.name]; ok {
+				return nil, errors.Errorf("channel config has no %s group", g.name)
+			}
+			continue
+		}
+		current, err := currentCapabilities(group)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid %s capabilities", g.name)
+		}
+
+		known := capabilities.Supported()[g.name]
+		newest := -1
+		for i, name := range known {
+			for _, c := range current {
+				if c == name {
+					newest = i
+				}
+			}
+		}
+		candidates := known[newest+1:]
+		if capability, ok := requested[g.name]; ok {
+			i := indexOf(known, capability)
+			switch {
+			case i < 0:
+				return nil, errors.Errorf("unknown %s capability %s", g.name, capability)
+			case i <= newest:
+				return nil, errors.Errorf("%s capability %s is not newer than the current %s", g.name, capability, known[newest])
+			}
+			candidates = []string{capability}
+		} else if len(requested) > 0 {
+			continue
+		}
+
+		for _, capability := range candidates {
+			upgrades = append(upgrades, &upgrade{
+				Group:      g.name,
+				Current:    current,
+				Capability: capability,
+				level:      g.level,
+			})
+		}
+	}
+	return upgrades, nil
+}
+
+func isCapabilityGroup(name string) bool {
+	for _, g := range capabilityGroups {
+		if g.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func currentCapabilities(group *cb.ConfigGroup) ([]string, error) {
+	value, ok := group.Values[channelconfig.CapabilitiesKey]
+	if !ok {
+		return nil, nil
+	}
+	caps := &cb.Capabilities{}
+	if err := proto.Unmarshal(value.Value, caps); err != nil {
+		return nil, err
+	}
+	var names []string
+	for name := range caps.Capabilities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// check determines which upgrades are safe, given the orderer endpoints of
+// the channel config.
+func (p *upgradePlan) check(ordererEndpoints []string) {
+	orderers := 0
+	for _, n := range p.Nodes {
+		if n.Kind == "orderer" {
+			orderers++
+		}
+	}
+
+	for _, u := range p.Upgrades {
+		processing := 0
+		for _, n := range p.Nodes {
+			if !n.processes(u.Group) {
+				continue
+			}
+			processing++
+			switch {
+			case n.Error != "":
+				u.Blockers = append(u.Blockers, fmt.Sprintf("%s could not be queried: %s", n, n.Error))
+			case n.capabilities == nil:
+				u.Blockers = append(u.Blockers, fmt.Sprintf("%s does not report the capabilities it supports", n))
+			case indexOf(n.capabilities[u.Group], u.Capability) < 0:
+				u.Blockers = append(u.Blockers, fmt.Sprintf("%s of version %s does not support it", n, n.Version))
+			}
+		}
+		if u.Group != channelconfig.ApplicationGroupKey && orderers < len(ordererEndpoints) {
+			u.Blockers = append(u.Blockers, fmt.Sprintf("the channel config lists %d orderer endpoints but %d orderers were queried", len(ordererEndpoints), orderers))
+		}
+		if processing == 0 {
+			u.Blockers = append(u.Blockers, fmt.Sprintf("no peer or orderer processing the %s group was reached", u.Group))
+		}
+		u.Safe = len(u.Blockers) == 0
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package discovery_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/cmd/common"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/core/operations"
+	. "github.com/hyperledger/fabric/discovery/client"
+	discovery "github.com/hyperledger/fabric/discovery/cmd"
+	"github.com/hyperledger/fabric/discovery/cmd/mocks"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type plan struct {
+	Channel string
+	Nodes   []struct {
+		Kind     string
+		Endpoint string
+		Version  string
+		Error    string
+	}
+	Upgrades []struct {
+		Group      string
+		Current    []string
+		Capability string
+		Safe       bool
+		Blockers   []string
+	}
+}
+
+func TestCapabilitiesCmd(t *testing.T) {
+	server := "peer0"
+	configBlock := writeConfigBlock(t)
+	stub := &mocks.Stub{}
+	buff := &bytes.Buffer{}
+	cmd := discovery.NewCapabilitiesCmd(stub, buff)
+
+	t.Run("no server supplied", func(t *testing.T) {
+		cmd.SetServer(nil)
+		cmd.SetConfigBlock(&configBlock)
+
+		err := cmd.Execute(common.Config{})
+		require.EqualError(t, err, "no server specified")
+	})
+
+	t.Run("no config block supplied", func(t *testing.T) {
+		cmd.SetServer(&server)
+		cmd.SetConfigBlock(nil)
+
+		err := cmd.Execute(common.Config{})
+		require.EqualError(t, err, "no config block specified")
+	})
+
+	t.Run("output without capabilities", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "update.pb")
+		cmd.SetServer(&server)
+		cmd.SetConfigBlock(&configBlock)
+		cmd.SetOutput(&output)
+		defer cmd.SetOutput(nil)
+
+		err := cmd.Execute(common.Config{})
+		require.EqualError(t, err, "no capability specified for the config update")
+	})
+
+	t.Run("invalid config block", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "missing.block")
+		cmd.SetServer(&server)
+		cmd.SetConfigBlock(&missing)
+
+		err := cmd.Execute(common.Config{})
+		require.Contains(t, err.Error(), "failed reading config block")
+	})
+
+	t.Run("invalid capabilities", func(t *testing.T) {
+		cmd.SetServer(&server)
+		cmd.SetConfigBlock(&configBlock)
+		defer cmd.SetCapabilities(nil)
+
+		for requested, expected := range map[string]string{
+			"Consortiums": "unknown config group 'Consortiums', must be one of Channel, Orderer or Application",
+			"Channel":     "Channel capability V1_3 is not newer than the current V2_0",
+			"Application": "unknown Application capability V9_9",
+		} {
+			capabilities := map[string]string{requested: "V9_9"}
+			if requested == "Channel" {
+				capabilities[requested] = "V1_3"
+			}
+			cmd.SetCapabilities(&capabilities)
+			err := cmd.Execute(common.Config{})
+			require.EqualError(t, err, expected)
+		}
+	})
+
+	t.Run("Server return error", func(t *testing.T) {
+		cmd.SetServer(&server)
+		cmd.SetConfigBlock(&configBlock)
+
+		stub.On("Send", server, mock.Anything, mock.Anything).Return(nil, errors.New("deadline exceeded")).Once()
+		err := cmd.Execute(common.Config{})
+		require.Contains(t, err.Error(), "deadline exceeded")
+	})
+}
+
+func TestCapabilitiesPlan(t *testing.T) {
+	server := "peer0"
+	configBlock := writeConfigBlock(t)
+
+	orderer := httptest.NewServer(&operations.VersionInfoHandler{Version: "3.1.0", Capabilities: capabilities.Supported()})
+	defer orderer.Close()
+	oldOrderer := httptest.NewServer(&operations.VersionInfoHandler{Version: "2.5.0"})
+	defer oldOrderer.Close()
+
+	upToDate := capabilityPeer(0, "3.1.0", capabilities.Supported())
+	outdated := capabilityPeer(1, "3.0.0", map[string][]string{
+		"Channel":     {"V1_1", "V1_3", "V1_4_2", "V1_4_3", "V2_0", "V3_0"},
+		"Application": {"V1_1", "V1_2", "V1_3", "V1_4_2", "V2_0"},
+	})
+	unknown := &Peer{MSPID: "Org1MSP", AliveMessage: aliveMessage(2), StateInfoMessage: stateInfoMessage(10)}
+
+	execute := func(t *testing.T, peers []*Peer, ordererURLs []string, requested map[string]string, output string) (*plan, error) {
+		chanRes := &mocks.ChannelResponse{}
+		chanRes.On("Peers").Return(peers, nil)
+		res := &mocks.ServiceResponse{}
+		res.On("ForChannel", "testchannel").Return(chanRes)
+		stub := &mocks.Stub{}
+		stub.On("Send", server, mock.Anything, mock.Anything).Return(res, nil)
+
+		buff := &bytes.Buffer{}
+		cmd := discovery.NewCapabilitiesCmd(stub, buff)
+		cmd.SetServer(&server)
+		cmd.SetConfigBlock(&configBlock)
+		cmd.SetCapabilities(&requested)
+		cmd.SetOrdererOperations(&ordererURLs)
+		cmd.SetOutput(&output)
+		err := cmd.Execute(common.Config{})
+
+		p := &plan{}
+		if buff.Len() > 0 {
+			require.NoError(t, json.Unmarshal(buff.Bytes(), p))
+		}
+		return p, err
+	}
+
+	t.Run("all newer capabilities", func(t *testing.T) {
+		p, err := execute(t, []*Peer{upToDate}, []string{orderer.URL}, nil, "")
+		require.NoError(t, err)
+		require.Equal(t, "testchannel", p.Channel)
+		require.Len(t, p.Nodes, 2)
+		require.Equal(t, "3.1.0", p.Nodes[1].Version)
+		require.Len(t, p.Upgrades, 2)
+		for i, capability := range []string{"V3_0", "V3_1"} {
+			require.Equal(t, "Channel", p.Upgrades[i].Group)
+			require.Equal(t, []string{"V2_0"}, p.Upgrades[i].Current)
+			require.Equal(t, capability, p.Upgrades[i].Capability)
+			require.True(t, p.Upgrades[i].Safe)
+			require.Empty(t, p.Upgrades[i].Blockers)
+		}
+	})
+
+	t.Run("unsafe upgrade", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "update.pb")
+		p, err := execute(t, []*Peer{upToDate, outdated, unknown}, []string{oldOrderer.URL, "http://127.0.0.1:0"}, map[string]string{"Channel": "V3_1"}, output)
+		require.EqualError(t, err, "not writing the config update, upgrading to Channel capability V3_1 is not safe")
+		require.NoFileExists(t, output)
+
+		require.Len(t, p.Upgrades, 1)
+		require.False(t, p.Upgrades[0].Safe)
+		require.Len(t, p.Upgrades[0].Blockers, 4)
+		require.Equal(t, "peer p1 (Org1MSP) of version 3.0.0 does not support it", p.Upgrades[0].Blockers[0])
+		require.Equal(t, "peer p2 (Org1MSP) does not report the capabilities it supports", p.Upgrades[0].Blockers[1])
+		require.Equal(t, "orderer "+oldOrderer.URL+" does not report the capabilities it supports", p.Upgrades[0].Blockers[2])
+		require.Contains(t, p.Upgrades[0].Blockers[3], "orderer http://127.0.0.1:0 could not be queried")
+	})
+
+	t.Run("orderers not queried", func(t *testing.T) {
+		p, err := execute(t, []*Peer{upToDate}, nil, map[string]string{"Orderer": "V2_0"}, "")
+		require.EqualError(t, err, "Orderer capability V2_0 is not newer than the current V2_0")
+		require.Nil(t, p.Upgrades)
+
+		p, err = execute(t, []*Peer{upToDate}, nil, map[string]string{"Channel": "V3_1"}, "")
+		require.NoError(t, err)
+		require.False(t, p.Upgrades[0].Safe)
+		require.Equal(t, []string{"the channel config lists 1 orderer endpoints but 0 orderers were queried"}, p.Upgrades[0].Blockers)
+	})
+
+	t.Run("config update", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "update.pb")
+		p, err := execute(t, []*Peer{upToDate}, []string{orderer.URL}, map[string]string{"Channel": "V3_1"}, output)
+		require.NoError(t, err)
+		require.True(t, p.Upgrades[0].Safe)
+
+		envBytes, err := ioutil.ReadFile(output)
+		require.NoError(t, err)
+		env, err := protoutil.UnmarshalEnvelope(envBytes)
+		require.NoError(t, err)
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		require.NoError(t, err)
+		configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+		require.NoError(t, proto.Unmarshal(payload.Data, configUpdateEnv))
+		configUpdate := &cb.ConfigUpdate{}
+		require.NoError(t, proto.Unmarshal(configUpdateEnv.ConfigUpdate, configUpdate))
+		require.Equal(t, "testchannel", configUpdate.ChannelId)
+
+		caps := &cb.Capabilities{}
+		require.NoError(t, proto.Unmarshal(configUpdate.WriteSet.Values["Capabilities"].Value, caps))
+		require.Equal(t, map[string]*cb.Capability{"V3_1": {}}, caps.Capabilities)
+	})
+}
+
+func writeConfigBlock(t *testing.T) string {
+	devConfigDir := configtest.GetDevConfigDir()
+	profile := genesisconfig.Load(genesisconfig.SampleAppChannelEtcdRaftProfile, devConfigDir)
+	certPath := filepath.Join(devConfigDir, "msp", "signcerts", "peer.pem")
+	for _, c := range profile.Orderer.EtcdRaft.Consenters {
+		c.ClientTlsCert = []byte(certPath)
+		c.ServerTlsCert = []byte(certPath)
+	}
+	block := encoder.New(profile).GenesisBlockForChannel("testchannel")
+
+	path := filepath.Join(t.TempDir(), "config.block")
+	require.NoError(t, ioutil.WriteFile(path, protoutil.MarshalOrPanic(block), 0o600))
+	return path
+}
+
+func capabilityPeer(id int, version string, caps map[string][]string) *Peer {
+	stateInfo := stateInfoMessage(10)
+	props := stateInfo.GetStateInfo().Properties
+	if err := protoext.SetCapabilities(props, version, caps); err != nil {
+		panic(err)
+	}
+	sMsg, _ := protoext.NoopSign(&gossip.GossipMessage{
+		Content: &gossip.GossipMessage_StateInfo{StateInfo: stateInfo.GetStateInfo()},
+	})
+	return &Peer{
+		MSPID:            "Org1MSP",
+		AliveMessage:     aliveMessage(id),
+		StateInfoMessage: sMsg,
+	}
+}
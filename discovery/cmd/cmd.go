@@ -16,9 +16,10 @@ import (
 )
 
 const (
-	PeersCommand     = "peers"
-	ConfigCommand    = "config"
-	EndorsersCommand = "endorsers"
+	PeersCommand        = "peers"
+	ConfigCommand       = "config"
+	EndorsersCommand    = "endorsers"
+	CapabilitiesCommand = "capabilities"
 )
 
 var (
@@ -83,4 +84,19 @@ func AddCommands(cli CommandRegistrar) {
 	endorserCmd.SetChaincodes(chaincodes)
 	endorserCmd.SetCollections(collections)
 	endorserCmd.SetNoPrivateReads(noPrivReads)
+
+	capabilitiesCmd := NewCapabilitiesCmd(&ClientStub{}, responseParserWriter)
+	capabilities := cli.Command(CapabilitiesCommand, "Check whether the peers and orderers of a channel support new capabilities", capabilitiesCmd.Execute)
+	configBlock := capabilities.Flag("configBlock", "Sets the path of the latest config block of the channel").String()
+	capabilityFlags := capabilities.Flag("capability", "Specifies the capability to upgrade a config group to, all newer capabilities are checked if none is given").PlaceHolder("GROUP=CAPABILITY").StringMap()
+	ordererOperations := capabilities.Flag("ordererOperations", "Specifies the URL of the operations endpoint of an orderer of the channel").PlaceHolder("URL").Strings()
+	operationsTLSCA := capabilities.Flag("operationsTLSCA", "Sets the TLS CA certificate file path that verifies the certificates of the operations endpoints").String()
+	output := capabilities.Flag("output", "Sets the path to write the config update enabling the specified capabilities to, if they are safe to enable").String()
+	server = capabilities.Flag("server", "Sets the endpoint of the server to connect").String()
+	capabilitiesCmd.SetServer(server)
+	capabilitiesCmd.SetConfigBlock(configBlock)
+	capabilitiesCmd.SetCapabilities(capabilityFlags)
+	capabilitiesCmd.SetOrdererOperations(ordererOperations)
+	capabilitiesCmd.SetOperationsTLSCA(operationsTLSCA)
+	capabilitiesCmd.SetOutput(output)
 }
@@ -23,6 +23,7 @@ func TestAddCommands(t *testing.T) {
 	cli.On("Command", discovery.PeersCommand, mock.Anything, configFunc).Return(app.Command(discovery.PeersCommand, ""))
 	cli.On("Command", discovery.ConfigCommand, mock.Anything, configFunc).Return(app.Command(discovery.ConfigCommand, ""))
 	cli.On("Command", discovery.EndorsersCommand, mock.Anything, configFunc).Return(app.Command(discovery.EndorsersCommand, ""))
+	cli.On("Command", discovery.CapabilitiesCommand, mock.Anything, configFunc).Return(app.Command(discovery.CapabilitiesCommand, ""))
 	discovery.AddCommands(cli)
 	// Ensure that serve and channel flags are were configured for the sub-commands
 	for _, cmd := range []string{discovery.PeersCommand, discovery.ConfigCommand, discovery.EndorsersCommand} {
@@ -32,4 +33,8 @@ func TestAddCommands(t *testing.T) {
 	// Ensure that chaincode and collection flags were called for the endorsers
 	require.NotNil(t, app.GetCommand(discovery.EndorsersCommand).GetFlag("chaincode"))
 	require.NotNil(t, app.GetCommand(discovery.EndorsersCommand).GetFlag("collection"))
+	// Ensure that the capabilities command reads the config block instead of a channel
+	require.NotNil(t, app.GetCommand(discovery.CapabilitiesCommand).GetFlag("server"))
+	require.NotNil(t, app.GetCommand(discovery.CapabilitiesCommand).GetFlag("configBlock"))
+	require.Nil(t, app.GetCommand(discovery.CapabilitiesCommand).GetFlag("channel"))
 }
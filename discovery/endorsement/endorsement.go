@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/common/graph"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policies/inquire"
+	"github.com/hyperledger/fabric/discovery/protoext"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	. "github.com/hyperledger/fabric/gossip/discovery"
@@ -42,6 +43,10 @@ type policyFetcher interface {
 	// PoliciesByChaincode returns the chaincode policy or existing collection level policies that can be
 	// inquired for which identities satisfy them
 	PoliciesByChaincode(channel string, cc string, collections ...string) []policies.InquireablePolicy
+
+	// PoliciesByKeys returns the policies that the given keys written by the chaincode are validated against,
+	// that can be inquired for which identities satisfy them
+	PoliciesByKeys(channel string, cc string, keys ...*protoext.WrittenKey) ([]policies.InquireablePolicy, error)
 }
 
 type gossipSupport interface {
@@ -220,7 +225,24 @@ func (ea *endorsementAnalyzer) computePrincipalSets(channelID common.ChannelID,
 	sessionLogger := logger.With("channel", string(channelID))
 	var inquireablePolicies []policies.InquireablePolicy
 	for _, chaincode := range interest.Chaincodes {
-		policies := ea.PoliciesByChaincode(string(channelID), chaincode.Name, chaincode.CollectionNames...)
+		writtenKeys, err := protoext.GetWrittenKeys(chaincode)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed extracting written keys of chaincode %s", chaincode.Name)
+		}
+
+		var policies []policies.InquireablePolicy
+		if len(writtenKeys) == 0 {
+			policies = ea.PoliciesByChaincode(string(channelID), chaincode.Name, chaincode.CollectionNames...)
+		} else {
+			// When the written keys are known, the policies are the ones the validators
+			// evaluate for them: the key-level policy of every key that has one, and the
+			// collection or chaincode policy only for the keys that don't
+			policies, err = ea.PoliciesByKeys(string(channelID), chaincode.Name, writtenKeys...)
+			if err != nil {
+				sessionLogger.Warningf("Failed retrieving key-level policies for chaincode %s: %v", chaincode.Name, err)
+				return nil, errors.WithStack(err)
+			}
+		}
 		if len(policies) == 0 {
 			sessionLogger.Debug("Policy for chaincode '", chaincode, "'doesn't exist")
 			return nil, errors.New("policy not found")
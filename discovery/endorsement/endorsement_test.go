@@ -18,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policies/inquire"
+	"github.com/hyperledger/fabric/discovery/protoext"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
@@ -551,6 +552,60 @@ func TestPeersForEndorsement(t *testing.T) {
 			peerIdentityString("p6"): {},
 		}, extractPeers(desc))
 	})
+
+	t.Run("Key-level EP", func(t *testing.T) {
+		// Scenario XIII: The chaincode EP can be satisfied by 2 principal combinations:
+		// p0 and p6, or p12 alone. The chaincode writes a key whose key-level EP
+		// requires p11, and another key without a key-level EP.
+		// Thus, the combinations that can satisfy would be p0, p6 and p11, or p11 and p12.
+		pb := principalBuilder{}
+		chaincodeEP := pb.newSet().addPrincipal(peerRole("p0")).
+			addPrincipal(peerRole("p6")).newSet().
+			addPrincipal(peerRole("p12")).buildPolicy()
+		keyEP := pb.newSet().addPrincipal(peerRole("p11")).buildPolicy()
+
+		ccCall := &discoveryprotos.ChaincodeCall{Name: cc}
+		require.NoError(t, protoext.SetWrittenKeys(ccCall, &protoext.WrittenKey{Key: "key"}))
+		interest := &discoveryprotos.ChaincodeInterest{
+			Chaincodes: []*discoveryprotos.ChaincodeCall{ccCall},
+		}
+
+		mf := &metadataFetcher{}
+		mf.On("Metadata").Return(&chaincode.Metadata{Name: cc, Version: "1.0"}).Times(3)
+		g.On("PeersOfChannel").Return(chanPeers.toMembers()).Times(3)
+		pf := &policyFetcherMock{}
+		pf.On("PoliciesByKeys", cc).Return([]policies.InquireablePolicy{chaincodeEP, keyEP}, nil).Once()
+		analyzer := NewEndorsementAnalyzer(g, pf, &principalEvaluatorMock{}, mf)
+		desc, err := analyzer.PeersForEndorsement(channel, interest)
+		require.NoError(t, err)
+		require.NotNil(t, desc)
+		require.Len(t, desc.Layouts, 2)
+		require.Len(t, desc.Layouts[0].QuantitiesByGroup, 3)
+		require.Len(t, desc.Layouts[1].QuantitiesByGroup, 2)
+		require.Equal(t, map[string]struct{}{
+			peerIdentityString("p0"):  {},
+			peerIdentityString("p6"):  {},
+			peerIdentityString("p11"): {},
+			peerIdentityString("p12"): {},
+		}, extractPeers(desc))
+
+		// When all the written keys have a key-level EP, the chaincode EP
+		// doesn't need to be satisfied, and p11 alone is enough
+		pf.On("PoliciesByKeys", cc).Return([]policies.InquireablePolicy{keyEP}, nil).Once()
+		desc, err = analyzer.PeersForEndorsement(channel, interest)
+		require.NoError(t, err)
+		require.Len(t, desc.Layouts, 1)
+		require.Equal(t, map[string]struct{}{
+			peerIdentityString("p11"): {},
+		}, extractPeers(desc))
+		pf.AssertNotCalled(t, "PoliciesByChaincode", cc)
+
+		// Failing to retrieve the key-level EPs fails the computation
+		pf.On("PoliciesByKeys", cc).Return(nil, errors.New("ledger error")).Once()
+		desc, err = analyzer.PeersForEndorsement(channel, interest)
+		require.Nil(t, desc)
+		require.EqualError(t, err, "ledger error")
+	})
 }
 
 func TestPeersAuthorizedByCriteria(t *testing.T) {
@@ -891,6 +946,14 @@ func (pf *policyFetcherMock) PoliciesByChaincode(channel string, chaincode strin
 	return arg.Get(0).([]policies.InquireablePolicy)
 }
 
+func (pf *policyFetcherMock) PoliciesByKeys(channel string, chaincode string, keys ...*protoext.WrittenKey) ([]policies.InquireablePolicy, error) {
+	args := pf.Called(chaincode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]policies.InquireablePolicy), args.Error(1)
+}
+
 type principalBuilder struct {
 	ip inquireablePolicy
 }
@@ -0,0 +1,33 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoext
+
+import (
+	"github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// GetWrittenKeys returns the keys written by the given ChaincodeCall,
+// as set by SetWrittenKeys.
+// Returns an error in case the operation fails.
+func GetWrittenKeys(cc *discovery.ChaincodeCall) ([]*WrittenKey, error) {
+	wk := &ChaincodeCallWrittenKeys{}
+	if _, err := protoutil.GetExtension(cc, protoutil.WrittenKeysExtension, wk); err != nil {
+		return nil, err
+	}
+	return wk.WrittenKeys, nil
+}
+
+// SetWrittenKeys sets the keys written by the given ChaincodeCall,
+// replacing any keys previously set.
+// Returns an error in case the operation fails.
+func SetWrittenKeys(cc *discovery.ChaincodeCall, keys ...*WrittenKey) error {
+	if len(keys) == 0 {
+		return protoutil.SetExtension(cc, protoutil.WrittenKeysExtension, nil)
+	}
+	return protoutil.SetExtension(cc, protoutil.WrittenKeysExtension, &ChaincodeCallWrittenKeys{WrittenKeys: keys})
+}
@@ -0,0 +1,53 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoext_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric/discovery/protoext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrittenKeys(t *testing.T) {
+	cc := &discovery.ChaincodeCall{Name: "mycc"}
+	keys, err := protoext.GetWrittenKeys(cc)
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	err = protoext.SetWrittenKeys(cc, &protoext.WrittenKey{Key: "k1"}, &protoext.WrittenKey{Collection: "col1", Key: "k2"})
+	require.NoError(t, err)
+
+	// The written keys survive a round trip through the wire
+	b, err := proto.Marshal(&discovery.ChaincodeInterest{Chaincodes: []*discovery.ChaincodeCall{cc}})
+	require.NoError(t, err)
+	interest := &discovery.ChaincodeInterest{}
+	require.NoError(t, proto.Unmarshal(b, interest))
+	require.Equal(t, "mycc", interest.Chaincodes[0].Name)
+
+	keys, err = protoext.GetWrittenKeys(interest.Chaincodes[0])
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	require.Equal(t, "k1", keys[0].Key)
+	require.Equal(t, "", keys[0].Collection)
+	require.Equal(t, "k2", keys[1].Key)
+	require.Equal(t, "col1", keys[1].Collection)
+
+	// Setting the written keys replaces the previous ones
+	require.NoError(t, protoext.SetWrittenKeys(cc, &protoext.WrittenKey{Key: "k3"}))
+	keys, err = protoext.GetWrittenKeys(cc)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, "k3", keys[0].Key)
+
+	cc.XXX_unrecognized = []byte{0}
+	_, err = protoext.GetWrittenKeys(cc)
+	require.Error(t, err)
+	require.Error(t, protoext.SetWrittenKeys(cc))
+}
@@ -9,10 +9,15 @@ package chaincode
 import (
 	"github.com/golang/protobuf/proto"
 	common2 "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policies/inquire"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/discovery/protoext"
+	"github.com/pkg/errors"
 )
 
 var logger = flogging.MustGetLogger("discovery.DiscoverySupport")
@@ -21,16 +26,25 @@ type MetadataRetriever interface {
 	Metadata(channel string, cc string, collections ...string) *chaincode.Metadata
 }
 
+// StateMetadataRetriever retrieves the metadata of keys in the world state
+type StateMetadataRetriever interface {
+	// StateMetadata returns the metadata of the given key of the namespace in the given channel.
+	// If the collection isn't empty, the key is looked up by its hash in the collection.
+	StateMetadata(channel, namespace, collection, key string) (map[string][]byte, error)
+}
+
 // DiscoverySupport implements support that is used for service discovery
 // that is related to chaincode
 type DiscoverySupport struct {
 	ci MetadataRetriever
+	sr StateMetadataRetriever
 }
 
 // NewDiscoverySupport creates a new DiscoverySupport
-func NewDiscoverySupport(ci MetadataRetriever) *DiscoverySupport {
+func NewDiscoverySupport(ci MetadataRetriever, sr StateMetadataRetriever) *DiscoverySupport {
 	s := &DiscoverySupport{
 		ci: ci,
+		sr: sr,
 	}
 	return s
 }
@@ -93,3 +107,100 @@ func (s *DiscoverySupport) PoliciesByChaincode(channel string, cc string, collec
 
 	return uniqueInquireablePolicies
 }
+
+// PoliciesByKeys returns the policies that a transaction writing the given keys of the
+// chaincode is validated against: the key-level endorsement policy of each key that has
+// one, plus the collection or chaincode endorsement policy if some keys have none.
+func (s *DiscoverySupport) PoliciesByKeys(channel string, cc string, keys ...*protoext.WrittenKey) ([]policies.InquireablePolicy, error) {
+	inquireablePolicies := make(map[string]struct{})
+	uniqueInquireablePolicies := []policies.InquireablePolicy{}
+	// the keys without a key-level policy fall back to the policy of their
+	// collection, if they are private, and to the chaincode policy otherwise
+	var fallbackCollections []string
+	fallback := make(map[string]struct{})
+	chaincodeFallback := false
+
+	for _, key := range keys {
+		metadata, err := s.sr.StateMetadata(channel, cc, key.Collection, key.Key)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed retrieving metadata of key %s", keyString(cc, key))
+		}
+		vp := metadata[pb.MetaDataKeys_VALIDATION_PARAMETER.String()]
+		if len(vp) == 0 {
+			if key.Collection == "" {
+				chaincodeFallback = true
+			} else if _, exists := fallback[key.Collection]; !exists {
+				fallbackCollections = append(fallbackCollections, key.Collection)
+				fallback[key.Collection] = struct{}{}
+			}
+			continue
+		}
+		if _, exists := inquireablePolicies[string(vp)]; exists {
+			continue
+		}
+
+		pol := &common2.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(vp, pol); err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling validation parameter of key %s", keyString(cc, key))
+		}
+		if len(pol.Identities) == 0 || pol.Rule == nil {
+			return nil, errors.Errorf("invalid validation parameter of key %s, either Identities(%v) or Rule(%v) are empty", keyString(cc, key), pol.Identities, pol.Rule)
+		}
+		uniqueInquireablePolicies = append(uniqueInquireablePolicies, inquire.NewInquireableSignaturePolicy(pol))
+		inquireablePolicies[string(vp)] = struct{}{}
+	}
+
+	if len(fallbackCollections) != 0 {
+		collectionPolicies := s.PoliciesByChaincode(channel, cc, fallbackCollections...)
+		if len(collectionPolicies) == 0 {
+			return nil, errors.Errorf("endorsement policy of chaincode %s not found", cc)
+		}
+		uniqueInquireablePolicies = append(uniqueInquireablePolicies, collectionPolicies...)
+	}
+	if chaincodeFallback {
+		chaincodePolicies := s.PoliciesByChaincode(channel, cc)
+		if len(chaincodePolicies) == 0 {
+			return nil, errors.Errorf("endorsement policy of chaincode %s not found", cc)
+		}
+		uniqueInquireablePolicies = append(uniqueInquireablePolicies, chaincodePolicies...)
+	}
+
+	return uniqueInquireablePolicies, nil
+}
+
+func keyString(cc string, key *protoext.WrittenKey) string {
+	if key.Collection == "" {
+		return cc + ":" + key.Key
+	}
+	return cc + ":" + key.Collection + ":" + key.Key
+}
+
+// LedgerGetter returns the ledger of a channel
+type LedgerGetter interface {
+	GetLedger(cid string) ledger.PeerLedger
+}
+
+// LedgerStateMetadataRetriever implements StateMetadataRetriever on top of the
+// ledgers of the peer
+type LedgerStateMetadataRetriever struct {
+	LedgerGetter
+}
+
+// StateMetadata returns the metadata of the given key of the namespace in the given channel.
+// If the collection isn't empty, the key is looked up by its hash in the collection.
+func (r *LedgerStateMetadataRetriever) StateMetadata(channel, namespace, collection, key string) (map[string][]byte, error) {
+	l := r.GetLedger(channel)
+	if l == nil {
+		return nil, errors.Errorf("channel %s doesn't exist", channel)
+	}
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed obtaining query executor")
+	}
+	defer qe.Done()
+
+	if collection == "" {
+		return qe.GetStateMetadata(namespace, key)
+	}
+	return qe.GetPrivateDataMetadataByHash(namespace, collection, util.ComputeStringHash(key))
+}
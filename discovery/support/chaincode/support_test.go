@@ -11,10 +11,17 @@ import (
 
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/msp"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/common/chaincode"
 	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/policies/inquire"
+	"github.com/hyperledger/fabric/core/ledger"
+	ledgermock "github.com/hyperledger/fabric/core/ledger/mock"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	peermock "github.com/hyperledger/fabric/core/peer/mock"
+	"github.com/hyperledger/fabric/discovery/protoext"
 	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,6 +33,15 @@ func (r *mockMetadataRetriever) Metadata(channel string, cc string, collections
 	return r.res
 }
 
+type mockStateMetadataRetriever map[string]map[string][]byte
+
+func (r mockStateMetadataRetriever) StateMetadata(channel, namespace, collection, key string) (map[string][]byte, error) {
+	if key == "error" {
+		return nil, errors.New("ledger error")
+	}
+	return r[collection+"/"+key], nil
+}
+
 func TestSupport(t *testing.T) {
 	emptySignaturePolicyEnvelope := &common.SignaturePolicyEnvelope{}
 	ccmd1 := &chaincode.Metadata{Policy: protoutil.MarshalOrPanic(emptySignaturePolicyEnvelope)}
@@ -82,7 +98,7 @@ func TestSupport(t *testing.T) {
 	for _, test := range tests {
 		test := test
 		t.Run(test.name, func(t *testing.T) {
-			sup := NewDiscoverySupport(&mockMetadataRetriever{res: test.input})
+			sup := NewDiscoverySupport(&mockMetadataRetriever{res: test.input}, nil)
 			res := sup.PoliciesByChaincode("", "", test.collNames...)
 			require.Equal(t, len(res), len(test.expectedReturn))
 			for i := 0; i < len(test.expectedReturn); i++ {
@@ -91,3 +107,139 @@ func TestSupport(t *testing.T) {
 		})
 	}
 }
+
+func TestPoliciesByKeys(t *testing.T) {
+	vpKey := pb.MetaDataKeys_VALIDATION_PARAMETER.String()
+	policy1 := &common.SignaturePolicyEnvelope{
+		Rule:       &common.SignaturePolicy{},
+		Identities: []*msp.MSPPrincipal{{Principal: []byte("principal-1")}},
+	}
+	policy2 := &common.SignaturePolicyEnvelope{
+		Rule:       &common.SignaturePolicy{},
+		Identities: []*msp.MSPPrincipal{{Principal: []byte("principal-2")}},
+	}
+	ccPolicy := &common.SignaturePolicyEnvelope{
+		Rule:       &common.SignaturePolicy{},
+		Identities: []*msp.MSPPrincipal{{Principal: []byte("principal-cc")}},
+	}
+	colPolicy := &common.SignaturePolicyEnvelope{
+		Rule:       &common.SignaturePolicy{},
+		Identities: []*msp.MSPPrincipal{{Principal: []byte("principal-col2")}},
+	}
+	ccmd := &chaincode.Metadata{
+		Policy:             protoutil.MarshalOrPanic(ccPolicy),
+		CollectionPolicies: map[string][]byte{"col2": protoutil.MarshalOrPanic(colPolicy)},
+	}
+	sup := NewDiscoverySupport(&mockMetadataRetriever{res: ccmd}, mockStateMetadataRetriever{
+		"/k1":        {vpKey: protoutil.MarshalOrPanic(policy1)},
+		"/k2":        {vpKey: protoutil.MarshalOrPanic(policy1)},
+		"col1/k1":    {vpKey: protoutil.MarshalOrPanic(policy2)},
+		"/other":     {"other": []byte("metadata")},
+		"/garbage":   {vpKey: []byte{1, 2, 3}},
+		"/emptyrule": {vpKey: protoutil.MarshalOrPanic(&common.SignaturePolicyEnvelope{Identities: policy1.Identities})},
+	})
+
+	t.Run("Keys with and without key-level policies", func(t *testing.T) {
+		res, err := sup.PoliciesByKeys("mychannel", "mycc",
+			&protoext.WrittenKey{Key: "k1"},
+			&protoext.WrittenKey{Key: "k2"},
+			&protoext.WrittenKey{Collection: "col1", Key: "k1"},
+			&protoext.WrittenKey{Key: "other"},
+			&protoext.WrittenKey{Key: "missing"},
+		)
+		require.NoError(t, err)
+		// Identical policies are returned only once, and the public keys
+		// without a key-level policy fall back to the chaincode policy
+		require.Len(t, res, 3)
+		require.Equal(t, inquire.NewInquireableSignaturePolicy(policy1).SatisfiedBy(), res[0].SatisfiedBy())
+		require.Equal(t, inquire.NewInquireableSignaturePolicy(policy2).SatisfiedBy(), res[1].SatisfiedBy())
+		require.Equal(t, inquire.NewInquireableSignaturePolicy(ccPolicy).SatisfiedBy(), res[2].SatisfiedBy())
+	})
+
+	t.Run("Only keys with key-level policies", func(t *testing.T) {
+		res, err := sup.PoliciesByKeys("mychannel", "mycc",
+			&protoext.WrittenKey{Key: "k1"},
+			&protoext.WrittenKey{Collection: "col1", Key: "k1"},
+		)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.Equal(t, inquire.NewInquireableSignaturePolicy(policy1).SatisfiedBy(), res[0].SatisfiedBy())
+		require.Equal(t, inquire.NewInquireableSignaturePolicy(policy2).SatisfiedBy(), res[1].SatisfiedBy())
+	})
+
+	t.Run("No key-level policies", func(t *testing.T) {
+		res, err := sup.PoliciesByKeys("mychannel", "mycc", &protoext.WrittenKey{Key: "other"})
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, inquire.NewInquireableSignaturePolicy(ccPolicy).SatisfiedBy(), res[0].SatisfiedBy())
+
+		// Private keys fall back to the policy of their collection, if it has one
+		res, err = sup.PoliciesByKeys("mychannel", "mycc",
+			&protoext.WrittenKey{Collection: "col2", Key: "missing"},
+			&protoext.WrittenKey{Collection: "col3", Key: "missing"},
+		)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		require.Equal(t, inquire.NewInquireableSignaturePolicy(colPolicy).SatisfiedBy(), res[0].SatisfiedBy())
+		require.Equal(t, inquire.NewInquireableSignaturePolicy(ccPolicy).SatisfiedBy(), res[1].SatisfiedBy())
+	})
+
+	t.Run("Chaincode not found", func(t *testing.T) {
+		sup := NewDiscoverySupport(&mockMetadataRetriever{}, mockStateMetadataRetriever{})
+		_, err := sup.PoliciesByKeys("mychannel", "mycc", &protoext.WrittenKey{Key: "other"})
+		require.EqualError(t, err, "endorsement policy of chaincode mycc not found")
+	})
+
+	t.Run("Ledger error", func(t *testing.T) {
+		_, err := sup.PoliciesByKeys("mychannel", "mycc", &protoext.WrittenKey{Collection: "col1", Key: "error"})
+		require.EqualError(t, err, "failed retrieving metadata of key mycc:col1:error: ledger error")
+	})
+
+	t.Run("Invalid validation parameter", func(t *testing.T) {
+		_, err := sup.PoliciesByKeys("mychannel", "mycc", &protoext.WrittenKey{Key: "garbage"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed unmarshaling validation parameter of key mycc:garbage")
+
+		_, err = sup.PoliciesByKeys("mychannel", "mycc", &protoext.WrittenKey{Key: "emptyrule"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid validation parameter of key mycc:emptyrule")
+	})
+}
+
+type ledgerGetter map[string]ledger.PeerLedger
+
+func (lg ledgerGetter) GetLedger(cid string) ledger.PeerLedger {
+	return lg[cid]
+}
+
+func TestLedgerStateMetadataRetriever(t *testing.T) {
+	qe := &ledgermock.QueryExecutor{}
+	qe.GetStateMetadataReturns(map[string][]byte{"public": nil}, nil)
+	qe.GetPrivateDataMetadataByHashReturns(map[string][]byte{"private": nil}, nil)
+	l := &peermock.PeerLedger{}
+	l.NewQueryExecutorReturns(qe, nil)
+	r := &LedgerStateMetadataRetriever{LedgerGetter: ledgerGetter{"mychannel": l}}
+
+	md, err := r.StateMetadata("mychannel", "mycc", "", "k1")
+	require.NoError(t, err)
+	require.Contains(t, md, "public")
+	ns, key := qe.GetStateMetadataArgsForCall(0)
+	require.Equal(t, "mycc", ns)
+	require.Equal(t, "k1", key)
+
+	md, err = r.StateMetadata("mychannel", "mycc", "col1", "k1")
+	require.NoError(t, err)
+	require.Contains(t, md, "private")
+	ns, coll, keyHash := qe.GetPrivateDataMetadataByHashArgsForCall(0)
+	require.Equal(t, "mycc", ns)
+	require.Equal(t, "col1", coll)
+	require.Equal(t, util.ComputeStringHash("k1"), keyHash)
+	require.Equal(t, 2, qe.DoneCallCount())
+
+	_, err = r.StateMetadata("otherchannel", "mycc", "", "k1")
+	require.EqualError(t, err, "channel otherchannel doesn't exist")
+
+	l.NewQueryExecutorReturns(nil, errors.New("ledger closed"))
+	_, err = r.StateMetadata("mychannel", "mycc", "", "k1")
+	require.EqualError(t, err, "failed obtaining query executor: ledger closed")
+}
@@ -43,6 +43,7 @@ import (
 	"github.com/hyperledger/fabric/discovery"
 	disc "github.com/hyperledger/fabric/discovery/client"
 	"github.com/hyperledger/fabric/discovery/endorsement"
+	discprotoext "github.com/hyperledger/fabric/discovery/protoext"
 	discsupport "github.com/hyperledger/fabric/discovery/support"
 	discacl "github.com/hyperledger/fabric/discovery/support/acl"
 	ccsupport "github.com/hyperledger/fabric/discovery/support/chaincode"
@@ -86,6 +87,13 @@ var (
 		Id:      []byte{43},
 		Policy:  protoutil.MarshalOrPanic(policyFromString("AND('Org1MSP.member', 'Org2MSP.member')")),
 	})
+
+	// Writing the key k1 of cc1 also requires an endorsement of Org2MSP
+	keyMetadata = stateMetadataRetriever{
+		"mychannel/cc1//k1": {
+			peer.MetaDataKeys_VALIDATION_PARAMETER.String(): protoutil.MarshalOrPanic(policyFromString("OR('Org2MSP.member')")),
+		},
+	}
 )
 
 func TestMain(m *testing.M) {
@@ -207,6 +215,38 @@ func TestGreenPath(t *testing.T) {
 		require.Equal(t, 1, len(endorsersByMSP["Org2MSP"]))
 	})
 
+	t.Run("Endorser chaincode with key-level endorsement policy", func(t *testing.T) {
+		cc := &ChaincodeCall{Name: "cc1"}
+		require.NoError(t, discprotoext.SetWrittenKeys(cc, &discprotoext.WrittenKey{Key: "k1"}, &discprotoext.WrittenKey{Key: "k2"}))
+		ccWithKeys := &ChaincodeInterest{Chaincodes: []*ChaincodeCall{cc}}
+		req, err := disc.NewRequest().OfChannel("mychannel").AddEndorsersQuery(ccWithKeys)
+		require.NoError(t, err)
+		res, err := client.Send(context.Background(), req, client.AuthInfo)
+		require.NoError(t, err)
+		endorsers, err := res.ForChannel("mychannel").Endorsers(ccWithKeys.Chaincodes, disc.NoFilter)
+		require.NoError(t, err)
+
+		endorsersByMSP := map[string][]string{}
+		for _, endorser := range endorsers {
+			endorsersByMSP[endorser.MSPID] = append(endorsersByMSP[endorser.MSPID], string(endorser.Identity))
+		}
+		// The policy of cc1 requires 2 peers from Org1MSP, the key-level
+		// policy of k1 adds 1 from Org2MSP and k2 has no key-level policy
+		require.Equal(t, 2, len(endorsersByMSP["Org1MSP"]))
+		require.Equal(t, 1, len(endorsersByMSP["Org2MSP"]))
+
+		// When only k1 is written, its key-level policy replaces the policy of cc1
+		require.NoError(t, discprotoext.SetWrittenKeys(cc, &discprotoext.WrittenKey{Key: "k1"}))
+		req, err = disc.NewRequest().OfChannel("mychannel").AddEndorsersQuery(ccWithKeys)
+		require.NoError(t, err)
+		res, err = client.Send(context.Background(), req, client.AuthInfo)
+		require.NoError(t, err)
+		endorsers, err = res.ForChannel("mychannel").Endorsers(ccWithKeys.Chaincodes, disc.NoFilter)
+		require.NoError(t, err)
+		require.Len(t, endorsers, 1)
+		require.Equal(t, "Org2MSP", endorsers[0].MSPID)
+	})
+
 	t.Run("Config query", func(t *testing.T) {
 		require.NoError(t, err)
 		res, err := client.Send(context.Background(), req, client.AuthInfo)
@@ -380,6 +420,14 @@ func newLSCCMetadataManager() *lsccMetadataManager {
 	}
 }
 
+// stateMetadataRetriever returns the metadata of keys, indexed by
+// channel/namespace/collection/key.
+type stateMetadataRetriever map[string]map[string][]byte
+
+func (r stateMetadataRetriever) StateMetadata(channel, namespace, collection, key string) (map[string][]byte, error) {
+	return r[channel+"/"+namespace+"/"+collection+"/"+key], nil
+}
+
 type principalEvaluator struct {
 	*discacl.DiscoverySupport
 	msp.MSPManager
@@ -444,7 +492,7 @@ func createSupport(t *testing.T, dir string, lsccMetadataManager *lsccMetadataMa
 		},
 	}
 
-	ccSup := ccsupport.NewDiscoverySupport(lsccMetadataManager)
+	ccSup := ccsupport.NewDiscoverySupport(lsccMetadataManager, keyMetadata)
 	ea := endorsement.NewEndorsementAnalyzer(gSup, ccSup, pe, lsccMetadataManager)
 
 	fakeConfigGetter := &mocks.ConfigGetter{}
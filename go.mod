@@ -28,7 +28,7 @@ require (
 	github.com/fsouza/go-dockerclient v1.4.1
 	github.com/go-kit/kit v0.8.0
 	github.com/golang/protobuf v1.3.3
-	github.com/golang/snappy v0.0.2 // indirect
+	github.com/golang/snappy v0.0.2
 	github.com/google/go-cmp v0.5.0 // indirect
 	github.com/gorilla/handlers v1.4.0
 	github.com/gorilla/mux v1.7.2
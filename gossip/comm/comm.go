@@ -50,6 +50,9 @@ type Comm interface {
 	// CloseConn closes a connection to a certain endpoint
 	CloseConn(peer *RemotePeer)
 
+	// Connections returns statistics of the open connections to remote peers
+	Connections() []ConnectionStats
+
 	// Stop stops the module
 	Stop()
 }
@@ -60,12 +63,26 @@ type RemotePeer struct {
 	PKIID    common.PKIidType
 }
 
+// ConnectionStats describes an open connection to a remote peer
+type ConnectionStats struct {
+	PKIID          common.PKIidType
+	Endpoint       string
+	Outbound       bool // whether the connection was initiated by this peer
+	QueuedMessages int  // messages waiting to be sent to the remote peer
+}
+
 // SendResult defines a result of a send to a remote peer
 type SendResult struct {
 	error
 	RemotePeer
 }
 
+// NewSendResult returns the result of sending a message to the given
+// remote peer, which failed if err isn't nil
+func NewSendResult(peer RemotePeer, err error) SendResult {
+	return SendResult{RemotePeer: peer, error: err}
+}
+
 // Error returns the error of the SendResult, or an empty string
 // if an error hasn't occurred
 func (sr SendResult) Error() string {
@@ -26,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 )
 
@@ -61,6 +62,10 @@ func NewCommInstance(s *grpc.Server, certs *common.TLSCertificates, idStore iden
 	peerIdentity api.PeerIdentityType, secureDialOpts api.PeerSecureDialOpts, sa api.SecurityAdvisor,
 	commMetrics *metrics.CommMetrics, config CommConfig, dialOpts ...grpc.DialOption) (Comm, error) {
 
+	if config.CompressionAlgorithm != "" && !IsValidCompressionAlgorithm(config.CompressionAlgorithm) {
+		return nil, errors.Errorf("unknown compression algorithm %s", config.CompressionAlgorithm)
+	}
+
 	commInst := &commImpl{
 		sa:              sa,
 		pubSub:          util.NewPubSub(),
@@ -83,6 +88,8 @@ func NewCommInstance(s *grpc.Server, certs *common.TLSCertificates, idStore iden
 		connTimeout:     config.ConnTimeout,
 		recvBuffSize:    config.RecvBuffSize,
 		sendBuffSize:    config.SendBuffSize,
+		compression:     config.CompressionAlgorithm,
+		compressionSize: config.CompressionThreshold,
 	}
 
 	connConfig := ConnConfig{
@@ -103,6 +110,11 @@ type CommConfig struct {
 	ConnTimeout  time.Duration // Connection timeout
 	RecvBuffSize int           // Buffer size of received messages
 	SendBuffSize int           // Buffer size of sending messages
+	// CompressionAlgorithm is the algorithm used to compress the payloads of blocks
+	// and private data sent to peers which support it
+	CompressionAlgorithm string
+	// CompressionThreshold is the size, in bytes, above which payloads are compressed
+	CompressionThreshold int
 }
 
 type commImpl struct {
@@ -129,6 +141,8 @@ type commImpl struct {
 	connTimeout     time.Duration
 	recvBuffSize    int
 	sendBuffSize    int
+	compression     string
+	compressionSize int
 }
 
 func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidType) (*connection, error) {
@@ -165,7 +179,7 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 		return nil, errors.WithStack(err)
 	}
 
-	ctx, cancel = context.WithCancel(context.Background())
+	ctx, cancel = context.WithCancel(withCompressionHeader(context.Background()))
 	if stream, err = cl.GossipStream(ctx); err == nil {
 		connInfo, err = c.authenticateRemotePeer(stream, true, false)
 		if err == nil {
@@ -196,6 +210,9 @@ func (c *commImpl) createConnection(endpoint string, expectedPKIID common.PKIidT
 			conn.info = connInfo
 			conn.logger = c.logger
 			conn.cancel = cancel
+			// The header of the remote peer has been received along with its connection message
+			header, _ := stream.Header()
+			conn.compressor = newCompressor(c.compression, c.compressionSize, header, c.metrics)
 
 			h := func(m *protoext.SignedGossipMessage) {
 				c.logger.Debug("Got message:", m)
@@ -371,6 +388,10 @@ func (c *commImpl) CloseConn(peer *RemotePeer) {
 	c.connStore.closeConnByPKIid(peer.PKIID)
 }
 
+func (c *commImpl) Connections() []ConnectionStats {
+	return c.connStore.stats()
+}
+
 func (c *commImpl) closeSubscriptions() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -564,6 +585,9 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	if c.isStopping() {
 		return fmt.Errorf("Shutting down")
 	}
+	if err := stream.SetHeader(compressionHeader()); err != nil {
+		c.logger.Debugf("Failed setting the header of the stream: %v", err)
+	}
 	connInfo, err := c.authenticateRemotePeer(stream, false, false)
 
 	if err == errProbe {
@@ -577,7 +601,8 @@ func (c *commImpl) GossipStream(stream proto.Gossip_GossipStreamServer) error {
 	}
 	c.logger.Debug("Servicing", extractRemoteAddress(stream))
 
-	conn := c.connStore.onConnected(stream, connInfo, c.metrics)
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	conn := c.connStore.onConnected(stream, connInfo, c.metrics, newCompressor(c.compression, c.compressionSize, md, c.metrics))
 
 	h := func(m *protoext.SignedGossipMessage) {
 		c.msgPublisher.DeMultiplex(&ReceivedMessageImpl{
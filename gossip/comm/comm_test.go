@@ -128,6 +128,12 @@ func (*naiveSecProvider) VerifyByChannel(_ common.ChannelID, _ api.PeerIdentityT
 func newCommInstanceOnlyWithMetrics(t *testing.T, commMetrics *metrics.CommMetrics, sec *naiveSecProvider,
 	gRPCServer *comm.GRPCServer, certs *common.TLSCertificates,
 	secureDialOpts api.PeerSecureDialOpts, dialOpts ...grpc.DialOption) Comm {
+	return newCommInstanceOnlyWithConfig(t, commMetrics, testCommConfig, sec, gRPCServer, certs, secureDialOpts, dialOpts...)
+}
+
+func newCommInstanceOnlyWithConfig(t *testing.T, commMetrics *metrics.CommMetrics, config CommConfig, sec *naiveSecProvider,
+	gRPCServer *comm.GRPCServer, certs *common.TLSCertificates,
+	secureDialOpts api.PeerSecureDialOpts, dialOpts ...grpc.DialOption) Comm {
 
 	_, portString, err := net.SplitHostPort(gRPCServer.Address())
 	require.NoError(t, err)
@@ -137,7 +143,7 @@ func newCommInstanceOnlyWithMetrics(t *testing.T, commMetrics *metrics.CommMetri
 	identityMapper := identity.NewIdentityMapper(sec, id, noopPurgeIdentity, sec)
 
 	commInst, err := NewCommInstance(gRPCServer.Server(), certs, identityMapper, id, secureDialOpts,
-		sec, commMetrics, testCommConfig, dialOpts...)
+		sec, commMetrics, config, dialOpts...)
 	require.NoError(t, err)
 
 	go func() {
@@ -885,6 +891,32 @@ func TestProbe(t *testing.T) {
 	require.Equal(t, api.PeerIdentityType(endpoint), id)
 }
 
+func TestConnections(t *testing.T) {
+	comm1, _ := newCommInstance(t, naiveSec)
+	defer comm1.Stop()
+	comm2, port2 := newCommInstance(t, naiveSec)
+	defer comm2.Stop()
+	require.Empty(t, comm1.Connections())
+
+	acceptChan := comm2.Accept(acceptAll)
+	comm1.Send(createGossipMsg(), remotePeer(port2))
+	select {
+	case <-acceptChan:
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "Didn't receive a message within a timely period")
+	}
+
+	stats := comm1.Connections()
+	require.Len(t, stats, 1)
+	require.Equal(t, remotePeer(port2).PKIID, stats[0].PKIID)
+	require.Equal(t, fmt.Sprintf("127.0.0.1:%d", port2), stats[0].Endpoint)
+	require.True(t, stats[0].Outbound)
+
+	stats = comm2.Connections()
+	require.Len(t, stats, 1)
+	require.False(t, stats[0].Outbound)
+}
+
 func TestPresumedDead(t *testing.T) {
 	comm1, _ := newCommInstance(t, naiveSec)
 	comm2, port2 := newCommInstance(t, naiveSec)
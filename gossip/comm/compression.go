@@ -0,0 +1,205 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/golang/snappy"
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// CompressionNone disables the compression of gossip payloads
+	CompressionNone = "none"
+	// CompressionGzip compresses gossip payloads with gzip
+	CompressionGzip = "gzip"
+	// CompressionSnappy compresses gossip payloads with the framed snappy format
+	CompressionSnappy = "snappy"
+
+	// DefCompressionThreshold is the default size, in bytes, above which
+	// gossip payloads are compressed
+	DefCompressionThreshold = 64 * 1024
+
+	// compressionMetadataKey is the gRPC metadata key used by peers during the
+	// handshake to advertise the compression algorithms they can decompress.
+	// Peers which do not advertise any algorithm are never sent compressed payloads.
+	compressionMetadataKey = "gossip-compression"
+
+	// maxDecompressedPayloadSize bounds the size of a decompressed payload, and
+	// matches the default maximum size of a gRPC message received by the peer.
+	maxDecompressedPayloadSize = 100 * 1024 * 1024
+)
+
+// The compressed payloads are told apart from the uncompressed ones by the
+// header of their format. Neither header can start a marshaled GossipMessage,
+// as the wire type of their first byte is not a valid protobuf wire type.
+var (
+	gzipHeader   = []byte{0x1f, 0x8b}
+	snappyHeader = []byte("\xff\x06\x00\x00sNaPpY")
+)
+
+// supportedCompressionAlgorithms are the algorithms this peer can decompress.
+var supportedCompressionAlgorithms = []string{CompressionGzip, CompressionSnappy}
+
+// IsValidCompressionAlgorithm returns whether the given compression algorithm
+// is supported.
+func IsValidCompressionAlgorithm(algorithm string) bool {
+	if algorithm == CompressionNone {
+		return true
+	}
+	for _, a := range supportedCompressionAlgorithms {
+		if a == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// compressionHeader returns the metadata advertising the compression
+// algorithms this peer can decompress.
+func compressionHeader() metadata.MD {
+	md := metadata.MD{}
+	md.Append(compressionMetadataKey, supportedCompressionAlgorithms...)
+	return md
+}
+
+// withCompressionHeader returns a context advertising the compression
+// algorithms this peer can decompress to the remote peer.
+func withCompressionHeader(ctx context.Context) context.Context {
+	return metadata.NewOutgoingContext(ctx, compressionHeader())
+}
+
+// compressedMessageType returns the type of the message used to label the
+// compression metrics, or an empty string if messages of its type are never
+// compressed.
+func compressedMessageType(msg *proto.GossipMessage) string {
+	switch {
+	case msg.GetDataMsg() != nil:
+		return "data"
+	case msg.GetPrivateData() != nil:
+		return "private_data"
+	case msg.GetPrivateRes() != nil:
+		return "private_response"
+	case msg.GetStateResponse() != nil:
+		return "state_response"
+	default:
+		return ""
+	}
+}
+
+// compressor compresses the payloads of the blocks and private data sent
+// to a remote peer which can decompress them.
+type compressor struct {
+	algorithm string
+	threshold int
+	metrics   *metrics.CommMetrics
+}
+
+// newCompressor returns a compressor for the configured algorithm if the
+// remote peer advertised it in the given metadata, otherwise nil.
+func newCompressor(algorithm string, threshold int, remote metadata.MD, commMetrics *metrics.CommMetrics) *compressor {
+	if algorithm == "" || algorithm == CompressionNone {
+		return nil
+	}
+	for _, a := range remote.Get(compressionMetadataKey) {
+		if a == algorithm {
+			return &compressor{
+				algorithm: algorithm,
+				threshold: threshold,
+				metrics:   commMetrics,
+			}
+		}
+	}
+	return nil
+}
+
+// compress returns the envelope of the message with its payload compressed,
+// or the envelope of the message itself if the message isn't worth
+// compressing. The signature still covers the uncompressed payload.
+func (c *compressor) compress(msg *protoext.SignedGossipMessage) *proto.Envelope {
+	envelope := msg.Envelope
+	if envelope == nil || len(envelope.Payload) < c.threshold {
+		return envelope
+	}
+	msgType := compressedMessageType(msg.GossipMessage)
+	if msgType == "" {
+		return envelope
+	}
+
+	payload, err := compressPayload(c.algorithm, envelope.Payload)
+	if err != nil || len(payload) >= len(envelope.Payload) {
+		payload = envelope.Payload
+	}
+	c.metrics.UncompressedBytes.With("message_type", msgType).Add(float64(len(envelope.Payload)))
+	c.metrics.CompressedBytes.With("message_type", msgType).Add(float64(len(payload)))
+
+	return &proto.Envelope{
+		Payload:        payload,
+		Signature:      envelope.Signature,
+		SecretEnvelope: envelope.SecretEnvelope,
+	}
+}
+
+func compressPayload(algorithm string, payload []byte) ([]byte, error) {
+	buff := &bytes.Buffer{}
+	var w io.WriteCloser
+	switch algorithm {
+	case CompressionGzip:
+		w = gzip.NewWriter(buff)
+	case CompressionSnappy:
+		w = snappy.NewBufferedWriter(buff)
+	default:
+		return nil, errors.Errorf("unknown compression algorithm %s", algorithm)
+	}
+	if _, err := w.Write(payload); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buff.Bytes(), nil
+}
+
+// decompressEnvelope returns the envelope with its payload decompressed,
+// or the envelope itself if its payload isn't compressed.
+func decompressEnvelope(envelope *proto.Envelope) (*proto.Envelope, error) {
+	var r io.Reader
+	switch {
+	case bytes.HasPrefix(envelope.Payload, gzipHeader):
+		gr, err := gzip.NewReader(bytes.NewReader(envelope.Payload))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed decompressing gzip payload")
+		}
+		r = gr
+	case bytes.HasPrefix(envelope.Payload, snappyHeader):
+		r = snappy.NewReader(bytes.NewReader(envelope.Payload))
+	default:
+		return envelope, nil
+	}
+
+	payload, err := ioutil.ReadAll(io.LimitReader(r, maxDecompressedPayloadSize+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed decompressing payload")
+	}
+	if len(payload) > maxDecompressedPayloadSize {
+		return nil, errors.Errorf("decompressed payload exceeds %d bytes", maxDecompressedPayloadSize)
+	}
+	return &proto.Envelope{
+		Payload:        payload,
+		Signature:      envelope.Signature,
+		SecretEnvelope: envelope.SecretEnvelope,
+	}, nil
+}
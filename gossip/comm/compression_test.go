@@ -0,0 +1,175 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/gossip/metrics/mocks"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+)
+
+func createBlockMsg(t *testing.T, size int) *protoext.SignedGossipMessage {
+	msg := &protoext.SignedGossipMessage{
+		GossipMessage: &proto.GossipMessage{
+			Tag: proto.GossipMessage_CHAN_AND_ORG,
+			Content: &proto.GossipMessage_DataMsg{
+				DataMsg: &proto.DataMessage{
+					Payload: &proto.Payload{
+						SeqNum: 1,
+						Data:   bytes.Repeat([]byte("block"), size/5),
+					},
+				},
+			},
+		},
+	}
+	_, err := msg.Sign(naiveSec.Sign)
+	require.NoError(t, err)
+	return msg
+}
+
+func TestCompressDecompress(t *testing.T) {
+	for _, algorithm := range []string{CompressionGzip, CompressionSnappy} {
+		t.Run(algorithm, func(t *testing.T) {
+			testMetricProvider := mocks.TestUtilConstructMetricProvider()
+			commMetrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).CommMetrics
+			c := &compressor{algorithm: algorithm, threshold: 1024, metrics: commMetrics}
+
+			msg := createBlockMsg(t, 100*1024)
+			envelope := c.compress(msg)
+			require.True(t, len(envelope.Payload) < len(msg.Envelope.Payload))
+			require.Equal(t, msg.Envelope.Signature, envelope.Signature)
+
+			require.Equal(t, 1, testMetricProvider.FakeUncompressedBytes.AddCallCount())
+			require.Equal(t, []string{"message_type", "data"}, testMetricProvider.FakeUncompressedBytes.WithArgsForCall(0))
+			require.Equal(t, float64(len(msg.Envelope.Payload)), testMetricProvider.FakeUncompressedBytes.AddArgsForCall(0))
+			require.Equal(t, 1, testMetricProvider.FakeCompressedBytes.AddCallCount())
+			require.Equal(t, []string{"message_type", "data"}, testMetricProvider.FakeCompressedBytes.WithArgsForCall(0))
+			require.Equal(t, float64(len(envelope.Payload)), testMetricProvider.FakeCompressedBytes.AddArgsForCall(0))
+
+			decompressed, err := decompressEnvelope(envelope)
+			require.NoError(t, err)
+			require.Equal(t, msg.Envelope.Payload, decompressed.Payload)
+			require.Equal(t, msg.Envelope.Signature, decompressed.Signature)
+
+			received, err := protoext.EnvelopeToGossipMessage(decompressed)
+			require.NoError(t, err)
+			require.NoError(t, received.Verify(nil, func(_ []byte, signature, message []byte) error {
+				return naiveSec.Verify(nil, signature, message)
+			}))
+			require.Equal(t, msg.GetDataMsg().Payload.Data, received.GetDataMsg().Payload.Data)
+		})
+	}
+}
+
+func TestCompressSkipped(t *testing.T) {
+	testMetricProvider := mocks.TestUtilConstructMetricProvider()
+	commMetrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).CommMetrics
+	c := &compressor{algorithm: CompressionGzip, threshold: 1024, metrics: commMetrics}
+
+	t.Run("below threshold", func(t *testing.T) {
+		msg := createBlockMsg(t, 100)
+		require.Equal(t, msg.Envelope, c.compress(msg))
+	})
+
+	t.Run("not a block nor private data", func(t *testing.T) {
+		msg, err := protoext.NoopSign(&proto.GossipMessage{
+			Content: &proto.GossipMessage_AliveMsg{
+				AliveMsg: &proto.AliveMessage{
+					Membership: &proto.Member{
+						Metadata: bytes.Repeat([]byte{1}, 2048),
+					},
+				},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, msg.Envelope, c.compress(msg))
+	})
+
+	require.Equal(t, 0, testMetricProvider.FakeUncompressedBytes.AddCallCount())
+
+	t.Run("uncompressed envelope", func(t *testing.T) {
+		msg := createBlockMsg(t, 100)
+		envelope, err := decompressEnvelope(msg.Envelope)
+		require.NoError(t, err)
+		require.Equal(t, msg.Envelope, envelope)
+	})
+
+	t.Run("corrupted payload", func(t *testing.T) {
+		_, err := decompressEnvelope(&proto.Envelope{Payload: append(gzipHeader, 1, 2, 3)})
+		require.Error(t, err)
+	})
+}
+
+func TestNewCompressor(t *testing.T) {
+	remote := compressionHeader()
+	require.Nil(t, newCompressor(CompressionNone, 1024, remote, disabledMetrics))
+	require.Nil(t, newCompressor(CompressionGzip, 1024, nil, disabledMetrics))
+	require.Nil(t, newCompressor(CompressionGzip, 1024, metadata.Pairs(compressionMetadataKey, CompressionSnappy), disabledMetrics))
+	require.Equal(t, &compressor{
+		algorithm: CompressionGzip,
+		threshold: 1024,
+		metrics:   disabledMetrics,
+	}, newCompressor(CompressionGzip, 1024, remote, disabledMetrics))
+
+	require.True(t, IsValidCompressionAlgorithm(CompressionNone))
+	require.True(t, IsValidCompressionAlgorithm(CompressionSnappy))
+	require.False(t, IsValidCompressionAlgorithm("lz4"))
+}
+
+func TestCompressionNegotiated(t *testing.T) {
+	newCompressingComm := func(commMetrics *metrics.CommMetrics, algorithm string) (Comm, int) {
+		config := testCommConfig
+		config.CompressionAlgorithm = algorithm
+		config.CompressionThreshold = 1024
+		port, gRPCServer, certs, secureDialOpts, dialOpts := util.CreateGRPCLayer()
+		comm := newCommInstanceOnlyWithConfig(t, commMetrics, config, naiveSec, gRPCServer, certs, secureDialOpts, dialOpts...)
+		return comm, port
+	}
+
+	testMetricProvider := mocks.TestUtilConstructMetricProvider()
+	comm1, port1 := newCompressingComm(metrics.NewGossipMetrics(testMetricProvider.FakeProvider).CommMetrics, CompressionGzip)
+	comm2, port2 := newCompressingComm(disabledMetrics, CompressionNone)
+	defer comm1.Stop()
+	defer comm2.Stop()
+
+	m1 := comm1.Accept(acceptAll)
+	m2 := comm2.Accept(acceptAll)
+
+	receive := func(msgs <-chan protoext.ReceivedMessage) protoext.ReceivedMessage {
+		select {
+		case msg := <-msgs:
+			return msg
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "timed out waiting for message")
+			return nil
+		}
+	}
+
+	// comm1 compresses the blocks it sends, and comm2 decompresses them
+	msg := createBlockMsg(t, 100*1024)
+	comm1.Send(msg, remotePeer(port2))
+	received := receive(m2).GetGossipMessage()
+	require.Equal(t, msg.Envelope.Payload, received.Envelope.Payload)
+	require.Equal(t, msg.Envelope.Signature, received.Envelope.Signature)
+	require.Equal(t, 1, testMetricProvider.FakeCompressedBytes.AddCallCount())
+	require.True(t, testMetricProvider.FakeCompressedBytes.AddArgsForCall(0) < float64(len(msg.Envelope.Payload)))
+
+	// comm2 doesn't compress what it sends over the same connection
+	msg = createBlockMsg(t, 100*1024)
+	comm2.Send(msg, remotePeer(port1))
+	received = receive(m1).GetGossipMessage()
+	require.Equal(t, msg.Envelope.Payload, received.Envelope.Payload)
+	require.Equal(t, 1, testMetricProvider.FakeCompressedBytes.AddCallCount())
+}
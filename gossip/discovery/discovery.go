@@ -155,10 +155,16 @@ type Discovery interface {
 	// GetMembership returns the alive members in the view
 	GetMembership() []NetworkMember
 
+	// DeadMembers returns the members in the view that are considered dead
+	DeadMembers() []NetworkMember
+
 	// InitiateSync makes the instance ask a given number of peers
 	// for their membership information
 	InitiateSync(peerNum int)
 
+	// Purge removes a member from the view, whether it is considered alive or dead
+	Purge(PKIID common.PKIidType)
+
 	// Connect makes this instance to connect to a remote instance
 	// The identifier param is a function that can be used to identify
 	// the peer, and to assert its PKI-ID, whether its in the peer's org or not,
@@ -15,6 +15,7 @@ import (
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	proto "github.com/hyperledger/fabric-protos-go/gossip"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/gossip/msgstore"
@@ -72,6 +73,7 @@ type gossipDiscoveryImpl struct {
 
 	bootstrapPeers    []string
 	anchorPeerTracker AnchorPeerTracker
+	clock             clock.Clock
 }
 
 type DiscoveryConfig struct {
@@ -82,14 +84,17 @@ type DiscoveryConfig struct {
 	MaxConnectionAttempts        int
 	MsgExpirationFactor          int
 	BootstrapPeers               []string
+	// Clock is the clock membership is timed by. The wall clock is used if nil.
+	Clock clock.Clock
 }
 
 // NewDiscoveryService returns a new discovery service with the comm module passed and the crypto service passed
 func NewDiscoveryService(self NetworkMember, comm CommService, crypt CryptoService, disPol DisclosurePolicy,
 	config DiscoveryConfig, anchorPeerTracker AnchorPeerTracker, logger util.Logger) Discovery {
+	clk := util.ClockOrDefault(config.Clock)
 	d := &gossipDiscoveryImpl{
 		self:             self,
-		incTime:          uint64(time.Now().UnixNano()),
+		incTime:          uint64(clk.Now().UnixNano()),
 		seqNum:           uint64(0),
 		deadLastTS:       make(map[string]*timestamp),
 		aliveLastTS:      make(map[string]*timestamp),
@@ -102,7 +107,7 @@ func NewDiscoveryService(self NetworkMember, comm CommService, crypt CryptoServi
 		toDieChan:        make(chan struct{}),
 		logger:           logger,
 		disclosurePolicy: disPol,
-		pubsub:           util.NewPubSub(),
+		pubsub:           util.NewPubSubWithClock(clk),
 
 		aliveTimeInterval:            config.AliveTimeInterval,
 		aliveExpirationTimeout:       config.AliveExpirationTimeout,
@@ -113,6 +118,7 @@ func NewDiscoveryService(self NetworkMember, comm CommService, crypt CryptoServi
 
 		bootstrapPeers:    config.BootstrapPeers,
 		anchorPeerTracker: anchorPeerTracker,
+		clock:             clk,
 	}
 
 	d.validateSelfConfig()
@@ -155,7 +161,7 @@ func (d *gossipDiscoveryImpl) Connect(member NetworkMember, id identifier) {
 					return
 				}
 				d.logger.Warningf("Could not connect to %v : %v", member, err)
-				time.Sleep(d.reconnectInterval)
+				d.clock.Sleep(d.reconnectInterval)
 				continue
 			}
 			peer := &NetworkMember{
@@ -221,7 +227,7 @@ func (d *gossipDiscoveryImpl) sendUntilAcked(peer *NetworkMember, message *proto
 		if _, timeoutErr := sub.Listen(); timeoutErr == nil {
 			return
 		}
-		time.Sleep(d.reconnectInterval)
+		d.clock.Sleep(d.reconnectInterval)
 	}
 }
 
@@ -555,6 +561,11 @@ func (d *gossipDiscoveryImpl) handleAliveMessage(m *protoext.SignedGossipMessage
 	// else, ignore the message because it is too old
 }
 
+// Purge removes a member from the view, whether it is considered alive or dead
+func (d *gossipDiscoveryImpl) Purge(id common.PKIidType) {
+	d.purge(id)
+}
+
 func (d *gossipDiscoveryImpl) purge(id common.PKIidType) {
 	d.logger.Infof("Purging %s from membership", id)
 	d.lock.Lock()
@@ -598,7 +609,7 @@ func (d *gossipDiscoveryImpl) resurrectMember(am *protoext.SignedGossipMessage,
 	member := am.GetAliveMsg().Membership
 	pkiID := member.PkiId
 	d.aliveLastTS[string(pkiID)] = &timestamp{
-		lastSeen: time.Now(),
+		lastSeen: d.clock.Now(),
 		seqNum:   t.SeqNum,
 		incTime:  tsToTime(t.IncNum),
 	}
@@ -644,7 +655,7 @@ func (d *gossipDiscoveryImpl) periodicalReconnectToDead() {
 
 		wg.Wait()
 		d.logger.Debug("Sleeping", d.reconnectInterval)
-		time.Sleep(d.reconnectInterval)
+		d.clock.Sleep(d.reconnectInterval)
 	}
 }
 
@@ -698,7 +709,7 @@ func (d *gossipDiscoveryImpl) periodicalCheckAlive() {
 	defer d.logger.Debug("Stopped")
 
 	for !d.toDie() {
-		time.Sleep(d.aliveExpirationCheckInterval)
+		d.clock.Sleep(d.aliveExpirationCheckInterval)
 		dead := d.getDeadMembers()
 		if len(dead) > 0 {
 			d.logger.Debugf("Got %v dead members: %v", len(dead), dead)
@@ -747,7 +758,7 @@ func (d *gossipDiscoveryImpl) getDeadMembers() []common.PKIidType {
 
 	dead := []common.PKIidType{}
 	for id, last := range d.aliveLastTS {
-		elapsedNonAliveTime := time.Since(last.lastSeen)
+		elapsedNonAliveTime := d.clock.Since(last.lastSeen)
 		if elapsedNonAliveTime > d.aliveExpirationTimeout {
 			d.logger.Warning("Haven't heard from", []byte(id), "for", elapsedNonAliveTime)
 			dead = append(dead, common.PKIidType(id))
@@ -761,7 +772,7 @@ func (d *gossipDiscoveryImpl) periodicalSendAlive() {
 
 	for !d.toDie() {
 		d.logger.Debug("Sleeping", d.aliveTimeInterval)
-		time.Sleep(d.aliveTimeInterval)
+		d.clock.Sleep(d.aliveTimeInterval)
 		if d.aliveMembership.Size() == 0 {
 			d.logger.Debugf("Empty membership, no one to send a heartbeat to")
 			continue
@@ -866,7 +877,7 @@ func (d *gossipDiscoveryImpl) learnExistingMembers(aliveArr []*protoext.SignedGo
 			// update existing aliveness data
 			alive := d.aliveLastTS[string(am.Membership.PkiId)]
 			alive.incTime = tsToTime(am.Timestamp.IncNum)
-			alive.lastSeen = time.Now()
+			alive.lastSeen = d.clock.Now()
 			alive.seqNum = am.Timestamp.SeqNum
 
 			if am := d.aliveMembership.MsgByID(m.GetAliveMsg().Membership.PkiId); am == nil {
@@ -895,7 +906,7 @@ func (d *gossipDiscoveryImpl) learnNewMembers(aliveMembers []*protoext.SignedGos
 		}
 		d.aliveLastTS[string(am.GetAliveMsg().Membership.PkiId)] = &timestamp{
 			incTime:  tsToTime(am.GetAliveMsg().Timestamp.IncNum),
-			lastSeen: time.Now(),
+			lastSeen: d.clock.Now(),
 			seqNum:   am.GetAliveMsg().Timestamp.SeqNum,
 		}
 
@@ -909,7 +920,7 @@ func (d *gossipDiscoveryImpl) learnNewMembers(aliveMembers []*protoext.SignedGos
 		}
 		d.deadLastTS[string(dm.GetAliveMsg().Membership.PkiId)] = &timestamp{
 			incTime:  tsToTime(dm.GetAliveMsg().Timestamp.IncNum),
-			lastSeen: time.Now(),
+			lastSeen: d.clock.Now(),
 			seqNum:   dm.GetAliveMsg().Timestamp.SeqNum,
 		}
 
@@ -967,6 +978,31 @@ func (d *gossipDiscoveryImpl) GetMembership() []NetworkMember {
 
 }
 
+func (d *gossipDiscoveryImpl) DeadMembers() []NetworkMember {
+	if d.toDie() {
+		return []NetworkMember{}
+	}
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	response := []NetworkMember{}
+	for _, m := range d.deadMembership.ToSlice() {
+		member := m.GetAliveMsg()
+		var internalEndpoint string
+		if netMem := d.id2Member[string(member.Membership.PkiId)]; netMem != nil {
+			internalEndpoint = netMem.InternalEndpoint
+		}
+		response = append(response, NetworkMember{
+			PKIid:            member.Membership.PkiId,
+			Endpoint:         member.Membership.Endpoint,
+			Metadata:         member.Membership.Metadata,
+			InternalEndpoint: internalEndpoint,
+			Envelope:         m.Envelope,
+		})
+	}
+	return response
+}
+
 func tsToTime(ts uint64) time.Time {
 	return time.Unix(int64(0), int64(ts))
 }
@@ -1079,7 +1115,7 @@ func newAliveMsgStore(d *gossipDiscoveryImpl) *aliveMsgStore {
 	}
 
 	s := &aliveMsgStore{
-		MessageStore: msgstore.NewMessageStoreExpirable(policy, trigger, aliveMsgTTL, externalLock, externalUnlock, callback),
+		MessageStore: msgstore.NewMessageStoreExpirable(policy, trigger, aliveMsgTTL, externalLock, externalUnlock, callback, d.clock),
 	}
 	return s
 }
@@ -899,6 +899,15 @@ func TestExpiration(t *testing.T) {
 
 	assertMembership(t, instances[:len(instances)-2], nodeNum-3)
 
+	// The stopped instances are now considered dead
+	for _, inst := range instances[:len(instances)-2] {
+		var deadEndpoints []string
+		for _, member := range inst.DeadMembers() {
+			deadEndpoints = append(deadEndpoints, member.Endpoint)
+		}
+		require.ElementsMatch(t, []string{"localhost:2614", "localhost:2615"}, deadEndpoints)
+	}
+
 	stopAction := &sync.WaitGroup{}
 	for i, inst := range instances {
 		if i+2 == nodeNum {
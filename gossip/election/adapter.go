@@ -8,6 +8,7 @@ package election
 
 import (
 	"bytes"
+	"math"
 	"sync"
 	"time"
 
@@ -58,6 +59,40 @@ type gossip interface {
 
 	// IsInMyOrg checks whether a network member is in this peer's org
 	IsInMyOrg(member discovery.NetworkMember) bool
+
+	// SelfMembershipInfo returns the peer's membership information
+	SelfMembershipInfo() discovery.NetworkMember
+}
+
+// PinnedLeaderPriority is the election priority of the pinned leader of a channel
+const PinnedLeaderPriority = math.MaxInt32
+
+// Priorities assigns election priorities to the peers of a channel by their
+// endpoints. All the peers of an organization should be given the same
+// priorities, in order for them to agree on the preferred leader.
+type Priorities struct {
+	// ByEndpoint maps the endpoints of peers to their election priority.
+	// Peers which aren't listed have a priority of 0
+	ByEndpoint map[string]int
+	// PinnedLeader is the endpoint of the peer preferred as the leader
+	// over all other peers
+	PinnedLeader string
+}
+
+// of returns the election priority of the given member
+func (p Priorities) of(member discovery.NetworkMember) int {
+	endpoints := []string{member.InternalEndpoint, member.Endpoint}
+	for _, endpoint := range endpoints {
+		if endpoint != "" && endpoint == p.PinnedLeader {
+			return PinnedLeaderPriority
+		}
+	}
+	for _, endpoint := range endpoints {
+		if priority, exists := p.ByEndpoint[endpoint]; exists && endpoint != "" {
+			return priority
+		}
+	}
+	return 0
 }
 
 type adapterImpl struct {
@@ -71,14 +106,15 @@ type adapterImpl struct {
 
 	logger util.Logger
 
-	doneCh   chan struct{}
-	stopOnce *sync.Once
-	metrics  *metrics.ElectionMetrics
+	doneCh     chan struct{}
+	stopOnce   *sync.Once
+	metrics    *metrics.ElectionMetrics
+	priorities Priorities
 }
 
 // NewAdapter creates new leader election adapter
 func NewAdapter(gossip gossip, pkiid common.PKIidType, channel common.ChannelID,
-	metrics *metrics.ElectionMetrics) LeaderElectionAdapter {
+	metrics *metrics.ElectionMetrics, priorities Priorities) LeaderElectionAdapter {
 	return &adapterImpl{
 		gossip:    gossip,
 		selfPKIid: pkiid,
@@ -90,9 +126,10 @@ func NewAdapter(gossip gossip, pkiid common.PKIidType, channel common.ChannelID,
 
 		logger: util.GetLogger(util.ElectionLogger, ""),
 
-		doneCh:   make(chan struct{}),
-		stopOnce: &sync.Once{},
-		metrics:  metrics,
+		doneCh:     make(chan struct{}),
+		stopOnce:   &sync.Once{},
+		metrics:    metrics,
+		priorities: priorities,
 	}
 }
 
@@ -170,6 +207,20 @@ func (ai *adapterImpl) ReportMetrics(isLeader bool) {
 	ai.metrics.Declaration.With("channel", string(ai.channel)).Set(leadershipBit)
 }
 
+// Priority returns the election priority of the peer with the given ID,
+// or 0 if the peer isn't known
+func (ai *adapterImpl) Priority(id peerID) int {
+	if bytes.Equal(id, ai.selfPKIid) {
+		return ai.priorities.of(ai.gossip.SelfMembershipInfo())
+	}
+	for _, peer := range ai.gossip.PeersOfChannel(ai.channel) {
+		if bytes.Equal(peer.PKIid, id) {
+			return ai.priorities.of(peer)
+		}
+	}
+	return 0
+}
+
 func (ai *adapterImpl) Stop() {
 	stopFunc := func() {
 		close(ai.doneCh)
@@ -41,7 +41,7 @@ func TestNewAdapter(t *testing.T) {
 	peersCluster.addPeer("peer0", mockGossip)
 
 	NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"),
-		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics)
+		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics, Priorities{})
 }
 
 func TestAdapterImpl_CreateMessage(t *testing.T) {
@@ -53,7 +53,7 @@ func TestAdapterImpl_CreateMessage(t *testing.T) {
 	mockGossip := newGossip("peer0", selfNetworkMember, nil)
 
 	adapter := NewAdapter(mockGossip, selfNetworkMember.PKIid, []byte("channel0"),
-		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics)
+		metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics, Priorities{})
 	msg := adapter.CreateMessage(true)
 
 	if !protoext.IsLeadershipMsg(msg.(*msgImpl).msg) {
@@ -261,6 +261,10 @@ func (g *peerMockGossip) putToAcceptors(msg *proto.GossipMessage) {
 
 }
 
+func (g *peerMockGossip) SelfMembershipInfo() discovery.NetworkMember {
+	return *g.member
+}
+
 func (g *peerMockGossip) IsInMyOrg(member discovery.NetworkMember) bool {
 	var myOrg, memberOrg string
 	var exists bool
@@ -323,7 +327,7 @@ func createCluster(pki2org map[string]string, peers ...int) (*clusterOfPeers, ma
 
 		mockGossip := newGossip(peerEndpoint, peerMember, pki2org)
 		adapter := NewAdapter(mockGossip, peerMember.PKIid, []byte("channel0"),
-			metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics)
+			metrics.NewGossipMetrics(&disabled.Provider{}).ElectionMetrics, Priorities{})
 		adapters[peerEndpoint] = adapter.(*adapterImpl)
 		cluster.addPeer(peerEndpoint, mockGossip)
 	}
@@ -337,7 +341,7 @@ func TestReportMetrics(t *testing.T) {
 	electionMetrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).ElectionMetrics
 
 	mockGossip := newGossip("", &discovery.NetworkMember{}, nil)
-	adapter := NewAdapter(mockGossip, nil, []byte("channel0"), electionMetrics)
+	adapter := NewAdapter(mockGossip, nil, []byte("channel0"), electionMetrics, Priorities{})
 
 	adapter.ReportMetrics(true)
 
@@ -362,3 +366,26 @@ func TestReportMetrics(t *testing.T) {
 	)
 
 }
+
+func TestAdapterImpl_Priority(t *testing.T) {
+	_, adapters := createCluster(nil, 0, 1, 2, 3)
+	for _, adapter := range adapters {
+		adapter.priorities = Priorities{
+			ByEndpoint:   map[string]int{"Peer1": 10, "Peer2": 20},
+			PinnedLeader: "Peer3",
+		}
+	}
+
+	adapter := adapters["Peer1"]
+	require.Equal(t, 0, adapter.Priority(peerID{byte(0)}))
+	require.Equal(t, 10, adapter.Priority(peerID{byte(1)}))
+	require.Equal(t, 20, adapter.Priority(peerID{byte(2)}))
+	require.Equal(t, PinnedLeaderPriority, adapter.Priority(peerID{byte(3)}))
+	require.Equal(t, 0, adapter.Priority(peerID{byte(4)}), "unknown peers have no priority")
+
+	// a peer is matched by its internal endpoint too
+	require.Equal(t, 30, Priorities{ByEndpoint: map[string]int{"internal:7051": 30}}.of(discovery.NetworkMember{
+		Endpoint:         "external:7051",
+		InternalEndpoint: "internal:7051",
+	}))
+}
@@ -18,7 +18,7 @@ import (
 
 // Gossip leader election module
 // Algorithm properties:
-// - Peers break symmetry by comparing IDs
+// - Peers break symmetry by comparing priorities, and then IDs
 // - Each peer is either a leader or a follower,
 //   and the aim is to have exactly 1 leader if the membership view
 //   is the same for all peers
@@ -50,9 +50,13 @@ import (
 //		If you are the leader:
 //			Broadcast leadership declaration
 //			If a leadership declaration was received from
-// 			a peer with a lower ID,
+// 			a better candidate,
 //			become a follower
 //		Else, you're a follower:
+//			If a leadership declaration was received from
+//			a peer with a lower priority, which wasn't
+//			elected because you yielded:
+//				become a leader
 //			If haven't received a leadership declaration within
 // 			a time threshold:
 //				set leaderKnown to false
@@ -63,9 +67,12 @@ import (
 //	If received a leadership declaration:
 //		return
 //	Iterate over all proposal messages collected.
-// 	If a proposal message from a peer with an ID lower
+// 	If a proposal message from a better candidate
 // 	than yourself was received, return.
 //	Else, declare yourself a leader
+//
+// A peer is a better candidate than another if it has a higher priority,
+// or the same priority and a lower ID.
 
 // LeaderElectionAdapter is used by the leader election module
 // to send and receive messages and to get membership information
@@ -84,6 +91,12 @@ type LeaderElectionAdapter interface {
 
 	// ReportMetrics sends a report to the metrics server about a leadership status
 	ReportMetrics(isLeader bool)
+
+	// Priority returns the election priority of the peer with the given ID.
+	// Peers with a higher priority are preferred as leaders. Peers that
+	// aren't known, e.g. peers not yet seen alive, have a priority of 0,
+	// so a peer may not be preempted until its membership is known
+	Priority(id peerID) int
 }
 
 type leadershipCallback func(isLeader bool)
@@ -93,6 +106,10 @@ type LeaderElectionService interface {
 	// IsLeader returns whether this peer is a leader or not
 	IsLeader() bool
 
+	// Leader returns the ID of the peer currently known to be the leader,
+	// or nil if no leader is known
+	Leader() []byte
+
 	// Stop stops the LeaderElectionService
 	Stop()
 
@@ -154,6 +171,7 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 		adapter:       adapter,
 		stopChan:      make(chan struct{}),
 		interruptChan: make(chan struct{}, 1),
+		preemptChan:   make(chan struct{}, 1),
 		logger:        util.GetLogger(util.ElectionLogger, ""),
 		callback:      noopCallback,
 		config:        config,
@@ -174,6 +192,7 @@ type leaderElectionSvcImpl struct {
 	sync.Mutex
 	stopChan      chan struct{}
 	interruptChan chan struct{}
+	preemptChan   chan struct{}
 	stopWG        sync.WaitGroup
 	isLeader      int32
 	leaderExists  int32
@@ -184,6 +203,14 @@ type leaderElectionSvcImpl struct {
 	callback      leadershipCallback
 	yieldTimer    *time.Timer
 	config        ElectionConfig
+	// lastLeader is the sender of the last leadership declaration,
+	// received at lastDeclaration
+	lastLeader      peerID
+	lastDeclaration time.Time
+	// yieldedTo is the leader elected while we yielded, which we
+	// do not preempt even if its priority is lower than ours.
+	// It is cleared once the leadership changes hands
+	yieldedTo peerID
 }
 
 func (le *leaderElectionSvcImpl) start() {
@@ -224,13 +251,27 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 	if msg.IsProposal() {
 		le.proposals.Add(string(msg.SenderID()))
 	} else if msg.IsDeclaration() {
+		le.lastLeader = msg.SenderID()
+		le.lastDeclaration = time.Now()
+		if !bytes.Equal(msg.SenderID(), le.yieldedTo) {
+			le.yieldedTo = nil
+		}
 		atomic.StoreInt32(&le.leaderExists, int32(1))
 		if le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if le.isBetterCandidate(msg.SenderID(), le.id) && le.IsLeader() {
 			le.stopBeingLeader()
 		}
+		// A leader with a lower priority than ours is preempted by declaring ourselves,
+		// unless we are yielding or it was elected because we yielded
+		if !le.IsLeader() && !le.isYielding() && !bytes.Equal(msg.SenderID(), le.yieldedTo) &&
+			le.adapter.Priority(le.id) > le.adapter.Priority(msg.SenderID()) {
+			select {
+			case le.preemptChan <- struct{}{}:
+			default:
+			}
+		}
 	} else {
 		// We shouldn't get here
 		le.logger.Error("Got a message that's not a proposal and not a declaration")
@@ -291,6 +332,11 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	if le.isYielding() {
 		return
 	}
+	// The leader we yielded to is gone, whoever is elected now
+	// may be preempted
+	le.Lock()
+	le.yieldedTo = nil
+	le.Unlock()
 	// Propose ourselves as a leader
 	le.propose()
 	// Collect other proposals
@@ -310,7 +356,7 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 	// for being a leader
 	for _, o := range le.proposals.ToArray() {
 		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
+		if le.isBetterCandidate(peerID(id), le.id) {
 			return
 		}
 	}
@@ -334,9 +380,22 @@ func (le *leaderElectionSvcImpl) follower() {
 
 	le.proposals.Clear()
 	atomic.StoreInt32(&le.leaderExists, int32(0))
+	// A preemption signaled before we became a follower might be stale,
+	// the next declaration of a lower priority leader signals it again
+	select {
+	case <-le.preemptChan:
+	default:
+	}
 	le.adapter.ReportMetrics(false)
 	select {
 	case <-time.After(le.config.LeaderAliveThreshold):
+	case <-le.preemptChan:
+		if le.isYielding() {
+			return
+		}
+		le.logger.Info(le.id, ": Preempting a leader with a lower priority")
+		le.beLeader()
+		atomic.StoreInt32(&le.leaderExists, int32(1))
 	case <-le.stopChan:
 	}
 }
@@ -383,6 +442,16 @@ func (le *leaderElectionSvcImpl) isAlive(id peerID) bool {
 	return false
 }
 
+// isBetterCandidate returns whether the peer of the given id is a better
+// candidate for being a leader than the other peer
+func (le *leaderElectionSvcImpl) isBetterCandidate(id, other peerID) bool {
+	priority, otherPriority := le.adapter.Priority(id), le.adapter.Priority(other)
+	if priority != otherPriority {
+		return priority > otherPriority
+	}
+	return bytes.Compare(id, other) < 0
+}
+
 func (le *leaderElectionSvcImpl) isLeaderExists() bool {
 	return atomic.LoadInt32(&le.leaderExists) == int32(1)
 }
@@ -394,6 +463,22 @@ func (le *leaderElectionSvcImpl) IsLeader() bool {
 	return isLeader
 }
 
+// Leader returns the ID of the peer currently known to be the leader,
+// or nil if no leader is known
+func (le *leaderElectionSvcImpl) Leader() []byte {
+	if le.IsLeader() {
+		return le.id
+	}
+	le.Lock()
+	defer le.Unlock()
+	// a leader declares its leadership periodically, so a declaration
+	// older than the alive threshold no longer designates a leader
+	if le.lastLeader == nil || time.Since(le.lastDeclaration) > le.config.LeaderAliveThreshold {
+		return nil
+	}
+	return le.lastLeader
+}
+
 func (le *leaderElectionSvcImpl) beLeader() {
 	le.logger.Info(le.id, ": Becoming a leader")
 	atomic.StoreInt32(&le.isLeader, int32(1))
@@ -425,6 +510,7 @@ func (le *leaderElectionSvcImpl) stopYielding() {
 	defer le.Unlock()
 	atomic.StoreInt32(&le.yield, int32(0))
 	le.yieldTimer.Stop()
+	le.yieldedTo = le.lastLeader
 }
 
 // Yield relinquishes the leadership until a new leader is elected,
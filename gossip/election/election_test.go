@@ -59,6 +59,7 @@ type peer struct {
 	leaderFromCallback bool
 	callbackInvoked    bool
 	lock               sync.RWMutex
+	priorities         map[string]int
 	LeaderElectionService
 }
 
@@ -125,6 +126,12 @@ func (p *peer) ReportMetrics(isLeader bool) {
 	p.Mock.Called(isLeader)
 }
 
+func (p *peer) Priority(id peerID) int {
+	p.sharedLock.RLock()
+	defer p.sharedLock.RUnlock()
+	return p.priorities[string(id)]
+}
+
 func (p *peer) leaderCallback(isLeader bool) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -145,11 +152,15 @@ func (p *peer) isCallbackInvoked() bool {
 }
 
 func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
+	return createPeersWithPriorities(spawnInterval, nil, ids...)
+}
+
+func createPeersWithPriorities(spawnInterval time.Duration, priorities map[string]int, ids ...int) []*peer {
 	peers := make([]*peer, len(ids))
 	peerMap := make(map[string]*peer)
 	l := &sync.RWMutex{}
 	for i, id := range ids {
-		p := createPeer(id, peerMap, l)
+		p := createPeerWithPriorities(id, peerMap, l, func(mock.Arguments) {}, priorities)
 		if spawnInterval != 0 {
 			time.Sleep(spawnInterval)
 		}
@@ -159,9 +170,13 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 }
 
 func createPeerWithCostumeMetrics(id int, peerMap map[string]*peer, l *sync.RWMutex, f func(mock.Arguments)) *peer {
+	return createPeerWithPriorities(id, peerMap, l, f, nil)
+}
+
+func createPeerWithPriorities(id int, peerMap map[string]*peer, l *sync.RWMutex, f func(mock.Arguments), priorities map[string]int) *peer {
 	idStr := fmt.Sprintf("p%d", id)
 	c := make(chan Msg, 100)
-	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
+	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false, priorities: priorities}
 	p.On("ReportMetrics", mock.Anything).Run(f)
 	config := ElectionConfig{
 		StartupGracePeriod:       testStartupGracePeriod,
@@ -253,6 +268,24 @@ func TestInitPeersAtSameTime(t *testing.T) {
 	waitForBoolFunc(t, peers[len(peers)-1].isLeaderFromCallback, true, "Leadership callback result is wrong for ", peers[len(peers)-1].id)
 }
 
+func TestLeader(t *testing.T) {
+	// Scenario: Peers are spawned at the same time and a leader is elected.
+	// Afterwards, the leader stops.
+	// expected outcome: all peers know the leader until its declarations expire
+	peers := createPeers(0, 2, 1, 0)
+	waitForLeaderElection(t, peers)
+	for _, p := range peers {
+		waitForBoolFunc(t, func() bool { return string(p.Leader()) == "p0" }, true, "Leader is wrong for ", p.id)
+	}
+
+	peers[2].Stop()
+	waitForBoolFunc(t, func() bool { return string(peers[0].Leader()) != "p0" }, true, "Leader hasn't expired for ", peers[0].id)
+
+	for _, p := range peers[:2] {
+		p.Stop()
+	}
+}
+
 func TestInitPeersStartAtIntervals(t *testing.T) {
 	// Scenario: Peers are spawned one by one in a slow rate
 	// expected outcome: the first peer is the leader although its ID is highest
@@ -261,6 +294,26 @@ func TestInitPeersStartAtIntervals(t *testing.T) {
 	require.True(t, peers[0].IsLeader())
 }
 
+func TestInitPeersWithPriorities(t *testing.T) {
+	// Scenario: Peers are spawned at the same time, and some of them are given a priority
+	// expected outcome: the peer with the highest priority is the leader, although its ID isn't the lowest
+	peers := createPeersWithPriorities(0, map[string]int{"p2": 10, "p3": 5}, 3, 2, 1, 0)
+	leaders := waitForLeaderElection(t, peers)
+	require.Equal(t, []string{"p2"}, leaders)
+	waitForBoolFunc(t, peers[1].isLeaderFromCallback, true, "Leadership callback result is wrong for ", peers[1].id)
+}
+
+func TestPreemptionByHigherPriority(t *testing.T) {
+	// Scenario: Peers are spawned one by one in a slow rate, and the last one has a higher priority
+	// expected outcome: the last peer takes over the leadership from the first peer
+	peers := createPeersWithPriorities(testStartupGracePeriod+testLeadershipDeclarationInterval, map[string]int{"p0": 10}, 3, 2, 1, 0)
+	waitForBoolFunc(t, peers[3].IsLeader, true, "Leadership wasn't preempted by ", peers[3].id)
+	waitForBoolFunc(t, peers[0].IsLeader, false, "Leadership wasn't relinquished by ", peers[0].id)
+	leaders := waitForLeaderElection(t, peers)
+	require.Equal(t, []string{"p0"}, leaders)
+	waitForBoolFunc(t, peers[0].isLeaderFromCallback, false, "Leadership callback result is wrong for ", peers[0].id)
+}
+
 func TestStop(t *testing.T) {
 	// Scenario: peers are spawned at the same time
 	// and then are stopped. We count the number of Gossip() invocations they invoke
@@ -388,6 +441,50 @@ func TestYield(t *testing.T) {
 	waitForBoolFunc(t, ensureP0isNotAleader, true)
 }
 
+func TestYieldWithPriority(t *testing.T) {
+	// Scenario: Peers spawn and the peer with the highest priority is elected.
+	// After a while, the leader yields.
+	// Expected outcome: a new leader is elected, and the old leader
+	// doesn't preempt it although it has a higher priority
+	peers := createPeersWithPriorities(0, map[string]int{"p3": 10}, 0, 1, 2, 3)
+	leaders := waitForLeaderElection(t, peers)
+	require.Equal(t, []string{"p3"}, leaders)
+	peers[3].Yield()
+
+	ensureP3isNotAleader := func() bool {
+		leaders := waitForLeaderElection(t, peers)
+		return len(leaders) == 1 && leaders[0] != "p3"
+	}
+	waitForBoolFunc(t, ensureP3isNotAleader, true)
+	time.Sleep(testLeaderAliveThreshold * 2)
+	// After a while, p3 doesn't preempt the new leader
+	waitForBoolFunc(t, ensureP3isNotAleader, true)
+}
+
+func TestPreemptAfterLeadershipChange(t *testing.T) {
+	// Scenario: p3 yielded and p0 was elected in its stead, then p1 took over.
+	// Expected outcome: p3 doesn't preempt p0, but preempts p1
+	p := &peer{id: "p3", sharedLock: &sync.RWMutex{}, priorities: map[string]int{"p3": 10}}
+	le := &leaderElectionSvcImpl{
+		id:            peerID("p3"),
+		proposals:     util.NewSet(),
+		adapter:       p,
+		interruptChan: make(chan struct{}, 1),
+		preemptChan:   make(chan struct{}, 1),
+		logger:        util.GetLogger(util.ElectionLogger, ""),
+		callback:      noopCallback,
+		yieldedTo:     peerID("p0"),
+	}
+
+	le.handleMessage(&msg{sender: "p0"})
+	require.Len(t, le.preemptChan, 0)
+	require.Equal(t, peerID("p0"), le.yieldedTo)
+
+	le.handleMessage(&msg{sender: "p1"})
+	require.Len(t, le.preemptChan, 1)
+	require.Nil(t, le.yieldedTo)
+}
+
 func TestYieldSinglePeer(t *testing.T) {
 	// Scenario: spawn a single peer and have it yield.
 	// Ensure it recovers its leadership after a while.
@@ -11,6 +11,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"github.com/hyperledger/fabric/gossip/util"
 )
 
@@ -87,6 +88,7 @@ type PullEngine struct {
 	digestWaitTime   time.Duration
 	requestWaitTime  time.Duration
 	responseWaitTime time.Duration
+	clock            clock.Clock
 }
 
 // PullEngineConfig is the configuration required to initialize a new pull engine
@@ -94,6 +96,9 @@ type PullEngineConfig struct {
 	DigestWaitTime   time.Duration
 	RequestWaitTime  time.Duration
 	ResponseWaitTime time.Duration
+	// Clock is the clock the pull rounds are timed by.
+	// The wall clock is used if nil
+	Clock clock.Clock
 }
 
 // NewPullEngineWithFilter creates an instance of a PullEngine with a certain sleep time
@@ -115,11 +120,12 @@ func NewPullEngineWithFilter(participant PullAdapter, sleepTime time.Duration, d
 		digestWaitTime:     config.DigestWaitTime,
 		requestWaitTime:    config.RequestWaitTime,
 		responseWaitTime:   config.ResponseWaitTime,
+		clock:              util.ClockOrDefault(config.Clock),
 	}
 
 	go func() {
 		for !engine.toDie() {
-			time.Sleep(sleepTime)
+			engine.clock.Sleep(sleepTime)
 			if engine.toDie() {
 				return
 			}
@@ -183,7 +189,7 @@ func (engine *PullEngine) initiatePull() {
 		engine.Hello(peer, nonce)
 	}
 
-	time.AfterFunc(engine.digestWaitTime, func() {
+	util.AfterFunc(engine.clock, engine.digestWaitTime, func() {
 		engine.processIncomingDigests()
 	})
 }
@@ -211,7 +217,7 @@ func (engine *PullEngine) processIncomingDigests() {
 		engine.SendReq(dest, seqsToReq, engine.peers2nonces[dest])
 	}
 
-	time.AfterFunc(engine.responseWaitTime, engine.endPull)
+	util.AfterFunc(engine.clock, engine.responseWaitTime, engine.endPull)
 }
 
 func (engine *PullEngine) endPull() {
@@ -266,7 +272,7 @@ func (engine *PullEngine) Remove(seqs ...string) {
 func (engine *PullEngine) OnHello(nonce uint64, context interface{}) {
 	engine.incomingNONCES.Add(nonce)
 
-	time.AfterFunc(engine.requestWaitTime, func() {
+	util.AfterFunc(engine.clock, engine.requestWaitTime, func() {
 		engine.incomingNONCES.Remove(nonce)
 	})
 
@@ -11,6 +11,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"github.com/pkg/errors"
 )
 
@@ -35,7 +36,8 @@ type batchingEmitter interface {
 // burstSize: a threshold that triggers a forwarding because of message count
 // latency: the maximum delay that each message can be stored without being forwarded
 // cb: a callback that is called in order for the forwarding to take place
-func newBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emitBatchCallback) batchingEmitter {
+// clk: the clock the periodic forwarding is timed by
+func newBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emitBatchCallback, clk clock.Clock) batchingEmitter {
 	if iterations < 0 {
 		panic(errors.Errorf("Got a negative iterations number"))
 	}
@@ -48,6 +50,7 @@ func newBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emi
 		lock:       &sync.Mutex{},
 		buff:       make([]*batchedMessage, 0),
 		stopFlag:   int32(0),
+		clock:      clk,
 	}
 
 	if iterations != 0 {
@@ -59,7 +62,7 @@ func newBatchingEmitter(iterations, burstSize int, latency time.Duration, cb emi
 
 func (p *batchingEmitterImpl) periodicEmit() {
 	for !p.toDie() {
-		time.Sleep(p.delay)
+		p.clock.Sleep(p.delay)
 		p.lock.Lock()
 		p.emit()
 		p.lock.Unlock()
@@ -107,6 +110,7 @@ type batchingEmitterImpl struct {
 	lock       *sync.Mutex
 	buff       []*batchedMessage
 	stopFlag   int32
+	clock      clock.Clock
 }
 
 type batchedMessage struct {
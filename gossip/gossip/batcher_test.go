@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/stretchr/testify/require"
 )
@@ -21,7 +22,7 @@ func init() {
 }
 
 func TestBatchingEmitterAddAndSize(t *testing.T) {
-	emitter := newBatchingEmitter(1, 10, time.Second, func(a []interface{}) {})
+	emitter := newBatchingEmitter(1, 10, time.Second, func(a []interface{}) {}, clock.NewClock())
 	defer emitter.Stop()
 	emitter.Add(1)
 	emitter.Add(2)
@@ -36,7 +37,7 @@ func TestBatchingEmitterStop(t *testing.T) {
 		atomic.AddInt32(&disseminationAttempts, int32(1))
 	}
 
-	emitter := newBatchingEmitter(10, 1, time.Duration(100)*time.Millisecond, cb)
+	emitter := newBatchingEmitter(10, 1, time.Duration(100)*time.Millisecond, cb, clock.NewClock())
 	emitter.Add(1)
 	time.Sleep(time.Duration(100) * time.Millisecond)
 	emitter.Stop()
@@ -52,7 +53,7 @@ func TestBatchingEmitterExpiration(t *testing.T) {
 		atomic.AddInt32(&disseminationAttempts, int32(1))
 	}
 
-	emitter := newBatchingEmitter(10, 1, time.Duration(10)*time.Millisecond, cb)
+	emitter := newBatchingEmitter(10, 1, time.Duration(10)*time.Millisecond, cb, clock.NewClock())
 	defer emitter.Stop()
 
 	emitter.Add(1)
@@ -78,7 +79,7 @@ func TestBatchingEmitterCounter(t *testing.T) {
 		}
 	}
 
-	emitter := newBatchingEmitter(5, 100, time.Duration(500)*time.Millisecond, cb)
+	emitter := newBatchingEmitter(5, 100, time.Duration(500)*time.Millisecond, cb, clock.NewClock())
 	defer emitter.Stop()
 
 	for i := 1; i <= 5; i++ {
@@ -104,7 +105,7 @@ func TestBatchingEmitterBurstSizeCap(t *testing.T) {
 	cb := func(a []interface{}) {
 		atomic.AddInt32(&disseminationAttempts, int32(1))
 	}
-	emitter := newBatchingEmitter(1, 10, time.Duration(800)*time.Millisecond, cb)
+	emitter := newBatchingEmitter(1, 10, time.Duration(800)*time.Millisecond, cb, clock.NewClock())
 	defer emitter.Stop()
 
 	for i := 0; i < 50; i++ {
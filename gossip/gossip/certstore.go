@@ -132,8 +132,8 @@ func (cs *certStore) createIdentityMessage() (*protoext.SignedGossipMessage, err
 	return sMsg, errors.WithStack(err)
 }
 
-func (cs *certStore) suspectPeers(isSuspected api.PeerSuspector) {
-	cs.idMapper.SuspectPeers(isSuspected)
+func (cs *certStore) suspectPeers(isSuspected api.PeerSuspector) []identity.InvalidIdentity {
+	return cs.idMapper.SuspectPeers(isSuspected)
 }
 
 func (cs *certStore) stop() {
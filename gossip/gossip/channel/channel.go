@@ -15,6 +15,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	proto "github.com/hyperledger/fabric-protos-go/gossip"
 	common_utils "github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -50,6 +51,14 @@ type Config struct {
 	RequestWaitTime             time.Duration
 	ResponseWaitTime            time.Duration
 	MsgExpirationTimeout        time.Duration
+	// Labels are published along with the properties of the peer, such as its data center.
+	Labels map[string]string
+	// Version and Capabilities are the version of the peer and the capabilities it supports,
+	// published along with its properties if the version is set.
+	Version      string
+	Capabilities map[string][]string
+	// Clock is the clock the channel is timed by. The wall clock is used if nil.
+	Clock clock.Clock
 }
 
 // GossipChannel defines an object that deals with all channel-related messages
@@ -95,6 +104,9 @@ type GossipChannel interface {
 	// LeaveChannel makes the peer leave the channel
 	LeaveChannel()
 
+	// EvictPeer removes the state info of the given peer from the channel
+	EvictPeer(pkiID common.PKIidType)
+
 	// Stop stops the channel's activity
 	Stop()
 }
@@ -155,13 +167,14 @@ type gossipChannel struct {
 	chainID                   common.ChannelID
 	blocksPuller              pull.Mediator
 	logger                    util.Logger
-	stateInfoPublishScheduler *time.Ticker
-	stateInfoRequestScheduler *time.Ticker
+	stateInfoPublishScheduler clock.Ticker
+	stateInfoRequestScheduler clock.Ticker
 	memFilter                 *membershipFilter
 	ledgerHeight              uint64
 	incTime                   uint64
 	leftChannel               int32
 	membershipTracker         *membershipTracker
+	clock                     clock.Clock
 }
 
 type membershipFilter struct {
@@ -188,18 +201,20 @@ func (mf *membershipFilter) GetMembership() []discovery.NetworkMember {
 func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.MessageCryptoService,
 	channelID common.ChannelID, adapter Adapter, joinMsg api.JoinChannelMessage,
 	metrics *metrics.MembershipMetrics, logger util.Logger) GossipChannel {
+	clk := util.ClockOrDefault(adapter.GetConf().Clock)
 	gc := &gossipChannel{
-		incTime:                   uint64(time.Now().UnixNano()),
+		incTime:                   uint64(clk.Now().UnixNano()),
 		selfOrg:                   org,
 		pkiID:                     pkiID,
 		mcs:                       mcs,
 		Adapter:                   adapter,
 		stopChan:                  make(chan struct{}, 1),
 		shouldGossipStateInfo:     int32(0),
-		stateInfoPublishScheduler: time.NewTicker(adapter.GetConf().PublishStateInfoInterval),
-		stateInfoRequestScheduler: time.NewTicker(adapter.GetConf().RequestStateInfoInterval),
+		stateInfoPublishScheduler: clk.NewTicker(adapter.GetConf().PublishStateInfoInterval),
+		stateInfoRequestScheduler: clk.NewTicker(adapter.GetConf().RequestStateInfoInterval),
 		orgs:                      []api.OrgIdentityType{},
 		chainID:                   channelID,
+		clock:                     clk,
 	}
 
 	if logger == nil {
@@ -223,7 +238,7 @@ func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.M
 	}, gc.GetConf().BlockExpirationInterval, nil, nil, func(m interface{}) {
 		gc.logger.Debugf("Removing %s from the message store", seqNumFromMsg(m))
 		gc.blocksPuller.Remove(seqNumFromMsg(m))
-	})
+	}, clk)
 
 	hashPeerExpiredInMembership := func(o interface{}) bool {
 		pkiID := o.(*protoext.SignedGossipMessage).GetStateInfo().PkiId
@@ -271,7 +286,7 @@ func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.M
 		}
 		return true
 	}
-	gc.stateInfoMsgStore = newStateInfoCache(gc.GetConf().StateInfoCacheSweepInterval, hashPeerExpiredInMembership, verifyStateInfoMsg)
+	gc.stateInfoMsgStore = newStateInfoCache(gc.GetConf().StateInfoCacheSweepInterval, hashPeerExpiredInMembership, verifyStateInfoMsg, clk)
 
 	// Setup a plain state info message at startup, just to have all required fields populated
 	// when this gossip channel is created
@@ -281,21 +296,21 @@ func NewGossipChannel(pkiID common.PKIidType, org api.OrgIdentityType, mcs api.M
 	ttl := adapter.GetConf().MsgExpirationTimeout
 	pol := protoext.NewGossipMessageComparator(0)
 
-	gc.leaderMsgStore = msgstore.NewMessageStoreExpirable(pol, msgstore.Noop, ttl, nil, nil, nil)
+	gc.leaderMsgStore = msgstore.NewMessageStoreExpirable(pol, msgstore.Noop, ttl, nil, nil, nil, clk)
 
 	gc.ConfigureChannel(joinMsg)
 
 	// Periodically publish state info
-	go gc.periodicalInvocation(gc.publishStateInfo, gc.stateInfoPublishScheduler.C)
+	go gc.periodicalInvocation(gc.publishStateInfo, gc.stateInfoPublishScheduler.C())
 	// Periodically request state info
-	go gc.periodicalInvocation(gc.requestStateInfo, gc.stateInfoRequestScheduler.C)
+	go gc.periodicalInvocation(gc.requestStateInfo, gc.stateInfoRequestScheduler.C())
 
-	ticker := time.NewTicker(gc.GetConf().TimeForMembershipTracker)
+	ticker := clk.NewTicker(gc.GetConf().TimeForMembershipTracker)
 	gc.membershipTracker = &membershipTracker{
 		getPeersToTrack: gc.GetPeers,
 		report:          gc.reportMembershipChanges,
 		stopChan:        make(chan struct{}, 1),
-		tickerChannel:   ticker.C,
+		tickerChannel:   ticker.C(),
 		metrics:         metrics,
 		chainID:         channelID,
 	}
@@ -357,6 +372,11 @@ func (gc *gossipChannel) LeaveChannel() {
 	atomic.StoreInt32(&gc.shouldGossipStateInfo, int32(1))
 }
 
+// EvictPeer removes the state info of the given peer from the channel
+func (gc *gossipChannel) EvictPeer(pkiID common.PKIidType) {
+	gc.stateInfoMsgStore.evict(pkiID)
+}
+
 func (gc *gossipChannel) hasLeftChannel() bool {
 	return atomic.LoadInt32(&gc.leftChannel) == 1
 }
@@ -458,6 +478,7 @@ func (gc *gossipChannel) createBlockPuller() pull.Mediator {
 			DigestWaitTime:   gc.GetConf().DigestWaitTime,
 			RequestWaitTime:  gc.GetConf().RequestWaitTime,
 			ResponseWaitTime: gc.GetConf().ResponseWaitTime,
+			Clock:            gc.clock,
 		},
 	}
 	seqNumFromMsg := func(msg *protoext.SignedGossipMessage) string {
@@ -966,7 +987,7 @@ func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*pro
 		PkiId:       gc.pkiID,
 		Timestamp: &proto.PeerTime{
 			IncNum: gc.incTime,
-			SeqNum: uint64(time.Now().UnixNano()),
+			SeqNum: uint64(gc.clock.Now().UnixNano()),
 		},
 		Properties: &proto.Properties{
 			LeftChannel:  leftChannel,
@@ -974,6 +995,16 @@ func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*pro
 			Chaincodes:   chaincodes,
 		},
 	}
+	if labels := gc.GetConf().Labels; len(labels) > 0 {
+		if err := protoext.SetLabels(stateInfMsg.Properties, labels); err != nil {
+			gc.logger.Warningf("Failed setting labels %v: %v", labels, err)
+		}
+	}
+	if conf := gc.GetConf(); conf.Version != "" {
+		if err := protoext.SetCapabilities(stateInfMsg.Properties, conf.Version, conf.Capabilities); err != nil {
+			gc.logger.Warningf("Failed setting capabilities of version %s: %v", conf.Version, err)
+		}
+	}
 	m := &proto.GossipMessage{
 		Nonce: 0,
 		Tag:   proto.GossipMessage_CHAN_OR_ORG,
@@ -985,7 +1016,7 @@ func (gc *gossipChannel) updateProperties(ledgerHeight uint64, chaincodes []*pro
 	gc.updateStateInfo(m)
 }
 
-func newStateInfoCache(sweepInterval time.Duration, hasExpired func(interface{}) bool, verifyFunc membershipPredicate) *stateInfoCache {
+func newStateInfoCache(sweepInterval time.Duration, hasExpired func(interface{}) bool, verifyFunc membershipPredicate, clk clock.Clock) *stateInfoCache {
 	membershipStore := util.NewMembershipStore()
 	pol := protoext.NewGossipMessageComparator(0)
 
@@ -1005,7 +1036,7 @@ func newStateInfoCache(sweepInterval time.Duration, hasExpired func(interface{})
 			select {
 			case <-s.stopChan:
 				return
-			case <-time.After(sweepInterval):
+			case <-clk.After(sweepInterval):
 				s.Purge(hasExpired)
 			}
 		}
@@ -1063,6 +1094,13 @@ func (cache *stateInfoCache) delete(msg *protoext.SignedGossipMessage) {
 	cache.Remove(msg.GetStateInfo().PkiId)
 }
 
+func (cache *stateInfoCache) evict(pkiID common.PKIidType) {
+	cache.Purge(func(o interface{}) bool {
+		return bytes.Equal(pkiID, o.(*protoext.SignedGossipMessage).GetStateInfo().PkiId)
+	})
+	cache.Remove(pkiID)
+}
+
 func (cache *stateInfoCache) Stop() {
 	cache.stopChan <- struct{}{}
 }
@@ -332,6 +332,57 @@ func TestSelf(t *testing.T) {
 	require.Equal(t, gMsg.GetStateInfo().PkiId, []byte("1"))
 }
 
+func TestSelfLabels(t *testing.T) {
+	cs := &cryptoService{}
+	conf := conf
+	conf.Labels = map[string]string{"datacenter": "dc1"}
+	adapter := new(gossipAdapterMock)
+	adapter.On("GetConf").Return(conf)
+	adapter.On("GetMembership").Return([]discovery.NetworkMember{})
+	adapter.On("GetOrgOfPeer", mock.Anything).Return(orgInChannelA)
+	adapter.On("Gossip", mock.Anything)
+	gc := NewGossipChannel(pkiIDInOrg1, orgInChannelA, cs, channelA, adapter, &joinChanMsg{}, disabledMetrics, nil)
+	defer gc.Stop()
+
+	gc.UpdateLedgerHeight(1)
+	labels, err := protoext.GetLabels(gc.Self().GetStateInfo().Properties)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"datacenter": "dc1"}, labels)
+
+	// Labels survive subsequent updates of the properties
+	gc.UpdateChaincodes([]*proto.Chaincode{{Name: "mycc"}})
+	labels, err = protoext.GetLabels(gc.Self().GetStateInfo().Properties)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"datacenter": "dc1"}, labels)
+	require.Equal(t, "mycc", gc.Self().GetStateInfo().Properties.Chaincodes[0].Name)
+}
+
+func TestSelfCapabilities(t *testing.T) {
+	cs := &cryptoService{}
+	conf := conf
+	conf.Labels = map[string]string{"datacenter": "dc1"}
+	conf.Version = "3.1.0"
+	conf.Capabilities = map[string][]string{"Channel": {"V3_0", "V3_1"}, "Application": {"V2_0"}}
+	adapter := new(gossipAdapterMock)
+	adapter.On("GetConf").Return(conf)
+	adapter.On("GetMembership").Return([]discovery.NetworkMember{})
+	adapter.On("GetOrgOfPeer", mock.Anything).Return(orgInChannelA)
+	adapter.On("Gossip", mock.Anything)
+	gc := NewGossipChannel(pkiIDInOrg1, orgInChannelA, cs, channelA, adapter, &joinChanMsg{}, disabledMetrics, nil)
+	defer gc.Stop()
+
+	gc.UpdateLedgerHeight(1)
+	pc, err := protoext.GetCapabilities(gc.Self().GetStateInfo().Properties)
+	require.NoError(t, err)
+	require.Equal(t, "3.1.0", pc.Version)
+	require.Equal(t, []string{"V3_0", "V3_1"}, pc.Channel)
+	require.Equal(t, []string{"V2_0"}, pc.Application)
+
+	labels, err := protoext.GetLabels(gc.Self().GetStateInfo().Properties)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"datacenter": "dc1"}, labels)
+}
+
 func TestMsgStoreNotExpire(t *testing.T) {
 	cs := &cryptoService{}
 
@@ -40,6 +40,15 @@ func (cs *channelState) stop() {
 	}
 }
 
+// evictPeer removes the state info of the given peer from all channels
+func (cs *channelState) evictPeer(pkiID common.PKIidType) {
+	cs.RLock()
+	defer cs.RUnlock()
+	for _, gc := range cs.channels {
+		gc.EvictPeer(pkiID)
+	}
+}
+
 func (cs *channelState) isStopping() bool {
 	return atomic.LoadInt32(&cs.stopping) == int32(1)
 }
@@ -132,6 +141,10 @@ func (ga *gossipAdapterImpl) GetConf() channel.Config {
 		RequestWaitTime:             ga.conf.RequestWaitTime,
 		ResponseWaitTime:            ga.conf.ResponseWaitTime,
 		MsgExpirationTimeout:        ga.conf.MsgExpirationTimeout,
+		Labels:                      ga.conf.Labels,
+		Version:                     ga.conf.Version,
+		Capabilities:                ga.conf.Capabilities,
+		Clock:                       ga.clock,
 	}
 }
 
@@ -11,12 +11,16 @@ import (
 	"strconv"
 	"time"
 
+	"code.cloudfoundry.org/clock"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/metadata"
 	"github.com/hyperledger/fabric/gossip/comm"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/election"
 	"github.com/hyperledger/fabric/gossip/gossip/algo"
 	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
 
@@ -81,6 +85,10 @@ type Config struct {
 	RecvBuffSize int
 	// SendBuffSize is the buffer size of sending message.
 	SendBuffSize int
+	// CompressionAlgorithm is the algorithm used to compress block and private data payloads.
+	CompressionAlgorithm string
+	// CompressionThreshold is the size in bytes above which payloads are compressed.
+	CompressionThreshold int
 
 	// MsgExpirationTimeout indicate leadership message expiration timeout.
 	MsgExpirationTimeout time.Duration
@@ -97,6 +105,16 @@ type Config struct {
 	MsgExpirationFactor int
 	// MaxConnectionAttempts is the max number of attempts to connect to a peer (wait for alive ack)
 	MaxConnectionAttempts int
+
+	// Labels are published to the peers of the channels the peer joins, and returned by the discovery service.
+	Labels map[string]string
+	// Version is the version of the peer binary, published along with the Capabilities it supports.
+	Version string
+	// Capabilities are the capabilities the peer supports, by the config group which enables them.
+	Capabilities map[string][]string
+
+	// Clock is the clock the periodic gossip tasks are timed by. The wall clock is used if nil.
+	Clock clock.Clock
 }
 
 // GlobalConfig builds a Config from the given endpoint, certificate and bootstrap peers.
@@ -141,6 +159,14 @@ func (c *Config) loadConfig(endpoint string, certs *common.TLSCertificates, boot
 	c.ConnTimeout = util.GetDurationOrDefault("peer.gossip.connTimeout", comm.DefConnTimeout)
 	c.RecvBuffSize = util.GetIntOrDefault("peer.gossip.recvBuffSize", comm.DefRecvBuffSize)
 	c.SendBuffSize = util.GetIntOrDefault("peer.gossip.sendBuffSize", comm.DefSendBuffSize)
+	c.CompressionAlgorithm = viper.GetString("peer.gossip.compression.algorithm")
+	if c.CompressionAlgorithm == "" {
+		c.CompressionAlgorithm = comm.CompressionNone
+	}
+	if !comm.IsValidCompressionAlgorithm(c.CompressionAlgorithm) {
+		return errors.Errorf("invalid gossip compression algorithm: %s", c.CompressionAlgorithm)
+	}
+	c.CompressionThreshold = util.GetIntOrDefault("peer.gossip.compression.threshold", comm.DefCompressionThreshold)
 	c.MsgExpirationTimeout = util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", election.DefLeaderAliveThreshold) * 10
 	c.AliveTimeInterval = util.GetDurationOrDefault("peer.gossip.aliveTimeInterval", discovery.DefAliveTimeInterval)
 	c.AliveExpirationTimeout = util.GetDurationOrDefault("peer.gossip.aliveExpirationTimeout", 5*c.AliveTimeInterval)
@@ -148,6 +174,9 @@ func (c *Config) loadConfig(endpoint string, certs *common.TLSCertificates, boot
 	c.ReconnectInterval = util.GetDurationOrDefault("peer.gossip.reconnectInterval", c.AliveExpirationTimeout)
 	c.MaxConnectionAttempts = util.GetIntOrDefault("peer.gossip.maxConnectionAttempts", discovery.DefMaxConnectionAttempts)
 	c.MsgExpirationFactor = util.GetIntOrDefault("peer.gossip.msgExpirationFactor", discovery.DefMsgExpirationFactor)
+	c.Labels = viper.GetStringMapString("peer.gossip.labels")
+	c.Version = metadata.Version
+	c.Capabilities = capabilities.Supported()
 
 	return nil
 }
@@ -12,6 +12,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/metadata"
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/election"
 
@@ -50,12 +52,15 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.connTimeout", "16s")
 	viper.Set("peer.gossip.recvBuffSize", 17)
 	viper.Set("peer.gossip.sendBuffSize", 18)
+	viper.Set("peer.gossip.compression.algorithm", "gzip")
+	viper.Set("peer.gossip.compression.threshold", 1024)
 	viper.Set("peer.gossip.election.leaderAliveThreshold", "19s")
 	viper.Set("peer.gossip.aliveTimeInterval", "20s")
 	viper.Set("peer.gossip.aliveExpirationTimeout", "21s")
 	viper.Set("peer.gossip.reconnectInterval", "22s")
 	viper.Set("peer.gossip.maxConnectionAttempts", "100")
 	viper.Set("peer.gossip.msgExpirationFactor", "10")
+	viper.Set("peer.gossip.labels", map[string]string{"datacenter": "dc1"})
 
 	coreConfig, err := gossip.GlobalConfig(endpoint, nil, bootstrap...)
 	require.NoError(t, err)
@@ -91,6 +96,8 @@ func TestGlobalConfig(t *testing.T) {
 		ConnTimeout:                  16 * time.Second,
 		RecvBuffSize:                 17,
 		SendBuffSize:                 18,
+		CompressionAlgorithm:         "gzip",
+		CompressionThreshold:         1024,
 		MsgExpirationTimeout:         19 * time.Second * 10, // LeaderAliveThreshold * 10
 		AliveTimeInterval:            20 * time.Second,
 		AliveExpirationTimeout:       21 * time.Second,
@@ -98,6 +105,9 @@ func TestGlobalConfig(t *testing.T) {
 		ReconnectInterval:            22 * time.Second,
 		MaxConnectionAttempts:        100,
 		MsgExpirationFactor:          10,
+		Labels:                       map[string]string{"datacenter": "dc1"},
+		Version:                      metadata.Version,
+		Capabilities:                 capabilities.Supported(),
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
@@ -146,6 +156,8 @@ func TestGlobalConfigDefaults(t *testing.T) {
 		ConnTimeout:                  comm.DefConnTimeout,
 		RecvBuffSize:                 comm.DefRecvBuffSize,
 		SendBuffSize:                 comm.DefSendBuffSize,
+		CompressionAlgorithm:         comm.CompressionNone,
+		CompressionThreshold:         comm.DefCompressionThreshold,
 		MsgExpirationTimeout:         election.DefLeaderAliveThreshold * 10,
 		AliveTimeInterval:            discovery.DefAliveTimeInterval,
 		AliveExpirationTimeout:       5 * discovery.DefAliveTimeInterval,
@@ -153,7 +165,18 @@ func TestGlobalConfigDefaults(t *testing.T) {
 		ReconnectInterval:            5 * discovery.DefAliveTimeInterval,
 		MaxConnectionAttempts:        120,
 		MsgExpirationFactor:          20,
+		Labels:                       map[string]string{},
+		Version:                      metadata.Version,
+		Capabilities:                 capabilities.Supported(),
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
 }
+
+func TestGlobalConfigInvalidCompression(t *testing.T) {
+	viper.Reset()
+	viper.Set("peer.gossip.compression.algorithm", "lz4")
+
+	_, err := gossip.GlobalConfig("0.0.0.0:7051", nil)
+	require.EqualError(t, err, "invalid gossip compression algorithm: lz4")
+}
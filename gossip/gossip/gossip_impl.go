@@ -15,6 +15,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"github.com/golang/protobuf/proto"
 	pg "github.com/hyperledger/fabric-protos-go/gossip"
 	"github.com/hyperledger/fabric/gossip/api"
@@ -66,16 +67,42 @@ type Node struct {
 	stateInfoMsgStore msgstore.MessageStore
 	certPuller        pull.Mediator
 	gossipMetrics     *metrics.GossipMetrics
+	clock             clock.Clock
 }
 
+// CommFactory creates the communication layer of a gossip instance
+// from the identity mapper of that instance.
+type CommFactory func(idMapper identity.Mapper) (comm.Comm, error)
+
 // New creates a gossip instance attached to a gRPC server
 func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	mcs api.MessageCryptoService, selfIdentity api.PeerIdentityType,
 	secureDialOpts api.PeerSecureDialOpts, gossipMetrics *metrics.GossipMetrics,
 	anchorPeerTracker discovery.AnchorPeerTracker) *Node {
+	commFactory := func(idMapper identity.Mapper) (comm.Comm, error) {
+		commConfig := comm.CommConfig{
+			DialTimeout:          conf.DialTimeout,
+			ConnTimeout:          conf.ConnTimeout,
+			RecvBuffSize:         conf.RecvBuffSize,
+			SendBuffSize:         conf.SendBuffSize,
+			CompressionAlgorithm: conf.CompressionAlgorithm,
+			CompressionThreshold: conf.CompressionThreshold,
+		}
+		return comm.NewCommInstance(s, conf.TLSCerts, idMapper, selfIdentity, secureDialOpts, sa,
+			gossipMetrics.CommMetrics, commConfig)
+	}
+	return NewWithComm(conf, commFactory, sa, mcs, selfIdentity, gossipMetrics, anchorPeerTracker)
+}
+
+// NewWithComm creates a gossip instance that communicates through
+// the communication layer created by the given CommFactory
+func NewWithComm(conf *Config, commFactory CommFactory, sa api.SecurityAdvisor,
+	mcs api.MessageCryptoService, selfIdentity api.PeerIdentityType,
+	gossipMetrics *metrics.GossipMetrics, anchorPeerTracker discovery.AnchorPeerTracker) *Node {
 	var err error
 
 	lgr := util.GetLogger(util.GossipLogger, conf.ID)
+	clk := util.ClockOrDefault(conf.Clock)
 
 	g := &Node{
 		selfOrg:               sa.OrgByPeerIdentity(selfIdentity),
@@ -90,8 +117,9 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 		toDieChan:             make(chan struct{}),
 		stopFlag:              int32(0),
 		stopSignal:            &sync.WaitGroup{},
-		includeIdentityPeriod: time.Now().Add(conf.PublishCertPeriod),
+		includeIdentityPeriod: clk.Now().Add(conf.PublishCertPeriod),
 		gossipMetrics:         gossipMetrics,
+		clock:                 clk,
 	}
 	g.stateInfoMsgStore = g.newStateInfoMsgStore()
 
@@ -100,15 +128,7 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 		g.certPuller.Remove(string(pkiID))
 	}, sa)
 
-	commConfig := comm.CommConfig{
-		DialTimeout:  conf.DialTimeout,
-		ConnTimeout:  conf.ConnTimeout,
-		RecvBuffSize: conf.RecvBuffSize,
-		SendBuffSize: conf.SendBuffSize,
-	}
-	g.comm, err = comm.NewCommInstance(s, conf.TLSCerts, g.idMapper, selfIdentity, secureDialOpts, sa,
-		gossipMetrics.CommMetrics, commConfig)
-
+	g.comm, err = commFactory(g.idMapper)
 	if err != nil {
 		lgr.Error("Failed instntiating communication layer:", err)
 		return nil
@@ -117,7 +137,7 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 	g.chanState = newChannelState(g)
 	g.emitter = newBatchingEmitter(conf.PropagateIterations,
 		conf.MaxPropagationBurstSize, conf.MaxPropagationBurstLatency,
-		g.sendGossipBatch)
+		g.sendGossipBatch, clk)
 
 	g.discAdapter = g.newDiscoveryAdapter()
 	g.disSecAdap = g.newDiscoverySecurityAdapter()
@@ -130,6 +150,7 @@ func New(conf *Config, s *grpc.Server, sa api.SecurityAdvisor,
 		MaxConnectionAttempts:        conf.MaxConnectionAttempts,
 		MsgExpirationFactor:          conf.MsgExpirationFactor,
 		BootstrapPeers:               conf.BootstrapPeers,
+		Clock:                        clk,
 	}
 	self := g.selfNetworkMember()
 	logger := util.GetLogger(util.DiscoveryLogger, self.InternalEndpoint)
@@ -159,7 +180,8 @@ func (g *Node) newStateInfoMsgStore() msgstore.MessageStore {
 		g.conf.PublishStateInfoInterval*100,
 		nil,
 		nil,
-		msgstore.Noop)
+		msgstore.Noop,
+		g.clock)
 }
 
 func (g *Node) selfNetworkMember() discovery.NetworkMember {
@@ -211,7 +233,23 @@ func (g *Node) LeaveChan(channelID common.ChannelID) {
 // SuspectPeers makes the gossip instance validate identities of suspected peers, and close
 // any connections to peers with identities that are found invalid
 func (g *Node) SuspectPeers(isSuspected api.PeerSuspector) {
-	g.certStore.suspectPeers(isSuspected)
+	for _, invalid := range g.certStore.suspectPeers(isSuspected) {
+		g.evictPeer(invalid)
+	}
+}
+
+// evictPeer removes a peer whose identity was found revoked or expired from the
+// membership and from the channels. The connection to the peer was already closed
+// and its identity removed from the cert store when the identity was purged.
+func (g *Node) evictPeer(invalid identity.InvalidIdentity) {
+	if bytes.Equal(invalid.PKIId, g.comm.GetPKIid()) {
+		return
+	}
+	g.disc.Purge(invalid.PKIId)
+	g.chanState.evictPeer(invalid.PKIId)
+	g.logger.Warningf("Evicted peer %s of organization %s from gossip, as its identity is %s",
+		invalid.PKIId, string(invalid.Organization), invalid.Reason)
+	g.gossipMetrics.MembershipMetrics.EvictedPeers.With("reason", invalid.Reason).Add(1)
 }
 
 func (g *Node) learnAnchorPeers(channel string, orgOfAnchorPeers api.OrgIdentityType, anchorPeers []api.AnchorPeer) {
@@ -286,7 +324,7 @@ func (g *Node) syncDiscovery() {
 	defer g.logger.Debug("Exiting discovery sync loop")
 	for !g.toDie() {
 		g.disc.InitiateSync(g.conf.PullPeerNum)
-		time.Sleep(g.conf.PullInterval)
+		g.clock.Sleep(g.conf.PullInterval)
 	}
 }
 
@@ -711,6 +749,16 @@ func (g *Node) Peers() []discovery.NetworkMember {
 	return g.disc.GetMembership()
 }
 
+// DeadPeers returns the NetworkMembers considered dead
+func (g *Node) DeadPeers() []discovery.NetworkMember {
+	return g.disc.DeadMembers()
+}
+
+// Connections returns statistics of the open connections to remote peers
+func (g *Node) Connections() []comm.ConnectionStats {
+	return g.comm.Connections()
+}
+
 // PeersOfChannel returns the NetworkMembers considered alive
 // and also subscribed to the channel given
 func (g *Node) PeersOfChannel(channel common.ChannelID) []discovery.NetworkMember {
@@ -985,6 +1033,7 @@ type discoverySecurityAdapter struct {
 	mcs                   api.MessageCryptoService
 	c                     comm.Comm
 	logger                util.Logger
+	clock                 clock.Clock
 }
 
 func (g *Node) newDiscoverySecurityAdapter() *discoverySecurityAdapter {
@@ -996,6 +1045,7 @@ func (g *Node) newDiscoverySecurityAdapter() *discoverySecurityAdapter {
 		logger:                g.logger,
 		includeIdentityPeriod: g.includeIdentityPeriod,
 		identity:              g.selfIdentity,
+		clock:                 g.clock,
 	}
 }
 
@@ -1038,7 +1088,7 @@ func (sa *discoverySecurityAdapter) SignMessage(m *pg.GossipMessage, internalEnd
 	signer := func(msg []byte) ([]byte, error) {
 		return sa.mcs.Sign(msg)
 	}
-	if protoext.IsAliveMsg(m) && time.Now().Before(sa.includeIdentityPeriod) {
+	if protoext.IsAliveMsg(m) && sa.clock.Now().Before(sa.includeIdentityPeriod) {
 		m.GetAliveMsg().Identity = sa.identity
 	}
 	sMsg := &protoext.SignedGossipMessage{
@@ -1090,6 +1140,7 @@ func (g *Node) createCertStorePuller() pull.Mediator {
 			DigestWaitTime:   g.conf.DigestWaitTime,
 			RequestWaitTime:  g.conf.RequestWaitTime,
 			ResponseWaitTime: g.conf.ResponseWaitTime,
+			Clock:            g.clock,
 		},
 	}
 	pkiIDFromMsg := func(msg *protoext.SignedGossipMessage) string {
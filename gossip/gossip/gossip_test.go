@@ -1472,6 +1472,54 @@ func TestIdentityExpiration(t *testing.T) {
 	g5.Stop()
 }
 
+func TestRevokedPeerEviction(t *testing.T) {
+	// Scenario: spawn 3 peers in a channel and make the MessageCryptoService of the first
+	// peer revoke the last one. Once the first peer re-validates the identities it knows,
+	// the revoked peer should be gone from its membership and channel right away, without
+	// waiting for its alive messages to expire.
+	testMetricProvider := mocks.TestUtilConstructMetricProvider()
+	gmetrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider)
+
+	port0, grpc0, certs0, secDialOpts0, _ := util.CreateGRPCLayer()
+	p0 := newGossipInstanceWithGrpcMcsMetrics(0, port0, grpc0, certs0, secDialOpts0, 100, &naiveCryptoService{}, gmetrics)
+	p1 := newGossipInstanceCreateGRPC(1, 100, port0)
+	port2, grpc2, certs2, secDialOpts2, _ := util.CreateGRPCLayer()
+	p2 := newGossipInstanceWithGRPC(2, port2, grpc2, certs2, secDialOpts2, 100, port0)
+	peers := []*gossipGRPC{p0, p1, p2}
+	defer stopPeers(peers)
+
+	for _, p := range peers {
+		p.JoinChan(&joinChanMsg{}, common.ChannelID("A"))
+		p.UpdateLedgerHeight(1, common.ChannelID("A"))
+	}
+	waitUntilOrFail(t, func() bool {
+		return len(p0.Peers()) == 2 && len(p0.PeersOfChannel(common.ChannelID("A"))) == 2
+	}, "waiting for the first peer to see the others in the channel")
+
+	revokedPkiID := common.PKIidType(fmt.Sprintf("127.0.0.1:%d", port2))
+	p0.Node.mcs.(*naiveCryptoService).revoke(revokedPkiID)
+	p0.SuspectPeers(func(_ api.PeerIdentityType) bool {
+		return true
+	})
+
+	isRevoked := func(m discovery.NetworkMember) bool {
+		return bytes.Equal(m.PKIid, revokedPkiID)
+	}
+	for _, m := range p0.Peers() {
+		require.False(t, isRevoked(m), "revoked peer is still alive in the membership")
+	}
+	for _, m := range p0.DeadPeers() {
+		require.False(t, isRevoked(m), "revoked peer is still dead in the membership")
+	}
+	for _, m := range p0.PeersOfChannel(common.ChannelID("A")) {
+		require.False(t, isRevoked(m), "revoked peer is still in the channel")
+	}
+
+	require.Equal(t, 1, testMetricProvider.FakeEvictedPeers.AddCallCount())
+	require.Equal(t, []string{"reason", "revoked"}, testMetricProvider.FakeEvictedPeers.WithArgsForCall(0))
+	require.Equal(t, float64(1), testMetricProvider.FakeEvictedPeers.AddArgsForCall(0))
+}
+
 func createDataMsg(seqnum uint64, data []byte, channel common.ChannelID) *proto.GossipMessage {
 	return &proto.GossipMessage{
 		Channel: []byte(channel),
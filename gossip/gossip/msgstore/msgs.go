@@ -10,7 +10,9 @@ import (
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/util"
 )
 
 var noopLock = func() {}
@@ -34,9 +36,11 @@ func NewMessageStore(pol common.MessageReplacingPolicy, trigger invalidationTrig
 // NewMessageStoreExpirable returns a new MessageStore with the message replacing
 // policy and invalidation trigger passed. It supports old message expiration after msgTTL, during expiration first external
 // lock taken, expiration callback invoked and external lock released. Callback and external lock can be nil.
-func NewMessageStoreExpirable(pol common.MessageReplacingPolicy, trigger invalidationTrigger, msgTTL time.Duration, externalLock func(), externalUnlock func(), externalExpire func(interface{})) MessageStore {
+// Messages expire by the given clock, or by the wall clock if it is nil.
+func NewMessageStoreExpirable(pol common.MessageReplacingPolicy, trigger invalidationTrigger, msgTTL time.Duration, externalLock func(), externalUnlock func(), externalExpire func(interface{}), clk clock.Clock) MessageStore {
 	store := newMsgStore(pol, trigger)
 	store.msgTTL = msgTTL
+	store.clock = util.ClockOrDefault(clk)
 
 	if externalLock != nil {
 		store.externalLock = externalLock
@@ -64,6 +68,7 @@ func newMsgStore(pol common.MessageReplacingPolicy, trigger invalidationTrigger)
 		externalUnlock:    noopLock,
 		expireMsgCallback: func(m interface{}) {},
 		expiredCount:      0,
+		clock:             clock.NewClock(),
 
 		doneCh: make(chan struct{}),
 	}
@@ -108,6 +113,7 @@ type messageStoreImpl struct {
 	externalLock      func()
 	externalUnlock    func()
 	expireMsgCallback func(msg interface{})
+	clock             clock.Clock
 	doneCh            chan struct{}
 	stopOnce          sync.Once
 }
@@ -137,7 +143,7 @@ func (s *messageStoreImpl) Add(message interface{}) bool {
 		}
 	}
 
-	s.messages = append(s.messages, &msg{data: message, created: time.Now()})
+	s.messages = append(s.messages, &msg{data: message, created: s.clock.Now()})
 	return true
 }
 
@@ -207,13 +213,13 @@ func (s *messageStoreImpl) expireMessages() {
 	for i := 0; i < n; i++ {
 		m := s.messages[i]
 		if !m.expired {
-			if time.Since(m.created) > s.msgTTL {
+			if s.clock.Since(m.created) > s.msgTTL {
 				m.expired = true
 				s.expireMsgCallback(m.data)
 				s.expiredCount++
 			}
 		} else {
-			if time.Since(m.created) > (s.msgTTL * 2) {
+			if s.clock.Since(m.created) > (s.msgTTL * 2) {
 				s.messages = append(s.messages[:i], s.messages[i+1:]...)
 				n--
 				i--
@@ -240,11 +246,11 @@ func (s *messageStoreImpl) expirationRoutine() {
 		select {
 		case <-s.doneCh:
 			return
-		case <-time.After(s.expirationCheckInterval()):
+		case <-s.clock.After(s.expirationCheckInterval()):
 			hasMessageExpired := func(m *msg) bool {
-				if !m.expired && time.Since(m.created) > s.msgTTL {
+				if !m.expired && s.clock.Since(m.created) > s.msgTTL {
 					return true
-				} else if time.Since(m.created) > (s.msgTTL * 2) {
+				} else if s.clock.Since(m.created) > (s.msgTTL * 2) {
 					return true
 				}
 				return false
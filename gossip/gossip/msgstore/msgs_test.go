@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"code.cloudfoundry.org/clock/fakeclock"
 	"github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/util"
 	"github.com/stretchr/testify/require"
@@ -141,13 +142,36 @@ func TestConcurrency(t *testing.T) {
 	atomic.CompareAndSwapInt32(&stopFlag, 0, 1)
 }
 
+func TestExpirationWithClock(t *testing.T) {
+	expired := make(chan int, 10)
+	msgTTL := time.Second * 3
+	clk := fakeclock.NewFakeClock(time.Unix(0, 0))
+
+	msgStore := NewMessageStoreExpirable(nonReplaceInts, Noop, msgTTL, nil, nil, func(m interface{}) {
+		expired <- m.(int)
+	}, clk)
+	defer msgStore.Stop()
+
+	for i := 0; i < 10; i++ {
+		require.True(t, msgStore.Add(i), "Adding", i)
+	}
+
+	clk.WaitForWatcherAndIncrement(msgTTL + time.Second)
+	require.Eventually(t, func() bool { return len(expired) == 10 }, time.Second*5, time.Millisecond*10)
+	require.Equal(t, 0, msgStore.Size(), "Wrong number of items in store - after expiration")
+	require.False(t, msgStore.CheckValid(0), "Expired messages are kept until twice their TTL")
+
+	clk.WaitForWatcherAndIncrement(msgTTL)
+	require.Eventually(t, func() bool { return msgStore.CheckValid(0) }, time.Second*5, time.Millisecond*10)
+}
+
 func TestExpiration(t *testing.T) {
 	expired := make(chan int, 50)
 	msgTTL := time.Second * 3
 
 	msgStore := NewMessageStoreExpirable(nonReplaceInts, Noop, msgTTL, nil, nil, func(m interface{}) {
 		expired <- m.(int)
-	})
+	}, nil)
 
 	for i := 0; i < 10; i++ {
 		require.True(t, msgStore.Add(i), "Adding", i)
@@ -207,7 +231,7 @@ func TestExpirationConcurrency(t *testing.T) {
 		},
 		func(m interface{}) {
 			expired = append(expired, m.(int))
-		})
+		}, nil)
 
 	lock.Lock()
 	for i := 0; i < 10; i++ {
@@ -248,7 +272,7 @@ func TestStop(t *testing.T) {
 
 	msgStore := NewMessageStoreExpirable(nonReplaceInts, Noop, msgTTL, nil, nil, func(m interface{}) {
 		expired = append(expired, m.(int))
-	})
+	}, nil)
 
 	for i := 0; i < 10; i++ {
 		require.True(t, msgStore.Add(i), "Adding", i)
@@ -44,8 +44,9 @@ type Mapper interface {
 	// GetPKIidOfCert returns the PKI-ID of a certificate
 	GetPKIidOfCert(api.PeerIdentityType) common.PKIidType
 
-	// SuspectPeers re-validates all peers that match the given predicate
-	SuspectPeers(isSuspected api.PeerSuspector)
+	// SuspectPeers re-validates all peers that match the given predicate,
+	// and returns the identities found revoked or expired
+	SuspectPeers(isSuspected api.PeerSuspector) []InvalidIdentity
 
 	// IdentityInfo returns information known peer identities
 	IdentityInfo() api.PeerIdentitySet
@@ -56,6 +57,19 @@ type Mapper interface {
 
 type purgeTrigger func(pkiID common.PKIidType, identity api.PeerIdentityType)
 
+// Reasons an identity is found invalid when re-validated
+const (
+	Revoked = "revoked"
+	Expired = "expired"
+)
+
+// InvalidIdentity is a known identity that was found revoked or
+// expired when re-validated, and was purged
+type InvalidIdentity struct {
+	api.PeerIdentityInfo
+	Reason string
+}
+
 // identityMapperImpl is a struct that implements Mapper
 type identityMapperImpl struct {
 	onPurge    purgeTrigger
@@ -186,35 +200,59 @@ func (is *identityMapperImpl) GetPKIidOfCert(identity api.PeerIdentityType) comm
 	return is.mcs.GetPKIidOfCert(identity)
 }
 
-// SuspectPeers re-validates all peers that match the given predicate
-func (is *identityMapperImpl) SuspectPeers(isSuspected api.PeerSuspector) {
-	for _, identity := range is.validateIdentities(isSuspected) {
+// SuspectPeers re-validates all peers that match the given predicate,
+// and returns the identities found revoked or expired
+func (is *identityMapperImpl) SuspectPeers(isSuspected api.PeerSuspector) []InvalidIdentity {
+	unused, invalid := is.validateIdentities(isSuspected)
+	for _, identity := range unused {
 		identity.cancelExpirationTimer()
 		is.delete(identity.pkiID, identity.peerIdentity)
 	}
+	var res []InvalidIdentity
+	for reason, identities := range invalid {
+		for _, identity := range identities {
+			identity.cancelExpirationTimer()
+			is.delete(identity.pkiID, identity.peerIdentity)
+			res = append(res, InvalidIdentity{
+				PeerIdentityInfo: api.PeerIdentityInfo{
+					PKIId:        identity.pkiID,
+					Identity:     identity.peerIdentity,
+					Organization: identity.orgId,
+				},
+				Reason: reason,
+			})
+		}
+	}
+	return res
 }
 
-// validateIdentities returns a list of identities that have been revoked, expired or haven't been
-// used for a long time
-func (is *identityMapperImpl) validateIdentities(isSuspected api.PeerSuspector) []*storedIdentity {
+// validateIdentities returns a list of identities that haven't been used for a long time,
+// and the identities that have been revoked or expired by the reason they are invalid
+func (is *identityMapperImpl) validateIdentities(isSuspected api.PeerSuspector) ([]*storedIdentity, map[string][]*storedIdentity) {
 	now := time.Now()
 	usageTh := GetIdentityUsageThreshold()
 	is.RLock()
 	defer is.RUnlock()
-	var revokedIdentities []*storedIdentity
+	var unusedIdentities []*storedIdentity
+	invalidIdentities := make(map[string][]*storedIdentity)
 	for pkiID, storedIdentity := range is.pkiID2Cert {
 		if pkiID != is.selfPKIID && storedIdentity.fetchLastAccessTime().Add(usageTh).Before(now) {
-			revokedIdentities = append(revokedIdentities, storedIdentity)
+			unusedIdentities = append(unusedIdentities, storedIdentity)
 			continue
 		}
 		if !isSuspected(storedIdentity.peerIdentity) {
 			continue
 		}
-		if err := is.mcs.ValidateIdentity(storedIdentity.fetchIdentity()); err != nil {
-			revokedIdentities = append(revokedIdentities, storedIdentity)
+		identity := storedIdentity.fetchIdentity()
+		if expirationDate, err := is.mcs.Expiration(identity); err == nil && !expirationDate.IsZero() && now.After(expirationDate) {
+			invalidIdentities[Expired] = append(invalidIdentities[Expired], storedIdentity)
+			continue
+		}
+		if err := is.mcs.ValidateIdentity(identity); err != nil {
+			invalidIdentities[Revoked] = append(invalidIdentities[Revoked], storedIdentity)
 		}
 	}
-	return revokedIdentities
+	return unusedIdentities, invalidIdentities
 }
 
 // IdentityInfo returns information known peer identities
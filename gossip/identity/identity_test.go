@@ -177,9 +177,18 @@ func TestListInvalidIdentities(t *testing.T) {
 	require.NotNil(t, cert)
 	// Revoke the certificate
 	msgCryptoService.revokedIdentities[string(pkiID)] = struct{}{}
-	idStore.SuspectPeers(func(_ api.PeerIdentityType) bool {
+	invalid := idStore.SuspectPeers(func(_ api.PeerIdentityType) bool {
 		return true
 	})
+	require.Equal(t, []InvalidIdentity{
+		{
+			PeerIdentityInfo: api.PeerIdentityInfo{
+				PKIId:    pkiID,
+				Identity: api.PeerIdentityType(identity),
+			},
+			Reason: Revoked,
+		},
+	}, invalid)
 	// Make sure it is not found anymore
 	cert, err = idStore.Get(pkiID)
 	require.Error(t, err)
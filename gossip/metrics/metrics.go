@@ -32,6 +32,12 @@ type StateMetrics struct {
 	Height            metrics.Gauge
 	CommitDuration    metrics.Histogram
 	PayloadBufferSize metrics.Gauge
+
+	TransferRequests        metrics.Counter
+	TransferTimeouts        metrics.Counter
+	TransferBlocks          metrics.Counter
+	TransferRequestDuration metrics.Histogram
+	TransferPeers           metrics.Gauge
 }
 
 func newStateMetrics(p metrics.Provider) *StateMetrics {
@@ -39,6 +45,12 @@ func newStateMetrics(p metrics.Provider) *StateMetrics {
 		Height:            p.NewGauge(HeightOpts),
 		CommitDuration:    p.NewHistogram(CommitDurationOpts),
 		PayloadBufferSize: p.NewGauge(PayloadBufferSizeOpts),
+
+		TransferRequests:        p.NewCounter(TransferRequestsOpts),
+		TransferTimeouts:        p.NewCounter(TransferTimeoutsOpts),
+		TransferBlocks:          p.NewCounter(TransferBlocksOpts),
+		TransferRequestDuration: p.NewHistogram(TransferRequestDurationOpts),
+		TransferPeers:           p.NewGauge(TransferPeersOpts),
 	}
 }
 
@@ -69,6 +81,51 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+
+	TransferRequestsOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "state",
+		Name:         "transfer_requests",
+		Help:         "Number of requests for a range of blocks sent by state transfer",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	TransferTimeoutsOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "state",
+		Name:         "transfer_timeouts",
+		Help:         "Number of state transfer requests that were not answered in time",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	TransferBlocksOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "state",
+		Name:         "transfer_blocks",
+		Help:         "Number of blocks received by state transfer",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	TransferRequestDurationOpts = metrics.HistogramOpts{
+		Namespace:    "gossip",
+		Subsystem:    "state",
+		Name:         "transfer_request_duration",
+		Help:         "Time it takes a peer to answer a state transfer request in seconds",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
+
+	TransferPeersOpts = metrics.GaugeOpts{
+		Namespace:    "gossip",
+		Subsystem:    "state",
+		Name:         "transfer_peers",
+		Help:         "Number of peers state transfer is requesting blocks from",
+		LabelNames:   []string{"channel"},
+		StatsdFormat: "%{#fqname}.%{channel}",
+	}
 )
 
 // ElectionMetrics encapsulates gossip leader election related metrics
@@ -95,16 +152,20 @@ var (
 
 // CommMetrics encapsulates gossip communication related metrics
 type CommMetrics struct {
-	SentMessages     metrics.Counter
-	BufferOverflow   metrics.Counter
-	ReceivedMessages metrics.Counter
+	SentMessages      metrics.Counter
+	BufferOverflow    metrics.Counter
+	ReceivedMessages  metrics.Counter
+	UncompressedBytes metrics.Counter
+	CompressedBytes   metrics.Counter
 }
 
 func newCommMetrics(p metrics.Provider) *CommMetrics {
 	return &CommMetrics{
-		SentMessages:     p.NewCounter(SentMessagesOpts),
-		BufferOverflow:   p.NewCounter(BufferOverflowOpts),
-		ReceivedMessages: p.NewCounter(ReceivedMessagesOpts),
+		SentMessages:      p.NewCounter(SentMessagesOpts),
+		BufferOverflow:    p.NewCounter(BufferOverflowOpts),
+		ReceivedMessages:  p.NewCounter(ReceivedMessagesOpts),
+		UncompressedBytes: p.NewCounter(UncompressedBytesOpts),
+		CompressedBytes:   p.NewCounter(CompressedBytesOpts),
 	}
 }
 
@@ -132,16 +193,36 @@ var (
 		Help:         "Number of messages received",
 		StatsdFormat: "%{#fqname}",
 	}
+
+	UncompressedBytesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "uncompressed_bytes",
+		Help:         "Size in bytes of the payloads sent before compression",
+		LabelNames:   []string{"message_type"},
+		StatsdFormat: "%{#fqname}.%{message_type}",
+	}
+
+	CompressedBytesOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "comm",
+		Name:         "compressed_bytes",
+		Help:         "Size in bytes of the payloads sent after compression",
+		LabelNames:   []string{"message_type"},
+		StatsdFormat: "%{#fqname}.%{message_type}",
+	}
 )
 
 // MembershipMetrics encapsulates gossip channel membership related metrics
 type MembershipMetrics struct {
-	Total metrics.Gauge
+	Total        metrics.Gauge
+	EvictedPeers metrics.Counter
 }
 
 func newMembershipMetrics(p metrics.Provider) *MembershipMetrics {
 	return &MembershipMetrics{
-		Total: p.NewGauge(TotalOpts),
+		Total:        p.NewGauge(TotalOpts),
+		EvictedPeers: p.NewCounter(EvictedPeersOpts),
 	}
 }
 
@@ -154,6 +235,15 @@ var (
 		LabelNames:   []string{"channel"},
 		StatsdFormat: "%{#fqname}.%{channel}",
 	}
+
+	EvictedPeersOpts = metrics.CounterOpts{
+		Namespace:    "gossip",
+		Subsystem:    "membership",
+		Name:         "evicted_peers",
+		Help:         "Number of peers evicted from gossip because their identity was revoked or expired",
+		LabelNames:   []string{"reason"},
+		StatsdFormat: "%{#fqname}.%{reason}",
+	}
 )
 
 // PrivdataMetrics encapsulates gossip private data related metrics
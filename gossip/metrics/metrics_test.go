@@ -34,6 +34,11 @@ func TestMetrics(t *testing.T) {
 	require.NotNil(t, gossipMetrics.StateMetrics.Height)
 	require.NotNil(t, gossipMetrics.StateMetrics.CommitDuration)
 	require.NotNil(t, gossipMetrics.StateMetrics.PayloadBufferSize)
+	require.NotNil(t, gossipMetrics.StateMetrics.TransferRequests)
+	require.NotNil(t, gossipMetrics.StateMetrics.TransferTimeouts)
+	require.NotNil(t, gossipMetrics.StateMetrics.TransferBlocks)
+	require.NotNil(t, gossipMetrics.StateMetrics.TransferRequestDuration)
+	require.NotNil(t, gossipMetrics.StateMetrics.TransferPeers)
 
 	require.NotNil(t, gossipMetrics.ElectionMetrics)
 	require.NotNil(t, gossipMetrics.ElectionMetrics.Declaration)
@@ -42,9 +47,12 @@ func TestMetrics(t *testing.T) {
 	require.NotNil(t, gossipMetrics.CommMetrics.SentMessages)
 	require.NotNil(t, gossipMetrics.CommMetrics.ReceivedMessages)
 	require.NotNil(t, gossipMetrics.CommMetrics.BufferOverflow)
+	require.NotNil(t, gossipMetrics.CommMetrics.UncompressedBytes)
+	require.NotNil(t, gossipMetrics.CommMetrics.CompressedBytes)
 
 	require.NotNil(t, gossipMetrics.MembershipMetrics)
 	require.NotNil(t, gossipMetrics.MembershipMetrics.Total)
+	require.NotNil(t, gossipMetrics.MembershipMetrics.EvictedPeers)
 
 	require.NotNil(t, gossipMetrics.PrivdataMetrics)
 	require.NotNil(t, gossipMetrics.PrivdataMetrics.CommitPrivateDataDuration)
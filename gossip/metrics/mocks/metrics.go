@@ -19,13 +19,22 @@ type TestMetricProvider struct {
 	FakeCommitDurationHist     *metricsfakes.Histogram
 	FakePayloadBufferSizeGauge *metricsfakes.Gauge
 
+	FakeTransferRequests        *metricsfakes.Counter
+	FakeTransferTimeouts        *metricsfakes.Counter
+	FakeTransferBlocks          *metricsfakes.Counter
+	FakeTransferRequestDuration *metricsfakes.Histogram
+	FakeTransferPeers           *metricsfakes.Gauge
+
 	FakeDeclarationGauge *metricsfakes.Gauge
 
-	FakeSentMessages     *metricsfakes.Counter
-	FakeBufferOverflow   *metricsfakes.Counter
-	FakeReceivedMessages *metricsfakes.Counter
+	FakeSentMessages      *metricsfakes.Counter
+	FakeBufferOverflow    *metricsfakes.Counter
+	FakeReceivedMessages  *metricsfakes.Counter
+	FakeUncompressedBytes *metricsfakes.Counter
+	FakeCompressedBytes   *metricsfakes.Counter
 
-	FakeTotalGauge *metricsfakes.Gauge
+	FakeTotalGauge   *metricsfakes.Gauge
+	FakeEvictedPeers *metricsfakes.Counter
 
 	FakeValidationDuration             *metricsfakes.Histogram
 	FakeListMissingPrivateDataDuration *metricsfakes.Histogram
@@ -45,13 +54,22 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 	fakeCommitDurationHist := testUtilConstructHist()
 	fakePayloadBufferSizeGauge := testUtilConstructGauge()
 
+	fakeTransferRequests := testUtilConstructCounter()
+	fakeTransferTimeouts := testUtilConstructCounter()
+	fakeTransferBlocks := testUtilConstructCounter()
+	fakeTransferRequestDuration := testUtilConstructHist()
+	fakeTransferPeers := testUtilConstructGauge()
+
 	fakeDeclarationGauge := testUtilConstructGauge()
 
 	fakeSentMessages := testUtilConstructCounter()
 	fakeBufferOverflow := testUtilConstructCounter()
 	fakeReceivedMessages := testUtilConstructCounter()
+	fakeUncompressedBytes := testUtilConstructCounter()
+	fakeCompressedBytes := testUtilConstructCounter()
 
 	fakeTotalGauge := testUtilConstructGauge()
+	fakeEvictedPeers := testUtilConstructCounter()
 
 	fakeValidationDuration := testUtilConstructHist()
 	fakeListMissingPrivateDataDuration := testUtilConstructHist()
@@ -71,6 +89,18 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakeSentMessages
 		case gmetrics.ReceivedMessagesOpts.Name:
 			return fakeReceivedMessages
+		case gmetrics.UncompressedBytesOpts.Name:
+			return fakeUncompressedBytes
+		case gmetrics.CompressedBytesOpts.Name:
+			return fakeCompressedBytes
+		case gmetrics.TransferRequestsOpts.Name:
+			return fakeTransferRequests
+		case gmetrics.TransferTimeoutsOpts.Name:
+			return fakeTransferTimeouts
+		case gmetrics.TransferBlocksOpts.Name:
+			return fakeTransferBlocks
+		case gmetrics.EvictedPeersOpts.Name:
+			return fakeEvictedPeers
 		}
 		return nil
 	}
@@ -96,6 +126,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakePullDuration
 		case gmetrics.RetrieveDurationOpts.Name:
 			return fakeRetrieveDuration
+		case gmetrics.TransferRequestDurationOpts.Name:
+			return fakeTransferRequestDuration
 		}
 		return nil
 	}
@@ -109,6 +141,8 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 			return fakeDeclarationGauge
 		case gmetrics.TotalOpts.Name:
 			return fakeTotalGauge
+		case gmetrics.TransferPeersOpts.Name:
+			return fakeTransferPeers
 		}
 		return nil
 	}
@@ -118,11 +152,19 @@ func TestUtilConstructMetricProvider() *TestMetricProvider {
 		fakeHeightGauge,
 		fakeCommitDurationHist,
 		fakePayloadBufferSizeGauge,
+		fakeTransferRequests,
+		fakeTransferTimeouts,
+		fakeTransferBlocks,
+		fakeTransferRequestDuration,
+		fakeTransferPeers,
 		fakeDeclarationGauge,
 		fakeSentMessages,
 		fakeBufferOverflow,
 		fakeReceivedMessages,
+		fakeUncompressedBytes,
+		fakeCompressedBytes,
 		fakeTotalGauge,
+		fakeEvictedPeers,
 		fakeValidationDuration,
 		fakeListMissingPrivateDataDuration,
 		fakeFetchDuration,
@@ -32,3 +32,26 @@ func (_m *MissingPvtDataTracker) GetMissingPvtDataInfoForMostRecentBlocks(maxBlo
 
 	return r0, r1
 }
+
+// ListMissingPvtData provides a mock function with given fields: filter
+func (_m *MissingPvtDataTracker) ListMissingPvtData(filter *ledger.MissingPvtDataFilter) ([]*ledger.MissingPvtDataEntry, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*ledger.MissingPvtDataEntry
+	if rf, ok := ret.Get(0).(func(*ledger.MissingPvtDataFilter) []*ledger.MissingPvtDataEntry); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*ledger.MissingPvtDataEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*ledger.MissingPvtDataFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -64,10 +64,24 @@ type Reconciler struct {
 	stopChan               chan struct{}
 	startOnce              sync.Once
 	stopOnce               sync.Once
+	// reconcileLock serializes the periodic and the on-demand reconciliation
+	reconcileLock sync.Mutex
 	ReconciliationFetcher
 	committer.Committer
 }
 
+// ReconcileProgress reports the progress of an on-demand reconciliation
+type ReconcileProgress struct {
+	// TotalBlocks is the number of blocks missing private data of eligible collections
+	TotalBlocks int `json:"total_blocks"`
+	// ProcessedBlocks is the number of blocks reconciliation was attempted for
+	ProcessedBlocks int `json:"processed_blocks"`
+	// Reconciled is the number of private data elements fetched and committed
+	Reconciled int `json:"reconciled"`
+	// Unreconciled is the number of private data elements which could not be fetched
+	Unreconciled int `json:"unreconciled"`
+}
+
 // NoOpReconciler non functional reconciler to be used
 // in case reconciliation has been disabled
 type NoOpReconciler struct {
@@ -128,6 +142,9 @@ func (r *Reconciler) run() {
 
 // returns the number of items that were reconciled , minBlock, maxBlock (blocks range) and an error
 func (r *Reconciler) reconcile() error {
+	r.reconcileLock.Lock()
+	defer r.reconcileLock.Unlock()
+
 	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
 	if err != nil {
 		r.logger.Error("reconciliation error when trying to get missingPvtDataTracker:", err)
@@ -159,28 +176,120 @@ func (r *Reconciler) reconcile() error {
 
 		r.logger.Debug("got from ledger", len(missingPvtDataInfo), "blocks with missing private data, trying to reconcile...")
 
-		dig2collectionCfg, minB, maxB := r.getDig2CollectionConfig(missingPvtDataInfo)
-		fetchedData, err := r.FetchReconciledItems(dig2collectionCfg)
+		reconciled, _, minB, maxB, err := r.reconcileBatch(missingPvtDataInfo)
 		if err != nil {
-			r.logger.Error("reconciliation error when trying to fetch missing items from different peers:", err)
 			return err
 		}
-
-		pvtDataToCommit := r.preparePvtDataToCommit(fetchedData.AvailableElements)
-		unreconciled := constructUnreconciledMissingData(dig2collectionCfg, fetchedData.AvailableElements)
-		pvtdataHashMismatch, err := r.CommitPvtDataOfOldBlocks(pvtDataToCommit, unreconciled)
-		if err != nil {
-			return errors.Wrap(err, "failed to commit private data")
-		}
-		r.logMismatched(pvtdataHashMismatch)
 		if minB < minBlock {
 			minBlock = minB
 		}
 		if maxB > maxBlock {
 			maxBlock = maxB
 		}
-		totalReconciled += len(fetchedData.AvailableElements)
+		totalReconciled += reconciled
+	}
+}
+
+// ReconcileMissingPvtData reconciles right away the missing private data of the eligible
+// collections which matches the filter, regardless of the reconciliation schedule. The
+// blocks are reconciled in batches of ReconcileBatchSize blocks, starting from the most
+// recent block, and the progress is reported to the given function after every batch.
+func (r *Reconciler) ReconcileMissingPvtData(filter *ledger.MissingPvtDataFilter, progress func(ReconcileProgress)) (ReconcileProgress, error) {
+	r.reconcileLock.Lock()
+	defer r.reconcileLock.Unlock()
+
+	var p ReconcileProgress
+	missingPvtDataTracker, err := r.GetMissingPvtDataTracker()
+	if err != nil {
+		return p, errors.WithMessage(err, "failed to get MissingPvtDataTracker")
+	}
+	if missingPvtDataTracker == nil {
+		return p, errors.New("got nil as MissingPvtDataTracker")
+	}
+	entries, err := missingPvtDataTracker.ListMissingPvtData(filter)
+	if err != nil {
+		return p, errors.WithMessage(err, "failed to list missing private data")
+	}
+
+	batchSize := r.ReconcileBatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	// the entries are sorted by block number, hence the entries of
+	// a block are never split between batches
+	var batches []ledger.MissingPvtDataInfo
+	for _, entry := range entries {
+		if !entry.Eligible {
+			continue
+		}
+		if len(batches) == 0 {
+			batches = append(batches, ledger.MissingPvtDataInfo{})
+		}
+		batch := batches[len(batches)-1]
+		if _, exists := batch[entry.BlockNum]; !exists {
+			if len(batch) == batchSize {
+				batch = ledger.MissingPvtDataInfo{}
+				batches = append(batches, batch)
+			}
+			p.TotalBlocks++
+		}
+		for _, txNum := range entry.TxNums {
+			batch.Add(entry.BlockNum, txNum, entry.Namespace, entry.Collection)
+		}
+	}
+
+	defer r.reportReconciliationDuration(time.Now())
+
+	for _, missingPvtDataInfo := range batches {
+		select {
+		case <-r.stopChan:
+			return p, errors.New("reconciler stopped")
+		default:
+		}
+
+		reconciled, unreconciled, _, _, err := r.reconcileBatch(missingPvtDataInfo)
+		if err != nil {
+			return p, err
+		}
+		p.ProcessedBlocks += len(missingPvtDataInfo)
+		p.Reconciled += reconciled
+		p.Unreconciled += unreconciled
+		if progress != nil {
+			progress(p)
+		}
+	}
+
+	r.logger.Infof("On-demand reconciliation finished. reconciled %d private data keys from %d blocks, %d keys could not be reconciled",
+		p.Reconciled, p.ProcessedBlocks, p.Unreconciled)
+	return p, nil
+}
+
+// reconcileBatch fetches the given missing private data from other peers and commits it. It returns
+// the number of private data elements reconciled, the number of those which could not be fetched, and
+// the range of blocks of the batch.
+func (r *Reconciler) reconcileBatch(missingPvtDataInfo ledger.MissingPvtDataInfo) (int, int, uint64, uint64, error) {
+	dig2collectionCfg, minB, maxB := r.getDig2CollectionConfig(missingPvtDataInfo)
+	fetchedData, err := r.FetchReconciledItems(dig2collectionCfg)
+	if err != nil {
+		r.logger.Error("reconciliation error when trying to fetch missing items from different peers:", err)
+		return 0, 0, 0, 0, err
+	}
+
+	pvtDataToCommit := r.preparePvtDataToCommit(fetchedData.AvailableElements)
+	unreconciled := constructUnreconciledMissingData(dig2collectionCfg, fetchedData.AvailableElements)
+	pvtdataHashMismatch, err := r.CommitPvtDataOfOldBlocks(pvtDataToCommit, unreconciled)
+	if err != nil {
+		return 0, 0, 0, 0, errors.Wrap(err, "failed to commit private data")
+	}
+	r.logMismatched(pvtdataHashMismatch)
+
+	unreconciledCount := 0
+	for _, blockPvtDataInfo := range unreconciled {
+		for _, collectionPvtDataInfo := range blockPvtDataInfo {
+			unreconciledCount += len(collectionPvtDataInfo)
+		}
 	}
+	return len(fetchedData.AvailableElements), unreconciledCount, minB, maxB, nil
 }
 
 func (r *Reconciler) reportReconciliationDuration(startTime time.Time) {
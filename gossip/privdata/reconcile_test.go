@@ -8,6 +8,7 @@ package privdata
 
 import (
 	"errors"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -541,6 +542,118 @@ func TestFailuresWhileReconcilingMissingPvtData(t *testing.T) {
 	require.Contains(t, "failed get missing pvt data for recent blocks", err.Error())
 }
 
+func TestReconcileMissingPvtDataOnDemand(t *testing.T) {
+	// Scenario: the missing private data of eligible collections in blocks 5, 4 and 2 is
+	// reconciled on demand, in batches of two blocks. The private data of block 2 cannot
+	// be fetched.
+	committer := &mocks.Committer{}
+	fetcher := &mocks.ReconciliationFetcher{}
+	configHistoryRetriever := &mocks.ConfigHistoryRetriever{}
+	missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+
+	filter := &ledger.MissingPvtDataFilter{Namespace: "ns1"}
+	missingPvtDataTracker.On("ListMissingPvtData", filter).Return([]*ledger.MissingPvtDataEntry{
+		{BlockNum: 5, Namespace: "ns1", Collection: "col1", TxNums: []uint64{0, 1}, Eligible: true},
+		{BlockNum: 4, Namespace: "ns1", Collection: "col1", TxNums: []uint64{3}, Eligible: true},
+		{BlockNum: 3, Namespace: "ns1", Collection: "col2", TxNums: []uint64{0}, Eligible: false},
+		{BlockNum: 2, Namespace: "ns1", Collection: "col1", TxNums: []uint64{2}, Eligible: true},
+	}, nil)
+
+	collectionConfigInfo := ledger.CollectionConfigInfo{
+		CollectionConfig: &peer.CollectionConfigPackage{
+			Config: []*peer.CollectionConfig{
+				{Payload: &peer.CollectionConfig_StaticCollectionConfig{
+					StaticCollectionConfig: &peer.StaticCollectionConfig{
+						Name: "col1",
+					},
+				}},
+			},
+		},
+		CommittingBlockNum: 1,
+	}
+	configHistoryRetriever.On("MostRecentCollectionConfigBelow", mock.Anything, mock.Anything).Return(&collectionConfigInfo, nil)
+	committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+	committer.On("GetConfigHistoryRetriever").Return(configHistoryRetriever, nil)
+
+	var requestedBlocks [][]uint64
+	fetcher.On("FetchReconciledItems", mock.Anything).Return(func(dig2CollectionConfig privdatacommon.Dig2CollectionConfig) *privdatacommon.FetchedPvtDataContainer {
+		result := &privdatacommon.FetchedPvtDataContainer{}
+		blocks := map[uint64]struct{}{}
+		for digest := range dig2CollectionConfig {
+			blocks[digest.BlockSeq] = struct{}{}
+			if digest.BlockSeq == 2 {
+				continue
+			}
+			result.AvailableElements = append(result.AvailableElements, &gossip2.PvtDataElement{
+				Digest: &gossip2.PvtDataDigest{
+					BlockSeq:   digest.BlockSeq,
+					Collection: digest.Collection,
+					Namespace:  digest.Namespace,
+					SeqInBlock: digest.SeqInBlock,
+				},
+				Payload: [][]byte{util2.ComputeSHA256([]byte("rws-pre-image"))},
+			})
+		}
+		var blockNums []uint64
+		for blockNum := range blocks {
+			blockNums = append(blockNums, blockNum)
+		}
+		sort.Slice(blockNums, func(i, j int) bool { return blockNums[i] > blockNums[j] })
+		requestedBlocks = append(requestedBlocks, blockNums)
+		return result
+	}, nil)
+	committer.On("CommitPvtDataOfOldBlocks", mock.Anything, mock.Anything).Return([]*ledger.PvtdataHashMismatch{}, nil)
+
+	testMetricProvider := gmetricsmocks.TestUtilConstructMetricProvider()
+	metrics := metrics.NewGossipMetrics(testMetricProvider.FakeProvider).PrivdataMetrics
+
+	r := &Reconciler{
+		channel:                "mychannel",
+		logger:                 logger.With("channel", "mychannel"),
+		metrics:                metrics,
+		ReconcileSleepInterval: time.Minute,
+		ReconcileBatchSize:     2,
+		ReconciliationFetcher:  fetcher,
+		Committer:              committer,
+		stopChan:               make(chan struct{}),
+	}
+
+	var reported []ReconcileProgress
+	progress, err := r.ReconcileMissingPvtData(filter, func(p ReconcileProgress) {
+		reported = append(reported, p)
+	})
+	require.NoError(t, err)
+	require.Equal(t, [][]uint64{{5, 4}, {2}}, requestedBlocks)
+	require.Equal(t, []ReconcileProgress{
+		{TotalBlocks: 3, ProcessedBlocks: 2, Reconciled: 3},
+		{TotalBlocks: 3, ProcessedBlocks: 3, Reconciled: 3, Unreconciled: 1},
+	}, reported)
+	require.Equal(t, reported[1], progress)
+	committer.AssertNumberOfCalls(t, "CommitPvtDataOfOldBlocks", 2)
+
+	t.Run("stopped reconciler", func(t *testing.T) {
+		r.Stop()
+		progress, err := r.ReconcileMissingPvtData(filter, nil)
+		require.EqualError(t, err, "reconciler stopped")
+		require.Equal(t, ReconcileProgress{TotalBlocks: 3}, progress)
+	})
+
+	t.Run("listing fails", func(t *testing.T) {
+		missingPvtDataTracker := &mocks.MissingPvtDataTracker{}
+		missingPvtDataTracker.On("ListMissingPvtData", mock.Anything).Return(nil, errors.New("db failure"))
+		committer := &mocks.Committer{}
+		committer.On("GetMissingPvtDataTracker").Return(missingPvtDataTracker, nil)
+		r := &Reconciler{
+			logger:    logger.With("channel", "mychannel"),
+			metrics:   metrics,
+			Committer: committer,
+			stopChan:  make(chan struct{}),
+		}
+		_, err := r.ReconcileMissingPvtData(nil, nil)
+		require.EqualError(t, err, "failed to list missing private data: db failure")
+	})
+}
+
 func TestConstructUnreconciledMissingData(t *testing.T) {
 	requestedMissingData := privdatacommon.Dig2CollectionConfig{
 		privdatacommon.DigKey{
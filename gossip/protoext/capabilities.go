@@ -0,0 +1,40 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoext
+
+import (
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// GetCapabilities returns the version and the supported capabilities of the
+// peer publishing the given Properties, as set by SetCapabilities.
+// Returns nil if the peer doesn't publish them, and an error in case the
+// operation fails.
+func GetCapabilities(props *gossip.Properties) (*PropertiesCapabilities, error) {
+	if props == nil {
+		return nil, nil
+	}
+	pc := &PropertiesCapabilities{}
+	found, err := protoutil.GetExtension(props, protoutil.PeerCapabilitiesExtension, pc)
+	if err != nil || !found {
+		return nil, err
+	}
+	return pc, nil
+}
+
+// SetCapabilities sets the version and the supported capabilities of the
+// peer publishing the given Properties, replacing any previously set.
+// Returns an error in case the operation fails.
+func SetCapabilities(props *gossip.Properties, version string, capabilities map[string][]string) error {
+	return protoutil.SetExtension(props, protoutil.PeerCapabilitiesExtension, &PropertiesCapabilities{
+		Version:     version,
+		Channel:     capabilities["Channel"],
+		Orderer:     capabilities["Orderer"],
+		Application: capabilities["Application"],
+	})
+}
@@ -0,0 +1,61 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoext_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities(t *testing.T) {
+	props := &gossip.Properties{LedgerHeight: 10}
+	pc, err := protoext.GetCapabilities(props)
+	require.NoError(t, err)
+	require.Nil(t, pc)
+
+	pc, err = protoext.GetCapabilities(nil)
+	require.NoError(t, err)
+	require.Nil(t, pc)
+
+	// Labels alone don't make for capabilities
+	require.NoError(t, protoext.SetLabels(props, map[string]string{"datacenter": "dc1"}))
+	pc, err = protoext.GetCapabilities(props)
+	require.NoError(t, err)
+	require.Nil(t, pc)
+
+	err = protoext.SetCapabilities(props, "3.1.0", map[string][]string{
+		"Channel":     {"V2_0", "V3_0"},
+		"Application": {"V2_0"},
+	})
+	require.NoError(t, err)
+
+	// The capabilities and the labels survive a round trip through the wire
+	b, err := proto.Marshal(&gossip.StateInfo{Properties: props})
+	require.NoError(t, err)
+	stateInfo := &gossip.StateInfo{}
+	require.NoError(t, proto.Unmarshal(b, stateInfo))
+
+	pc, err = protoext.GetCapabilities(stateInfo.Properties)
+	require.NoError(t, err)
+	require.Equal(t, "3.1.0", pc.Version)
+	require.Equal(t, []string{"V2_0", "V3_0"}, pc.Channel)
+	require.Empty(t, pc.Orderer)
+	require.Equal(t, []string{"V2_0"}, pc.Application)
+
+	labels, err := protoext.GetLabels(stateInfo.Properties)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"datacenter": "dc1"}, labels)
+
+	props.XXX_unrecognized = []byte{0}
+	_, err = protoext.GetCapabilities(props)
+	require.Error(t, err)
+	require.Error(t, protoext.SetCapabilities(props, "3.1.0", nil))
+}
@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoext
+
+import (
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// GetLabels returns the labels of the peer publishing the given Properties,
+// as set by SetLabels.
+// Returns an error in case the operation fails.
+func GetLabels(props *gossip.Properties) (map[string]string, error) {
+	if props == nil {
+		return nil, nil
+	}
+	pl := &PropertiesLabels{}
+	if _, err := protoutil.GetExtension(props, protoutil.PeerLabelsExtension, pl); err != nil {
+		return nil, err
+	}
+	return pl.Labels, nil
+}
+
+// SetLabels sets the labels of the peer publishing the given Properties,
+// replacing any labels previously set.
+// Returns an error in case the operation fails.
+func SetLabels(props *gossip.Properties, labels map[string]string) error {
+	if len(labels) == 0 {
+		return protoutil.SetExtension(props, protoutil.PeerLabelsExtension, nil)
+	}
+	return protoutil.SetExtension(props, protoutil.PeerLabelsExtension, &PropertiesLabels{Labels: labels})
+}
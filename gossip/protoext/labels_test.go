@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoext_test
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabels(t *testing.T) {
+	props := &gossip.Properties{LedgerHeight: 10}
+	labels, err := protoext.GetLabels(props)
+	require.NoError(t, err)
+	require.Empty(t, labels)
+
+	labels, err = protoext.GetLabels(nil)
+	require.NoError(t, err)
+	require.Empty(t, labels)
+
+	err = protoext.SetLabels(props, map[string]string{"datacenter": "dc1", "zone": "a"})
+	require.NoError(t, err)
+
+	// The labels survive a round trip through the wire
+	b, err := proto.Marshal(&gossip.StateInfo{Properties: props})
+	require.NoError(t, err)
+	stateInfo := &gossip.StateInfo{}
+	require.NoError(t, proto.Unmarshal(b, stateInfo))
+	require.Equal(t, uint64(10), stateInfo.Properties.LedgerHeight)
+
+	labels, err = protoext.GetLabels(stateInfo.Properties)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"datacenter": "dc1", "zone": "a"}, labels)
+
+	// Setting the labels replaces the previous ones
+	require.NoError(t, protoext.SetLabels(props, map[string]string{"datacenter": "dc2"}))
+	labels, err = protoext.GetLabels(props)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"datacenter": "dc2"}, labels)
+
+	props.XXX_unrecognized = []byte{0}
+	_, err = protoext.GetLabels(props)
+	require.Error(t, err)
+	require.Error(t, protoext.SetLabels(props, nil))
+}
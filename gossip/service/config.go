@@ -40,6 +40,12 @@ type ServiceConfig struct {
 	// ElectionLeaderElectionDuration is the time passes since last declaration message before peer decides to perform
 	// leader election (unit: second).
 	ElectionLeaderElectionDuration time.Duration
+	// ElectionPriorities maps the endpoints of the peers of the organization to their
+	// election priority. Peers with a higher priority are preferred as leaders.
+	ElectionPriorities map[string]int
+	// ElectionPinnedLeaders maps channels to the endpoint of the peer preferred as their
+	// leader over all other peers.
+	ElectionPinnedLeaders map[string]string
 	// PvtDataPullRetryThreshold determines the maximum duration of time private data corresponding for
 	// a given block.
 	PvtDataPullRetryThreshold time.Duration
@@ -76,6 +82,7 @@ func (c *ServiceConfig) loadGossipConfig() {
 	c.ElectionMembershipSampleInterval = util.GetDurationOrDefault("peer.gossip.election.membershipSampleInterval", election.DefMembershipSampleInterval)
 	c.ElectionLeaderAliveThreshold = util.GetDurationOrDefault("peer.gossip.election.leaderAliveThreshold", election.DefLeaderAliveThreshold)
 	c.ElectionLeaderElectionDuration = util.GetDurationOrDefault("peer.gossip.election.leaderElectionDuration", election.DefLeaderElectionDuration)
+	c.loadElectionPriorities()
 
 	c.PvtDataPushAckTimeout = viper.GetDuration("peer.gossip.pvtData.pushAckTimeout")
 	c.PvtDataPullRetryThreshold = viper.GetDuration("peer.gossip.pvtData.pullRetryThreshold")
@@ -95,3 +102,29 @@ func (c *ServiceConfig) loadGossipConfig() {
 		c.TransientstoreMaxBlockRetention = transientBlockRetentionDefault
 	}
 }
+
+func (c *ServiceConfig) loadElectionPriorities() {
+	var priorities []struct {
+		Endpoint string
+		Priority int
+	}
+	if err := viper.UnmarshalKey("peer.gossip.election.priorities", &priorities); err != nil {
+		logger.Warningf("Failed loading peer.gossip.election.priorities, ignoring election priorities: %s", err)
+	}
+	c.ElectionPriorities = map[string]int{}
+	for _, p := range priorities {
+		c.ElectionPriorities[p.Endpoint] = p.Priority
+	}
+
+	var pinnedLeaders []struct {
+		Channel  string
+		Endpoint string
+	}
+	if err := viper.UnmarshalKey("peer.gossip.election.pinnedLeaders", &pinnedLeaders); err != nil {
+		logger.Warningf("Failed loading peer.gossip.election.pinnedLeaders, ignoring pinned leaders: %s", err)
+	}
+	c.ElectionPinnedLeaders = map[string]string{}
+	for _, p := range pinnedLeaders {
+		c.ElectionPinnedLeaders[p.Channel] = p.Endpoint
+	}
+}
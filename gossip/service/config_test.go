@@ -31,6 +31,13 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.pvtData.btlPullMargin", 15)
 	viper.Set("peer.gossip.pvtData.transientstoreMaxBlockRetention", 1000)
 	viper.Set("peer.gossip.pvtData.skipPullingInvalidTransactionsDuringCommit", false)
+	viper.Set("peer.gossip.election.priorities", []map[string]interface{}{
+		{"endpoint": "peer0.org1.example.com:7051", "priority": 10},
+		{"endpoint": "peer1.org1.example.com:7051", "priority": 5},
+	})
+	viper.Set("peer.gossip.election.pinnedLeaders", []map[string]interface{}{
+		{"channel": "mychannel", "endpoint": "peer1.org1.example.com:7051"},
+	})
 
 	coreConfig := service.GlobalConfig()
 
@@ -49,6 +56,13 @@ func TestGlobalConfig(t *testing.T) {
 		BtlPullMargin:                              15,
 		TransientstoreMaxBlockRetention:            uint64(1000),
 		SkipPullingInvalidTransactionsDuringCommit: false,
+		ElectionPriorities: map[string]int{
+			"peer0.org1.example.com:7051": 10,
+			"peer1.org1.example.com:7051": 5,
+		},
+		ElectionPinnedLeaders: map[string]string{
+			"mychannel": "peer1.org1.example.com:7051",
+		},
 	}
 
 	require.Equal(t, coreConfig, expectedConfig)
@@ -8,6 +8,7 @@ package service
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	gproto "github.com/hyperledger/fabric-protos-go/gossip"
@@ -17,6 +18,7 @@ import (
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/deliverservice"
+	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
@@ -61,6 +63,12 @@ type gossipSvc interface {
 	// and also subscribed to the channel given
 	PeersOfChannel(common.ChannelID) []discovery.NetworkMember
 
+	// DeadPeers returns the NetworkMembers considered dead
+	DeadPeers() []discovery.NetworkMember
+
+	// Connections returns statistics of the open connections to remote peers
+	Connections() []comm.ConnectionStats
+
 	// UpdateMetadata updates the self metadata of the discovery layer
 	// the peer publishes to other peers
 	UpdateMetadata(metadata []byte)
@@ -153,11 +161,15 @@ type privateHandler struct {
 	coordinator gossipprivdata.Coordinator
 	distributor gossipprivdata.PvtDataDistributor
 	reconciler  gossipprivdata.PvtDataReconciler
+	// onDemandReconciler reconciles missing private data at the request of
+	// an operator, even when periodic reconciliation is disabled
+	onDemandReconciler *gossipprivdata.Reconciler
 }
 
 func (p privateHandler) close() {
 	p.coordinator.Close()
 	p.reconciler.Stop()
+	p.onDemandReconciler.Stop()
 }
 
 // GossipService handles the interaction between gossip service and peer
@@ -358,20 +370,22 @@ func (g *GossipService) InitializeChannel(channelID string, ordererSource *order
 		support.IdDeserializeFactory)
 
 	var reconciler gossipprivdata.PvtDataReconciler
+	onDemandReconciler := gossipprivdata.NewReconciler(channelID, g.metrics.PrivdataMetrics,
+		support.Committer, fetcher, g.privdataConfig)
 
 	if g.privdataConfig.ReconciliationEnabled {
-		reconciler = gossipprivdata.NewReconciler(channelID, g.metrics.PrivdataMetrics,
-			support.Committer, fetcher, g.privdataConfig)
+		reconciler = onDemandReconciler
 	} else {
 		reconciler = &gossipprivdata.NoOpReconciler{}
 	}
 
 	pushAckTimeout := g.serviceConfig.PvtDataPushAckTimeout
 	g.privateHandlers[channelID] = privateHandler{
-		support:     support,
-		coordinator: coordinator,
-		distributor: gossipprivdata.NewDistributor(channelID, g, collectionAccessFactory, g.metrics.PrivdataMetrics, pushAckTimeout),
-		reconciler:  reconciler,
+		support:            support,
+		coordinator:        coordinator,
+		distributor:        gossipprivdata.NewDistributor(channelID, g, collectionAccessFactory, g.metrics.PrivdataMetrics, pushAckTimeout),
+		reconciler:         reconciler,
+		onDemandReconciler: onDemandReconciler,
 	}
 	g.privateHandlers[channelID].reconciler.Start()
 
@@ -470,6 +484,107 @@ func (g *GossipService) AddPayload(channelID string, payload *gproto.Payload) er
 	return g.chains[channelID].AddPayload(payload)
 }
 
+// Leadership describes which peer of the organization pulls the blocks of a
+// channel from the ordering service.
+type Leadership struct {
+	Elected  bool             // whether the leader is elected rather than statically configured
+	IsLeader bool             // whether this peer pulls the blocks from the ordering service
+	Leader   common.PKIidType // the PKI-ID of the leader, if known
+}
+
+// Channels returns the IDs of the channels initialized in the gossip service
+func (g *GossipService) Channels() []string {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	channels := make([]string, 0, len(g.chains))
+	for channelID := range g.chains {
+		channels = append(channels, channelID)
+	}
+	sort.Strings(channels)
+	return channels
+}
+
+// Leadership returns the leadership of a channel, and false if the channel
+// hasn't been initialized in the gossip service
+func (g *GossipService) Leadership(channelID string) (Leadership, bool) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, exists := g.chains[channelID]; !exists {
+		return Leadership{}, false
+	}
+
+	if le, exists := g.leaderElection[channelID]; exists {
+		return Leadership{
+			Elected:  true,
+			IsLeader: le.IsLeader(),
+			Leader:   le.Leader(),
+		}, true
+	}
+
+	// without leader election, a peer configured as the static
+	// org leader pulls blocks whenever it has a delivery service
+	if g.serviceConfig.OrgLeader && g.deliveryService[channelID] != nil {
+		return Leadership{
+			IsLeader: true,
+			Leader:   g.mcs.GetPKIidOfCert(g.peerIdentity),
+		}, true
+	}
+	return Leadership{}, true
+}
+
+// Reelect makes the peer relinquish its leadership of the channel,
+// so that a new leader is elected among the peers of the organization
+func (g *GossipService) Reelect(channelID string) error {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	if _, exists := g.chains[channelID]; !exists {
+		return errors.Errorf("channel %s does not exist", channelID)
+	}
+	le, exists := g.leaderElection[channelID]
+	if !exists {
+		return errors.Errorf("leader election is not enabled for channel %s", channelID)
+	}
+	if !le.IsLeader() {
+		return errors.Errorf("peer is not the leader of channel %s, the leader is %s", channelID, common.PKIidType(le.Leader()))
+	}
+	le.Yield()
+	return nil
+}
+
+// ListMissingPvtData returns the private data of the channel missing on the peer
+// which matches the filter
+func (g *GossipService) ListMissingPvtData(channelID string, filter *ledger.MissingPvtDataFilter) ([]*ledger.MissingPvtDataEntry, error) {
+	g.lock.RLock()
+	handler, exists := g.privateHandlers[channelID]
+	g.lock.RUnlock()
+	if !exists {
+		return nil, errors.Errorf("channel %s not found", channelID)
+	}
+
+	missingPvtDataTracker, err := handler.support.Committer.GetMissingPvtDataTracker()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get MissingPvtDataTracker")
+	}
+	return missingPvtDataTracker.ListMissingPvtData(filter)
+}
+
+// ReconcileMissingPvtData reconciles right away the missing private data of the
+// channel which matches the filter, and reports the progress to the given function
+func (g *GossipService) ReconcileMissingPvtData(channelID string, filter *ledger.MissingPvtDataFilter, progress func(gossipprivdata.ReconcileProgress)) (gossipprivdata.ReconcileProgress, error) {
+	g.lock.RLock()
+	handler, exists := g.privateHandlers[channelID]
+	g.lock.RUnlock()
+	if !exists {
+		return gossipprivdata.ReconcileProgress{}, errors.Errorf("channel %s not found", channelID)
+	}
+
+	// the lock is not held while reconciling, which can take long
+	return handler.onDemandReconciler.ReconcileMissingPvtData(filter, progress)
+}
+
 // Stop stops the gossip component
 func (g *GossipService) Stop() {
 	g.lock.Lock()
@@ -494,7 +609,11 @@ func (g *GossipService) Stop() {
 func (g *GossipService) newLeaderElectionComponent(channelID string, callback func(bool),
 	electionMetrics *gossipmetrics.ElectionMetrics) election.LeaderElectionService {
 	PKIid := g.mcs.GetPKIidOfCert(g.peerIdentity)
-	adapter := election.NewAdapter(g, PKIid, gossipcommon.ChannelID(channelID), electionMetrics)
+	priorities := election.Priorities{
+		ByEndpoint:   g.serviceConfig.ElectionPriorities,
+		PinnedLeader: g.serviceConfig.ElectionPinnedLeaders[channelID],
+	}
+	adapter := election.NewAdapter(g, PKIid, gossipcommon.ChannelID(channelID), electionMetrics, priorities)
 	config := election.ElectionConfig{
 		StartupGracePeriod:       g.serviceConfig.ElectionStartupGracePeriod,
 		MembershipSampleInterval: g.serviceConfig.ElectionMembershipSampleInterval,
@@ -114,7 +114,7 @@ func TestInitGossipService(t *testing.T) {
 	require.NoError(t, err)
 	signer := mgmt.GetLocalSigningIdentityOrPanic(cryptoProvider)
 
-	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(cryptoProvider), cryptoProvider)
+	messageCryptoService := peergossip.NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(cryptoProvider), cryptoProvider, nil)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager(cryptoProvider))
 	gossipConfig, err := gossip.GlobalConfig(endpoint, nil)
 	require.NoError(t, err)
@@ -865,6 +865,93 @@ func (*naiveCryptoService) Verify(peerIdentity api.PeerIdentityType, signature,
 
 var orgInChannelA = api.OrgIdentityType("ORG1")
 
+type leaderElectionMock struct {
+	isLeader bool
+	leader   []byte
+	yielded  bool
+}
+
+func (le *leaderElectionMock) IsLeader() bool {
+	return le.isLeader
+}
+
+func (le *leaderElectionMock) Leader() []byte {
+	return le.leader
+}
+
+func (le *leaderElectionMock) Stop() {
+}
+
+func (le *leaderElectionMock) Yield() {
+	le.yielded = true
+}
+
+func TestLeadership(t *testing.T) {
+	g := &GossipService{
+		mcs:          &naiveCryptoService{},
+		peerIdentity: api.PeerIdentityType("peer0"),
+		chains: map[string]state.GossipStateProvider{
+			"elected":  nil,
+			"static":   nil,
+			"follower": nil,
+		},
+		leaderElection: map[string]election.LeaderElectionService{
+			"elected": &leaderElectionMock{leader: []byte("peer1")},
+		},
+		deliveryService: map[string]deliverservice.DeliverService{
+			"static": &mockDeliverService{},
+		},
+		serviceConfig: &ServiceConfig{OrgLeader: true},
+	}
+
+	require.Equal(t, []string{"elected", "follower", "static"}, g.Channels())
+
+	_, exists := g.Leadership("unknown")
+	require.False(t, exists)
+
+	leadership, exists := g.Leadership("elected")
+	require.True(t, exists)
+	require.Equal(t, Leadership{Elected: true, Leader: gossipcommon.PKIidType("peer1")}, leadership)
+
+	leadership, exists = g.Leadership("static")
+	require.True(t, exists)
+	require.Equal(t, Leadership{IsLeader: true, Leader: gossipcommon.PKIidType("peer0")}, leadership)
+
+	leadership, exists = g.Leadership("follower")
+	require.True(t, exists)
+	require.Equal(t, Leadership{}, leadership)
+}
+
+func TestReelect(t *testing.T) {
+	leader := &leaderElectionMock{isLeader: true, leader: []byte("peer0")}
+	follower := &leaderElectionMock{leader: []byte("peer1")}
+	g := &GossipService{
+		chains: map[string]state.GossipStateProvider{
+			"leader":   nil,
+			"follower": nil,
+			"static":   nil,
+		},
+		leaderElection: map[string]election.LeaderElectionService{
+			"leader":   leader,
+			"follower": follower,
+		},
+	}
+
+	err := g.Reelect("unknown")
+	require.EqualError(t, err, "channel unknown does not exist")
+
+	err = g.Reelect("static")
+	require.EqualError(t, err, "leader election is not enabled for channel static")
+
+	err = g.Reelect("follower")
+	require.EqualError(t, err, "peer is not the leader of channel follower, the leader is 7065657231")
+	require.False(t, follower.yielded)
+
+	err = g.Reelect("leader")
+	require.NoError(t, err)
+	require.True(t, leader.yielded)
+}
+
 func TestInvalidInitialization(t *testing.T) {
 	grpcServer := grpc.NewServer()
 	endpoint, socket := getAvailablePort(t)
@@ -0,0 +1,84 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/gossip/service"
+)
+
+//go:generate counterfeiter -o fakes/leadership_service.go -fake-name LeadershipService . LeadershipService
+
+// LeadershipService is the part of the gossip service that reports and
+// relinquishes the leadership of channels.
+type LeadershipService interface {
+	Leadership(channelID string) (service.Leadership, bool)
+	Reelect(channelID string) error
+}
+
+func NewLeadershipHandler(leadershipService LeadershipService) *LeadershipHandler {
+	return &LeadershipHandler{
+		LeadershipService: leadershipService,
+		Logger:            flogging.MustGetLogger("gossip.httpadmin"),
+	}
+}
+
+// LeadershipHandler forces the re-election of the leader of a channel on
+// POST, provided this peer is the elected leader. The channel query
+// parameter is required.
+type LeadershipHandler struct {
+	LeadershipService LeadershipService
+	Logger            *flogging.FabricLogger
+}
+
+func (h *LeadershipHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		err := fmt.Errorf("invalid request method: %s", req.Method)
+		h.sendResponse(resp, http.StatusBadRequest, err)
+		return
+	}
+
+	channelID := req.URL.Query().Get("channel")
+	if channelID == "" {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("channel is required"))
+		return
+	}
+	leadership, exists := h.LeadershipService.Leadership(channelID)
+	if !exists {
+		h.sendResponse(resp, http.StatusNotFound, fmt.Errorf("channel %s not found", channelID))
+		return
+	}
+	if !leadership.Elected {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("leader election is not enabled for channel %s", channelID))
+		return
+	}
+
+	if err := h.LeadershipService.Reelect(channelID); err != nil {
+		h.sendResponse(resp, http.StatusConflict, err)
+		return
+	}
+	h.Logger.Infof("Relinquished the leadership of channel %s on request", channelID)
+	resp.WriteHeader(http.StatusAccepted)
+}
+
+func (h *LeadershipHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
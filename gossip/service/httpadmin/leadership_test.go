@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/gossip/service/httpadmin"
+	"github.com/hyperledger/fabric/gossip/service/httpadmin/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LeadershipHandler", func() {
+	var (
+		fakeLeadershipService *fakes.LeadershipService
+		handler               *httpadmin.LeadershipHandler
+	)
+
+	BeforeEach(func() {
+		fakeLeadershipService = &fakes.LeadershipService{}
+		fakeLeadershipService.LeadershipReturns(service.Leadership{Elected: true, IsLeader: true}, true)
+
+		handler = httpadmin.NewLeadershipHandler(fakeLeadershipService)
+	})
+
+	It("forces the re-election of the leader of the channel", func() {
+		req := httptest.NewRequest(http.MethodPost, "/gossip/leadership?channel=mychannel", nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		Expect(resp.Result().StatusCode).To(Equal(http.StatusAccepted))
+		Expect(fakeLeadershipService.ReelectCallCount()).To(Equal(1))
+		Expect(fakeLeadershipService.ReelectArgsForCall(0)).To(Equal("mychannel"))
+	})
+
+	Context("when the peer isn't the leader", func() {
+		BeforeEach(func() {
+			fakeLeadershipService.ReelectReturns(errors.New("peer is not the leader of channel mychannel"))
+		})
+
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest(http.MethodPost, "/gossip/leadership?channel=mychannel", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Result().StatusCode).To(Equal(http.StatusConflict))
+			Expect(resp.Body).To(MatchJSON(`{"error": "peer is not the leader of channel mychannel"}`))
+		})
+	})
+
+	Context("when the leader of the channel isn't elected", func() {
+		BeforeEach(func() {
+			fakeLeadershipService.LeadershipReturns(service.Leadership{IsLeader: true}, true)
+		})
+
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest(http.MethodPost, "/gossip/leadership?channel=mychannel", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Result().StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "leader election is not enabled for channel mychannel"}`))
+			Expect(fakeLeadershipService.ReelectCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the channel doesn't exist", func() {
+		BeforeEach(func() {
+			fakeLeadershipService.LeadershipReturns(service.Leadership{}, false)
+		})
+
+		It("responds with a not found error", func() {
+			req := httptest.NewRequest(http.MethodPost, "/gossip/leadership?channel=unknown", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Result().StatusCode).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "channel unknown not found"}`))
+		})
+	})
+
+	It("requires the channel", func() {
+		req := httptest.NewRequest(http.MethodPost, "/gossip/leadership", nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		Expect(resp.Result().StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(resp.Body).To(MatchJSON(`{"error": "channel is required"}`))
+	})
+
+	It("rejects methods other than POST", func() {
+		req := httptest.NewRequest(http.MethodGet, "/gossip/leadership?channel=mychannel", nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		Expect(resp.Result().StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(resp.Body).To(MatchJSON(`{"error": "invalid request method: GET"}`))
+	})
+})
@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/gossip/privdata"
+)
+
+//go:generate counterfeiter -o fakes/pvtdata_service.go -fake-name PvtDataService . PvtDataService
+
+// PvtDataService is the part of the gossip service that reports and
+// reconciles missing private data.
+type PvtDataService interface {
+	Channels() []string
+	ListMissingPvtData(channelID string, filter *ledger.MissingPvtDataFilter) ([]*ledger.MissingPvtDataEntry, error)
+	ReconcileMissingPvtData(channelID string, filter *ledger.MissingPvtDataFilter, progress func(privdata.ReconcileProgress)) (privdata.ReconcileProgress, error)
+}
+
+// MissingPvtData lists the transactions of a block missing the private data
+// of a collection.
+type MissingPvtData struct {
+	BlockNum   uint64   `json:"block_num"`
+	Namespace  string   `json:"namespace"`
+	Collection string   `json:"collection"`
+	TxNums     []uint64 `json:"tx_nums"`
+}
+
+// MissingPvtDataReport is the private data of a channel missing on this
+// peer, split by whether the peer is eligible for the collection.
+type MissingPvtDataReport struct {
+	Channel    string           `json:"channel"`
+	Eligible   []MissingPvtData `json:"eligible"`
+	Ineligible []MissingPvtData `json:"ineligible"`
+}
+
+// ReconcileProgress is written after every batch of blocks reconciled.
+// The last one written is marked as done, along with the error which
+// stopped the reconciliation, if any.
+type ReconcileProgress struct {
+	privdata.ReconcileProgress
+	Done  bool   `json:"done"`
+	Error string `json:"error,omitempty"`
+}
+
+func NewPvtDataHandler(pvtDataService PvtDataService) *PvtDataHandler {
+	return &PvtDataHandler{
+		PvtDataService: pvtDataService,
+		Logger:         flogging.MustGetLogger("gossip.httpadmin"),
+	}
+}
+
+// PvtDataHandler reports the missing private data of a channel on GET, and
+// reconciles it right away on POST. The channel query parameter is
+// required, while the start, end, namespace and collection parameters
+// restrict the blocks, namespace and collection reported or reconciled.
+type PvtDataHandler struct {
+	PvtDataService PvtDataService
+	Logger         *flogging.FabricLogger
+}
+
+func (h *PvtDataHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodPost {
+		err := fmt.Errorf("invalid request method: %s", req.Method)
+		h.sendResponse(resp, http.StatusBadRequest, err)
+		return
+	}
+
+	query := req.URL.Query()
+	channelID := query.Get("channel")
+	if channelID == "" {
+		h.sendResponse(resp, http.StatusBadRequest, fmt.Errorf("channel is required"))
+		return
+	}
+	if !h.channelExists(channelID) {
+		h.sendResponse(resp, http.StatusNotFound, fmt.Errorf("channel %s not found", channelID))
+		return
+	}
+	filter, err := missingPvtDataFilter(query)
+	if err != nil {
+		h.sendResponse(resp, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.Method == http.MethodPost {
+		h.reconcile(resp, channelID, filter)
+		return
+	}
+
+	entries, err := h.PvtDataService.ListMissingPvtData(channelID, filter)
+	if err != nil {
+		h.sendResponse(resp, http.StatusInternalServerError, err)
+		return
+	}
+	report := &MissingPvtDataReport{
+		Channel:    channelID,
+		Eligible:   []MissingPvtData{},
+		Ineligible: []MissingPvtData{},
+	}
+	for _, entry := range entries {
+		missing := MissingPvtData{
+			BlockNum:   entry.BlockNum,
+			Namespace:  entry.Namespace,
+			Collection: entry.Collection,
+			TxNums:     entry.TxNums,
+		}
+		if entry.Eligible {
+			report.Eligible = append(report.Eligible, missing)
+		} else {
+			report.Ineligible = append(report.Ineligible, missing)
+		}
+	}
+	h.sendResponse(resp, http.StatusOK, report)
+}
+
+// reconcile streams the progress of the reconciliation as a sequence of
+// JSON objects, one per line.
+func (h *PvtDataHandler) reconcile(resp http.ResponseWriter, channelID string, filter *ledger.MissingPvtDataFilter) {
+	resp.Header().Set("Content-Type", "application/x-ndjson")
+	resp.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(resp)
+	write := func(progress *ReconcileProgress) {
+		if err := encoder.Encode(progress); err != nil {
+			h.Logger.Errorw("failed to encode payload", "error", err)
+			return
+		}
+		if flusher, ok := resp.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+
+	p, err := h.PvtDataService.ReconcileMissingPvtData(channelID, filter, func(p privdata.ReconcileProgress) {
+		write(&ReconcileProgress{ReconcileProgress: p})
+	})
+	final := &ReconcileProgress{ReconcileProgress: p, Done: true}
+	if err != nil {
+		h.Logger.Warningf("On-demand reconciliation of channel %s failed: %s", channelID, err)
+		final.Error = err.Error()
+	}
+	write(final)
+}
+
+func (h *PvtDataHandler) channelExists(channelID string) bool {
+	for _, c := range h.PvtDataService.Channels() {
+		if c == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *PvtDataHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
+
+func missingPvtDataFilter(query url.Values) (*ledger.MissingPvtDataFilter, error) {
+	filter := &ledger.MissingPvtDataFilter{
+		Namespace:  query.Get("namespace"),
+		Collection: query.Get("collection"),
+	}
+	for param, blockNum := range map[string]*uint64{"start": &filter.StartBlock, "end": &filter.EndBlock} {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s block number: %s", param, value)
+		}
+		*blockNum = n
+	}
+	if filter.EndBlock != 0 && filter.StartBlock > filter.EndBlock {
+		return nil, fmt.Errorf("start block %d is greater than end block %d", filter.StartBlock, filter.EndBlock)
+	}
+	return filter, nil
+}
@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/gossip/privdata"
+	"github.com/hyperledger/fabric/gossip/service/httpadmin"
+	"github.com/hyperledger/fabric/gossip/service/httpadmin/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PvtDataHandler", func() {
+	var (
+		fakePvtDataService *fakes.PvtDataService
+		handler            *httpadmin.PvtDataHandler
+	)
+
+	BeforeEach(func() {
+		fakePvtDataService = &fakes.PvtDataService{}
+		fakePvtDataService.ChannelsReturns([]string{"mychannel"})
+		fakePvtDataService.ListMissingPvtDataReturns([]*ledger.MissingPvtDataEntry{
+			{BlockNum: 7, Namespace: "cc", Collection: "coll1", TxNums: []uint64{0, 3}, Eligible: true},
+			{BlockNum: 5, Namespace: "cc", Collection: "coll2", TxNums: []uint64{1}},
+		}, nil)
+		fakePvtDataService.ReconcileMissingPvtDataStub = func(channelID string, filter *ledger.MissingPvtDataFilter, progress func(privdata.ReconcileProgress)) (privdata.ReconcileProgress, error) {
+			progress(privdata.ReconcileProgress{TotalBlocks: 2, ProcessedBlocks: 1, Reconciled: 2})
+			progress(privdata.ReconcileProgress{TotalBlocks: 2, ProcessedBlocks: 2, Reconciled: 2, Unreconciled: 1})
+			return privdata.ReconcileProgress{TotalBlocks: 2, ProcessedBlocks: 2, Reconciled: 2, Unreconciled: 1}, nil
+		}
+
+		handler = httpadmin.NewPvtDataHandler(fakePvtDataService)
+	})
+
+	It("responds with the missing private data of the channel", func() {
+		req := httptest.NewRequest(http.MethodGet, "/pvtdata?channel=mychannel&start=2&end=9&namespace=cc&collection=coll1", nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		Expect(resp.Result().StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Result().Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(resp.Body).To(MatchJSON(`{
+			"channel": "mychannel",
+			"eligible": [{"block_num": 7, "namespace": "cc", "collection": "coll1", "tx_nums": [0, 3]}],
+			"ineligible": [{"block_num": 5, "namespace": "cc", "collection": "coll2", "tx_nums": [1]}]
+		}`))
+
+		Expect(fakePvtDataService.ListMissingPvtDataCallCount()).To(Equal(1))
+		channelID, filter := fakePvtDataService.ListMissingPvtDataArgsForCall(0)
+		Expect(channelID).To(Equal("mychannel"))
+		Expect(filter).To(Equal(&ledger.MissingPvtDataFilter{StartBlock: 2, EndBlock: 9, Namespace: "cc", Collection: "coll1"}))
+	})
+
+	Context("when listing the missing private data fails", func() {
+		BeforeEach(func() {
+			fakePvtDataService.ListMissingPvtDataReturns(nil, errors.New("db failure"))
+		})
+
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest(http.MethodGet, "/pvtdata?channel=mychannel", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Result().StatusCode).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body).To(MatchJSON(`{"error": "db failure"}`))
+		})
+	})
+
+	It("reconciles the missing private data and streams the progress", func() {
+		req := httptest.NewRequest(http.MethodPost, "/pvtdata?channel=mychannel&collection=coll1", nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		Expect(resp.Result().StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Result().Header.Get("Content-Type")).To(Equal("application/x-ndjson"))
+		lines := strings.Split(strings.TrimSpace(resp.Body.String()), "\n")
+		Expect(lines).To(HaveLen(3))
+		Expect(lines[0]).To(MatchJSON(`{"total_blocks": 2, "processed_blocks": 1, "reconciled": 2, "unreconciled": 0, "done": false}`))
+		Expect(lines[1]).To(MatchJSON(`{"total_blocks": 2, "processed_blocks": 2, "reconciled": 2, "unreconciled": 1, "done": false}`))
+		Expect(lines[2]).To(MatchJSON(`{"total_blocks": 2, "processed_blocks": 2, "reconciled": 2, "unreconciled": 1, "done": true}`))
+
+		Expect(fakePvtDataService.ReconcileMissingPvtDataCallCount()).To(Equal(1))
+		channelID, filter, _ := fakePvtDataService.ReconcileMissingPvtDataArgsForCall(0)
+		Expect(channelID).To(Equal("mychannel"))
+		Expect(filter).To(Equal(&ledger.MissingPvtDataFilter{Collection: "coll1"}))
+	})
+
+	Context("when reconciliation fails", func() {
+		BeforeEach(func() {
+			fakePvtDataService.ReconcileMissingPvtDataStub = nil
+			fakePvtDataService.ReconcileMissingPvtDataReturns(privdata.ReconcileProgress{TotalBlocks: 4}, errors.New("reconciler stopped"))
+		})
+
+		It("reports the error in the last line", func() {
+			req := httptest.NewRequest(http.MethodPost, "/pvtdata?channel=mychannel", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Result().StatusCode).To(Equal(http.StatusOK))
+			Expect(resp.Body).To(MatchJSON(`{"total_blocks": 4, "processed_blocks": 0, "reconciled": 0, "unreconciled": 0, "done": true, "error": "reconciler stopped"}`))
+		})
+	})
+
+	Context("when the channel is not provided", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest(http.MethodGet, "/pvtdata", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Result().StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "channel is required"}`))
+		})
+	})
+
+	Context("when the channel does not exist", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest(http.MethodPost, "/pvtdata?channel=missing", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Result().StatusCode).To(Equal(http.StatusNotFound))
+			Expect(resp.Body).To(MatchJSON(`{"error": "channel missing not found"}`))
+			Expect(fakePvtDataService.ReconcileMissingPvtDataCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the block range is invalid", func() {
+		It("responds with an error payload", func() {
+			req := httptest.NewRequest(http.MethodGet, "/pvtdata?channel=mychannel&start=x", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Result().StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "invalid start block number: x"}`))
+
+			req = httptest.NewRequest(http.MethodGet, "/pvtdata?channel=mychannel&start=9&end=3", nil)
+			resp = httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Result().StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "start block 9 is greater than end block 3"}`))
+		})
+	})
+
+	Context("when an unsupported method is used", func() {
+		It("responds with an error", func() {
+			req := httptest.NewRequest(http.MethodPut, "/pvtdata?channel=mychannel", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Result().StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "invalid request method: PUT"}`))
+		})
+	})
+})
@@ -0,0 +1,242 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/gossip/service"
+)
+
+//go:generate counterfeiter -o fakes/gossip_service.go -fake-name GossipService . GossipService
+
+// GossipService is the part of the gossip service that the status is
+// gathered from.
+type GossipService interface {
+	SelfMembershipInfo() discovery.NetworkMember
+	Peers() []discovery.NetworkMember
+	DeadPeers() []discovery.NetworkMember
+	Connections() []comm.ConnectionStats
+	Channels() []string
+	SelfChannelInfo(common.ChannelID) *protoext.SignedGossipMessage
+	PeersOfChannel(common.ChannelID) []discovery.NetworkMember
+	Leadership(channelID string) (service.Leadership, bool)
+}
+
+// Member is a member of the gossip network.
+type Member struct {
+	PKIID            string `json:"pki_id"`
+	Endpoint         string `json:"endpoint"`
+	InternalEndpoint string `json:"internal_endpoint,omitempty"`
+}
+
+// Chaincode is a chaincode a peer has published to the channel.
+type Chaincode struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ChannelPeer is a peer of a channel along with the properties it published
+// in its StateInfo message.
+type ChannelPeer struct {
+	Member
+	LedgerHeight uint64      `json:"ledger_height"`
+	LeftChannel  bool        `json:"left_channel,omitempty"`
+	Chaincodes   []Chaincode `json:"chaincodes,omitempty"`
+}
+
+// Leadership describes which peer of the organization pulls the blocks of
+// the channel from the ordering service.
+type Leadership struct {
+	Elected  bool   `json:"elected"`
+	IsLeader bool   `json:"is_leader"`
+	Leader   string `json:"leader,omitempty"`
+}
+
+// Channel is the view of a channel from this peer.
+type Channel struct {
+	Name       string        `json:"name"`
+	Leadership Leadership    `json:"leadership"`
+	Self       *ChannelPeer  `json:"self,omitempty"`
+	Peers      []ChannelPeer `json:"peers"`
+}
+
+// Connection is an open connection to a remote peer.
+type Connection struct {
+	PKIID          string `json:"pki_id"`
+	Endpoint       string `json:"endpoint"`
+	Outbound       bool   `json:"outbound"`
+	QueuedMessages int    `json:"queued_messages"`
+}
+
+// Status is the membership and channel topology of the gossip network as
+// seen by this peer.
+type Status struct {
+	Self        Member       `json:"self"`
+	Alive       []Member     `json:"alive"`
+	Dead        []Member     `json:"dead"`
+	Channels    []Channel    `json:"channels"`
+	Connections []Connection `json:"connections"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func NewStatusHandler(gossipService GossipService) *StatusHandler {
+	return &StatusHandler{
+		GossipService: gossipService,
+		Logger:        flogging.MustGetLogger("gossip.httpadmin"),
+	}
+}
+
+// StatusHandler serves the Status of the gossip service. The channels
+// reported can be restricted to a single one with the channel query
+// parameter.
+type StatusHandler struct {
+	GossipService GossipService
+	Logger        *flogging.FabricLogger
+}
+
+func (h *StatusHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		err := fmt.Errorf("invalid request method: %s", req.Method)
+		h.sendResponse(resp, http.StatusBadRequest, err)
+		return
+	}
+
+	var channels []string
+	if channelID := req.URL.Query().Get("channel"); channelID != "" {
+		if _, exists := h.GossipService.Leadership(channelID); !exists {
+			err := fmt.Errorf("channel %s not found", channelID)
+			h.sendResponse(resp, http.StatusNotFound, err)
+			return
+		}
+		channels = []string{channelID}
+	} else {
+		channels = h.GossipService.Channels()
+	}
+
+	status := &Status{
+		Self:        member(h.GossipService.SelfMembershipInfo()),
+		Alive:       members(h.GossipService.Peers()),
+		Dead:        members(h.GossipService.DeadPeers()),
+		Channels:    []Channel{},
+		Connections: connections(h.GossipService.Connections()),
+	}
+	for _, channelID := range channels {
+		leadership, exists := h.GossipService.Leadership(channelID)
+		if !exists {
+			continue
+		}
+		channel := Channel{
+			Name: channelID,
+			Leadership: Leadership{
+				Elected:  leadership.Elected,
+				IsLeader: leadership.IsLeader,
+			},
+			Peers: channelPeers(h.GossipService.PeersOfChannel(common.ChannelID(channelID))),
+		}
+		if leadership.Leader != nil {
+			channel.Leadership.Leader = leadership.Leader.String()
+		}
+		if stateInfo := h.GossipService.SelfChannelInfo(common.ChannelID(channelID)); stateInfo != nil && stateInfo.GetStateInfo() != nil {
+			// the channel properties of this peer are only found in its own
+			// StateInfo message
+			self := h.GossipService.SelfMembershipInfo()
+			self.Properties = stateInfo.GetStateInfo().Properties
+			selfPeer := channelPeer(self)
+			channel.Self = &selfPeer
+		}
+		status.Channels = append(status.Channels, channel)
+	}
+
+	h.sendResponse(resp, http.StatusOK, status)
+}
+
+func (h *StatusHandler) sendResponse(resp http.ResponseWriter, code int, payload interface{}) {
+	encoder := json.NewEncoder(resp)
+	if err, ok := payload.(error); ok {
+		payload = &ErrorResponse{Error: err.Error()}
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+
+	if err := encoder.Encode(payload); err != nil {
+		h.Logger.Errorw("failed to encode payload", "error", err)
+	}
+}
+
+func member(m discovery.NetworkMember) Member {
+	return Member{
+		PKIID:            m.PKIid.String(),
+		Endpoint:         m.Endpoint,
+		InternalEndpoint: m.InternalEndpoint,
+	}
+}
+
+// members returns the members sorted by endpoint.
+func members(networkMembers []discovery.NetworkMember) []Member {
+	res := make([]Member, 0, len(networkMembers))
+	for _, m := range networkMembers {
+		res = append(res, member(m))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Endpoint < res[j].Endpoint
+	})
+	return res
+}
+
+func channelPeer(m discovery.NetworkMember) ChannelPeer {
+	peer := ChannelPeer{Member: member(m)}
+	if m.Properties != nil {
+		peer.LedgerHeight = m.Properties.LedgerHeight
+		peer.LeftChannel = m.Properties.LeftChannel
+		for _, cc := range m.Properties.Chaincodes {
+			peer.Chaincodes = append(peer.Chaincodes, Chaincode{Name: cc.Name, Version: cc.Version})
+		}
+	}
+	return peer
+}
+
+// channelPeers returns the peers sorted by endpoint.
+func channelPeers(networkMembers []discovery.NetworkMember) []ChannelPeer {
+	res := make([]ChannelPeer, 0, len(networkMembers))
+	for _, m := range networkMembers {
+		res = append(res, channelPeer(m))
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Endpoint < res[j].Endpoint
+	})
+	return res
+}
+
+// connections returns the connections sorted by endpoint.
+func connections(stats []comm.ConnectionStats) []Connection {
+	res := make([]Connection, 0, len(stats))
+	for _, s := range stats {
+		res = append(res, Connection{
+			PKIID:          s.PKIID.String(),
+			Endpoint:       s.Endpoint,
+			Outbound:       s.Outbound,
+			QueuedMessages: s.QueuedMessages,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Endpoint < res[j].Endpoint
+	})
+	return res
+}
@@ -0,0 +1,169 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package httpadmin_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	gproto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/gossip/service"
+	"github.com/hyperledger/fabric/gossip/service/httpadmin"
+	"github.com/hyperledger/fabric/gossip/service/httpadmin/fakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StatusHandler", func() {
+	var (
+		fakeGossipService *fakes.GossipService
+		handler           *httpadmin.StatusHandler
+	)
+
+	BeforeEach(func() {
+		fakeGossipService = &fakes.GossipService{}
+		fakeGossipService.SelfMembershipInfoReturns(discovery.NetworkMember{
+			PKIid:            common.PKIidType("self"),
+			Endpoint:         "peer0:7051",
+			InternalEndpoint: "peer0.internal:7051",
+		})
+		fakeGossipService.PeersReturns([]discovery.NetworkMember{
+			{PKIid: common.PKIidType("p2"), Endpoint: "peer2:7051"},
+			{PKIid: common.PKIidType("p1"), Endpoint: "peer1:7051"},
+		})
+		fakeGossipService.DeadPeersReturns([]discovery.NetworkMember{
+			{PKIid: common.PKIidType("p3"), Endpoint: "peer3:7051"},
+		})
+		fakeGossipService.ConnectionsReturns([]comm.ConnectionStats{
+			{PKIID: common.PKIidType("p1"), Endpoint: "peer1:7051", Outbound: true, QueuedMessages: 2},
+		})
+		fakeGossipService.ChannelsReturns([]string{"mychannel", "otherchannel"})
+		fakeGossipService.LeadershipStub = func(channelID string) (service.Leadership, bool) {
+			switch channelID {
+			case "mychannel":
+				return service.Leadership{Elected: true, Leader: common.PKIidType("p1")}, true
+			case "otherchannel":
+				return service.Leadership{}, true
+			default:
+				return service.Leadership{}, false
+			}
+		}
+		fakeGossipService.SelfChannelInfoStub = func(channelID common.ChannelID) *protoext.SignedGossipMessage {
+			if string(channelID) != "mychannel" {
+				return nil
+			}
+			return &protoext.SignedGossipMessage{
+				GossipMessage: &gproto.GossipMessage{
+					Content: &gproto.GossipMessage_StateInfo{
+						StateInfo: &gproto.StateInfo{
+							Properties: &gproto.Properties{LedgerHeight: 10},
+						},
+					},
+				},
+			}
+		}
+		fakeGossipService.PeersOfChannelStub = func(channelID common.ChannelID) []discovery.NetworkMember {
+			if string(channelID) != "mychannel" {
+				return nil
+			}
+			return []discovery.NetworkMember{
+				{
+					PKIid:    common.PKIidType("p1"),
+					Endpoint: "peer1:7051",
+					Properties: &gproto.Properties{
+						LedgerHeight: 9,
+						Chaincodes:   []*gproto.Chaincode{{Name: "mycc", Version: "1.0"}},
+					},
+				},
+			}
+		}
+
+		handler = &httpadmin.StatusHandler{
+			GossipService: fakeGossipService,
+		}
+	})
+
+	It("responds with the membership and channel topology", func() {
+		req := httptest.NewRequest("GET", "/ignored", nil)
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+
+		Expect(resp.Result().StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Result().Header.Get("Content-Type")).To(Equal("application/json"))
+		Expect(resp.Body).To(MatchJSON(`{
+			"self": {"pki_id": "73656c66", "endpoint": "peer0:7051", "internal_endpoint": "peer0.internal:7051"},
+			"alive": [
+				{"pki_id": "7031", "endpoint": "peer1:7051"},
+				{"pki_id": "7032", "endpoint": "peer2:7051"}
+			],
+			"dead": [
+				{"pki_id": "7033", "endpoint": "peer3:7051"}
+			],
+			"channels": [
+				{
+					"name": "mychannel",
+					"leadership": {"elected": true, "is_leader": false, "leader": "7031"},
+					"self": {"pki_id": "73656c66", "endpoint": "peer0:7051", "internal_endpoint": "peer0.internal:7051", "ledger_height": 10},
+					"peers": [
+						{"pki_id": "7031", "endpoint": "peer1:7051", "ledger_height": 9, "chaincodes": [{"name": "mycc", "version": "1.0"}]}
+					]
+				},
+				{
+					"name": "otherchannel",
+					"leadership": {"elected": false, "is_leader": false},
+					"peers": []
+				}
+			],
+			"connections": [
+				{"pki_id": "7031", "endpoint": "peer1:7051", "outbound": true, "queued_messages": 2}
+			]
+		}`))
+	})
+
+	Context("when a channel is requested", func() {
+		It("responds with only that channel", func() {
+			req := httptest.NewRequest("GET", "/ignored?channel=otherchannel", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Result().StatusCode).To(Equal(http.StatusOK))
+			status := &httpadmin.Status{}
+			Expect(json.Unmarshal(resp.Body.Bytes(), status)).To(Succeed())
+			Expect(status.Channels).To(HaveLen(1))
+			Expect(status.Channels[0].Name).To(Equal("otherchannel"))
+			Expect(fakeGossipService.ChannelsCallCount()).To(Equal(0))
+		})
+
+		Context("and the channel does not exist", func() {
+			It("responds with an error payload", func() {
+				req := httptest.NewRequest("GET", "/ignored?channel=missing", nil)
+				resp := httptest.NewRecorder()
+				handler.ServeHTTP(resp, req)
+
+				Expect(resp.Result().StatusCode).To(Equal(http.StatusNotFound))
+				Expect(resp.Body).To(MatchJSON(`{"error": "channel missing not found"}`))
+			})
+		})
+	})
+
+	Context("when an unsupported method is used", func() {
+		It("responds with an error", func() {
+			req := httptest.NewRequest("PUT", "/ignored", nil)
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Result().StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body).To(MatchJSON(`{"error": "invalid request method: PUT"}`))
+			Expect(fakeGossipService.PeersCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -70,6 +70,14 @@ func (*gossipMock) PeersOfChannel(common.ChannelID) []discovery.NetworkMember {
 	panic("implement me")
 }
 
+func (*gossipMock) DeadPeers() []discovery.NetworkMember {
+	panic("implement me")
+}
+
+func (*gossipMock) Connections() []comm.ConnectionStats {
+	panic("implement me")
+}
+
 func (*gossipMock) UpdateMetadata(metadata []byte) {
 	panic("implement me")
 }
@@ -0,0 +1,385 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/pkg/errors"
+)
+
+// virtualComm is a comm.Comm that exchanges messages through a simulated
+// network instead of gRPC streams. Each instance of a simulated peer has
+// its own virtualComm, which is detached from the network when it stops.
+type virtualComm struct {
+	network  *Network
+	endpoint string
+	identity api.PeerIdentityType
+	pkiID    common.PKIidType
+	idMapper identity.Mapper
+	logger   util.Logger
+
+	msgPublisher    *comm.ChannelDeMultiplexer
+	deadEndpoints   chan common.PKIidType
+	identityChanges chan common.PKIidType
+
+	lock        sync.Mutex
+	inbox       []*delivery
+	inboxSignal chan struct{}
+	acks        map[string]chan error
+	accepting   chan struct{}
+	acceptOnce  sync.Once
+
+	stopping int32
+	exitChan chan struct{}
+	stopWG   sync.WaitGroup
+}
+
+func newVirtualComm(network *Network, endpoint string, selfIdentity api.PeerIdentityType, idMapper identity.Mapper) *virtualComm {
+	c := &virtualComm{
+		network:         network,
+		endpoint:        endpoint,
+		identity:        selfIdentity,
+		pkiID:           idMapper.GetPKIidOfCert(selfIdentity),
+		idMapper:        idMapper,
+		logger:          util.GetLogger(util.SimulationLogger, endpoint),
+		msgPublisher:    comm.NewChannelDemultiplexer(),
+		deadEndpoints:   make(chan common.PKIidType, 100),
+		identityChanges: make(chan common.PKIidType, 1),
+		inboxSignal:     make(chan struct{}, 1),
+		acks:            make(map[string]chan error),
+		accepting:       make(chan struct{}),
+		exitChan:        make(chan struct{}),
+	}
+	c.stopWG.Add(1)
+	go c.receive()
+	return c
+}
+
+// GetPKIid returns this instance's PKI id
+func (c *virtualComm) GetPKIid() common.PKIidType {
+	return c.pkiID
+}
+
+// Send sends a message to remote peers asynchronously
+func (c *virtualComm) Send(msg *protoext.SignedGossipMessage, peers ...*comm.RemotePeer) {
+	if c.isStopping() {
+		return
+	}
+	for _, peer := range peers {
+		if err := c.network.route(c, peer, msg); err != nil {
+			c.logger.Debugf("Failed sending to %v: %v", peer, err)
+			c.disconnect(peer.PKIID)
+		}
+	}
+}
+
+// SendWithAck sends a message to remote peers, waiting for acknowledgement from minAck of them,
+// or until the given timeout expires on the clock of the network
+func (c *virtualComm) SendWithAck(msg *protoext.SignedGossipMessage, timeout time.Duration, minAck int, peers ...*comm.RemotePeer) comm.AggregatedSendResult {
+	if len(peers) == 0 {
+		return nil
+	}
+	var err error
+	msg.Nonce = util.RandomUInt64()
+	msg, err = protoext.NoopSign(msg.GossipMessage)
+	if c.isStopping() || err != nil {
+		if err == nil {
+			err = errors.New("comm is stopping")
+		}
+		var results []comm.SendResult
+		for _, p := range peers {
+			results = append(results, comm.NewSendResult(*p, err))
+		}
+		return results
+	}
+
+	acks := make(chan comm.SendResult, len(peers))
+	for _, p := range peers {
+		go func(p *comm.RemotePeer) {
+			acks <- comm.NewSendResult(*p, c.sendAndWaitForAck(msg, timeout, p))
+		}(p)
+	}
+
+	var results []comm.SendResult
+	successAcks := 0
+	for len(results) < len(peers) && successAcks < minAck {
+		result := <-acks
+		results = append(results, result)
+		if result.Error() == "" {
+			successAcks++
+		}
+	}
+	return results
+}
+
+func (c *virtualComm) sendAndWaitForAck(msg *protoext.SignedGossipMessage, timeout time.Duration, peer *comm.RemotePeer) error {
+	topic := topicForAck(msg.Nonce, peer.PKIID)
+	ackChan := make(chan error, 1)
+	c.lock.Lock()
+	c.acks[topic] = ackChan
+	c.lock.Unlock()
+	defer func() {
+		c.lock.Lock()
+		delete(c.acks, topic)
+		c.lock.Unlock()
+	}()
+
+	if err := c.network.route(c, peer, msg); err != nil {
+		c.disconnect(peer.PKIID)
+		return err
+	}
+	select {
+	case err := <-ackChan:
+		return err
+	case <-c.network.clock.After(timeout):
+		return errors.New("timed out waiting for ack")
+	case <-c.exitChan:
+		return errors.New("comm is stopping")
+	}
+}
+
+// Probe returns nil if the remote peer is reachable, and an error if it isn't
+func (c *virtualComm) Probe(peer *comm.RemotePeer) error {
+	if c.isStopping() {
+		return errors.New("comm is stopping")
+	}
+	_, err := c.network.handshake(c, peer)
+	return err
+}
+
+// Handshake returns the identity of the remote peer if it is reachable
+func (c *virtualComm) Handshake(peer *comm.RemotePeer) (api.PeerIdentityType, error) {
+	if c.isStopping() {
+		return nil, errors.New("comm is stopping")
+	}
+	return c.network.handshake(c, peer)
+}
+
+// Accept returns a dedicated read-only channel for messages sent by other nodes that match a certain predicate.
+func (c *virtualComm) Accept(acceptor common.MessageAcceptor) <-chan protoext.ReceivedMessage {
+	genericChan := c.msgPublisher.AddChannel(acceptor)
+	specificChan := make(chan protoext.ReceivedMessage, 10)
+	c.acceptOnce.Do(func() {
+		close(c.accepting)
+	})
+
+	if c.isStopping() {
+		return specificChan
+	}
+
+	c.stopWG.Add(1)
+	go func() {
+		defer c.stopWG.Done()
+		for {
+			select {
+			case msg, channelOpen := <-genericChan:
+				if !channelOpen {
+					return
+				}
+				select {
+				case specificChan <- msg.(*receivedMessage):
+				case <-c.exitChan:
+					return
+				}
+			case <-c.exitChan:
+				return
+			}
+		}
+	}()
+	return specificChan
+}
+
+// PresumedDead returns a read-only channel for node endpoints that are suspected to be offline
+func (c *virtualComm) PresumedDead() <-chan common.PKIidType {
+	return c.deadEndpoints
+}
+
+// IdentitySwitch returns a read-only channel about identity change events,
+// which never occur in a simulated network
+func (c *virtualComm) IdentitySwitch() <-chan common.PKIidType {
+	return c.identityChanges
+}
+
+// CloseConn closes the connection to a certain peer
+func (c *virtualComm) CloseConn(peer *comm.RemotePeer) {
+	c.network.closeConn(c, peer.PKIID)
+}
+
+// Connections returns statistics of the open connections to remote peers
+func (c *virtualComm) Connections() []comm.ConnectionStats {
+	return c.network.connections(c)
+}
+
+// Stop stops the module and detaches it from the network
+func (c *virtualComm) Stop() {
+	if !atomic.CompareAndSwapInt32(&c.stopping, 0, 1) {
+		return
+	}
+	c.network.detach(c)
+	c.msgPublisher.Close()
+	close(c.exitChan)
+	c.stopWG.Wait()
+}
+
+func (c *virtualComm) isStopping() bool {
+	return atomic.LoadInt32(&c.stopping) == 1
+}
+
+func (c *virtualComm) disconnect(pkiID common.PKIidType) {
+	if len(pkiID) == 0 {
+		return
+	}
+	c.network.closeConn(c, pkiID)
+	select {
+	case c.deadEndpoints <- pkiID:
+	default:
+		c.logger.Debugf("Dropping presumed dead notification for %s", pkiID)
+	}
+}
+
+// enqueue hands a message over to the receive loop
+func (c *virtualComm) enqueue(d *delivery) {
+	c.lock.Lock()
+	c.inbox = append(c.inbox, d)
+	c.lock.Unlock()
+	select {
+	case c.inboxSignal <- struct{}{}:
+	default:
+	}
+}
+
+// receive dispatches the messages delivered to this peer in the order they
+// were delivered, just like the receive loop of a connection would.
+// Messages are only dispatched once the gossip instance accepts messages,
+// just like a peer only serves gossip once gossip is initialized.
+func (c *virtualComm) receive() {
+	defer c.stopWG.Done()
+	defer func() {
+		c.lock.Lock()
+		c.network.processed(len(c.inbox))
+		c.inbox = nil
+		c.lock.Unlock()
+	}()
+
+	select {
+	case <-c.exitChan:
+		return
+	case <-c.accepting:
+	}
+	for {
+		select {
+		case <-c.exitChan:
+			return
+		case <-c.inboxSignal:
+		}
+		for {
+			c.lock.Lock()
+			if len(c.inbox) == 0 {
+				c.lock.Unlock()
+				break
+			}
+			d := c.inbox[0]
+			c.inbox = c.inbox[1:]
+			c.lock.Unlock()
+
+			c.dispatch(d)
+			c.network.processed(1)
+		}
+	}
+}
+
+func (c *virtualComm) dispatch(d *delivery) {
+	if protoext.IsAck(d.msg.GossipMessage) {
+		c.lock.Lock()
+		ackChan, exists := c.acks[topicForAck(d.msg.Nonce, d.from.pkiID)]
+		c.lock.Unlock()
+		if !exists {
+			return
+		}
+		var err error
+		if ackErr := d.msg.GetAck().Error; ackErr != "" {
+			err = errors.New(ackErr)
+		}
+		select {
+		case ackChan <- err:
+		default:
+		}
+		return
+	}
+	c.msgPublisher.DeMultiplex(&receivedMessage{
+		SignedGossipMessage: d.msg,
+		receiver:            c,
+		sender:              d.from,
+	})
+}
+
+func topicForAck(nonce uint64, pkiID common.PKIidType) string {
+	return fmt.Sprintf("%d %s", nonce, pkiID)
+}
+
+// receivedMessage is a message a peer received through the simulated network
+type receivedMessage struct {
+	*protoext.SignedGossipMessage
+	receiver *virtualComm
+	sender   *virtualComm
+}
+
+// Respond sends a msg to the source that sent the receivedMessage
+func (m *receivedMessage) Respond(msg *proto.GossipMessage) {
+	sMsg, err := protoext.NoopSign(msg)
+	if err != nil {
+		m.receiver.logger.Errorf("Failed creating SignedGossipMessage: %+v", errors.WithStack(err))
+		return
+	}
+	m.receiver.Send(sMsg, &comm.RemotePeer{Endpoint: m.sender.endpoint, PKIID: m.sender.pkiID})
+}
+
+// GetGossipMessage returns the inner GossipMessage
+func (m *receivedMessage) GetGossipMessage() *protoext.SignedGossipMessage {
+	return m.SignedGossipMessage
+}
+
+// GetSourceEnvelope returns the Envelope the receivedMessage was
+// constructed with
+func (m *receivedMessage) GetSourceEnvelope() *proto.Envelope {
+	return m.Envelope
+}
+
+// GetConnectionInfo returns information about the remote peer
+// that sent the message
+func (m *receivedMessage) GetConnectionInfo() *protoext.ConnectionInfo {
+	return &protoext.ConnectionInfo{
+		ID:       m.sender.pkiID,
+		Endpoint: m.sender.endpoint,
+		Identity: m.sender.identity,
+	}
+}
+
+// Ack returns to the sender an acknowledgement for the message
+func (m *receivedMessage) Ack(err error) {
+	ackMsg := &proto.GossipMessage{
+		Nonce: m.GetGossipMessage().Nonce,
+		Content: &proto.GossipMessage_Ack{
+			Ack: &proto.Acknowledgement{},
+		},
+	}
+	if err != nil {
+		ackMsg.GetAck().Error = err.Error()
+	}
+	m.Respond(ackMsg)
+}
@@ -0,0 +1,112 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/common"
+)
+
+// identitySeparator separates the endpoint of a simulated peer
+// from its organization in the identity of the peer
+const identitySeparator = "@"
+
+// peerIdentity returns the identity of the simulated peer with the given
+// endpoint in the given organization
+func peerIdentity(endpoint, org string) api.PeerIdentityType {
+	return api.PeerIdentityType(endpoint + identitySeparator + org)
+}
+
+// cryptoService is the MessageCryptoService and SecurityAdvisor of simulated
+// peers. Identities are the endpoints of peers suffixed by their organizations,
+// PKI-IDs are the identities themselves, and signatures are copies of the
+// signed messages. It is not meant to be secure.
+type cryptoService struct{}
+
+// OrgByPeerIdentity returns the organization encoded in the given identity
+func (*cryptoService) OrgByPeerIdentity(identity api.PeerIdentityType) api.OrgIdentityType {
+	i := strings.LastIndex(string(identity), identitySeparator)
+	if i == -1 {
+		return nil
+	}
+	return api.OrgIdentityType(identity[i+len(identitySeparator):])
+}
+
+// GetPKIidOfCert returns the PKI-ID of a peer's identity
+func (*cryptoService) GetPKIidOfCert(identity api.PeerIdentityType) common.PKIidType {
+	return common.PKIidType(identity)
+}
+
+// VerifyBlock accepts every block
+func (*cryptoService) VerifyBlock(common.ChannelID, uint64, *cb.Block) error {
+	return nil
+}
+
+// Sign returns a copy of msg as its signature
+func (*cryptoService) Sign(msg []byte) ([]byte, error) {
+	sig := make([]byte, len(msg))
+	copy(sig, msg)
+	return sig, nil
+}
+
+// Verify checks that the signature is a copy of the message
+func (*cryptoService) Verify(_ api.PeerIdentityType, signature, message []byte) error {
+	if !bytes.Equal(signature, message) {
+		return fmt.Errorf("wrong signature: %v, %v", signature, message)
+	}
+	return nil
+}
+
+// VerifyByChannel checks that the signature is a copy of the message
+func (cs *cryptoService) VerifyByChannel(_ common.ChannelID, identity api.PeerIdentityType, signature, message []byte) error {
+	return cs.Verify(identity, signature, message)
+}
+
+// ValidateIdentity accepts every identity that belongs to an organization
+func (cs *cryptoService) ValidateIdentity(identity api.PeerIdentityType) error {
+	if len(cs.OrgByPeerIdentity(identity)) == 0 {
+		return fmt.Errorf("identity %s has no organization", string(identity))
+	}
+	return nil
+}
+
+// Expiration returns the zero time, as simulated identities never expire
+func (*cryptoService) Expiration(api.PeerIdentityType) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+// joinChannelMessage is the configuration of a simulated channel,
+// in which the first peer that joins from each organization is its anchor peer
+type joinChannelMessage struct {
+	seqNum      uint64
+	anchorPeers map[string][]api.AnchorPeer
+}
+
+// SequenceNumber returns the sequence number of the configuration
+func (jcm *joinChannelMessage) SequenceNumber() uint64 {
+	return jcm.seqNum
+}
+
+// Members returns the organizations of the channel
+func (jcm *joinChannelMessage) Members() []api.OrgIdentityType {
+	var orgs []api.OrgIdentityType
+	for org := range jcm.anchorPeers {
+		orgs = append(orgs, api.OrgIdentityType(org))
+	}
+	return orgs
+}
+
+// AnchorPeersOf returns the anchor peers of the given organization
+func (jcm *joinChannelMessage) AnchorPeersOf(org api.OrgIdentityType) []api.AnchorPeer {
+	return jcm.anchorPeers[string(org)]
+}
@@ -0,0 +1,668 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation
+
+import (
+	"bytes"
+	"container/heap"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefTimeStep is the default amount of virtual time the clock of
+	// the network is advanced by at once
+	DefTimeStep = 50 * time.Millisecond
+
+	// settleQuantum is the wall time the network yields to the peers
+	// after each step of the clock
+	settleQuantum = 200 * time.Microsecond
+	// maxSettleRounds bounds the wall time the network waits for
+	// the delivered messages to be processed after each step
+	maxSettleRounds = 5000
+)
+
+// startTime is the time the clock of every simulated network starts at
+var startTime = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// Network is an in-process network of gossip peers. The peers communicate
+// through virtual connections and are timed by a virtual clock that only
+// moves when the network is advanced, so that minutes of gossip activity
+// can be simulated in seconds.
+//
+// Simulations are not deterministic. The faults of the network, i.e. which
+// messages are dropped and how much each of them is delayed, are derived
+// from the seed of the network and the traffic of each link, but the
+// interleaving of the goroutines of the peers is up to the Go scheduler.
+// The network also waits for the peers in wall time after each step of
+// the clock, and the timers of the gossip components that are not given
+// a clock, such as the expiration of identities by the identity mapper,
+// run in real time. A simulation replayed with the same seed is hence
+// subject to the same faults on each link, but not necessarily to the
+// same sequence of events, and should be checked for outcomes, such as
+// convergence, rather than for exact traces.
+type Network struct {
+	seed   int64
+	clock  *fakeclock.FakeClock
+	logger util.Logger
+
+	// inFlight is the number of messages that were handed to peers
+	// and that the peers haven't dispatched yet
+	inFlight int64
+
+	lock      sync.Mutex
+	step      time.Duration
+	latency   time.Duration
+	jitter    time.Duration
+	dropRate  float64
+	peers     []*Peer
+	comms     map[string]*virtualComm
+	endpoints map[string]string
+	groups    map[string]int
+	conns     map[link]struct{}
+	links     map[link]*linkState
+	pending   deliveryQueue
+	seq       uint64
+	channels  map[string]*joinChannelMessage
+	members   map[string][]*Peer
+}
+
+// link is a directed pair of endpoints
+type link struct {
+	from string
+	to   string
+}
+
+type linkState struct {
+	sent          uint64
+	lastDeliverAt time.Time
+}
+
+// delivery is a message on its way from one peer to another
+type delivery struct {
+	deliverAt time.Time
+	seq       uint64
+	from      *virtualComm
+	to        *virtualComm
+	msg       *protoext.SignedGossipMessage
+}
+
+// NewNetwork creates a network without peers whose faults are derived from the given seed
+func NewNetwork(seed int64) *Network {
+	return &Network{
+		seed:      seed,
+		clock:     fakeclock.NewFakeClock(startTime),
+		logger:    util.GetLogger(util.SimulationLogger, ""),
+		step:      DefTimeStep,
+		comms:     make(map[string]*virtualComm),
+		endpoints: make(map[string]string),
+		groups:    make(map[string]int),
+		conns:     make(map[link]struct{}),
+		links:     make(map[link]*linkState),
+		channels:  make(map[string]*joinChannelMessage),
+		members:   make(map[string][]*Peer),
+	}
+}
+
+// Now returns the current virtual time of the network
+func (n *Network) Now() time.Time {
+	return n.clock.Now()
+}
+
+// Elapsed returns the virtual time that passed since the network was created
+func (n *Network) Elapsed() time.Duration {
+	return n.clock.Since(startTime)
+}
+
+// SetTimeStep sets the amount of virtual time the clock is advanced by at once.
+// Smaller steps time the peers more accurately, at the expense of a longer simulation.
+func (n *Network) SetTimeStep(step time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.step = step
+}
+
+// SetLatency sets the delay of the messages sent from now on to the given
+// latency, plus up to the given jitter
+func (n *Network) SetLatency(latency, jitter time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.latency = latency
+	n.jitter = jitter
+}
+
+// SetDropRate sets the fraction of the messages sent from now on that are lost
+func (n *Network) SetDropRate(rate float64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.dropRate = rate
+}
+
+// Partition splits the network into the given groups of peers. Peers can only
+// reach peers of their own group, and the peers not in any of the groups
+// form a group of their own. Connections between groups are closed.
+func (n *Network) Partition(groups ...[]*Peer) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.groups = make(map[string]int)
+	for i, group := range groups {
+		for _, p := range group {
+			n.groups[p.Endpoint] = i + 1
+		}
+	}
+	for l := range n.conns {
+		if n.groups[l.from] != n.groups[l.to] {
+			delete(n.conns, l)
+		}
+	}
+	n.logger.Infof("Partitioned the network into %d groups at %v", len(groups), n.Elapsed())
+}
+
+// Heal removes the partitions of the network
+func (n *Network) Heal() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.groups = make(map[string]int)
+	n.logger.Infof("Healed the network at %v", n.Elapsed())
+}
+
+// Advance moves the clock of the network forward by d, one time step at a time.
+// After each step, the messages that are due are delivered and the peers
+// are given a chance to process them.
+func (n *Network) Advance(d time.Duration) {
+	end := n.clock.Now().Add(d)
+	for n.clock.Now().Before(end) {
+		n.lock.Lock()
+		step := n.step
+		n.lock.Unlock()
+		if remaining := end.Sub(n.clock.Now()); remaining < step {
+			step = remaining
+		}
+		n.clock.Increment(step)
+		n.deliverDue()
+		n.settle()
+	}
+}
+
+// AdvanceUntil advances the network one time step at a time until the given
+// condition holds, or until max virtual time passes. It returns whether the
+// condition holds.
+func (n *Network) AdvanceUntil(condition func() bool, max time.Duration) bool {
+	end := n.clock.Now().Add(max)
+	for !condition() {
+		if !n.clock.Now().Before(end) {
+			return false
+		}
+		n.lock.Lock()
+		step := n.step
+		n.lock.Unlock()
+		if remaining := end.Sub(n.clock.Now()); remaining < step {
+			step = remaining
+		}
+		n.Advance(step)
+	}
+	return true
+}
+
+// settle waits for the peers to dispatch the messages they were handed.
+// It polls in wall time and gives up after maxSettleRounds, so on a loaded
+// machine some messages may still be processed after the next step.
+func (n *Network) settle() {
+	for i := 0; i < maxSettleRounds; i++ {
+		time.Sleep(settleQuantum)
+		if atomic.LoadInt64(&n.inFlight) == 0 {
+			return
+		}
+	}
+}
+
+func (n *Network) processed(count int) {
+	atomic.AddInt64(&n.inFlight, -int64(count))
+}
+
+// AddPeer creates and starts a peer of the given organization. The peer
+// bootstraps from the first peer of its organization.
+func (n *Network) AddPeer(org string) *Peer {
+	n.lock.Lock()
+	var bootstrapPeers []string
+	count := 0
+	for _, p := range n.peers {
+		if p.Org != org {
+			continue
+		}
+		if count == 0 {
+			bootstrapPeers = append(bootstrapPeers, p.Endpoint)
+		}
+		count++
+	}
+	p := &Peer{
+		Endpoint:       fmt.Sprintf("peer%d.%s:7051", count, org),
+		Org:            org,
+		network:        n,
+		bootstrapPeers: bootstrapPeers,
+		channels:       make(map[string]struct{}),
+		blocks:         make(map[string]map[uint64]struct{}),
+	}
+	n.peers = append(n.peers, p)
+	n.lock.Unlock()
+
+	p.Start()
+	return p
+}
+
+// AddPeers creates and starts count peers of the given organization
+func (n *Network) AddPeers(org string, count int) []*Peer {
+	var peers []*Peer
+	for i := 0; i < count; i++ {
+		peers = append(peers, n.AddPeer(org))
+	}
+	return peers
+}
+
+// Peers returns the peers of the network, running or not
+func (n *Network) Peers() []*Peer {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return append([]*Peer(nil), n.peers...)
+}
+
+// JoinChannel makes the given peers join the given channel. The first peer
+// of each organization that joins the channel becomes an anchor peer of
+// the channel, and the peers that already joined the channel are updated
+// with its new configuration.
+func (n *Network) JoinChannel(channelID string, peers ...*Peer) {
+	n.lock.Lock()
+	anchorPeers := make(map[string][]api.AnchorPeer)
+	var seqNum uint64
+	if jcm, exists := n.channels[channelID]; exists {
+		seqNum = jcm.seqNum
+		for org, anchors := range jcm.anchorPeers {
+			anchorPeers[org] = anchors
+		}
+	}
+	for _, p := range peers {
+		if _, exists := anchorPeers[p.Org]; !exists {
+			host, port, _ := net.SplitHostPort(p.Endpoint)
+			portNum, _ := strconv.Atoi(port)
+			anchorPeers[p.Org] = []api.AnchorPeer{{Host: host, Port: portNum}}
+		}
+		if !containsPeer(n.members[channelID], p) {
+			n.members[channelID] = append(n.members[channelID], p)
+		}
+	}
+	jcm := &joinChannelMessage{seqNum: seqNum + 1, anchorPeers: anchorPeers}
+	n.channels[channelID] = jcm
+	members := append([]*Peer(nil), n.members[channelID]...)
+	n.lock.Unlock()
+
+	for _, p := range members {
+		p.joinChannel(channelID, jcm)
+	}
+}
+
+// ChannelPeers returns the peers that joined the given channel, running or not
+func (n *Network) ChannelPeers(channelID string) []*Peer {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return append([]*Peer(nil), n.members[channelID]...)
+}
+
+// IsAnchorPeer returns whether the given endpoint is an anchor peer of any channel
+func (n *Network) IsAnchorPeer(endpoint string) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	for _, jcm := range n.channels {
+		for _, anchors := range jcm.anchorPeers {
+			for _, anchor := range anchors {
+				if net.JoinHostPort(anchor.Host, strconv.Itoa(anchor.Port)) == endpoint {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (n *Network) channelConfig(channelID string) *joinChannelMessage {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.channels[channelID]
+}
+
+// MembershipConverged returns whether every running peer considers alive
+// exactly the running peers it can reach that are either of its organization
+// or share a channel with it
+func (n *Network) MembershipConverged() bool {
+	for _, p := range n.Peers() {
+		if !p.Running() {
+			continue
+		}
+		var expected []string
+		for _, q := range n.Peers() {
+			if q != p && q.Running() && n.reachable(p, q) && (q.Org == p.Org || n.shareChannel(p, q)) {
+				expected = append(expected, q.Endpoint)
+			}
+		}
+		if !equalEndpoints(p.AlivePeers(), expected) {
+			return false
+		}
+	}
+	return true
+}
+
+// ChannelMembershipConverged returns whether every running peer of the given
+// channel considers part of the channel exactly the running peers of the
+// channel it can reach
+func (n *Network) ChannelMembershipConverged(channelID string) bool {
+	members := n.ChannelPeers(channelID)
+	for _, p := range members {
+		if !p.Running() {
+			continue
+		}
+		var expected []string
+		for _, q := range members {
+			if q != p && q.Running() && n.reachable(p, q) {
+				expected = append(expected, q.Endpoint)
+			}
+		}
+		if !equalEndpoints(p.ChannelPeers(channelID), expected) {
+			return false
+		}
+	}
+	return true
+}
+
+// BlockDisseminated returns whether every running peer of the given channel
+// has the block with the given sequence number
+func (n *Network) BlockDisseminated(channelID string, seqNum uint64) bool {
+	for _, p := range n.ChannelPeers(channelID) {
+		if p.Running() && !p.HasBlock(channelID, seqNum) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *Network) reachable(p, q *Peer) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.groups[p.Endpoint] == n.groups[q.Endpoint]
+}
+
+func (n *Network) shareChannel(p, q *Peer) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	for _, members := range n.members {
+		if containsPeer(members, p) && containsPeer(members, q) {
+			return true
+		}
+	}
+	return false
+}
+
+// attach connects the comm of a peer that starts to the network
+func (n *Network) attach(c *virtualComm) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.comms[c.endpoint] = c
+	n.endpoints[string(c.pkiID)] = c.endpoint
+}
+
+// detach disconnects the comm of a peer that stops from the network
+func (n *Network) detach(c *virtualComm) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if n.comms[c.endpoint] != c {
+		return
+	}
+	delete(n.comms, c.endpoint)
+	for l := range n.conns {
+		if l.from == c.endpoint || l.to == c.endpoint {
+			delete(n.conns, l)
+		}
+	}
+}
+
+// lookup returns the comm the given remote peer can be reached at by the given comm.
+// It is called with the lock held.
+func (n *Network) lookup(from *virtualComm, peer *comm.RemotePeer) (*virtualComm, error) {
+	if n.comms[from.endpoint] != from {
+		return nil, errors.New("comm is stopping")
+	}
+	to, exists := n.comms[peer.Endpoint]
+	if !exists {
+		return nil, errors.Errorf("%s is unreachable", peer.Endpoint)
+	}
+	if n.groups[from.endpoint] != n.groups[to.endpoint] {
+		return nil, errors.Errorf("%s is partitioned away", peer.Endpoint)
+	}
+	if len(peer.PKIID) != 0 && !bytes.Equal(peer.PKIID, to.pkiID) {
+		return nil, errors.Errorf("PKI-ID of %s mismatches the expected one", peer.Endpoint)
+	}
+	return to, nil
+}
+
+// handshake returns the identity of the given remote peer if it is reachable
+func (n *Network) handshake(from *virtualComm, peer *comm.RemotePeer) (api.PeerIdentityType, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	to, err := n.lookup(from, peer)
+	if err != nil {
+		return nil, err
+	}
+	exchangeIdentities(from, to)
+	return to.identity, nil
+}
+
+// connect opens a connection between the given comms unless there already is one.
+// It is called with the lock held.
+func (n *Network) connect(from, to *virtualComm) {
+	if _, exists := n.conns[link{from: from.endpoint, to: to.endpoint}]; exists {
+		return
+	}
+	if _, exists := n.conns[link{from: to.endpoint, to: from.endpoint}]; exists {
+		return
+	}
+	exchangeIdentities(from, to)
+	n.conns[link{from: from.endpoint, to: to.endpoint}] = struct{}{}
+}
+
+// exchangeIdentities makes the given comms learn each other's identity,
+// just like peers authenticating each other over a real connection do
+func exchangeIdentities(from, to *virtualComm) {
+	if err := from.idMapper.Put(to.pkiID, to.identity); err != nil {
+		from.logger.Warningf("Failed storing the identity of %s: %v", to.endpoint, err)
+	}
+	if err := to.idMapper.Put(from.pkiID, from.identity); err != nil {
+		to.logger.Warningf("Failed storing the identity of %s: %v", from.endpoint, err)
+	}
+}
+
+func (n *Network) closeConn(c *virtualComm, pkiID common.PKIidType) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	endpoint, exists := n.endpoints[string(pkiID)]
+	if !exists {
+		return
+	}
+	delete(n.conns, link{from: c.endpoint, to: endpoint})
+	delete(n.conns, link{from: endpoint, to: c.endpoint})
+}
+
+func (n *Network) connections(c *virtualComm) []comm.ConnectionStats {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	var stats []comm.ConnectionStats
+	for l := range n.conns {
+		var remote string
+		switch c.endpoint {
+		case l.from:
+			remote = l.to
+		case l.to:
+			remote = l.from
+		default:
+			continue
+		}
+		stat := comm.ConnectionStats{Endpoint: remote, Outbound: l.from == c.endpoint}
+		if remoteComm, exists := n.comms[remote]; exists {
+			stat.PKIID = remoteComm.pkiID
+		}
+		stats = append(stats, stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Endpoint < stats[j].Endpoint
+	})
+	return stats
+}
+
+// route sends a message from the given comm to the given remote peer.
+// It returns an error if the remote peer is unreachable, and nil if the
+// message was sent, even if it is lost on the way.
+func (n *Network) route(from *virtualComm, peer *comm.RemotePeer, msg *protoext.SignedGossipMessage) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	to, err := n.lookup(from, peer)
+	if err != nil {
+		return err
+	}
+	n.connect(from, to)
+
+	l := link{from: from.endpoint, to: to.endpoint}
+	state, exists := n.links[l]
+	if !exists {
+		state = &linkState{}
+		n.links[l] = state
+	}
+	state.sent++
+
+	if n.dropRate > 0 && float64(n.random(l, state.sent, 0)%1000000)/1000000 < n.dropRate {
+		n.logger.Debugf("Dropping message %d from %s to %s", state.sent, l.from, l.to)
+		return nil
+	}
+	delay := n.latency
+	if n.jitter > 0 {
+		delay += time.Duration(n.random(l, state.sent, 1) % uint64(n.jitter))
+	}
+	// Messages of a link are delivered in the order they were sent, like over a stream
+	deliverAt := n.clock.Now().Add(delay)
+	if deliverAt.Before(state.lastDeliverAt) {
+		deliverAt = state.lastDeliverAt
+	}
+	state.lastDeliverAt = deliverAt
+
+	n.seq++
+	d := &delivery{deliverAt: deliverAt, seq: n.seq, from: from, to: to, msg: msg}
+	if deliverAt.After(n.clock.Now()) {
+		heap.Push(&n.pending, d)
+		return nil
+	}
+	n.deliver(d)
+	return nil
+}
+
+// deliverDue delivers the messages whose delivery time has come
+func (n *Network) deliverDue() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	now := n.clock.Now()
+	for n.pending.Len() > 0 && !n.pending[0].deliverAt.After(now) {
+		n.deliver(heap.Pop(&n.pending).(*delivery))
+	}
+}
+
+// deliver hands a message to its recipient, unless either end of the
+// link went down or got partitioned away while the message was on its way.
+// It is called with the lock held.
+func (n *Network) deliver(d *delivery) {
+	if n.comms[d.from.endpoint] != d.from || n.comms[d.to.endpoint] != d.to {
+		return
+	}
+	if n.groups[d.from.endpoint] != n.groups[d.to.endpoint] {
+		return
+	}
+	atomic.AddInt64(&n.inFlight, 1)
+	d.to.enqueue(d)
+}
+
+// random returns a pseudo-random number derived from the seed of the network
+// and the given link, message counter and salt
+func (n *Network) random(l link, counter uint64, salt byte) uint64 {
+	h := fnv.New64a()
+	buff := make([]byte, 8)
+	binary.BigEndian.PutUint64(buff, uint64(n.seed))
+	h.Write(buff)
+	h.Write([]byte(l.from))
+	h.Write([]byte{0})
+	h.Write([]byte(l.to))
+	binary.BigEndian.PutUint64(buff, counter)
+	h.Write(buff)
+	h.Write([]byte{salt})
+	return h.Sum64()
+}
+
+// deliveryQueue is a min-heap of deliveries ordered by their delivery time,
+// and then by the order they were sent in
+type deliveryQueue []*delivery
+
+func (q deliveryQueue) Len() int { return len(q) }
+
+func (q deliveryQueue) Less(i, j int) bool {
+	if q[i].deliverAt.Equal(q[j].deliverAt) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].deliverAt.Before(q[j].deliverAt)
+}
+
+func (q deliveryQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *deliveryQueue) Push(x interface{}) { *q = append(*q, x.(*delivery)) }
+
+func (q *deliveryQueue) Pop() interface{} {
+	old := *q
+	d := old[len(old)-1]
+	old[len(old)-1] = nil
+	*q = old[:len(old)-1]
+	return d
+}
+
+func containsPeer(peers []*Peer, p *Peer) bool {
+	for _, q := range peers {
+		if q == p {
+			return true
+		}
+	}
+	return false
+}
+
+func equalEndpoints(actual, expected []string) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+	sort.Strings(actual)
+	sort.Strings(expected)
+	for i := range actual {
+		if actual[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
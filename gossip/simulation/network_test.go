@@ -0,0 +1,157 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	util.SetupTestLogging()
+}
+
+func stopAll(n *Network) {
+	for _, p := range n.Peers() {
+		p.Stop()
+	}
+}
+
+func TestMembershipConvergence(t *testing.T) {
+	n := NewNetwork(1)
+	defer stopAll(n)
+	n.AddPeers("org1", 10)
+
+	require.True(t, n.AdvanceUntil(n.MembershipConverged, time.Minute))
+	t.Logf("Membership converged after %v", n.Elapsed())
+}
+
+func TestBlockDissemination(t *testing.T) {
+	n := NewNetwork(2)
+	defer stopAll(n)
+	org1 := n.AddPeers("org1", 4)
+	org2 := n.AddPeers("org2", 4)
+	n.JoinChannel("testchannel", append(org1, org2...)...)
+
+	require.True(t, n.AdvanceUntil(n.MembershipConverged, time.Minute))
+	require.True(t, n.AdvanceUntil(func() bool {
+		return n.ChannelMembershipConverged("testchannel")
+	}, time.Minute))
+	require.True(t, n.IsAnchorPeer(org1[0].Endpoint))
+	require.False(t, n.IsAnchorPeer(org1[1].Endpoint))
+
+	org1[0].Broadcast("testchannel", 1)
+	require.True(t, n.AdvanceUntil(func() bool {
+		for _, p := range org1 {
+			if !p.HasBlock("testchannel", 1) {
+				return false
+			}
+		}
+		return true
+	}, 30*time.Second))
+	// Blocks don't cross organizations
+	for _, p := range org2 {
+		require.False(t, p.HasBlock("testchannel", 1))
+	}
+
+	org2[0].Broadcast("testchannel", 1)
+	require.True(t, n.AdvanceUntil(func() bool {
+		return n.BlockDisseminated("testchannel", 1)
+	}, 30*time.Second))
+}
+
+func TestPartitionAndHeal(t *testing.T) {
+	n := NewNetwork(3)
+	defer stopAll(n)
+	peers := n.AddPeers("org1", 6)
+	require.True(t, n.AdvanceUntil(n.MembershipConverged, time.Minute))
+
+	n.Partition(peers[:3], peers[3:])
+	require.True(t, n.AdvanceUntil(n.MembershipConverged, 2*time.Minute))
+	require.Len(t, peers[0].AlivePeers(), 2)
+	require.Len(t, peers[5].AlivePeers(), 2)
+
+	n.Heal()
+	require.True(t, n.AdvanceUntil(n.MembershipConverged, 2*time.Minute))
+	require.Len(t, peers[0].AlivePeers(), 5)
+}
+
+func TestRestart(t *testing.T) {
+	n := NewNetwork(4)
+	defer stopAll(n)
+	peers := n.AddPeers("org1", 4)
+	n.JoinChannel("testchannel", peers...)
+	require.True(t, n.AdvanceUntil(n.MembershipConverged, time.Minute))
+
+	peers[2].Stop()
+	require.False(t, peers[2].Running())
+	require.Nil(t, peers[2].AlivePeers())
+	require.True(t, n.AdvanceUntil(n.MembershipConverged, 2*time.Minute))
+	require.Len(t, peers[0].AlivePeers(), 2)
+
+	peers[2].Start()
+	require.True(t, n.AdvanceUntil(n.MembershipConverged, 2*time.Minute))
+	require.True(t, n.AdvanceUntil(func() bool {
+		return n.ChannelMembershipConverged("testchannel")
+	}, time.Minute))
+
+	peers[0].Broadcast("testchannel", 1)
+	require.True(t, n.AdvanceUntil(func() bool {
+		return n.BlockDisseminated("testchannel", 1)
+	}, 30*time.Second))
+}
+
+func TestLossyNetwork(t *testing.T) {
+	n := NewNetwork(5)
+	defer stopAll(n)
+	n.SetLatency(100*time.Millisecond, 50*time.Millisecond)
+	n.SetDropRate(0.05)
+	peers := n.AddPeers("org1", 6)
+	n.JoinChannel("testchannel", peers...)
+
+	require.True(t, n.AdvanceUntil(n.MembershipConverged, 2*time.Minute))
+	peers[0].Broadcast("testchannel", 1)
+	require.True(t, n.AdvanceUntil(func() bool {
+		return n.BlockDisseminated("testchannel", 1)
+	}, time.Minute))
+}
+
+func TestFaultsAreDerivedFromSeed(t *testing.T) {
+	l := link{from: "peer0.org1:7051", to: "peer1.org1:7051"}
+	n1, n2, n3 := NewNetwork(1), NewNetwork(1), NewNetwork(2)
+	for counter := uint64(0); counter < 100; counter++ {
+		require.Equal(t, n1.random(l, counter, 0), n2.random(l, counter, 0))
+	}
+	require.NotEqual(t, n1.random(l, 1, 0), n3.random(l, 1, 0))
+	require.NotEqual(t, n1.random(l, 1, 0), n1.random(l, 1, 1))
+	require.NotEqual(t, n1.random(l, 1, 0), n1.random(link{from: l.to, to: l.from}, 1, 0))
+}
+
+func TestLargeNetwork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping simulation of a large network in short mode")
+	}
+	n := NewNetwork(6)
+	defer stopAll(n)
+	var peers []*Peer
+	for _, org := range []string{"org1", "org2", "org3", "org4"} {
+		peers = append(peers, n.AddPeers(org, 25)...)
+	}
+	n.JoinChannel("testchannel", peers...)
+
+	require.True(t, n.AdvanceUntil(n.MembershipConverged, 3*time.Minute))
+	t.Logf("Membership of %d peers converged after %v", len(peers), n.Elapsed())
+	for i := 0; i < len(peers); i += 25 {
+		peers[i].Broadcast("testchannel", 1)
+	}
+	require.True(t, n.AdvanceUntil(func() bool {
+		return n.BlockDisseminated("testchannel", 1)
+	}, time.Minute))
+}
@@ -0,0 +1,250 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package simulation
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	gcomm "github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/gossip"
+	"github.com/hyperledger/fabric/gossip/gossip/algo"
+	"github.com/hyperledger/fabric/gossip/gossip/channel"
+	"github.com/hyperledger/fabric/gossip/identity"
+	"github.com/hyperledger/fabric/gossip/metrics"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+// Peer is a gossip peer of a simulated network. A peer can be stopped and
+// started again, in which case it rejoins its channels with a new gossip
+// instance, while keeping the blocks it received.
+type Peer struct {
+	Endpoint string
+	Org      string
+
+	network        *Network
+	bootstrapPeers []string
+
+	lock     sync.Mutex
+	node     *gossip.Node
+	channels map[string]struct{}
+	blocks   map[string]map[uint64]struct{}
+}
+
+// Start starts the peer if it isn't running
+func (p *Peer) Start() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.node != nil {
+		return
+	}
+
+	selfIdentity := peerIdentity(p.Endpoint, p.Org)
+	commFactory := func(idMapper identity.Mapper) (gcomm.Comm, error) {
+		c := newVirtualComm(p.network, p.Endpoint, selfIdentity, idMapper)
+		p.network.attach(c)
+		return c, nil
+	}
+	cs := &cryptoService{}
+	p.node = gossip.NewWithComm(p.config(), commFactory, cs, cs, selfIdentity,
+		metrics.NewGossipMetrics(&disabled.Provider{}), p.network)
+
+	for channelID := range p.channels {
+		p.node.JoinChan(p.network.channelConfig(channelID), common.ChannelID(channelID))
+		p.node.UpdateLedgerHeight(p.ledgerHeight(channelID), common.ChannelID(channelID))
+		p.trackBlocks(channelID)
+	}
+}
+
+// Stop stops the peer if it is running
+func (p *Peer) Stop() {
+	p.lock.Lock()
+	node := p.node
+	p.node = nil
+	p.lock.Unlock()
+	if node != nil {
+		node.Stop()
+	}
+}
+
+// Restart stops the peer and starts it again
+func (p *Peer) Restart() {
+	p.Stop()
+	p.Start()
+}
+
+// Running returns whether the peer is running
+func (p *Peer) Running() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.node != nil
+}
+
+// Node returns the gossip instance of the peer, or nil if it isn't running
+func (p *Peer) Node() *gossip.Node {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.node
+}
+
+// AlivePeers returns the endpoints of the peers this peer considers alive
+func (p *Peer) AlivePeers() []string {
+	node := p.Node()
+	if node == nil {
+		return nil
+	}
+	return endpointsOf(node.Peers())
+}
+
+// ChannelPeers returns the endpoints of the peers this peer considers
+// part of the given channel
+func (p *Peer) ChannelPeers(channelID string) []string {
+	node := p.Node()
+	if node == nil {
+		return nil
+	}
+	return endpointsOf(node.PeersOfChannel(common.ChannelID(channelID)))
+}
+
+// Broadcast disseminates the block with the given sequence number in the given
+// channel, like the leader peer of an organization does with the blocks it pulls
+// from the ordering service. Blocks are only disseminated to peers of the same
+// organization, hence each organization needs a peer that broadcasts them.
+func (p *Peer) Broadcast(channelID string, seqNum uint64) {
+	node := p.Node()
+	if node == nil {
+		return
+	}
+	p.addBlock(channelID, seqNum)
+	block := &cb.Block{
+		Header: &cb.BlockHeader{Number: seqNum},
+		Data:   &cb.BlockData{},
+	}
+	node.Gossip(&proto.GossipMessage{
+		Channel: []byte(channelID),
+		Tag:     proto.GossipMessage_CHAN_AND_ORG,
+		Content: &proto.GossipMessage_DataMsg{
+			DataMsg: &proto.DataMessage{
+				Payload: &proto.Payload{
+					Data:   protoutil.MarshalOrPanic(block),
+					SeqNum: seqNum,
+				},
+			},
+		},
+	})
+}
+
+// HasBlock returns whether the peer has the block with the given sequence
+// number of the given channel
+func (p *Peer) HasBlock(channelID string, seqNum uint64) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, exists := p.blocks[channelID][seqNum]
+	return exists
+}
+
+func (p *Peer) joinChannel(channelID string, jcm *joinChannelMessage) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, joined := p.channels[channelID]
+	p.channels[channelID] = struct{}{}
+	if p.node == nil {
+		return
+	}
+	p.node.JoinChan(jcm, common.ChannelID(channelID))
+	if !joined {
+		p.node.UpdateLedgerHeight(p.ledgerHeight(channelID), common.ChannelID(channelID))
+		p.trackBlocks(channelID)
+	}
+}
+
+// trackBlocks records the blocks of the given channel the peer receives.
+// It is called with the lock held.
+func (p *Peer) trackBlocks(channelID string) {
+	blocks, _ := p.node.Accept(func(m interface{}) bool {
+		msg := m.(*proto.GossipMessage)
+		return protoext.IsDataMsg(msg) && bytes.Equal(msg.Channel, []byte(channelID))
+	}, false)
+	go func() {
+		for msg := range blocks {
+			p.addBlock(channelID, msg.GetDataMsg().Payload.SeqNum)
+		}
+	}()
+}
+
+func (p *Peer) addBlock(channelID string, seqNum uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.blocks[channelID] == nil {
+		p.blocks[channelID] = make(map[uint64]struct{})
+	}
+	p.blocks[channelID][seqNum] = struct{}{}
+	if p.node != nil {
+		p.node.UpdateLedgerHeight(p.ledgerHeight(channelID), common.ChannelID(channelID))
+	}
+}
+
+// ledgerHeight returns the height of the ledger of the given channel, as if
+// the peer committed every block up to the highest block it received.
+// It is called with the lock held.
+func (p *Peer) ledgerHeight(channelID string) uint64 {
+	height := uint64(1)
+	for seqNum := range p.blocks[channelID] {
+		if seqNum+1 > height {
+			height = seqNum + 1
+		}
+	}
+	return height
+}
+
+// config returns the gossip configuration of the peer, which is the default
+// configuration of a peer timed by the clock of the network
+func (p *Peer) config() *gossip.Config {
+	return &gossip.Config{
+		ID:                           p.Endpoint,
+		BootstrapPeers:               p.bootstrapPeers,
+		PropagateIterations:          1,
+		PropagatePeerNum:             3,
+		MaxBlockCountToStore:         100,
+		MaxPropagationBurstSize:      10,
+		MaxPropagationBurstLatency:   10 * time.Millisecond,
+		PullInterval:                 4 * time.Second,
+		PullPeerNum:                  3,
+		PublishCertPeriod:            10 * time.Second,
+		PublishStateInfoInterval:     4 * time.Second,
+		RequestStateInfoInterval:     4 * time.Second,
+		InternalEndpoint:             p.Endpoint,
+		ExternalEndpoint:             p.Endpoint,
+		TimeForMembershipTracker:     5 * time.Second,
+		DigestWaitTime:               algo.DefDigestWaitTime,
+		RequestWaitTime:              algo.DefRequestWaitTime,
+		ResponseWaitTime:             algo.DefResponseWaitTime,
+		MsgExpirationTimeout:         channel.DefMsgExpirationTimeout,
+		AliveTimeInterval:            discovery.DefAliveTimeInterval,
+		AliveExpirationTimeout:       discovery.DefAliveExpirationTimeout,
+		AliveExpirationCheckInterval: discovery.DefAliveExpirationCheckInterval,
+		ReconnectInterval:            discovery.DefReconnectInterval,
+		MaxConnectionAttempts:        discovery.DefMaxConnectionAttempts,
+		MsgExpirationFactor:          discovery.DefMsgExpirationFactor,
+		Clock:                        p.network.clock,
+	}
+}
+
+func endpointsOf(members []discovery.NetworkMember) []string {
+	var endpoints []string
+	for _, member := range members {
+		endpoints = append(endpoints, member.PreferredEndpoint())
+	}
+	return endpoints
+}
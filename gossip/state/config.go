@@ -13,23 +13,25 @@ import (
 )
 
 const (
-	DefStateCheckInterval   = 10 * time.Second
-	DefStateResponseTimeout = 3 * time.Second
-	DefStateBatchSize       = 10
-	DefStateMaxRetries      = 3
-	DefStateBlockBufferSize = 20
-	DefStateChannelSize     = 100
-	DefStateEnabled         = false
+	DefStateCheckInterval    = 10 * time.Second
+	DefStateResponseTimeout  = 3 * time.Second
+	DefStateBatchSize        = 10
+	DefStateMaxRetries       = 3
+	DefStateBlockBufferSize  = 20
+	DefStateChannelSize      = 100
+	DefStateEnabled          = false
+	DefStateMaxParallelPeers = 4
 )
 
 type StateConfig struct {
-	StateCheckInterval   time.Duration
-	StateResponseTimeout time.Duration
-	StateBatchSize       uint64
-	StateMaxRetries      int
-	StateBlockBufferSize int
-	StateChannelSize     int
-	StateEnabled         bool
+	StateCheckInterval    time.Duration
+	StateResponseTimeout  time.Duration
+	StateBatchSize        uint64
+	StateMaxRetries       int
+	StateBlockBufferSize  int
+	StateChannelSize      int
+	StateEnabled          bool
+	StateMaxParallelPeers int
 }
 
 func GlobalConfig() *StateConfig {
@@ -68,4 +70,8 @@ func (c *StateConfig) loadStateConfig() {
 	if viper.IsSet("peer.gossip.state.enabled") {
 		c.StateEnabled = viper.GetBool("peer.gossip.state.enabled")
 	}
+	c.StateMaxParallelPeers = DefStateMaxParallelPeers
+	if viper.IsSet("peer.gossip.state.maxParallelPeers") {
+		c.StateMaxParallelPeers = viper.GetInt("peer.gossip.state.maxParallelPeers")
+	}
 }
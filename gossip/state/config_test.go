@@ -24,17 +24,19 @@ func TestGlobalConfig(t *testing.T) {
 	viper.Set("peer.gossip.state.blockBufferSize", 5)
 	viper.Set("peer.gossip.state.channelSize", 6)
 	viper.Set("peer.gossip.state.enabled", true)
+	viper.Set("peer.gossip.state.maxParallelPeers", 7)
 
 	coreConfig := state.GlobalConfig()
 
 	expectedConfig := &state.StateConfig{
-		StateCheckInterval:   time.Second,
-		StateResponseTimeout: 2 * time.Second,
-		StateBatchSize:       uint64(3),
-		StateMaxRetries:      4,
-		StateBlockBufferSize: 5,
-		StateChannelSize:     6,
-		StateEnabled:         true,
+		StateCheckInterval:    time.Second,
+		StateResponseTimeout:  2 * time.Second,
+		StateBatchSize:        uint64(3),
+		StateMaxRetries:       4,
+		StateBlockBufferSize:  5,
+		StateChannelSize:      6,
+		StateEnabled:          true,
+		StateMaxParallelPeers: 7,
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
@@ -46,13 +48,14 @@ func TestGlobalConfigDefaults(t *testing.T) {
 	coreConfig := state.GlobalConfig()
 
 	expectedConfig := &state.StateConfig{
-		StateCheckInterval:   10 * time.Second,
-		StateResponseTimeout: 3 * time.Second,
-		StateBatchSize:       uint64(10),
-		StateMaxRetries:      3,
-		StateBlockBufferSize: 20,
-		StateChannelSize:     100,
-		StateEnabled:         false,
+		StateCheckInterval:    10 * time.Second,
+		StateResponseTimeout:  3 * time.Second,
+		StateBatchSize:        uint64(10),
+		StateMaxRetries:       3,
+		StateBlockBufferSize:  20,
+		StateChannelSize:      100,
+		StateEnabled:          false,
+		StateMaxParallelPeers: 4,
 	}
 
 	require.Equal(t, expectedConfig, coreConfig)
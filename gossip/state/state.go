@@ -628,65 +628,6 @@ func (s *GossipStateProviderImpl) maxAvailableLedgerHeight() uint64 {
 	return max
 }
 
-// requestBlocksInRange capable to acquire blocks with sequence
-// numbers in the range [start...end).
-func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
-	atomic.StoreInt32(&s.stateTransferActive, 1)
-	defer atomic.StoreInt32(&s.stateTransferActive, 0)
-
-	for prev := start; prev <= end; {
-		next := min(end, prev+s.config.StateBatchSize)
-
-		gossipMsg := s.stateRequestMessage(prev, next)
-
-		responseReceived := false
-		tryCounts := 0
-
-		for !responseReceived {
-			if tryCounts > s.config.StateMaxRetries {
-				s.logger.Warningf("Wasn't  able to get blocks in range [%d...%d), after %d retries",
-					prev, next, tryCounts)
-				return
-			}
-			// Select peers to ask for blocks
-			peer, err := s.selectPeerToRequestFrom(next)
-			if err != nil {
-				s.logger.Warningf("Cannot send state request for blocks in range [%d...%d), due to %+v",
-					prev, next, errors.WithStack(err))
-				return
-			}
-
-			s.logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d), "+
-				"for chainID %s", peer.Endpoint, prev, next, s.chainID)
-
-			s.mediator.Send(gossipMsg, peer)
-			tryCounts++
-
-			// Wait until timeout or response arrival
-			select {
-			case msg, stillOpen := <-s.stateResponseCh:
-				if !stillOpen {
-					return
-				}
-				if msg.GetGossipMessage().Nonce !=
-					gossipMsg.Nonce {
-					continue
-				}
-				// Got corresponding response for state request, can continue
-				index, err := s.handleStateResponse(msg)
-				if err != nil {
-					s.logger.Warningf("Wasn't able to process state response for "+
-						"blocks [%d...%d], due to %+v", prev, next, errors.WithStack(err))
-					continue
-				}
-				prev = index + 1
-				responseReceived = true
-			case <-time.After(s.config.StateResponseTimeout):
-			}
-		}
-	}
-}
-
 // stateRequestMessage generates state request message for given blocks in range [beginSeq...endSeq]
 func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq uint64) *proto.GossipMessage {
 	return &proto.GossipMessage{
@@ -702,45 +643,6 @@ func (s *GossipStateProviderImpl) stateRequestMessage(beginSeq uint64, endSeq ui
 	}
 }
 
-// selectPeerToRequestFrom selects peer which has required blocks to ask missing blocks from
-func (s *GossipStateProviderImpl) selectPeerToRequestFrom(height uint64) (*comm.RemotePeer, error) {
-	// Filter peers which posses required range of missing blocks
-	peers := s.filterPeers(s.hasRequiredHeight(height))
-
-	n := len(peers)
-	if n == 0 {
-		return nil, errors.New("there are no peers to ask for missing blocks from")
-	}
-
-	// Select peer to ask for blocks
-	return peers[util.RandomInt(n)], nil
-}
-
-// filterPeers returns list of peers which aligns the predicate provided
-func (s *GossipStateProviderImpl) filterPeers(predicate func(peer discovery.NetworkMember) bool) []*comm.RemotePeer {
-	var peers []*comm.RemotePeer
-
-	for _, member := range s.mediator.PeersOfChannel(common2.ChannelID(s.chainID)) {
-		if predicate(member) {
-			peers = append(peers, &comm.RemotePeer{Endpoint: member.PreferredEndpoint(), PKIID: member.PKIid})
-		}
-	}
-
-	return peers
-}
-
-// hasRequiredHeight returns predicate which is capable to filter peers with ledger height above than indicated
-// by provided input parameter
-func (s *GossipStateProviderImpl) hasRequiredHeight(height uint64) func(peer discovery.NetworkMember) bool {
-	return func(peer discovery.NetworkMember) bool {
-		if peer.Properties != nil {
-			return peer.Properties.LedgerHeight >= height
-		}
-		s.logger.Debug(peer.PreferredEndpoint(), "doesn't have properties")
-		return false
-	}
-}
-
 // AddPayload adds new payload into state.
 func (s *GossipStateProviderImpl) AddPayload(payload *proto.Payload) error {
 	return s.addPayload(payload, s.blockingMode)
@@ -0,0 +1,336 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	"github.com/hyperledger/fabric/gossip/util"
+	"github.com/pkg/errors"
+)
+
+// blockRange is a range of blocks [start...end] which is yet to be
+// received by state transfer.
+type blockRange struct {
+	start uint64
+	end   uint64
+	// tries is the number of requests for the range which failed
+	tries int
+	// lastPeer is the PKI-ID of the peer the range was last requested
+	// from, which is avoided when requesting the range again
+	lastPeer string
+}
+
+// transferPeer is a peer blocks are requested from by state transfer.
+type transferPeer struct {
+	remotePeer *comm.RemotePeer
+	height     uint64
+	// batchSize is the span of the next request sent to the peer. It is
+	// halved every time the peer fails to respond and grows back as the
+	// peer responds.
+	batchSize uint64
+	busy      bool
+}
+
+// transferRequest is a request for a range of blocks sent to a peer
+// which has not been responded to yet.
+type transferRequest struct {
+	blockRange
+	peer   *transferPeer
+	sentAt time.Time
+}
+
+// blockTransfer splits a range of missing blocks across several peers and
+// requests the blocks from all of them at the same time. Each peer has at
+// most one request in flight.
+type blockTransfer struct {
+	s *GossipStateProviderImpl
+
+	// pending holds the ranges yet to be requested, sorted by start
+	pending  []*blockRange
+	inflight map[uint64]*transferRequest
+	peers    map[string]*transferPeer
+}
+
+// requestBlocksInRange capable to acquire blocks with sequence
+// numbers in the range [start...end).
+func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64) {
+	atomic.StoreInt32(&s.stateTransferActive, 1)
+	defer atomic.StoreInt32(&s.stateTransferActive, 0)
+
+	t := &blockTransfer{
+		s:        s,
+		pending:  []*blockRange{{start: start, end: end}},
+		inflight: map[uint64]*transferRequest{},
+		peers:    map[string]*transferPeer{},
+	}
+	defer s.stateMetrics.TransferPeers.With("channel", s.chainID).Set(0)
+
+	for {
+		if err := t.dispatch(); err != nil {
+			s.logger.Warningf("Cannot complete state transfer of blocks in range [%d...%d), due to %+v", start, end, err)
+			return
+		}
+		if len(t.pending) == 0 && len(t.inflight) == 0 {
+			return
+		}
+		s.stateMetrics.TransferPeers.With("channel", s.chainID).Set(float64(len(t.inflight)))
+
+		// Wait until timeout or response arrival
+		select {
+		case msg, stillOpen := <-s.stateResponseCh:
+			if !stillOpen {
+				return
+			}
+			t.handleResponse(msg)
+		case <-time.After(t.nextTimeout()):
+			t.expire()
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+// dispatch requests the lowest pending ranges from idle peers, up to the
+// configured number of peers. It fails if a range exhausted its retries or
+// no peer can be asked for the lowest pending range.
+func (t *blockTransfer) dispatch() error {
+	s := t.s
+	// blocks which arrived since the transfer started, by gossip or
+	// through another request, don't need to be requested again
+	next := s.payloads.Next()
+	for len(t.pending) > 0 && t.pending[0].end < next {
+		t.pending = t.pending[1:]
+	}
+	if len(t.pending) > 0 && t.pending[0].start < next {
+		t.pending[0].start = next
+	}
+
+	// Only blocks within twice the block buffer size of the next block to
+	// deliver are requested. Blocks past a missing block are kept in the
+	// payloads buffer, which must not grow to the point adding the missing
+	// block blocks.
+	windowEnd := next + 2*uint64(s.config.StateBlockBufferSize)
+
+	maxParallelPeers := s.config.StateMaxParallelPeers
+	if maxParallelPeers < 1 {
+		maxParallelPeers = 1
+	}
+
+	t.refreshPeers()
+	for len(t.inflight) < maxParallelPeers && len(t.pending) > 0 {
+		r := t.pending[0]
+		if r.tries > s.config.StateMaxRetries {
+			return errors.Errorf("wasn't able to get blocks in range [%d...%d], after %d retries", r.start, r.end, r.tries)
+		}
+		if r.start >= windowEnd {
+			break
+		}
+
+		peer, end := t.selectPeer(r, windowEnd-1)
+		if peer == nil {
+			if len(t.inflight) == 0 {
+				return errors.New("there are no peers to ask for missing blocks from")
+			}
+			break
+		}
+
+		req := &transferRequest{
+			blockRange: blockRange{start: r.start, end: end, tries: r.tries, lastPeer: r.lastPeer},
+			peer:       peer,
+		}
+		if end == r.end {
+			t.pending = t.pending[1:]
+		} else {
+			r.start = end + 1
+		}
+		t.send(req)
+	}
+
+	return nil
+}
+
+// refreshPeers updates the peers of the channel along with their ledger
+// heights. Peers which are no longer alive are dropped unless a request
+// was sent to them.
+func (t *blockTransfer) refreshPeers() {
+	alive := map[string]struct{}{}
+	for _, member := range t.s.mediator.PeersOfChannel(common2.ChannelID(t.s.chainID)) {
+		if member.Properties == nil {
+			t.s.logger.Debug(member.PreferredEndpoint(), "doesn't have properties")
+			continue
+		}
+		id := string(member.PKIid)
+		alive[id] = struct{}{}
+		peer, exists := t.peers[id]
+		if !exists {
+			peer = &transferPeer{batchSize: t.s.config.StateBatchSize}
+			t.peers[id] = peer
+		}
+		peer.remotePeer = &comm.RemotePeer{Endpoint: member.PreferredEndpoint(), PKIID: member.PKIid}
+		peer.height = member.Properties.LedgerHeight
+	}
+	for id, peer := range t.peers {
+		if _, exists := alive[id]; !exists && !peer.busy {
+			delete(t.peers, id)
+		}
+	}
+}
+
+// selectPeer selects an idle peer to request a range starting at the start
+// of the given range from, and returns it along with the end of the range
+// to request. Peers the range was not last requested from are preferred.
+func (t *blockTransfer) selectPeer(r *blockRange, maxEnd uint64) (*transferPeer, uint64) {
+	type candidate struct {
+		peer *transferPeer
+		end  uint64
+	}
+	var preferred, others []candidate
+	for id, peer := range t.peers {
+		if peer.busy {
+			continue
+		}
+		end := min(min(r.end, r.start+peer.batchSize), maxEnd)
+		if peer.height < end {
+			continue
+		}
+		if id == r.lastPeer {
+			others = append(others, candidate{peer: peer, end: end})
+			continue
+		}
+		preferred = append(preferred, candidate{peer: peer, end: end})
+	}
+
+	candidates := preferred
+	if len(candidates) == 0 {
+		candidates = others
+	}
+	if len(candidates) == 0 {
+		return nil, 0
+	}
+	c := candidates[util.RandomInt(len(candidates))]
+	return c.peer, c.end
+}
+
+func (t *blockTransfer) send(req *transferRequest) {
+	s := t.s
+	gossipMsg := s.stateRequestMessage(req.start, req.end)
+
+	s.logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
+		"for chainID %s", req.peer.remotePeer.Endpoint, req.start, req.end, s.chainID)
+
+	req.peer.busy = true
+	req.sentAt = time.Now()
+	t.inflight[gossipMsg.Nonce] = req
+	s.mediator.Send(gossipMsg, req.peer.remotePeer)
+	s.stateMetrics.TransferRequests.With("channel", s.chainID).Add(1)
+}
+
+// handleResponse processes the response to an in flight request. Blocks
+// of the request the peer did not send are requested again.
+func (t *blockTransfer) handleResponse(msg protoext.ReceivedMessage) {
+	s := t.s
+	req, exists := t.inflight[msg.GetGossipMessage().Nonce]
+	if !exists {
+		// a response to a request which timed out, or to a request of
+		// a previous transfer
+		return
+	}
+	delete(t.inflight, msg.GetGossipMessage().Nonce)
+	req.peer.busy = false
+	s.stateMetrics.TransferRequestDuration.With("channel", s.chainID).Observe(time.Since(req.sentAt).Seconds())
+
+	// Got corresponding response for state request, can continue
+	index, err := s.handleStateResponse(msg)
+	if err == nil && index < req.start {
+		err = errors.Errorf("response does not contain blocks in range [%d...%d]", req.start, req.end)
+	}
+	if err != nil {
+		s.logger.Warningf("Wasn't able to process state response for "+
+			"blocks [%d...%d], due to %+v", req.start, req.end, errors.WithStack(err))
+		req.peer.decreaseBatchSize()
+		t.retry(req)
+		return
+	}
+
+	s.stateMetrics.TransferBlocks.With("channel", s.chainID).Add(float64(len(msg.GetGossipMessage().GetStateResponse().GetPayloads())))
+	req.peer.increaseBatchSize(s.config.StateBatchSize)
+	if index < req.end {
+		t.requeue(&blockRange{start: index + 1, end: req.end, tries: req.tries, lastPeer: req.lastPeer})
+	}
+}
+
+// expire retries the in flight requests which were not responded to in time.
+func (t *blockTransfer) expire() {
+	s := t.s
+	now := time.Now()
+	for nonce, req := range t.inflight {
+		if now.Sub(req.sentAt) < s.config.StateResponseTimeout {
+			continue
+		}
+		s.logger.Debugf("State transfer request for blocks in range [%d...%d] sent to peer %s timed out",
+			req.start, req.end, req.peer.remotePeer.Endpoint)
+		delete(t.inflight, nonce)
+		req.peer.busy = false
+		req.peer.decreaseBatchSize()
+		s.stateMetrics.TransferTimeouts.With("channel", s.chainID).Add(1)
+		t.retry(req)
+	}
+}
+
+// nextTimeout returns the time left until the earliest in flight request
+// times out. Without requests in flight, it returns the interval the
+// payloads buffer is waited on to drain.
+func (t *blockTransfer) nextTimeout() time.Duration {
+	if len(t.inflight) == 0 {
+		return enqueueRetryInterval
+	}
+	var earliest time.Time
+	for _, req := range t.inflight {
+		if earliest.IsZero() || req.sentAt.Before(earliest) {
+			earliest = req.sentAt
+		}
+	}
+	timeout := time.Until(earliest.Add(t.s.config.StateResponseTimeout))
+	if timeout < 0 {
+		return 0
+	}
+	return timeout
+}
+
+// retry puts a failed request back among the pending ranges, to be
+// requested from another peer if there is one.
+func (t *blockTransfer) retry(req *transferRequest) {
+	t.requeue(&blockRange{
+		start:    req.start,
+		end:      req.end,
+		tries:    req.tries + 1,
+		lastPeer: string(req.peer.remotePeer.PKIID),
+	})
+}
+
+func (t *blockTransfer) requeue(r *blockRange) {
+	i := sort.Search(len(t.pending), func(i int) bool {
+		return t.pending[i].start > r.start
+	})
+	t.pending = append(t.pending, nil)
+	copy(t.pending[i+1:], t.pending[i:])
+	t.pending[i] = r
+}
+
+func (p *transferPeer) decreaseBatchSize() {
+	p.batchSize /= 2
+}
+
+func (p *transferPeer) increaseBatchSize(max uint64) {
+	p.batchSize = min(max, 2*p.batchSize+1)
+}
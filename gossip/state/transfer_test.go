@@ -0,0 +1,338 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	pcomm "github.com/hyperledger/fabric-protos-go/common"
+	proto "github.com/hyperledger/fabric-protos-go/gossip"
+	tspb "github.com/hyperledger/fabric-protos-go/transientstore"
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/gossip/api"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/metrics"
+	gmetricsmocks "github.com/hyperledger/fabric/gossip/metrics/mocks"
+	"github.com/hyperledger/fabric/gossip/protoext"
+	gutil "github.com/hyperledger/fabric/gossip/util"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+type sentStateRequest struct {
+	msg  *proto.GossipMessage
+	peer *comm.RemotePeer
+}
+
+type transferGossip struct {
+	peers []discovery.NetworkMember
+	sent  chan sentStateRequest
+}
+
+func (g *transferGossip) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
+	g.sent <- sentStateRequest{msg: msg, peer: peers[0]}
+}
+
+func (g *transferGossip) Accept(acceptor common.MessageAcceptor, passThrough bool) (<-chan *proto.GossipMessage, <-chan protoext.ReceivedMessage) {
+	return nil, nil
+}
+
+func (g *transferGossip) UpdateLedgerHeight(height uint64, channelID common.ChannelID) {}
+
+func (g *transferGossip) PeersOfChannel(common.ChannelID) []discovery.NetworkMember {
+	return g.peers
+}
+
+type transferMCS struct{}
+
+func (transferMCS) VerifyBlock(channelID common.ChannelID, seqNum uint64, signedBlock *pcomm.Block) error {
+	return nil
+}
+
+func (transferMCS) VerifyByChannel(channelID common.ChannelID, peerIdentity api.PeerIdentityType, signature, message []byte) error {
+	return nil
+}
+
+type transferLedger struct{}
+
+func (transferLedger) StoreBlock(block *pcomm.Block, data gutil.PvtDataCollections) error {
+	return nil
+}
+
+func (transferLedger) StorePvtData(txid string, privData *tspb.TxPvtReadWriteSetWithConfigInfo, blckHeight uint64) error {
+	return nil
+}
+
+func (transferLedger) GetPvtDataAndBlockByNum(seqNum uint64, peerAuthInfo protoutil.SignedData) (*pcomm.Block, gutil.PvtDataCollections, error) {
+	return nil, nil, nil
+}
+
+func (transferLedger) LedgerHeight() (uint64, error) {
+	return 1, nil
+}
+
+func (transferLedger) Close() {}
+
+func newTransferTestState(peerCount int, config *StateConfig) (*GossipStateProviderImpl, *transferGossip, *gmetricsmocks.TestMetricProvider) {
+	g := &transferGossip{sent: make(chan sentStateRequest, 10)}
+	for i := 0; i < peerCount; i++ {
+		g.peers = append(g.peers, discovery.NetworkMember{
+			PKIid:      common.PKIidType{byte(i)},
+			Endpoint:   string(rune('a'+i)) + ":7051",
+			Properties: &proto.Properties{LedgerHeight: 100},
+		})
+	}
+	testMetricProvider := gmetricsmocks.TestUtilConstructMetricProvider()
+
+	s := &GossipStateProviderImpl{
+		logger:          flogging.MustGetLogger(gutil.StateLogger),
+		chainID:         "testchannelid",
+		mediator:        &ServicesMediator{GossipAdapter: g, MCSAdapter: transferMCS{}},
+		payloads:        NewPayloadsBuffer(1),
+		ledger:          transferLedger{},
+		stateResponseCh: make(chan protoext.ReceivedMessage, 10),
+		stopCh:          make(chan struct{}),
+		stateMetrics:    metrics.NewGossipMetrics(testMetricProvider.FakeProvider).StateMetrics,
+		config:          config,
+	}
+	return s, g, testMetricProvider
+}
+
+func transferTestConfig() *StateConfig {
+	return &StateConfig{
+		StateResponseTimeout:  time.Minute,
+		StateBatchSize:        10,
+		StateMaxRetries:       3,
+		StateBlockBufferSize:  20,
+		StateMaxParallelPeers: 3,
+	}
+}
+
+// stateResponse builds the response to a state request carrying the
+// blocks in the range [start...end].
+func stateResponse(request *proto.GossipMessage, start, end uint64) protoext.ReceivedMessage {
+	response := &proto.RemoteStateResponse{}
+	for seqNum := start; seqNum <= end; seqNum++ {
+		response.Payloads = append(response.Payloads, &proto.Payload{
+			SeqNum: seqNum,
+			Data:   protoutil.MarshalOrPanic(protoutil.NewBlock(seqNum, []byte{})),
+		})
+	}
+	msg := &receivedMessageMock{}
+	msg.On("GetGossipMessage").Return(&protoext.SignedGossipMessage{
+		GossipMessage: &proto.GossipMessage{
+			Nonce:   request.Nonce,
+			Content: &proto.GossipMessage_StateResponse{StateResponse: response},
+		},
+	})
+	return msg
+}
+
+func requestedRange(req sentStateRequest) [2]uint64 {
+	stateRequest := req.msg.GetStateRequest()
+	return [2]uint64{stateRequest.StartSeqNum, stateRequest.EndSeqNum}
+}
+
+func receiveStateRequest(t *testing.T, g *transferGossip) sentStateRequest {
+	select {
+	case req := <-g.sent:
+		return req
+	case <-time.After(10 * time.Second):
+		t.Fatal("state request was not sent")
+		return sentStateRequest{}
+	}
+}
+
+func requireTransferDone(t *testing.T, done chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("state transfer did not complete")
+	}
+}
+
+func TestBlockTransferSplitsRangeAcrossPeers(t *testing.T) {
+	s, g, testMetricProvider := newTransferTestState(3, transferTestConfig())
+
+	done := make(chan struct{})
+	go func() {
+		s.requestBlocksInRange(1, 30)
+		close(done)
+	}()
+
+	requests := map[[2]uint64]sentStateRequest{}
+	peers := map[string]struct{}{}
+	for i := 0; i < 3; i++ {
+		req := receiveStateRequest(t, g)
+		requests[requestedRange(req)] = req
+		peers[string(req.peer.PKIID)] = struct{}{}
+	}
+	require.Len(t, peers, 3)
+	require.Contains(t, requests, [2]uint64{1, 11})
+	require.Contains(t, requests, [2]uint64{12, 22})
+	require.Contains(t, requests, [2]uint64{23, 30})
+
+	// responses arriving out of order are buffered until delivered in order
+	s.stateResponseCh <- stateResponse(requests[[2]uint64{23, 30}].msg, 23, 30)
+	s.stateResponseCh <- stateResponse(requests[[2]uint64{1, 11}].msg, 1, 11)
+	s.stateResponseCh <- stateResponse(requests[[2]uint64{12, 22}].msg, 12, 22)
+	requireTransferDone(t, done)
+
+	require.Equal(t, 30, s.payloads.Size())
+	for seqNum := uint64(1); seqNum <= 30; seqNum++ {
+		require.Equal(t, seqNum, s.payloads.Pop().SeqNum)
+	}
+
+	require.Equal(t, 3, testMetricProvider.FakeTransferRequests.AddCallCount())
+	var blocks float64
+	for i := 0; i < testMetricProvider.FakeTransferBlocks.AddCallCount(); i++ {
+		blocks += testMetricProvider.FakeTransferBlocks.AddArgsForCall(i)
+	}
+	require.Equal(t, float64(30), blocks)
+	require.Equal(t, 3, testMetricProvider.FakeTransferRequestDuration.ObserveCallCount())
+	require.Equal(t, float64(3), testMetricProvider.FakeTransferPeers.SetArgsForCall(0))
+	lastSet := testMetricProvider.FakeTransferPeers.SetCallCount() - 1
+	require.Equal(t, float64(0), testMetricProvider.FakeTransferPeers.SetArgsForCall(lastSet))
+}
+
+func TestBlockTransferRetriesOnAnotherPeer(t *testing.T) {
+	config := transferTestConfig()
+	config.StateResponseTimeout = 100 * time.Millisecond
+	config.StateMaxParallelPeers = 1
+	s, g, testMetricProvider := newTransferTestState(2, config)
+
+	done := make(chan struct{})
+	go func() {
+		s.requestBlocksInRange(1, 5)
+		close(done)
+	}()
+
+	first := receiveStateRequest(t, g)
+	require.Equal(t, [2]uint64{1, 5}, requestedRange(first))
+
+	// the first peer does not respond in time
+	second := receiveStateRequest(t, g)
+	require.Equal(t, [2]uint64{1, 5}, requestedRange(second))
+	require.NotEqual(t, first.peer.PKIID, second.peer.PKIID)
+	require.Equal(t, 1, testMetricProvider.FakeTransferTimeouts.AddCallCount())
+
+	// a late response to the first request is ignored
+	s.stateResponseCh <- stateResponse(first.msg, 1, 5)
+	s.stateResponseCh <- stateResponse(second.msg, 1, 5)
+	requireTransferDone(t, done)
+	require.Equal(t, 5, s.payloads.Size())
+}
+
+func TestBlockTransferBatchSizeAdapts(t *testing.T) {
+	config := transferTestConfig()
+	config.StateResponseTimeout = 100 * time.Millisecond
+	config.StateMaxParallelPeers = 1
+	s, g, _ := newTransferTestState(1, config)
+
+	done := make(chan struct{})
+	go func() {
+		s.requestBlocksInRange(1, 30)
+		close(done)
+	}()
+
+	// every timeout halves the batch size of the peer
+	require.Equal(t, [2]uint64{1, 11}, requestedRange(receiveStateRequest(t, g)))
+	require.Equal(t, [2]uint64{1, 6}, requestedRange(receiveStateRequest(t, g)))
+	req := receiveStateRequest(t, g)
+	require.Equal(t, [2]uint64{1, 3}, requestedRange(req))
+
+	// and every response grows it back, while the ranges split by the
+	// timeouts are requested first
+	s.stateResponseCh <- stateResponse(req.msg, 1, 3)
+	req = receiveStateRequest(t, g)
+	require.Equal(t, [2]uint64{4, 6}, requestedRange(req))
+	s.stateResponseCh <- stateResponse(req.msg, 4, 6)
+	req = receiveStateRequest(t, g)
+	require.Equal(t, [2]uint64{7, 11}, requestedRange(req))
+
+	// blocks missing from a response are requested again
+	s.stateResponseCh <- stateResponse(req.msg, 7, 9)
+	req = receiveStateRequest(t, g)
+	require.Equal(t, [2]uint64{10, 11}, requestedRange(req))
+	s.stateResponseCh <- stateResponse(req.msg, 10, 11)
+	req = receiveStateRequest(t, g)
+	require.Equal(t, [2]uint64{12, 22}, requestedRange(req))
+	s.stateResponseCh <- stateResponse(req.msg, 12, 22)
+	req = receiveStateRequest(t, g)
+	require.Equal(t, [2]uint64{23, 30}, requestedRange(req))
+	s.stateResponseCh <- stateResponse(req.msg, 23, 30)
+
+	requireTransferDone(t, done)
+	require.Equal(t, 30, s.payloads.Size())
+}
+
+func TestBlockTransferGivesUp(t *testing.T) {
+	config := transferTestConfig()
+	config.StateResponseTimeout = 50 * time.Millisecond
+	config.StateMaxRetries = 1
+	s, g, _ := newTransferTestState(1, config)
+
+	done := make(chan struct{})
+	go func() {
+		s.requestBlocksInRange(1, 5)
+		close(done)
+	}()
+
+	receiveStateRequest(t, g)
+	receiveStateRequest(t, g)
+	requireTransferDone(t, done)
+	require.Empty(t, g.sent)
+}
+
+func TestBlockTransferNoPeers(t *testing.T) {
+	s, g, _ := newTransferTestState(1, transferTestConfig())
+	g.peers[0].Properties.LedgerHeight = 3
+
+	done := make(chan struct{})
+	go func() {
+		s.requestBlocksInRange(5, 10)
+		close(done)
+	}()
+
+	requireTransferDone(t, done)
+	require.Empty(t, g.sent)
+}
+
+func TestBlockTransferWindow(t *testing.T) {
+	config := transferTestConfig()
+	config.StateBlockBufferSize = 2
+	s, g, _ := newTransferTestState(3, config)
+
+	done := make(chan struct{})
+	go func() {
+		s.requestBlocksInRange(1, 20)
+		close(done)
+	}()
+	defer func() {
+		close(s.stopCh)
+		requireTransferDone(t, done)
+	}()
+
+	// only blocks within twice the block buffer size of the next block to
+	// deliver are requested
+	req := receiveStateRequest(t, g)
+	require.Equal(t, [2]uint64{1, 4}, requestedRange(req))
+	s.stateResponseCh <- stateResponse(req.msg, 1, 4)
+
+	time.Sleep(3 * enqueueRetryInterval)
+	require.Empty(t, g.sent)
+
+	// once blocks are delivered, the following blocks are requested
+	for i := 0; i < 4; i++ {
+		require.NotNil(t, s.payloads.Pop())
+	}
+	req = receiveStateRequest(t, g)
+	require.Equal(t, [2]uint64{5, 8}, requestedRange(req))
+}
@@ -25,6 +25,7 @@ const (
 	ServiceLogger     = "gossip.service"
 	StateLogger       = "gossip.state"
 	PrivateDataLogger = "gossip.privdata"
+	SimulationLogger  = "gossip.simulation"
 )
 
 var loggers = make(map[string]Logger)
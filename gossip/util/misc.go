@@ -14,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"github.com/spf13/viper"
 )
 
@@ -185,6 +186,24 @@ func RandomUInt64() uint64 {
 	return rand.Uint64()
 }
 
+// ClockOrDefault returns the given clock, or the wall clock if it is nil
+func ClockOrDefault(clk clock.Clock) clock.Clock {
+	if clk == nil {
+		return clock.NewClock()
+	}
+	return clk
+}
+
+// AfterFunc waits for the duration to elapse on the given clock
+// and then calls f in its own goroutine
+func AfterFunc(clk clock.Clock, d time.Duration, f func()) {
+	timer := clk.NewTimer(d)
+	go func() {
+		<-timer.C()
+		f()
+	}()
+}
+
 func BytesToStrings(bytes [][]byte) []string {
 	strings := make([]string, len(bytes))
 	for i, b := range bytes {
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/clock"
 	"github.com/pkg/errors"
 )
 
@@ -26,6 +27,9 @@ type PubSub struct {
 
 	// a map from topic to Set of subscriptions
 	subscriptions map[string]*Set
+
+	// the clock subscriptions expire by, or nil for the wall clock
+	clock clock.Clock
 }
 
 // Subscription defines a subscription to a topic
@@ -38,9 +42,10 @@ type Subscription interface {
 }
 
 type subscription struct {
-	top string
-	ttl time.Duration
-	c   chan interface{}
+	top   string
+	ttl   time.Duration
+	c     chan interface{}
+	after func(time.Duration) <-chan time.Time
 }
 
 // Listen blocks until a publish was made
@@ -48,7 +53,7 @@ type subscription struct {
 // subscription's TTL passed
 func (s *subscription) Listen() (interface{}, error) {
 	select {
-	case <-time.After(s.ttl):
+	case <-s.after(s.ttl):
 		return nil, errors.New("timed out")
 	case item := <-s.c:
 		return item, nil
@@ -63,6 +68,15 @@ func NewPubSub() *PubSub {
 	}
 }
 
+// NewPubSubWithClock creates a new PubSub with an empty
+// set of subscriptions that expire by the given clock
+func NewPubSubWithClock(clk clock.Clock) *PubSub {
+	return &PubSub{
+		subscriptions: make(map[string]*Set),
+		clock:         clk,
+	}
+}
+
 // Publish publishes an item to all subscribers on the topic
 func (ps *PubSub) Publish(topic string, item interface{}) error {
 	ps.RLock()
@@ -84,9 +98,13 @@ func (ps *PubSub) Publish(topic string, item interface{}) error {
 // Subscribe returns a subscription to a topic that expires when given TTL passes
 func (ps *PubSub) Subscribe(topic string, ttl time.Duration) Subscription {
 	sub := &subscription{
-		top: topic,
-		ttl: ttl,
-		c:   make(chan interface{}, subscriptionBuffSize),
+		top:   topic,
+		ttl:   ttl,
+		c:     make(chan interface{}, subscriptionBuffSize),
+		after: time.After,
+	}
+	if ps.clock != nil {
+		sub.after = ps.clock.After
 	}
 
 	ps.Lock()
@@ -103,9 +121,14 @@ func (ps *PubSub) Subscribe(topic string, ttl time.Duration) Subscription {
 	s.Add(sub)
 
 	// When the timeout expires, remove the subscription
-	time.AfterFunc(ttl, func() {
+	unSubscribe := func() {
 		ps.unSubscribe(sub)
-	})
+	}
+	if ps.clock != nil {
+		AfterFunc(ps.clock, ttl, unSubscribe)
+	} else {
+		time.AfterFunc(ttl, unSubscribe)
+	}
 	return sub
 }
 
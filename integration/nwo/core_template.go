@@ -77,6 +77,7 @@ peer:
        batchSize: 10
        blockBufferSize: 20
        maxRetries: 3
+       maxParallelPeers: 4
   events:
     address: 127.0.0.1:{{ .PeerPort Peer "Events" }}
     buffersize: 100
@@ -67,45 +67,64 @@ type ClientKeepalive struct {
 }
 
 type Gossip struct {
-	Bootstrap                  string          `yaml:"bootstrap,omitempty"`
-	UseLeaderElection          bool            `yaml:"useLeaderElection"`
-	OrgLeader                  bool            `yaml:"orgLeader"`
-	MembershipTrackerInterval  time.Duration   `yaml:"membershipTrackerInterval,omitempty"`
-	Endpoint                   string          `yaml:"endpoint,omitempty"`
-	MaxBlockCountToStore       int             `yaml:"maxBlockCountToStore,omitempty"`
-	MaxPropagationBurstLatency time.Duration   `yaml:"maxPropagationBurstLatency,omitempty"`
-	MaxPropagationBurstSize    int             `yaml:"maxPropagationBurstSize,omitempty"`
-	PropagateIterations        int             `yaml:"propagateIterations,omitempty"`
-	PropagatePeerNum           int             `yaml:"propagatePeerNum,omitempty"`
-	PullInterval               time.Duration   `yaml:"pullInterval,omitempty"`
-	PullPeerNum                int             `yaml:"pullPeerNum,omitempty"`
-	RequestStateInfoInterval   time.Duration   `yaml:"requestStateInfoInterval,omitempty"`
-	PublishStateInfoInterval   time.Duration   `yaml:"publishStateInfoInterval,omitempty"`
-	StateInfoRetentionInterval time.Duration   `yaml:"stateInfoRetentionInterval,omitempty"`
-	PublishCertPeriod          time.Duration   `yaml:"publishCertPeriod,omitempty"`
-	DialTimeout                time.Duration   `yaml:"dialTimeout,omitempty"`
-	ConnTimeout                time.Duration   `yaml:"connTimeout,omitempty"`
-	RecvBuffSize               int             `yaml:"recvBuffSize,omitempty"`
-	SendBuffSize               int             `yaml:"sendBuffSize,omitempty"`
-	DigestWaitTime             time.Duration   `yaml:"digestWaitTime,omitempty"`
-	RequestWaitTime            time.Duration   `yaml:"requestWaitTime,omitempty"`
-	ResponseWaitTime           time.Duration   `yaml:"responseWaitTime,omitempty"`
-	AliveTimeInterval          time.Duration   `yaml:"aliveTimeInterval,omitempty"`
-	AliveExpirationTimeout     time.Duration   `yaml:"aliveExpirationTimeout,omitempty"`
-	ReconnectInterval          time.Duration   `yaml:"reconnectInterval,omitempty"`
-	MsgExpirationFactor        int             `yaml:"msgExpirationFactor,omitempty"`
-	MaxConnectionAttempts      int             `yaml:"maxConnectionAttempts,omitempty"`
-	ExternalEndpoint           string          `yaml:"externalEndpoint,omitempty"`
-	Election                   *GossipElection `yaml:"election,omitempty"`
-	PvtData                    *GossipPvtData  `yaml:"pvtData,omitempty"`
-	State                      *GossipState    `yaml:"state,omitempty"`
+	Bootstrap                  string             `yaml:"bootstrap,omitempty"`
+	UseLeaderElection          bool               `yaml:"useLeaderElection"`
+	OrgLeader                  bool               `yaml:"orgLeader"`
+	MembershipTrackerInterval  time.Duration      `yaml:"membershipTrackerInterval,omitempty"`
+	Endpoint                   string             `yaml:"endpoint,omitempty"`
+	MaxBlockCountToStore       int                `yaml:"maxBlockCountToStore,omitempty"`
+	MaxPropagationBurstLatency time.Duration      `yaml:"maxPropagationBurstLatency,omitempty"`
+	MaxPropagationBurstSize    int                `yaml:"maxPropagationBurstSize,omitempty"`
+	PropagateIterations        int                `yaml:"propagateIterations,omitempty"`
+	PropagatePeerNum           int                `yaml:"propagatePeerNum,omitempty"`
+	PullInterval               time.Duration      `yaml:"pullInterval,omitempty"`
+	PullPeerNum                int                `yaml:"pullPeerNum,omitempty"`
+	RequestStateInfoInterval   time.Duration      `yaml:"requestStateInfoInterval,omitempty"`
+	PublishStateInfoInterval   time.Duration      `yaml:"publishStateInfoInterval,omitempty"`
+	StateInfoRetentionInterval time.Duration      `yaml:"stateInfoRetentionInterval,omitempty"`
+	PublishCertPeriod          time.Duration      `yaml:"publishCertPeriod,omitempty"`
+	DialTimeout                time.Duration      `yaml:"dialTimeout,omitempty"`
+	ConnTimeout                time.Duration      `yaml:"connTimeout,omitempty"`
+	RecvBuffSize               int                `yaml:"recvBuffSize,omitempty"`
+	SendBuffSize               int                `yaml:"sendBuffSize,omitempty"`
+	Compression                *GossipCompression `yaml:"compression,omitempty"`
+	DigestWaitTime             time.Duration      `yaml:"digestWaitTime,omitempty"`
+	RequestWaitTime            time.Duration      `yaml:"requestWaitTime,omitempty"`
+	ResponseWaitTime           time.Duration      `yaml:"responseWaitTime,omitempty"`
+	AliveTimeInterval          time.Duration      `yaml:"aliveTimeInterval,omitempty"`
+	AliveExpirationTimeout     time.Duration      `yaml:"aliveExpirationTimeout,omitempty"`
+	ReconnectInterval          time.Duration      `yaml:"reconnectInterval,omitempty"`
+	MsgExpirationFactor        int                `yaml:"msgExpirationFactor,omitempty"`
+	MaxConnectionAttempts      int                `yaml:"maxConnectionAttempts,omitempty"`
+	ExternalEndpoint           string             `yaml:"externalEndpoint,omitempty"`
+	Labels                     map[string]string  `yaml:"labels,omitempty"`
+	Election                   *GossipElection    `yaml:"election,omitempty"`
+	PvtData                    *GossipPvtData     `yaml:"pvtData,omitempty"`
+	State                      *GossipState       `yaml:"state,omitempty"`
+}
+
+type GossipCompression struct {
+	Algorithm string `yaml:"algorithm,omitempty"`
+	Threshold int    `yaml:"threshold,omitempty"`
 }
 
 type GossipElection struct {
-	StartupGracePeriod       time.Duration `yaml:"startupGracePeriod,omitempty"`
-	MembershipSampleInterval time.Duration `yaml:"membershipSampleInterval,omitempty"`
-	LeaderAliveThreshold     time.Duration `yaml:"leaderAliveThreshold,omitempty"`
-	LeaderElectionDuration   time.Duration `yaml:"leaderElectionDuration,omitempty"`
+	StartupGracePeriod       time.Duration       `yaml:"startupGracePeriod,omitempty"`
+	MembershipSampleInterval time.Duration       `yaml:"membershipSampleInterval,omitempty"`
+	LeaderAliveThreshold     time.Duration       `yaml:"leaderAliveThreshold,omitempty"`
+	LeaderElectionDuration   time.Duration       `yaml:"leaderElectionDuration,omitempty"`
+	Priorities               []*ElectionPriority `yaml:"priorities,omitempty"`
+	PinnedLeaders            []*PinnedLeader     `yaml:"pinnedLeaders,omitempty"`
+}
+
+type ElectionPriority struct {
+	Endpoint string `yaml:"endpoint"`
+	Priority int    `yaml:"priority"`
+}
+
+type PinnedLeader struct {
+	Channel  string `yaml:"channel"`
+	Endpoint string `yaml:"endpoint"`
 }
 
 type GossipPvtData struct {
@@ -127,12 +146,13 @@ type ImplicitCollDisseminationPolicy struct {
 }
 
 type GossipState struct {
-	Enabled         bool          `yaml:"enabled"`
-	CheckInterval   time.Duration `yaml:"checkInterval,omitempty"`
-	ResponseTimeout time.Duration `yaml:"responseTimeout,omitempty"`
-	BatchSize       int           `yaml:"batchSize,omitempty"`
-	BlockBufferSize int           `yaml:"blockBufferSize,omitempty"`
-	MaxRetries      int           `yaml:"maxRetries,omitempty"`
+	Enabled          bool          `yaml:"enabled"`
+	CheckInterval    time.Duration `yaml:"checkInterval,omitempty"`
+	ResponseTimeout  time.Duration `yaml:"responseTimeout,omitempty"`
+	BatchSize        int           `yaml:"batchSize,omitempty"`
+	BlockBufferSize  int           `yaml:"blockBufferSize,omitempty"`
+	MaxRetries       int           `yaml:"maxRetries,omitempty"`
+	MaxParallelPeers int           `yaml:"maxParallelPeers,omitempty"`
 }
 
 type Events struct {
@@ -188,6 +208,7 @@ type PKCS11 struct {
 
 type DeliveryClient struct {
 	ReconnectTotalTimeThreshold time.Duration      `yaml:"reconnectTotalTimeThreshold,omitempty"`
+	LeaderDemotionThreshold     time.Duration      `yaml:"leaderDemotionThreshold,omitempty"`
 	AddressOverrides            []*AddressOverride `yaml:"addressOverrides,omitempty"`
 }
 
@@ -225,7 +246,8 @@ type Discovery struct {
 }
 
 type Limits struct {
-	Concurrency *Concurrency `yaml:"concurrency,omitempty"`
+	Concurrency *Concurrency    `yaml:"concurrency,omitempty"`
+	Endorser    *EndorserLimits `yaml:"endorser,omitempty"`
 }
 
 type Concurrency struct {
@@ -233,9 +255,29 @@ type Concurrency struct {
 	DeliverService  int `yaml:"deliverService,omitempty"`
 }
 
+type EndorserLimits struct {
+	Channel            *EndorserLimit           `yaml:"channel,omitempty"`
+	Chaincode          *EndorserLimit           `yaml:"chaincode,omitempty"`
+	ChaincodeOverrides []ChaincodeEndorserLimit `yaml:"chaincodeOverrides,omitempty"`
+}
+
+type EndorserLimit struct {
+	Concurrency int     `yaml:"concurrency,omitempty"`
+	Rate        float64 `yaml:"rate,omitempty"`
+	Burst       int     `yaml:"burst,omitempty"`
+}
+
+type ChaincodeEndorserLimit struct {
+	Name        string  `yaml:"name,omitempty"`
+	Concurrency int     `yaml:"concurrency,omitempty"`
+	Rate        float64 `yaml:"rate,omitempty"`
+	Burst       int     `yaml:"burst,omitempty"`
+}
+
 type VM struct {
-	Endpoint string  `yaml:"endpoint,omitempty"`
-	Docker   *Docker `yaml:"docker,omitempty"`
+	Endpoint string   `yaml:"endpoint,omitempty"`
+	Docker   *Docker  `yaml:"docker,omitempty"`
+	Process  *Process `yaml:"process,omitempty"`
 }
 
 type Docker struct {
@@ -244,6 +286,18 @@ type Docker struct {
 	HostConfig   *docker.HostConfig `yaml:"hostConfig,omitempty"`
 }
 
+type Process struct {
+	Enabled              bool           `yaml:"enabled"`
+	PropagateEnvironment []string       `yaml:"propagateEnvironment,omitempty"`
+	Cgroup               *ProcessCgroup `yaml:"cgroup,omitempty"`
+}
+
+type ProcessCgroup struct {
+	Path   string  `yaml:"path,omitempty"`
+	CPUs   float64 `yaml:"cpus,omitempty"`
+	Memory int64   `yaml:"memory,omitempty"`
+}
+
 type Chaincode struct {
 	Builder          string            `yaml:"builder,omitempty"`
 	Pull             bool              `yaml:"pull"`
@@ -14,6 +14,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/genesis"
 	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/policies/expression"
 	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
@@ -52,6 +53,9 @@ const (
 
 	// ImplicitMetaPolicyType is the 'Type' string for implicit meta policies
 	ImplicitMetaPolicyType = "ImplicitMeta"
+
+	// ExpressionPolicyType is the 'Type' string for expression policies
+	ExpressionPolicyType = "Expression"
 )
 
 func addValue(cg *cb.ConfigGroup, value channelconfig.ConfigValue, modPolicy string) {
@@ -117,6 +121,15 @@ func AddPolicies(cg *cb.ConfigGroup, policyMap map[string]*genesisconfig.Policy,
 					Value: protoutil.MarshalOrPanic(sp),
 				},
 			}
+		case ExpressionPolicyType:
+			ep, err := expression.NewConfigPolicy(policy.Rule)
+			if err != nil {
+				return errors.Wrapf(err, "invalid expression policy rule '%s'", policy.Rule)
+			}
+			cg.Policies[policyName] = &cb.ConfigPolicy{
+				ModPolicy: modPolicy,
+				Policy:    ep,
+			}
 		default:
 			return errors.Errorf("unknown policy type: %s", policy.Type)
 		}
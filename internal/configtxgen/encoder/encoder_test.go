@@ -16,6 +16,7 @@ import (
 	cb "github.com/hyperledger/fabric-protos-go/common"
 	ab "github.com/hyperledger/fabric-protos-go/orderer"
 	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric/common/policies/expression"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
 	"github.com/hyperledger/fabric/internal/configtxgen/encoder/fakes"
@@ -203,6 +204,36 @@ var _ = Describe("Encoder", func() {
 			})
 		})
 
+		Context("when the policy is an expression policy", func() {
+			BeforeEach(func() {
+				policies["Readers"].Type = "Expression"
+				policies["Readers"].Rule = "OutOf(2, Weight(2, 'Org1MSP.admin'), 'Org2MSP.member', 'Org3MSP.member')"
+			})
+
+			It("adds the expression policy to the group", func() {
+				err := encoder.AddPolicies(cg, policies, "Readers")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cg.Policies["Readers"].Policy).To(Equal(&cb.Policy{
+					Type: expression.PolicyType,
+					Value: protoutil.MarshalOrPanic(&expression.ExpressionPolicy{
+						Expression: "OutOf(2, Weight(2, 'Org1MSP.admin'), 'Org2MSP.member', 'Org3MSP.member')",
+					}),
+				}))
+			})
+		})
+
+		Context("when the expression policy definition is bad", func() {
+			BeforeEach(func() {
+				policies["Readers"].Type = "Expression"
+				policies["Readers"].Rule = "garbage"
+			})
+
+			It("wraps and returns the error", func() {
+				err := encoder.AddPolicies(cg, policies, "Readers")
+				Expect(err).To(MatchError("invalid expression policy rule 'garbage': expected '(' at offset 7, found end of expression"))
+			})
+		})
+
 		Context("when the implicit policy definition is bad", func() {
 			BeforeEach(func() {
 				policies["Readers"].Type = "ImplicitMeta"
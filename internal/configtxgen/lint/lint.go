@@ -0,0 +1,574 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lint
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/policies/expression"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+)
+
+// Severity tells whether a finding makes the config unusable.
+type Severity string
+
+const (
+	// Error findings make the config rejected by orderers or peers, or
+	// make parts of it unusable.
+	Error Severity = "error"
+	// Warning findings are likely mistakes that do not make the config
+	// rejected.
+	Warning Severity = "warning"
+)
+
+// Finding is a problem found in a profile.
+type Finding struct {
+	Severity Severity `json:"severity"`
+	// Path is the path of the offending element in the channel config,
+	// or the profile section for problems found before encoding.
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Path, f.Message)
+}
+
+// HasErrors returns whether any of the findings is an error.
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+type linter struct {
+	findings []Finding
+}
+
+func (l *linter) errorf(path, format string, args ...interface{}) {
+	l.findings = append(l.findings, Finding{Severity: Error, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (l *linter) warnf(path, format string, args ...interface{}) {
+	l.findings = append(l.findings, Finding{Severity: Warning, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// Lint checks the profile for mistakes that would otherwise only show up
+// when an orderer or a peer rejects the config generated from it. The
+// profile is encoded into a channel config, from which a channel config
+// bundle is built, and the bundle goes through the checks orderers and peers
+// run on channel configs. Every policy reference of the config is resolved
+// and the policies are checked against the organizations of the channel.
+func Lint(profile *genesisconfig.Profile, channelID string, cryptoProvider bccsp.BCCSP) []Finding {
+	l := &linter{}
+	l.lintProfile(profile)
+
+	group, err := encoder.NewChannelGroup(profile)
+	if err != nil {
+		l.errorf("Profile", "could not encode channel config: %s", err)
+		return l.findings
+	}
+	config := &cb.Config{ChannelGroup: group}
+
+	bundle, err := channelconfig.NewBundle(channelID, config, cryptoProvider)
+	if err != nil {
+		l.errorf("/"+channelconfig.ChannelGroupKey, "could not build channel config: %s", err)
+		return l.findings
+	}
+
+	l.lintCapabilities(bundle)
+	l.lintStandardPolicies(bundle)
+	l.lintConfigGroup(bundle, []string{channelconfig.ChannelGroupKey}, group)
+	l.lintACLs(bundle, group)
+	l.lintOrderer(bundle)
+	l.lintApplication(bundle)
+
+	return l.findings
+}
+
+// lintProfile checks the parts of the profile that are dropped or changed
+// when encoding it.
+func (l *linter) lintProfile(profile *genesisconfig.Profile) {
+	if profile.Orderer != nil && profile.Application != nil {
+		for _, org := range profile.Orderer.Organizations {
+			if len(org.AnchorPeers) == 0 || isApplicationOrg(profile, org.Name) {
+				continue
+			}
+			l.warnf("Orderer.Organizations."+org.Name, "anchor peers are ignored, the organization is not an application organization of the profile")
+		}
+	}
+
+	if profile.Application != nil {
+		for _, org := range profile.Application.Organizations {
+			for _, ap := range org.AnchorPeers {
+				if ap == nil || ap.Host == "" || ap.Port <= 0 || ap.Port > 65535 {
+					l.errorf("Application.Organizations."+org.Name, "invalid anchor peer %v, host and port are required", ap)
+				}
+			}
+		}
+	}
+
+	channelLevel := maxCapability(profile.Capabilities)
+	check := func(section string, capabilities map[string]bool) {
+		if level := maxCapability(capabilities); channelLevel != "" && level != "" && compareCapabilities(level, channelLevel) > 0 {
+			l.warnf(section+".Capabilities", "capability %s is newer than channel capability %s, raise the channel capability so that nodes not supporting it are stopped from processing the channel", level, channelLevel)
+		}
+	}
+	if profile.Orderer != nil {
+		check("Orderer", profile.Orderer.Capabilities)
+	}
+	if profile.Application != nil {
+		check("Application", profile.Application.Capabilities)
+	}
+}
+
+// lintCapabilities runs the capability checks orderers and peers run when
+// joining a channel.
+func (l *linter) lintCapabilities(bundle *channelconfig.Bundle) {
+	if err := bundle.ChannelConfig().Capabilities().Supported(); err != nil {
+		l.errorf(path(channelconfig.ChannelGroupKey, channelconfig.CapabilitiesKey), "%s", err)
+	}
+	if oc, ok := bundle.OrdererConfig(); ok {
+		if err := oc.Capabilities().Supported(); err != nil {
+			l.errorf(path(channelconfig.ChannelGroupKey, channelconfig.OrdererGroupKey, channelconfig.CapabilitiesKey), "%s", err)
+		}
+	}
+	if ac, ok := bundle.ApplicationConfig(); ok {
+		if err := ac.Capabilities().Supported(); err != nil {
+			l.errorf(path(channelconfig.ChannelGroupKey, channelconfig.ApplicationGroupKey, channelconfig.CapabilitiesKey), "%s", err)
+		}
+	}
+}
+
+// lintStandardPolicies checks for the policies channelconfig.LogSanityChecks
+// expects, and for the endorsement policies of the new chaincode lifecycle.
+func (l *linter) lintStandardPolicies(bundle *channelconfig.Bundle) {
+	pm := bundle.PolicyManager()
+	expected := []string{policies.ChannelReaders, policies.ChannelWriters}
+	if _, ok := pm.Manager([]string{policies.ApplicationPrefix}); ok {
+		expected = append(expected, policies.ChannelApplicationReaders, policies.ChannelApplicationWriters, policies.ChannelApplicationAdmins)
+	}
+	if _, ok := pm.Manager([]string{policies.OrdererPrefix}); ok {
+		expected = append(expected, policies.BlockValidation, policies.ChannelOrdererAdmins, policies.ChannelOrdererWriters, policies.ChannelOrdererReaders)
+	}
+	if ac, ok := bundle.ApplicationConfig(); ok && ac.Capabilities().LifecycleV20() {
+		expected = append(expected,
+			path(channelconfig.ChannelGroupKey, channelconfig.ApplicationGroupKey, "LifecycleEndorsement"),
+			path(channelconfig.ChannelGroupKey, channelconfig.ApplicationGroupKey, "Endorsement"),
+		)
+	}
+	for _, policyName := range expected {
+		if _, ok := pm.GetPolicy(policyName); !ok {
+			l.warnf(policyName, "policy is missing, this will likely cause problems in production systems")
+		}
+	}
+}
+
+// lintConfigGroup resolves the mod policies of the group and of its values,
+// policies and sub-groups, and checks its policies.
+func (l *linter) lintConfigGroup(bundle *channelconfig.Bundle, groupPath []string, group *cb.ConfigGroup) {
+	groupName := path(groupPath...)
+	l.lintModPolicy(bundle, groupPath, groupName, group.ModPolicy)
+	for _, key := range sortedKeys(group.Values) {
+		l.lintModPolicy(bundle, groupPath, groupName+"/Values/"+key, group.Values[key].ModPolicy)
+	}
+	for _, key := range sortedKeys(group.Policies) {
+		policyName := groupName + "/Policies/" + key
+		configPolicy := group.Policies[key]
+		l.lintModPolicy(bundle, groupPath, policyName, configPolicy.ModPolicy)
+		if configPolicy.Policy != nil {
+			l.lintPolicy(bundle, policyName, group, configPolicy.Policy)
+		}
+	}
+	for _, key := range sortedKeys(group.Groups) {
+		l.lintConfigGroup(bundle, append(append([]string{}, groupPath...), key), group.Groups[key])
+	}
+}
+
+// lintModPolicy resolves the mod policy the way the config update validator
+// does: absolute references from the root, and relative references from the
+// group itself for groups, or from the group holding the element otherwise.
+func (l *linter) lintModPolicy(bundle *channelconfig.Bundle, groupPath []string, elementPath, modPolicy string) {
+	if modPolicy == "" {
+		l.warnf(elementPath, "no mod policy, the element can never be modified")
+		return
+	}
+	manager := bundle.PolicyManager()
+	if !strings.HasPrefix(modPolicy, policies.PathSeparator) {
+		var ok bool
+		if manager, ok = manager.Manager(groupPath[1:]); !ok {
+			l.errorf(elementPath, "mod policy %s cannot be resolved", modPolicy)
+			return
+		}
+	}
+	if _, ok := manager.GetPolicy(modPolicy); !ok {
+		l.errorf(elementPath, "mod policy %s does not exist", modPolicy)
+	}
+}
+
+// lintPolicy checks that implicit meta policies can be satisfied by the
+// sub-groups of their group, and that signature and expression policies only
+// reference organizations of the channel.
+func (l *linter) lintPolicy(bundle *channelconfig.Bundle, policyName string, group *cb.ConfigGroup, policy *cb.Policy) {
+	switch policy.Type {
+	case int32(cb.Policy_IMPLICIT_META):
+		imp := &cb.ImplicitMetaPolicy{}
+		if err := proto.Unmarshal(policy.Value, imp); err != nil {
+			l.errorf(policyName, "could not unmarshal implicit meta policy: %s", err)
+			return
+		}
+		var missing []string
+		for _, key := range sortedKeys(group.Groups) {
+			if _, ok := group.Groups[key].Policies[imp.SubPolicy]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		present := len(group.Groups) - len(missing)
+		switch {
+		case len(group.Groups) == 0:
+			l.warnf(policyName, "%s %s policy has no sub-groups to evaluate and can never be satisfied", imp.Rule, imp.SubPolicy)
+		case imp.Rule == cb.ImplicitMetaPolicy_ANY && present == 0,
+			imp.Rule == cb.ImplicitMetaPolicy_ALL && len(missing) != 0,
+			imp.Rule == cb.ImplicitMetaPolicy_MAJORITY && present <= len(group.Groups)/2:
+			l.errorf(policyName, "%s %s policy can never be satisfied, sub-groups %s have no %s policy", imp.Rule, imp.SubPolicy, strings.Join(missing, ", "), imp.SubPolicy)
+		case len(missing) != 0 && imp.Rule != cb.ImplicitMetaPolicy_ANY:
+			l.warnf(policyName, "sub-groups %s have no %s policy", strings.Join(missing, ", "), imp.SubPolicy)
+		}
+
+	case int32(cb.Policy_SIGNATURE):
+		env := &cb.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(policy.Value, env); err != nil {
+			l.errorf(policyName, "could not unmarshal signature policy: %s", err)
+			return
+		}
+		var mspIDs []string
+		for _, principal := range env.Identities {
+			mspID, err := principalMSPID(principal)
+			if err != nil {
+				l.errorf(policyName, "%s", err)
+				continue
+			}
+			mspIDs = append(mspIDs, mspID)
+		}
+		l.lintPolicyMSPs(bundle, policyName, mspIDs)
+
+	case expression.PolicyType:
+		ep := &expression.ExpressionPolicy{}
+		if err := proto.Unmarshal(policy.Value, ep); err != nil {
+			l.errorf(policyName, "could not unmarshal expression policy: %s", err)
+			return
+		}
+		e, err := expression.Parse(ep.Expression)
+		if err != nil {
+			l.errorf(policyName, "invalid expression: %s", err)
+			return
+		}
+		l.lintPolicyMSPs(bundle, policyName, e.MSPIDs())
+	}
+}
+
+// lintPolicyMSPs checks that the MSPs referenced by a policy are organizations of the channel.
+func (l *linter) lintPolicyMSPs(bundle *channelconfig.Bundle, policyName string, mspIDs []string) {
+	msps, err := bundle.MSPManager().GetMSPs()
+	if err != nil {
+		l.errorf(policyName, "could not get MSPs of the channel: %s", err)
+		return
+	}
+	for _, mspID := range mspIDs {
+		if _, ok := msps[mspID]; mspID != "" && !ok {
+			l.errorf(policyName, "policy references MSP %s, which is not an organization of the channel", mspID)
+		}
+	}
+}
+
+// lintACLs resolves the policy references of the ACLs the way peers do,
+// from the root of the channel config.
+func (l *linter) lintACLs(bundle *channelconfig.Bundle, group *cb.ConfigGroup) {
+	application, ok := group.Groups[channelconfig.ApplicationGroupKey]
+	if !ok {
+		return
+	}
+	value, ok := application.Values[channelconfig.ACLsKey]
+	if !ok {
+		return
+	}
+	acls := &pb.ACLs{}
+	if err := proto.Unmarshal(value.Value, acls); err != nil {
+		return
+	}
+	aclsPath := path(channelconfig.ChannelGroupKey, channelconfig.ApplicationGroupKey, "Values", channelconfig.ACLsKey)
+	for _, resource := range sortedKeys(acls.Acls) {
+		policyRef := acls.Acls[resource].PolicyRef
+		if _, ok := bundle.PolicyManager().GetPolicy(policyRef); !ok {
+			l.errorf(aclsPath, "policy %s of resource %s does not exist", policyRef, resource)
+		}
+	}
+}
+
+// lintOrderer checks the orderer endpoints and the etcdraft consenters.
+func (l *linter) lintOrderer(bundle *channelconfig.Bundle) {
+	oc, ok := bundle.OrdererConfig()
+	if !ok {
+		return
+	}
+	ordererPath := path(channelconfig.ChannelGroupKey, channelconfig.OrdererGroupKey)
+
+	endpoints := len(bundle.ChannelConfig().OrdererAddresses())
+	for _, name := range sortedKeys(oc.Organizations()) {
+		org := oc.Organizations()[name]
+		endpoints += len(org.Endpoints())
+		if len(org.Endpoints()) == 0 && bundle.ChannelConfig().Capabilities().OrgSpecificOrdererEndpoints() {
+			l.warnf(path(channelconfig.ChannelGroupKey, channelconfig.OrdererGroupKey, name), "organization has no orderer endpoints")
+		}
+	}
+	if endpoints == 0 {
+		l.warnf(ordererPath, "no orderer endpoints are defined, clients and peers cannot reach the ordering service")
+	}
+
+	if oc.ConsensusType() != "etcdraft" {
+		return
+	}
+	metadata := &etcdraft.ConfigMetadata{}
+	if err := proto.Unmarshal(oc.ConsensusMetadata(), metadata); err != nil {
+		l.errorf(ordererPath, "could not unmarshal etcdraft metadata: %s", err)
+		return
+	}
+
+	if o := metadata.Options; o != nil {
+		if o.HeartbeatTick == 0 || o.ElectionTick == 0 || o.MaxInflightBlocks == 0 {
+			l.errorf(ordererPath, "none of HeartbeatTick (%d), ElectionTick (%d) and MaxInflightBlocks (%d) can be zero", o.HeartbeatTick, o.ElectionTick, o.MaxInflightBlocks)
+		} else if o.ElectionTick <= o.HeartbeatTick {
+			l.errorf(ordererPath, "ElectionTick (%d) must be greater than HeartbeatTick (%d)", o.ElectionTick, o.HeartbeatTick)
+		}
+		if d, err := time.ParseDuration(o.TickInterval); err != nil || d == 0 {
+			l.errorf(ordererPath, "invalid TickInterval '%s'", o.TickInterval)
+		}
+	}
+
+	if len(metadata.Consenters) == 0 {
+		l.errorf(ordererPath, "etcdraft consenter set is empty")
+		return
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+	for _, org := range oc.Organizations() {
+		for _, cert := range parseCertificates(org.MSP().GetTLSRootCerts()) {
+			opts.Roots.AddCert(cert)
+		}
+		for _, cert := range parseCertificates(org.MSP().GetTLSIntermediateCerts()) {
+			opts.Intermediates.AddCert(cert)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, c := range metadata.Consenters {
+		endpoint := c.Host + ":" + strconv.Itoa(int(c.Port))
+		if seen[endpoint] {
+			l.errorf(ordererPath, "consenter %s is defined more than once", endpoint)
+		}
+		seen[endpoint] = true
+		for _, tlsCert := range []struct {
+			certType string
+			raw      []byte
+		}{{"client", c.ClientTlsCert}, {"server", c.ServerTlsCert}} {
+			l.lintConsenterCert(ordererPath, endpoint, tlsCert.certType, tlsCert.raw, opts)
+		}
+	}
+}
+
+func (l *linter) lintConsenterCert(ordererPath, endpoint, certType string, raw []byte, opts x509.VerifyOptions) {
+	certs := parseCertificates([][]byte{raw})
+	if len(certs) == 0 {
+		l.errorf(ordererPath, "TLS %s certificate of consenter %s is not a PEM encoded certificate", certType, endpoint)
+		return
+	}
+	cert := certs[0]
+	opts.CurrentTime = cert.NotBefore.Add(time.Second)
+	if _, err := cert.Verify(opts); err != nil {
+		l.errorf(ordererPath, "TLS %s certificate of consenter %s is not issued by the TLS root certificates of the orderer organizations: %s", certType, endpoint, err)
+		return
+	}
+	if time.Now().After(cert.NotAfter) {
+		l.warnf(ordererPath, "TLS %s certificate of consenter %s expired on %s", certType, endpoint, cert.NotAfter.Format(time.RFC3339))
+	}
+}
+
+// lintApplication checks the anchor peers of the application organizations.
+func (l *linter) lintApplication(bundle *channelconfig.Bundle) {
+	ac, ok := bundle.ApplicationConfig()
+	if !ok {
+		return
+	}
+	owners := map[string]string{}
+	for _, name := range sortedKeys(ac.Organizations()) {
+		for _, ap := range ac.Organizations()[name].AnchorPeers() {
+			endpoint := ap.Host + ":" + strconv.Itoa(int(ap.Port))
+			if owner, ok := owners[endpoint]; ok {
+				l.warnf(path(channelconfig.ChannelGroupKey, channelconfig.ApplicationGroupKey, name, "Values", channelconfig.AnchorPeersKey),
+					"anchor peer %s is also an anchor peer of %s", endpoint, owner)
+				continue
+			}
+			owners[endpoint] = name
+		}
+	}
+}
+
+func isApplicationOrg(profile *genesisconfig.Profile, name string) bool {
+	if profile.Application == nil {
+		return false
+	}
+	for _, org := range profile.Application.Organizations {
+		if org.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func principalMSPID(principal *mb.MSPPrincipal) (string, error) {
+	switch principal.PrincipalClassification {
+	case mb.MSPPrincipal_ROLE:
+		role := &mb.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return "", fmt.Errorf("could not unmarshal role principal: %s", err)
+		}
+		return role.MspIdentifier, nil
+	case mb.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &mb.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			return "", fmt.Errorf("could not unmarshal organization unit principal: %s", err)
+		}
+		return ou.MspIdentifier, nil
+	case mb.MSPPrincipal_IDENTITY:
+		id := &mb.SerializedIdentity{}
+		if err := proto.Unmarshal(principal.Principal, id); err != nil {
+			return "", fmt.Errorf("could not unmarshal identity principal: %s", err)
+		}
+		return id.Mspid, nil
+	default:
+		return "", nil
+	}
+}
+
+// maxCapability returns the highest enabled capability, if any.
+func maxCapability(capabilities map[string]bool) string {
+	max := ""
+	for c, enabled := range capabilities {
+		if enabled && (max == "" || compareCapabilities(c, max) > 0) {
+			max = c
+		}
+	}
+	return max
+}
+
+// compareCapabilities compares capabilities named after the release that
+// introduced them, such as V1_4_2 or V2_0.
+func compareCapabilities(a, b string) int {
+	va, vb := capabilityVersion(a), capabilityVersion(b)
+	for i := 0; i < len(va) || i < len(vb); i++ {
+		var x, y int
+		if i < len(va) {
+			x = va[i]
+		}
+		if i < len(vb) {
+			y = vb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func capabilityVersion(capability string) []int {
+	var version []int
+	for _, part := range strings.Split(strings.TrimPrefix(capability, "V"), "_") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		version = append(version, n)
+	}
+	return version
+}
+
+func parseCertificates(raw [][]byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for _, r := range raw {
+		block, _ := pem.Decode(r)
+		if block == nil {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		certs = append(certs, cert)
+	}
+	return certs
+}
+
+func path(elements ...string) string {
+	return policies.PathSeparator + strings.Join(elements, policies.PathSeparator)
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]*cb.ConfigValue:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*cb.ConfigPolicy:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*cb.ConfigGroup:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*pb.APIResource:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]channelconfig.OrdererOrg:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]channelconfig.ApplicationOrg:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,190 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lint
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/stretchr/testify/require"
+)
+
+// loadProfile loads the sample etcdraft application channel profile with
+// consenters using a certificate issued by the sample TLS root certificate.
+func loadProfile(t *testing.T) *genesisconfig.Profile {
+	devConfigDir := configtest.GetDevConfigDir()
+	profile := genesisconfig.Load(genesisconfig.SampleAppChannelEtcdRaftProfile, devConfigDir)
+	certPath := filepath.Join(devConfigDir, "msp", "tlsintermediatecerts", "tlsintermediate.pem")
+	for _, c := range profile.Orderer.EtcdRaft.Consenters {
+		c.ClientTlsCert = []byte(certPath)
+		c.ServerTlsCert = []byte(certPath)
+	}
+	return profile
+}
+
+func lint(t *testing.T, profile *genesisconfig.Profile) []Finding {
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	return Lint(profile, "testchannel", cryptoProvider)
+}
+
+func TestLintValidProfile(t *testing.T) {
+	findings := lint(t, loadProfile(t))
+	require.Empty(t, findings)
+	require.False(t, HasErrors(findings))
+}
+
+func TestLintEncodingFailure(t *testing.T) {
+	profile := loadProfile(t)
+	profile.Application.Organizations[0].MSPDir = "/nonexistent"
+
+	findings := lint(t, profile)
+	require.Len(t, findings, 1)
+	require.Equal(t, Error, findings[0].Severity)
+	require.Equal(t, "Profile", findings[0].Path)
+	require.Contains(t, findings[0].Message, "could not encode channel config")
+	require.True(t, HasErrors(findings))
+}
+
+func TestLintConsenters(t *testing.T) {
+	profile := loadProfile(t)
+	consenters := profile.Orderer.EtcdRaft.Consenters
+	consenters[1].ServerTlsCert = []byte(filepath.Join(configtest.GetDevConfigDir(), "msp", "signcerts", "peer.pem"))
+	consenters[2].Host = consenters[0].Host
+	consenters[2].Port = consenters[0].Port
+
+	findings := lint(t, profile)
+	require.Len(t, findings, 2)
+	require.Equal(t, Finding{
+		Severity: Error,
+		Path:     "/Channel/Orderer",
+		Message:  "TLS server certificate of consenter raft1.example.com:7050 is not issued by the TLS root certificates of the orderer organizations: x509: certificate signed by unknown authority",
+	}, findings[0])
+	require.Equal(t, Finding{
+		Severity: Error,
+		Path:     "/Channel/Orderer",
+		Message:  "consenter raft0.example.com:7050 is defined more than once",
+	}, findings[1])
+
+	profile = loadProfile(t)
+	profile.Orderer.EtcdRaft.Options.ElectionTick = profile.Orderer.EtcdRaft.Options.HeartbeatTick
+	findings = lint(t, profile)
+	require.Len(t, findings, 1)
+	require.Contains(t, findings[0].Message, "ElectionTick (1) must be greater than HeartbeatTick (1)")
+}
+
+func TestLintPolicies(t *testing.T) {
+	profile := loadProfile(t)
+	profile.Application.Organizations[0].Policies["Readers"] = &genesisconfig.Policy{Type: "Signature", Rule: "OR('SampleOrg.member', 'OtherOrg.member')"}
+	profile.Application.Policies["Admins"] = &genesisconfig.Policy{Type: "ImplicitMeta", Rule: "ALL Operators"}
+	profile.Application.ACLs["custom/Resource"] = "/Channel/Application/Nonexistent"
+
+	findings := lint(t, profile)
+	require.Equal(t, []Finding{
+		{
+			Severity: Error,
+			Path:     "/Channel/Application/Policies/Admins",
+			Message:  "ALL Operators policy can never be satisfied, sub-groups SampleOrg have no Operators policy",
+		},
+		{
+			Severity: Error,
+			Path:     "/Channel/Application/SampleOrg/Policies/Readers",
+			Message:  "policy references MSP OtherOrg, which is not an organization of the channel",
+		},
+		{
+			Severity: Error,
+			Path:     "/Channel/Application/Values/ACLs",
+			Message:  "policy /Channel/Application/Nonexistent of resource custom/Resource does not exist",
+		},
+	}, findings)
+
+	profile = loadProfile(t)
+	delete(profile.Application.Policies, "Endorsement")
+	findings = lint(t, profile)
+	require.Len(t, findings, 1)
+	require.Equal(t, Finding{
+		Severity: Warning,
+		Path:     "/Channel/Application/Endorsement",
+		Message:  "policy is missing, this will likely cause problems in production systems",
+	}, findings[0])
+}
+
+func TestLintExpressionPolicies(t *testing.T) {
+	profile := loadProfile(t)
+	profile.Capabilities = map[string]bool{"V3_1": true}
+	profile.Application.Policies["Auditors"] = &genesisconfig.Policy{Type: "Expression", Rule: "AND('SampleOrg.admin', Identity('OtherOrg.member', Attr('dept', 'audit')))"}
+
+	findings := lint(t, profile)
+	require.Equal(t, []Finding{
+		{
+			Severity: Error,
+			Path:     "/Channel/Application/Policies/Auditors",
+			Message:  "policy references MSP OtherOrg, which is not an organization of the channel",
+		},
+	}, findings)
+
+	profile.Capabilities = map[string]bool{"V2_0": true}
+	findings = lint(t, profile)
+	require.Len(t, findings, 1)
+	require.Equal(t, Error, findings[0].Severity)
+	require.Contains(t, findings[0].Message, "policy Auditors at path Channel/Application has unknown policy type: 10000")
+}
+
+func TestLintCapabilities(t *testing.T) {
+	profile := loadProfile(t)
+	profile.Application.Capabilities = map[string]bool{"V2_0": true, "V9_9": true}
+
+	findings := lint(t, profile)
+	require.Len(t, findings, 2)
+	require.Equal(t, Finding{
+		Severity: Warning,
+		Path:     "Application.Capabilities",
+		Message:  "capability V9_9 is newer than channel capability V2_0, raise the channel capability so that nodes not supporting it are stopped from processing the channel",
+	}, findings[0])
+	require.Equal(t, Error, findings[1].Severity)
+	require.Equal(t, "/Channel/Application/Capabilities", findings[1].Path)
+	require.Contains(t, findings[1].Message, "V9_9")
+}
+
+func TestLintAnchorPeers(t *testing.T) {
+	profile := loadProfile(t)
+	profile.Orderer.Organizations[0].Name = "OrdererOrg"
+	profile.Application.Organizations[0].AnchorPeers = append(profile.Application.Organizations[0].AnchorPeers, &genesisconfig.AnchorPeer{Host: "peer0.example.com"})
+	org2 := *profile.Application.Organizations[0]
+	org2.Name = "Org2"
+	org2.AnchorPeers = org2.AnchorPeers[:1]
+	profile.Application.Organizations = append(profile.Application.Organizations, &org2)
+
+	findings := lint(t, profile)
+	require.Equal(t, []Finding{
+		{
+			Severity: Warning,
+			Path:     "Orderer.Organizations.OrdererOrg",
+			Message:  "anchor peers are ignored, the organization is not an application organization of the profile",
+		},
+		{
+			Severity: Error,
+			Path:     "Application.Organizations.SampleOrg",
+			Message:  "invalid anchor peer &{peer0.example.com 0}, host and port are required",
+		},
+		{
+			Severity: Warning,
+			Path:     "/Channel/Application/SampleOrg/Values/AnchorPeers",
+			Message:  "anchor peer 127.0.0.1:7051 is also an anchor peer of Org2",
+		},
+	}, findings)
+}
+
+func TestCompareCapabilities(t *testing.T) {
+	require.Equal(t, 0, compareCapabilities("V2_0", "V2_0"))
+	require.Equal(t, 1, compareCapabilities("V2_0", "V1_4_3"))
+	require.Equal(t, -1, compareCapabilities("V1_4", "V1_4_2"))
+	require.Equal(t, "V1_4_3", maxCapability(map[string]bool{"V1_4_3": true, "V1_1": true, "V2_0": false}))
+}
@@ -0,0 +1,641 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-config/protolator"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/policies/expression"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Formats accepted by Marshal.
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+// ChangeType classifies a change between two configs.
+type ChangeType string
+
+const (
+	OrgAdded           ChangeType = "org_added"
+	OrgRemoved         ChangeType = "org_removed"
+	CertificateAdded   ChangeType = "certificate_added"
+	CertificateRemoved ChangeType = "certificate_removed"
+	MSPModified        ChangeType = "msp_modified"
+	PolicyAdded        ChangeType = "policy_added"
+	PolicyRemoved      ChangeType = "policy_removed"
+	PolicyModified     ChangeType = "policy_modified"
+	ConsenterAdded     ChangeType = "consenter_added"
+	ConsenterRemoved   ChangeType = "consenter_removed"
+	ConsenterModified  ChangeType = "consenter_modified"
+	CapabilityAdded    ChangeType = "capability_added"
+	CapabilityRemoved  ChangeType = "capability_removed"
+	ParameterModified  ChangeType = "parameter_modified"
+	GroupAdded         ChangeType = "group_added"
+	GroupRemoved       ChangeType = "group_removed"
+	ValueAdded         ChangeType = "value_added"
+	ValueRemoved       ChangeType = "value_removed"
+	ValueModified      ChangeType = "value_modified"
+	ModPolicyModified  ChangeType = "mod_policy_modified"
+)
+
+// Report lists the changes between two configs, in the order of a depth
+// first walk of the config tree.
+type Report struct {
+	Changes []*Change `json:"changes"`
+}
+
+// Change describes a single semantic change to the config element at Path.
+type Change struct {
+	Type ChangeType `json:"type"`
+	// Path is the path of the config element, e.g.
+	// "/Channel/Application/Org1/Values/MSP".
+	Path string `json:"path"`
+	// Subject identifies what changed within the element, e.g. the
+	// certificate field of an MSP, a consenter, a capability or a parameter.
+	Subject  string `json:"subject,omitempty"`
+	Original string `json:"original,omitempty"`
+	Updated  string `json:"updated,omitempty"`
+}
+
+func (c *Change) String() string {
+	switch c.Type {
+	case OrgAdded:
+		return fmt.Sprintf("%s: organization %s added", c.Path, c.Updated)
+	case OrgRemoved:
+		return fmt.Sprintf("%s: organization %s removed", c.Path, c.Original)
+	case CertificateAdded:
+		return fmt.Sprintf("%s: certificate added to %s: %s", c.Path, c.Subject, c.Updated)
+	case CertificateRemoved:
+		return fmt.Sprintf("%s: certificate removed from %s: %s", c.Path, c.Subject, c.Original)
+	case PolicyAdded:
+		return fmt.Sprintf("%s: policy added: %s", c.Path, c.Updated)
+	case PolicyRemoved:
+		return fmt.Sprintf("%s: policy removed: %s", c.Path, c.Original)
+	case ConsenterAdded:
+		return fmt.Sprintf("%s: consenter %s added with %s", c.Path, c.Subject, c.Updated)
+	case ConsenterRemoved:
+		return fmt.Sprintf("%s: consenter %s removed", c.Path, c.Subject)
+	case CapabilityAdded:
+		return fmt.Sprintf("%s: capability %s added", c.Path, c.Subject)
+	case CapabilityRemoved:
+		return fmt.Sprintf("%s: capability %s removed", c.Path, c.Subject)
+	case GroupAdded:
+		return fmt.Sprintf("%s: group added", c.Path)
+	case GroupRemoved:
+		return fmt.Sprintf("%s: group removed", c.Path)
+	case ValueAdded:
+		return fmt.Sprintf("%s: value added with %s", c.Path, orUnknown(c.Updated))
+	case ValueRemoved:
+		return fmt.Sprintf("%s: value removed", c.Path)
+	}
+
+	s := fmt.Sprintf("%s: ", c.Path)
+	switch c.Type {
+	case ConsenterModified:
+		s += fmt.Sprintf("consenter %s modified", c.Subject)
+	case MSPModified:
+		s += fmt.Sprintf("MSP %s modified", c.Subject)
+	case PolicyModified:
+		s += "policy modified"
+	case ParameterModified:
+		s += fmt.Sprintf("%s modified", c.Subject)
+	case ModPolicyModified:
+		s += "mod_policy modified"
+	default:
+		s += "value modified"
+	}
+	if c.Original != "" || c.Updated != "" {
+		s += fmt.Sprintf(" from %s to %s", orNone(c.Original), orNone(c.Updated))
+	}
+	return s
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown content"
+	}
+	return s
+}
+
+func (r *Report) String() string {
+	if len(r.Changes) == 0 {
+		return "no changes\n"
+	}
+	var b strings.Builder
+	for _, c := range r.Changes {
+		b.WriteString(c.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Marshal renders the report in the given format.
+func Marshal(report *Report, format string) ([]byte, error) {
+	switch format {
+	case TextFormat:
+		return []byte(report.String()), nil
+	case JSONFormat:
+		out, err := json.MarshalIndent(report, "", "\t")
+		if err != nil {
+			return nil, errors.Wrap(err, "error marshaling report")
+		}
+		return append(out, '\n'), nil
+	default:
+		return nil, errors.Errorf("unknown format '%s'", format)
+	}
+}
+
+// Apply returns the config resulting from applying the config update in the
+// envelope to the config. The signatures of the config update are not
+// checked.
+func Apply(config *cb.Config, env *cb.Envelope) (*cb.Config, error) {
+	configUpdateEnv, err := protoutil.EnvelopeToConfigUpdate(env)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not extract config update")
+	}
+
+	configUpdate, err := configtx.UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	validator, err := configtx.NewValidatorImpl(configUpdate.ChannelId, config, channelconfig.RootGroupKey, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not create config validator")
+	}
+
+	updated, err := validator.ProposedConfig(configUpdateEnv)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid config update")
+	}
+	return updated, nil
+}
+
+// Compare returns the semantic changes between the original and the updated
+// config.
+func Compare(original, updated *cb.Config) (*Report, error) {
+	if original.GetChannelGroup() == nil {
+		return nil, errors.New("original config has no channel group")
+	}
+	if updated.GetChannelGroup() == nil {
+		return nil, errors.New("updated config has no channel group")
+	}
+
+	r := &Report{Changes: []*Change{}}
+	if err := r.compareGroup("/"+channelconfig.RootGroupKey, original.ChannelGroup, updated.ChannelGroup); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Report) add(change *Change) {
+	r.Changes = append(r.Changes, change)
+}
+
+func (r *Report) compareGroup(path string, original, updated *cb.ConfigGroup) error {
+	if original.ModPolicy != updated.ModPolicy {
+		r.add(&Change{Type: ModPolicyModified, Path: path, Original: original.ModPolicy, Updated: updated.ModPolicy})
+	}
+
+	for _, key := range sortedKeys(original.Values, updated.Values) {
+		if err := r.compareValue(path+"/Values/"+key, key, original.Values[key], updated.Values[key]); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range sortedKeys(original.Policies, updated.Policies) {
+		r.comparePolicy(path+"/Policies/"+key, original.Policies[key], updated.Policies[key])
+	}
+
+	for _, key := range sortedKeys(original.Groups, updated.Groups) {
+		groupPath := path + "/" + key
+		originalGroup, updatedGroup := original.Groups[key], updated.Groups[key]
+		switch {
+		case originalGroup == nil:
+			r.add(groupChange(GroupAdded, OrgAdded, groupPath, key, updatedGroup))
+		case updatedGroup == nil:
+			r.add(groupChange(GroupRemoved, OrgRemoved, groupPath, key, originalGroup))
+		default:
+			if err := r.compareGroup(groupPath, originalGroup, updatedGroup); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// groupChange reports a group being added or removed as an organization
+// being added or removed if the group defines an MSP.
+func groupChange(groupType, orgType ChangeType, path, key string, group *cb.ConfigGroup) *Change {
+	mspValue, ok := group.Values[channelconfig.MSPKey]
+	if !ok {
+		return &Change{Type: groupType, Path: path}
+	}
+
+	mspID := key
+	mspConfig := &mb.MSPConfig{}
+	fabricConfig := &mb.FabricMSPConfig{}
+	if proto.Unmarshal(mspValue.Value, mspConfig) == nil && proto.Unmarshal(mspConfig.Config, fabricConfig) == nil && fabricConfig.Name != "" {
+		mspID = fabricConfig.Name
+	}
+
+	if orgType == OrgAdded {
+		return &Change{Type: orgType, Path: path, Updated: mspID}
+	}
+	return &Change{Type: orgType, Path: path, Original: mspID}
+}
+
+func (r *Report) compareValue(path, key string, original, updated *cb.ConfigValue) error {
+	switch {
+	case original == nil:
+		r.add(&Change{Type: ValueAdded, Path: path, Updated: describeValue(key, updated.Value)})
+		return nil
+	case updated == nil:
+		r.add(&Change{Type: ValueRemoved, Path: path, Original: describeValue(key, original.Value)})
+		return nil
+	}
+
+	if original.ModPolicy != updated.ModPolicy {
+		r.add(&Change{Type: ModPolicyModified, Path: path, Original: original.ModPolicy, Updated: updated.ModPolicy})
+	}
+	if bytes.Equal(original.Value, updated.Value) {
+		return nil
+	}
+
+	var err error
+	switch key {
+	case channelconfig.MSPKey:
+		err = r.compareMSP(path, original.Value, updated.Value)
+	case channelconfig.CapabilitiesKey:
+		err = r.compareCapabilities(path, original.Value, updated.Value)
+	case channelconfig.BatchSizeKey:
+		err = r.compareBatchSize(path, original.Value, updated.Value)
+	case channelconfig.BatchTimeoutKey:
+		err = r.compareBatchTimeout(path, original.Value, updated.Value)
+	case channelconfig.ConsensusTypeKey:
+		err = r.compareConsensusType(path, original.Value, updated.Value)
+	default:
+		originalDesc, updatedDesc := describeValue(key, original.Value), describeValue(key, updated.Value)
+		if originalDesc == updatedDesc {
+			// the values are equivalent or not understood
+			originalDesc, updatedDesc = "", ""
+		}
+		r.add(&Change{Type: ValueModified, Path: path, Original: originalDesc, Updated: updatedDesc})
+	}
+	return errors.WithMessagef(err, "could not compare %s", path)
+}
+
+func (r *Report) compareMSP(path string, original, updated []byte) error {
+	originalMSP, err := unmarshalFabricMSPConfig(original)
+	if err != nil {
+		return err
+	}
+	updatedMSP, err := unmarshalFabricMSPConfig(updated)
+	if err != nil {
+		return err
+	}
+
+	if originalMSP.Name != updatedMSP.Name {
+		r.add(&Change{Type: MSPModified, Path: path, Subject: "name", Original: originalMSP.Name, Updated: updatedMSP.Name})
+	}
+
+	for _, field := range []struct {
+		name              string
+		original, updated [][]byte
+	}{
+		{"root_certs", originalMSP.RootCerts, updatedMSP.RootCerts},
+		{"intermediate_certs", originalMSP.IntermediateCerts, updatedMSP.IntermediateCerts},
+		{"admins", originalMSP.Admins, updatedMSP.Admins},
+		{"tls_root_certs", originalMSP.TlsRootCerts, updatedMSP.TlsRootCerts},
+		{"tls_intermediate_certs", originalMSP.TlsIntermediateCerts, updatedMSP.TlsIntermediateCerts},
+	} {
+		for _, cert := range missingFrom(field.original, field.updated) {
+			r.add(&Change{Type: CertificateRemoved, Path: path, Subject: field.name, Original: describeCertificate(cert)})
+		}
+		for _, cert := range missingFrom(field.updated, field.original) {
+			r.add(&Change{Type: CertificateAdded, Path: path, Subject: field.name, Updated: describeCertificate(cert)})
+		}
+	}
+
+	for _, field := range []struct {
+		name              string
+		original, updated interface{}
+	}{
+		{"revocation_list", originalMSP.RevocationList, updatedMSP.RevocationList},
+		{"organizational_unit_identifiers", originalMSP.OrganizationalUnitIdentifiers, updatedMSP.OrganizationalUnitIdentifiers},
+		{"crypto_config", originalMSP.CryptoConfig, updatedMSP.CryptoConfig},
+		{"fabric_node_ous", originalMSP.FabricNodeOus, updatedMSP.FabricNodeOus},
+		{"signing_identity", originalMSP.SigningIdentity, updatedMSP.SigningIdentity},
+	} {
+		if !reflect.DeepEqual(field.original, field.updated) {
+			r.add(&Change{Type: MSPModified, Path: path, Subject: field.name})
+		}
+	}
+	return nil
+}
+
+func unmarshalFabricMSPConfig(value []byte) (*mb.FabricMSPConfig, error) {
+	mspConfig := &mb.MSPConfig{}
+	if err := proto.Unmarshal(value, mspConfig); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal MSP config")
+	}
+	fabricConfig := &mb.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal fabric MSP config")
+	}
+	return fabricConfig, nil
+}
+
+// missingFrom returns the certificates of certs which are not in others.
+func missingFrom(certs, others [][]byte) [][]byte {
+	var missing [][]byte
+	for _, cert := range certs {
+		found := false
+		for _, other := range others {
+			if bytes.Equal(cert, other) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, cert)
+		}
+	}
+	return missing
+}
+
+func describeCertificate(certPEM []byte) string {
+	if block, _ := pem.Decode(certPEM); block != nil {
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			return fmt.Sprintf("%s (serial %x, expires %s)", cert.Subject, cert.SerialNumber, cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+	}
+	return fmt.Sprintf("unparseable certificate with SHA-256 %x", sha256.Sum256(certPEM))
+}
+
+func (r *Report) compareCapabilities(path string, original, updated []byte) error {
+	originalCapabilities, updatedCapabilities := &cb.Capabilities{}, &cb.Capabilities{}
+	if err := proto.Unmarshal(original, originalCapabilities); err != nil {
+		return errors.Wrap(err, "could not unmarshal capabilities")
+	}
+	if err := proto.Unmarshal(updated, updatedCapabilities); err != nil {
+		return errors.Wrap(err, "could not unmarshal capabilities")
+	}
+
+	for _, name := range sortedKeys(originalCapabilities.Capabilities, updatedCapabilities.Capabilities) {
+		_, inOriginal := originalCapabilities.Capabilities[name]
+		_, inUpdated := updatedCapabilities.Capabilities[name]
+		switch {
+		case !inOriginal:
+			r.add(&Change{Type: CapabilityAdded, Path: path, Subject: name})
+		case !inUpdated:
+			r.add(&Change{Type: CapabilityRemoved, Path: path, Subject: name})
+		}
+	}
+	return nil
+}
+
+func (r *Report) compareBatchSize(path string, original, updated []byte) error {
+	originalBatchSize, updatedBatchSize := &ab.BatchSize{}, &ab.BatchSize{}
+	if err := proto.Unmarshal(original, originalBatchSize); err != nil {
+		return errors.Wrap(err, "could not unmarshal batch size")
+	}
+	if err := proto.Unmarshal(updated, updatedBatchSize); err != nil {
+		return errors.Wrap(err, "could not unmarshal batch size")
+	}
+
+	r.compareParameter(path, "max_message_count", originalBatchSize.MaxMessageCount, updatedBatchSize.MaxMessageCount)
+	r.compareParameter(path, "absolute_max_bytes", originalBatchSize.AbsoluteMaxBytes, updatedBatchSize.AbsoluteMaxBytes)
+	r.compareParameter(path, "preferred_max_bytes", originalBatchSize.PreferredMaxBytes, updatedBatchSize.PreferredMaxBytes)
+	return nil
+}
+
+func (r *Report) compareBatchTimeout(path string, original, updated []byte) error {
+	originalBatchTimeout, updatedBatchTimeout := &ab.BatchTimeout{}, &ab.BatchTimeout{}
+	if err := proto.Unmarshal(original, originalBatchTimeout); err != nil {
+		return errors.Wrap(err, "could not unmarshal batch timeout")
+	}
+	if err := proto.Unmarshal(updated, updatedBatchTimeout); err != nil {
+		return errors.Wrap(err, "could not unmarshal batch timeout")
+	}
+
+	r.compareParameter(path, "timeout", originalBatchTimeout.Timeout, updatedBatchTimeout.Timeout)
+	return nil
+}
+
+func (r *Report) compareConsensusType(path string, original, updated []byte) error {
+	originalConsensusType, updatedConsensusType := &ab.ConsensusType{}, &ab.ConsensusType{}
+	if err := proto.Unmarshal(original, originalConsensusType); err != nil {
+		return errors.Wrap(err, "could not unmarshal consensus type")
+	}
+	if err := proto.Unmarshal(updated, updatedConsensusType); err != nil {
+		return errors.Wrap(err, "could not unmarshal consensus type")
+	}
+
+	r.compareParameter(path, "type", originalConsensusType.Type, updatedConsensusType.Type)
+	r.compareParameter(path, "state", originalConsensusType.State, updatedConsensusType.State)
+	if bytes.Equal(originalConsensusType.Metadata, updatedConsensusType.Metadata) {
+		return nil
+	}
+	if originalConsensusType.Type != "etcdraft" || updatedConsensusType.Type != "etcdraft" {
+		r.add(&Change{Type: ParameterModified, Path: path, Subject: "metadata"})
+		return nil
+	}
+
+	originalMetadata, updatedMetadata := &etcdraft.ConfigMetadata{}, &etcdraft.ConfigMetadata{}
+	if err := proto.Unmarshal(originalConsensusType.Metadata, originalMetadata); err != nil {
+		return errors.Wrap(err, "could not unmarshal etcdraft metadata")
+	}
+	if err := proto.Unmarshal(updatedConsensusType.Metadata, updatedMetadata); err != nil {
+		return errors.Wrap(err, "could not unmarshal etcdraft metadata")
+	}
+
+	r.compareConsenters(path, originalMetadata.Consenters, updatedMetadata.Consenters)
+
+	originalOptions, updatedOptions := originalMetadata.Options, updatedMetadata.Options
+	if originalOptions == nil {
+		originalOptions = &etcdraft.Options{}
+	}
+	if updatedOptions == nil {
+		updatedOptions = &etcdraft.Options{}
+	}
+	r.compareParameter(path, "options.tick_interval", originalOptions.TickInterval, updatedOptions.TickInterval)
+	r.compareParameter(path, "options.election_tick", originalOptions.ElectionTick, updatedOptions.ElectionTick)
+	r.compareParameter(path, "options.heartbeat_tick", originalOptions.HeartbeatTick, updatedOptions.HeartbeatTick)
+	r.compareParameter(path, "options.max_inflight_blocks", originalOptions.MaxInflightBlocks, updatedOptions.MaxInflightBlocks)
+	r.compareParameter(path, "options.snapshot_interval_size", originalOptions.SnapshotIntervalSize, updatedOptions.SnapshotIntervalSize)
+	return nil
+}
+
+func (r *Report) compareConsenters(path string, original, updated []*etcdraft.Consenter) {
+	endpoint := func(c *etcdraft.Consenter) string {
+		return fmt.Sprintf("%s:%d", c.Host, c.Port)
+	}
+	originalConsenters := map[string]*etcdraft.Consenter{}
+	for _, c := range original {
+		originalConsenters[endpoint(c)] = c
+	}
+	updatedConsenters := map[string]*etcdraft.Consenter{}
+	for _, c := range updated {
+		updatedConsenters[endpoint(c)] = c
+	}
+
+	for _, key := range sortedKeys(originalConsenters, updatedConsenters) {
+		originalConsenter, updatedConsenter := originalConsenters[key], updatedConsenters[key]
+		switch {
+		case originalConsenter == nil:
+			r.add(&Change{Type: ConsenterAdded, Path: path, Subject: key, Updated: describeConsenter(updatedConsenter)})
+		case updatedConsenter == nil:
+			r.add(&Change{Type: ConsenterRemoved, Path: path, Subject: key, Original: describeConsenter(originalConsenter)})
+		case !proto.Equal(originalConsenter, updatedConsenter):
+			r.add(&Change{Type: ConsenterModified, Path: path, Subject: key, Original: describeConsenter(originalConsenter), Updated: describeConsenter(updatedConsenter)})
+		}
+	}
+}
+
+func describeConsenter(c *etcdraft.Consenter) string {
+	return fmt.Sprintf("client TLS certificate %s and server TLS certificate %s", describeCertificate(c.ClientTlsCert), describeCertificate(c.ServerTlsCert))
+}
+
+func (r *Report) compareParameter(path, name string, original, updated interface{}) {
+	if original == updated {
+		return
+	}
+	r.add(&Change{Type: ParameterModified, Path: path, Subject: name, Original: fmt.Sprint(original), Updated: fmt.Sprint(updated)})
+}
+
+func (r *Report) comparePolicy(path string, original, updated *cb.ConfigPolicy) {
+	switch {
+	case original == nil:
+		r.add(&Change{Type: PolicyAdded, Path: path, Updated: describePolicy(updated.Policy)})
+		return
+	case updated == nil:
+		r.add(&Change{Type: PolicyRemoved, Path: path, Original: describePolicy(original.Policy)})
+		return
+	}
+
+	if original.ModPolicy != updated.ModPolicy {
+		r.add(&Change{Type: ModPolicyModified, Path: path, Original: original.ModPolicy, Updated: updated.ModPolicy})
+	}
+	if !proto.Equal(original.Policy, updated.Policy) {
+		r.add(&Change{Type: PolicyModified, Path: path, Original: describePolicy(original.Policy), Updated: describePolicy(updated.Policy)})
+	}
+}
+
+// describePolicy returns the rule of the policy in the syntax used by
+// configtx.yaml.
+func describePolicy(policy *cb.Policy) string {
+	switch cb.Policy_PolicyType(policy.GetType()) {
+	case cb.Policy_SIGNATURE:
+		spe := &cb.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(policy.Value, spe); err != nil {
+			return fmt.Sprintf("malformed signature policy: %s", err)
+		}
+		s, err := policydsl.ToString(spe)
+		if err != nil {
+			return fmt.Sprintf("signature policy not expressible in policy language: %s", err)
+		}
+		return s
+	case cb.Policy_IMPLICIT_META:
+		imp := &cb.ImplicitMetaPolicy{}
+		if err := proto.Unmarshal(policy.Value, imp); err != nil {
+			return fmt.Sprintf("malformed implicit meta policy: %s", err)
+		}
+		return fmt.Sprintf("%s %s", imp.Rule, imp.SubPolicy)
+	case cb.Policy_PolicyType(expression.PolicyType):
+		ep := &expression.ExpressionPolicy{}
+		if err := proto.Unmarshal(policy.Value, ep); err != nil {
+			return fmt.Sprintf("malformed expression policy: %s", err)
+		}
+		return ep.Expression
+	default:
+		return fmt.Sprintf("policy of type %s", cb.Policy_PolicyType(policy.GetType()))
+	}
+}
+
+// valueMessages maps the keys of the config values without dedicated
+// comparison to the message they contain.
+var valueMessages = map[string]func() proto.Message{
+	channelconfig.ACLsKey:                      func() proto.Message { return &pb.ACLs{} },
+	channelconfig.AnchorPeersKey:               func() proto.Message { return &pb.AnchorPeers{} },
+	channelconfig.ConsortiumKey:                func() proto.Message { return &cb.Consortium{} },
+	channelconfig.HashingAlgorithmKey:          func() proto.Message { return &cb.HashingAlgorithm{} },
+	channelconfig.BlockDataHashingStructureKey: func() proto.Message { return &cb.BlockDataHashingStructure{} },
+	channelconfig.OrdererAddressesKey:          func() proto.Message { return &cb.OrdererAddresses{} },
+	channelconfig.EndpointsKey:                 func() proto.Message { return &cb.OrdererAddresses{} },
+	channelconfig.ChannelRestrictionsKey:       func() proto.Message { return &ab.ChannelRestrictions{} },
+	channelconfig.KafkaBrokersKey:              func() proto.Message { return &ab.KafkaBrokers{} },
+}
+
+// describeValue returns the content of a config value of a known type as
+// compact JSON, or an empty string if the type is not known.
+func describeValue(key string, value []byte) string {
+	newMessage, ok := valueMessages[key]
+	if !ok {
+		return ""
+	}
+	msg := newMessage()
+	if err := proto.Unmarshal(value, msg); err != nil {
+		return ""
+	}
+	buf := &bytes.Buffer{}
+	if err := protolator.DeepMarshalJSON(buf, msg); err != nil {
+		return ""
+	}
+	compact := &bytes.Buffer{}
+	if err := json.Compact(compact, buf.Bytes()); err != nil {
+		return ""
+	}
+	return compact.String()
+}
+
+// sortedKeys returns the sorted union of the keys of two maps with string
+// keys.
+func sortedKeys(original, updated interface{}) []string {
+	keys := map[string]struct{}{}
+	for _, m := range []interface{}{original, updated} {
+		for _, key := range reflect.ValueOf(m).MapKeys() {
+			keys[key.String()] = struct{}{}
+		}
+	}
+	result := make([]string, 0, len(keys))
+	for key := range keys {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,230 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package diff
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies/expression"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/internal/configtxlator/edit"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func loadProfile(t *testing.T) *genesisconfig.Profile {
+	devConfigDir := configtest.GetDevConfigDir()
+	profile := genesisconfig.Load(genesisconfig.SampleAppChannelEtcdRaftProfile, devConfigDir)
+	certPath := filepath.Join(devConfigDir, "msp", "signcerts", "peer.pem")
+	for _, c := range profile.Orderer.EtcdRaft.Consenters {
+		c.ClientTlsCert = []byte(certPath)
+		c.ServerTlsCert = []byte(certPath)
+	}
+	return profile
+}
+
+func configBlock(t *testing.T) *cb.Block {
+	return encoder.New(loadProfile(t)).GenesisBlockForChannel("testchannel")
+}
+
+func readCert(t *testing.T, name string) []byte {
+	cert, err := ioutil.ReadFile(filepath.Join(configtest.GetDevConfigDir(), "msp", name))
+	require.NoError(t, err)
+	return cert
+}
+
+func TestCompareNoChanges(t *testing.T) {
+	_, config, err := edit.ConfigFromBlock(configBlock(t))
+	require.NoError(t, err)
+
+	report, err := Compare(config, config)
+	require.NoError(t, err)
+	require.Empty(t, report.Changes)
+	require.Equal(t, "no changes\n", report.String())
+
+	_, err = Compare(&cb.Config{}, config)
+	require.EqualError(t, err, "original config has no channel group")
+	_, err = Compare(config, &cb.Config{})
+	require.EqualError(t, err, "updated config has no channel group")
+}
+
+func TestApplyAndCompare(t *testing.T) {
+	block := configBlock(t)
+	_, config, err := edit.ConfigFromBlock(block)
+	require.NoError(t, err)
+
+	orgConf := *loadProfile(t).Application.Organizations[0]
+	orgConf.Name = "Org2"
+	orgConf.ID = "Org2MSP"
+	org, err := encoder.NewApplicationOrgGroup(&orgConf)
+	require.NoError(t, err)
+
+	serverCert := readCert(t, filepath.Join("tlsintermediatecerts", "tlsintermediate.pem"))
+	env, err := edit.Edit(block,
+		edit.AddApplicationOrg(org),
+		edit.RemoveConsenter("raft1.example.com", 7050),
+		edit.ReplaceConsenter("raft2.example.com", 7050, &etcdraft.Consenter{
+			Host:          "raft3.example.com",
+			Port:          7050,
+			ClientTlsCert: serverCert,
+			ServerTlsCert: serverCert,
+		}),
+		edit.SetBatchParameters(edit.BatchParameters{MaxMessageCount: 42, Timeout: 5 * time.Second}),
+		edit.SetCapabilities(edit.ApplicationLevel, []string{"V2_5"}),
+	)
+	require.NoError(t, err)
+
+	updated, err := Apply(config, env)
+	require.NoError(t, err)
+	require.Equal(t, config.Sequence+1, updated.Sequence)
+
+	report, err := Compare(config, updated)
+	require.NoError(t, err)
+	require.Equal(t, []*Change{
+		{Type: CapabilityRemoved, Path: "/Channel/Application/Values/Capabilities", Subject: "V2_0"},
+		{Type: CapabilityAdded, Path: "/Channel/Application/Values/Capabilities", Subject: "V2_5"},
+		{Type: OrgAdded, Path: "/Channel/Application/Org2MSP", Updated: "Org2MSP"},
+		{Type: ParameterModified, Path: "/Channel/Orderer/Values/BatchSize", Subject: "max_message_count", Original: "500", Updated: "42"},
+		{Type: ParameterModified, Path: "/Channel/Orderer/Values/BatchTimeout", Subject: "timeout", Original: "2s", Updated: "5s"},
+		{Type: ConsenterRemoved, Path: "/Channel/Orderer/Values/ConsensusType", Subject: "raft1.example.com:7050", Original: describeConsenter(consenter(t, config, 1))},
+		{Type: ConsenterRemoved, Path: "/Channel/Orderer/Values/ConsensusType", Subject: "raft2.example.com:7050", Original: describeConsenter(consenter(t, config, 2))},
+		{Type: ConsenterAdded, Path: "/Channel/Orderer/Values/ConsensusType", Subject: "raft3.example.com:7050", Updated: describeConsenter(consenter(t, updated, 1))},
+	}, report.Changes)
+
+	require.Equal(t, "/Channel/Orderer/Values/BatchSize: max_message_count modified from 500 to 42", report.Changes[3].String())
+	require.Equal(t, "/Channel/Application/Org2MSP: organization Org2MSP added", report.Changes[2].String())
+	require.Contains(t, report.Changes[7].String(), "consenter raft3.example.com:7050 added with client TLS certificate CN=Org2-child1")
+
+	env, err = edit.Edit(block, edit.RemoveApplicationOrg("SampleOrg"))
+	require.NoError(t, err)
+	updated, err = Apply(config, env)
+	require.NoError(t, err)
+	report, err = Compare(config, updated)
+	require.NoError(t, err)
+	require.Equal(t, "/Channel/Application/SampleOrg: organization SampleOrg removed\n", report.String())
+}
+
+func consenter(t *testing.T, config *cb.Config, i int) *etcdraft.Consenter {
+	consensusType := &ab.ConsensusType{}
+	require.NoError(t, proto.Unmarshal(config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.ConsensusTypeKey].Value, consensusType))
+	metadata := &etcdraft.ConfigMetadata{}
+	require.NoError(t, proto.Unmarshal(consensusType.Metadata, metadata))
+	return metadata.Consenters[i]
+}
+
+func TestApplyErrors(t *testing.T) {
+	_, config, err := edit.ConfigFromBlock(configBlock(t))
+	require.NoError(t, err)
+
+	_, err = Apply(config, &cb.Envelope{Payload: []byte("garbage")})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "could not extract config update")
+
+	env, err := protoutil.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, "testchannel", nil, &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: protoutil.MarshalOrPanic(&cb.ConfigUpdate{
+			ChannelId: "testchannel",
+			WriteSet:  &cb.ConfigGroup{Version: 5, ModPolicy: "Admins"},
+		}),
+	}, 0, 0)
+	require.NoError(t, err)
+	_, err = Apply(config, env)
+	require.EqualError(t, err, "invalid config update: attempt to set key [Group]  /Channel to version 5, but key is at version 0")
+}
+
+func TestCompareConfigElements(t *testing.T) {
+	_, original, err := edit.ConfigFromBlock(configBlock(t))
+	require.NoError(t, err)
+	updated := proto.Clone(original).(*cb.Config)
+
+	org := updated.ChannelGroup.Groups[channelconfig.ApplicationGroupKey].Groups["SampleOrg"]
+	mspConfig := &mb.MSPConfig{}
+	require.NoError(t, proto.Unmarshal(org.Values[channelconfig.MSPKey].Value, mspConfig))
+	fabricConfig := &mb.FabricMSPConfig{}
+	require.NoError(t, proto.Unmarshal(mspConfig.Config, fabricConfig))
+	removedRoot := fabricConfig.RootCerts[0]
+	addedRoot := readCert(t, filepath.Join("tlscacerts", "tlsroot.pem"))
+	fabricConfig.RootCerts = [][]byte{addedRoot}
+	fabricConfig.FabricNodeOus = &mb.FabricNodeOUs{Enable: true}
+	mspConfig.Config = protoutil.MarshalOrPanic(fabricConfig)
+	org.Values[channelconfig.MSPKey].Value = protoutil.MarshalOrPanic(mspConfig)
+
+	org.Policies["Admins"].Policy.Value = protoutil.MarshalOrPanic(policydsl.SignedByNOutOfGivenRole(2, mb.MSPRole_ADMIN, []string{"SampleOrg", "Org2MSP"}))
+	org.Policies["Admins"].ModPolicy = "Writers"
+	delete(org.Policies, "Readers")
+	endorsement, err := expression.NewConfigPolicy("OutOf(2, Weight(2, Identity('SampleOrg.peer', OU('ops'))), 'SampleOrg.admin', 'SampleOrg.client')")
+	require.NoError(t, err)
+	org.Policies["Endorsement"].Policy = endorsement
+	org.Policies["Auditors"] = &cb.ConfigPolicy{
+		Policy: &cb.Policy{
+			Type:  int32(cb.Policy_IMPLICIT_META),
+			Value: protoutil.MarshalOrPanic(&cb.ImplicitMetaPolicy{Rule: cb.ImplicitMetaPolicy_MAJORITY, SubPolicy: "Admins"}),
+		},
+	}
+
+	org.Values[channelconfig.AnchorPeersKey].Value = protoutil.MarshalOrPanic(&pb.AnchorPeers{
+		AnchorPeers: []*pb.AnchorPeer{{Host: "peer0.example.com", Port: 7051}},
+	})
+	org.Values["Unknown"] = &cb.ConfigValue{Value: []byte("unknown")}
+
+	report, err := Compare(original, updated)
+	require.NoError(t, err)
+	require.Equal(t, []*Change{
+		{
+			Type:     ValueModified,
+			Path:     "/Channel/Application/SampleOrg/Values/AnchorPeers",
+			Original: `{"anchor_peers":[{"host":"127.0.0.1","port":7051}]}`,
+			Updated:  `{"anchor_peers":[{"host":"peer0.example.com","port":7051}]}`,
+		},
+		{Type: CertificateRemoved, Path: "/Channel/Application/SampleOrg/Values/MSP", Subject: "root_certs", Original: describeCertificate(removedRoot)},
+		{Type: CertificateAdded, Path: "/Channel/Application/SampleOrg/Values/MSP", Subject: "root_certs", Updated: describeCertificate(addedRoot)},
+		{Type: MSPModified, Path: "/Channel/Application/SampleOrg/Values/MSP", Subject: "fabric_node_ous"},
+		{Type: ValueAdded, Path: "/Channel/Application/SampleOrg/Values/Unknown"},
+		{Type: ModPolicyModified, Path: "/Channel/Application/SampleOrg/Policies/Admins", Original: "Admins", Updated: "Writers"},
+		{Type: PolicyModified, Path: "/Channel/Application/SampleOrg/Policies/Admins", Original: "OR('SampleOrg.member')", Updated: "AND('Org2MSP.admin', 'SampleOrg.admin')"},
+		{Type: PolicyAdded, Path: "/Channel/Application/SampleOrg/Policies/Auditors", Updated: "MAJORITY Admins"},
+		{Type: PolicyModified, Path: "/Channel/Application/SampleOrg/Policies/Endorsement", Original: "OR('SampleOrg.member')", Updated: "OutOf(2, Weight(2, Identity('SampleOrg.peer', OU('ops'))), 'SampleOrg.admin', 'SampleOrg.client')"},
+		{Type: PolicyRemoved, Path: "/Channel/Application/SampleOrg/Policies/Readers", Original: "OR('SampleOrg.member')"},
+	}, report.Changes)
+
+	require.Equal(t, "/Channel/Application/SampleOrg/Policies/Admins: policy modified from OR('SampleOrg.member') to AND('Org2MSP.admin', 'SampleOrg.admin')", report.Changes[6].String())
+	require.Equal(t, "/Channel/Application/SampleOrg/Values/MSP: MSP fabric_node_ous modified", report.Changes[3].String())
+	require.Equal(t, "/Channel/Application/SampleOrg/Values/Unknown: value added with unknown content", report.Changes[4].String())
+}
+
+func TestMarshal(t *testing.T) {
+	report := &Report{Changes: []*Change{
+		{Type: CapabilityAdded, Path: "/Channel/Values/Capabilities", Subject: "V2_0"},
+	}}
+
+	text, err := Marshal(report, TextFormat)
+	require.NoError(t, err)
+	require.Equal(t, "/Channel/Values/Capabilities: capability V2_0 added\n", string(text))
+
+	out, err := Marshal(report, JSONFormat)
+	require.NoError(t, err)
+	decoded := &Report{}
+	require.NoError(t, json.Unmarshal(out, decoded))
+	require.Equal(t, report, decoded)
+	require.Contains(t, string(out), `"type": "capability_added"`)
+
+	_, err = Marshal(report, "yaml")
+	require.EqualError(t, err, "unknown format 'yaml'")
+}
@@ -0,0 +1,698 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package edit
+
+import (
+	"bytes"
+	"encoding/pem"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-config/protolator"
+	"github.com/hyperledger/fabric-config/protolator/protoext/ordererext"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/internal/configtxlator/update"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Capability levels accepted by SetCapabilities.
+const (
+	ChannelLevel     = "channel"
+	OrdererLevel     = "orderer"
+	ApplicationLevel = "application"
+)
+
+// Operation modifies a channel config in place.
+type Operation func(config *cb.Config) error
+
+// ConfigFromBlock extracts the channel ID and the channel config from a
+// config block.
+func ConfigFromBlock(block *cb.Block) (string, *cb.Config, error) {
+	env, err := protoutil.ExtractEnvelope(block, 0)
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "could not extract envelope from block")
+	}
+
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "could not unmarshal payload")
+	}
+	if payload.Header == nil {
+		return "", nil, errors.New("block envelope has no header")
+	}
+
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return "", nil, errors.WithMessage(err, "could not unmarshal channel header")
+	}
+	if chdr.Type != int32(cb.HeaderType_CONFIG) {
+		return "", nil, errors.Errorf("block is not a config block, found header type %d", chdr.Type)
+	}
+
+	configEnv := &cb.ConfigEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configEnv); err != nil {
+		return "", nil, errors.Wrap(err, "could not unmarshal config envelope")
+	}
+	if configEnv.Config == nil || configEnv.Config.ChannelGroup == nil {
+		return "", nil, errors.New("config envelope has no channel group")
+	}
+
+	return chdr.ChannelId, configEnv.Config, nil
+}
+
+// Edit applies the operations to a copy of the config found in the block and
+// returns an unsigned envelope carrying the resulting config update, ready to
+// be signed by the channel admins.
+func Edit(block *cb.Block, ops ...Operation) (*cb.Envelope, error) {
+	channelID, original, err := ConfigFromBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := proto.Clone(original).(*cb.Config)
+	for _, op := range ops {
+		if err := op(updated); err != nil {
+			return nil, err
+		}
+	}
+
+	configUpdate, err := update.Compute(original, updated)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not compute config update")
+	}
+	configUpdate.ChannelId = channelID
+
+	return protoutil.CreateSignedEnvelope(
+		cb.HeaderType_CONFIG_UPDATE,
+		channelID,
+		nil,
+		&cb.ConfigUpdateEnvelope{ConfigUpdate: protoutil.MarshalOrPanic(configUpdate)},
+		0,
+		0,
+	)
+}
+
+// AddApplicationOrg returns an operation adding the organization, as printed
+// by configtxgen -printOrg, to the application group. The organization is
+// keyed by its MSP ID and any orderer endpoints are dropped.
+func AddApplicationOrg(org *cb.ConfigGroup) Operation {
+	return func(config *cb.Config) error {
+		mspID, err := orgMSPID(org)
+		if err != nil {
+			return err
+		}
+
+		application, err := applicationGroup(config)
+		if err != nil {
+			return err
+		}
+		if _, ok := application.Groups[mspID]; ok {
+			return errors.Errorf("organization %s already exists in the application group", mspID)
+		}
+
+		org = proto.Clone(org).(*cb.ConfigGroup)
+		delete(org.Values, channelconfig.EndpointsKey)
+		if org.ModPolicy == "" {
+			org.ModPolicy = channelconfig.AdminsPolicyKey
+		}
+		if application.Groups == nil {
+			application.Groups = map[string]*cb.ConfigGroup{}
+		}
+		application.Groups[mspID] = org
+		return nil
+	}
+}
+
+// RemoveApplicationOrg returns an operation removing the organization with
+// the given MSP ID from the application group.
+func RemoveApplicationOrg(mspID string) Operation {
+	return func(config *cb.Config) error {
+		application, err := applicationGroup(config)
+		if err != nil {
+			return err
+		}
+		if _, ok := application.Groups[mspID]; !ok {
+			return errors.Errorf("organization %s not found in the application group", mspID)
+		}
+		delete(application.Groups, mspID)
+		return nil
+	}
+}
+
+// SetAnchorPeers returns an operation replacing the anchor peers of the
+// application organization with the given MSP ID. An empty list removes the
+// anchor peers altogether.
+func SetAnchorPeers(mspID string, anchorPeers []*pb.AnchorPeer) Operation {
+	return func(config *cb.Config) error {
+		application, err := applicationGroup(config)
+		if err != nil {
+			return err
+		}
+		org, ok := application.Groups[mspID]
+		if !ok {
+			return errors.Errorf("organization %s not found in the application group", mspID)
+		}
+
+		if len(anchorPeers) == 0 {
+			delete(org.Values, channelconfig.AnchorPeersKey)
+			return nil
+		}
+		setValue(org, channelconfig.AnchorPeersValue(anchorPeers))
+		return nil
+	}
+}
+
+// AddConsenter returns an operation adding the consenter to the etcdraft
+// consensus metadata.
+func AddConsenter(consenter *etcdraft.Consenter) Operation {
+	return func(config *cb.Config) error {
+		return updateConsenters(config, func(consenters []*etcdraft.Consenter) ([]*etcdraft.Consenter, error) {
+			if consenterIndex(consenters, consenter.Host, consenter.Port) >= 0 {
+				return nil, errors.Errorf("consenter %s:%d already exists", consenter.Host, consenter.Port)
+			}
+			return append(consenters, consenter), nil
+		})
+	}
+}
+
+// RemoveConsenter returns an operation removing the consenter with the given
+// host and port from the etcdraft consensus metadata.
+func RemoveConsenter(host string, port uint32) Operation {
+	return func(config *cb.Config) error {
+		return updateConsenters(config, func(consenters []*etcdraft.Consenter) ([]*etcdraft.Consenter, error) {
+			i := consenterIndex(consenters, host, port)
+			if i < 0 {
+				return nil, errors.Errorf("consenter %s:%d not found", host, port)
+			}
+			if len(consenters) == 1 {
+				return nil, errors.Errorf("cannot remove %s:%d, it is the last consenter", host, port)
+			}
+			return append(consenters[:i], consenters[i+1:]...), nil
+		})
+	}
+}
+
+// ReplaceConsenter returns an operation replacing the consenter with the
+// given host and port by another consenter, keeping its position.
+func ReplaceConsenter(host string, port uint32, consenter *etcdraft.Consenter) Operation {
+	return func(config *cb.Config) error {
+		return updateConsenters(config, func(consenters []*etcdraft.Consenter) ([]*etcdraft.Consenter, error) {
+			i := consenterIndex(consenters, host, port)
+			if i < 0 {
+				return nil, errors.Errorf("consenter %s:%d not found", host, port)
+			}
+			if j := consenterIndex(consenters, consenter.Host, consenter.Port); j >= 0 && j != i {
+				return nil, errors.Errorf("consenter %s:%d already exists", consenter.Host, consenter.Port)
+			}
+			consenters[i] = consenter
+			return consenters, nil
+		})
+	}
+}
+
+// BatchParameters holds the batch settings of the ordering service. Zero
+// fields are left unchanged.
+type BatchParameters struct {
+	MaxMessageCount   uint32
+	AbsoluteMaxBytes  uint32
+	PreferredMaxBytes uint32
+	Timeout           time.Duration
+}
+
+// SetBatchParameters returns an operation updating the batch size and batch
+// timeout of the ordering service.
+func SetBatchParameters(params BatchParameters) Operation {
+	return func(config *cb.Config) error {
+		orderer, err := ordererGroup(config)
+		if err != nil {
+			return err
+		}
+
+		if params.MaxMessageCount != 0 || params.AbsoluteMaxBytes != 0 || params.PreferredMaxBytes != 0 {
+			batchSize := &ab.BatchSize{}
+			if err := unmarshalValue(orderer, channelconfig.BatchSizeKey, batchSize); err != nil {
+				return err
+			}
+			if params.MaxMessageCount != 0 {
+				batchSize.MaxMessageCount = params.MaxMessageCount
+			}
+			if params.AbsoluteMaxBytes != 0 {
+				batchSize.AbsoluteMaxBytes = params.AbsoluteMaxBytes
+			}
+			if params.PreferredMaxBytes != 0 {
+				batchSize.PreferredMaxBytes = params.PreferredMaxBytes
+			}
+			if batchSize.PreferredMaxBytes > batchSize.AbsoluteMaxBytes {
+				return errors.Errorf("preferred max bytes (%d) must not exceed absolute max bytes (%d)", batchSize.PreferredMaxBytes, batchSize.AbsoluteMaxBytes)
+			}
+			setValue(orderer, channelconfig.BatchSizeValue(batchSize.MaxMessageCount, batchSize.AbsoluteMaxBytes, batchSize.PreferredMaxBytes))
+		}
+
+		if params.Timeout < 0 {
+			return errors.Errorf("batch timeout must be positive, got %s", params.Timeout)
+		}
+		if params.Timeout != 0 {
+			setValue(orderer, channelconfig.BatchTimeoutValue(params.Timeout.String()))
+		}
+
+		return nil
+	}
+}
+
+// SetCapabilities returns an operation replacing the capabilities of the
+// channel, orderer or application level.
+func SetCapabilities(level string, capabilities []string) Operation {
+	return func(config *cb.Config) error {
+		var group *cb.ConfigGroup
+		var err error
+		switch level {
+		case ChannelLevel:
+			group = config.ChannelGroup
+		case OrdererLevel:
+			group, err = ordererGroup(config)
+		case ApplicationLevel:
+			group, err = applicationGroup(config)
+		default:
+			return errors.Errorf("unknown capability level '%s', must be one of %s, %s or %s", level, ChannelLevel, OrdererLevel, ApplicationLevel)
+		}
+		if err != nil {
+			return err
+		}
+
+		caps := map[string]bool{}
+		for _, c := range capabilities {
+			caps[c] = true
+		}
+		setValue(group, channelconfig.CapabilitiesValue(caps))
+		return nil
+	}
+}
+
+// SetACLs returns an operation merging the resource to policy mappings into
+// the application ACLs.
+func SetACLs(acls map[string]string) Operation {
+	return func(config *cb.Config) error {
+		application, err := applicationGroup(config)
+		if err != nil {
+			return err
+		}
+
+		existing := &pb.ACLs{}
+		if err := unmarshalValue(application, channelconfig.ACLsKey, existing); err != nil {
+			return err
+		}
+
+		merged := map[string]string{}
+		for resource, acl := range existing.Acls {
+			merged[resource] = acl.PolicyRef
+		}
+		for resource, policyRef := range acls {
+			merged[resource] = policyRef
+		}
+		setValue(application, channelconfig.ACLValues(merged))
+		return nil
+	}
+}
+
+// CertificateRenewal pairs a PEM encoded certificate with the PEM encoded
+// certificate renewing it.
+type CertificateRenewal struct {
+	Previous []byte
+	Current  []byte
+	// KeepPrevious keeps trusting the previous certificate alongside the
+	// current one, which gives an overlap period when a CA is rotated.
+	KeepPrevious bool
+}
+
+// RenewCertificates returns an operation replacing the renewed certificates
+// in the MSPs of the application, orderer and consortium organizations, and
+// in the TLS certificates of the etcdraft consenters. Root, intermediate and
+// admin certificates that are kept alongside their renewal are appended to,
+// organizational unit identifiers pinned to them are duplicated, and node OU
+// identifiers pinned to them are unpinned so that identities issued by
+// either certificate are classified. Certificates not found in the config are
+// ignored.
+func RenewCertificates(renewals []CertificateRenewal) Operation {
+	return func(config *cb.Config) error {
+		for _, r := range renewals {
+			if !isCertificatePEM(r.Previous) || !isCertificatePEM(r.Current) {
+				return errors.New("renewed certificates must be PEM encoded certificates")
+			}
+		}
+
+		var orgs []*cb.ConfigGroup
+		for _, key := range []string{channelconfig.ApplicationGroupKey, channelconfig.OrdererGroupKey} {
+			if group, ok := config.ChannelGroup.Groups[key]; ok {
+				for _, org := range group.Groups {
+					orgs = append(orgs, org)
+				}
+			}
+		}
+		if consortiums, ok := config.ChannelGroup.Groups[channelconfig.ConsortiumsGroupKey]; ok {
+			for _, consortium := range consortiums.Groups {
+				for _, org := range consortium.Groups {
+					orgs = append(orgs, org)
+				}
+			}
+		}
+
+		for _, org := range orgs {
+			if err := renewMSPCertificates(org, renewals); err != nil {
+				return err
+			}
+		}
+
+		return renewConsenterCertificates(config, renewals)
+	}
+}
+
+func renewMSPCertificates(org *cb.ConfigGroup, renewals []CertificateRenewal) error {
+	if _, ok := org.Values[channelconfig.MSPKey]; !ok {
+		return nil
+	}
+	mspConfig := &mb.MSPConfig{}
+	if err := unmarshalValue(org, channelconfig.MSPKey, mspConfig); err != nil {
+		return err
+	}
+	fabricConfig := &mb.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+		return errors.Wrap(err, "could not unmarshal organization MSP config")
+	}
+
+	changed := false
+	renewList := func(certs [][]byte) [][]byte {
+		var renewed [][]byte
+		for _, cert := range certs {
+			r, ok := findRenewal(renewals, cert)
+			if !ok {
+				renewed = append(renewed, cert)
+				continue
+			}
+			changed = true
+			if r.KeepPrevious {
+				renewed = append(renewed, cert)
+			}
+			if !containsCertificate(certs, r.Current) && !containsCertificate(renewed, r.Current) {
+				renewed = append(renewed, r.Current)
+			}
+		}
+		return renewed
+	}
+	fabricConfig.RootCerts = renewList(fabricConfig.RootCerts)
+	fabricConfig.IntermediateCerts = renewList(fabricConfig.IntermediateCerts)
+	fabricConfig.Admins = renewList(fabricConfig.Admins)
+	fabricConfig.TlsRootCerts = renewList(fabricConfig.TlsRootCerts)
+	fabricConfig.TlsIntermediateCerts = renewList(fabricConfig.TlsIntermediateCerts)
+
+	var ouIdentifiers []*mb.FabricOUIdentifier
+	for _, ou := range fabricConfig.OrganizationalUnitIdentifiers {
+		r, ok := findRenewal(renewals, ou.Certificate)
+		if !ok {
+			ouIdentifiers = append(ouIdentifiers, ou)
+			continue
+		}
+		changed = true
+		if r.KeepPrevious {
+			ouIdentifiers = append(ouIdentifiers, ou)
+		}
+		ouIdentifiers = append(ouIdentifiers, &mb.FabricOUIdentifier{
+			Certificate:                  r.Current,
+			OrganizationalUnitIdentifier: ou.OrganizationalUnitIdentifier,
+		})
+	}
+	fabricConfig.OrganizationalUnitIdentifiers = ouIdentifiers
+
+	if nodeOUs := fabricConfig.FabricNodeOus; nodeOUs != nil {
+		for _, ou := range []*mb.FabricOUIdentifier{
+			nodeOUs.ClientOuIdentifier,
+			nodeOUs.PeerOuIdentifier,
+			nodeOUs.AdminOuIdentifier,
+			nodeOUs.OrdererOuIdentifier,
+		} {
+			if ou == nil {
+				continue
+			}
+			r, ok := findRenewal(renewals, ou.Certificate)
+			if !ok {
+				continue
+			}
+			changed = true
+			ou.Certificate = r.Current
+			if r.KeepPrevious {
+				ou.Certificate = nil
+			}
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	mspConfig.Config = protoutil.MarshalOrPanic(fabricConfig)
+	setValue(org, channelconfig.MSPValue(mspConfig))
+	return nil
+}
+
+func renewConsenterCertificates(config *cb.Config, renewals []CertificateRenewal) error {
+	orderer, ok := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	if !ok {
+		return nil
+	}
+	consensusType := &ab.ConsensusType{}
+	if err := unmarshalValue(orderer, channelconfig.ConsensusTypeKey, consensusType); err != nil {
+		return err
+	}
+	if consensusType.Type != "etcdraft" {
+		return nil
+	}
+
+	metadata := &etcdraft.ConfigMetadata{}
+	if err := proto.Unmarshal(consensusType.Metadata, metadata); err != nil {
+		return errors.Wrap(err, "could not unmarshal etcdraft metadata")
+	}
+	changed := false
+	for _, c := range metadata.Consenters {
+		if r, ok := findRenewal(renewals, c.ClientTlsCert); ok {
+			c.ClientTlsCert = r.Current
+			changed = true
+		}
+		if r, ok := findRenewal(renewals, c.ServerTlsCert); ok {
+			c.ServerTlsCert = r.Current
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	var err error
+	consensusType.Metadata, err = proto.Marshal(metadata)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal etcdraft metadata")
+	}
+	setValue(orderer, &consensusTypeValue{consensusType: consensusType})
+	return nil
+}
+
+// findRenewal returns the renewal of the PEM encoded certificate, if any.
+func findRenewal(renewals []CertificateRenewal, cert []byte) (CertificateRenewal, bool) {
+	for _, r := range renewals {
+		if sameCertificate(r.Previous, cert) {
+			return r, true
+		}
+	}
+	return CertificateRenewal{}, false
+}
+
+func containsCertificate(certs [][]byte, cert []byte) bool {
+	for _, c := range certs {
+		if sameCertificate(c, cert) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameCertificate compares two PEM encoded certificates regardless of the
+// formatting of their encoding.
+func sameCertificate(a, b []byte) bool {
+	blockA, _ := pem.Decode(a)
+	blockB, _ := pem.Decode(b)
+	if blockA == nil || blockB == nil {
+		return false
+	}
+	return bytes.Equal(blockA.Bytes, blockB.Bytes)
+}
+
+func isCertificatePEM(b []byte) bool {
+	block, _ := pem.Decode(b)
+	return block != nil && block.Type == "CERTIFICATE"
+}
+
+func applicationGroup(config *cb.Config) (*cb.ConfigGroup, error) {
+	application, ok := config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey]
+	if !ok {
+		return nil, errors.New("config has no application group")
+	}
+	return application, nil
+}
+
+func ordererGroup(config *cb.Config) (*cb.ConfigGroup, error) {
+	orderer, ok := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	if !ok {
+		return nil, errors.New("config has no orderer group")
+	}
+	return orderer, nil
+}
+
+func orgMSPID(org *cb.ConfigGroup) (string, error) {
+	mspConfig := &mb.MSPConfig{}
+	if err := unmarshalValue(org, channelconfig.MSPKey, mspConfig); err != nil {
+		return "", err
+	}
+	fabricConfig := &mb.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+		return "", errors.Wrap(err, "could not unmarshal organization MSP config")
+	}
+	if fabricConfig.Name == "" {
+		return "", errors.New("organization has no MSP ID")
+	}
+	return fabricConfig.Name, nil
+}
+
+// unmarshalValue unmarshals the value stored under key into msg, leaving msg
+// untouched if the group has no such value.
+func unmarshalValue(group *cb.ConfigGroup, key string, msg proto.Message) error {
+	value, ok := group.Values[key]
+	if !ok {
+		return nil
+	}
+	if err := proto.Unmarshal(value.Value, msg); err != nil {
+		return errors.Wrapf(err, "could not unmarshal %s value", key)
+	}
+	return nil
+}
+
+// setValue stores the value in the group, keeping the mod policy of any
+// value it replaces.
+func setValue(group *cb.ConfigGroup, value channelconfig.ConfigValue) {
+	modPolicy := channelconfig.AdminsPolicyKey
+	if existing, ok := group.Values[value.Key()]; ok && existing.ModPolicy != "" {
+		modPolicy = existing.ModPolicy
+	}
+	if group.Values == nil {
+		group.Values = map[string]*cb.ConfigValue{}
+	}
+	group.Values[value.Key()] = &cb.ConfigValue{
+		Value:     protoutil.MarshalOrPanic(value.Value()),
+		ModPolicy: modPolicy,
+	}
+}
+
+func updateConsenters(config *cb.Config, update func([]*etcdraft.Consenter) ([]*etcdraft.Consenter, error)) error {
+	orderer, err := ordererGroup(config)
+	if err != nil {
+		return err
+	}
+
+	consensusType := &ab.ConsensusType{}
+	if err := unmarshalValue(orderer, channelconfig.ConsensusTypeKey, consensusType); err != nil {
+		return err
+	}
+	if consensusType.Type != "etcdraft" {
+		return errors.Errorf("consensus type is '%s', consenters can only be edited for etcdraft", consensusType.Type)
+	}
+
+	metadata := &etcdraft.ConfigMetadata{}
+	if err := proto.Unmarshal(consensusType.Metadata, metadata); err != nil {
+		return errors.Wrap(err, "could not unmarshal etcdraft metadata")
+	}
+
+	metadata.Consenters, err = update(metadata.Consenters)
+	if err != nil {
+		return err
+	}
+
+	consensusType.Metadata, err = proto.Marshal(metadata)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal etcdraft metadata")
+	}
+	setValue(orderer, &consensusTypeValue{consensusType: consensusType})
+	return nil
+}
+
+func consenterIndex(consenters []*etcdraft.Consenter, host string, port uint32) int {
+	for i, c := range consenters {
+		if c.Host == host && c.Port == port {
+			return i
+		}
+	}
+	return -1
+}
+
+// consensusTypeValue carries a consensus type, including its state, which
+// channelconfig.ConsensusTypeValue does not expose.
+type consensusTypeValue struct {
+	consensusType *ab.ConsensusType
+}
+
+func (c *consensusTypeValue) Key() string {
+	return channelconfig.ConsensusTypeKey
+}
+
+func (c *consensusTypeValue) Value() proto.Message {
+	return c.consensusType
+}
+
+// ParseOrg decodes an organization definition in the JSON format printed by
+// configtxgen -printOrg.
+func ParseOrg(r io.Reader) (*cb.ConfigGroup, error) {
+	org := &ordererext.DynamicOrdererOrgGroup{ConfigGroup: &cb.ConfigGroup{}}
+	if err := protolator.DeepUnmarshalJSON(r, org); err != nil {
+		return nil, errors.Wrap(err, "could not decode organization")
+	}
+	return org.ConfigGroup, nil
+}
+
+// ParseAnchorPeer parses an anchor peer given as host:port.
+func ParseAnchorPeer(s string) (*pb.AnchorPeer, error) {
+	host, port, err := parseEndpoint(s)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "invalid anchor peer '%s'", s)
+	}
+	return &pb.AnchorPeer{Host: host, Port: int32(port)}, nil
+}
+
+// ParseACL parses a resource to policy mapping given as resource=policy.
+func ParseACL(s string) (string, string, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("invalid ACL '%s', expected resource=policy", s)
+	}
+	return parts[0], parts[1], nil
+}
+
+func parseEndpoint(s string) (string, uint32, error) {
+	host, portStr, err := net.SplitHostPort(s)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil || port == 0 {
+		return "", 0, errors.Errorf("invalid port '%s'", portStr)
+	}
+	return host, uint32(port), nil
+}
@@ -0,0 +1,379 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package edit
+
+import (
+	"bytes"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-config/protolator"
+	"github.com/hyperledger/fabric-config/protolator/protoext/ordererext"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/internal/cryptogen/ca"
+	"github.com/hyperledger/fabric/internal/cryptogen/csp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func loadProfile(t *testing.T) *genesisconfig.Profile {
+	devConfigDir := configtest.GetDevConfigDir()
+	profile := genesisconfig.Load(genesisconfig.SampleAppChannelEtcdRaftProfile, devConfigDir)
+	certPath := filepath.Join(devConfigDir, "msp", "signcerts", "peer.pem")
+	for _, c := range profile.Orderer.EtcdRaft.Consenters {
+		c.ClientTlsCert = []byte(certPath)
+		c.ServerTlsCert = []byte(certPath)
+	}
+	return profile
+}
+
+func configBlock(t *testing.T) *cb.Block {
+	return encoder.New(loadProfile(t)).GenesisBlockForChannel("testchannel")
+}
+
+func newOrg(t *testing.T, name string) *cb.ConfigGroup {
+	orgConf := *loadProfile(t).Application.Organizations[0]
+	orgConf.Name = name
+	orgConf.ID = name
+	orgConf.OrdererEndpoints = []string{"orderer.example.com:7050"}
+	org, err := encoder.NewOrdererOrgGroup(&orgConf)
+	require.NoError(t, err)
+	return org
+}
+
+// updateFromEnvelope extracts the config update from an envelope produced by
+// Edit.
+func updateFromEnvelope(t *testing.T, env *cb.Envelope) *cb.ConfigUpdate {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	require.NoError(t, err)
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	require.NoError(t, err)
+	require.Equal(t, int32(cb.HeaderType_CONFIG_UPDATE), chdr.Type)
+	require.Equal(t, "testchannel", chdr.ChannelId)
+
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	require.NoError(t, proto.Unmarshal(payload.Data, configUpdateEnv))
+	require.Empty(t, configUpdateEnv.Signatures)
+	configUpdate := &cb.ConfigUpdate{}
+	require.NoError(t, proto.Unmarshal(configUpdateEnv.ConfigUpdate, configUpdate))
+	require.Equal(t, "testchannel", configUpdate.ChannelId)
+	return configUpdate
+}
+
+func consenters(t *testing.T, group *cb.ConfigGroup) []*etcdraft.Consenter {
+	consensusType := &ab.ConsensusType{}
+	require.NoError(t, proto.Unmarshal(group.Values[channelconfig.ConsensusTypeKey].Value, consensusType))
+	metadata := &etcdraft.ConfigMetadata{}
+	require.NoError(t, proto.Unmarshal(consensusType.Metadata, metadata))
+	return metadata.Consenters
+}
+
+func TestConfigFromBlock(t *testing.T) {
+	channelID, config, err := ConfigFromBlock(configBlock(t))
+	require.NoError(t, err)
+	require.Equal(t, "testchannel", channelID)
+	require.Contains(t, config.ChannelGroup.Groups, channelconfig.ApplicationGroupKey)
+
+	_, _, err = ConfigFromBlock(&cb.Block{Data: &cb.BlockData{}})
+	require.EqualError(t, err, "could not extract envelope from block: envelope index out of bounds")
+
+	env, err := protoutil.CreateSignedEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "testchannel", nil, &cb.Envelope{}, 0, 0)
+	require.NoError(t, err)
+	_, _, err = ConfigFromBlock(&cb.Block{Data: &cb.BlockData{Data: [][]byte{protoutil.MarshalOrPanic(env)}}})
+	require.EqualError(t, err, "block is not a config block, found header type 3")
+}
+
+func TestAddRemoveApplicationOrg(t *testing.T) {
+	block := configBlock(t)
+
+	env, err := Edit(block, AddApplicationOrg(newOrg(t, "Org2MSP")))
+	require.NoError(t, err)
+	configUpdate := updateFromEnvelope(t, env)
+	written := configUpdate.WriteSet.Groups[channelconfig.ApplicationGroupKey]
+	require.Contains(t, written.Groups, "Org2MSP")
+	require.NotContains(t, written.Groups["Org2MSP"].Values, channelconfig.EndpointsKey)
+	require.Contains(t, written.Groups["Org2MSP"].Values, channelconfig.MSPKey)
+	require.Equal(t, uint64(1), written.Version)
+
+	_, err = Edit(block, AddApplicationOrg(newOrg(t, "SampleOrg")))
+	require.EqualError(t, err, "organization SampleOrg already exists in the application group")
+
+	_, err = Edit(block, AddApplicationOrg(&cb.ConfigGroup{}))
+	require.EqualError(t, err, "organization has no MSP ID")
+
+	env, err = Edit(block, RemoveApplicationOrg("SampleOrg"))
+	require.NoError(t, err)
+	configUpdate = updateFromEnvelope(t, env)
+	require.Empty(t, configUpdate.WriteSet.Groups[channelconfig.ApplicationGroupKey].Groups)
+
+	_, err = Edit(block, RemoveApplicationOrg("Org2MSP"))
+	require.EqualError(t, err, "organization Org2MSP not found in the application group")
+}
+
+func TestSetAnchorPeers(t *testing.T) {
+	block := configBlock(t)
+	anchorPeers := []*pb.AnchorPeer{{Host: "peer0.example.com", Port: 7051}}
+
+	env, err := Edit(block, SetAnchorPeers("SampleOrg", anchorPeers))
+	require.NoError(t, err)
+	configUpdate := updateFromEnvelope(t, env)
+	org := configUpdate.WriteSet.Groups[channelconfig.ApplicationGroupKey].Groups["SampleOrg"]
+	value := org.Values[channelconfig.AnchorPeersKey]
+	require.NotNil(t, value)
+	require.Equal(t, channelconfig.AdminsPolicyKey, value.ModPolicy)
+	ap := &pb.AnchorPeers{}
+	require.NoError(t, proto.Unmarshal(value.Value, ap))
+	require.True(t, proto.Equal(&pb.AnchorPeers{AnchorPeers: anchorPeers}, ap))
+
+	_, err = Edit(block, SetAnchorPeers("Org2MSP", anchorPeers))
+	require.EqualError(t, err, "organization Org2MSP not found in the application group")
+}
+
+func TestConsenters(t *testing.T) {
+	block := configBlock(t)
+	_, config, err := ConfigFromBlock(block)
+	require.NoError(t, err)
+	existing := consenters(t, config.ChannelGroup.Groups[channelconfig.OrdererGroupKey])
+	require.Len(t, existing, 3)
+
+	newConsenter := &etcdraft.Consenter{
+		Host:          "orderer2.example.com",
+		Port:          7050,
+		ClientTlsCert: []byte("client-cert"),
+		ServerTlsCert: []byte("server-cert"),
+	}
+
+	env, err := Edit(block, AddConsenter(newConsenter))
+	require.NoError(t, err)
+	written := updateFromEnvelope(t, env).WriteSet.Groups[channelconfig.OrdererGroupKey]
+	updated := consenters(t, written)
+	require.Len(t, updated, 4)
+	require.True(t, proto.Equal(newConsenter, updated[3]))
+
+	_, err = Edit(block, AddConsenter(existing[0]))
+	require.EqualError(t, err, "consenter "+existing[0].Host+":7050 already exists")
+
+	env, err = Edit(block, ReplaceConsenter(existing[0].Host, existing[0].Port, newConsenter))
+	require.NoError(t, err)
+	updated = consenters(t, updateFromEnvelope(t, env).WriteSet.Groups[channelconfig.OrdererGroupKey])
+	require.Len(t, updated, 3)
+	require.True(t, proto.Equal(newConsenter, updated[0]))
+	require.Equal(t, existing[1].Host, updated[1].Host)
+
+	env, err = Edit(block, AddConsenter(newConsenter), RemoveConsenter(existing[0].Host, existing[0].Port))
+	require.NoError(t, err)
+	updated = consenters(t, updateFromEnvelope(t, env).WriteSet.Groups[channelconfig.OrdererGroupKey])
+	require.Len(t, updated, 3)
+	require.Equal(t, existing[1].Host, updated[0].Host)
+	require.Equal(t, "orderer2.example.com", updated[2].Host)
+
+	_, err = Edit(block,
+		RemoveConsenter(existing[0].Host, existing[0].Port),
+		RemoveConsenter(existing[1].Host, existing[1].Port),
+		RemoveConsenter(existing[2].Host, existing[2].Port),
+	)
+	require.EqualError(t, err, "cannot remove "+existing[2].Host+":7050, it is the last consenter")
+
+	_, err = Edit(block, RemoveConsenter("missing.example.com", 7050))
+	require.EqualError(t, err, "consenter missing.example.com:7050 not found")
+}
+
+func TestSetBatchParameters(t *testing.T) {
+	block := configBlock(t)
+	_, config, err := ConfigFromBlock(block)
+	require.NoError(t, err)
+	original := &ab.BatchSize{}
+	require.NoError(t, proto.Unmarshal(config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.BatchSizeKey].Value, original))
+
+	env, err := Edit(block, SetBatchParameters(BatchParameters{MaxMessageCount: 42, Timeout: 5 * time.Second}))
+	require.NoError(t, err)
+	written := updateFromEnvelope(t, env).WriteSet.Groups[channelconfig.OrdererGroupKey]
+
+	batchSize := &ab.BatchSize{}
+	require.NoError(t, proto.Unmarshal(written.Values[channelconfig.BatchSizeKey].Value, batchSize))
+	require.Equal(t, uint32(42), batchSize.MaxMessageCount)
+	require.Equal(t, original.AbsoluteMaxBytes, batchSize.AbsoluteMaxBytes)
+	require.Equal(t, original.PreferredMaxBytes, batchSize.PreferredMaxBytes)
+
+	batchTimeout := &ab.BatchTimeout{}
+	require.NoError(t, proto.Unmarshal(written.Values[channelconfig.BatchTimeoutKey].Value, batchTimeout))
+	require.Equal(t, "5s", batchTimeout.Timeout)
+
+	_, err = Edit(block, SetBatchParameters(BatchParameters{PreferredMaxBytes: original.AbsoluteMaxBytes + 1}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "must not exceed absolute max bytes")
+
+	_, err = Edit(block, SetBatchParameters(BatchParameters{Timeout: -time.Second}))
+	require.EqualError(t, err, "batch timeout must be positive, got -1s")
+}
+
+func TestSetCapabilities(t *testing.T) {
+	block := configBlock(t)
+
+	env, err := Edit(block,
+		SetCapabilities(ChannelLevel, []string{"V1_4_3"}),
+		SetCapabilities(ApplicationLevel, []string{"V2_0", "V1_4_2"}),
+	)
+	require.NoError(t, err)
+	writeSet := updateFromEnvelope(t, env).WriteSet
+
+	caps := &cb.Capabilities{}
+	require.NoError(t, proto.Unmarshal(writeSet.Values[channelconfig.CapabilitiesKey].Value, caps))
+	require.Len(t, caps.Capabilities, 1)
+	require.Contains(t, caps.Capabilities, "V1_4_3")
+
+	caps = &cb.Capabilities{}
+	require.NoError(t, proto.Unmarshal(writeSet.Groups[channelconfig.ApplicationGroupKey].Values[channelconfig.CapabilitiesKey].Value, caps))
+	require.Len(t, caps.Capabilities, 2)
+	require.Contains(t, caps.Capabilities, "V1_4_2")
+
+	_, err = Edit(block, SetCapabilities("consortium", []string{"V2_0"}))
+	require.EqualError(t, err, "unknown capability level 'consortium', must be one of channel, orderer or application")
+}
+
+func TestSetACLs(t *testing.T) {
+	block := configBlock(t)
+	_, config, err := ConfigFromBlock(block)
+	require.NoError(t, err)
+	original := &pb.ACLs{}
+	require.NoError(t, proto.Unmarshal(config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey].Values[channelconfig.ACLsKey].Value, original))
+	require.NotEmpty(t, original.Acls)
+
+	env, err := Edit(block, SetACLs(map[string]string{
+		"peer/Propose":    "/Channel/Application/Admins",
+		"custom/Resource": "/Channel/Application/Readers",
+	}))
+	require.NoError(t, err)
+	written := updateFromEnvelope(t, env).WriteSet.Groups[channelconfig.ApplicationGroupKey]
+
+	acls := &pb.ACLs{}
+	require.NoError(t, proto.Unmarshal(written.Values[channelconfig.ACLsKey].Value, acls))
+	require.Equal(t, "/Channel/Application/Admins", acls.Acls["peer/Propose"].PolicyRef)
+	require.Equal(t, "/Channel/Application/Readers", acls.Acls["custom/Resource"].PolicyRef)
+	require.Len(t, acls.Acls, len(original.Acls)+1)
+}
+
+func TestRenewCertificates(t *testing.T) {
+	devConfigDir := configtest.GetDevConfigDir()
+	rootCert, err := ioutil.ReadFile(filepath.Join(devConfigDir, "msp", "cacerts", "cacert.pem"))
+	require.NoError(t, err)
+	peerCert, err := ioutil.ReadFile(filepath.Join(devConfigDir, "msp", "signcerts", "peer.pem"))
+	require.NoError(t, err)
+
+	tempDir, err := ioutil.TempDir("", "edit")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+	newCA, err := ca.NewCA(tempDir, "SampleOrg", "ca.example.com", "", "", "", "", "", "", csp.ECDSA)
+	require.NoError(t, err)
+	newRootCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: newCA.SignCert.Raw})
+	newPeerCert, err := ca.RenewCertificate(newCA.SignCert, newCA.SignCert.PublicKey, newCA.SignCert, newCA.Signer)
+	require.NoError(t, err)
+	newPeerCertPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: newPeerCert.Raw})
+
+	sampleOrgMSP := func(configUpdate *cb.ConfigUpdate, group string) *mb.FabricMSPConfig {
+		org := configUpdate.WriteSet.Groups[group].Groups["SampleOrg"]
+		mspConfig := &mb.MSPConfig{}
+		require.NoError(t, proto.Unmarshal(org.Values[channelconfig.MSPKey].Value, mspConfig))
+		fabricConfig := &mb.FabricMSPConfig{}
+		require.NoError(t, proto.Unmarshal(mspConfig.Config, fabricConfig))
+		return fabricConfig
+	}
+
+	block := configBlock(t)
+
+	// replace the root certificate
+	env, err := Edit(block, RenewCertificates([]CertificateRenewal{{Previous: rootCert, Current: newRootCert}}))
+	require.NoError(t, err)
+	configUpdate := updateFromEnvelope(t, env)
+	for _, group := range []string{channelconfig.ApplicationGroupKey, channelconfig.OrdererGroupKey} {
+		fabricConfig := sampleOrgMSP(configUpdate, group)
+		require.Equal(t, [][]byte{newRootCert}, fabricConfig.RootCerts)
+		require.Len(t, fabricConfig.OrganizationalUnitIdentifiers, 1)
+		require.Equal(t, newRootCert, fabricConfig.OrganizationalUnitIdentifiers[0].Certificate)
+	}
+
+	// keep trusting the previous root certificate
+	env, err = Edit(block, RenewCertificates([]CertificateRenewal{{Previous: rootCert, Current: newRootCert, KeepPrevious: true}}))
+	require.NoError(t, err)
+	fabricConfig := sampleOrgMSP(updateFromEnvelope(t, env), channelconfig.ApplicationGroupKey)
+	require.Len(t, fabricConfig.RootCerts, 2)
+	require.Equal(t, newRootCert, fabricConfig.RootCerts[1])
+	require.Len(t, fabricConfig.OrganizationalUnitIdentifiers, 2)
+	require.Equal(t, "COP", fabricConfig.OrganizationalUnitIdentifiers[1].OrganizationalUnitIdentifier)
+	require.Equal(t, newRootCert, fabricConfig.OrganizationalUnitIdentifiers[1].Certificate)
+
+	// renew the TLS certificates of the consenters
+	env, err = Edit(block, RenewCertificates([]CertificateRenewal{{Previous: peerCert, Current: newPeerCertPEM}}))
+	require.NoError(t, err)
+	configUpdate = updateFromEnvelope(t, env)
+	for _, c := range consenters(t, configUpdate.WriteSet.Groups[channelconfig.OrdererGroupKey]) {
+		require.Equal(t, newPeerCertPEM, c.ClientTlsCert)
+		require.Equal(t, newPeerCertPEM, c.ServerTlsCert)
+	}
+
+	// certificates not in the config are ignored
+	_, err = Edit(block, RenewCertificates([]CertificateRenewal{{Previous: newRootCert, Current: newPeerCertPEM}}))
+	require.EqualError(t, err, "could not compute config update: no differences detected between original and updated config")
+
+	_, err = Edit(block, RenewCertificates([]CertificateRenewal{{Previous: rootCert, Current: []byte("garbage")}}))
+	require.EqualError(t, err, "renewed certificates must be PEM encoded certificates")
+}
+
+func TestEditNoChanges(t *testing.T) {
+	_, err := Edit(configBlock(t))
+	require.EqualError(t, err, "could not compute config update: no differences detected between original and updated config")
+}
+
+func TestParseOrg(t *testing.T) {
+	org := newOrg(t, "Org2MSP")
+	buf := &bytes.Buffer{}
+	require.NoError(t, protolator.DeepMarshalJSON(buf, &ordererext.DynamicOrdererOrgGroup{ConfigGroup: org}))
+
+	parsed, err := ParseOrg(buf)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(org, parsed))
+
+	_, err = ParseOrg(strings.NewReader("{"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "could not decode organization")
+}
+
+func TestParseAnchorPeer(t *testing.T) {
+	ap, err := ParseAnchorPeer("peer0.example.com:7051")
+	require.NoError(t, err)
+	require.True(t, proto.Equal(&pb.AnchorPeer{Host: "peer0.example.com", Port: 7051}, ap))
+
+	_, err = ParseAnchorPeer("peer0.example.com")
+	require.EqualError(t, err, "invalid anchor peer 'peer0.example.com': address peer0.example.com: missing port in address")
+
+	_, err = ParseAnchorPeer("peer0.example.com:70510")
+	require.EqualError(t, err, "invalid anchor peer 'peer0.example.com:70510': invalid port '70510'")
+}
+
+func TestParseACL(t *testing.T) {
+	resource, policy, err := ParseACL("peer/Propose=/Channel/Application/Writers")
+	require.NoError(t, err)
+	require.Equal(t, "peer/Propose", resource)
+	require.Equal(t, "/Channel/Application/Writers", policy)
+
+	_, _, err = ParseACL("peer/Propose")
+	require.EqualError(t, err, "invalid ACL 'peer/Propose', expected resource=policy")
+}
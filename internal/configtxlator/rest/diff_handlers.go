@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/configtxlator/diff"
+)
+
+// ComputeDiff responds with the semantic changes between the config in the
+// 'original' field and either the config in the 'updated' field or the
+// config resulting from applying the config update envelope in the 'update'
+// field. The report is rendered in the format given by the 'format' field,
+// text or json, defaulting to text.
+func ComputeDiff(w http.ResponseWriter, r *http.Request) {
+	originalConfig, err := fieldConfigProto("original", r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error with field 'original': %s\n", err)
+		return
+	}
+
+	format := r.FormValue("format")
+	if format == "" {
+		format = diff.TextFormat
+	}
+
+	var updatedConfig *cb.Config
+	_, _, updatedErr := r.FormFile("updated")
+	_, _, updateErr := r.FormFile("update")
+	switch {
+	case (updatedErr == nil) == (updateErr == nil):
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Exactly one of the fields 'updated' and 'update' must be specified\n")
+		return
+	case updatedErr == nil:
+		updatedConfig, err = fieldConfigProto("updated", r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error with field 'updated': %s\n", err)
+			return
+		}
+	default:
+		updateBytes, err := fieldBytes("update", r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error with field 'update': %s\n", err)
+			return
+		}
+
+		env := &cb.Envelope{}
+		err = proto.Unmarshal(updateBytes, env)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error unmarshaling config update envelope: %s\n", err)
+			return
+		}
+
+		updatedConfig, err = diff.Apply(originalConfig, env)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error applying config update: %s\n", err)
+			return
+		}
+	}
+
+	report, err := diff.Compare(originalConfig, updatedConfig)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error computing diff: %s\n", err)
+		return
+	}
+
+	encoded, err := diff.Marshal(report, format)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "Error rendering report: %s\n", err)
+		return
+	}
+
+	if format == diff.JSONFormat {
+		w.Header().Set("Content-Type", "application/json")
+	} else {
+		w.Header().Set("Content-Type", "text/plain")
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(encoded)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/internal/configtxlator/diff"
+	"github.com/hyperledger/fabric/internal/configtxlator/edit"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func diffConfigs(t *testing.T) (original, updated, update []byte) {
+	block := &cb.Block{}
+	require.NoError(t, proto.Unmarshal(editConfigBlock(t), block))
+	_, config, err := edit.ConfigFromBlock(block)
+	require.NoError(t, err)
+
+	env, err := edit.Edit(block, edit.SetCapabilities(edit.ApplicationLevel, []string{"V2_5"}))
+	require.NoError(t, err)
+	updatedConfig, err := diff.Apply(config, env)
+	require.NoError(t, err)
+
+	return protoutil.MarshalOrPanic(config), protoutil.MarshalOrPanic(updatedConfig), protoutil.MarshalOrPanic(env)
+}
+
+func TestComputeDiff(t *testing.T) {
+	original, updated, update := diffConfigs(t)
+	expected := "/Channel/Application/Values/Capabilities: capability V2_0 removed\n" +
+		"/Channel/Application/Values/Capabilities: capability V2_5 added\n"
+
+	rec := postEdit(t, "/configtxlator/compute/diff", map[string][]byte{"original": original, "updated": updated}, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "text/plain", rec.Header().Get("Content-Type"))
+	require.Equal(t, expected, rec.Body.String())
+
+	rec = postEdit(t, "/configtxlator/compute/diff",
+		map[string][]byte{"original": original, "update": update},
+		map[string][]string{"format": {"json"}},
+	)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	report := &diff.Report{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), report))
+	require.Len(t, report.Changes, 2)
+	require.Equal(t, diff.CapabilityAdded, report.Changes[1].Type)
+}
+
+func TestComputeDiffErrors(t *testing.T) {
+	original, updated, update := diffConfigs(t)
+
+	rec := postEdit(t, "/configtxlator/compute/diff", map[string][]byte{"original": original}, nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, "Exactly one of the fields 'updated' and 'update' must be specified\n", rec.Body.String())
+
+	rec = postEdit(t, "/configtxlator/compute/diff", map[string][]byte{"original": original, "updated": updated, "update": update}, nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = postEdit(t, "/configtxlator/compute/diff", map[string][]byte{"original": []byte("garbage"), "updated": updated}, nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "Error with field 'original'")
+
+	rec = postEdit(t, "/configtxlator/compute/diff", map[string][]byte{"original": updated, "update": update}, nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "Error applying config update")
+
+	rec = postEdit(t, "/configtxlator/compute/diff",
+		map[string][]byte{"original": original, "updated": updated},
+		map[string][]string{"format": {"yaml"}},
+	)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, "Error rendering report: unknown format 'yaml'\n", rec.Body.String())
+}
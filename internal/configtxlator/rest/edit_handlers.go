@@ -0,0 +1,241 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/internal/configtxlator/edit"
+)
+
+// editHandler returns a handler which applies the operation built from the
+// request to the config block in the 'config_block' field, and responds with
+// the marshaled config update envelope.
+func editHandler(buildOperation func(r *http.Request) (edit.Operation, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		blockBytes, err := fieldBytes("config_block", r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error with field 'config_block': %s\n", err)
+			return
+		}
+
+		block := &cb.Block{}
+		err = proto.Unmarshal(blockBytes, block)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error unmarshaling config block: %s\n", err)
+			return
+		}
+
+		op, err := buildOperation(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error with request parameters: %s\n", err)
+			return
+		}
+
+		env, err := edit.Edit(block, op)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "Error editing config: %s\n", err)
+			return
+		}
+
+		encoded, err := proto.Marshal(env)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "Error marshaling config update envelope: %s\n", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		w.Write(encoded)
+	}
+}
+
+func requiredFormValue(fieldName string, r *http.Request) (string, error) {
+	value := r.FormValue(fieldName)
+	if value == "" {
+		return "", fmt.Errorf("missing field '%s'", fieldName)
+	}
+	return value, nil
+}
+
+func uint32FormValue(fieldName string, r *http.Request) (uint32, error) {
+	value := r.FormValue(fieldName)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid field '%s': %s", fieldName, err)
+	}
+	return uint32(n), nil
+}
+
+func consenterFromForm(hostField, portField string, r *http.Request) (*etcdraft.Consenter, error) {
+	host, err := requiredFormValue(hostField, r)
+	if err != nil {
+		return nil, err
+	}
+	port, err := uint32FormValue(portField, r)
+	if err != nil {
+		return nil, err
+	}
+	if port == 0 {
+		return nil, fmt.Errorf("missing field '%s'", portField)
+	}
+	clientCert, err := fieldBytes("client_tls_cert", r)
+	if err != nil {
+		return nil, fmt.Errorf("error with field 'client_tls_cert': %s", err)
+	}
+	serverCert, err := fieldBytes("server_tls_cert", r)
+	if err != nil {
+		return nil, fmt.Errorf("error with field 'server_tls_cert': %s", err)
+	}
+	return &etcdraft.Consenter{
+		Host:          host,
+		Port:          port,
+		ClientTlsCert: clientCert,
+		ServerTlsCert: serverCert,
+	}, nil
+}
+
+var AddOrg = editHandler(func(r *http.Request) (edit.Operation, error) {
+	orgBytes, err := fieldBytes("org", r)
+	if err != nil {
+		return nil, fmt.Errorf("error with field 'org': %s", err)
+	}
+	org, err := edit.ParseOrg(bytes.NewReader(orgBytes))
+	if err != nil {
+		return nil, err
+	}
+	return edit.AddApplicationOrg(org), nil
+})
+
+var RemoveOrg = editHandler(func(r *http.Request) (edit.Operation, error) {
+	mspID, err := requiredFormValue("msp_id", r)
+	if err != nil {
+		return nil, err
+	}
+	return edit.RemoveApplicationOrg(mspID), nil
+})
+
+var SetAnchorPeers = editHandler(func(r *http.Request) (edit.Operation, error) {
+	mspID, err := requiredFormValue("msp_id", r)
+	if err != nil {
+		return nil, err
+	}
+	var anchorPeers []*pb.AnchorPeer
+	for _, s := range r.Form["anchor_peer"] {
+		ap, err := edit.ParseAnchorPeer(s)
+		if err != nil {
+			return nil, err
+		}
+		anchorPeers = append(anchorPeers, ap)
+	}
+	return edit.SetAnchorPeers(mspID, anchorPeers), nil
+})
+
+var AddConsenter = editHandler(func(r *http.Request) (edit.Operation, error) {
+	consenter, err := consenterFromForm("host", "port", r)
+	if err != nil {
+		return nil, err
+	}
+	return edit.AddConsenter(consenter), nil
+})
+
+var RemoveConsenter = editHandler(func(r *http.Request) (edit.Operation, error) {
+	host, err := requiredFormValue("host", r)
+	if err != nil {
+		return nil, err
+	}
+	port, err := uint32FormValue("port", r)
+	if err != nil {
+		return nil, err
+	}
+	return edit.RemoveConsenter(host, port), nil
+})
+
+var ReplaceConsenter = editHandler(func(r *http.Request) (edit.Operation, error) {
+	host, err := requiredFormValue("host", r)
+	if err != nil {
+		return nil, err
+	}
+	port, err := uint32FormValue("port", r)
+	if err != nil {
+		return nil, err
+	}
+	if r.FormValue("new_host") == "" {
+		r.Form.Set("new_host", host)
+	}
+	if r.FormValue("new_port") == "" {
+		r.Form.Set("new_port", strconv.FormatUint(uint64(port), 10))
+	}
+	consenter, err := consenterFromForm("new_host", "new_port", r)
+	if err != nil {
+		return nil, err
+	}
+	return edit.ReplaceConsenter(host, port, consenter), nil
+})
+
+var SetBatchParams = editHandler(func(r *http.Request) (edit.Operation, error) {
+	var params edit.BatchParameters
+	var err error
+	if params.MaxMessageCount, err = uint32FormValue("max_message_count", r); err != nil {
+		return nil, err
+	}
+	if params.AbsoluteMaxBytes, err = uint32FormValue("absolute_max_bytes", r); err != nil {
+		return nil, err
+	}
+	if params.PreferredMaxBytes, err = uint32FormValue("preferred_max_bytes", r); err != nil {
+		return nil, err
+	}
+	if timeout := r.FormValue("timeout"); timeout != "" {
+		if params.Timeout, err = time.ParseDuration(timeout); err != nil {
+			return nil, fmt.Errorf("invalid field 'timeout': %s", err)
+		}
+	}
+	return edit.SetBatchParameters(params), nil
+})
+
+var SetCapabilities = editHandler(func(r *http.Request) (edit.Operation, error) {
+	level, err := requiredFormValue("level", r)
+	if err != nil {
+		return nil, err
+	}
+	capabilities := r.Form["capability"]
+	if len(capabilities) == 0 {
+		return nil, fmt.Errorf("missing field 'capability'")
+	}
+	return edit.SetCapabilities(level, capabilities), nil
+})
+
+var SetACLs = editHandler(func(r *http.Request) (edit.Operation, error) {
+	acls := map[string]string{}
+	for _, s := range r.Form["acl"] {
+		resource, policy, err := edit.ParseACL(s)
+		if err != nil {
+			return nil, err
+		}
+		acls[resource] = policy
+	}
+	if len(acls) == 0 {
+		return nil, fmt.Errorf("missing field 'acl'")
+	}
+	return edit.SetACLs(acls), nil
+})
@@ -0,0 +1,213 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rest
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+func editConfigBlock(t *testing.T) []byte {
+	devConfigDir := configtest.GetDevConfigDir()
+	profile := genesisconfig.Load(genesisconfig.SampleAppChannelEtcdRaftProfile, devConfigDir)
+	certPath := filepath.Join(devConfigDir, "msp", "signcerts", "peer.pem")
+	for _, c := range profile.Orderer.EtcdRaft.Consenters {
+		c.ClientTlsCert = []byte(certPath)
+		c.ServerTlsCert = []byte(certPath)
+	}
+	return protoutil.MarshalOrPanic(encoder.New(profile).GenesisBlockForChannel("testchannel"))
+}
+
+func postEdit(t *testing.T, path string, files map[string][]byte, values map[string][]string) *httptest.ResponseRecorder {
+	buffer := &bytes.Buffer{}
+	mpw := multipart.NewWriter(buffer)
+
+	for name, contents := range files {
+		ffw, err := mpw.CreateFormFile(name, name)
+		require.NoError(t, err)
+		_, err = bytes.NewReader(contents).WriteTo(ffw)
+		require.NoError(t, err)
+	}
+	for name, vals := range values {
+		for _, v := range vals {
+			require.NoError(t, mpw.WriteField(name, v))
+		}
+	}
+	require.NoError(t, mpw.Close())
+
+	req, err := http.NewRequest("POST", path, buffer)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", mpw.FormDataContentType())
+
+	rec := httptest.NewRecorder()
+	NewRouter().ServeHTTP(rec, req)
+	return rec
+}
+
+func configUpdateFromResponse(t *testing.T, rec *httptest.ResponseRecorder) *cb.ConfigUpdate {
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	env := &cb.Envelope{}
+	require.NoError(t, proto.Unmarshal(rec.Body.Bytes(), env))
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	require.NoError(t, err)
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	require.NoError(t, proto.Unmarshal(payload.Data, configUpdateEnv))
+	configUpdate := &cb.ConfigUpdate{}
+	require.NoError(t, proto.Unmarshal(configUpdateEnv.ConfigUpdate, configUpdate))
+	require.Equal(t, "testchannel", configUpdate.ChannelId)
+	return configUpdate
+}
+
+func TestEditSetAnchorPeers(t *testing.T) {
+	rec := postEdit(t, "/configtxlator/edit/set-anchor-peers",
+		map[string][]byte{"config_block": editConfigBlock(t)},
+		map[string][]string{
+			"msp_id":      {"SampleOrg"},
+			"anchor_peer": {"peer0.example.com:7051", "peer1.example.com:7051"},
+		},
+	)
+
+	configUpdate := configUpdateFromResponse(t, rec)
+	require.Contains(t, configUpdate.WriteSet.Groups["Application"].Groups["SampleOrg"].Values, "AnchorPeers")
+}
+
+func TestEditSetBatchParams(t *testing.T) {
+	rec := postEdit(t, "/configtxlator/edit/set-batch-params",
+		map[string][]byte{"config_block": editConfigBlock(t)},
+		map[string][]string{
+			"max_message_count": {"42"},
+			"timeout":           {"5s"},
+		},
+	)
+
+	configUpdate := configUpdateFromResponse(t, rec)
+	values := configUpdate.WriteSet.Groups["Orderer"].Values
+	require.Contains(t, values, "BatchSize")
+	require.Contains(t, values, "BatchTimeout")
+}
+
+func TestEditReplaceConsenter(t *testing.T) {
+	rec := postEdit(t, "/configtxlator/edit/replace-consenter",
+		map[string][]byte{
+			"config_block":    editConfigBlock(t),
+			"client_tls_cert": []byte("client-cert"),
+			"server_tls_cert": []byte("server-cert"),
+		},
+		map[string][]string{
+			"host": {"raft0.example.com"},
+			"port": {"7050"},
+		},
+	)
+
+	configUpdate := configUpdateFromResponse(t, rec)
+	require.Contains(t, configUpdate.WriteSet.Groups["Orderer"].Values, "ConsensusType")
+}
+
+func TestEditSetACLs(t *testing.T) {
+	rec := postEdit(t, "/configtxlator/edit/set-acls",
+		map[string][]byte{"config_block": editConfigBlock(t)},
+		map[string][]string{
+			"acl": {"peer/Propose=/Channel/Application/Admins"},
+		},
+	)
+
+	configUpdate := configUpdateFromResponse(t, rec)
+	require.Contains(t, configUpdate.WriteSet.Groups["Application"].Values, "ACLs")
+}
+
+func TestEditBadRequests(t *testing.T) {
+	block := editConfigBlock(t)
+
+	tests := []struct {
+		name        string
+		path        string
+		files       map[string][]byte
+		values      map[string][]string
+		expectedErr string
+	}{
+		{
+			name:        "missing config block",
+			path:        "/configtxlator/edit/remove-org",
+			values:      map[string][]string{"msp_id": {"SampleOrg"}},
+			expectedErr: "Error with field 'config_block': http: no such file\n",
+		},
+		{
+			name:        "bad config block",
+			path:        "/configtxlator/edit/remove-org",
+			files:       map[string][]byte{"config_block": []byte("garbage")},
+			values:      map[string][]string{"msp_id": {"SampleOrg"}},
+			expectedErr: "Error unmarshaling config block",
+		},
+		{
+			name:        "missing msp id",
+			path:        "/configtxlator/edit/remove-org",
+			files:       map[string][]byte{"config_block": block},
+			expectedErr: "Error with request parameters: missing field 'msp_id'\n",
+		},
+		{
+			name:        "unknown org",
+			path:        "/configtxlator/edit/remove-org",
+			files:       map[string][]byte{"config_block": block},
+			values:      map[string][]string{"msp_id": {"Org2MSP"}},
+			expectedErr: "Error editing config: organization Org2MSP not found in the application group\n",
+		},
+		{
+			name:        "missing org",
+			path:        "/configtxlator/edit/add-org",
+			files:       map[string][]byte{"config_block": block},
+			expectedErr: "Error with request parameters: error with field 'org': http: no such file\n",
+		},
+		{
+			name:        "bad port",
+			path:        "/configtxlator/edit/remove-consenter",
+			files:       map[string][]byte{"config_block": block},
+			values:      map[string][]string{"host": {"raft0.example.com"}, "port": {"port"}},
+			expectedErr: "Error with request parameters: invalid field 'port'",
+		},
+		{
+			name:        "bad timeout",
+			path:        "/configtxlator/edit/set-batch-params",
+			files:       map[string][]byte{"config_block": block},
+			values:      map[string][]string{"timeout": {"soon"}},
+			expectedErr: "Error with request parameters: invalid field 'timeout'",
+		},
+		{
+			name:        "bad capability level",
+			path:        "/configtxlator/edit/set-capabilities",
+			files:       map[string][]byte{"config_block": block},
+			values:      map[string][]string{"level": {"consortium"}, "capability": {"V2_0"}},
+			expectedErr: "Error editing config: unknown capability level 'consortium'",
+		},
+		{
+			name:        "missing acl",
+			path:        "/configtxlator/edit/set-acls",
+			files:       map[string][]byte{"config_block": block},
+			expectedErr: "Error with request parameters: missing field 'acl'\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := postEdit(t, tt.path, tt.files, tt.values)
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			require.Contains(t, rec.Body.String(), tt.expectedErr)
+		})
+	}
+}
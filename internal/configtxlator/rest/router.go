@@ -22,6 +22,37 @@ func NewRouter() *mux.Router {
 	router.
 		HandleFunc("/configtxlator/compute/update-from-configs", ComputeUpdateFromConfigs).
 		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/compute/diff", ComputeDiff).
+		Methods("POST")
+
+	router.
+		HandleFunc("/configtxlator/edit/add-org", AddOrg).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/edit/remove-org", RemoveOrg).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/edit/set-anchor-peers", SetAnchorPeers).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/edit/add-consenter", AddConsenter).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/edit/remove-consenter", RemoveConsenter).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/edit/replace-consenter", ReplaceConsenter).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/edit/set-batch-params", SetBatchParams).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/edit/set-capabilities", SetCapabilities).
+		Methods("POST")
+	router.
+		HandleFunc("/configtxlator/edit/set-acls", SetACLs).
+		Methods("POST")
 
 	return router
 }
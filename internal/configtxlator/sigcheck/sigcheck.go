@@ -0,0 +1,274 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sigcheck
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	mb "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/policies/inquire"
+	"github.com/hyperledger/fabric/internal/configtxlator/edit"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+)
+
+// Report describes whether the signatures collected on a config update
+// satisfy the policies governing the config it modifies.
+type Report struct {
+	ChannelID string     `json:"channel_id"`
+	Satisfied bool       `json:"satisfied"`
+	Signers   []*Signer  `json:"signers"`
+	Elements  []*Element `json:"elements"`
+}
+
+// Signer describes an identity which signed the config update.
+type Signer struct {
+	MSPID   string `json:"mspid"`
+	Subject string `json:"subject,omitempty"`
+	// Error explains why the signature cannot count towards any policy.
+	Error string `json:"error,omitempty"`
+}
+
+// Element describes the policy evaluation for a config element modified by
+// the config update.
+type Element struct {
+	Key       string `json:"key"`
+	ModPolicy string `json:"mod_policy"`
+	Satisfied bool   `json:"satisfied"`
+	Error     string `json:"error,omitempty"`
+	// Counted lists the signers satisfying principals of the policy.
+	Counted []string `json:"counted,omitempty"`
+	// Missing lists the principals, as MSPID.role or MSPID.OU, which still
+	// need to sign for the smallest set of additional signatures that would
+	// satisfy the policy.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// Check evaluates the signatures of the config update in the envelope
+// against the mod_policy of every element it modifies in the config
+// contained in the config block.
+func Check(block *cb.Block, env *cb.Envelope, cryptoProvider bccsp.BCCSP) (*Report, error) {
+	channelID, config, err := edit.ConfigFromBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := channelconfig.NewBundle(channelID, config, cryptoProvider)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not create channel config bundle")
+	}
+
+	validator, err := configtx.NewValidatorImpl(channelID, config, channelconfig.RootGroupKey, bundle.PolicyManager())
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not create config validator")
+	}
+
+	configUpdateEnv, err := protoutil.EnvelopeToConfigUpdate(env)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not extract config update")
+	}
+
+	elements, err := validator.ModifiedElements(configUpdateEnv)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid config update")
+	}
+
+	signedData, err := protoutil.ConfigUpdateEnvelopeAsSignedData(configUpdateEnv)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not extract signatures")
+	}
+
+	report := &Report{
+		ChannelID: channelID,
+		Satisfied: true,
+	}
+
+	signers, identities := evaluateSigners(signedData, bundle.MSPManager())
+	report.Signers = signers
+
+	for _, element := range elements {
+		e := &Element{
+			Key:       element.Key,
+			ModPolicy: element.ModPolicy,
+			Satisfied: true,
+		}
+		if err := element.Policy.EvaluateSignedData(signedData); err != nil {
+			e.Satisfied = false
+			e.Error = err.Error()
+			report.Satisfied = false
+		}
+
+		counted, missing, err := analyzePolicy(element.Policy, identities)
+		switch {
+		case err != nil && !e.Satisfied:
+			e.Error = fmt.Sprintf("%s; the missing principals could not be determined: %s", e.Error, err)
+		case !e.Satisfied:
+			e.Missing = missing
+		}
+		e.Counted = counted
+		report.Elements = append(report.Elements, e)
+	}
+
+	return report, nil
+}
+
+// signerIdentity is a valid identity which signed the config update.
+type signerIdentity struct {
+	msp.Identity
+	description string
+}
+
+// evaluateSigners describes every signer and returns the identities whose
+// signatures are valid, deduplicated the same way policy evaluation does.
+func evaluateSigners(signedData []*protoutil.SignedData, deserializer msp.IdentityDeserializer) ([]*Signer, []*signerIdentity) {
+	signers := []*Signer{}
+	var identities []*signerIdentity
+	seen := map[string]struct{}{}
+
+	for _, sd := range signedData {
+		signer := describeSerializedIdentity(sd.Identity)
+		signers = append(signers, signer)
+
+		identity, err := deserializer.DeserializeIdentity(sd.Identity)
+		if err != nil {
+			signer.Error = fmt.Sprintf("could not deserialize identity: %s", err)
+			continue
+		}
+
+		key := identity.GetIdentifier().Mspid + identity.GetIdentifier().Id
+		if _, ok := seen[key]; ok {
+			signer.Error = "duplicate signature"
+			continue
+		}
+
+		if err := identity.Validate(); err != nil {
+			signer.Error = fmt.Sprintf("invalid identity: %s", err)
+			continue
+		}
+
+		if err := identity.Verify(sd.Data, sd.Signature); err != nil {
+			signer.Error = fmt.Sprintf("invalid signature: %s", err)
+			continue
+		}
+
+		seen[key] = struct{}{}
+		identities = append(identities, &signerIdentity{
+			Identity:    identity,
+			description: signer.String(),
+		})
+	}
+
+	return signers, identities
+}
+
+func (s *Signer) String() string {
+	if s.Subject == "" {
+		return s.MSPID
+	}
+	return fmt.Sprintf("%s (%s)", s.MSPID, s.Subject)
+}
+
+func describeSerializedIdentity(serializedIdentity []byte) *Signer {
+	sID := &mb.SerializedIdentity{}
+	if err := proto.Unmarshal(serializedIdentity, sID); err != nil {
+		return &Signer{Error: fmt.Sprintf("could not unmarshal identity: %s", err)}
+	}
+
+	signer := &Signer{MSPID: sID.Mspid}
+	if block, _ := pem.Decode(sID.IdBytes); block != nil {
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			signer.Subject = cert.Subject.String()
+		}
+	}
+	return signer
+}
+
+// analyzePolicy determines which signers count towards the policy and which
+// principals are missing. It relies on the policy being convertible to a
+// signature policy, which is the case for signature policies and for
+// implicit meta policies built on top of them.
+func analyzePolicy(policy policies.Policy, identities []*signerIdentity) (counted, missing []string, err error) {
+	converter, ok := policy.(policies.Converter)
+	if !ok {
+		return nil, nil, errors.Errorf("policy of type %T is not convertible to a signature policy", policy)
+	}
+	spe, err := converter.Convert()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	principalSets := inquire.NewInquireableSignaturePolicy(spe).SatisfiedBy()
+	if len(principalSets) == 0 {
+		return nil, nil, errors.New("policy cannot be satisfied by any set of principals")
+	}
+
+	for i, principalSet := range principalSets {
+		c, m := matchPrincipals(principalSet, identities)
+		if i == 0 || len(m) < len(missing) {
+			counted, missing = c, m
+		}
+		if len(missing) == 0 {
+			break
+		}
+	}
+	return counted, missing, nil
+}
+
+// matchPrincipals assigns each principal of the set to a distinct identity
+// satisfying it, maximizing the number of principals satisfied, and returns
+// the signers used as well as the unsatisfied principals.
+func matchPrincipals(principalSet policies.PrincipalSet, identities []*signerIdentity) (counted, missing []string) {
+	satisfies := make([][]bool, len(principalSet))
+	for i, principal := range principalSet {
+		satisfies[i] = make([]bool, len(identities))
+		for j, identity := range identities {
+			satisfies[i][j] = identity.SatisfiesPrincipal(principal) == nil
+		}
+	}
+
+	// identityOwner[j] is the principal assigned to identity j, or -1
+	identityOwner := make([]int, len(identities))
+	for j := range identityOwner {
+		identityOwner[j] = -1
+	}
+
+	var assign func(i int, visited []bool) bool
+	assign = func(i int, visited []bool) bool {
+		for j := range identities {
+			if !satisfies[i][j] || visited[j] {
+				continue
+			}
+			visited[j] = true
+			if identityOwner[j] == -1 || assign(identityOwner[j], visited) {
+				identityOwner[j] = i
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, principal := range principalSet {
+		if !assign(i, make([]bool, len(identities))) {
+			missing = append(missing, policies.PrincipalString(principal))
+		}
+	}
+	for j, owner := range identityOwner {
+		if owner != -1 {
+			counted = append(counted, identities[j].description)
+		}
+	}
+	return counted, missing
+}
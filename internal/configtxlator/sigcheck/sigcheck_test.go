@@ -0,0 +1,177 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sigcheck
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/internal/configtxgen/encoder"
+	"github.com/hyperledger/fabric/internal/configtxgen/genesisconfig"
+	"github.com/hyperledger/fabric/internal/configtxlator/edit"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/stretchr/testify/require"
+)
+
+// configBlock returns a config block for a channel with two application
+// organizations: SampleOrg, administered by any of its members, and
+// Org2MSP, administered by its admins.
+func configBlock(t *testing.T) *cb.Block {
+	devConfigDir := configtest.GetDevConfigDir()
+	profile := genesisconfig.Load(genesisconfig.SampleAppChannelEtcdRaftProfile, devConfigDir)
+	certPath := filepath.Join(devConfigDir, "msp", "signcerts", "peer.pem")
+	for _, c := range profile.Orderer.EtcdRaft.Consenters {
+		c.ClientTlsCert = []byte(certPath)
+		c.ServerTlsCert = []byte(certPath)
+	}
+
+	org2 := *profile.Application.Organizations[0]
+	org2.Name = "Org2MSP"
+	org2.ID = "Org2MSP"
+	org2.Policies = map[string]*genesisconfig.Policy{
+		"Readers": {Type: "Signature", Rule: "OR('Org2MSP.member')"},
+		"Writers": {Type: "Signature", Rule: "OR('Org2MSP.member')"},
+		"Admins":  {Type: "Signature", Rule: "OR('Org2MSP.admin')"},
+	}
+	profile.Application.Organizations = append(profile.Application.Organizations, &org2)
+
+	return encoder.New(profile).GenesisBlockForChannel("testchannel")
+}
+
+func newSigner(t *testing.T) msp.SigningIdentity {
+	mspDir := configtest.GetDevMspDir()
+	ks, err := sw.NewFileBasedKeyStore(nil, filepath.Join(mspDir, "keystore"), true)
+	require.NoError(t, err)
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(ks)
+	require.NoError(t, err)
+	conf, err := msp.GetLocalMspConfig(mspDir, nil, "SampleOrg")
+	require.NoError(t, err)
+	localMSP, err := msp.New(&msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_0}}, cryptoProvider)
+	require.NoError(t, err)
+	require.NoError(t, localMSP.Setup(conf))
+	signer, err := localMSP.GetDefaultSigningIdentity()
+	require.NoError(t, err)
+	return signer
+}
+
+// sign adds a signature by the signer to the config update in the envelope.
+func sign(t *testing.T, env *cb.Envelope, signer msp.SigningIdentity) *cb.Envelope {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	require.NoError(t, err)
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	require.NoError(t, proto.Unmarshal(payload.Data, configUpdateEnv))
+
+	sigHeader, err := protoutil.NewSignatureHeader(signer)
+	require.NoError(t, err)
+	configSig := &cb.ConfigSignature{SignatureHeader: protoutil.MarshalOrPanic(sigHeader)}
+	configSig.Signature, err = signer.Sign(util.ConcatenateBytes(configSig.SignatureHeader, configUpdateEnv.ConfigUpdate))
+	require.NoError(t, err)
+	configUpdateEnv.Signatures = append(configUpdateEnv.Signatures, configSig)
+
+	payload.Data = protoutil.MarshalOrPanic(configUpdateEnv)
+	return &cb.Envelope{Payload: protoutil.MarshalOrPanic(payload)}
+}
+
+func TestCheck(t *testing.T) {
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	block := configBlock(t)
+	signer := newSigner(t)
+
+	aclUpdate, err := edit.Edit(block, edit.SetACLs(map[string]string{"peer/Propose": "/Channel/Application/Admins"}))
+	require.NoError(t, err)
+
+	t.Run("Unsigned", func(t *testing.T) {
+		report, err := Check(block, aclUpdate, cryptoProvider)
+		require.NoError(t, err)
+		require.Equal(t, "testchannel", report.ChannelID)
+		require.False(t, report.Satisfied)
+		require.Empty(t, report.Signers)
+		require.Len(t, report.Elements, 1)
+
+		element := report.Elements[0]
+		require.Equal(t, "[Value]  /Channel/Application/ACLs", element.Key)
+		require.Equal(t, "/Channel/Application/Admins", element.ModPolicy)
+		require.False(t, element.Satisfied)
+		require.Contains(t, element.Error, "implicit policy evaluation failed - 0 sub-policies were satisfied, but this policy requires 2 of the 'Admins' sub-policies to be satisfied")
+		require.Empty(t, element.Counted)
+		require.ElementsMatch(t, []string{"SampleOrg.member", "Org2MSP.admin"}, element.Missing)
+	})
+
+	t.Run("Partially signed", func(t *testing.T) {
+		report, err := Check(block, sign(t, aclUpdate, signer), cryptoProvider)
+		require.NoError(t, err)
+		require.False(t, report.Satisfied)
+		require.Len(t, report.Signers, 1)
+		require.Equal(t, "SampleOrg", report.Signers[0].MSPID)
+		require.NotEmpty(t, report.Signers[0].Subject)
+		require.Empty(t, report.Signers[0].Error)
+
+		element := report.Elements[0]
+		require.False(t, element.Satisfied)
+		require.Equal(t, []string{report.Signers[0].String()}, element.Counted)
+		require.Equal(t, []string{"Org2MSP.admin"}, element.Missing)
+	})
+
+	t.Run("Satisfied", func(t *testing.T) {
+		anchorPeersUpdate, err := edit.Edit(block, edit.SetAnchorPeers("SampleOrg", []*pb.AnchorPeer{{Host: "peer0", Port: 7051}}))
+		require.NoError(t, err)
+
+		report, err := Check(block, sign(t, anchorPeersUpdate, signer), cryptoProvider)
+		require.NoError(t, err)
+		require.True(t, report.Satisfied)
+		require.Len(t, report.Elements, 1)
+
+		element := report.Elements[0]
+		require.Equal(t, "[Value]  /Channel/Application/SampleOrg/AnchorPeers", element.Key)
+		require.Equal(t, "/Channel/Application/SampleOrg/Admins", element.ModPolicy)
+		require.True(t, element.Satisfied)
+		require.Empty(t, element.Error)
+		require.Len(t, element.Counted, 1)
+		require.Empty(t, element.Missing)
+	})
+
+	t.Run("Bad and duplicate signatures", func(t *testing.T) {
+		env := sign(t, sign(t, aclUpdate, signer), signer)
+		payload, err := protoutil.UnmarshalPayload(env.Payload)
+		require.NoError(t, err)
+		configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+		require.NoError(t, proto.Unmarshal(payload.Data, configUpdateEnv))
+		configUpdateEnv.Signatures[0].Signature = []byte("garbage")
+		payload.Data = protoutil.MarshalOrPanic(configUpdateEnv)
+		env.Payload = protoutil.MarshalOrPanic(payload)
+
+		report, err := Check(block, env, cryptoProvider)
+		require.NoError(t, err)
+		require.Len(t, report.Signers, 2)
+		require.Contains(t, report.Signers[0].Error, "invalid signature")
+		require.Empty(t, report.Signers[1].Error)
+		require.Equal(t, []string{"Org2MSP.admin"}, report.Elements[0].Missing)
+
+		env = sign(t, sign(t, aclUpdate, signer), signer)
+		report, err = Check(block, env, cryptoProvider)
+		require.NoError(t, err)
+		require.Empty(t, report.Signers[0].Error)
+		require.Equal(t, "duplicate signature", report.Signers[1].Error)
+	})
+
+	t.Run("Wrong channel", func(t *testing.T) {
+		env, err := protoutil.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, "otherchannel", nil, &cb.ConfigUpdateEnvelope{
+			ConfigUpdate: protoutil.MarshalOrPanic(&cb.ConfigUpdate{ChannelId: "otherchannel"}),
+		}, 0, 0)
+		require.NoError(t, err)
+		_, err = Check(block, env, cryptoProvider)
+		require.EqualError(t, err, "invalid config update: ConfigUpdate for channel 'otherchannel' but envelope for channel 'testchannel'")
+	})
+}
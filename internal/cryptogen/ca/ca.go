@@ -8,6 +8,7 @@ package ca
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/sha256"
@@ -39,7 +40,8 @@ type CA struct {
 }
 
 // NewCA creates an instance of CA and saves the signing key pair in
-// baseDir/name
+// baseDir/name. The key pair of the CA is of the given key algorithm,
+// which is either csp.ECDSA or csp.ED25519.
 func NewCA(
 	baseDir,
 	org,
@@ -49,7 +51,8 @@ func NewCA(
 	locality,
 	orgUnit,
 	streetAddress,
-	postalCode string,
+	postalCode,
+	keyAlg string,
 ) (*CA, error) {
 
 	var ca *CA
@@ -59,7 +62,7 @@ func NewCA(
 		return nil, err
 	}
 
-	priv, err := csp.GeneratePrivateKey(baseDir)
+	signer, err := csp.GenerateSigner(baseDir, keyAlg)
 	if err != nil {
 		return nil, err
 	}
@@ -81,24 +84,22 @@ func NewCA(
 	subject.CommonName = name
 
 	template.Subject = subject
-	template.SubjectKeyId = computeSKI(priv)
+	template.SubjectKeyId = computeSKI(signer.Public())
 
-	x509Cert, err := genCertificateECDSA(
+	x509Cert, err := genCertificate(
 		baseDir,
 		name,
 		&template,
 		&template,
-		&priv.PublicKey,
-		priv,
+		signer.Public(),
+		signer,
 	)
 	if err != nil {
 		return nil, err
 	}
 	ca = &CA{
-		Name: name,
-		Signer: &csp.ECDSASigner{
-			PrivateKey: priv,
-		},
+		Name:               name,
+		Signer:             signer,
 		SignCert:           x509Cert,
 		Country:            country,
 		Province:           province,
@@ -118,7 +119,7 @@ func (ca *CA) SignCertificate(
 	name string,
 	orgUnits,
 	alternateNames []string,
-	pub *ecdsa.PublicKey,
+	pub crypto.PublicKey,
 	ku x509.KeyUsage,
 	eku []x509.ExtKeyUsage,
 ) (*x509.Certificate, error) {
@@ -151,7 +152,7 @@ func (ca *CA) SignCertificate(
 		}
 	}
 
-	cert, err := genCertificateECDSA(
+	cert, err := genCertificate(
 		baseDir,
 		name,
 		&template,
@@ -167,10 +168,59 @@ func (ca *CA) SignCertificate(
 	return cert, nil
 }
 
+// RenewCertificate creates a certificate for the given public key with the
+// subject, subject alternative names, key usages and basic constraints of
+// the given certificate, but with a new serial number and validity period.
+// A CA certificate keeps its subject key identifier unless its key changes.
+// The certificate is issued by parent using signer, or is self-signed using
+// signer if parent is nil.
+func RenewCertificate(
+	cert *x509.Certificate,
+	pub crypto.PublicKey,
+	parent *x509.Certificate,
+	signer crypto.Signer,
+) (*x509.Certificate, error) {
+
+	template := x509Template()
+	template.RawSubject = cert.RawSubject
+	template.Subject = cert.Subject
+	template.KeyUsage = cert.KeyUsage
+	template.ExtKeyUsage = cert.ExtKeyUsage
+	template.UnknownExtKeyUsage = cert.UnknownExtKeyUsage
+	template.DNSNames = cert.DNSNames
+	template.EmailAddresses = cert.EmailAddresses
+	template.IPAddresses = cert.IPAddresses
+	template.URIs = cert.URIs
+	template.IsCA = cert.IsCA
+	template.MaxPathLen = cert.MaxPathLen
+	template.MaxPathLenZero = cert.MaxPathLenZero
+	if cert.IsCA {
+		template.SubjectKeyId = computeSKI(pub)
+		if k, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool }); ok && k.Equal(pub) && len(cert.SubjectKeyId) != 0 {
+			template.SubjectKeyId = cert.SubjectKeyId
+		}
+	}
+	if parent == nil {
+		parent = &template
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, &template, parent, pub, signer)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(certBytes)
+}
+
 // compute Subject Key Identifier
-func computeSKI(privKey *ecdsa.PrivateKey) []byte {
-	// Marshall the public key
-	raw := elliptic.Marshal(privKey.Curve, privKey.PublicKey.X, privKey.PublicKey.Y)
+func computeSKI(pub crypto.PublicKey) []byte {
+	var raw []byte
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		// Marshall the public key
+		raw = elliptic.Marshal(k.Curve, k.X, k.Y)
+	case ed25519.PublicKey:
+		raw = k
+	}
 
 	// Hash it
 	hash := sha256.Sum256(raw)
@@ -241,13 +291,13 @@ func x509Template() x509.Certificate {
 
 }
 
-// generate a signed X509 certificate using ECDSA
-func genCertificateECDSA(
+// generate a signed X509 certificate
+func genCertificate(
 	baseDir,
 	name string,
 	template,
 	parent *x509.Certificate,
-	pub *ecdsa.PublicKey,
+	pub crypto.PublicKey,
 	priv interface{},
 ) (*x509.Certificate, error) {
 
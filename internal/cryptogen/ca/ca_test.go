@@ -7,6 +7,7 @@ package ca_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/x509"
 	"io/ioutil"
 	"net"
@@ -62,6 +63,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSA,
 	)
 	require.NoError(t, err, "Error generating CA")
 
@@ -135,6 +137,7 @@ func TestNewCA(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSA,
 	)
 	require.NoError(t, err, "Error generating CA")
 	require.NotNil(t, rootCA, "Failed to return CA")
@@ -162,6 +165,51 @@ func TestNewCA(t *testing.T) {
 	require.Equal(t, testPostalCode, rootCA.SignCert.Subject.PostalCode[0], "Failed to match postalCode")
 }
 
+func TestNewCAED25519(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "ca-test")
+	if err != nil {
+		t.Fatalf("Failed to create test directory: %s", err)
+	}
+	defer os.RemoveAll(testDir)
+
+	caDir := filepath.Join(testDir, "ca")
+	rootCA, err := ca.NewCA(
+		caDir,
+		testCAName,
+		testCAName,
+		testCountry,
+		testProvince,
+		testLocality,
+		testOrganizationalUnit,
+		testStreetAddress,
+		testPostalCode,
+		csp.ED25519,
+	)
+	require.NoError(t, err, "Error generating CA")
+	require.Equal(t, x509.PureEd25519, rootCA.SignCert.SignatureAlgorithm)
+	require.IsType(t, ed25519.PublicKey{}, rootCA.SignCert.PublicKey)
+
+	// an Ed25519 CA can sign certificates for both ECDSA and Ed25519 keys
+	certDir, err := ioutil.TempDir(testDir, "certs")
+	if err != nil {
+		t.Fatalf("Failed to create certs directory: %s", err)
+	}
+	for _, keyAlg := range []string{csp.ECDSA, csp.ED25519} {
+		signer, err := csp.GenerateSigner(certDir, keyAlg)
+		require.NoError(t, err, "Failed to generate signer")
+
+		cert, err := rootCA.SignCertificate(certDir, testName, nil, nil, signer.Public(),
+			x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+		require.NoError(t, err, "Failed to generate signed certificate")
+		require.Equal(t, signer.Public(), cert.PublicKey)
+		require.NoError(t, cert.CheckSignatureFrom(rootCA.SignCert))
+	}
+
+	_, err = ca.NewCA(filepath.Join(testDir, "ca2"), testCA2Name, testCA2Name, testCountry, testProvince,
+		testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "rsa")
+	require.EqualError(t, err, "unsupported key algorithm: rsa")
+}
+
 func TestGenerateSignCertificate(t *testing.T) {
 	testDir, err := ioutil.TempDir("", "ca-test")
 	if err != nil {
@@ -189,6 +237,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 		testOrganizationalUnit,
 		testStreetAddress,
 		testPostalCode,
+		csp.ECDSA,
 	)
 	require.NoError(t, err, "Error generating CA")
 
@@ -257,6 +306,50 @@ func TestGenerateSignCertificate(t *testing.T) {
 
 }
 
+func TestRenewCertificate(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "ca-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(testDir)
+
+	rootCA, err := ca.NewCA(filepath.Join(testDir, "ca"), testCAName, testCAName, testCountry, testProvince,
+		testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ECDSA)
+	require.NoError(t, err)
+
+	certDir := filepath.Join(testDir, "certs")
+	require.NoError(t, os.MkdirAll(certDir, 0755))
+	priv, err := csp.GeneratePrivateKey(certDir)
+	require.NoError(t, err)
+	cert, err := rootCA.SignCertificate(certDir, testName, []string{"peer"}, []string{testName2, testIP},
+		&priv.PublicKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth})
+	require.NoError(t, err)
+
+	// renew the certificate keeping its key
+	renewed, err := ca.RenewCertificate(cert, cert.PublicKey, rootCA.SignCert, rootCA.Signer)
+	require.NoError(t, err)
+	require.NotEqual(t, cert.SerialNumber, renewed.SerialNumber)
+	require.Equal(t, cert.RawSubject, renewed.RawSubject)
+	require.Equal(t, cert.DNSNames, renewed.DNSNames)
+	require.Equal(t, cert.IPAddresses, renewed.IPAddresses)
+	require.Equal(t, cert.KeyUsage, renewed.KeyUsage)
+	require.Equal(t, cert.ExtKeyUsage, renewed.ExtKeyUsage)
+	require.Equal(t, cert.PublicKey, renewed.PublicKey)
+	require.NoError(t, renewed.CheckSignatureFrom(rootCA.SignCert))
+
+	// renew the self-signed CA certificate with a new key
+	signer, err := csp.GenerateSigner(filepath.Join(testDir, "certs"), csp.ED25519)
+	require.NoError(t, err)
+	renewedCA, err := ca.RenewCertificate(rootCA.SignCert, signer.Public(), nil, signer)
+	require.NoError(t, err)
+	require.True(t, renewedCA.IsCA)
+	require.Equal(t, rootCA.SignCert.RawSubject, renewedCA.RawSubject)
+	require.Equal(t, renewed.AuthorityKeyId, rootCA.SignCert.SubjectKeyId)
+	require.NotEqual(t, rootCA.SignCert.SubjectKeyId, renewedCA.SubjectKeyId)
+	require.NoError(t, renewedCA.CheckSignatureFrom(renewedCA))
+
+	_, err = ca.RenewCertificate(cert, &ecdsa.PublicKey{}, rootCA.SignCert, rootCA.Signer)
+	require.Error(t, err)
+}
+
 func checkForFile(file string) bool {
 	if _, err := os.Stat(file); os.IsNotExist(err) {
 		return false
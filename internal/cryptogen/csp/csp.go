@@ -8,6 +8,7 @@ package csp
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/x509"
@@ -23,6 +24,13 @@ import (
 	"github.com/pkg/errors"
 )
 
+const (
+	// ECDSA is the key algorithm of ECDSA keys on the P-256 curve
+	ECDSA = "ecdsa"
+	// ED25519 is the key algorithm of Ed25519 keys
+	ED25519 = "ed25519"
+)
+
 // LoadPrivateKey loads a private key from a file in keystorePath.  It looks
 // for a file ending in "_sk" and expects a PEM-encoded PKCS8 EC private key.
 func LoadPrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
@@ -73,6 +81,53 @@ func parsePrivateKeyPEM(rawKey []byte) (*ecdsa.PrivateKey, error) {
 	return priv, nil
 }
 
+// LoadSigner loads a private key from a file in keystorePath like
+// LoadPrivateKey does, and returns a signer for it. Both EC and Ed25519
+// private keys are supported.
+func LoadSigner(keystorePath string) (crypto.Signer, error) {
+	var signer crypto.Signer
+
+	walkFunc := func(path string, info os.FileInfo, pathErr error) error {
+		if !strings.HasSuffix(path, "_sk") {
+			return nil
+		}
+
+		rawKey, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		block, _ := pem.Decode(rawKey)
+		if block == nil {
+			return errors.Errorf("%s: bytes are not PEM encoded", path)
+		}
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return errors.WithMessagef(err, "%s: pem bytes are not PKCS8 encoded", path)
+		}
+
+		switch k := key.(type) {
+		case *ecdsa.PrivateKey:
+			signer = &ECDSASigner{PrivateKey: k}
+		case ed25519.PrivateKey:
+			signer = k
+		default:
+			return errors.Errorf("%s: unsupported private key type %T", path, key)
+		}
+		return nil
+	}
+
+	err := filepath.Walk(keystorePath, walkFunc)
+	if err != nil {
+		return nil, err
+	}
+	if signer == nil {
+		return nil, errors.Errorf("no private key found in %s", keystorePath)
+	}
+
+	return signer, nil
+}
+
 // GeneratePrivateKey creates an EC private key using a P-256 curve and stores
 // it in keystorePath.
 func GeneratePrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
@@ -82,9 +137,43 @@ func GeneratePrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
 		return nil, errors.WithMessage(err, "failed to generate private key")
 	}
 
+	err = storePrivateKey(keystorePath, priv)
+	if err != nil {
+		return nil, err
+	}
+
+	return priv, err
+}
+
+// GenerateSigner creates a private key of the given key algorithm, stores it
+// in keystorePath, and returns a signer for it. ECDSA keys use the P-256 curve.
+func GenerateSigner(keystorePath, keyAlg string) (crypto.Signer, error) {
+	switch keyAlg {
+	case ECDSA, "":
+		priv, err := GeneratePrivateKey(keystorePath)
+		if err != nil {
+			return nil, err
+		}
+		return &ECDSASigner{PrivateKey: priv}, nil
+	case ED25519:
+		_, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to generate private key")
+		}
+		err = storePrivateKey(keystorePath, priv)
+		if err != nil {
+			return nil, err
+		}
+		return priv, nil
+	default:
+		return nil, errors.Errorf("unsupported key algorithm: %s", keyAlg)
+	}
+}
+
+func storePrivateKey(keystorePath string, priv crypto.PrivateKey) error {
 	pkcs8Encoded, err := x509.MarshalPKCS8PrivateKey(priv)
 	if err != nil {
-		return nil, errors.WithMessage(err, "failed to marshal private key")
+		return errors.WithMessage(err, "failed to marshal private key")
 	}
 
 	pemEncoded := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Encoded})
@@ -92,10 +181,10 @@ func GeneratePrivateKey(keystorePath string) (*ecdsa.PrivateKey, error) {
 	keyFile := filepath.Join(keystorePath, "priv_sk")
 	err = ioutil.WriteFile(keyFile, pemEncoded, 0600)
 	if err != nil {
-		return nil, errors.WithMessagef(err, "failed to save private key to file %s", keyFile)
+		return errors.WithMessagef(err, "failed to save private key to file %s", keyFile)
 	}
 
-	return priv, err
+	return nil
 }
 
 /**
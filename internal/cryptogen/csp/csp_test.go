@@ -6,7 +6,9 @@ SPDX-License-Identifier: Apache-2.0
 package csp_test
 
 import (
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -124,6 +126,49 @@ func TestGeneratePrivateKey(t *testing.T) {
 	require.Contains(t, err.Error(), "no such file or directory")
 }
 
+func TestGenerateAndLoadSigner(t *testing.T) {
+	for _, keyAlg := range []string{csp.ECDSA, csp.ED25519} {
+		t.Run(keyAlg, func(t *testing.T) {
+			testDir, err := ioutil.TempDir("", "csp-test")
+			if err != nil {
+				t.Fatalf("Failed to create test directory: %s", err)
+			}
+			defer os.RemoveAll(testDir)
+
+			signer, err := csp.GenerateSigner(testDir, keyAlg)
+			require.NoError(t, err, "Failed to generate signer")
+			require.Equal(t, true, checkForFile(filepath.Join(testDir, "priv_sk")),
+				"Expected to find private key file")
+
+			loadedSigner, err := csp.LoadSigner(testDir)
+			require.NoError(t, err, "Failed to load signer")
+			require.Equal(t, signer.Public(), loadedSigner.Public(), "Expected public keys to match")
+		})
+	}
+
+	_, err := csp.GenerateSigner(os.TempDir(), "rsa")
+	require.EqualError(t, err, "unsupported key algorithm: rsa")
+
+	_, err = csp.LoadSigner(os.TempDir() + "/notExist")
+	require.Error(t, err)
+}
+
+func TestED25519Signer(t *testing.T) {
+	testDir, err := ioutil.TempDir("", "csp-test")
+	if err != nil {
+		t.Fatalf("Failed to create test directory: %s", err)
+	}
+	defer os.RemoveAll(testDir)
+
+	signer, err := csp.GenerateSigner(testDir, csp.ED25519)
+	require.NoError(t, err, "Failed to generate signer")
+
+	msg := []byte("hello")
+	sig, err := signer.Sign(rand.Reader, msg, crypto.Hash(0))
+	require.NoError(t, err, "Failed to create signature")
+	require.True(t, ed25519.Verify(signer.Public().(ed25519.PublicKey), msg, sig), "Expected valid signature")
+}
+
 func TestECDSASigner(t *testing.T) {
 	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
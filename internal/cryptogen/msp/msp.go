@@ -39,6 +39,9 @@ var nodeOUMap = map[int]string{
 	ORDERER: ORDEREROU,
 }
 
+// GenerateLocalMSP generates the local MSP and the TLS artifacts of a node.
+// The signing identity of the node has a key pair of the given key algorithm,
+// while TLS key pairs are always ECDSA key pairs.
 func GenerateLocalMSP(
 	baseDir,
 	name string,
@@ -47,6 +50,7 @@ func GenerateLocalMSP(
 	tlsCA *ca.CA,
 	nodeType int,
 	nodeOUs bool,
+	keyAlg string,
 ) error {
 
 	// create folder structure
@@ -70,7 +74,7 @@ func GenerateLocalMSP(
 	keystore := filepath.Join(mspDir, "keystore")
 
 	// generate private key
-	priv, err := csp.GeneratePrivateKey(keystore)
+	signer, err := csp.GenerateSigner(keystore, keyAlg)
 	if err != nil {
 		return err
 	}
@@ -85,7 +89,7 @@ func GenerateLocalMSP(
 		name,
 		ous,
 		nil,
-		&priv.PublicKey,
+		signer.Public(),
 		x509.KeyUsageDigitalSignature,
 		[]x509.ExtKeyUsage{},
 	)
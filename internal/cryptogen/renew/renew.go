@@ -0,0 +1,683 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package renew
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger/fabric/internal/cryptogen/ca"
+	"github.com/hyperledger/fabric/internal/cryptogen/csp"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Node types of the certificates found in a cryptogen output directory.
+const (
+	CA      = "ca"
+	TLSCA   = "tlsca"
+	Peer    = "peer"
+	Orderer = "orderer"
+	User    = "user"
+)
+
+var nodeDirs = []struct {
+	dir      string
+	nodeType string
+}{
+	{"peers", Peer},
+	{"orderers", Orderer},
+	{"users", User},
+}
+
+// trustDirs are the MSP folders in which the previous certificate of a
+// rotated CA is kept next to the current one.
+var trustDirs = map[string]bool{
+	"cacerts":              true,
+	"intermediatecerts":    true,
+	"tlscacerts":           true,
+	"tlsintermediatecerts": true,
+}
+
+// Options select the certificates to renew and how to renew them.
+type Options struct {
+	// Orgs are the domains of the organizations to renew. All
+	// organizations are renewed if empty.
+	Orgs []string
+	// NodeTypes are the node types to renew. All node types are renewed if
+	// empty.
+	NodeTypes []string
+	// ExpiringWithin only renews certificates expiring within the given
+	// duration. All selected certificates are renewed if zero.
+	ExpiringWithin time.Duration
+	// RotateKeys generates new keys for renewed node and user certificates.
+	RotateKeys bool
+	// RotateCAs generates new keys for renewed CA certificates. The
+	// certificates issued by a rotated CA are reissued by its new key, and
+	// its previous certificate is kept in the trusted certificates of the
+	// MSPs for an overlap period.
+	RotateCAs bool
+	// IssuerCADirs are directories holding the certificate and key of CAs,
+	// outside of the cryptogen output directory, that issued intermediate
+	// CAs of the organizations.
+	IssuerCADirs []string
+}
+
+// Change describes a renewed certificate.
+type Change struct {
+	Path             string
+	Subject          string
+	IsCA             bool
+	KeyRotated       bool
+	PreviousNotAfter time.Time
+	NotAfter         time.Time
+	// Previous and Current are the PEM encoded previous and current
+	// certificates.
+	Previous []byte
+	Current  []byte
+}
+
+// Report lists the renewed certificates and the files holding copies of
+// them that were updated.
+type Report struct {
+	Changes      []Change
+	UpdatedFiles []string
+}
+
+type entry struct {
+	org      string
+	nodeType string
+	certPath string
+	// keyDir is the directory holding the private key of CAs
+	keyDir string
+	// keyPath is the path of the private key of the certificate
+	keyPath string
+	cert    *x509.Certificate
+	issuer  *authority
+
+	// set when the certificate is renewed
+	renewed  *x509.Certificate
+	signer   crypto.Signer
+	newKey   string
+	selected bool
+}
+
+// authority is a CA able to issue certificates, either found in the
+// cryptogen output directory or in one of the issuer CA directories.
+type authority struct {
+	cert   *x509.Certificate
+	signer crypto.Signer
+	entry  *entry
+}
+
+// Renew renews the certificates of the cryptogen output directory selected
+// by the options. Renewed certificates keep the subject, subject alternative
+// names and usages of the certificates they replace, and are written in
+// place, along with any rotated key. Copies of the renewed certificates in
+// MSP folders and TLS bundles are updated as well, and MSP configurations
+// pinning organizational units to a rotated CA are updated to accept both
+// of its certificates.
+func Renew(baseDir string, opts Options) (*Report, error) {
+	for _, t := range opts.NodeTypes {
+		switch t {
+		case CA, TLSCA, Peer, Orderer, User:
+		default:
+			return nil, errors.Errorf("unknown node type '%s', must be one of %s, %s, %s, %s or %s", t, CA, TLSCA, Peer, Orderer, User)
+		}
+	}
+
+	entries, err := scan(baseDir, opts.Orgs)
+	if err != nil {
+		return nil, err
+	}
+
+	authorities, err := loadIssuers(opts.IssuerCADirs)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.cert.IsCA {
+			authorities = append(authorities, &authority{cert: e.cert, entry: e})
+		}
+	}
+	for _, e := range entries {
+		e.issuer = findIssuer(e.cert, authorities)
+		e.selected = selected(e, opts)
+	}
+
+	tmpDir, err := ioutil.TempDir(baseDir, ".renew")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// CAs are renewed before the certificates they issue
+	sort.SliceStable(entries, func(i, j int) bool {
+		return depth(entries[i]) < depth(entries[j])
+	})
+	for i, e := range entries {
+		if err := plan(e, opts, filepath.Join(tmpDir, strconv.Itoa(i))); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &Report{}
+	for _, e := range entries {
+		if e.renewed == nil {
+			continue
+		}
+		if err := writePEM(e.certPath, e.renewed.Raw); err != nil {
+			return nil, err
+		}
+		if e.newKey != "" {
+			if err := os.Rename(e.newKey, e.keyPath); err != nil {
+				return nil, errors.Wrapf(err, "failed writing key of %s", e.certPath)
+			}
+		}
+		report.Changes = append(report.Changes, Change{
+			Path:             e.certPath,
+			Subject:          e.cert.Subject.String(),
+			IsCA:             e.cert.IsCA,
+			KeyRotated:       e.newKey != "",
+			PreviousNotAfter: e.cert.NotAfter,
+			NotAfter:         e.renewed.NotAfter,
+			Previous:         pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: e.cert.Raw}),
+			Current:          pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: e.renewed.Raw}),
+		})
+	}
+
+	report.UpdatedFiles, err = propagate(baseDir, tmpDir, report.Changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// plan renews the certificate of the entry if it is selected, or if its
+// issuer has a new key, generating any new key in keyDir.
+func plan(e *entry, opts Options, keyDir string) error {
+	issuerRotated := e.issuer != nil && e.issuer.entry != nil && e.issuer.entry.newKey != ""
+	if !e.selected && !issuerRotated {
+		return nil
+	}
+
+	rotate := opts.RotateKeys
+	if e.cert.IsCA {
+		rotate = opts.RotateCAs && e.selected
+	}
+
+	var signer crypto.Signer
+	pub := e.cert.PublicKey
+	if rotate {
+		keyAlg, err := keyAlgorithm(pub)
+		if err != nil {
+			return errors.WithMessagef(err, "failed rotating key of %s", e.certPath)
+		}
+		if err := os.Mkdir(keyDir, 0700); err != nil {
+			return err
+		}
+		signer, err = csp.GenerateSigner(keyDir, keyAlg)
+		if err != nil {
+			return errors.WithMessagef(err, "failed rotating key of %s", e.certPath)
+		}
+		pub = signer.Public()
+		e.newKey = filepath.Join(keyDir, "priv_sk")
+	} else if e.cert.IsCA {
+		var err error
+		signer, err = csp.LoadSigner(e.keyDir)
+		if err != nil {
+			return errors.WithMessagef(err, "failed loading key of %s", e.certPath)
+		}
+	}
+	e.signer = signer
+
+	var parent *x509.Certificate
+	var parentSigner crypto.Signer
+	switch {
+	case isSelfSigned(e.cert):
+		parentSigner = signer
+	case e.issuer == nil:
+		return errors.Errorf("issuer of %s not found", e.certPath)
+	default:
+		parent, parentSigner = e.issuer.cert, e.issuer.signer
+		if ie := e.issuer.entry; ie != nil {
+			if ie.renewed != nil {
+				parent = ie.renewed
+			}
+			if ie.signer == nil {
+				var err error
+				if ie.signer, err = csp.LoadSigner(ie.keyDir); err != nil {
+					return errors.WithMessagef(err, "failed loading key of %s", ie.certPath)
+				}
+			}
+			parentSigner = ie.signer
+		}
+	}
+
+	renewed, err := ca.RenewCertificate(e.cert, pub, parent, parentSigner)
+	if err != nil {
+		return errors.WithMessagef(err, "failed renewing %s", e.certPath)
+	}
+	e.renewed = renewed
+	return nil
+}
+
+// scan finds the CA, node and user certificates of the organizations of the
+// cryptogen output directory.
+func scan(baseDir string, orgs []string) ([]*entry, error) {
+	found := map[string]bool{}
+	var entries []*entry
+	for _, orgsDir := range []string{"peerOrganizations", "ordererOrganizations"} {
+		orgDirs, err := ioutil.ReadDir(filepath.Join(baseDir, orgsDir))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, orgDir := range orgDirs {
+			org := orgDir.Name()
+			if !orgDir.IsDir() || (len(orgs) != 0 && !contains(orgs, org)) {
+				continue
+			}
+			found[org] = true
+			orgEntries, err := scanOrg(filepath.Join(baseDir, orgsDir, org), org)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, orgEntries...)
+		}
+	}
+
+	for _, org := range orgs {
+		if !found[org] {
+			return nil, errors.Errorf("organization %s not found in %s", org, baseDir)
+		}
+	}
+	return entries, nil
+}
+
+func scanOrg(orgDir, org string) ([]*entry, error) {
+	var entries []*entry
+	add := func(nodeType, certPath, keyPath, keyDir string) error {
+		cert, err := loadCertificate(certPath)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, &entry{
+			org:      org,
+			nodeType: nodeType,
+			certPath: certPath,
+			keyPath:  keyPath,
+			keyDir:   keyDir,
+			cert:     cert,
+		})
+		return nil
+	}
+
+	for _, caType := range []string{CA, TLSCA} {
+		caDir := filepath.Join(orgDir, caType)
+		certPath, err := findFile(caDir, "-cert.pem")
+		if err != nil {
+			return nil, err
+		}
+		if certPath == "" {
+			continue
+		}
+		keyPath, err := findFile(caDir, "_sk")
+		if err != nil {
+			return nil, err
+		}
+		if keyPath == "" {
+			keyPath = filepath.Join(caDir, "priv_sk")
+		}
+		if err := add(caType, certPath, keyPath, caDir); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, nd := range nodeDirs {
+		dir, nodeType := nd.dir, nd.nodeType
+		nodes, err := ioutil.ReadDir(filepath.Join(orgDir, dir))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			nodeDir := filepath.Join(orgDir, dir, node.Name())
+			if !node.IsDir() {
+				continue
+			}
+
+			mspDir := filepath.Join(nodeDir, "msp")
+			certPath, err := findFile(filepath.Join(mspDir, "signcerts"), ".pem")
+			if err != nil {
+				return nil, err
+			}
+			if certPath != "" {
+				keyPath, err := findFile(filepath.Join(mspDir, "keystore"), "_sk")
+				if err != nil {
+					return nil, err
+				}
+				if keyPath == "" {
+					keyPath = filepath.Join(mspDir, "keystore", "priv_sk")
+				}
+				if err := add(nodeType, certPath, keyPath, ""); err != nil {
+					return nil, err
+				}
+			}
+
+			for _, prefix := range []string{"server", "client"} {
+				certPath := filepath.Join(nodeDir, "tls", prefix+".crt")
+				if _, err := os.Stat(certPath); os.IsNotExist(err) {
+					continue
+				}
+				if err := add(nodeType, certPath, filepath.Join(nodeDir, "tls", prefix+".key"), ""); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func loadIssuers(dirs []string) ([]*authority, error) {
+	var authorities []*authority
+	for _, dir := range dirs {
+		cert, err := ca.LoadCertificateECDSA(dir)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed loading issuer CA certificate from %s", dir)
+		}
+		if cert == nil {
+			return nil, errors.Errorf("no issuer CA certificate found in %s", dir)
+		}
+		signer, err := csp.LoadSigner(dir)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed loading issuer CA key from %s", dir)
+		}
+		authorities = append(authorities, &authority{cert: cert, signer: signer})
+	}
+	return authorities, nil
+}
+
+func findIssuer(cert *x509.Certificate, authorities []*authority) *authority {
+	if isSelfSigned(cert) {
+		return nil
+	}
+	for _, a := range authorities {
+		if a.cert == cert || !bytes.Equal(cert.RawIssuer, a.cert.RawSubject) {
+			continue
+		}
+		if cert.CheckSignatureFrom(a.cert) == nil {
+			return a
+		}
+	}
+	return nil
+}
+
+func selected(e *entry, opts Options) bool {
+	if len(opts.NodeTypes) != 0 && !contains(opts.NodeTypes, e.nodeType) {
+		return false
+	}
+	if opts.ExpiringWithin != 0 && e.cert.NotAfter.After(time.Now().Add(opts.ExpiringWithin)) {
+		return false
+	}
+	return true
+}
+
+// depth returns the number of CAs of the organizations between the
+// certificate and its root.
+func depth(e *entry) int {
+	d := 0
+	for a := e.issuer; a != nil && a.entry != nil && d < 16; a = a.entry.issuer {
+		d++
+	}
+	return d
+}
+
+// propagate replaces the previous certificates by the current ones in the
+// PEM files of the output directory. The previous certificate of a rotated
+// CA is kept next to the current one.
+func propagate(baseDir, skipDir string, changes []Change) ([]string, error) {
+	renewed := map[string]bool{}
+	for _, c := range changes {
+		renewed[c.Path] = true
+	}
+
+	var updated []string
+	walkFunc := func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path == skipDir {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if renewed[path] || !(strings.HasSuffix(path, ".pem") || strings.HasSuffix(path, ".crt")) {
+			return nil
+		}
+
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var out []byte
+		var previous *Change
+		changed := false
+		for rest := raw; ; {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			c := findChange(changes, block)
+			if c == nil {
+				out = append(out, pem.EncodeToMemory(block)...)
+				continue
+			}
+			changed = true
+			out = append(out, c.Current...)
+			if c.IsCA && c.KeyRotated {
+				if trustDirs[filepath.Base(filepath.Dir(path))] {
+					previous = c
+				} else {
+					out = append(out, c.Previous...)
+				}
+			}
+		}
+		if !changed {
+			return nil
+		}
+
+		if err := ioutil.WriteFile(path, out, info.Mode()); err != nil {
+			return err
+		}
+		updated = append(updated, path)
+		if previous != nil {
+			previousPath := strings.TrimSuffix(strings.TrimSuffix(path, filepath.Ext(path)), "-cert") + "-previous-cert.pem"
+			if err := ioutil.WriteFile(previousPath, previous.Previous, info.Mode()); err != nil {
+				return err
+			}
+			updated = append(updated, previousPath)
+
+			configUpdated, err := unpinRotatedCA(filepath.Dir(filepath.Dir(path)), path, previousPath)
+			if err != nil {
+				return err
+			}
+			if configUpdated {
+				updated = append(updated, filepath.Join(filepath.Dir(filepath.Dir(path)), "config.yaml"))
+			}
+		}
+		return nil
+	}
+
+	if err := filepath.Walk(baseDir, walkFunc); err != nil {
+		return nil, errors.Wrap(err, "failed updating copies of renewed certificates")
+	}
+	return updated, nil
+}
+
+// unpinRotatedCA updates the configuration of the MSP so that identities
+// issued by either the current or the previous certificate of a rotated CA
+// are classified: node OU identifiers pinned to the CA are unpinned, and
+// organizational unit identifiers pinned to it are duplicated for the
+// previous certificate.
+func unpinRotatedCA(mspDir, certPath, previousPath string) (bool, error) {
+	configPath := filepath.Join(mspDir, "config.yaml")
+	raw, err := ioutil.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	config := &msp.Configuration{}
+	if err := yaml.Unmarshal(raw, config); err != nil {
+		return false, errors.Wrapf(err, "failed unmarshaling %s", configPath)
+	}
+
+	certFile, err := filepath.Rel(mspDir, certPath)
+	if err != nil {
+		return false, err
+	}
+	previousFile, err := filepath.Rel(mspDir, previousPath)
+	if err != nil {
+		return false, err
+	}
+	pinned := func(certificate string) bool {
+		return certificate != "" && filepath.Clean(certificate) == certFile
+	}
+
+	changed := false
+	var ouIdentifiers []*msp.OrganizationalUnitIdentifiersConfiguration
+	for _, ou := range config.OrganizationalUnitIdentifiers {
+		ouIdentifiers = append(ouIdentifiers, ou)
+		if pinned(ou.Certificate) {
+			changed = true
+			ouIdentifiers = append(ouIdentifiers, &msp.OrganizationalUnitIdentifiersConfiguration{
+				Certificate:                  previousFile,
+				OrganizationalUnitIdentifier: ou.OrganizationalUnitIdentifier,
+			})
+		}
+	}
+	config.OrganizationalUnitIdentifiers = ouIdentifiers
+
+	if nodeOUs := config.NodeOUs; nodeOUs != nil {
+		for _, ou := range []*msp.OrganizationalUnitIdentifiersConfiguration{
+			nodeOUs.ClientOUIdentifier,
+			nodeOUs.PeerOUIdentifier,
+			nodeOUs.AdminOUIdentifier,
+			nodeOUs.OrdererOUIdentifier,
+		} {
+			if ou != nil && pinned(ou.Certificate) {
+				changed = true
+				ou.Certificate = ""
+			}
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+	raw, err = yaml.Marshal(config)
+	if err != nil {
+		return false, err
+	}
+	return true, ioutil.WriteFile(configPath, raw, 0644)
+}
+
+func findChange(changes []Change, block *pem.Block) *Change {
+	if block.Type != "CERTIFICATE" {
+		return nil
+	}
+	for i, c := range changes {
+		p, _ := pem.Decode(c.Previous)
+		if bytes.Equal(p.Bytes, block.Bytes) {
+			return &changes[i]
+		}
+	}
+	return nil
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
+
+func keyAlgorithm(pub crypto.PublicKey) (string, error) {
+	switch pub.(type) {
+	case *ecdsa.PublicKey:
+		return csp.ECDSA, nil
+	case ed25519.PublicKey:
+		return csp.ED25519, nil
+	default:
+		return "", errors.Errorf("unsupported public key type %T", pub)
+	}
+}
+
+func loadCertificate(path string) (*x509.Certificate, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.Errorf("%s: wrong PEM encoding", path)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Errorf("%s: wrong DER encoding", path)
+	}
+	return cert, nil
+}
+
+func writePEM(path string, der []byte) error {
+	return ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644)
+}
+
+// findFile returns the path of the file of dir with the given suffix, if any.
+func findFile(dir, suffix string) (string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), suffix) {
+			return filepath.Join(dir, f.Name()), nil
+		}
+	}
+	return "", nil
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
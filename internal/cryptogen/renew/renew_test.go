@@ -0,0 +1,249 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package renew_test
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/internal/cryptogen/ca"
+	"github.com/hyperledger/fabric/internal/cryptogen/csp"
+	"github.com/hyperledger/fabric/internal/cryptogen/msp"
+	"github.com/hyperledger/fabric/internal/cryptogen/renew"
+	fabricmsp "github.com/hyperledger/fabric/msp"
+	"github.com/stretchr/testify/require"
+)
+
+const org = "org1.example.com"
+
+var (
+	orgDir    = filepath.Join("peerOrganizations", org)
+	caCert    = filepath.Join(orgDir, "ca", "ca.org1.example.com-cert.pem")
+	tlsCACert = filepath.Join(orgDir, "tlsca", "tlsca.org1.example.com-cert.pem")
+	peerDir   = filepath.Join(orgDir, "peers", "peer0.org1.example.com")
+	peerCert  = filepath.Join(peerDir, "msp", "signcerts", "peer0.org1.example.com-cert.pem")
+	peerTLS   = filepath.Join(peerDir, "tls", "server.crt")
+	userDir   = filepath.Join(orgDir, "users", "User1@org1.example.com")
+	userCert  = filepath.Join(userDir, "msp", "signcerts", "User1@org1.example.com-cert.pem")
+)
+
+// generateOrg generates a peer organization with a peer and a user the way
+// cryptogen generate does.
+func generateOrg(t *testing.T, baseDir string) {
+	dir := filepath.Join(baseDir, orgDir)
+	signCA, err := ca.NewCA(filepath.Join(dir, "ca"), org, "ca."+org, "", "", "", "", "", "", csp.ECDSA)
+	require.NoError(t, err)
+	tlsCA, err := ca.NewCA(filepath.Join(dir, "tlsca"), org, "tlsca."+org, "", "", "", "", "", "", csp.ECDSA)
+	require.NoError(t, err)
+	require.NoError(t, msp.GenerateVerifyingMSP(filepath.Join(dir, "msp"), signCA, tlsCA, true))
+	require.NoError(t, msp.GenerateLocalMSP(filepath.Join(baseDir, peerDir), "peer0."+org, []string{"peer0." + org}, signCA, tlsCA, msp.PEER, true, csp.ECDSA))
+	require.NoError(t, msp.GenerateLocalMSP(filepath.Join(baseDir, userDir), "User1@"+org, nil, signCA, tlsCA, msp.CLIENT, true, csp.ED25519))
+}
+
+func loadCert(t *testing.T, path string) *x509.Certificate {
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	block, _ := pem.Decode(raw)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
+
+// validateIdentity validates the certificate against the verifying MSP of
+// the organization.
+func validateIdentity(t *testing.T, baseDir string, cert *x509.Certificate) error {
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	orgMSP, err := fabricmsp.New(&fabricmsp.BCCSPNewOpts{NewBaseOpts: fabricmsp.NewBaseOpts{Version: fabricmsp.MSPv1_4_3}}, cryptoProvider)
+	require.NoError(t, err)
+	mspConf, err := fabricmsp.GetVerifyingMspConfig(filepath.Join(baseDir, orgDir, "msp"), "Org1MSP", "bccsp")
+	require.NoError(t, err)
+	require.NoError(t, orgMSP.Setup(mspConf))
+
+	sID, err := proto.Marshal(&mspproto.SerializedIdentity{
+		Mspid:   "Org1MSP",
+		IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+	})
+	require.NoError(t, err)
+	id, err := orgMSP.DeserializeIdentity(sID)
+	require.NoError(t, err)
+	return id.Validate()
+}
+
+func setup(t *testing.T) (string, func()) {
+	baseDir, err := ioutil.TempDir("", "renew")
+	require.NoError(t, err)
+	generateOrg(t, baseDir)
+	return baseDir, func() { os.RemoveAll(baseDir) }
+}
+
+func TestRenewAll(t *testing.T) {
+	baseDir, cleanup := setup(t)
+	defer cleanup()
+
+	previous := map[string]*x509.Certificate{}
+	for _, path := range []string{caCert, tlsCACert, peerCert, peerTLS, userCert} {
+		previous[path] = loadCert(t, filepath.Join(baseDir, path))
+	}
+
+	report, err := renew.Renew(baseDir, renew.Options{})
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 6)
+
+	for path, cert := range previous {
+		renewed := loadCert(t, filepath.Join(baseDir, path))
+		require.NotEqual(t, cert.SerialNumber, renewed.SerialNumber, path)
+		require.Equal(t, cert.PublicKey, renewed.PublicKey, path)
+		require.Equal(t, cert.Subject.String(), renewed.Subject.String(), path)
+	}
+
+	// the CA keeps its key, so certificates it issued before remain valid
+	renewedCA := loadCert(t, filepath.Join(baseDir, caCert))
+	require.Equal(t, previous[caCert].SubjectKeyId, renewedCA.SubjectKeyId)
+	require.NoError(t, previous[peerCert].CheckSignatureFrom(renewedCA))
+	require.NoError(t, loadCert(t, filepath.Join(baseDir, peerCert)).CheckSignatureFrom(renewedCA))
+
+	// copies of the renewed certificates are updated
+	require.Contains(t, report.UpdatedFiles, filepath.Join(baseDir, orgDir, "msp", "cacerts", "ca.org1.example.com-cert.pem"))
+	require.Equal(t, renewedCA.Raw, loadCert(t, filepath.Join(baseDir, peerDir, "msp", "cacerts", "ca.org1.example.com-cert.pem")).Raw)
+	require.Equal(t, loadCert(t, filepath.Join(baseDir, tlsCACert)).Raw, loadCert(t, filepath.Join(baseDir, peerDir, "tls", "ca.crt")).Raw)
+
+	// no temporary files are left behind
+	files, err := ioutil.ReadDir(baseDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+}
+
+func TestRenewFilters(t *testing.T) {
+	baseDir, cleanup := setup(t)
+	defer cleanup()
+
+	report, err := renew.Renew(baseDir, renew.Options{ExpiringWithin: time.Hour})
+	require.NoError(t, err)
+	require.Empty(t, report.Changes)
+
+	report, err = renew.Renew(baseDir, renew.Options{Orgs: []string{org}, NodeTypes: []string{renew.User}, ExpiringWithin: 20 * 365 * 24 * time.Hour})
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 2)
+	require.Equal(t, filepath.Join(baseDir, userCert), report.Changes[0].Path)
+	require.Equal(t, filepath.Join(baseDir, userDir, "tls", "client.crt"), report.Changes[1].Path)
+	require.False(t, report.Changes[0].KeyRotated)
+	require.False(t, report.Changes[0].NotAfter.Before(report.Changes[0].PreviousNotAfter))
+
+	_, err = renew.Renew(baseDir, renew.Options{Orgs: []string{"org2.example.com"}})
+	require.EqualError(t, err, "organization org2.example.com not found in "+baseDir)
+
+	_, err = renew.Renew(baseDir, renew.Options{NodeTypes: []string{"admin"}})
+	require.EqualError(t, err, "unknown node type 'admin', must be one of ca, tlsca, peer, orderer or user")
+}
+
+func TestRenewRotateKeys(t *testing.T) {
+	baseDir, cleanup := setup(t)
+	defer cleanup()
+
+	previousKey, err := ioutil.ReadFile(filepath.Join(baseDir, peerDir, "tls", "server.key"))
+	require.NoError(t, err)
+	previousCert := loadCert(t, filepath.Join(baseDir, peerTLS))
+
+	report, err := renew.Renew(baseDir, renew.Options{NodeTypes: []string{renew.Peer}, RotateKeys: true})
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 2)
+	for _, c := range report.Changes {
+		require.True(t, c.KeyRotated)
+	}
+
+	key, err := ioutil.ReadFile(filepath.Join(baseDir, peerDir, "tls", "server.key"))
+	require.NoError(t, err)
+	require.NotEqual(t, previousKey, key)
+	renewedCert := loadCert(t, filepath.Join(baseDir, peerTLS))
+	require.NotEqual(t, previousCert.PublicKey, renewedCert.PublicKey)
+	require.Equal(t, previousCert.DNSNames, renewedCert.DNSNames)
+
+	block, _ := pem.Decode(key)
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+	require.Equal(t, renewedCert.PublicKey, priv.(crypto.Signer).Public())
+
+	signer, err := csp.LoadSigner(filepath.Join(baseDir, peerDir, "msp", "keystore"))
+	require.NoError(t, err)
+	require.Equal(t, loadCert(t, filepath.Join(baseDir, peerCert)).PublicKey, signer.Public())
+}
+
+func TestRenewRotateCAs(t *testing.T) {
+	baseDir, cleanup := setup(t)
+	defer cleanup()
+
+	previousCA := loadCert(t, filepath.Join(baseDir, caCert))
+	previousPeerCert := loadCert(t, filepath.Join(baseDir, peerCert))
+	previousTLSCert := loadCert(t, filepath.Join(baseDir, peerTLS))
+
+	report, err := renew.Renew(baseDir, renew.Options{NodeTypes: []string{renew.CA}, RotateCAs: true})
+	require.NoError(t, err)
+	// the CA and the enrollment certificates it issued
+	require.Len(t, report.Changes, 3)
+	require.True(t, report.Changes[0].IsCA)
+	require.True(t, report.Changes[0].KeyRotated)
+	require.False(t, report.Changes[1].KeyRotated)
+
+	renewedCA := loadCert(t, filepath.Join(baseDir, caCert))
+	require.NotEqual(t, previousCA.PublicKey, renewedCA.PublicKey)
+	signer, err := csp.LoadSigner(filepath.Join(baseDir, orgDir, "ca"))
+	require.NoError(t, err)
+	require.Equal(t, renewedCA.PublicKey, signer.Public())
+
+	for _, path := range []string{peerCert, userCert} {
+		require.NoError(t, loadCert(t, filepath.Join(baseDir, path)).CheckSignatureFrom(renewedCA))
+	}
+	// the TLS certificates are issued by the TLS CA, which is not rotated
+	require.Equal(t, previousTLSCert.Raw, loadCert(t, filepath.Join(baseDir, peerTLS)).Raw)
+
+	// the previous CA certificate is still trusted
+	for _, mspDir := range []string{filepath.Join(orgDir, "msp"), filepath.Join(peerDir, "msp")} {
+		cacerts := filepath.Join(baseDir, mspDir, "cacerts")
+		require.Equal(t, renewedCA.Raw, loadCert(t, filepath.Join(cacerts, "ca.org1.example.com-cert.pem")).Raw)
+		require.Equal(t, previousCA.Raw, loadCert(t, filepath.Join(cacerts, "ca.org1.example.com-previous-cert.pem")).Raw)
+	}
+	require.NoError(t, validateIdentity(t, baseDir, loadCert(t, filepath.Join(baseDir, peerCert))))
+	require.NoError(t, validateIdentity(t, baseDir, previousPeerCert))
+}
+
+func TestRenewIntermediateCA(t *testing.T) {
+	baseDir, cleanup := setup(t)
+	defer cleanup()
+
+	// turn the CA of the organization into an intermediate CA
+	rootDir := filepath.Join(baseDir, "..", filepath.Base(baseDir)+"-root")
+	defer os.RemoveAll(rootDir)
+	rootCA, err := ca.NewCA(rootDir, "root", "root.example.com", "", "", "", "", "", "", csp.ECDSA)
+	require.NoError(t, err)
+	orgCA := loadCert(t, filepath.Join(baseDir, caCert))
+	intermediate, err := ca.RenewCertificate(orgCA, orgCA.PublicKey, rootCA.SignCert, rootCA.Signer)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(baseDir, caCert), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: intermediate.Raw}), 0644))
+
+	_, err = renew.Renew(baseDir, renew.Options{NodeTypes: []string{renew.CA}})
+	require.EqualError(t, err, "issuer of "+filepath.Join(baseDir, caCert)+" not found")
+
+	report, err := renew.Renew(baseDir, renew.Options{NodeTypes: []string{renew.CA}, RotateCAs: true, IssuerCADirs: []string{rootDir}})
+	require.NoError(t, err)
+	require.Len(t, report.Changes, 3)
+
+	renewed := loadCert(t, filepath.Join(baseDir, caCert))
+	require.NoError(t, renewed.CheckSignatureFrom(rootCA.SignCert))
+	require.NotEqual(t, intermediate.PublicKey, renewed.PublicKey)
+	require.NoError(t, loadCert(t, filepath.Join(baseDir, peerCert)).CheckSignatureFrom(renewed))
+}
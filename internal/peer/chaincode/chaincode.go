@@ -20,7 +20,7 @@ import (
 
 const (
 	chainFuncName = "chaincode"
-	chainCmdDes   = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade|list."
+	chainCmdDes   = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade|list|events."
 )
 
 var logger = flogging.MustGetLogger("chaincodeCmd")
@@ -49,6 +49,7 @@ func Cmd(cf *ChaincodeCmdFactory, cryptoProvider bccsp.BCCSP) *cobra.Command {
 	chaincodeCmd.AddCommand(signpackageCmd(cf, cryptoProvider))
 	chaincodeCmd.AddCommand(upgradeCmd(cf, cryptoProvider))
 	chaincodeCmd.AddCommand(listCmd(cf, cryptoProvider))
+	chaincodeCmd.AddCommand(eventsCmd(cf, cryptoProvider))
 
 	return chaincodeCmd
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/peer/ccevents"
 	"github.com/hyperledger/fabric/internal/peer/common"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/protoutil"
@@ -433,11 +434,12 @@ func validatePeerConnectionParameters(cmdName string) error {
 
 // ChaincodeCmdFactory holds the clients used by ChaincodeCmd
 type ChaincodeCmdFactory struct {
-	EndorserClients []pb.EndorserClient
-	DeliverClients  []pb.DeliverClient
-	Certificate     tls.Certificate
-	Signer          identity.SignerSerializer
-	BroadcastClient common.BroadcastClient
+	EndorserClients       []pb.EndorserClient
+	DeliverClients        []pb.DeliverClient
+	ChaincodeEventsClient ccevents.ChaincodeEventsClient
+	Certificate           tls.Certificate
+	Signer                identity.SignerSerializer
+	BroadcastClient       common.BroadcastClient
 }
 
 // InitCmdFactory init the ChaincodeCmdFactory with default clients
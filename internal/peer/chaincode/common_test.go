@@ -23,6 +23,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/hyperledger/fabric/core/config/configtest"
+	"github.com/hyperledger/fabric/core/peer/ccevents"
 	"github.com/hyperledger/fabric/internal/peer/chaincode/mock"
 	"github.com/hyperledger/fabric/internal/peer/common"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
@@ -50,6 +51,18 @@ type peerDeliverClient interface {
 	pb.DeliverClient
 }
 
+//go:generate counterfeiter -o mock/chaincode_events_client.go --fake-name ChaincodeEventsClient . chaincodeEventsClient
+
+type chaincodeEventsClient interface {
+	ccevents.ChaincodeEventsClient
+}
+
+//go:generate counterfeiter -o mock/chaincode_events_stream.go --fake-name ChaincodeEventsStream . chaincodeEventsStream
+
+type chaincodeEventsStream interface {
+	ccevents.ChaincodeEvents_DeliverChaincodeEventsClient
+}
+
 func TestCheckChaincodeCmdParamsWithNewCallingSchema(t *testing.T) {
 	chaincodeCtorJSON = `{ "Args":["func", "param"] }`
 	chaincodePath = "some/path"
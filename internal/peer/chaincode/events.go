@@ -0,0 +1,336 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pcommon "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/peer/ccevents"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/identity"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// maxEventsReconnectAttempts is the number of consecutive failed attempts
+	// to (re)establish the chaincode events stream before giving up.
+	maxEventsReconnectAttempts = 5
+)
+
+// eventsReconnectDelay is the time waited before reconnecting to the peer
+// after the chaincode events stream failed.
+var eventsReconnectDelay = 2 * time.Second
+
+var (
+	eventName  string
+	startBlock int64
+	stopBlock  int64
+)
+
+var chaincodeEventsCmd *cobra.Command
+
+// eventsCmd returns the cobra command for Chaincode Events
+func eventsCmd(cf *ChaincodeCmdFactory, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	chaincodeEventsCmd = &cobra.Command{
+		Use:   "events",
+		Short: fmt.Sprintf("Listen to the events emitted by the specified %s.", chainFuncName),
+		Long: fmt.Sprintf("Listen to the events emitted by the committed transactions of the specified %s and print them as JSON, one per line. "+
+			"The stream is resumed after the last block received if the connection to the peer is lost.", chainFuncName),
+		ValidArgs: []string{"0"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return chaincodeEvents(cmd, cf, cryptoProvider)
+		},
+	}
+	flagList := []string{
+		"name",
+		"channelID",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"connectionProfile",
+	}
+	attachFlags(chaincodeEventsCmd, flagList)
+
+	chaincodeEventsCmd.Flags().StringVarP(&eventName, "eventName", "e", "",
+		"Regular expression the name of the events must match, all events are received if not set")
+	chaincodeEventsCmd.Flags().Int64VarP(&startBlock, "startBlock", "", -1,
+		"Number of the block to start receiving events from, -1 starts from the newest block")
+	chaincodeEventsCmd.Flags().Int64VarP(&stopBlock, "stopBlock", "", -1,
+		"Number of the block to stop receiving events at, -1 keeps receiving events until interrupted")
+
+	return chaincodeEventsCmd
+}
+
+// chaincodeEvent is the JSON representation of a chaincode event printed
+// by the events command.
+type chaincodeEvent struct {
+	BlockNumber uint64 `json:"block_number"`
+	TxID        string `json:"tx_id"`
+	ChaincodeID string `json:"chaincode_id"`
+	EventName   string `json:"event_name"`
+	Payload     []byte `json:"payload"`
+}
+
+func chaincodeEvents(cmd *cobra.Command, cf *ChaincodeCmdFactory, cryptoProvider bccsp.BCCSP) error {
+	if channelID == "" {
+		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	if chaincodeName == common.UndefinedParamValue || chaincodeName == "" {
+		return errors.New("The required parameter 'name' is empty. Rerun the command with -n flag")
+	}
+	if startBlock < -1 {
+		return errors.Errorf("invalid start block %d", startBlock)
+	}
+	if stopBlock < -1 || (stopBlock != -1 && stopBlock < startBlock) {
+		return errors.Errorf("invalid stop block %d", stopBlock)
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = initEventsCmdFactory(cmd.Name(), cryptoProvider)
+		if err != nil {
+			return err
+		}
+	}
+
+	l := &eventsListener{
+		Client:      cf.ChaincodeEventsClient,
+		Signer:      cf.Signer,
+		Certificate: cf.Certificate,
+		Writer:      os.Stdout,
+		Request: &ccevents.ChaincodeEventsRequest{
+			ChaincodeId:      chaincodeName,
+			EventNamePattern: eventName,
+		},
+	}
+	return l.listen(context.Background(), startBlock, stopBlock)
+}
+
+// initEventsCmdFactory returns a ChaincodeCmdFactory holding a chaincode
+// events client for the peer passed to the events command.
+func initEventsCmdFactory(cmdName string, cryptoProvider bccsp.BCCSP) (*ChaincodeCmdFactory, error) {
+	if err := validatePeerConnectionParameters(cmdName); err != nil {
+		return nil, errors.WithMessage(err, "error validating peer connection parameters")
+	}
+	cf, err := InitCmdFactory(cmdName, false, false, cryptoProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	var address, tlsRootCertFile string
+	if len(peerAddresses) > 0 {
+		address = peerAddresses[0]
+	}
+	if len(tlsRootCertFiles) > 0 {
+		tlsRootCertFile = tlsRootCertFiles[0]
+	}
+	cf.ChaincodeEventsClient, err = common.GetChaincodeEventsClientFnc(address, tlsRootCertFile)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "error getting chaincode events client for %s", cmdName)
+	}
+	return cf, nil
+}
+
+// eventsListener receives the chaincode events matching a request and
+// writes them as JSON lines.
+type eventsListener struct {
+	Client      ccevents.ChaincodeEventsClient
+	Signer      identity.SignerSerializer
+	Certificate tls.Certificate
+	Writer      io.Writer
+	Request     *ccevents.ChaincodeEventsRequest
+}
+
+// listen receives the events of the blocks from start to stop, where -1
+// respectively means the newest block and no stop block. When the stream
+// fails, it is reestablished from the block following the last one received.
+// The peer sends the first block of a stream even if it holds no matching
+// events, so a start at the newest block is resolved to a block number as
+// soon as the stream opens and is not moved forward by a reconnection.
+func (l *eventsListener) listen(ctx context.Context, start, stop int64) error {
+	next := start
+	attempts := 0
+	for {
+		if stop >= 0 && next > stop {
+			return nil
+		}
+		envelope, err := l.seekEnvelope(next, stop)
+		if err != nil {
+			return err
+		}
+
+		received, err := l.receive(ctx, envelope)
+		if received != nil {
+			next = int64(*received) + 1
+			attempts = 0
+		}
+		if err == nil {
+			return nil
+		}
+		if _, ok := err.(*eventsStatusError); ok {
+			return err
+		}
+
+		attempts++
+		if attempts >= maxEventsReconnectAttempts {
+			return errors.WithMessagef(err, "failed to receive chaincode events after %d attempts", attempts)
+		}
+		logger.Warningf("Chaincode events stream failed, reconnecting from block %s: %s", seekDescription(next), err)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(eventsReconnectDelay):
+		}
+	}
+}
+
+// eventsStatusError is returned when the peer terminates the stream with a
+// status other than success. Such a stream is not reestablished.
+type eventsStatusError struct {
+	status pcommon.Status
+}
+
+func (e *eventsStatusError) Error() string {
+	return fmt.Sprintf("chaincode events stream terminated with status %s", e.status)
+}
+
+// receive opens a single chaincode events stream and writes the received
+// events until the stream ends. It returns the number of the last block
+// received, if any.
+func (l *eventsListener) receive(ctx context.Context, envelope *pcommon.Envelope) (*uint64, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := l.Client.DeliverChaincodeEvents(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "error connecting to the chaincode events service")
+	}
+	if err := stream.Send(envelope); err != nil {
+		return nil, errors.Wrap(err, "error sending chaincode events request")
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, errors.Wrap(err, "error closing chaincode events request stream")
+	}
+
+	var received *uint64
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return received, errors.Wrap(err, "error receiving chaincode events")
+		}
+
+		switch r := resp.Type.(type) {
+		case *ccevents.ChaincodeEventsResponse_Status:
+			if r.Status != pcommon.Status_SUCCESS {
+				return received, &eventsStatusError{status: r.Status}
+			}
+			return received, nil
+		case *ccevents.ChaincodeEventsResponse_Block:
+			if err := l.write(r.Block); err != nil {
+				return received, err
+			}
+			number := r.Block.Number
+			received = &number
+		default:
+			return received, errors.Errorf("unexpected response type %T", r)
+		}
+	}
+}
+
+func (l *eventsListener) write(block *ccevents.ChaincodeEventsBlock) error {
+	for _, event := range block.Events {
+		b, err := json.Marshal(&chaincodeEvent{
+			BlockNumber: block.Number,
+			TxID:        event.TxId,
+			ChaincodeID: event.ChaincodeId,
+			EventName:   event.EventName,
+			Payload:     event.Payload,
+		})
+		if err != nil {
+			return errors.Wrap(err, "error marshaling chaincode event")
+		}
+		if _, err := fmt.Fprintf(l.Writer, "%s\n", b); err != nil {
+			return errors.Wrap(err, "error writing chaincode event")
+		}
+	}
+	return nil
+}
+
+// seekEnvelope returns the signed DELIVER_SEEK_INFO envelope for the blocks
+// from start to stop, carrying the events request in the channel header
+// extension.
+func (l *eventsListener) seekEnvelope(start, stop int64) (*pcommon.Envelope, error) {
+	seekInfo := &ab.SeekInfo{
+		Start:    seekPosition(start, &ab.SeekPosition{Type: &ab.SeekPosition_Newest{Newest: &ab.SeekNewest{}}}),
+		Stop:     seekPosition(stop, &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: math.MaxUint64}}}),
+		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
+	}
+
+	extension, err := proto.Marshal(l.Request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling chaincode events request")
+	}
+
+	chdr := protoutil.MakeChannelHeader(pcommon.HeaderType_DELIVER_SEEK_INFO, 0, channelID, 0)
+	chdr.Extension = extension
+	// check for client certificate and create hash if present
+	if len(l.Certificate.Certificate) > 0 {
+		chdr.TlsCertHash = util.ComputeSHA256(l.Certificate.Certificate[0])
+	}
+
+	shdr, err := protoutil.NewSignatureHeader(l.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := proto.Marshal(&pcommon.Payload{
+		Header: protoutil.MakePayloadHeader(chdr, shdr),
+		Data:   protoutil.MarshalOrPanic(seekInfo),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshaling payload")
+	}
+
+	signature, err := l.Signer.Sign(payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error signing chaincode events request")
+	}
+
+	return &pcommon.Envelope{Payload: payload, Signature: signature}, nil
+}
+
+// seekPosition returns the position of the given block number, or the
+// default position if the number is -1.
+func seekPosition(number int64, defaultPosition *ab.SeekPosition) *ab.SeekPosition {
+	if number < 0 {
+		return defaultPosition
+	}
+	return &ab.SeekPosition{Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: uint64(number)}}}
+}
+
+func seekDescription(number int64) string {
+	if number < 0 {
+		return "newest"
+	}
+	return fmt.Sprintf("%d", number)
+}
@@ -0,0 +1,257 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	cb "github.com/hyperledger/fabric-protos-go/common"
+	ab "github.com/hyperledger/fabric-protos-go/orderer"
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/core/peer/ccevents"
+	"github.com/hyperledger/fabric/internal/peer/chaincode/mock"
+	"github.com/hyperledger/fabric/protoutil"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventsCmd(t *testing.T) {
+	defer resetFlags()
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	stream := &mock.ChaincodeEventsStream{}
+	stream.RecvReturnsOnCall(0, eventsBlockResponse(5, "event1"), nil)
+	stream.RecvReturnsOnCall(1, &ccevents.ChaincodeEventsResponse{
+		Type: &ccevents.ChaincodeEventsResponse_Status{Status: cb.Status_SUCCESS},
+	}, nil)
+	client := &mock.ChaincodeEventsClient{}
+	client.DeliverChaincodeEventsReturns(stream, nil)
+	mockCF := &ChaincodeCmdFactory{
+		ChaincodeEventsClient: client,
+		Signer:                &mock.SignerSerializer{},
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr string
+	}{
+		{
+			name:        "missing channel",
+			args:        []string{"-n", "mycc"},
+			expectedErr: "The required parameter 'channelID' is empty. Rerun the command with -C flag",
+		},
+		{
+			name:        "missing name",
+			args:        []string{"-C", "mychannel"},
+			expectedErr: "The required parameter 'name' is empty. Rerun the command with -n flag",
+		},
+		{
+			name:        "invalid start block",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--startBlock", "-2"},
+			expectedErr: "invalid start block -2",
+		},
+		{
+			name:        "stop block before start block",
+			args:        []string{"-C", "mychannel", "-n", "mycc", "--startBlock", "5", "--stopBlock", "4"},
+			expectedErr: "invalid stop block 4",
+		},
+		{
+			name: "success",
+			args: []string{"-C", "mychannel", "-n", "mycc", "-e", "event.*", "--startBlock", "5", "--stopBlock", "5"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resetFlags()
+			channelID = ""
+			cmd := newEventsCmdForTest(mockCF, tc.args, cryptoProvider)
+			err := cmd.Execute()
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+
+	require.Equal(t, 1, stream.SendCallCount())
+	request, seekInfo := unmarshalEventsEnvelope(t, stream.SendArgsForCall(0))
+	require.True(t, proto.Equal(&ccevents.ChaincodeEventsRequest{ChaincodeId: "mycc", EventNamePattern: "event.*"}, request))
+	require.Equal(t, uint64(5), seekInfo.Start.GetSpecified().Number)
+	require.Equal(t, uint64(5), seekInfo.Stop.GetSpecified().Number)
+}
+
+func TestEventsListener(t *testing.T) {
+	defer func(delay time.Duration) { eventsReconnectDelay = delay }(eventsReconnectDelay)
+	eventsReconnectDelay = 0
+	channelID = "mychannel"
+	defer func() { channelID = "" }()
+
+	newListener := func(client ccevents.ChaincodeEventsClient, w io.Writer) *eventsListener {
+		return &eventsListener{
+			Client:  client,
+			Signer:  &mock.SignerSerializer{},
+			Writer:  w,
+			Request: &ccevents.ChaincodeEventsRequest{ChaincodeId: "mycc"},
+		}
+	}
+
+	t.Run("resumes after the last block received", func(t *testing.T) {
+		failing := &mock.ChaincodeEventsStream{}
+		failing.RecvReturnsOnCall(0, eventsBlockResponse(3, "event1", "event2"), nil)
+		failing.RecvReturnsOnCall(1, nil, errors.New("connection reset"))
+		resumed := &mock.ChaincodeEventsStream{}
+		resumed.RecvReturnsOnCall(0, eventsBlockResponse(7, "event3"), nil)
+		resumed.RecvReturnsOnCall(1, &ccevents.ChaincodeEventsResponse{
+			Type: &ccevents.ChaincodeEventsResponse_Status{Status: cb.Status_SUCCESS},
+		}, nil)
+		client := &mock.ChaincodeEventsClient{}
+		client.DeliverChaincodeEventsReturnsOnCall(0, failing, nil)
+		client.DeliverChaincodeEventsReturnsOnCall(1, resumed, nil)
+
+		buf := &bytes.Buffer{}
+		err := newListener(client, buf).listen(context.Background(), -1, 10)
+		require.NoError(t, err)
+		require.Equal(t,
+			`{"block_number":3,"tx_id":"tx-event1","chaincode_id":"mycc","event_name":"event1","payload":"cGF5bG9hZA=="}`+"\n"+
+				`{"block_number":3,"tx_id":"tx-event2","chaincode_id":"mycc","event_name":"event2","payload":"cGF5bG9hZA=="}`+"\n"+
+				`{"block_number":7,"tx_id":"tx-event3","chaincode_id":"mycc","event_name":"event3","payload":"cGF5bG9hZA=="}`+"\n",
+			buf.String(),
+		)
+
+		_, seekInfo := unmarshalEventsEnvelope(t, failing.SendArgsForCall(0))
+		require.NotNil(t, seekInfo.Start.GetNewest())
+		require.Equal(t, uint64(10), seekInfo.Stop.GetSpecified().Number)
+		_, seekInfo = unmarshalEventsEnvelope(t, resumed.SendArgsForCall(0))
+		require.Equal(t, uint64(4), seekInfo.Start.GetSpecified().Number)
+		require.Equal(t, uint64(10), seekInfo.Stop.GetSpecified().Number)
+	})
+
+	t.Run("resumes from the resolved newest block", func(t *testing.T) {
+		failing := &mock.ChaincodeEventsStream{}
+		failing.RecvReturnsOnCall(0, eventsBlockResponse(12), nil)
+		failing.RecvReturnsOnCall(1, nil, errors.New("connection reset"))
+		resumed := &mock.ChaincodeEventsStream{}
+		resumed.RecvReturnsOnCall(0, eventsBlockResponse(13, "event1"), nil)
+		resumed.RecvReturnsOnCall(1, nil, errors.New("connection reset"))
+		final := &mock.ChaincodeEventsStream{}
+		final.RecvReturnsOnCall(0, &ccevents.ChaincodeEventsResponse{
+			Type: &ccevents.ChaincodeEventsResponse_Status{Status: cb.Status_SUCCESS},
+		}, nil)
+		client := &mock.ChaincodeEventsClient{}
+		client.DeliverChaincodeEventsReturnsOnCall(0, failing, nil)
+		client.DeliverChaincodeEventsReturnsOnCall(1, resumed, nil)
+		client.DeliverChaincodeEventsReturnsOnCall(2, final, nil)
+
+		buf := &bytes.Buffer{}
+		err := newListener(client, buf).listen(context.Background(), -1, -1)
+		require.NoError(t, err)
+		require.Equal(t,
+			`{"block_number":13,"tx_id":"tx-event1","chaincode_id":"mycc","event_name":"event1","payload":"cGF5bG9hZA=="}`+"\n",
+			buf.String(),
+		)
+
+		_, seekInfo := unmarshalEventsEnvelope(t, failing.SendArgsForCall(0))
+		require.NotNil(t, seekInfo.Start.GetNewest())
+		_, seekInfo = unmarshalEventsEnvelope(t, resumed.SendArgsForCall(0))
+		require.Equal(t, uint64(13), seekInfo.Start.GetSpecified().Number)
+		_, seekInfo = unmarshalEventsEnvelope(t, final.SendArgsForCall(0))
+		require.Equal(t, uint64(14), seekInfo.Start.GetSpecified().Number)
+	})
+
+	t.Run("stops after the stop block was received", func(t *testing.T) {
+		stream := &mock.ChaincodeEventsStream{}
+		stream.RecvReturnsOnCall(0, eventsBlockResponse(10, "event1"), nil)
+		stream.RecvReturnsOnCall(1, nil, errors.New("connection reset"))
+		client := &mock.ChaincodeEventsClient{}
+		client.DeliverChaincodeEventsReturns(stream, nil)
+
+		err := newListener(client, &bytes.Buffer{}).listen(context.Background(), 10, 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, client.DeliverChaincodeEventsCallCount())
+	})
+
+	t.Run("gives up after repeated failures", func(t *testing.T) {
+		client := &mock.ChaincodeEventsClient{}
+		client.DeliverChaincodeEventsReturns(nil, errors.New("connection refused"))
+
+		err := newListener(client, &bytes.Buffer{}).listen(context.Background(), 0, -1)
+		require.EqualError(t, err, "failed to receive chaincode events after 5 attempts: error connecting to the chaincode events service: connection refused")
+		require.Equal(t, maxEventsReconnectAttempts, client.DeliverChaincodeEventsCallCount())
+	})
+
+	t.Run("does not reconnect after a failure status", func(t *testing.T) {
+		stream := &mock.ChaincodeEventsStream{}
+		stream.RecvReturns(&ccevents.ChaincodeEventsResponse{
+			Type: &ccevents.ChaincodeEventsResponse_Status{Status: cb.Status_FORBIDDEN},
+		}, nil)
+		client := &mock.ChaincodeEventsClient{}
+		client.DeliverChaincodeEventsReturns(stream, nil)
+
+		err := newListener(client, &bytes.Buffer{}).listen(context.Background(), 0, -1)
+		require.EqualError(t, err, "chaincode events stream terminated with status FORBIDDEN")
+		require.Equal(t, 1, client.DeliverChaincodeEventsCallCount())
+	})
+
+	t.Run("signing failure", func(t *testing.T) {
+		signer := &mock.SignerSerializer{}
+		signer.SignReturns(nil, errors.New("hsm unavailable"))
+		l := newListener(&mock.ChaincodeEventsClient{}, &bytes.Buffer{})
+		l.Signer = signer
+
+		err := l.listen(context.Background(), 0, -1)
+		require.EqualError(t, err, "error signing chaincode events request: hsm unavailable")
+	})
+}
+
+func newEventsCmdForTest(cf *ChaincodeCmdFactory, args []string, cryptoProvider bccsp.BCCSP) *cobra.Command {
+	cmd := eventsCmd(cf, cryptoProvider)
+	addFlags(cmd)
+	cmd.SetArgs(args)
+	return cmd
+}
+
+func eventsBlockResponse(number uint64, eventNames ...string) *ccevents.ChaincodeEventsResponse {
+	block := &ccevents.ChaincodeEventsBlock{ChannelId: "mychannel", Number: number}
+	for _, name := range eventNames {
+		block.Events = append(block.Events, &pb.ChaincodeEvent{
+			ChaincodeId: "mycc",
+			TxId:        "tx-" + name,
+			EventName:   name,
+			Payload:     []byte("payload"),
+		})
+	}
+	return &ccevents.ChaincodeEventsResponse{
+		Type: &ccevents.ChaincodeEventsResponse_Block{Block: block},
+	}
+}
+
+func unmarshalEventsEnvelope(t *testing.T, env *cb.Envelope) (*ccevents.ChaincodeEventsRequest, *ab.SeekInfo) {
+	payload, err := protoutil.UnmarshalPayload(env.Payload)
+	require.NoError(t, err)
+	chdr, err := protoutil.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	require.NoError(t, err)
+	require.Equal(t, int32(cb.HeaderType_DELIVER_SEEK_INFO), chdr.Type)
+	require.Equal(t, "mychannel", chdr.ChannelId)
+
+	request := &ccevents.ChaincodeEventsRequest{}
+	require.NoError(t, proto.Unmarshal(chdr.Extension, request))
+	seekInfo := &ab.SeekInfo{}
+	require.NoError(t, proto.Unmarshal(payload.Data, seekInfo))
+	return request, seekInfo
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/peer/ccevents"
 	"github.com/hyperledger/fabric/core/scc/cscc"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/hyperledger/fabric/msp"
@@ -61,6 +62,11 @@ var (
 	// by default it is set to GetDeliverClient function
 	GetDeliverClientFnc func(address, tlsRootCertFile string) (pb.Deliver_DeliverClient, error)
 
+	// GetChaincodeEventsClientFnc is a function that returns a new chaincode events
+	// client connection to the provided peer address using the TLS root cert file,
+	// by default it is set to GetChaincodeEventsClient function
+	GetChaincodeEventsClientFnc func(address, tlsRootCertFile string) (ccevents.ChaincodeEventsClient, error)
+
 	// GetDefaultSignerFnc is a function that returns a default Signer(Default/PERR)
 	// by default it is set to GetDefaultSigner function
 	GetDefaultSignerFnc func() (msp.SigningIdentity, error)
@@ -91,6 +97,7 @@ func init() {
 	GetOrdererEndpointOfChainFnc = GetOrdererEndpointOfChain
 	GetDeliverClientFnc = GetDeliverClient
 	GetPeerDeliverClientFnc = GetPeerDeliverClient
+	GetChaincodeEventsClientFnc = GetChaincodeEventsClient
 	GetCertificateFnc = GetCertificate
 }
 
@@ -183,6 +190,11 @@ func SetBCCSPConfigOverrides(bccspConfig *factory.FactoryOpts) error {
 		bccspConfig.PKCS11.Pin = pkcs11Pin
 	}
 
+	// Remote signer overrides
+	if remoteAddress, exist := os.LookupEnv("CORE_PEER_BCCSP_REMOTE_ADDRESS"); exist && bccspConfig.Remote != nil {
+		bccspConfig.Remote.Address = remoteAddress
+	}
+
 	return nil
 }
 
@@ -20,6 +20,7 @@ import (
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/bccsp/pkcs11"
+	"github.com/hyperledger/fabric/bccsp/remote"
 	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
@@ -279,6 +280,11 @@ func TestSetBCCSPConfigOverrides(t *testing.T) {
 			Label:    "test-pkcs11-label",
 			Pin:      "test-pkcs11-pin",
 		},
+		Remote: &remote.RemoteOpts{
+			Hash:     "SHA2",
+			Security: 256,
+			Address:  "test-remote-address:7060",
+		},
 	}
 
 	t.Run("success", func(t *testing.T) {
@@ -395,6 +401,7 @@ func setBCCSPEnvVariables(bccspConfig *factory.FactoryOpts) (cleanup func()) {
 	os.Setenv("CORE_PEER_BCCSP_PKCS11_PIN", bccspConfig.PKCS11.Pin)
 	os.Setenv("CORE_PEER_BCCSP_PKCS11_LABEL", bccspConfig.PKCS11.Label)
 	os.Setenv("CORE_PEER_BCCSP_PKCS11_LIBRARY", bccspConfig.PKCS11.Library)
+	os.Setenv("CORE_PEER_BCCSP_REMOTE_ADDRESS", bccspConfig.Remote.Address)
 
 	return func() {
 		os.Unsetenv("CORE_PEER_BCCSP_DEFAULT")
@@ -405,5 +412,6 @@ func setBCCSPEnvVariables(bccspConfig *factory.FactoryOpts) (cleanup func()) {
 		os.Unsetenv("CORE_PEER_BCCSP_PKCS11_PIN")
 		os.Unsetenv("CORE_PEER_BCCSP_PKCS11_LABEL")
 		os.Unsetenv("CORE_PEER_BCCSP_PKCS11_LIBRARY")
+		os.Unsetenv("CORE_PEER_BCCSP_REMOTE_ADDRESS")
 	}
 }
@@ -14,6 +14,7 @@ import (
 
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/peer/ccevents"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -191,6 +192,33 @@ func GetPeerDeliverClient(address, tlsRootCertFile string) (pb.DeliverClient, er
 	return peerClient.PeerDeliver()
 }
 
+// ChaincodeEventsClient returns a client for the chaincode events service
+func (pc *PeerClient) ChaincodeEventsClient() (ccevents.ChaincodeEventsClient, error) {
+	conn, err := pc.CommonClient.NewConnection(pc.Address, comm.ServerNameOverride(pc.sn))
+	if err != nil {
+		return nil, errors.WithMessagef(err, "chaincode events client failed to connect to %s", pc.Address)
+	}
+	return ccevents.NewChaincodeEventsClient(conn), nil
+}
+
+// GetChaincodeEventsClient returns a new chaincode events client. If both the
+// address and tlsRootCertFile are not provided, the target values for the
+// client are taken from the configuration settings for "peer.address" and
+// "peer.tls.rootcert.file"
+func GetChaincodeEventsClient(address, tlsRootCertFile string) (ccevents.ChaincodeEventsClient, error) {
+	var peerClient *PeerClient
+	var err error
+	if address != "" {
+		peerClient, err = NewPeerClientForAddress(address, tlsRootCertFile)
+	} else {
+		peerClient, err = NewPeerClientFromEnv()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return peerClient.ChaincodeEventsClient()
+}
+
 // SnapshotClient returns a client for the snapshot service
 func (pc *PeerClient) SnapshotClient() (pb.SnapshotClient, error) {
 	conn, err := pc.CommonClient.NewConnection(pc.Address, comm.ServerNameOverride(pc.sn))
@@ -112,6 +112,13 @@ func TestPeerClient(t *testing.T) {
 	dClient, err = common.GetDeliverClient("", "")
 	require.NoError(t, err)
 	require.NotNil(t, dClient)
+
+	ceClient, err := pClient1.ChaincodeEventsClient()
+	require.NoError(t, err)
+	require.NotNil(t, ceClient)
+	ceClient, err = common.GetChaincodeEventsClient("", "")
+	require.NoError(t, err)
+	require.NotNil(t, ceClient)
 }
 
 func TestPeerClientTimeout(t *testing.T) {
@@ -237,4 +244,8 @@ func TestGetClients_AddressError(t *testing.T) {
 	dClient, err := common.GetDeliverClient("peer0", "")
 	require.Contains(t, err.Error(), "tls root cert file must be set")
 	require.Nil(t, dClient)
+
+	ceClient, err := common.GetChaincodeEventsClient("peer0", "")
+	require.Contains(t, err.Error(), "tls root cert file must be set")
+	require.Nil(t, ceClient)
 }
@@ -32,6 +32,12 @@ type Hasher interface {
 	Hash(msg []byte, opts bccsp.HashOpts) (hash []byte, err error)
 }
 
+// RevocationChecker checks online whether the certificates of an identity
+// have been revoked.
+type RevocationChecker interface {
+	CheckIdentity(id msp.Identity) error
+}
+
 // MSPMessageCryptoService implements the MessageCryptoService interface
 // using the peer MSPs (local and channel-related)
 //
@@ -46,6 +52,7 @@ type MSPMessageCryptoService struct {
 	localSigner                identity.SignerSerializer
 	deserializer               mgmt.DeserializersManager
 	hasher                     Hasher
+	revocationChecker          RevocationChecker
 }
 
 // NewMCS creates a new instance of MSPMessageCryptoService
@@ -54,17 +61,22 @@ type MSPMessageCryptoService struct {
 // 1. a policies.ChannelPolicyManagerGetter that gives access to the policy manager of a given channel via the Manager method.
 // 2. an instance of identity.SignerSerializer
 // 3. an identity deserializer manager
+// 4. a hasher
+// 5. a revocation checker admitting only the peers whose certificates have
+// not been revoked, or nil to not check revocation online
 func NewMCS(
 	channelPolicyManagerGetter policies.ChannelPolicyManagerGetter,
 	localSigner identity.SignerSerializer,
 	deserializer mgmt.DeserializersManager,
 	hasher Hasher,
+	revocationChecker RevocationChecker,
 ) *MSPMessageCryptoService {
 	return &MSPMessageCryptoService{
 		channelPolicyManagerGetter: channelPolicyManagerGetter,
 		localSigner:                localSigner,
 		deserializer:               deserializer,
 		hasher:                     hasher,
+		revocationChecker:          revocationChecker,
 	}
 }
 
@@ -76,8 +88,17 @@ func (s *MSPMessageCryptoService) ValidateIdentity(peerIdentity api.PeerIdentity
 	// below we check only that peerIdentity is not
 	// invalid, revoked or expired.
 
-	_, _, err := s.getValidatedIdentity(peerIdentity)
-	return err
+	identity, _, err := s.getValidatedIdentity(peerIdentity)
+	if err != nil {
+		return err
+	}
+
+	// Revocation is checked online when peers are admitted only, the
+	// validation of blocks and of transactions does not depend on it
+	if s.revocationChecker != nil {
+		return s.revocationChecker.CheckIdentity(identity)
+	}
+	return nil
 }
 
 // GetPKIidOfCert returns the PKI-ID of a peer's identity
@@ -56,6 +56,7 @@ func TestPKIidOfCert(t *testing.T) {
 		signer,
 		deserializersManager,
 		cryptoProvider,
+		nil,
 	)
 
 	peerIdentity := []byte("Alice")
@@ -89,7 +90,7 @@ func TestPKIidOfNil(t *testing.T) {
 	signer := &mocks.SignerSerializer{}
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	require.NoError(t, err)
-	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(cryptoProvider), cryptoProvider)
+	msgCryptoService := NewMCS(&mocks.ChannelPolicyManagerGetter{}, signer, mgmt.NewDeserializersManager(cryptoProvider), cryptoProvider, nil)
 
 	pkid := msgCryptoService.GetPKIidOfCert(nil)
 	// Check pkid is not nil
@@ -112,6 +113,7 @@ func TestValidateIdentity(t *testing.T) {
 		signer,
 		deserializersManager,
 		cryptoProvider,
+		nil,
 	)
 
 	err = msgCryptoService.ValidateIdentity([]byte("Alice"))
@@ -138,6 +140,48 @@ func TestValidateIdentity(t *testing.T) {
 	require.Equal(t, "identity is not well formed: invalid form", err.Error())
 }
 
+type revocationCheckerFunc func(msp.Identity) error
+
+func (f revocationCheckerFunc) CheckIdentity(id msp.Identity) error {
+	return f(id)
+}
+
+func TestValidateIdentityRevoked(t *testing.T) {
+	deserializersManager := &mocks.DeserializersManager{
+		LocalDeserializer: &mocks.IdentityDeserializer{Identity: []byte("Alice"), Msg: []byte("msg1"), Mock: mock.Mock{}},
+		ChannelDeserializers: map[string]msp.IdentityDeserializer{
+			"A": &mocks.IdentityDeserializer{Identity: []byte("Bob"), Msg: []byte("msg2"), Mock: mock.Mock{}},
+		},
+	}
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+	var checked []string
+	msgCryptoService := NewMCS(
+		&mocks.ChannelPolicyManagerGetterWithManager{},
+		&mocks.SignerSerializer{},
+		deserializersManager,
+		cryptoProvider,
+		revocationCheckerFunc(func(id msp.Identity) error {
+			msg := string(id.(*mocks.Identity).Msg)
+			checked = append(checked, msg)
+			if msg == "msg2" {
+				return errors.New("The certificate [10] has been revoked (ocsp)")
+			}
+			return nil
+		}),
+	)
+
+	err = msgCryptoService.ValidateIdentity([]byte("Alice"))
+	require.NoError(t, err)
+
+	err = msgCryptoService.ValidateIdentity([]byte("Bob"))
+	require.EqualError(t, err, "The certificate [10] has been revoked (ocsp)")
+
+	err = msgCryptoService.ValidateIdentity([]byte("Charlie"))
+	require.Error(t, err)
+	require.Equal(t, []string{"msg1", "msg2"}, checked)
+}
+
 func TestSign(t *testing.T) {
 	signer := &mocks.SignerSerializer{}
 	signer.SignReturns([]byte("signature"), nil)
@@ -149,6 +193,7 @@ func TestSign(t *testing.T) {
 		signer,
 		mgmt.NewDeserializersManager(cryptoProvider),
 		cryptoProvider,
+		nil,
 	)
 
 	msg := []byte("Hello World!!!")
@@ -185,6 +230,7 @@ func TestVerify(t *testing.T) {
 			},
 		},
 		cryptoProvider,
+		nil,
 	)
 
 	msg := []byte("msg1")
@@ -243,6 +289,7 @@ func TestVerifyBlock(t *testing.T) {
 			},
 		},
 		cryptoProvider,
+		nil,
 	)
 
 	// - Prepare testing valid block, Alice signs it.
@@ -360,6 +407,7 @@ func TestExpiration(t *testing.T) {
 		&mocks.SignerSerializer{},
 		deserializersManager,
 		cryptoProvider,
+		nil,
 	)
 
 	// Green path I check the expiration date is as expected
@@ -55,6 +55,9 @@ type ApproveForMyOrgInput struct {
 	WaitForEvent             bool
 	WaitForEventTimeout      time.Duration
 	TxID                     string
+	// Definitions, when set, are approved instead of the definition
+	// specified by the other fields
+	Definitions []*DefinitionInput
 }
 
 // Validate the input for an ApproveChaincodeDefinitionForMyOrg proposal
@@ -63,6 +66,10 @@ func (a *ApproveForMyOrgInput) Validate() error {
 		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
 	}
 
+	if len(a.Definitions) > 0 {
+		return validateDefinitions(a.Name, a.Definitions)
+	}
+
 	if a.Name == "" {
 		return errors.New("The required parameter 'name' is empty. Rerun the command with -n flag")
 	}
@@ -138,6 +145,7 @@ func ApproveForMyOrgCmd(a *ApproverForMyOrg, cryptoProvider bccsp.BCCSP) *cobra.
 		"channel-config-policy",
 		"init-required",
 		"collections-config",
+		"definitions-file",
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
@@ -271,6 +279,13 @@ func (a *ApproverForMyOrg) createInput() (*ApproveForMyOrgInput, error) {
 		WaitForEventTimeout:      waitForEventTimeout,
 	}
 
+	if definitionsFile != "" {
+		input.Definitions, err = readDefinitionsFile(definitionsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return input, nil
 }
 
@@ -279,24 +294,8 @@ func (a *ApproverForMyOrg) createProposal(inputTxID string) (proposal *pb.Propos
 		return nil, "", errors.New("nil signer provided")
 	}
 
-	var ccsrc *lb.ChaincodeSource
-	if a.Input.PackageID != "" {
-		ccsrc = &lb.ChaincodeSource{
-			Type: &lb.ChaincodeSource_LocalPackage{
-				LocalPackage: &lb.ChaincodeSource_Local{
-					PackageId: a.Input.PackageID,
-				},
-			},
-		}
-	} else {
-		ccsrc = &lb.ChaincodeSource{
-			Type: &lb.ChaincodeSource_Unavailable_{
-				Unavailable: &lb.ChaincodeSource_Unavailable{},
-			},
-		}
-	}
-
-	args := &lb.ApproveChaincodeDefinitionForMyOrgArgs{
+	funcName := approveFuncName
+	var args proto.Message = &lb.ApproveChaincodeDefinitionForMyOrgArgs{
 		Name:                a.Input.Name,
 		Version:             a.Input.Version,
 		Sequence:            a.Input.Sequence,
@@ -305,14 +304,18 @@ func (a *ApproverForMyOrg) createProposal(inputTxID string) (proposal *pb.Propos
 		ValidationParameter: a.Input.ValidationParameterBytes,
 		InitRequired:        a.Input.InitRequired,
 		Collections:         a.Input.CollectionConfigPackage,
-		Source:              ccsrc,
+		Source:              chaincodeSource(a.Input.PackageID),
+	}
+	if len(a.Input.Definitions) > 0 {
+		funcName = approveDefinitionsFuncName
+		args = approveDefinitionsArgs(a.Input.Definitions)
 	}
 
 	argsBytes, err := proto.Marshal(args)
 	if err != nil {
 		return nil, "", err
 	}
-	ccInput := &pb.ChaincodeInput{Args: [][]byte{[]byte(approveFuncName), argsBytes}}
+	ccInput := &pb.ChaincodeInput{Args: [][]byte{[]byte(funcName), argsBytes}}
 
 	cis := &pb.ChaincodeInvocationSpec{
 		ChaincodeSpec: &pb.ChaincodeSpec{
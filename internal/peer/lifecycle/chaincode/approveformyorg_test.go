@@ -11,8 +11,10 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/batch"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
 	"github.com/pkg/errors"
@@ -78,6 +80,66 @@ var _ = Describe("ApproverForMyOrg", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		Context("when several chaincode definitions are provided", func() {
+			BeforeEach(func() {
+				approver.Input.Name = ""
+				approver.Input.Definitions = []*chaincode.DefinitionInput{
+					{Name: "testcc", Version: "1.0", PackageID: "testpackageid", Sequence: 2},
+					{Name: "othercc", Version: "2.0", Sequence: 1},
+				}
+			})
+
+			It("approves all the chaincode definitions in a single proposal", func() {
+				err := approver.Approve()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockEndorserClient.ProcessProposalCallCount()).To(Equal(1))
+				_, signedProposal, _ := mockEndorserClient.ProcessProposalArgsForCall(0)
+				args := proposalArgs(signedProposal)
+				Expect(string(args[0])).To(Equal("ApproveChaincodeDefinitionsForMyOrg"))
+				approveArgs := &batch.ApproveChaincodeDefinitionsForMyOrgArgs{}
+				Expect(proto.Unmarshal(args[1], approveArgs)).To(Succeed())
+				Expect(approveArgs.Definitions).To(HaveLen(2))
+				Expect(approveArgs.Definitions[0].Name).To(Equal("testcc"))
+				Expect(approveArgs.Definitions[0].Source.GetLocalPackage().PackageId).To(Equal("testpackageid"))
+				Expect(approveArgs.Definitions[1].Name).To(Equal("othercc"))
+				Expect(approveArgs.Definitions[1].Source.GetUnavailable()).NotTo(BeNil())
+			})
+
+			Context("when the chaincode name is also provided", func() {
+				BeforeEach(func() {
+					approver.Input.Name = "testcc"
+				})
+
+				It("returns an error", func() {
+					err := approver.Approve()
+					Expect(err).To(MatchError("cannot specify both \"--definitions-file\" and \"--name\""))
+				})
+			})
+
+			Context("when a chaincode has more than one definition", func() {
+				BeforeEach(func() {
+					approver.Input.Definitions[1].Name = "testcc"
+				})
+
+				It("returns an error", func() {
+					err := approver.Approve()
+					Expect(err).To(MatchError("chaincode 'testcc' has more than one definition"))
+				})
+			})
+
+			Context("when a definition has no sequence", func() {
+				BeforeEach(func() {
+					approver.Input.Definitions[1].Sequence = 0
+				})
+
+				It("returns an error", func() {
+					err := approver.Approve()
+					Expect(err).To(MatchError("definition of chaincode 'othercc' has no sequence"))
+				})
+			})
+		})
+
 		Context("when the channel name is not provided", func() {
 			BeforeEach(func() {
 				approver.Input.ChannelID = ""
@@ -326,6 +388,54 @@ var _ = Describe("ApproverForMyOrg", func() {
 			Expect(err).To(MatchError(ContainSubstring("failed to retrieve endorser client")))
 		})
 
+		Context("when a definitions file is specified", func() {
+			BeforeEach(func() {
+				approveForMyOrgCmd.SetArgs([]string{
+					"--channelID=testchannel",
+					"--definitions-file=testdata/definitions.json",
+					"--peerAddresses=querypeer1",
+					"--tlsRootCertFiles=tls1",
+				})
+			})
+
+			It("sets up the approver for my org and attempts to approve the chaincode definitions", func() {
+				err := approveForMyOrgCmd.Execute()
+				Expect(err).To(MatchError(ContainSubstring("failed to retrieve endorser client")))
+			})
+		})
+
+		Context("when the definitions file does not exist", func() {
+			BeforeEach(func() {
+				approveForMyOrgCmd.SetArgs([]string{
+					"--channelID=testchannel",
+					"--definitions-file=testdata/missing.json",
+					"--peerAddresses=querypeer1",
+					"--tlsRootCertFiles=tls1",
+				})
+			})
+
+			It("returns an error", func() {
+				err := approveForMyOrgCmd.Execute()
+				Expect(err).To(MatchError(ContainSubstring("could not read definitions file testdata/missing.json")))
+			})
+		})
+
+		Context("when the definitions file is not valid", func() {
+			BeforeEach(func() {
+				approveForMyOrgCmd.SetArgs([]string{
+					"--channelID=testchannel",
+					"--definitions-file=testdata/connectionprofile.yaml",
+					"--peerAddresses=querypeer1",
+					"--tlsRootCertFiles=tls1",
+				})
+			})
+
+			It("returns an error", func() {
+				err := approveForMyOrgCmd.Execute()
+				Expect(err).To(MatchError(ContainSubstring("could not parse definitions file testdata/connectionprofile.yaml")))
+			})
+		})
+
 		Context("when the channel config policy is specified", func() {
 			BeforeEach(func() {
 				approveForMyOrgCmd.SetArgs([]string{
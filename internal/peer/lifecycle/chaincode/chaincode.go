@@ -17,10 +17,13 @@ import (
 )
 
 const (
-	lifecycleName                = "_lifecycle"
-	approveFuncName              = "ApproveChaincodeDefinitionForMyOrg"
-	commitFuncName               = "CommitChaincodeDefinition"
-	checkCommitReadinessFuncName = "CheckCommitReadiness"
+	lifecycleName                              = "_lifecycle"
+	approveFuncName                            = "ApproveChaincodeDefinitionForMyOrg"
+	approveDefinitionsFuncName                 = "ApproveChaincodeDefinitionsForMyOrg"
+	commitFuncName                             = "CommitChaincodeDefinition"
+	commitDefinitionsFuncName                  = "CommitChaincodeDefinitions"
+	checkCommitReadinessFuncName               = "CheckCommitReadiness"
+	checkCommitReadinessForDefinitionsFuncName = "CheckCommitReadinessForDefinitions"
 )
 
 var logger = flogging.MustGetLogger("cli.lifecycle.chaincode")
@@ -59,6 +62,7 @@ var (
 	endorsementPlugin     string
 	validationPlugin      string
 	collectionsConfigFile string
+	definitionsFile       string
 	peerAddresses         []string
 	tlsRootCertFiles      []string
 	connectionProfilePath string
@@ -103,6 +107,7 @@ func ResetFlags() {
 	flags.StringVarP(&endorsementPlugin, "endorsement-plugin", "E", "", "The name of the endorsement plugin to be used for this chaincode")
 	flags.StringVarP(&validationPlugin, "validation-plugin", "V", "", "The name of the validation plugin to be used for this chaincode")
 	flags.StringVar(&collectionsConfigFile, "collections-config", "", "The fully qualified path to the collection JSON file including the file name")
+	flags.StringVar(&definitionsFile, "definitions-file", "", "The fully qualified path to a JSON file listing several chaincode definitions to process in a single transaction, instead of the definition specified by the other flags")
 	flags.StringArrayVarP(&peerAddresses, "peerAddresses", "", []string{""}, "The addresses of the peers to connect to")
 	flags.StringArrayVarP(&tlsRootCertFiles, "tlsRootCertFiles", "", []string{""},
 		"If TLS is enabled, the paths to the TLS root cert files of the peers to connect to. The order and number of certs specified should match the --peerAddresses flag")
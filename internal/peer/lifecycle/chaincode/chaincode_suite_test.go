@@ -16,6 +16,7 @@ import (
 	"github.com/hyperledger/fabric/internal/peer/common"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
 	msptesttools "github.com/hyperledger/fabric/msp/mgmt/testtools"
+	"github.com/hyperledger/fabric/protoutil"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -69,6 +70,17 @@ var _ = BeforeSuite(func() {
 	flogging.SetWriter(GinkgoWriter)
 })
 
+// proposalArgs returns the chaincode input arguments of a signed proposal.
+func proposalArgs(signedProposal *pb.SignedProposal) [][]byte {
+	proposal, err := protoutil.UnmarshalProposal(signedProposal.ProposalBytes)
+	Expect(err).NotTo(HaveOccurred())
+	cpp, err := protoutil.UnmarshalChaincodeProposalPayload(proposal.Payload)
+	Expect(err).NotTo(HaveOccurred())
+	cis, err := protoutil.UnmarshalChaincodeInvocationSpec(cpp.Input)
+	Expect(err).NotTo(HaveOccurred())
+	return cis.ChaincodeSpec.Input.Args
+}
+
 // TODO remove this?
 func TestMain(m *testing.M) {
 	err := msptesttools.LoadMSPSetupForTesting()
@@ -19,6 +19,7 @@ import (
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/batch"
 	"github.com/hyperledger/fabric/internal/pkg/identity"
 	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
@@ -57,6 +58,9 @@ type CommitReadinessCheckInput struct {
 	PeerAddresses            []string
 	TxID                     string
 	OutputFormat             string
+	// Definitions, when set, are checked instead of the definition
+	// specified by the other fields
+	Definitions []*DefinitionInput
 }
 
 // Validate the input for a CheckCommitReadiness proposal
@@ -65,6 +69,10 @@ func (c *CommitReadinessCheckInput) Validate() error {
 		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
 	}
 
+	if len(c.Definitions) > 0 {
+		return validateDefinitions(c.Name, c.Definitions)
+	}
+
 	if c.Name == "" {
 		return errors.New("The required parameter 'name' is empty. Rerun the command with -n flag")
 	}
@@ -133,6 +141,7 @@ func CheckCommitReadinessCmd(c *CommitReadinessChecker, cryptoProvider bccsp.BCC
 		"channel-config-policy",
 		"init-required",
 		"collections-config",
+		"definitions-file",
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
@@ -184,6 +193,13 @@ func (c *CommitReadinessChecker) ReadinessCheck() error {
 		return errors.Errorf("query failed with status: %d - %s", proposalResponse.Response.Status, proposalResponse.Response.Message)
 	}
 
+	if len(c.Input.Definitions) > 0 {
+		if strings.ToLower(c.Input.OutputFormat) == "json" {
+			return printResponseAsJSON(proposalResponse, &batch.CheckCommitReadinessForDefinitionsResult{}, c.Writer)
+		}
+		return c.printDefinitionsResponse(proposalResponse)
+	}
+
 	if strings.ToLower(c.Input.OutputFormat) == "json" {
 		return printResponseAsJSON(proposalResponse, &lb.CheckCommitReadinessResult{}, c.Writer)
 	}
@@ -213,6 +229,36 @@ func (c *CommitReadinessChecker) printResponse(proposalResponse *pb.ProposalResp
 	return nil
 }
 
+// printDefinitionsResponse prints the readiness of each of the checked
+// definitions as human readable plain-text.
+func (c *CommitReadinessChecker) printDefinitionsResponse(proposalResponse *pb.ProposalResponse) error {
+	result := &batch.CheckCommitReadinessForDefinitionsResult{}
+	err := proto.Unmarshal(proposalResponse.Response.Payload, result)
+	if err != nil {
+		return errors.Wrap(err, "failed to unmarshal proposal response's response payload")
+	}
+
+	for _, readiness := range result.Definitions {
+		if readiness.Error != "" {
+			fmt.Fprintf(c.Writer, "Chaincode definition for chaincode '%s', sequence '%d' on channel '%s' cannot be committed: %s\n", readiness.Name, readiness.Sequence, c.Input.ChannelID, readiness.Error)
+			continue
+		}
+
+		orgs := []string{}
+		for org := range readiness.Approvals {
+			orgs = append(orgs, org)
+		}
+		sort.Strings(orgs)
+
+		fmt.Fprintf(c.Writer, "Chaincode definition for chaincode '%s', sequence '%d' on channel '%s' approval status by org:\n", readiness.Name, readiness.Sequence, c.Input.ChannelID)
+		for _, org := range orgs {
+			fmt.Fprintf(c.Writer, "%s: %t\n", org, readiness.Approvals[org])
+		}
+	}
+
+	return nil
+}
+
 // setInput creates the input struct based on the CLI flags
 func (c *CommitReadinessChecker) createInput() (*CommitReadinessCheckInput, error) {
 	policyBytes, err := createPolicyBytes(signaturePolicy, channelConfigPolicy)
@@ -240,11 +286,19 @@ func (c *CommitReadinessChecker) createInput() (*CommitReadinessCheckInput, erro
 		OutputFormat:             output,
 	}
 
+	if definitionsFile != "" {
+		input.Definitions, err = readDefinitionsFile(definitionsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return input, nil
 }
 
 func (c *CommitReadinessChecker) createProposal(inputTxID string) (*pb.Proposal, error) {
-	args := &lb.CheckCommitReadinessArgs{
+	funcName := checkCommitReadinessFuncName
+	var args proto.Message = &lb.CheckCommitReadinessArgs{
 		Name:                c.Input.Name,
 		Version:             c.Input.Version,
 		Sequence:            c.Input.Sequence,
@@ -254,12 +308,16 @@ func (c *CommitReadinessChecker) createProposal(inputTxID string) (*pb.Proposal,
 		InitRequired:        c.Input.InitRequired,
 		Collections:         c.Input.CollectionConfigPackage,
 	}
+	if len(c.Input.Definitions) > 0 {
+		funcName = checkCommitReadinessForDefinitionsFuncName
+		args = checkCommitReadinessForDefinitionsArgs(c.Input.Definitions)
+	}
 
 	argsBytes, err := proto.Marshal(args)
 	if err != nil {
 		return nil, err
 	}
-	ccInput := &pb.ChaincodeInput{Args: [][]byte{[]byte(checkCommitReadinessFuncName), argsBytes}}
+	ccInput := &pb.ChaincodeInput{Args: [][]byte{[]byte(funcName), argsBytes}}
 
 	cis := &pb.ChaincodeInvocationSpec{
 		ChaincodeSpec: &pb.ChaincodeSpec{
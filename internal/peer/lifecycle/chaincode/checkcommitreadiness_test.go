@@ -13,8 +13,10 @@ import (
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
 	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/batch"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
+	"github.com/hyperledger/fabric/protoutil"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -81,6 +83,61 @@ var _ = Describe("CheckCommitReadiness", func() {
 			Eventually(commitReadinessChecker.Writer).Should(gbytes.Say("well...ok: true"))
 		})
 
+		Context("when several chaincode definitions are provided", func() {
+			BeforeEach(func() {
+				mockResult := &batch.CheckCommitReadinessForDefinitionsResult{
+					Definitions: []*batch.CheckCommitReadinessForDefinitionsResult_Readiness{
+						{
+							Name:      "testcc",
+							Sequence:  2,
+							Approvals: map[string]bool{"seemsfinetome": true, "absolutely-not": false},
+						},
+						{
+							Name:     "othercc",
+							Sequence: 1,
+							Error:    "requested sequence is 1, but new definition must be sequence 2",
+						},
+					},
+				}
+				mockProposalResponse.Response.Payload = protoutil.MarshalOrPanic(mockResult)
+
+				commitReadinessChecker.Input.Name = ""
+				commitReadinessChecker.Input.Definitions = []*chaincode.DefinitionInput{
+					{Name: "testcc", Version: "1.0", PackageID: "testpackageid", Sequence: 2},
+					{Name: "othercc", Version: "2.0", Sequence: 1},
+				}
+			})
+
+			It("checks the readiness of each chaincode definition and writes the output as human readable plain-text", func() {
+				err := commitReadinessChecker.ReadinessCheck()
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(commitReadinessChecker.Writer).Should(gbytes.Say("Chaincode definition for chaincode 'testcc', sequence '2' on channel 'testchannel' approval status by org"))
+				Eventually(commitReadinessChecker.Writer).Should(gbytes.Say("absolutely-not: false"))
+				Eventually(commitReadinessChecker.Writer).Should(gbytes.Say("seemsfinetome: true"))
+				Eventually(commitReadinessChecker.Writer).Should(gbytes.Say("Chaincode definition for chaincode 'othercc', sequence '1' on channel 'testchannel' cannot be committed: requested sequence is 1, but new definition must be sequence 2"))
+
+				_, signedProposal, _ := mockEndorserClient.ProcessProposalArgsForCall(0)
+				args := proposalArgs(signedProposal)
+				Expect(string(args[0])).To(Equal("CheckCommitReadinessForDefinitions"))
+				checkArgs := &batch.CheckCommitReadinessForDefinitionsArgs{}
+				Expect(proto.Unmarshal(args[1], checkArgs)).To(Succeed())
+				Expect(checkArgs.Definitions).To(HaveLen(2))
+			})
+
+			Context("when JSON-formatted output is requested", func() {
+				BeforeEach(func() {
+					commitReadinessChecker.Input.OutputFormat = "json"
+				})
+
+				It("writes the output as JSON", func() {
+					err := commitReadinessChecker.ReadinessCheck()
+					Expect(err).NotTo(HaveOccurred())
+					Eventually(commitReadinessChecker.Writer).Should(gbytes.Say(`"name": "othercc"`))
+					Eventually(commitReadinessChecker.Writer).Should(gbytes.Say(`"error": "requested sequence is 1, but new definition must be sequence 2"`))
+				})
+			})
+		})
+
 		Context("when JSON-formatted output is requested", func() {
 			BeforeEach(func() {
 				commitReadinessChecker.Input.OutputFormat = "json"
@@ -55,6 +55,9 @@ type CommitInput struct {
 	WaitForEvent             bool
 	WaitForEventTimeout      time.Duration
 	TxID                     string
+	// Definitions, when set, are committed instead of the definition
+	// specified by the other fields
+	Definitions []*DefinitionInput
 }
 
 // Validate the input for a CommitChaincodeDefinition proposal
@@ -63,6 +66,10 @@ func (c *CommitInput) Validate() error {
 		return errors.New("The required parameter 'channelID' is empty. Rerun the command with -C flag")
 	}
 
+	if len(c.Definitions) > 0 {
+		return validateDefinitions(c.Name, c.Definitions)
+	}
+
 	if c.Name == "" {
 		return errors.New("The required parameter 'name' is empty. Rerun the command with -n flag")
 	}
@@ -137,6 +144,7 @@ func CommitCmd(c *Committer, cryptoProvider bccsp.BCCSP) *cobra.Command {
 		"channel-config-policy",
 		"init-required",
 		"collections-config",
+		"definitions-file",
 		"peerAddresses",
 		"tlsRootCertFiles",
 		"connectionProfile",
@@ -268,11 +276,19 @@ func (c *Committer) createInput() (*CommitInput, error) {
 		WaitForEventTimeout:      waitForEventTimeout,
 	}
 
+	if definitionsFile != "" {
+		input.Definitions, err = readDefinitionsFile(definitionsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return input, nil
 }
 
 func (c *Committer) createProposal(inputTxID string) (proposal *pb.Proposal, txID string, err error) {
-	args := &lb.CommitChaincodeDefinitionArgs{
+	funcName := commitFuncName
+	var args proto.Message = &lb.CommitChaincodeDefinitionArgs{
 		Name:                c.Input.Name,
 		Version:             c.Input.Version,
 		Sequence:            c.Input.Sequence,
@@ -282,12 +298,16 @@ func (c *Committer) createProposal(inputTxID string) (proposal *pb.Proposal, txI
 		InitRequired:        c.Input.InitRequired,
 		Collections:         c.Input.CollectionConfigPackage,
 	}
+	if len(c.Input.Definitions) > 0 {
+		funcName = commitDefinitionsFuncName
+		args = commitDefinitionsArgs(c.Input.Definitions)
+	}
 
 	argsBytes, err := proto.Marshal(args)
 	if err != nil {
 		return nil, "", err
 	}
-	ccInput := &pb.ChaincodeInput{Args: [][]byte{[]byte(commitFuncName), argsBytes}}
+	ccInput := &pb.ChaincodeInput{Args: [][]byte{[]byte(funcName), argsBytes}}
 
 	cis := &pb.ChaincodeInvocationSpec{
 		ChaincodeSpec: &pb.ChaincodeSpec{
@@ -11,8 +11,10 @@ import (
 	"crypto/tls"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	pb "github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/batch"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode"
 	"github.com/hyperledger/fabric/internal/peer/lifecycle/chaincode/mock"
 	"github.com/pkg/errors"
@@ -77,6 +79,44 @@ var _ = Describe("Commit", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		Context("when several chaincode definitions are provided", func() {
+			BeforeEach(func() {
+				committer.Input.Name = ""
+				committer.Input.Definitions = []*chaincode.DefinitionInput{
+					{Name: "testcc", Version: "1.0", PackageID: "testpackageid", Sequence: 2},
+					{Name: "othercc", Version: "2.0", Sequence: 1},
+				}
+			})
+
+			It("commits all the chaincode definitions in a single proposal", func() {
+				err := committer.Commit()
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(mockEndorserClient.ProcessProposalCallCount()).To(Equal(1))
+				_, signedProposal, _ := mockEndorserClient.ProcessProposalArgsForCall(0)
+				args := proposalArgs(signedProposal)
+				Expect(string(args[0])).To(Equal("CommitChaincodeDefinitions"))
+				commitArgs := &batch.CommitChaincodeDefinitionsArgs{}
+				Expect(proto.Unmarshal(args[1], commitArgs)).To(Succeed())
+				Expect(commitArgs.Definitions).To(HaveLen(2))
+				Expect(commitArgs.Definitions[0].Name).To(Equal("testcc"))
+				Expect(commitArgs.Definitions[0].Sequence).To(Equal(int64(2)))
+				Expect(commitArgs.Definitions[1].Name).To(Equal("othercc"))
+				Expect(commitArgs.Definitions[1].Version).To(Equal("2.0"))
+			})
+
+			Context("when a definition has no version", func() {
+				BeforeEach(func() {
+					committer.Input.Definitions[0].Version = ""
+				})
+
+				It("returns an error", func() {
+					err := committer.Commit()
+					Expect(err).To(MatchError("definition of chaincode 'testcc' has no version"))
+				})
+			})
+		})
+
 		Context("when the channel name is not provided", func() {
 			BeforeEach(func() {
 				committer.Input.ChannelID = ""
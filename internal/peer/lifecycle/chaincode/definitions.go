@@ -0,0 +1,198 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	pb "github.com/hyperledger/fabric-protos-go/peer"
+	lb "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle/batch"
+	"github.com/pkg/errors"
+)
+
+// DefinitionInput holds the parameters of one of several chaincode
+// definitions approved, checked, or committed in a single proposal.
+// ValidationParameterBytes is the (marshalled) endorsement policy when using
+// the default endorsement and validation plugins
+type DefinitionInput struct {
+	Name                     string
+	Version                  string
+	PackageID                string
+	Sequence                 int64
+	EndorsementPlugin        string
+	ValidationPlugin         string
+	ValidationParameterBytes []byte
+	CollectionConfigPackage  *pb.CollectionConfigPackage
+	InitRequired             bool
+}
+
+// definitionFileEntry is the JSON representation of a chaincode definition
+// in a definitions file.
+type definitionFileEntry struct {
+	Name                string `json:"name"`
+	Version             string `json:"version"`
+	PackageID           string `json:"package_id"`
+	Sequence            int64  `json:"sequence"`
+	EndorsementPlugin   string `json:"endorsement_plugin"`
+	ValidationPlugin    string `json:"validation_plugin"`
+	SignaturePolicy     string `json:"signature_policy"`
+	ChannelConfigPolicy string `json:"channel_config_policy"`
+	InitRequired        bool   `json:"init_required"`
+	CollectionsConfig   string `json:"collections_config"`
+}
+
+// readDefinitionsFile reads the chaincode definitions listed in a JSON
+// definitions file. Relative paths to collection configuration files are
+// relative to the directory of the definitions file.
+func readDefinitionsFile(path string) ([]*DefinitionInput, error) {
+	fileBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read definitions file %s", path)
+	}
+
+	var entries []*definitionFileEntry
+	if err := json.Unmarshal(fileBytes, &entries); err != nil {
+		return nil, errors.Wrapf(err, "could not parse definitions file %s", path)
+	}
+	if len(entries) == 0 {
+		return nil, errors.Errorf("no chaincode definitions found in definitions file %s", path)
+	}
+
+	definitions := make([]*DefinitionInput, len(entries))
+	for i, e := range entries {
+		policyBytes, err := createPolicyBytes(e.SignaturePolicy, e.ChannelConfigPolicy)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid definition %d in definitions file %s", i, path)
+		}
+
+		collectionsConfig := e.CollectionsConfig
+		if collectionsConfig != "" && !filepath.IsAbs(collectionsConfig) {
+			collectionsConfig = filepath.Join(filepath.Dir(path), collectionsConfig)
+		}
+		ccp, err := createCollectionConfigPackage(collectionsConfig)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid definition %d in definitions file %s", i, path)
+		}
+
+		definitions[i] = &DefinitionInput{
+			Name:                     e.Name,
+			Version:                  e.Version,
+			PackageID:                e.PackageID,
+			Sequence:                 e.Sequence,
+			EndorsementPlugin:        e.EndorsementPlugin,
+			ValidationPlugin:         e.ValidationPlugin,
+			ValidationParameterBytes: policyBytes,
+			CollectionConfigPackage:  ccp,
+			InitRequired:             e.InitRequired,
+		}
+	}
+
+	return definitions, nil
+}
+
+// validateDefinitions validates the definitions of a proposal for several
+// chaincode definitions. name is the value of the name flag, which cannot
+// be combined with the definitions file.
+func validateDefinitions(name string, definitions []*DefinitionInput) error {
+	if name != "" {
+		return errors.New("cannot specify both \"--definitions-file\" and \"--name\"")
+	}
+
+	names := map[string]struct{}{}
+	for i, d := range definitions {
+		if d.Name == "" {
+			return errors.Errorf("definition %d has no name", i)
+		}
+		if _, ok := names[d.Name]; ok {
+			return errors.Errorf("chaincode '%s' has more than one definition", d.Name)
+		}
+		names[d.Name] = struct{}{}
+
+		if d.Version == "" {
+			return errors.Errorf("definition of chaincode '%s' has no version", d.Name)
+		}
+		if d.Sequence == 0 {
+			return errors.Errorf("definition of chaincode '%s' has no sequence", d.Name)
+		}
+	}
+
+	return nil
+}
+
+// chaincodeSource returns the source of an approved chaincode definition.
+func chaincodeSource(packageID string) *lb.ChaincodeSource {
+	if packageID == "" {
+		return &lb.ChaincodeSource{
+			Type: &lb.ChaincodeSource_Unavailable_{
+				Unavailable: &lb.ChaincodeSource_Unavailable{},
+			},
+		}
+	}
+
+	return &lb.ChaincodeSource{
+		Type: &lb.ChaincodeSource_LocalPackage{
+			LocalPackage: &lb.ChaincodeSource_Local{
+				PackageId: packageID,
+			},
+		},
+	}
+}
+
+func approveDefinitionsArgs(definitions []*DefinitionInput) *batch.ApproveChaincodeDefinitionsForMyOrgArgs {
+	args := &batch.ApproveChaincodeDefinitionsForMyOrgArgs{}
+	for _, d := range definitions {
+		args.Definitions = append(args.Definitions, &lb.ApproveChaincodeDefinitionForMyOrgArgs{
+			Name:                d.Name,
+			Version:             d.Version,
+			Sequence:            d.Sequence,
+			EndorsementPlugin:   d.EndorsementPlugin,
+			ValidationPlugin:    d.ValidationPlugin,
+			ValidationParameter: d.ValidationParameterBytes,
+			InitRequired:        d.InitRequired,
+			Collections:         d.CollectionConfigPackage,
+			Source:              chaincodeSource(d.PackageID),
+		})
+	}
+	return args
+}
+
+func checkCommitReadinessForDefinitionsArgs(definitions []*DefinitionInput) *batch.CheckCommitReadinessForDefinitionsArgs {
+	args := &batch.CheckCommitReadinessForDefinitionsArgs{}
+	for _, d := range definitions {
+		args.Definitions = append(args.Definitions, &lb.CheckCommitReadinessArgs{
+			Name:                d.Name,
+			Version:             d.Version,
+			Sequence:            d.Sequence,
+			EndorsementPlugin:   d.EndorsementPlugin,
+			ValidationPlugin:    d.ValidationPlugin,
+			ValidationParameter: d.ValidationParameterBytes,
+			InitRequired:        d.InitRequired,
+			Collections:         d.CollectionConfigPackage,
+		})
+	}
+	return args
+}
+
+func commitDefinitionsArgs(definitions []*DefinitionInput) *batch.CommitChaincodeDefinitionsArgs {
+	args := &batch.CommitChaincodeDefinitionsArgs{}
+	for _, d := range definitions {
+		args.Definitions = append(args.Definitions, &lb.CommitChaincodeDefinitionArgs{
+			Name:                d.Name,
+			Version:             d.Version,
+			Sequence:            d.Sequence,
+			EndorsementPlugin:   d.EndorsementPlugin,
+			ValidationPlugin:    d.ValidationPlugin,
+			ValidationParameter: d.ValidationParameterBytes,
+			InitRequired:        d.InitRequired,
+			Collections:         d.CollectionConfigPackage,
+		})
+	}
+	return args
+}
@@ -0,0 +1,289 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hyperledger/fabric/gossip/service/httpadmin"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/hyperledger/fabric/internal/pkg/comm"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var (
+	operationsAddress string
+	operationsCAFile  string
+	operationsCert    string
+	operationsKey     string
+	gossipJSONOutput  bool
+)
+
+func gossipCmd() *cobra.Command {
+	gossipStatusCmd.ResetFlags()
+	flags := gossipStatusCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to report the topology of. All channels are reported if not set.")
+	addOperationsFlags(flags)
+	flags.BoolVar(&gossipJSONOutput, "json", false, "Print the status as returned by the operations endpoint.")
+
+	gossipReelectCmd.ResetFlags()
+	flags = gossipReelectCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel to re-elect the leader of.")
+	addOperationsFlags(flags)
+
+	gossipStatusCmd.ResetCommands()
+	gossipStatusCmd.AddCommand(gossipReelectCmd)
+	return gossipStatusCmd
+}
+
+var gossipStatusCmd = &cobra.Command{
+	Use:   "gossip",
+	Short: "Shows the gossip membership and channel topology of a running peer.",
+	Long:  `Shows the gossip membership, the ledger heights and leadership of each channel, and the open gossip connections of a running peer. The status is retrieved from the operations endpoint of the peer.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return errors.Errorf("trailing args detected: %s", args)
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		client, baseURL, err := operationsClient()
+		if err != nil {
+			return err
+		}
+
+		ch := ""
+		if channelID != common.UndefinedParamValue {
+			ch = channelID
+		}
+		status, body, err := getGossipStatus(client, baseURL, ch)
+		if err != nil {
+			return err
+		}
+
+		if gossipJSONOutput {
+			var buffer bytes.Buffer
+			if err := json.Indent(&buffer, body, "", "\t"); err != nil {
+				return errors.Wrap(err, "failed to format gossip status")
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), buffer.String())
+			return nil
+		}
+		printGossipStatus(cmd.OutOrStdout(), status)
+		return nil
+	},
+}
+
+var gossipReelectCmd = &cobra.Command{
+	Use:   "reelect",
+	Short: "Forces the re-election of the leader of a channel.",
+	Long:  `Forces the re-election of the leader of a channel, which pulls the blocks of the channel from the ordering service for the peers of its organization. The peer must be the elected leader of the channel, and relinquishes its leadership to another peer of its organization through its operations endpoint.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return errors.Errorf("trailing args detected: %s", args)
+		}
+		if channelID == common.UndefinedParamValue {
+			return errors.New("Must supply channel ID")
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		client, baseURL, err := operationsClient()
+		if err != nil {
+			return err
+		}
+		if err := reelectLeader(client, baseURL, channelID); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Relinquished the leadership of channel %s, a new leader is being elected\n", channelID)
+		return nil
+	},
+}
+
+// addOperationsFlags adds the flags used to reach the operations endpoint
+// of the peer.
+func addOperationsFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&operationsAddress, "operationsAddress", "", "Address of the operations endpoint of the peer. Defaults to operations.listenAddress.")
+	flags.StringVar(&operationsCAFile, "cafile", "", "Path to file containing PEM-encoded trusted certificate(s) for the operations endpoint. Enables TLS.")
+	flags.StringVar(&operationsCert, "certfile", "", "Path to file containing PEM-encoded X509 certificate used for mutual TLS with the operations endpoint.")
+	flags.StringVar(&operationsKey, "keyfile", "", "Path to file containing PEM-encoded private key used for mutual TLS with the operations endpoint.")
+}
+
+// operationsClient returns the HTTP client and base URL used to reach the
+// operations endpoint, as set by the operations flags.
+func operationsClient() (*http.Client, string, error) {
+	address := operationsAddress
+	if address == "" {
+		address = viper.GetString("operations.listenAddress")
+	}
+	client, scheme, err := newOperationsClient(operationsCAFile, operationsCert, operationsKey)
+	if err != nil {
+		return nil, "", err
+	}
+	return client, fmt.Sprintf("%s://%s", scheme, address), nil
+}
+
+// newOperationsClient returns the HTTP client and URL scheme used to reach the
+// operations endpoint. TLS is used when a CA file is supplied.
+func newOperationsClient(caFile, certFile, keyFile string) (*http.Client, string, error) {
+	if caFile == "" {
+		return &http.Client{}, "http", nil
+	}
+
+	caPEM, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to read CA file %s", caFile)
+	}
+	caCertPool := x509.NewCertPool()
+	if err := comm.AddPemToCertPool(caPEM, caCertPool); err != nil {
+		return nil, "", errors.WithMessagef(err, "failed to add CA file %s to cert pool", caFile)
+	}
+
+	tlsConfig := &tls.Config{RootCAs: caCertPool}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "failed to load client cert/key pair")
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}, "https", nil
+}
+
+// getGossipStatus retrieves the gossip status from the operations endpoint
+// at baseURL and returns it along with the raw response body.
+func getGossipStatus(client *http.Client, baseURL, channelID string) (*httpadmin.Status, []byte, error) {
+	statusURL := baseURL + "/gossip"
+	if channelID != "" {
+		statusURL += "?channel=" + url.QueryEscape(channelID)
+	}
+
+	resp, err := client.Get(statusURL)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve gossip status")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read gossip status")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, operationsError("failed to retrieve gossip status", resp, body)
+	}
+
+	status := &httpadmin.Status{}
+	if err := json.Unmarshal(body, status); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse gossip status")
+	}
+	return status, body, nil
+}
+
+// reelectLeader makes the peer at baseURL relinquish its leadership of the
+// channel through the operations endpoint.
+func reelectLeader(client *http.Client, baseURL, channelID string) error {
+	resp, err := client.Post(baseURL+"/gossip/leadership?channel="+url.QueryEscape(channelID), "", nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to re-elect leader")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrap(err, "failed to read re-election response")
+		}
+		return operationsError("failed to re-elect leader", resp, body)
+	}
+	return nil
+}
+
+func printGossipStatus(out io.Writer, status *httpadmin.Status) {
+	fmt.Fprintf(out, "Self: %s\n", memberString(status.Self))
+
+	fmt.Fprintf(out, "Alive members: %d\n", len(status.Alive))
+	for _, m := range status.Alive {
+		fmt.Fprintf(out, "\t%s\n", memberString(m))
+	}
+	fmt.Fprintf(out, "Dead members: %d\n", len(status.Dead))
+	for _, m := range status.Dead {
+		fmt.Fprintf(out, "\t%s\n", memberString(m))
+	}
+
+	for _, ch := range status.Channels {
+		fmt.Fprintf(out, "Channel: %s\n", ch.Name)
+		fmt.Fprintf(out, "\tLeader: %s\n", leadershipString(ch.Leadership))
+		if ch.Self != nil {
+			fmt.Fprintf(out, "\tSelf: %s\n", channelPeerString(*ch.Self))
+		}
+		fmt.Fprintf(out, "\tPeers: %d\n", len(ch.Peers))
+		for _, p := range ch.Peers {
+			fmt.Fprintf(out, "\t\t%s\n", channelPeerString(p))
+		}
+	}
+
+	fmt.Fprintf(out, "Connections: %d\n", len(status.Connections))
+	for _, c := range status.Connections {
+		direction := "inbound"
+		if c.Outbound {
+			direction = "outbound"
+		}
+		fmt.Fprintf(out, "\t%s (%s), %s, %d queued messages\n", c.Endpoint, c.PKIID, direction, c.QueuedMessages)
+	}
+}
+
+func memberString(m httpadmin.Member) string {
+	s := fmt.Sprintf("%s (%s)", m.Endpoint, m.PKIID)
+	if m.InternalEndpoint != "" && m.InternalEndpoint != m.Endpoint {
+		s += fmt.Sprintf(", internal endpoint %s", m.InternalEndpoint)
+	}
+	return s
+}
+
+func channelPeerString(p httpadmin.ChannelPeer) string {
+	s := fmt.Sprintf("%s (%s), height %d", p.Endpoint, p.PKIID, p.LedgerHeight)
+	if len(p.Chaincodes) != 0 {
+		var chaincodes []string
+		for _, cc := range p.Chaincodes {
+			chaincodes = append(chaincodes, fmt.Sprintf("%s:%s", cc.Name, cc.Version))
+		}
+		s += fmt.Sprintf(", chaincodes %s", strings.Join(chaincodes, ", "))
+	}
+	if p.LeftChannel {
+		s += ", left channel"
+	}
+	return s
+}
+
+func leadershipString(l httpadmin.Leadership) string {
+	var leader string
+	switch {
+	case l.IsLeader:
+		leader = "self"
+	case l.Leader != "":
+		leader = l.Leader
+	default:
+		leader = "unknown"
+	}
+	if l.Elected {
+		return leader + " (elected)"
+	}
+	return leader + " (static)"
+}
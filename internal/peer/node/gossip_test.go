@@ -0,0 +1,136 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+const testGossipStatus = `{
+	"self": {"pki_id": "aa", "endpoint": "peer0:7051"},
+	"alive": [{"pki_id": "bb", "endpoint": "peer1:7051"}],
+	"dead": [],
+	"channels": [
+		{
+			"name": "mychannel",
+			"leadership": {"elected": true, "is_leader": false, "leader": "bb"},
+			"self": {"pki_id": "aa", "endpoint": "peer0:7051", "ledger_height": 10},
+			"peers": [{"pki_id": "bb", "endpoint": "peer1:7051", "ledger_height": 9, "chaincodes": [{"name": "mycc", "version": "1.0"}]}]
+		}
+	],
+	"connections": [{"pki_id": "bb", "endpoint": "peer1:7051", "outbound": true, "queued_messages": 0}]
+}`
+
+func TestGossipCmd(t *testing.T) {
+	var requestURI string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURI = r.RequestURI
+		if r.URL.Query().Get("channel") == "missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "channel missing not found"}`))
+			return
+		}
+		w.Write([]byte(testGossipStatus))
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("prints the status", func(t *testing.T) {
+		viper.Set("operations.listenAddress", address)
+		defer viper.Reset()
+
+		cmd := gossipCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOutput(out)
+		cmd.SetArgs([]string{})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, "/gossip", requestURI)
+		require.Equal(t, `Self: peer0:7051 (aa)
+Alive members: 1
+	peer1:7051 (bb)
+Dead members: 0
+Channel: mychannel
+	Leader: bb (elected)
+	Self: peer0:7051 (aa), height 10
+	Peers: 1
+		peer1:7051 (bb), height 9, chaincodes mycc:1.0
+Connections: 1
+	peer1:7051 (bb), outbound, 0 queued messages
+`, out.String())
+	})
+
+	t.Run("prints the status as JSON for a channel", func(t *testing.T) {
+		cmd := gossipCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOutput(out)
+		cmd.SetArgs([]string{"--operationsAddress", address, "-c", "mychannel", "--json"})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, "/gossip?channel=mychannel", requestURI)
+		require.JSONEq(t, testGossipStatus, out.String())
+	})
+
+	t.Run("when the endpoint returns an error", func(t *testing.T) {
+		cmd := gossipCmd()
+		cmd.SetArgs([]string{"--operationsAddress", address, "-c", "missing"})
+		err := cmd.Execute()
+		require.EqualError(t, err, "failed to retrieve gossip status: channel missing not found")
+	})
+
+	t.Run("when the CA file does not exist", func(t *testing.T) {
+		cmd := gossipCmd()
+		cmd.SetArgs([]string{"--operationsAddress", address, "--cafile", "/does/not/exist"})
+		err := cmd.Execute()
+		require.EqualError(t, err, "failed to read CA file /does/not/exist: open /does/not/exist: no such file or directory")
+	})
+}
+
+func TestGossipReelectCmd(t *testing.T) {
+	var method, requestURI string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, requestURI = r.Method, r.RequestURI
+		if r.URL.Query().Get("channel") == "follower" {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "peer is not the leader of channel follower, the leader is bb"}`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("relinquishes the leadership", func(t *testing.T) {
+		cmd := gossipCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOutput(out)
+		cmd.SetArgs([]string{"reelect", "--operationsAddress", address, "-c", "mychannel"})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, http.MethodPost, method)
+		require.Equal(t, "/gossip/leadership?channel=mychannel", requestURI)
+		require.Equal(t, "Relinquished the leadership of channel mychannel, a new leader is being elected\n", out.String())
+	})
+
+	t.Run("when the peer is not the leader", func(t *testing.T) {
+		cmd := gossipCmd()
+		cmd.SetArgs([]string{"reelect", "--operationsAddress", address, "-c", "follower"})
+		err := cmd.Execute()
+		require.EqualError(t, err, "failed to re-elect leader: peer is not the leader of channel follower, the leader is bb")
+	})
+
+	t.Run("when the channel is not supplied", func(t *testing.T) {
+		cmd := gossipCmd()
+		cmd.SetArgs([]string{"reelect", "--operationsAddress", address})
+		err := cmd.Execute()
+		require.EqualError(t, err, "Must supply channel ID")
+	})
+}
@@ -11,7 +11,10 @@ import (
 	"strings"
 
 	"github.com/hyperledger/fabric/common/semaphore"
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/endorser"
 	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/core/scc"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 )
@@ -23,19 +26,100 @@ func initGrpcSemaphores(config *peer.Config) map[string]semaphore.Semaphore {
 
 	// Currently concurrency limit is applied to endorser service and deliver service.
 	// These services are defined in fabric-protos and fabric-protos-go (generated from fabric-protos).
-	// Below service names must match their definitions.
+	// Below service names must match their definitions. The chaincode events
+	// service is defined in the ccevents package until it is upstreamed.
 	if endorserConcurrency != 0 {
 		logger.Infof("concurrency limit for endorser service is %d", endorserConcurrency)
-		semaphores["/protos.Endorser"] = semaphore.New(endorserConcurrency)
+		// evaluations run chaincodes too and share the endorser limit
+		endorserSemaphore := semaphore.New(endorserConcurrency)
+		semaphores["/protos.Endorser"] = endorserSemaphore
+		semaphores["/evaluate.Evaluator"] = endorserSemaphore
 	}
 	if deliverConcurrency != 0 {
 		logger.Infof("concurrency limit for deliver service is %d", deliverConcurrency)
-		semaphores["/protos.Deliver"] = semaphore.New(deliverConcurrency)
+		// chaincode event streams are a deliver variant and share its limit
+		deliverSemaphore := semaphore.New(deliverConcurrency)
+		semaphores["/protos.Deliver"] = deliverSemaphore
+		semaphores["/ccevents.ChaincodeEvents"] = deliverSemaphore
 	}
 
 	return semaphores
 }
 
+// chaincodeInfoProvider returns the definition of a chaincode on a channel.
+type chaincodeInfoProvider interface {
+	ChaincodeInfo(channelID, name string) (*lifecycle.LocalChaincodeInfo, error)
+}
+
+// endorserLimiterRegistry reports the channels joined by the peer and the
+// chaincodes defined on them to the endorser limiter.
+type endorserLimiterRegistry struct {
+	// ledgers returns the ledger of a joined channel, which also holds the
+	// chaincodes deployed with the legacy lifecycle
+	ledgers     getLedger
+	chaincodes  chaincodeInfoProvider
+	builtinSCCs scc.BuiltinSCCs
+}
+
+func (r endorserLimiterRegistry) ChannelExists(channelID string) bool {
+	return r.ledgers(channelID) != nil
+}
+
+func (r endorserLimiterRegistry) ChaincodeExists(channelID, chaincodeName string) bool {
+	if r.builtinSCCs.IsSysCC(chaincodeName) {
+		return true
+	}
+	if _, err := r.chaincodes.ChaincodeInfo(channelID, chaincodeName); err == nil {
+		return true
+	}
+	return r.legacyChaincodeExists(channelID, chaincodeName)
+}
+
+// legacyChaincodeExists returns whether lscc holds a definition of the
+// chaincode on the channel.
+func (r endorserLimiterRegistry) legacyChaincodeExists(channelID, chaincodeName string) bool {
+	l := r.ledgers(channelID)
+	if l == nil {
+		return false
+	}
+	qe, err := l.NewQueryExecutor()
+	if err != nil {
+		logger.Warningf("could not look up chaincode %s in lscc on channel %s: %s", chaincodeName, channelID, err)
+		return false
+	}
+	defer qe.Done()
+	definition, err := qe.GetState("lscc", chaincodeName)
+	if err != nil {
+		logger.Warningf("could not look up chaincode %s in lscc on channel %s: %s", chaincodeName, channelID, err)
+		return false
+	}
+	return definition != nil
+}
+
+// initEndorserLimiter creates the limiter enforcing the per-channel and
+// per-chaincode endorser limits. It returns nil when no limit is configured.
+func initEndorserLimiter(config *peer.Config, registry endorser.LimiterRegistry) *endorser.Limiter {
+	limiterConfig := endorser.LimiterConfig{
+		Channel:            endorser.Limit(config.LimitsEndorserChannel),
+		Chaincode:          endorser.Limit(config.LimitsEndorserChaincode),
+		ChaincodeOverrides: map[string]endorser.Limit{},
+	}
+	for _, override := range config.LimitsEndorserChaincodeOverrides {
+		limiterConfig.ChaincodeOverrides[override.Name] = endorser.Limit{
+			Concurrency: override.Concurrency,
+			Rate:        override.Rate,
+			Burst:       override.Burst,
+		}
+	}
+
+	if limiterConfig.Channel == (endorser.Limit{}) && limiterConfig.Chaincode == (endorser.Limit{}) && len(limiterConfig.ChaincodeOverrides) == 0 {
+		return nil
+	}
+
+	logger.Infof("endorser limits per channel: %+v, per chaincode: %+v, chaincode overrides: %+v", limiterConfig.Channel, limiterConfig.Chaincode, limiterConfig.ChaincodeOverrides)
+	return endorser.NewLimiter(limiterConfig, registry)
+}
+
 func unaryGrpcLimiter(semaphores map[string]semaphore.Semaphore) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		serviceName := getServiceName(info.FullMethod)
@@ -11,10 +11,16 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 
+	"github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/core/ledger"
+	ledgermock "github.com/hyperledger/fabric/core/ledger/mock"
 	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/core/scc"
+	"github.com/hyperledger/fabric/internal/peer/node/mock"
 )
 
 func TestInitGrpcSemaphores(t *testing.T) {
@@ -23,7 +29,9 @@ func TestInitGrpcSemaphores(t *testing.T) {
 		LimitsConcurrencyDeliverService:  5,
 	}
 	semaphores := initGrpcSemaphores(&config)
-	require.Equal(t, 2, len(semaphores))
+	require.Equal(t, 4, len(semaphores))
+	require.Equal(t, semaphores["/protos.Endorser"], semaphores["/evaluate.Evaluator"])
+	require.Equal(t, semaphores["/protos.Deliver"], semaphores["/ccevents.ChaincodeEvents"])
 }
 
 func TestInitGrpcNoSemaphores(t *testing.T) {
@@ -35,6 +43,115 @@ func TestInitGrpcNoSemaphores(t *testing.T) {
 	require.Equal(t, 0, len(semaphores))
 }
 
+func TestInitEndorserLimiter(t *testing.T) {
+	config := peer.Config{
+		LimitsEndorserChaincodeOverrides: []peer.ChaincodeEndorserLimit{
+			{Name: "mycc", Concurrency: 1},
+		},
+	}
+	limiter := initEndorserLimiter(&config, endorserLimiterRegistry{ledgers: noLedgers})
+	require.NotNil(t, limiter)
+
+	release, err := limiter.Acquire("mychannel", "mycc")
+	require.NoError(t, err)
+	_, err = limiter.Acquire("mychannel", "mycc")
+	require.EqualError(t, err, "too many requests for chaincode mycc on channel mychannel, exceeding concurrency limit")
+	release()
+
+	_, err = limiter.Acquire("mychannel", "othercc")
+	require.NoError(t, err)
+}
+
+func TestInitNoEndorserLimiter(t *testing.T) {
+	require.Nil(t, initEndorserLimiter(&peer.Config{}, nil))
+}
+
+type fakeChaincodes map[string]string
+
+func (c fakeChaincodes) ChaincodeInfo(channelID, name string) (*lifecycle.LocalChaincodeInfo, error) {
+	if c[name] != channelID {
+		return nil, errors.Errorf("unknown chaincode '%s' for channel '%s'", name, channelID)
+	}
+	return &lifecycle.LocalChaincodeInfo{}, nil
+}
+
+func noLedgers(string) ledger.PeerLedger {
+	return nil
+}
+
+// lsccLedgers returns the ledger getter of a channel whose lscc namespace
+// defines the given chaincodes.
+func lsccLedgers(channelID string, legacyChaincodes ...string) getLedger {
+	qe := &ledgermock.QueryExecutor{}
+	qe.GetStateStub = func(namespace, key string) ([]byte, error) {
+		for _, name := range legacyChaincodes {
+			if namespace == "lscc" && key == name {
+				return []byte("definition"), nil
+			}
+		}
+		return nil, nil
+	}
+	l := &mock.PeerLedger{}
+	l.NewQueryExecutorReturns(qe, nil)
+	return func(cid string) ledger.PeerLedger {
+		if cid != channelID {
+			return nil
+		}
+		return l
+	}
+}
+
+func TestEndorserLimiterRegistry(t *testing.T) {
+	registry := endorserLimiterRegistry{
+		ledgers:     lsccLedgers("mychannel", "legacycc"),
+		chaincodes:  fakeChaincodes{"mycc": "mychannel"},
+		builtinSCCs: scc.BuiltinSCCs{"qscc": {}},
+	}
+
+	require.True(t, registry.ChannelExists("mychannel"))
+	require.False(t, registry.ChannelExists("otherchannel"))
+	require.True(t, registry.ChaincodeExists("mychannel", "mycc"))
+	require.True(t, registry.ChaincodeExists("mychannel", "qscc"))
+	require.True(t, registry.ChaincodeExists("mychannel", "legacycc"))
+	require.False(t, registry.ChaincodeExists("mychannel", "othercc"))
+	require.False(t, registry.ChaincodeExists("otherchannel", "mycc"))
+	require.False(t, registry.ChaincodeExists("otherchannel", "legacycc"))
+
+	l := &mock.PeerLedger{}
+	l.NewQueryExecutorReturns(nil, errors.New("ledger closed"))
+	registry.ledgers = func(string) ledger.PeerLedger { return l }
+	require.False(t, registry.ChaincodeExists("mychannel", "legacycc"))
+}
+
+func TestEndorserLimiterLegacyChaincodes(t *testing.T) {
+	config := peer.Config{
+		LimitsEndorserChaincode: peer.EndorserLimit{Concurrency: 1},
+	}
+	limiter := initEndorserLimiter(&config, endorserLimiterRegistry{
+		ledgers:     lsccLedgers("mychannel", "legacycc1", "legacycc2"),
+		chaincodes:  fakeChaincodes{},
+		builtinSCCs: scc.BuiltinSCCs{},
+	})
+	require.NotNil(t, limiter)
+
+	// each legacy chaincode has a limit of its own
+	release1, err := limiter.Acquire("mychannel", "legacycc1")
+	require.NoError(t, err)
+	defer release1()
+	release2, err := limiter.Acquire("mychannel", "legacycc2")
+	require.NoError(t, err)
+	defer release2()
+	_, err = limiter.Acquire("mychannel", "legacycc1")
+	require.EqualError(t, err, "too many requests for chaincode legacycc1 on channel mychannel, exceeding concurrency limit")
+
+	// while undefined chaincodes share one
+	release, err := limiter.Acquire("mychannel", "undefined1")
+	require.NoError(t, err)
+	defer release()
+	_, err = limiter.Acquire("mychannel", "undefined2")
+	require.EqualError(t, err, "too many requests for chaincode undefined2 on channel mychannel, exceeding concurrency limit shared by the undefined chaincodes")
+}
+
 func TestInitGrpcSemaphoresPanic(t *testing.T) {
 	config := peer.Config{
 		LimitsConcurrencyEndorserService: -1,
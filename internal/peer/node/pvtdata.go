@@ -0,0 +1,259 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hyperledger/fabric/gossip/service/httpadmin"
+	"github.com/hyperledger/fabric/internal/peer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var (
+	pvtDataStartBlock uint64
+	pvtDataEndBlock   uint64
+	pvtDataNamespace  string
+	pvtDataCollection string
+	pvtDataJSONOutput bool
+)
+
+func pvtDataCmd() *cobra.Command {
+	pvtDataListCmd.ResetFlags()
+	addPvtDataFlags(pvtDataListCmd.Flags())
+	pvtDataListCmd.Flags().BoolVar(&pvtDataJSONOutput, "json", false, "Print the report as returned by the operations endpoint.")
+
+	pvtDataReconcileCmd.ResetFlags()
+	addPvtDataFlags(pvtDataReconcileCmd.Flags())
+	pvtDataReconcileCmd.Flags().BoolVar(&pvtDataJSONOutput, "json", false, "Print the progress as returned by the operations endpoint.")
+
+	pvtDataParentCmd.ResetCommands()
+	pvtDataParentCmd.AddCommand(pvtDataListCmd)
+	pvtDataParentCmd.AddCommand(pvtDataReconcileCmd)
+	return pvtDataParentCmd
+}
+
+func addPvtDataFlags(flags *pflag.FlagSet) {
+	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "Channel of the private data.")
+	flags.Uint64Var(&pvtDataStartBlock, "startBlock", 0, "Lowest block number of the private data.")
+	flags.Uint64Var(&pvtDataEndBlock, "endBlock", 0, "Highest block number of the private data. Defaults to the last committed block.")
+	flags.StringVar(&pvtDataNamespace, "namespace", "", "Namespace (chaincode name) of the private data. All namespaces if not set.")
+	flags.StringVar(&pvtDataCollection, "collection", "", "Collection of the private data. All collections if not set.")
+	addOperationsFlags(flags)
+}
+
+var pvtDataParentCmd = &cobra.Command{
+	Use:   "pvtdata",
+	Short: "Reports and reconciles the private data missing on a running peer.",
+	Long:  `Reports and reconciles the private data missing on a running peer. The private data is reported and reconciled through the operations endpoint of the peer.`,
+}
+
+var pvtDataListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the private data missing on a running peer.",
+	Long:  `Lists the private data of a channel missing on a running peer, per block, namespace and collection. The private data of the collections the peer is eligible for is listed separately from the private data of the collections it is not eligible for.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkPvtDataArgs(args); err != nil {
+			return err
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		client, baseURL, err := operationsClient()
+		if err != nil {
+			return err
+		}
+		report, body, err := getMissingPvtData(client, baseURL)
+		if err != nil {
+			return err
+		}
+
+		if pvtDataJSONOutput {
+			var buffer bytes.Buffer
+			if err := json.Indent(&buffer, body, "", "\t"); err != nil {
+				return errors.Wrap(err, "failed to format missing private data")
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), buffer.String())
+			return nil
+		}
+		printMissingPvtData(cmd.OutOrStdout(), report)
+		return nil
+	},
+}
+
+var pvtDataReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Reconciles the private data missing on a running peer.",
+	Long:  `Reconciles right away the private data of a channel missing on a running peer, for the collections the peer is eligible for, by pulling it from other peers. The progress is printed as the blocks are reconciled.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkPvtDataArgs(args); err != nil {
+			return err
+		}
+		// Parsing of the command line is done so silence cmd usage
+		cmd.SilenceUsage = true
+
+		client, baseURL, err := operationsClient()
+		if err != nil {
+			return err
+		}
+		return reconcileMissingPvtData(client, baseURL, cmd.OutOrStdout(), pvtDataJSONOutput)
+	},
+}
+
+func checkPvtDataArgs(args []string) error {
+	if len(args) != 0 {
+		return errors.Errorf("trailing args detected: %s", args)
+	}
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	if pvtDataEndBlock != 0 && pvtDataStartBlock > pvtDataEndBlock {
+		return errors.Errorf("start block %d is greater than end block %d", pvtDataStartBlock, pvtDataEndBlock)
+	}
+	return nil
+}
+
+// pvtDataURL returns the URL of the private data operations endpoint, with
+// the query parameters set by the flags.
+func pvtDataURL(baseURL string) string {
+	query := url.Values{}
+	query.Set("channel", channelID)
+	if pvtDataStartBlock != 0 {
+		query.Set("start", strconv.FormatUint(pvtDataStartBlock, 10))
+	}
+	if pvtDataEndBlock != 0 {
+		query.Set("end", strconv.FormatUint(pvtDataEndBlock, 10))
+	}
+	if pvtDataNamespace != "" {
+		query.Set("namespace", pvtDataNamespace)
+	}
+	if pvtDataCollection != "" {
+		query.Set("collection", pvtDataCollection)
+	}
+	return baseURL + "/pvtdata?" + query.Encode()
+}
+
+// getMissingPvtData retrieves the missing private data report from the
+// operations endpoint at baseURL and returns it along with the raw response
+// body.
+func getMissingPvtData(client *http.Client, baseURL string) (*httpadmin.MissingPvtDataReport, []byte, error) {
+	resp, err := client.Get(pvtDataURL(baseURL))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to retrieve missing private data")
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to read missing private data")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, operationsError("failed to retrieve missing private data", resp, body)
+	}
+
+	report := &httpadmin.MissingPvtDataReport{}
+	if err := json.Unmarshal(body, report); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse missing private data")
+	}
+	return report, body, nil
+}
+
+// reconcileMissingPvtData requests the reconciliation of the missing private
+// data from the operations endpoint at baseURL, and prints the progress to
+// out as it is streamed back.
+func reconcileMissingPvtData(client *http.Client, baseURL string, out io.Writer, jsonOutput bool) error {
+	resp, err := client.Post(pvtDataURL(baseURL), "application/json", nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to request reconciliation")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return errors.Wrap(err, "failed to read reconciliation response")
+		}
+		return operationsError("failed to request reconciliation", resp, body)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if err == io.EOF {
+				return errors.New("reconciliation response ended before reconciliation finished")
+			}
+			return errors.Wrap(err, "failed to read reconciliation progress")
+		}
+		progress := &httpadmin.ReconcileProgress{}
+		if err := json.Unmarshal(raw, progress); err != nil {
+			return errors.Wrap(err, "failed to parse reconciliation progress")
+		}
+
+		if jsonOutput {
+			fmt.Fprintln(out, string(raw))
+		} else {
+			printReconcileProgress(out, progress)
+		}
+		if progress.Done {
+			if progress.Error != "" {
+				return errors.Errorf("reconciliation failed: %s", progress.Error)
+			}
+			return nil
+		}
+	}
+}
+
+// operationsError returns the error reported by the operations endpoint in
+// a response which was not successful.
+func operationsError(msg string, resp *http.Response, body []byte) error {
+	errResp := &httpadmin.ErrorResponse{}
+	if err := json.Unmarshal(body, errResp); err != nil || errResp.Error == "" {
+		return errors.Errorf("%s: %s", msg, resp.Status)
+	}
+	return errors.Errorf("%s: %s", msg, errResp.Error)
+}
+
+func printMissingPvtData(out io.Writer, report *httpadmin.MissingPvtDataReport) {
+	fmt.Fprintf(out, "Channel: %s\n", report.Channel)
+	fmt.Fprintf(out, "Eligible missing private data: %d\n", len(report.Eligible))
+	for _, m := range report.Eligible {
+		fmt.Fprintf(out, "\t%s\n", missingPvtDataString(m))
+	}
+	fmt.Fprintf(out, "Ineligible missing private data: %d\n", len(report.Ineligible))
+	for _, m := range report.Ineligible {
+		fmt.Fprintf(out, "\t%s\n", missingPvtDataString(m))
+	}
+}
+
+func missingPvtDataString(m httpadmin.MissingPvtData) string {
+	return fmt.Sprintf("block %d, namespace %s, collection %s, transactions %v", m.BlockNum, m.Namespace, m.Collection, m.TxNums)
+}
+
+func printReconcileProgress(out io.Writer, p *httpadmin.ReconcileProgress) {
+	if p.Done {
+		if p.Error != "" {
+			fmt.Fprintf(out, "Reconciliation stopped after %d of %d blocks: %d reconciled, %d not available\n",
+				p.ProcessedBlocks, p.TotalBlocks, p.Reconciled, p.Unreconciled)
+			return
+		}
+		fmt.Fprintf(out, "Reconciliation finished for %d blocks: %d reconciled, %d not available\n",
+			p.ProcessedBlocks, p.Reconciled, p.Unreconciled)
+		return
+	}
+	fmt.Fprintf(out, "Reconciled %d of %d blocks: %d reconciled, %d not available\n",
+		p.ProcessedBlocks, p.TotalBlocks, p.Reconciled, p.Unreconciled)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testMissingPvtData = `{
+	"channel": "mychannel",
+	"eligible": [{"block_num": 7, "namespace": "mycc", "collection": "coll1", "tx_nums": [0, 3]}],
+	"ineligible": [{"block_num": 5, "namespace": "mycc", "collection": "coll2", "tx_nums": [1]}]
+}`
+
+func TestPvtDataCmd(t *testing.T) {
+	var requestMethod, requestURI string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestMethod, requestURI = r.Method, r.RequestURI
+		switch r.URL.Query().Get("channel") {
+		case "missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "channel missing not found"}`))
+		case "failing":
+			w.Write([]byte(`{"total_blocks": 3, "processed_blocks": 1, "reconciled": 2, "unreconciled": 0, "done": false}` + "\n"))
+			w.Write([]byte(`{"total_blocks": 3, "processed_blocks": 1, "reconciled": 2, "unreconciled": 0, "done": true, "error": "reconciler stopped"}` + "\n"))
+		case "truncated":
+			w.Write([]byte(`{"total_blocks": 3, "processed_blocks": 1, "reconciled": 2, "unreconciled": 0, "done": false}` + "\n"))
+		default:
+			if r.Method == http.MethodGet {
+				w.Write([]byte(testMissingPvtData))
+				return
+			}
+			w.Write([]byte(`{"total_blocks": 2, "processed_blocks": 1, "reconciled": 2, "unreconciled": 0, "done": false}` + "\n"))
+			w.Write([]byte(`{"total_blocks": 2, "processed_blocks": 2, "reconciled": 2, "unreconciled": 1, "done": false}` + "\n"))
+			w.Write([]byte(`{"total_blocks": 2, "processed_blocks": 2, "reconciled": 2, "unreconciled": 1, "done": true}` + "\n"))
+		}
+	}))
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("lists the missing private data", func(t *testing.T) {
+		cmd := pvtDataCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOutput(out)
+		cmd.SetArgs([]string{"list", "--operationsAddress", address, "-c", "mychannel", "--startBlock", "2", "--endBlock", "9", "--namespace", "mycc"})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, http.MethodGet, requestMethod)
+		require.Equal(t, "/pvtdata?channel=mychannel&end=9&namespace=mycc&start=2", requestURI)
+		require.Equal(t, `Channel: mychannel
+Eligible missing private data: 1
+	block 7, namespace mycc, collection coll1, transactions [0 3]
+Ineligible missing private data: 1
+	block 5, namespace mycc, collection coll2, transactions [1]
+`, out.String())
+	})
+
+	t.Run("lists the missing private data as JSON", func(t *testing.T) {
+		cmd := pvtDataCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOutput(out)
+		cmd.SetArgs([]string{"list", "--operationsAddress", address, "-c", "mychannel", "--json"})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, "/pvtdata?channel=mychannel", requestURI)
+		require.JSONEq(t, testMissingPvtData, out.String())
+	})
+
+	t.Run("reconciles the missing private data", func(t *testing.T) {
+		cmd := pvtDataCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOutput(out)
+		cmd.SetArgs([]string{"reconcile", "--operationsAddress", address, "-c", "mychannel", "--collection", "coll1"})
+		require.NoError(t, cmd.Execute())
+		require.Equal(t, http.MethodPost, requestMethod)
+		require.Equal(t, "/pvtdata?channel=mychannel&collection=coll1", requestURI)
+		require.Equal(t, `Reconciled 1 of 2 blocks: 2 reconciled, 0 not available
+Reconciled 2 of 2 blocks: 2 reconciled, 1 not available
+Reconciliation finished for 2 blocks: 2 reconciled, 1 not available
+`, out.String())
+	})
+
+	t.Run("when reconciliation fails", func(t *testing.T) {
+		cmd := pvtDataCmd()
+		out := &bytes.Buffer{}
+		cmd.SetOutput(out)
+		cmd.SetArgs([]string{"reconcile", "--operationsAddress", address, "-c", "failing"})
+		err := cmd.Execute()
+		require.EqualError(t, err, "reconciliation failed: reconciler stopped")
+		require.Contains(t, out.String(), "Reconciliation stopped after 1 of 3 blocks: 2 reconciled, 0 not available\n")
+	})
+
+	t.Run("when the progress stream ends early", func(t *testing.T) {
+		cmd := pvtDataCmd()
+		cmd.SetOutput(&bytes.Buffer{})
+		cmd.SetArgs([]string{"reconcile", "--operationsAddress", address, "-c", "truncated"})
+		err := cmd.Execute()
+		require.EqualError(t, err, "reconciliation response ended before reconciliation finished")
+	})
+
+	t.Run("when the endpoint returns an error", func(t *testing.T) {
+		cmd := pvtDataCmd()
+		cmd.SetArgs([]string{"reconcile", "--operationsAddress", address, "-c", "missing"})
+		err := cmd.Execute()
+		require.EqualError(t, err, "failed to request reconciliation: channel missing not found")
+
+		cmd = pvtDataCmd()
+		cmd.SetArgs([]string{"list", "--operationsAddress", address, "-c", "missing"})
+		err = cmd.Execute()
+		require.EqualError(t, err, "failed to retrieve missing private data: channel missing not found")
+	})
+
+	t.Run("when the channel is not supplied", func(t *testing.T) {
+		cmd := pvtDataCmd()
+		cmd.SetArgs([]string{"list", "--operationsAddress", address})
+		err := cmd.Execute()
+		require.EqualError(t, err, "Must supply channel ID")
+	})
+
+	t.Run("when the block range is invalid", func(t *testing.T) {
+		cmd := pvtDataCmd()
+		cmd.SetArgs([]string{"reconcile", "--operationsAddress", address, "-c", "mychannel", "--startBlock", "9", "--endBlock", "3"})
+		err := cmd.Execute()
+		require.EqualError(t, err, "start block 9 is greater than end block 3")
+	})
+}
@@ -56,9 +56,11 @@ import (
 	"github.com/hyperledger/fabric/core/container"
 	"github.com/hyperledger/fabric/core/container/dockercontroller"
 	"github.com/hyperledger/fabric/core/container/externalbuilder"
+	"github.com/hyperledger/fabric/core/container/processcontroller"
 	"github.com/hyperledger/fabric/core/deliverservice"
 	"github.com/hyperledger/fabric/core/dispatcher"
 	"github.com/hyperledger/fabric/core/endorser"
+	"github.com/hyperledger/fabric/core/endorser/evaluate"
 	authHandler "github.com/hyperledger/fabric/core/handlers/auth"
 	endorsement2 "github.com/hyperledger/fabric/core/handlers/endorsement/api"
 	endorsement3 "github.com/hyperledger/fabric/core/handlers/endorsement/api/identities"
@@ -71,6 +73,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/snapshotgrpc"
 	"github.com/hyperledger/fabric/core/operations"
 	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/core/peer/ccevents"
 	"github.com/hyperledger/fabric/core/policy"
 	"github.com/hyperledger/fabric/core/scc"
 	"github.com/hyperledger/fabric/core/scc/cscc"
@@ -90,6 +93,7 @@ import (
 	gossipprivdata "github.com/hyperledger/fabric/gossip/privdata"
 	"github.com/hyperledger/fabric/gossip/service"
 	gossipservice "github.com/hyperledger/fabric/gossip/service"
+	gossiphttpadmin "github.com/hyperledger/fabric/gossip/service/httpadmin"
 	peergossip "github.com/hyperledger/fabric/internal/peer/gossip"
 	"github.com/hyperledger/fabric/internal/peer/version"
 	"github.com/hyperledger/fabric/internal/pkg/comm"
@@ -228,6 +232,14 @@ func serve(args []string) error {
 	logObserver := floggingmetrics.NewObserver(metricsProvider)
 	flogging.SetObserver(logObserver)
 
+	// Online revocation checks apply to the admission of proposals and of
+	// gossip peers only, so that the validation of transactions does not
+	// depend on the network and gives the same results on all the peers
+	var revocationChecker *msp.RevocationChecker
+	if coreConfig.MSPRevocation.Enabled() {
+		revocationChecker = msp.NewRevocationChecker(coreConfig.MSPRevocation, metricsProvider)
+	}
+
 	mspID := coreConfig.LocalMSPID
 
 	membershipInfoProvider := privdata.NewMembershipInfoProvider(mspID, createSelfSignedData(), identityDeserializerFactory)
@@ -296,6 +308,7 @@ func serve(args []string) error {
 		StoreProvider:            transientStoreProvider,
 		CryptoProvider:           factory.GetDefault(),
 		OrdererEndpointOverrides: deliverServiceConfig.OrdererEndpointOverrides,
+		PolicyTraceCapacity:      coreConfig.PolicyTraceCapacity,
 	}
 
 	localMSP := mgmt.GetLocalMSP(factory.GetDefault())
@@ -462,6 +475,7 @@ func serve(args []string) error {
 		deliverGRPCClient,
 		deliverServiceConfig,
 		privdataConfig,
+		revocationChecker,
 	)
 	if err != nil {
 		return errors.WithMessage(err, "failed to initialize gossip service")
@@ -469,6 +483,9 @@ func serve(args []string) error {
 	defer gossipService.Stop()
 
 	peerInstance.GossipService = gossipService
+	opsSystem.RegisterHandler("/gossip", gossiphttpadmin.NewStatusHandler(gossipService), coreConfig.OperationsTLSEnabled)
+	opsSystem.RegisterHandler("/pvtdata", gossiphttpadmin.NewPvtDataHandler(gossipService), coreConfig.OperationsTLSEnabled)
+	opsSystem.RegisterHandler("/gossip/leadership", gossiphttpadmin.NewLeadershipHandler(gossipService), coreConfig.OperationsTLSEnabled)
 
 	if err := lifecycleCache.InitializeLocalChaincodes(); err != nil {
 		return errors.WithMessage(err, "could not initialize local chaincodes")
@@ -502,6 +519,7 @@ func serve(args []string) error {
 		PolicyCheckerProvider: policyCheckerProvider,
 	}
 	pb.RegisterDeliverServer(peerServer.Server(), abServer)
+	ccevents.RegisterChaincodeEventsServer(peerServer.Server(), abServer)
 
 	// Create a self-signed CA for chaincode service
 	ca, err := tlsgen.NewCA()
@@ -526,14 +544,46 @@ func serve(args []string) error {
 		HandlerRegistry: chaincodeHandlerRegistry,
 	}
 
-	if coreConfig.VMEndpoint == "" && len(coreConfig.ExternalBuilders) == 0 {
-		logger.Panic("VMEndpoint not set and no ExternalBuilders defined")
+	if coreConfig.VMEndpoint == "" && !coreConfig.VMProcessEnabled && len(coreConfig.ExternalBuilders) == 0 {
+		logger.Panic("VMEndpoint not set, process runtime disabled and no ExternalBuilders defined")
 	}
 
 	chaincodeConfig := chaincode.GlobalConfig()
 
 	var dockerBuilder container.DockerBuilder
-	if coreConfig.VMEndpoint != "" {
+	if coreConfig.VMProcessEnabled {
+		processRuntimePath := filepath.Join(coreconfig.GetPath("peer.fileSystemPath"), "processcontroller")
+		processBuildOutput := filepath.Join(processRuntimePath, "builds")
+		if err := os.MkdirAll(processBuildOutput, 0700); err != nil {
+			logger.Panicf("could not create process runtime build output dir: %s", err)
+		}
+
+		processVM := &processcontroller.ProcessVM{
+			BuildDir:             processBuildOutput,
+			LogDir:               filepath.Join(processRuntimePath, "logs"),
+			MaxLogSize:           50 * 1024 * 1024,
+			PropagateEnvironment: coreConfig.VMProcessPropagateEnvironment,
+			LoggingEnv: []string{
+				"CORE_CHAINCODE_LOGGING_LEVEL=" + chaincodeConfig.LogLevel,
+				"CORE_CHAINCODE_LOGGING_SHIM=" + chaincodeConfig.ShimLogLevel,
+				"CORE_CHAINCODE_LOGGING_FORMAT=" + chaincodeConfig.LogFormat,
+			},
+			MSPID:        mspID,
+			TermTimeout:  5 * time.Second,
+			BuildMetrics: processcontroller.NewBuildMetrics(opsSystem.Provider),
+		}
+		if coreConfig.VMProcessCgroupPath != "" {
+			processVM.Cgroup = &processcontroller.CgroupConfig{
+				Path:   coreConfig.VMProcessCgroupPath,
+				CPUs:   coreConfig.VMProcessCgroupCPUs,
+				Memory: coreConfig.VMProcessCgroupMemory,
+			}
+		}
+		if coreConfig.VMEndpoint != "" {
+			logger.Info("Process runtime enabled, chaincode will not be run in docker containers")
+		}
+		dockerBuilder = processVM
+	} else if coreConfig.VMEndpoint != "" {
 		client, err := createDockerClient(coreConfig)
 		if err != nil {
 			logger.Panicf("cannot create docker client: %s", err)
@@ -700,7 +750,7 @@ func serve(args []string) error {
 		peerInstance,
 		factory.GetDefault(),
 	)
-	qsccInst := scc.SelfDescribingSysCC(qscc.New(aclProvider, peerInstance))
+	qsccInst := scc.SelfDescribingSysCC(qscc.New(aclProvider, peerInstance, peerInstance))
 
 	pb.RegisterChaincodeSupportServer(ccSrv.Server(), ccSupSrv)
 
@@ -745,6 +795,14 @@ func serve(args []string) error {
 		LocalMSP:               localMSP,
 		Support:                endorserSupport,
 		Metrics:                endorser.NewMetrics(metricsProvider),
+		Limiter: initEndorserLimiter(coreConfig, endorserLimiterRegistry{
+			ledgers:     peerInstance.GetLedger,
+			chaincodes:  lifecycleCache,
+			builtinSCCs: builtinSCCs,
+		}),
+	}
+	if revocationChecker != nil {
+		serverEndorser.RevocationChecker = revocationChecker
 	}
 
 	// deploy system chaincodes
@@ -872,9 +930,12 @@ func serve(args []string) error {
 	}
 
 	// start the peer server
-	auth := authHandler.ChainFilters(serverEndorser, authFilters...)
+	// evaluations go through the same auth filters as endorsements
+	auth := authHandler.ChainFilters(authHandler.EvaluationDispatcher(serverEndorser, serverEndorser), authFilters...)
 	// Register the Endorser server
 	pb.RegisterEndorserServer(peerServer.Server(), auth)
+	// Register the Evaluator server
+	evaluate.RegisterEvaluatorServer(peerServer.Server(), authHandler.ChainEvaluator(auth))
 
 	// register the snapshot server
 	snapshotSvc := &snapshotgrpc.SnapshotService{LedgerGetter: peerInstance, ACLProvider: aclProvider}
@@ -953,7 +1014,7 @@ func registerDiscoveryService(
 	channelVerifier := discacl.NewChannelVerifier(policies.ChannelApplicationWriters, polMgr)
 	acl := discacl.NewDiscoverySupport(channelVerifier, localAccessPolicy, discacl.ChannelConfigGetterFunc(peerInstance.GetStableChannelConfig))
 	gSup := gossip.NewDiscoverySupport(gossipService)
-	ccSup := ccsupport.NewDiscoverySupport(metadataProvider)
+	ccSup := ccsupport.NewDiscoverySupport(metadataProvider, &ccsupport.LedgerStateMetadataRetriever{LedgerGetter: peerInstance})
 	ea := endorsement.NewEndorsementAnalyzer(gSup, ccSup, acl, metadataProvider)
 	confSup := config.NewDiscoverySupport(config.CurrentConfigGetterFunc(func(channelID string) *common.Config {
 		channel := peerInstance.Channel(channelID)
@@ -1171,6 +1232,7 @@ func initGossipService(
 	deliverGRPCClient *comm.GRPCClient,
 	deliverServiceConfig *deliverservice.DeliverServiceConfig,
 	privdataConfig *gossipprivdata.PrivdataConfig,
+	revocationChecker *msp.RevocationChecker,
 ) (*gossipservice.GossipService, error) {
 
 	var certs *gossipcommon.TLSCertificates
@@ -1185,11 +1247,16 @@ func initGossipService(
 		certs.TLSClientCert.Store(&clientCert)
 	}
 
+	var mcsRevocationChecker peergossip.RevocationChecker
+	if revocationChecker != nil {
+		mcsRevocationChecker = revocationChecker
+	}
 	messageCryptoService := peergossip.NewMCS(
 		policyMgr,
 		signer,
 		mgmt.NewDeserializersManager(factory.GetDefault()),
 		factory.GetDefault(),
+		mcsRevocationChecker,
 	)
 	secAdv := peergossip.NewSecurityAdvisor(mgmt.NewDeserializersManager(factory.GetDefault()))
 	bootstrap := viper.GetStringSlice("peer.gossip.bootstrap")
@@ -99,6 +99,12 @@ type Deliverer struct {
 	MaxRetryDelay     time.Duration
 	InitialRetryDelay time.Duration
 	MaxRetryDuration  time.Duration
+	// LeaderDemotionThreshold is the time the deliverer may spend retrying
+	// since it last received a block before it yields the leadership, if it
+	// may yield it. Only the back-off delays between attempts count towards
+	// it, not the time spent connecting or waiting on a stream that fails
+	// eventually. Zero disables the demotion.
+	LeaderDemotionThreshold time.Duration
 
 	// TLSCertHash should be nil when TLS is not enabled
 	TLSCertHash []byte // util.ComputeSHA256(b.credSupport.GetClientCertificate().Certificate[0])
@@ -113,6 +119,7 @@ const backoffExponentBase = 1.2
 func (d *Deliverer) DeliverBlocks() {
 	failureCounter := 0
 	totalDuration := time.Duration(0)
+	failureDuration := time.Duration(0)
 
 	// InitialRetryDelay * backoffExponentBase^n > MaxRetryDelay
 	// backoffExponentBase^n > MaxRetryDelay / InitialRetryDelay
@@ -134,6 +141,11 @@ func (d *Deliverer) DeliverBlocks() {
 				sleepDuration = time.Duration(math.Pow(1.2, float64(failureCounter-1))*100) * time.Millisecond
 			}
 			totalDuration += sleepDuration
+			failureDuration += sleepDuration
+			if d.YieldLeadership && d.LeaderDemotionThreshold > 0 && failureDuration > d.LeaderDemotionThreshold {
+				d.Logger.Warningf("failed receiving blocks for more than %v, yielding leadership", d.LeaderDemotionThreshold)
+				return
+			}
 			if totalDuration > d.MaxRetryDuration {
 				if d.YieldLeadership {
 					d.Logger.Warningf("attempted to retry block delivery for more than %v, giving up", d.MaxRetryDuration)
@@ -202,6 +214,7 @@ func (d *Deliverer) DeliverBlocks() {
 					break RecvLoop
 				}
 				failureCounter = 0
+				failureDuration = 0
 			case <-d.DoneC:
 				break RecvLoop
 			}
@@ -300,6 +300,31 @@ var _ = Describe("Blocksprovider", func() {
 		})
 	})
 
+	When("the consecutive errors exceed the leader demotion threshold", func() {
+		BeforeEach(func() {
+			d.YieldLeadership = true
+			d.LeaderDemotionThreshold = time.Minute
+			fakeDeliverStreamer.DeliverReturns(nil, fmt.Errorf("deliver-error"))
+		})
+
+		It("yields the leadership before exceeding the max retry duration", func() {
+			Eventually(endC).Should(BeClosed())
+			Expect(fakeSleeper.SleepCallCount()).To(Equal(26))
+			Expect(fakeSleeper.SleepArgsForCall(25)).To(Equal(9539 * time.Millisecond))
+		})
+
+		When("the peer is a static leader", func() {
+			BeforeEach(func() {
+				d.YieldLeadership = false
+			})
+
+			It("ignores the leader demotion threshold", func() {
+				Eventually(fakeSleeper.SleepCallCount).Should(BeNumerically(">", 26))
+				Expect(endC).NotTo(BeClosed())
+			})
+		})
+	})
+
 	When("an error occurs, then a block is successfully delivered", func() {
 		BeforeEach(func() {
 			fakeDeliverStreamer.DeliverReturnsOnCall(0, nil, fmt.Errorf("deliver-error"))
@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package cache
 
 import (
+	"time"
+
 	pmsp "github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/msp"
@@ -92,18 +94,47 @@ func (c *cachedMSP) Validate(id msp.Identity) error {
 	identifier := id.GetIdentifier()
 	key := string(identifier.Mspid + ":" + identifier.Id)
 
-	_, ok := c.validateIdentityCache.get(key)
+	v, ok := c.validateIdentityCache.get(key)
 	if ok {
-		// cache only stores if the identity is valid.
-		return nil
+		// cache only stores if the identity is valid, either forever
+		// or until the revocation status it depends on expires.
+		validUntil, expires := v.(time.Time)
+		if !expires || time.Now().Before(validUntil) {
+			return nil
+		}
 	}
 
-	err := c.MSP.Validate(id)
-	if err == nil {
+	validUntil, err := c.validate(id)
+	if err != nil {
+		return err
+	}
+
+	if validUntil.IsZero() {
 		c.validateIdentityCache.add(key, true)
+	} else {
+		c.validateIdentityCache.add(key, validUntil)
+	}
+	return nil
+}
+
+func (c *cachedMSP) validate(id msp.Identity) (time.Time, error) {
+	if ev, ok := c.MSP.(msp.ExpiringValidator); ok {
+		return ev.ValidateWithExpiry(id)
 	}
+	return time.Time{}, c.MSP.Validate(id)
+}
 
-	return err
+// revalidateIfExpired validates the identity again if its cached
+// validation depends on a revocation status that has expired.
+func (c *cachedMSP) revalidateIfExpired(key string, id msp.Identity) error {
+	v, ok := c.validateIdentityCache.get(key)
+	if !ok {
+		return nil
+	}
+	if validUntil, expires := v.(time.Time); expires && !time.Now().Before(validUntil) {
+		return c.Validate(id)
+	}
+	return nil
 }
 
 func (c *cachedMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrincipal) error {
@@ -115,7 +146,8 @@ func (c *cachedMSP) SatisfiesPrincipal(id msp.Identity, principal *pmsp.MSPPrinc
 	v, ok := c.satisfiesPrincipalCache.get(key)
 	if ok {
 		if v == nil {
-			return nil
+			// the identity may have been revoked since it was validated
+			return c.revalidateIfExpired(identityKey, id)
 		}
 
 		return v.(error)
@@ -9,6 +9,7 @@ package cache
 import (
 	"sync"
 	"testing"
+	"time"
 
 	msp2 "github.com/hyperledger/fabric-protos-go/msp"
 	"github.com/hyperledger/fabric/msp"
@@ -213,6 +214,52 @@ func TestValidate(t *testing.T) {
 	require.False(t, ok)
 }
 
+// expiringMSP is a mock MSP whose validation results expire.
+type expiringMSP struct {
+	*mocks.MockMSP
+}
+
+func (m *expiringMSP) ValidateWithExpiry(id msp.Identity) (time.Time, error) {
+	args := m.Called(id)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func TestValidateWithExpiry(t *testing.T) {
+	mockMSP := &expiringMSP{MockMSP: &mocks.MockMSP{}}
+	i, err := New(mockMSP)
+	require.NoError(t, err)
+
+	mockIdentity := &mocks.MockIdentity{ID: "Alice"}
+	mockIdentity.On("GetIdentifier").Return(&msp.IdentityIdentifier{Mspid: "MSP", Id: "Alice"})
+	mockMSPPrincipal := &msp2.MSPPrincipal{PrincipalClassification: msp2.MSPPrincipal_IDENTITY, Principal: []byte{1, 2, 3}}
+	mockMSP.On("SatisfiesPrincipal", mockIdentity, mockMSPPrincipal).Return(nil).Once()
+
+	// Check validation is cached until it expires
+	mockMSP.On("ValidateWithExpiry", mockIdentity).Return(time.Now().Add(time.Hour), nil).Once()
+	err = i.Validate(mockIdentity)
+	require.NoError(t, err)
+	err = i.Validate(mockIdentity)
+	require.NoError(t, err)
+	err = i.SatisfiesPrincipal(mockIdentity, mockMSPPrincipal)
+	require.NoError(t, err)
+	err = i.SatisfiesPrincipal(mockIdentity, mockMSPPrincipal)
+	require.NoError(t, err)
+	mockMSP.AssertNumberOfCalls(t, "ValidateWithExpiry", 1)
+
+	// Check an expired validation is done again, also when
+	// checking principals
+	key := "MSP:Alice"
+	i.(*cachedMSP).validateIdentityCache.add(key, time.Now().Add(-time.Second))
+	mockMSP.On("ValidateWithExpiry", mockIdentity).Return(time.Time{}, errors.New("The certificate has been revoked")).Once()
+	err = i.SatisfiesPrincipal(mockIdentity, mockMSPPrincipal)
+	require.EqualError(t, err, "The certificate has been revoked")
+	mockMSP.On("ValidateWithExpiry", mockIdentity).Return(time.Time{}, errors.New("The certificate has been revoked")).Once()
+	err = i.Validate(mockIdentity)
+	require.EqualError(t, err, "The certificate has been revoked")
+	mockMSP.AssertExpectations(t)
+	mockMSP.AssertNotCalled(t, "Validate", mockIdentity)
+}
+
 func TestSatisfiesValidateIndirectCall(t *testing.T) {
 	mockMSP := &mocks.MockMSP{}
 
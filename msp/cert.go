@@ -19,6 +19,7 @@ package msp
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
@@ -62,6 +63,13 @@ type tbsCertificate struct {
 	Extensions         []pkix.Extension `asn1:"optional,explicit,tag:3"`
 }
 
+// isED25519Cert returns whether the given certificate carries an Ed25519
+// public key or is signed using Ed25519
+func isED25519Cert(cert *x509.Certificate) bool {
+	_, isED25519Key := cert.PublicKey.(ed25519.PublicKey)
+	return isED25519Key || cert.SignatureAlgorithm == x509.PureEd25519
+}
+
 func isECDSASignedCert(cert *x509.Certificate) bool {
 	return cert.SignatureAlgorithm == x509.ECDSAWithSHA1 ||
 		cert.SignatureAlgorithm == x509.ECDSAWithSHA256 ||
@@ -18,6 +18,7 @@ const (
 	MSPv1_1
 	MSPv1_3
 	MSPv1_4_3
+	MSPv3_0
 )
 
 // NewOpts represent
@@ -38,6 +39,12 @@ func (o *NewBaseOpts) GetVersion() MSPVersion {
 // BCCSPNewOpts contains the options to instantiate a new BCCSP-based (X509) MSP
 type BCCSPNewOpts struct {
 	NewBaseOpts
+
+	// RevocationChecker, if set, checks online the revocation status of
+	// the certificates of the identities validated by the MSP. It must not
+	// be set for channel MSPs, whose validation results have to be the same
+	// on all the nodes of a channel.
+	RevocationChecker *RevocationChecker
 }
 
 // IdemixNewOpts contains the options to instantiate a new Idemix-based MSP
@@ -47,22 +54,23 @@ type IdemixNewOpts struct {
 
 // New create a new MSP instance depending on the passed Opts
 func New(opts NewOpts, cryptoProvider bccsp.BCCSP) (MSP, error) {
-	switch opts.(type) {
+	switch o := opts.(type) {
 	case *BCCSPNewOpts:
 		switch opts.GetVersion() {
-		case MSPv1_0:
-			return newBccspMsp(MSPv1_0, cryptoProvider)
-		case MSPv1_1:
-			return newBccspMsp(MSPv1_1, cryptoProvider)
-		case MSPv1_3:
-			return newBccspMsp(MSPv1_3, cryptoProvider)
-		case MSPv1_4_3:
-			return newBccspMsp(MSPv1_4_3, cryptoProvider)
+		case MSPv1_0, MSPv1_1, MSPv1_3, MSPv1_4_3, MSPv3_0:
+			theMsp, err := newBccspMsp(opts.GetVersion(), cryptoProvider)
+			if err != nil {
+				return nil, err
+			}
+			theMsp.(*bccspmsp).revocationChecker = o.RevocationChecker
+			return theMsp, nil
 		default:
 			return nil, errors.Errorf("Invalid *BCCSPNewOpts. Version not recognized [%v]", opts.GetVersion())
 		}
 	case *IdemixNewOpts:
 		switch opts.GetVersion() {
+		case MSPv3_0:
+			fallthrough
 		case MSPv1_4_3:
 			fallthrough
 		case MSPv1_3:
@@ -24,7 +24,7 @@ func TestNewInvalidOpts(t *testing.T) {
 	require.Contains(t, err.Error(), "Invalid msp.NewOpts instance. It must be either *BCCSPNewOpts or *IdemixNewOpts. It was [<nil>]")
 	require.Nil(t, i)
 
-	i, err = New(&BCCSPNewOpts{NewBaseOpts{Version: -1}}, cryptoProvider)
+	i, err = New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: -1}}, cryptoProvider)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "Invalid *BCCSPNewOpts. Version not recognized [-1]")
 	require.Nil(t, i)
@@ -39,7 +39,7 @@ func TestNew(t *testing.T) {
 	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
 	require.NoError(t, err)
 
-	i, err := New(&BCCSPNewOpts{NewBaseOpts{Version: MSPv1_0}}, cryptoProvider)
+	i, err := New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: MSPv1_0}}, cryptoProvider)
 	require.NoError(t, err)
 	require.NotNil(t, i)
 	require.Equal(t, MSPVersion(MSPv1_0), i.(*bccspmsp).version)
@@ -52,7 +52,7 @@ func TestNew(t *testing.T) {
 		runtime.FuncForPC(reflect.ValueOf(i.(*bccspmsp).validateIdentityOUsV1).Pointer()).Name(),
 	)
 
-	i, err = New(&BCCSPNewOpts{NewBaseOpts{Version: MSPv1_1}}, cryptoProvider)
+	i, err = New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: MSPv1_1}}, cryptoProvider)
 	require.NoError(t, err)
 	require.NotNil(t, i)
 	require.Equal(t, MSPVersion(MSPv1_1), i.(*bccspmsp).version)
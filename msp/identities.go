@@ -8,6 +8,7 @@ package msp
 
 import (
 	"crypto"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/hex"
@@ -50,6 +51,11 @@ type identity struct {
 	// validationErr contains the validation error for this
 	// instance. It can be read if validated is true
 	validationErr error
+
+	// validUntil is the time until which the validation result holds,
+	// because it depends on the revocation status of the certificates
+	// checked online. A zero time means the result holds forever.
+	validUntil time.Time
 }
 
 func newIdentity(cert *x509.Certificate, pk bccsp.Key, msp *bccspmsp) (Identity, error) {
@@ -169,15 +175,10 @@ func NewSerializedIdentity(mspID string, certPEM []byte) ([]byte, error) {
 func (id *identity) Verify(msg []byte, sig []byte) error {
 	// mspIdentityLogger.Infof("Verifying signature")
 
-	// Compute Hash
-	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+	// Compute Hash, unless the signature scheme hashes the message itself
+	digest, err := id.signedBytes(msg)
 	if err != nil {
-		return errors.WithMessage(err, "failed getting hash function options")
-	}
-
-	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
-	if err != nil {
-		return errors.WithMessage(err, "failed computing digest")
+		return err
 	}
 
 	if mspIdentityLogger.IsEnabledFor(zapcore.DebugLevel) {
@@ -213,6 +214,26 @@ func (id *identity) Serialize() ([]byte, error) {
 	return idBytes, nil
 }
 
+// signedBytes returns the bytes that the signatures of this identity over msg
+// are computed on: the digest of msg, or msg itself for Ed25519 identities, as
+// Ed25519 hashes the message as part of the signature scheme (RFC 8032)
+func (id *identity) signedBytes(msg []byte) ([]byte, error) {
+	if _, isED25519 := id.cert.PublicKey.(ed25519.PublicKey); isED25519 {
+		return msg, nil
+	}
+
+	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting hash function options")
+	}
+
+	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed computing digest")
+	}
+	return digest, nil
+}
+
 func (id *identity) getHashOpt(hashFamily string) (bccsp.HashOpts, error) {
 	switch hashFamily {
 	case bccsp.SHA2:
@@ -252,15 +273,10 @@ func newSigningIdentity(cert *x509.Certificate, pk bccsp.Key, signer crypto.Sign
 func (id *signingidentity) Sign(msg []byte) ([]byte, error) {
 	//mspIdentityLogger.Infof("Signing message")
 
-	// Compute Hash
-	hashOpt, err := id.getHashOpt(id.msp.cryptoConfig.SignatureHashFamily)
+	// Compute Hash, unless the signature scheme hashes the message itself
+	digest, err := id.signedBytes(msg)
 	if err != nil {
-		return nil, errors.WithMessage(err, "failed getting hash function options")
-	}
-
-	digest, err := id.msp.bccsp.Hash(msg, hashOpt)
-	if err != nil {
-		return nil, errors.WithMessage(err, "failed computing digest")
+		return nil, err
 	}
 
 	if len(msg) < 32 {
@@ -9,6 +9,7 @@ package msp
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/x509"
 	"encoding/asn1"
 	"encoding/hex"
@@ -1039,6 +1040,35 @@ func TestIdentityExpired(t *testing.T) {
 	}
 }
 
+func TestED25519Identity(t *testing.T) {
+	// Ed25519 identities require MSPv3_0
+	_, err := getLocalMSPWithVersionAndError(t, "testdata/ed25519", MSPv1_4_3)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Ed25519 certificates are not supported by this MSP version, the V3_0 channel capability is required")
+
+	thisMSP := getLocalMSPWithVersion(t, "testdata/ed25519", MSPv3_0)
+	id, err := thisMSP.GetDefaultSigningIdentity()
+	require.NoError(t, err)
+	require.NoError(t, id.Validate())
+
+	serializedID, err := id.Serialize()
+	require.NoError(t, err)
+	idBack, err := thisMSP.DeserializeIdentity(serializedID)
+	require.NoError(t, err)
+
+	msg := []byte("foo")
+	sig, err := id.Sign(msg)
+	require.NoError(t, err)
+	require.NoError(t, id.Verify(msg, sig))
+	require.NoError(t, idBack.Verify(msg, sig))
+	require.Error(t, idBack.Verify(msg[1:], sig))
+	require.Error(t, idBack.Verify(msg, sig[1:]))
+
+	// The message is signed as is, so that standard Ed25519 verifiers accept it
+	pubKey := id.(*signingidentity).cert.PublicKey.(ed25519.PublicKey)
+	require.True(t, ed25519.Verify(pubKey, msg, sig))
+}
+
 func TestIdentityPolicyPrincipal(t *testing.T) {
 	id, err := localMsp.GetDefaultSigningIdentity()
 	require.NoError(t, err)
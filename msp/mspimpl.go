@@ -8,10 +8,12 @@ package msp
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/hex"
 	"encoding/pem"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	m "github.com/hyperledger/fabric-protos-go/msp"
@@ -101,6 +103,11 @@ type bccspmsp struct {
 	// These are the OUIdentifiers of the clients, peers, admins and orderers.
 	// They are used to tell apart these entities
 	clientOU, peerOU, adminOU, ordererOU *OUIdentifier
+
+	// revocationChecker checks the revocation status of certificates
+	// online, it is nil if online checks are disabled, as they always
+	// are for channel MSPs
+	revocationChecker *RevocationChecker
 }
 
 // newBccspMsp returns an MSP instance backed up by a BCCSP
@@ -129,7 +136,7 @@ func newBccspMsp(version MSPVersion, defaultBCCSP bccsp.BCCSP) (MSP, error) {
 		theMsp.internalValidateIdentityOusFunc = theMsp.validateIdentityOUsV11
 		theMsp.internalSatisfiesPrincipalInternalFunc = theMsp.satisfiesPrincipalInternalV13
 		theMsp.internalSetupAdmin = theMsp.setupAdminsPreV142
-	case MSPv1_4_3:
+	case MSPv1_4_3, MSPv3_0:
 		theMsp.internalSetupFunc = theMsp.setupV142
 		theMsp.internalValidateIdentityOusFunc = theMsp.validateIdentityOUsV142
 		theMsp.internalSatisfiesPrincipalInternalFunc = theMsp.satisfiesPrincipalInternalV142
@@ -227,9 +234,13 @@ func (msp *bccspmsp) getSigningIdentityFromConf(sidInfo *m.SigningIdentityInfo)
 		if pemKey == nil {
 			return nil, errors.Errorf("%s: wrong PEM encoding", sidInfo.PrivateSigner.KeyIdentifier)
 		}
-		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+		var importOpts bccsp.KeyImportOpts = &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true}
+		if _, isED25519 := idPub.(*identity).cert.PublicKey.(ed25519.PublicKey); isED25519 {
+			importOpts = &bccsp.ED25519PrivateKeyImportOpts{Temporary: true}
+		}
+		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, importOpts)
 		if err != nil {
-			return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed to import EC private key")
+			return nil, errors.WithMessagef(err, "getIdentityFromBytes error: Failed to import %s private key", importOpts.Algorithm())
 		}
 	}
 
@@ -328,6 +339,22 @@ func (msp *bccspmsp) Validate(id Identity) error {
 	}
 }
 
+// ValidateWithExpiry validates the given identity like Validate does and
+// returns the time until which the result holds, a zero time meaning forever.
+// Results expire when they depend on the revocation status of certificates
+// checked online.
+func (msp *bccspmsp) ValidateWithExpiry(id Identity) (time.Time, error) {
+	switch id := id.(type) {
+	case *identity:
+		err := msp.validateIdentity(id)
+		id.validationMutex.Lock()
+		defer id.validationMutex.Unlock()
+		return id.validUntil, err
+	default:
+		return time.Time{}, errors.New("identity type not recognized")
+	}
+}
+
 // hasOURole checks that the identity belongs to the organizational unit
 // associated to the specified MSPRole.
 // This function does not check the certifiers identifier.
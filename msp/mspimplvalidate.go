@@ -22,12 +22,15 @@ func (msp *bccspmsp) validateIdentity(id *identity) error {
 	id.validationMutex.Lock()
 	defer id.validationMutex.Unlock()
 
-	// return cached validation value if already validated
-	if id.validated {
+	// return cached validation value if already validated, unless it
+	// depends on a revocation status that has to be checked again
+	if id.validated && (id.validUntil.IsZero() || time.Now().Before(id.validUntil)) {
 		return id.validationErr
 	}
 
 	id.validated = true
+	id.validationErr = nil
+	id.validUntil = time.Time{}
 
 	validationChain, err := msp.getCertificationChainForBCCSPIdentity(id)
 	if err != nil {
@@ -35,6 +38,12 @@ func (msp *bccspmsp) validateIdentity(id *identity) error {
 		return id.validationErr
 	}
 
+	err = msp.validateKeyAlgorithms(validationChain)
+	if err != nil {
+		id.validationErr = err
+		return id.validationErr
+	}
+
 	err = msp.validateIdentityAgainstChain(id, validationChain)
 	if err != nil {
 		id.validationErr = errors.WithMessage(err, "could not validate identity against certification chain")
@@ -47,6 +56,30 @@ func (msp *bccspmsp) validateIdentity(id *identity) error {
 		return id.validationErr
 	}
 
+	if msp.revocationChecker != nil {
+		id.validUntil, err = msp.revocationChecker.checkChain(validationChain)
+		if err != nil {
+			id.validationErr = errors.WithMessage(err, "could not validate identity's revocation status")
+			return id.validationErr
+		}
+	}
+
+	return nil
+}
+
+// validateKeyAlgorithms checks that the given certification chain only uses
+// key algorithms supported by the version of this MSP. Ed25519 is only
+// supported starting from MSPv3_0, so that peers of a channel whose
+// capabilities predate it agree on rejecting Ed25519 identities.
+func (msp *bccspmsp) validateKeyAlgorithms(chain []*x509.Certificate) error {
+	if msp.version >= MSPv3_0 {
+		return nil
+	}
+	for _, cert := range chain {
+		if isED25519Cert(cert) {
+			return errors.Errorf("Ed25519 certificates are not supported by this MSP version, the V3_0 channel capability is required [%s]", cert.Subject)
+		}
+	}
 	return nil
 }
 
@@ -59,6 +92,10 @@ func (msp *bccspmsp) validateCAIdentity(id *identity) error {
 	if err != nil {
 		return errors.WithMessage(err, "could not obtain certification chain")
 	}
+
+	if err := msp.validateKeyAlgorithms(validationChain); err != nil {
+		return err
+	}
 	if len(validationChain) == 1 {
 		// validationChain[0] is the root CA certificate
 		return nil
@@ -0,0 +1,489 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ocsp"
+)
+
+// RevocationFailurePolicy tells what to do when the revocation status of a
+// certificate cannot be determined online.
+type RevocationFailurePolicy string
+
+const (
+	// RevocationSoftFail accepts certificates whose revocation status
+	// cannot be determined.
+	RevocationSoftFail RevocationFailurePolicy = "soft"
+	// RevocationHardFail rejects certificates whose revocation status
+	// cannot be determined.
+	RevocationHardFail RevocationFailurePolicy = "hard"
+)
+
+const (
+	defaultRevocationTimeout            = 5 * time.Second
+	defaultRevocationValidity           = time.Hour
+	defaultRevocationFailureRetry       = time.Minute
+	maxRevocationResponseSize     int64 = 10 << 20
+	maxCachedRevocationStatuses         = 10000
+	maxCachedCRLs                       = 100
+)
+
+// RevocationOpts configures the online revocation checking of certificates.
+type RevocationOpts struct {
+	// OCSP enables querying the OCSP responders listed in certificates.
+	OCSP bool
+	// CRLDistributionPoints enables fetching the CRLs from the distribution
+	// points listed in certificates.
+	CRLDistributionPoints bool
+	// FailurePolicy is applied when the revocation status of a certificate
+	// cannot be determined. It defaults to RevocationSoftFail.
+	FailurePolicy RevocationFailurePolicy
+	// Timeout bounds each request to an OCSP responder or a CRL
+	// distribution point.
+	Timeout time.Duration
+	// DefaultValidity is how long a revocation status is cached when the
+	// OCSP response or the CRL does not state its next update.
+	DefaultValidity time.Duration
+	// FailureRetry is how long a failure to determine the revocation
+	// status is cached before it is tried again.
+	FailureRetry time.Duration
+}
+
+// Enabled returns whether any online revocation check is enabled.
+func (o RevocationOpts) Enabled() bool {
+	return o.OCSP || o.CRLDistributionPoints
+}
+
+var (
+	revocationChecks = metrics.CounterOpts{
+		Namespace:    "msp",
+		Subsystem:    "revocation",
+		Name:         "checks",
+		Help:         "The number of online certificate revocation checks, by source and result.",
+		LabelNames:   []string{"source", "result"},
+		StatsdFormat: "%{#fqname}.%{source}.%{result}",
+	}
+	revocationCacheHits = metrics.CounterOpts{
+		Namespace: "msp",
+		Subsystem: "revocation",
+		Name:      "cache_hits",
+		Help:      "The number of certificate revocation statuses served from the cache.",
+	}
+	revocationFetchDuration = metrics.HistogramOpts{
+		Namespace:    "msp",
+		Subsystem:    "revocation",
+		Name:         "fetch_duration",
+		Help:         "The time taken to fetch an OCSP response or a CRL, in seconds.",
+		LabelNames:   []string{"source", "success"},
+		StatsdFormat: "%{#fqname}.%{source}.%{success}",
+	}
+)
+
+// RevocationMetrics are the metrics of a RevocationChecker.
+type RevocationMetrics struct {
+	Checks        metrics.Counter
+	CacheHits     metrics.Counter
+	FetchDuration metrics.Histogram
+}
+
+// NewRevocationMetrics creates the metrics of a RevocationChecker.
+func NewRevocationMetrics(p metrics.Provider) *RevocationMetrics {
+	return &RevocationMetrics{
+		Checks:        p.NewCounter(revocationChecks),
+		CacheHits:     p.NewCounter(revocationCacheHits),
+		FetchDuration: p.NewHistogram(revocationFetchDuration),
+	}
+}
+
+// revocationStatus is the cached revocation status of a certificate.
+type revocationStatus struct {
+	revoked bool
+	source  string
+	// err is the reason why the status could not be determined, if so.
+	err error
+	// validUntil is the time until which the status holds,
+	// a zero time means forever.
+	validUntil time.Time
+}
+
+// cachedCRL is a CRL fetched from a distribution point.
+type cachedCRL struct {
+	crl        *pkix.CertificateList
+	validUntil time.Time
+}
+
+// RevocationChecker checks the revocation status of certificates against
+// the OCSP responders and the CRL distribution points they list. Statuses
+// and CRLs are cached for as long as they are valid, and failures to
+// determine a status for the failure retry period. The caches are bounded,
+// expired entries being evicted first when they are full.
+type RevocationChecker struct {
+	opts    RevocationOpts
+	client  *http.Client
+	metrics *RevocationMetrics
+	now     func() time.Time
+
+	mutex       sync.Mutex
+	statuses    map[string]*revocationStatus
+	crls        map[string]*cachedCRL
+	maxStatuses int
+	maxCRLs     int
+}
+
+// NewRevocationChecker creates a RevocationChecker with the given options.
+func NewRevocationChecker(opts RevocationOpts, metricsProvider metrics.Provider) *RevocationChecker {
+	if opts.FailurePolicy == "" {
+		opts.FailurePolicy = RevocationSoftFail
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultRevocationTimeout
+	}
+	if opts.DefaultValidity == 0 {
+		opts.DefaultValidity = defaultRevocationValidity
+	}
+	if opts.FailureRetry == 0 {
+		opts.FailureRetry = defaultRevocationFailureRetry
+	}
+
+	return &RevocationChecker{
+		opts:        opts,
+		client:      &http.Client{},
+		metrics:     NewRevocationMetrics(metricsProvider),
+		now:         time.Now,
+		statuses:    map[string]*revocationStatus{},
+		crls:        map[string]*cachedCRL{},
+		maxStatuses: maxCachedRevocationStatuses,
+		maxCRLs:     maxCachedCRLs,
+	}
+}
+
+// ExpiringValidator is implemented by MSPs whose identity validation results
+// expire, because they depend on the revocation status of certificates
+// checked online.
+type ExpiringValidator interface {
+	// ValidateWithExpiry validates the given identity like Validate does and
+	// returns the time until which the result holds, a zero time meaning
+	// forever.
+	ValidateWithExpiry(id Identity) (time.Time, error)
+}
+
+// CheckIdentity checks online the revocation status of the certificates of
+// the given identity, but the root CA. Identities that are not backed by X.509
+// certificates are not checked.
+//
+// Unlike the validation of identities by the channel MSPs, which must yield
+// the same result on all the nodes of a channel, CheckIdentity is meant for
+// the admission of requests and of peers only.
+func (rc *RevocationChecker) CheckIdentity(id Identity) error {
+	x509Identity, ok := id.(*identity)
+	if !ok {
+		return nil
+	}
+
+	chain, err := x509Identity.msp.getCertificationChainForBCCSPIdentity(x509Identity)
+	if err != nil {
+		return errors.WithMessage(err, "could not obtain certification chain")
+	}
+
+	_, err = rc.checkChain(chain)
+	return err
+}
+
+// checkChain checks the revocation status of the certificates of the given
+// certification chain, but the root CA. It returns the time until which the
+// result holds, a zero time meaning forever.
+func (rc *RevocationChecker) checkChain(chain []*x509.Certificate) (time.Time, error) {
+	var validUntil time.Time
+	for i := 0; i < len(chain)-1; i++ {
+		until, err := rc.Check(chain[i], chain[i+1])
+		if !until.IsZero() && (validUntil.IsZero() || until.Before(validUntil)) {
+			validUntil = until
+		}
+		if err != nil {
+			return validUntil, err
+		}
+	}
+
+	return validUntil, nil
+}
+
+// Check checks whether cert, issued by issuer, has been revoked. It returns
+// the time until which the result holds, a zero time meaning forever.
+//
+// An error is returned if the certificate has been revoked, or if its
+// revocation status cannot be determined and the failure policy is hard.
+func (rc *RevocationChecker) Check(cert, issuer *x509.Certificate) (time.Time, error) {
+	issuerKey := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	key := hex.EncodeToString(issuerKey[:]) + ":" + cert.SerialNumber.String()
+	now := rc.now()
+
+	rc.mutex.Lock()
+	status, ok := rc.statuses[key]
+	rc.mutex.Unlock()
+	if ok && (status.validUntil.IsZero() || now.Before(status.validUntil)) {
+		rc.metrics.CacheHits.Add(1)
+		return rc.result(status, cert)
+	}
+
+	status, err := rc.fetchStatus(cert, issuer, now)
+	if err != nil {
+		rc.metrics.Checks.With("source", "none", "result", "failed").Add(1)
+		mspLogger.Warningf("Could not determine the revocation status of certificate [%s], retrying in %s: %s", cert.SerialNumber, rc.opts.FailureRetry, err)
+		status = &revocationStatus{source: "none", err: err, validUntil: now.Add(rc.opts.FailureRetry)}
+	} else if status == nil {
+		// the certificate does not tell where to check its status
+		return time.Time{}, nil
+	} else {
+		result := "good"
+		if status.revoked {
+			result = "revoked"
+		}
+		rc.metrics.Checks.With("source", status.source, "result", result).Add(1)
+	}
+
+	rc.mutex.Lock()
+	rc.statuses[key] = status
+	evictStatuses(rc.statuses, rc.maxStatuses, now)
+	rc.mutex.Unlock()
+
+	return rc.result(status, cert)
+}
+
+// result returns the outcome of the check of cert given its status, applying
+// the failure policy if the status could not be determined.
+func (rc *RevocationChecker) result(s *revocationStatus, cert *x509.Certificate) (time.Time, error) {
+	if s.revoked {
+		return time.Time{}, errors.Errorf("The certificate [%s] has been revoked (%s)", cert.SerialNumber, s.source)
+	}
+	if s.err != nil && rc.opts.FailurePolicy == RevocationHardFail {
+		return s.validUntil, errors.WithMessagef(s.err, "could not determine the revocation status of certificate [%s]", cert.SerialNumber)
+	}
+	return s.validUntil, nil
+}
+
+// evictStatuses removes the expired statuses once there are more than max,
+// and then arbitrary ones until there are max statuses left.
+func evictStatuses(statuses map[string]*revocationStatus, max int, now time.Time) {
+	if len(statuses) <= max {
+		return
+	}
+	for key, status := range statuses {
+		if !status.validUntil.IsZero() && !now.Before(status.validUntil) {
+			delete(statuses, key)
+		}
+	}
+	for key := range statuses {
+		if len(statuses) <= max {
+			return
+		}
+		delete(statuses, key)
+	}
+}
+
+// fetchStatus queries the OCSP responders first and the CRL distribution
+// points next, and returns the first status obtained. It returns a nil
+// status if the certificate lists neither.
+func (rc *RevocationChecker) fetchStatus(cert, issuer *x509.Certificate, now time.Time) (*revocationStatus, error) {
+	var errs []string
+
+	if rc.opts.OCSP {
+		for _, server := range cert.OCSPServer {
+			status, err := rc.checkOCSP(server, cert, issuer, now)
+			if err == nil {
+				return status, nil
+			}
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if rc.opts.CRLDistributionPoints {
+		for _, url := range cert.CRLDistributionPoints {
+			status, err := rc.checkCRL(url, cert, issuer, now)
+			if err == nil {
+				return status, nil
+			}
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil, nil
+	}
+	return nil, errors.New(strings.Join(errs, "; "))
+}
+
+func (rc *RevocationChecker) checkOCSP(server string, cert, issuer *x509.Certificate, now time.Time) (*revocationStatus, error) {
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed creating OCSP request")
+	}
+
+	raw, err := rc.fetch("ocsp", server, func(ctx context.Context) (*http.Request, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, server, bytes.NewReader(req))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/ocsp-request")
+		return httpReq, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := ocsp.ParseResponseForCert(raw, cert, issuer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid OCSP response from %s", server)
+	}
+	if !resp.NextUpdate.IsZero() && !now.Before(resp.NextUpdate) {
+		return nil, errors.Errorf("OCSP response from %s expired at %s", server, resp.NextUpdate)
+	}
+
+	switch resp.Status {
+	case ocsp.Good:
+		return &revocationStatus{source: "ocsp", validUntil: rc.validUntil(resp.NextUpdate, now)}, nil
+	case ocsp.Revoked:
+		return &revocationStatus{source: "ocsp", revoked: true}, nil
+	default:
+		return nil, errors.Errorf("OCSP responder %s does not know the certificate", server)
+	}
+}
+
+func (rc *RevocationChecker) checkCRL(url string, cert, issuer *x509.Certificate, now time.Time) (*revocationStatus, error) {
+	crl, validUntil, err := rc.getCRL(url, issuer, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, revoked := range crl.TBSCertList.RevokedCertificates {
+		if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			return &revocationStatus{source: "crl", revoked: true}, nil
+		}
+	}
+	return &revocationStatus{source: "crl", validUntil: validUntil}, nil
+}
+
+// getCRL returns the CRL published at the given distribution point,
+// from the cache if it is still valid.
+func (rc *RevocationChecker) getCRL(url string, issuer *x509.Certificate, now time.Time) (*pkix.CertificateList, time.Time, error) {
+	rc.mutex.Lock()
+	cached, ok := rc.crls[url]
+	rc.mutex.Unlock()
+	if ok && now.Before(cached.validUntil) {
+		if err := issuer.CheckCRLSignature(cached.crl); err == nil {
+			return cached.crl, cached.validUntil, nil
+		}
+	}
+
+	raw, err := rc.fetch("crl", url, func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	crl, err := x509.ParseCRL(raw)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrapf(err, "invalid CRL from %s", url)
+	}
+	if err := issuer.CheckCRLSignature(crl); err != nil {
+		return nil, time.Time{}, errors.Wrapf(err, "CRL from %s is not signed by the certificate issuer", url)
+	}
+	if crl.HasExpired(now) {
+		return nil, time.Time{}, errors.Errorf("CRL from %s expired at %s", url, crl.TBSCertList.NextUpdate)
+	}
+
+	validUntil := rc.validUntil(crl.TBSCertList.NextUpdate, now)
+	rc.mutex.Lock()
+	rc.crls[url] = &cachedCRL{crl: crl, validUntil: validUntil}
+	evictCRLs(rc.crls, rc.maxCRLs, now)
+	rc.mutex.Unlock()
+
+	return crl, validUntil, nil
+}
+
+// evictCRLs removes the expired CRLs once there are more than max, and then
+// arbitrary ones until there are max CRLs left.
+func evictCRLs(crls map[string]*cachedCRL, max int, now time.Time) {
+	if len(crls) <= max {
+		return
+	}
+	for url, cached := range crls {
+		if !now.Before(cached.validUntil) {
+			delete(crls, url)
+		}
+	}
+	for url := range crls {
+		if len(crls) <= max {
+			return
+		}
+		delete(crls, url)
+	}
+}
+
+func (rc *RevocationChecker) validUntil(nextUpdate, now time.Time) time.Time {
+	if nextUpdate.IsZero() {
+		return now.Add(rc.opts.DefaultValidity)
+	}
+	return nextUpdate
+}
+
+func (rc *RevocationChecker) fetch(source, url string, newRequest func(context.Context) (*http.Request, error)) ([]byte, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, errors.Errorf("unsupported %s URL %s", source, url)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rc.opts.Timeout)
+	defer cancel()
+
+	startTime := time.Now()
+	raw, err := rc.doFetch(ctx, url, newRequest)
+	rc.metrics.FetchDuration.With("source", source, "success", boolLabel(err == nil)).Observe(time.Since(startTime).Seconds())
+	return raw, err
+}
+
+func (rc *RevocationChecker) doFetch(ctx context.Context, url string, newRequest func(context.Context) (*http.Request, error)) ([]byte, error) {
+	req, err := newRequest(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed creating request to %s", url)
+	}
+	resp, err := rc.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed querying %s", url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("%s returned status %s", url, resp.Status)
+	}
+	raw, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxRevocationResponseSize))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed reading response from %s", url)
+	}
+	return raw, nil
+}
+
+func boolLabel(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
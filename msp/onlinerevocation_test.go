@@ -0,0 +1,451 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package msp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/metrics/disabled"
+	"github.com/hyperledger/fabric/common/metrics/metricsfakes"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+)
+
+// revocationTestCA is a CA whose certificates point to an OCSP responder
+// and a CRL distribution point served by an HTTP test server.
+type revocationTestCA struct {
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	server *httptest.Server
+
+	mutex        sync.Mutex
+	revoked      map[string]bool
+	ocspStatus   int
+	ocspRequests int
+	crlRequests  int
+	nextUpdate   time.Time
+}
+
+func newRevocationTestCA(t *testing.T) *revocationTestCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "revocation-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+
+	ca := &revocationTestCA{
+		cert:       cert,
+		key:        key,
+		revoked:    map[string]bool{},
+		ocspStatus: http.StatusOK,
+		nextUpdate: time.Now().Add(10 * time.Minute),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ocsp", ca.serveOCSP)
+	mux.HandleFunc("/crl", ca.serveCRL)
+	ca.server = httptest.NewServer(mux)
+	return ca
+}
+
+func (ca *revocationTestCA) revoke(cert *x509.Certificate) {
+	ca.mutex.Lock()
+	defer ca.mutex.Unlock()
+	ca.revoked[cert.SerialNumber.String()] = true
+}
+
+func (ca *revocationTestCA) setNextUpdate(nextUpdate time.Time) {
+	ca.mutex.Lock()
+	defer ca.mutex.Unlock()
+	ca.nextUpdate = nextUpdate
+}
+
+func (ca *revocationTestCA) requests() (int, int) {
+	ca.mutex.Lock()
+	defer ca.mutex.Unlock()
+	return ca.ocspRequests, ca.crlRequests
+}
+
+func (ca *revocationTestCA) serveOCSP(w http.ResponseWriter, r *http.Request) {
+	ca.mutex.Lock()
+	defer ca.mutex.Unlock()
+	ca.ocspRequests++
+
+	if ca.ocspStatus != http.StatusOK {
+		w.WriteHeader(ca.ocspStatus)
+		return
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	req, err := ocsp.ParseRequest(body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	template := ocsp.Response{
+		SerialNumber: req.SerialNumber,
+		Status:       ocsp.Good,
+		ThisUpdate:   time.Now().Add(-time.Minute),
+		NextUpdate:   ca.nextUpdate,
+	}
+	if ca.revoked[req.SerialNumber.String()] {
+		template.Status = ocsp.Revoked
+		template.RevokedAt = time.Now().Add(-time.Minute)
+	}
+	resp, err := ocsp.CreateResponse(ca.cert, ca.cert, template, ca.key)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Write(resp)
+}
+
+func (ca *revocationTestCA) serveCRL(w http.ResponseWriter, r *http.Request) {
+	ca.mutex.Lock()
+	defer ca.mutex.Unlock()
+	ca.crlRequests++
+
+	var revoked []pkix.RevokedCertificate
+	for serial := range ca.revoked {
+		n, _ := new(big.Int).SetString(serial, 10)
+		revoked = append(revoked, pkix.RevokedCertificate{SerialNumber: n, RevocationTime: time.Now().Add(-time.Minute)})
+	}
+	crl, err := ca.cert.CreateCRL(rand.Reader, ca.key, revoked, time.Now().Add(-time.Minute), ca.nextUpdate)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Write(crl)
+}
+
+// issue issues a certificate listing the OCSP responder and the CRL
+// distribution point of the CA if requested.
+func (ca *revocationTestCA) issue(t *testing.T, serial int64, withOCSP, withCRL bool) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "revocation-user", OrganizationalUnit: []string{"client"}},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	if withOCSP {
+		template.OCSPServer = []string{ca.server.URL + "/ocsp"}
+	}
+	if withCRL {
+		template.CRLDistributionPoints = []string{ca.server.URL + "/crl"}
+	}
+	raw, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(raw)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestRevocationCheckerOCSP(t *testing.T) {
+	ca := newRevocationTestCA(t)
+	defer ca.server.Close()
+
+	fakeProvider := &metricsfakes.Provider{}
+	checks := &metricsfakes.Counter{}
+	checks.WithReturns(checks)
+	cacheHits := &metricsfakes.Counter{}
+	fakeProvider.NewCounterStub = func(opts metrics.CounterOpts) metrics.Counter {
+		if opts.Name == "cache_hits" {
+			return cacheHits
+		}
+		return checks
+	}
+	fetchDuration := &metricsfakes.Histogram{}
+	fetchDuration.WithReturns(fetchDuration)
+	fakeProvider.NewHistogramReturns(fetchDuration)
+
+	rc := NewRevocationChecker(RevocationOpts{OCSP: true, CRLDistributionPoints: true}, fakeProvider)
+
+	good := ca.issue(t, 10, true, true)
+	validUntil, err := rc.Check(good, ca.cert)
+	require.NoError(t, err)
+	require.WithinDuration(t, ca.nextUpdate, validUntil, time.Second)
+	require.Equal(t, 1, checks.WithCallCount())
+	require.Equal(t, []string{"source", "ocsp", "result", "good"}, checks.WithArgsForCall(0))
+	require.Equal(t, []string{"source", "ocsp", "success", "true"}, fetchDuration.WithArgsForCall(0))
+
+	// the status is cached until the next update
+	_, err = rc.Check(good, ca.cert)
+	require.NoError(t, err)
+	ocspRequests, crlRequests := ca.requests()
+	require.Equal(t, 1, ocspRequests)
+	require.Equal(t, 0, crlRequests)
+	require.Equal(t, 1, cacheHits.AddCallCount())
+
+	// once expired, the status is fetched again
+	ca.revoke(good)
+	rc.now = func() time.Time { return ca.nextUpdate.Add(-time.Second) }
+	_, err = rc.Check(good, ca.cert)
+	require.NoError(t, err)
+	expired := ca.nextUpdate.Add(time.Second)
+	rc.now = func() time.Time { return expired }
+	ca.setNextUpdate(time.Now().Add(time.Hour))
+	_, err = rc.Check(good, ca.cert)
+	require.EqualError(t, err, "The certificate [10] has been revoked (ocsp)")
+	require.Equal(t, []string{"source", "ocsp", "result", "revoked"}, checks.WithArgsForCall(1))
+}
+
+func TestRevocationCheckerCRL(t *testing.T) {
+	ca := newRevocationTestCA(t)
+	defer ca.server.Close()
+
+	rc := NewRevocationChecker(RevocationOpts{CRLDistributionPoints: true}, &disabled.Provider{})
+
+	good := ca.issue(t, 10, true, true)
+	revoked := ca.issue(t, 11, true, true)
+	ca.revoke(revoked)
+
+	validUntil, err := rc.Check(good, ca.cert)
+	require.NoError(t, err)
+	require.WithinDuration(t, ca.nextUpdate, validUntil, time.Second)
+
+	// the CRL is fetched once for all the certificates it covers
+	_, err = rc.Check(revoked, ca.cert)
+	require.EqualError(t, err, "The certificate [11] has been revoked (crl)")
+	ocspRequests, crlRequests := ca.requests()
+	require.Equal(t, 0, ocspRequests)
+	require.Equal(t, 1, crlRequests)
+
+	// a CRL not signed by the issuer is rejected
+	otherCA := newRevocationTestCA(t)
+	defer otherCA.server.Close()
+	rc = NewRevocationChecker(RevocationOpts{CRLDistributionPoints: true, FailurePolicy: RevocationHardFail}, &disabled.Provider{})
+	_, err = rc.Check(good, otherCA.cert)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not signed by the certificate issuer")
+}
+
+func TestRevocationCheckerFallsBackToCRL(t *testing.T) {
+	ca := newRevocationTestCA(t)
+	defer ca.server.Close()
+	ca.ocspStatus = http.StatusServiceUnavailable
+
+	rc := NewRevocationChecker(RevocationOpts{OCSP: true, CRLDistributionPoints: true, FailurePolicy: RevocationHardFail}, &disabled.Provider{})
+	cert := ca.issue(t, 10, true, true)
+	ca.revoke(cert)
+
+	_, err := rc.Check(cert, ca.cert)
+	require.EqualError(t, err, "The certificate [10] has been revoked (crl)")
+	ocspRequests, crlRequests := ca.requests()
+	require.Equal(t, 1, ocspRequests)
+	require.Equal(t, 1, crlRequests)
+}
+
+func TestRevocationCheckerFailurePolicy(t *testing.T) {
+	ca := newRevocationTestCA(t)
+	defer ca.server.Close()
+	ca.ocspStatus = http.StatusServiceUnavailable
+
+	cert := ca.issue(t, 10, true, false)
+	now := time.Now()
+
+	rc := NewRevocationChecker(RevocationOpts{OCSP: true, FailureRetry: time.Minute}, &disabled.Provider{})
+	rc.now = func() time.Time { return now }
+	validUntil, err := rc.Check(cert, ca.cert)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(time.Minute), validUntil)
+
+	rc = NewRevocationChecker(RevocationOpts{OCSP: true, FailurePolicy: RevocationHardFail, FailureRetry: time.Minute}, &disabled.Provider{})
+	rc.now = func() time.Time { return now }
+	validUntil, err = rc.Check(cert, ca.cert)
+	require.EqualError(t, err, "could not determine the revocation status of certificate [10]: "+ca.server.URL+"/ocsp returned status 503 Service Unavailable")
+	require.Equal(t, now.Add(time.Minute), validUntil)
+
+	// failures are cached until they are retried
+	ca.mutex.Lock()
+	ca.ocspStatus = http.StatusOK
+	ca.mutex.Unlock()
+	_, err = rc.Check(cert, ca.cert)
+	require.Error(t, err)
+	ocspRequests, _ := ca.requests()
+	require.Equal(t, 2, ocspRequests)
+
+	now = now.Add(time.Minute)
+	_, err = rc.Check(cert, ca.cert)
+	require.NoError(t, err)
+	ocspRequests, _ = ca.requests()
+	require.Equal(t, 3, ocspRequests)
+}
+
+func TestRevocationCheckerCacheEviction(t *testing.T) {
+	ca := newRevocationTestCA(t)
+	defer ca.server.Close()
+
+	now := time.Now()
+	rc := NewRevocationChecker(RevocationOpts{OCSP: true, CRLDistributionPoints: true}, &disabled.Provider{})
+	rc.now = func() time.Time { return now }
+	rc.maxStatuses = 2
+	rc.maxCRLs = 1
+
+	for i := int64(10); i < 13; i++ {
+		_, err := rc.Check(ca.issue(t, i, true, false), ca.cert)
+		require.NoError(t, err)
+	}
+	require.Len(t, rc.statuses, 2)
+
+	// expired statuses are evicted first
+	ca.setNextUpdate(now.Add(time.Hour))
+	now = now.Add(20 * time.Minute)
+	_, err := rc.Check(ca.issue(t, 13, true, false), ca.cert)
+	require.NoError(t, err)
+	require.Len(t, rc.statuses, 1)
+
+	otherCA := newRevocationTestCA(t)
+	defer otherCA.server.Close()
+	otherCA.setNextUpdate(now.Add(time.Hour))
+	_, err = rc.Check(ca.issue(t, 14, false, true), ca.cert)
+	require.NoError(t, err)
+	_, err = rc.Check(otherCA.issue(t, 10, false, true), otherCA.cert)
+	require.NoError(t, err)
+	require.Len(t, rc.crls, 1)
+	require.Contains(t, rc.crls, otherCA.server.URL+"/crl")
+}
+
+func TestRevocationCheckerNoURLs(t *testing.T) {
+	ca := newRevocationTestCA(t)
+	defer ca.server.Close()
+
+	rc := NewRevocationChecker(RevocationOpts{OCSP: true, CRLDistributionPoints: true, FailurePolicy: RevocationHardFail}, &disabled.Provider{})
+
+	validUntil, err := rc.Check(ca.issue(t, 10, false, false), ca.cert)
+	require.NoError(t, err)
+	require.True(t, validUntil.IsZero())
+
+	// only the enabled sources are used
+	rc = NewRevocationChecker(RevocationOpts{OCSP: true, FailurePolicy: RevocationHardFail}, &disabled.Provider{})
+	validUntil, err = rc.Check(ca.issue(t, 11, false, true), ca.cert)
+	require.NoError(t, err)
+	require.True(t, validUntil.IsZero())
+	ocspRequests, crlRequests := ca.requests()
+	require.Equal(t, 0, ocspRequests)
+	require.Equal(t, 0, crlRequests)
+}
+
+func TestValidateIdentityOnlineRevocation(t *testing.T) {
+	ca := newRevocationTestCA(t)
+	defer ca.server.Close()
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	thisMSP, err := New(&BCCSPNewOpts{
+		NewBaseOpts:       NewBaseOpts{Version: MSPv1_0},
+		RevocationChecker: NewRevocationChecker(RevocationOpts{OCSP: true}, &disabled.Provider{}),
+	}, cryptoProvider)
+	require.NoError(t, err)
+	fabricConfig, err := proto.Marshal(&msp.FabricMSPConfig{
+		Name:      "RevocationOrg",
+		RootCerts: [][]byte{pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})},
+		CryptoConfig: &msp.FabricCryptoConfig{
+			SignatureHashFamily:            "SHA2",
+			IdentityIdentifierHashFunction: "SHA256",
+		},
+	})
+	require.NoError(t, err)
+	err = thisMSP.Setup(&msp.MSPConfig{Type: int32(FABRIC), Config: fabricConfig})
+	require.NoError(t, err)
+
+	cert := ca.issue(t, 10, true, false)
+	serializedID, err := proto.Marshal(&msp.SerializedIdentity{
+		Mspid:   "RevocationOrg",
+		IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+	})
+	require.NoError(t, err)
+	id, err := thisMSP.DeserializeIdentity(serializedID)
+	require.NoError(t, err)
+
+	validUntil, err := thisMSP.(ExpiringValidator).ValidateWithExpiry(id)
+	require.NoError(t, err)
+	require.WithinDuration(t, ca.nextUpdate, validUntil, time.Second)
+
+	// the identity is revoked once its revocation status expired
+	ca.revoke(cert)
+	require.NoError(t, id.Validate())
+	id.(*identity).validUntil = time.Now().Add(-time.Second)
+	expired := ca.nextUpdate.Add(time.Second)
+	thisMSP.(*bccspmsp).revocationChecker.now = func() time.Time { return expired }
+	ca.setNextUpdate(time.Now().Add(time.Hour))
+	err = id.Validate()
+	require.EqualError(t, err, "could not validate identity's revocation status: The certificate [10] has been revoked (ocsp)")
+	_, err = thisMSP.(ExpiringValidator).ValidateWithExpiry(id)
+	require.EqualError(t, err, "could not validate identity's revocation status: The certificate [10] has been revoked (ocsp)")
+}
+
+func TestCheckIdentity(t *testing.T) {
+	ca := newRevocationTestCA(t)
+	defer ca.server.Close()
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	require.NoError(t, err)
+
+	// channel MSPs are created without a checker
+	thisMSP, err := New(&BCCSPNewOpts{NewBaseOpts: NewBaseOpts{Version: MSPv1_0}}, cryptoProvider)
+	require.NoError(t, err)
+	fabricConfig, err := proto.Marshal(&msp.FabricMSPConfig{
+		Name:      "RevocationOrg",
+		RootCerts: [][]byte{pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})},
+		CryptoConfig: &msp.FabricCryptoConfig{
+			SignatureHashFamily:            "SHA2",
+			IdentityIdentifierHashFunction: "SHA256",
+		},
+	})
+	require.NoError(t, err)
+	err = thisMSP.Setup(&msp.MSPConfig{Type: int32(FABRIC), Config: fabricConfig})
+	require.NoError(t, err)
+
+	cert := ca.issue(t, 10, true, false)
+	ca.revoke(cert)
+	serializedID, err := proto.Marshal(&msp.SerializedIdentity{
+		Mspid:   "RevocationOrg",
+		IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+	})
+	require.NoError(t, err)
+	id, err := thisMSP.DeserializeIdentity(serializedID)
+	require.NoError(t, err)
+
+	require.NoError(t, id.Validate())
+	ocspRequests, _ := ca.requests()
+	require.Equal(t, 0, ocspRequests)
+
+	rc := NewRevocationChecker(RevocationOpts{OCSP: true}, &disabled.Provider{})
+	err = rc.CheckIdentity(id)
+	require.EqualError(t, err, "The certificate [10] has been revoked (ocsp)")
+	require.NoError(t, rc.CheckIdentity(&idemixidentity{}))
+}
@@ -18,6 +18,16 @@ type ChannelCapabilities struct {
 	consensusTypeMigrationReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	ExpressionPoliciesStub        func() bool
+	expressionPoliciesMutex       sync.RWMutex
+	expressionPoliciesArgsForCall []struct {
+	}
+	expressionPoliciesReturns struct {
+		result1 bool
+	}
+	expressionPoliciesReturnsOnCall map[int]struct {
+		result1 bool
+	}
 	MSPVersionStub        func() msp.MSPVersion
 	mSPVersionMutex       sync.RWMutex
 	mSPVersionArgsForCall []struct {
@@ -104,6 +114,58 @@ func (fake *ChannelCapabilities) ConsensusTypeMigrationReturnsOnCall(i int, resu
 	}{result1}
 }
 
+func (fake *ChannelCapabilities) ExpressionPolicies() bool {
+	fake.expressionPoliciesMutex.Lock()
+	ret, specificReturn := fake.expressionPoliciesReturnsOnCall[len(fake.expressionPoliciesArgsForCall)]
+	fake.expressionPoliciesArgsForCall = append(fake.expressionPoliciesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ExpressionPolicies", []interface{}{})
+	fake.expressionPoliciesMutex.Unlock()
+	if fake.ExpressionPoliciesStub != nil {
+		return fake.ExpressionPoliciesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.expressionPoliciesReturns
+	return fakeReturns.result1
+}
+
+func (fake *ChannelCapabilities) ExpressionPoliciesCallCount() int {
+	fake.expressionPoliciesMutex.RLock()
+	defer fake.expressionPoliciesMutex.RUnlock()
+	return len(fake.expressionPoliciesArgsForCall)
+}
+
+func (fake *ChannelCapabilities) ExpressionPoliciesCalls(stub func() bool) {
+	fake.expressionPoliciesMutex.Lock()
+	defer fake.expressionPoliciesMutex.Unlock()
+	fake.ExpressionPoliciesStub = stub
+}
+
+func (fake *ChannelCapabilities) ExpressionPoliciesReturns(result1 bool) {
+	fake.expressionPoliciesMutex.Lock()
+	defer fake.expressionPoliciesMutex.Unlock()
+	fake.ExpressionPoliciesStub = nil
+	fake.expressionPoliciesReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *ChannelCapabilities) ExpressionPoliciesReturnsOnCall(i int, result1 bool) {
+	fake.expressionPoliciesMutex.Lock()
+	defer fake.expressionPoliciesMutex.Unlock()
+	fake.ExpressionPoliciesStub = nil
+	if fake.expressionPoliciesReturnsOnCall == nil {
+		fake.expressionPoliciesReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.expressionPoliciesReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *ChannelCapabilities) MSPVersion() msp.MSPVersion {
 	fake.mSPVersionMutex.Lock()
 	ret, specificReturn := fake.mSPVersionReturnsOnCall[len(fake.mSPVersionArgsForCall)]
@@ -265,6 +327,8 @@ func (fake *ChannelCapabilities) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.consensusTypeMigrationMutex.RLock()
 	defer fake.consensusTypeMigrationMutex.RUnlock()
+	fake.expressionPoliciesMutex.RLock()
+	defer fake.expressionPoliciesMutex.RUnlock()
 	fake.mSPVersionMutex.RLock()
 	defer fake.mSPVersionMutex.RUnlock()
 	fake.orgSpecificOrdererEndpointsMutex.RLock()
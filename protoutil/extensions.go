@@ -0,0 +1,147 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoutil
+
+import (
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// Fabric extends some of the messages defined in fabric-protos-go with data
+// of its own. An extension is a single field of the extended message, which
+// carries an embedded message and whose number is in the range reserved for
+// extensions. It is kept among the unknown fields of the extended message,
+// so that the nodes which don't know about the extension ignore it.
+
+const (
+	// ExtensionFieldMin is the lowest field number reserved for extensions.
+	// Messages of fabric-protos-go must not define fields in the range.
+	ExtensionFieldMin int32 = 10000
+	// ExtensionFieldMax is the highest field number reserved for extensions.
+	ExtensionFieldMax int32 = 10099
+)
+
+// The extensions, by field number. Extensions of different messages may
+// share a field number, but they are numbered uniquely for clarity.
+const (
+	// WrittenKeysExtension extends a discovery ChaincodeCall with the keys
+	// written by the chaincode call.
+	WrittenKeysExtension int32 = 10000
+	// PeerLabelsExtension extends the gossip Properties of a peer with its
+	// labels.
+	PeerLabelsExtension int32 = 10001
+	// PeerCapabilitiesExtension extends the gossip Properties of a peer with
+	// its version and the capabilities it supports.
+	PeerCapabilitiesExtension int32 = 10002
+)
+
+// GetExtension unmarshals the extension of msg with the given field number
+// into ext. It returns false if msg does not carry the extension.
+func GetExtension(msg proto.Message, field int32, ext proto.Message) (bool, error) {
+	unknown, err := unknownFields(msg)
+	if err != nil {
+		return false, err
+	}
+	_, value, found, err := splitUnknownFields(*unknown, field)
+	if err != nil || !found {
+		return false, err
+	}
+	if err := proto.Unmarshal(value, ext); err != nil {
+		return false, errors.Wrapf(err, "error unmarshaling extension %d of %T", field, msg)
+	}
+	return true, nil
+}
+
+// SetExtension sets the extension of msg with the given field number to
+// ext, replacing any previous value while preserving the other unknown fields
+// of msg. A nil ext removes the extension.
+func SetExtension(msg proto.Message, field int32, ext proto.Message) error {
+	if field < ExtensionFieldMin || field > ExtensionFieldMax {
+		return errors.Errorf("field number %d is not reserved for extensions", field)
+	}
+	unknown, err := unknownFields(msg)
+	if err != nil {
+		return err
+	}
+	rest, _, _, err := splitUnknownFields(*unknown, field)
+	if err != nil {
+		return err
+	}
+	if ext != nil && !reflect.ValueOf(ext).IsNil() {
+		value, err := proto.Marshal(ext)
+		if err != nil {
+			return errors.Wrapf(err, "error marshaling extension %d of %T", field, msg)
+		}
+		rest = append(rest, proto.EncodeVarint(uint64(field)<<3|proto.WireBytes)...)
+		rest = append(rest, proto.EncodeVarint(uint64(len(value)))...)
+		rest = append(rest, value...)
+	}
+	*unknown = rest
+	return nil
+}
+
+// unknownFields returns the unknown fields retained by msg.
+func unknownFields(msg proto.Message) (*[]byte, error) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil, errors.Errorf("cannot access the unknown fields of %T", msg)
+	}
+	f := v.Elem().FieldByName("XXX_unrecognized")
+	if !f.IsValid() || f.Type() != reflect.TypeOf([]byte(nil)) {
+		return nil, errors.Errorf("%T does not retain unknown fields", msg)
+	}
+	return f.Addr().Interface().(*[]byte), nil
+}
+
+// splitUnknownFields returns the encoded fields other than the given field
+// number, and the value of the last occurrence of the field.
+func splitUnknownFields(b []byte, field int32) (rest, value []byte, found bool, err error) {
+	for len(b) > 0 {
+		key, n := proto.DecodeVarint(b)
+		if n == 0 {
+			return nil, nil, false, errors.New("malformed unknown fields")
+		}
+		number, wireType := key>>3, key&7
+		size := n
+		switch wireType {
+		case proto.WireVarint:
+			_, n = proto.DecodeVarint(b[size:])
+			if n == 0 {
+				return nil, nil, false, errors.New("malformed unknown fields")
+			}
+			size += n
+		case proto.WireFixed64:
+			size += 8
+		case proto.WireFixed32:
+			size += 4
+		case proto.WireBytes:
+			length, n := proto.DecodeVarint(b[size:])
+			if n == 0 || length > uint64(len(b)-size-n) {
+				return nil, nil, false, errors.New("malformed unknown fields")
+			}
+			size += n + int(length)
+			if number == uint64(field) {
+				value, found = b[size-int(length):size], true
+			}
+		default:
+			return nil, nil, false, errors.Errorf("unsupported wire type %d in unknown fields", wireType)
+		}
+		if size > len(b) {
+			return nil, nil, false, errors.New("malformed unknown fields")
+		}
+		if number == uint64(field) && wireType != proto.WireBytes {
+			return nil, nil, false, errors.Errorf("unexpected wire type %d for extension %d", wireType, field)
+		}
+		if number != uint64(field) {
+			rest = append(rest, b[:size]...)
+		}
+		b = b[size:]
+	}
+	return rest, value, found, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package protoutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/discovery"
+	"github.com/hyperledger/fabric-protos-go/gossip"
+	"github.com/stretchr/testify/require"
+)
+
+// extendedMessages are the messages of fabric-protos-go which are extended.
+var extendedMessages = []proto.Message{
+	&discovery.ChaincodeCall{},
+	&gossip.Properties{},
+}
+
+func TestExtensionFieldsNotDefinedUpstream(t *testing.T) {
+	for _, msg := range extendedMessages {
+		props := proto.GetProperties(reflect.TypeOf(msg).Elem())
+		for _, prop := range props.Prop {
+			require.Falsef(t, int32(prop.Tag) >= ExtensionFieldMin && int32(prop.Tag) <= ExtensionFieldMax,
+				"%T defines field %s with number %d, reserved for extensions", msg, prop.OrigName, prop.Tag)
+		}
+		for _, oneof := range props.OneofTypes {
+			require.Falsef(t, int32(oneof.Prop.Tag) >= ExtensionFieldMin && int32(oneof.Prop.Tag) <= ExtensionFieldMax,
+				"%T defines field %s with number %d, reserved for extensions", msg, oneof.Prop.OrigName, oneof.Prop.Tag)
+		}
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	cc := &discovery.ChaincodeCall{Name: "mycc"}
+
+	found, err := GetExtension(cc, ExtensionFieldMin, &discovery.ChaincodeCall{})
+	require.NoError(t, err)
+	require.False(t, found)
+
+	err = SetExtension(cc, ExtensionFieldMin, &discovery.ChaincodeCall{Name: "first"})
+	require.NoError(t, err)
+	err = SetExtension(cc, ExtensionFieldMin+1, &discovery.ChaincodeCall{Name: "other"})
+	require.NoError(t, err)
+	err = SetExtension(cc, ExtensionFieldMin, &discovery.ChaincodeCall{Name: "second"})
+	require.NoError(t, err)
+
+	// the extensions survive a round trip through the wire
+	received := &discovery.ChaincodeCall{}
+	require.NoError(t, proto.Unmarshal(MarshalOrPanic(cc), received))
+	require.Equal(t, "mycc", received.Name)
+
+	ext := &discovery.ChaincodeCall{}
+	found, err = GetExtension(received, ExtensionFieldMin, ext)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "second", ext.Name)
+	found, err = GetExtension(received, ExtensionFieldMin+1, ext)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "other", ext.Name)
+
+	// removing an extension preserves the other unknown fields
+	require.NoError(t, SetExtension(received, ExtensionFieldMin, nil))
+	found, err = GetExtension(received, ExtensionFieldMin, ext)
+	require.NoError(t, err)
+	require.False(t, found)
+	found, err = GetExtension(received, ExtensionFieldMin+1, ext)
+	require.NoError(t, err)
+	require.True(t, found)
+}
+
+func TestExtensionsErrors(t *testing.T) {
+	err := SetExtension(&discovery.ChaincodeCall{}, 5, &discovery.ChaincodeCall{})
+	require.EqualError(t, err, "field number 5 is not reserved for extensions")
+
+	_, err = GetExtension((*discovery.ChaincodeCall)(nil), ExtensionFieldMin, &discovery.ChaincodeCall{})
+	require.EqualError(t, err, "cannot access the unknown fields of *discovery.ChaincodeCall")
+
+	cc := &discovery.ChaincodeCall{XXX_unrecognized: []byte{0x80}}
+	_, err = GetExtension(cc, ExtensionFieldMin, &discovery.ChaincodeCall{})
+	require.EqualError(t, err, "malformed unknown fields")
+
+	// the extension field is a varint instead of an embedded message
+	cc.XXX_unrecognized = append(proto.EncodeVarint(uint64(ExtensionFieldMin)<<3|proto.WireVarint), 1)
+	_, err = GetExtension(cc, ExtensionFieldMin, &discovery.ChaincodeCall{})
+	require.EqualError(t, err, "unexpected wire type 0 for extension 10000")
+
+	cc.XXX_unrecognized = append(proto.EncodeVarint(uint64(ExtensionFieldMin)<<3|proto.WireBytes), 1, 0xff)
+	_, err = GetExtension(cc, ExtensionFieldMin, &discovery.ChaincodeCall{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "error unmarshaling extension 10000 of *discovery.ChaincodeCall")
+}
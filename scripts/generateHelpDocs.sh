@@ -44,7 +44,7 @@ generateHelpText \
         docs/wrappers/license_postscript.md \
         "${commands[@]}"
 
-commands=("peer chaincode install" "peer chaincode instantiate" "peer chaincode invoke" "peer chaincode list" "peer chaincode package" "peer chaincode query" "peer chaincode signpackage" "peer chaincode upgrade")
+commands=("peer chaincode install" "peer chaincode instantiate" "peer chaincode invoke" "peer chaincode list" "peer chaincode package" "peer chaincode query" "peer chaincode signpackage" "peer chaincode upgrade" "peer chaincode events")
 generateHelpText \
         docs/source/commands/peerchaincode.md \
         docs/wrappers/peer_chaincode_preamble.md \
@@ -65,7 +65,7 @@ generateHelpText \
         docs/wrappers/peer_channel_postscript.md \
         "${commands[@]}"
 
-commands=("peer node pause" "peer node rebuild-dbs" "peer node reset" "peer node resume" "peer node rollback" "peer node start" "peer node upgrade-dbs")
+commands=("peer node gossip" "peer node gossip reelect" "peer node pause" "peer node pvtdata" "peer node pvtdata list" "peer node pvtdata reconcile" "peer node rebuild-dbs" "peer node reset" "peer node resume" "peer node rollback" "peer node start" "peer node upgrade-dbs")
 generateHelpText \
         docs/source/commands/peernode.md \
         docs/wrappers/peer_node_preamble.md \
@@ -86,14 +86,14 @@ generateHelpText \
         docs/wrappers/configtxgen_postscript.md \
         "${commands[@]}"
 
-commands=("cryptogen help" "cryptogen generate" "cryptogen showtemplate" "cryptogen extend" "cryptogen version")
+commands=("cryptogen help" "cryptogen generate" "cryptogen showtemplate" "cryptogen extend" "cryptogen renew" "cryptogen version")
 generateHelpText \
         docs/source/commands/cryptogen.md \
         docs/wrappers/cryptogen_preamble.md \
         docs/wrappers/cryptogen_postscript.md \
         "${commands[@]}"
 
-commands=("configtxlator start" "configtxlator proto_encode" "configtxlator proto_decode" "configtxlator compute_update" "configtxlator version")
+commands=("configtxlator start" "configtxlator proto_encode" "configtxlator proto_decode" "configtxlator compute_update" "configtxlator edit" "configtxlator check_signatures" "configtxlator diff" "configtxlator version")
 generateHelpText \
         docs/source/commands/configtxlator.md \
         docs/wrappers/configtxlator_preamble.md \